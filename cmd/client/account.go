@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// deleteAccount запрос удаления аккаунта с возможностью сначала выгрузить все секреты в файл.
+func deleteAccount() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Export all secrets to a file before deletion? (y/n): ")
+	exportChoice, _ := reader.ReadString('\n')
+	if strings.EqualFold(strings.TrimSpace(exportChoice), "y") {
+		path, err := exportSecrets()
+		if err != nil {
+			fmt.Printf("Export failed, account is not deleted: %v\n", err)
+			return
+		}
+		fmt.Printf("Secrets exported to %s\n", path)
+	}
+
+	fmt.Print("Enter password to confirm account deletion: ")
+	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		fmt.Printf("Failed to read password: %v\n", err)
+		return
+	}
+
+	req := &pb.DeleteAccountRequest{Password: strings.TrimSpace(string(bytePassword))}
+
+	// Для E2E аккаунта пароль подтверждается ключом аутентификации
+	if vaultKey != nil {
+		kdfRes, err := userClient.GetKDFParams(context.Background(), &pb.GetKDFParamsRequest{Login: currentLogin})
+		if err != nil {
+			fmt.Printf("Account deletion failed: %v\n", err)
+			return
+		}
+		req.Password, _, err = deriveE2ELogin(req.GetPassword(), kdfRes.GetKdfParams())
+		if err != nil {
+			fmt.Printf("Account deletion failed: %v\n", err)
+			return
+		}
+	}
+
+	res, err := userClient.DeleteAccount(withToken(context.Background()), req)
+	if err != nil {
+		fmt.Printf("Account deletion failed: %v\n", err)
+		return
+	}
+
+	clearSession()
+	fmt.Printf("Account will be deleted on %s. Log in before that to cancel deletion.\n",
+		res.GetDeleteAfter().AsTime().Local().Format(time.DateTime))
+}
+
+// exportSecrets выгрузка всех секретов в JSON файл в текущей папке. Секреты E2E аккаунта
+// расшифровываются на клиенте, файл доступен только владельцу.
+func exportSecrets() (string, error) {
+	res, err := secretClient.ExportSecrets(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		return "", fmt.Errorf("failed to export secrets: %w", err)
+	}
+
+	for _, secret := range res.GetSecrets() {
+		if secret.GetClientEncrypted() && vaultKey != nil {
+			openSecretData(secret)
+			secret.ClientEncrypted = false
+		}
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(res)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	path := fmt.Sprintf("goph-keeper-export-%s-%s.json", currentLogin, time.Now().Format("2006_01_02_15_04_05"))
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return path, nil
+}
+
+// offerCancelDeletion предложение отменить запрошенное удаление аккаунта после входа.
+func offerCancelDeletion(deleteAfter time.Time) {
+	fmt.Printf("\nYour account is scheduled for deletion on %s.\n", deleteAfter.Local().Format(time.DateTime))
+	fmt.Print("Cancel account deletion? (y/n): ")
+
+	choice, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(choice), "y") {
+		return
+	}
+
+	if _, err := userClient.CancelAccountDeletion(withToken(context.Background()), &emptypb.Empty{}); err != nil {
+		fmt.Printf("Failed to cancel account deletion: %v\n", err)
+		return
+	}
+
+	fmt.Println("Account deletion canceled")
+}
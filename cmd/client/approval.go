@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func manageAccessApprovals() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\n1. Require approval for a secret")
+	fmt.Println("2. Show approval policy")
+	fmt.Println("3. Remove approval requirement")
+	fmt.Println("4. Request access to a secret")
+	fmt.Println("5. Access requests")
+	fmt.Println("6. Approve request")
+	fmt.Println("7. Deny request")
+	fmt.Println("8. Secret access log")
+	fmt.Print("Select an option: ")
+	option, _ := reader.ReadString('\n')
+
+	switch strings.TrimSpace(option) {
+	case "1":
+		setApprovalPolicy(reader)
+	case "2":
+		showApprovalPolicy(reader)
+	case "3":
+		removeApprovalPolicy(reader)
+	case "4":
+		requestSecretAccess(reader)
+	case "5":
+		listAccessRequests()
+	case "6":
+		decideSecretAccess(reader, true)
+	case "7":
+		decideSecretAccess(reader, false)
+	case "8":
+		listAccessEvents(reader)
+	default:
+		fmt.Println("Invalid option")
+	}
+}
+
+func setApprovalPolicy(reader *bufio.Reader) {
+	id, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("Approver logins (comma separated): ")
+	input, _ := reader.ReadString('\n')
+	var logins []string
+	for _, login := range strings.Split(input, ",") {
+		if login = strings.TrimSpace(login); login != "" {
+			logins = append(logins, login)
+		}
+	}
+
+	fmt.Print("Access window in minutes after approval (leave empty for default): ")
+	grantInput, _ := reader.ReadString('\n')
+	grant, err := parseOptionalUint(grantInput)
+	if err != nil {
+		fmt.Println("Invalid access window")
+		return
+	}
+
+	res, err := secretClient.SetApprovalPolicy(withToken(context.Background()), &pb.SetApprovalPolicyRequest{
+		SecretId:       id,
+		ApproverLogins: logins,
+		GrantMinutes:   grant,
+	})
+	if err != nil {
+		fmt.Printf("Failed to set approval policy: %v\n", err)
+		return
+	}
+
+	printApprovalPolicy(res)
+}
+
+func showApprovalPolicy(reader *bufio.Reader) {
+	id, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := secretClient.GetApprovalPolicy(withToken(context.Background()), &pb.ApprovalPolicyRequest{SecretId: id})
+	if err != nil {
+		fmt.Printf("Failed to get approval policy: %v\n", err)
+		return
+	}
+
+	printApprovalPolicy(res)
+}
+
+func printApprovalPolicy(p *pb.ApprovalPolicy) {
+	fmt.Printf("Secret %d requires approval by one of: %s, access window %d minutes\n",
+		p.GetSecretId(), strings.Join(p.GetApproverLogins(), ", "), p.GetGrantMinutes())
+}
+
+func removeApprovalPolicy(reader *bufio.Reader) {
+	id, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	_, err := secretClient.RemoveApprovalPolicy(withToken(context.Background()), &pb.ApprovalPolicyRequest{SecretId: id})
+	if err != nil {
+		fmt.Printf("Failed to remove approval policy: %v\n", err)
+		return
+	}
+
+	fmt.Println("Secret no longer requires approval")
+}
+
+func requestSecretAccess(reader *bufio.Reader) {
+	id, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("Reason: ")
+	reason, _ := reader.ReadString('\n')
+
+	res, err := secretClient.RequestAccess(withToken(context.Background()), &pb.RequestSecretAccessRequest{
+		SecretId: id,
+		Reason:   strings.TrimSpace(reason),
+	})
+	if err != nil {
+		fmt.Printf("Failed to request access: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Access requested (request ID %d), waiting for approval until %s\n",
+		res.GetId(), res.GetExpiresAt().AsTime().Local().Format(time.DateTime))
+}
+
+func listAccessRequests() {
+	res, err := secretClient.ListAccessRequests(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get access requests: %v\n", err)
+		return
+	}
+
+	if len(res.GetRequests()) == 0 {
+		fmt.Println("No access requests")
+		return
+	}
+
+	for _, r := range res.GetRequests() {
+		fmt.Printf("%d. secret %d, requested by %s: %s\n",
+			r.GetId(), r.GetSecretId(), r.GetRequesterLogin(), accessRequestState(r))
+		if r.GetReason() != "" {
+			fmt.Printf("   Reason: %s\n", r.GetReason())
+		}
+	}
+}
+
+// decideSecretAccess одобрение запроса доступа к секрету или отказ в нем утверждающим.
+func decideSecretAccess(reader *bufio.Reader, approve bool) {
+	id, ok := readID(reader, "Request ID: ")
+	if !ok {
+		return
+	}
+
+	ctx := withToken(context.Background())
+	req := &pb.DecideAccessRequest{RequestId: id}
+
+	var (
+		res *pb.SecretAccessRequest
+		err error
+	)
+	if approve {
+		res, err = secretClient.ApproveAccess(ctx, req)
+	} else {
+		res, err = secretClient.DenyAccess(ctx, req)
+	}
+	if err != nil {
+		fmt.Printf("Failed to update request: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s: %s\n", res.GetRequesterLogin(), accessRequestState(res))
+}
+
+func listAccessEvents(reader *bufio.Reader) {
+	id, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := secretClient.ListAccessEvents(withToken(context.Background()), &pb.ApprovalPolicyRequest{SecretId: id})
+	if err != nil {
+		fmt.Printf("Failed to get access log: %v\n", err)
+		return
+	}
+
+	if len(res.GetEvents()) == 0 {
+		fmt.Println("Access log is empty")
+		return
+	}
+
+	for _, ev := range res.GetEvents() {
+		actor := ev.GetActorLogin()
+		if actor == "" {
+			actor = "deleted user"
+		}
+		line := fmt.Sprintf("%s  %s by %s", ev.GetCreatedAt().AsTime().Local().Format(time.DateTime),
+			ev.GetAction(), actor)
+		if ev.GetRequestId() != 0 {
+			line += fmt.Sprintf(" (request %d)", ev.GetRequestId())
+		}
+		fmt.Println(line)
+	}
+}
+
+// accessRequestState описание состояния запроса доступа к секрету.
+func accessRequestState(r *pb.SecretAccessRequest) string {
+	switch r.GetStatus() {
+	case pb.AccessRequestStatus_ACCESS_REQUEST_PENDING:
+		return "pending until " + r.GetExpiresAt().AsTime().Local().Format(time.DateTime)
+	case pb.AccessRequestStatus_ACCESS_REQUEST_APPROVED:
+		until := r.GetGrantedUntil().AsTime()
+		if time.Now().After(until) {
+			return "approved by " + r.GetDecidedByLogin() + ", access window closed"
+		}
+		return "approved by " + r.GetDecidedByLogin() + ", access until " + until.Local().Format(time.DateTime)
+	case pb.AccessRequestStatus_ACCESS_REQUEST_DENIED:
+		return "denied by " + r.GetDecidedByLogin()
+	case pb.AccessRequestStatus_ACCESS_REQUEST_EXPIRED:
+		return "expired without an answer"
+	default:
+		return r.GetStatus().String()
+	}
+}
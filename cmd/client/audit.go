@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+)
+
+// auditPageSize размер страницы журнала аудита в клиенте.
+const auditPageSize = 20
+
+func showAuditLog() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Action (create, read, update, delete, share, login, login_failed; leave empty for all): ")
+	action, _ := reader.ReadString('\n')
+
+	fmt.Print("User login, * for all users (administrators only; leave empty for your own events): ")
+	login, _ := reader.ReadString('\n')
+
+	req := &pb.QueryAuditLogRequest{
+		Action:   strings.TrimSpace(action),
+		PageSize: auditPageSize,
+	}
+	switch login = strings.TrimSpace(login); login {
+	case "":
+	case "*":
+		req.AllUsers = true
+	default:
+		req.ActorLogin = login
+	}
+
+	for {
+		res, err := auditClient.QueryAuditLog(withToken(context.Background()), req)
+		if err != nil {
+			fmt.Printf("Failed to get audit log: %v\n", err)
+			return
+		}
+
+		if len(res.GetEvents()) == 0 && req.GetPageToken() == 0 {
+			fmt.Println("Audit log is empty")
+			return
+		}
+
+		for _, ev := range res.GetEvents() {
+			line := fmt.Sprintf("%s  %s %s by %s", ev.GetCreatedAt().AsTime().Local().Format(time.DateTime),
+				ev.GetAction(), ev.GetResult(), ev.GetActorLogin())
+			if ev.GetLogin() != ev.GetActorLogin() {
+				line += fmt.Sprintf(" in account %s", ev.GetLogin())
+			}
+			if ev.GetSecretId() != 0 {
+				line += fmt.Sprintf(", secret %d", ev.GetSecretId())
+			}
+			fmt.Printf("%s, from %s (%s)\n", line, ev.GetClientIp(), ev.GetUserAgent())
+		}
+
+		if res.GetNextPageToken() == 0 {
+			return
+		}
+
+		fmt.Print("Show more? (y/n): ")
+		answer, _ := reader.ReadString('\n')
+		if strings.TrimSpace(answer) != "y" {
+			return
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // формат базы HIBP
+	"fmt"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// checkBreaches проверка всех сохраненных паролей по базе утечек на сервере.
+func checkBreaches() {
+	// Пароли E2E аккаунта проверяются на клиенте по диапазонам хешей
+	if vaultKey != nil {
+		checkBreachesLocally()
+		return
+	}
+
+	res, err := secretClient.CheckBreaches(withToken(context.Background()), &pb.CheckBreachesRequest{CheckStored: true})
+	if err != nil {
+		fmt.Printf("Failed to check breaches: %v\n", err)
+		return
+	}
+
+	printBreached(res.GetChecked(), res.GetSecrets())
+}
+
+func checkBreachesLocally() {
+	res, err := secretClient.ExportSecrets(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to check breaches: %v\n", err)
+		return
+	}
+
+	var passwords []*pb.PasswordData
+	names := make(map[*pb.PasswordData]string)
+	for _, secret := range res.GetSecrets() {
+		if secret.GetClientEncrypted() {
+			openSecretData(secret)
+		}
+		if data := secret.GetPasswordData(); data != nil {
+			passwords = append(passwords, data)
+			names[data] = secret.GetName()
+		}
+	}
+
+	plain := make([]string, 0, len(passwords))
+	for _, data := range passwords {
+		plain = append(plain, data.GetPassword())
+	}
+
+	counts, err := lookupBreaches(plain)
+	if err != nil {
+		fmt.Printf("Failed to check breaches: %v\n", err)
+		return
+	}
+
+	var breached []*pb.BreachedSecret
+	for i, data := range passwords {
+		if counts[i] > 0 {
+			breached = append(breached, &pb.BreachedSecret{
+				Name:        names[data],
+				Username:    data.GetUsername(),
+				Url:         data.GetUrl(),
+				BreachCount: counts[i],
+			})
+		}
+	}
+
+	printBreached(uint32(len(passwords)), breached) //nolint:gosec // число секретов мало
+}
+
+// lookupBreaches число появлений каждого пароля в утечках. Сервер получает только префиксы хешей
+// и возвращает все хеши диапазонов, совпадения ищутся здесь.
+func lookupBreaches(passwords []string) ([]uint32, error) {
+	hashes := make([]string, len(passwords))
+	var prefixes []string
+	seen := make(map[string]bool)
+	for i, p := range passwords {
+		hashes[i] = fmt.Sprintf("%X", sha1.Sum([]byte(p))) //nolint:gosec // формат базы HIBP
+		if prefix := hashes[i][:breach.PrefixLen]; !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return make([]uint32, len(passwords)), nil
+	}
+
+	res, err := secretClient.CheckBreaches(withToken(context.Background()), &pb.CheckBreachesRequest{Prefixes: prefixes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get breach ranges: %w", err)
+	}
+
+	found := make(map[string]uint32)
+	for _, r := range res.GetRanges() {
+		for _, m := range r.GetMatches() {
+			found[strings.ToUpper(r.GetPrefix())+m.GetSuffix()] = m.GetCount()
+		}
+	}
+
+	counts := make([]uint32, len(passwords))
+	for i, h := range hashes {
+		counts[i] = found[h]
+	}
+
+	return counts, nil
+}
+
+// markBreachedLocally проверка по утечкам пароля, который сервер получит только зашифрованным.
+// Если база утечек на сервере не подключена, оценка остается без изменений.
+func markBreachedLocally(strength *pb.PasswordStrength, password string) {
+	counts, err := lookupBreaches([]string{password})
+	if err != nil {
+		if status.Code(err) != codes.FailedPrecondition {
+			fmt.Printf("Failed to check password against breaches: %v\n", err)
+		}
+		return
+	}
+
+	if counts[0] > 0 {
+		strength.BreachCount = counts[0]
+		strength.Score = 0
+		strength.Warnings = append(strength.GetWarnings(), "this password appeared in a data breach")
+	}
+}
+
+func printBreached(checked uint32, secrets []*pb.BreachedSecret) {
+	fmt.Printf("Checked %d passwords\n", checked)
+	if len(secrets) == 0 {
+		fmt.Println("No breached passwords found")
+		return
+	}
+
+	fmt.Println("Breached passwords, change them as soon as possible:")
+	for _, s := range secrets {
+		fmt.Printf("   %s (%s %s): seen %d times\n", s.GetName(), s.GetUsername(), s.GetUrl(), s.GetBreachCount())
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+)
+
+// vaultKey ключ хранилища пользователя в режиме E2E, живет только в памяти клиента.
+var vaultKey []byte
+
+// prepareE2ERegistration генерация параметров KDF и ключа хранилища для нового E2E аккаунта.
+// Возвращает запрос регистрации, в котором вместо пароля передается ключ аутентификации.
+func prepareE2ERegistration(login, password string) (*pb.RegisterUserRequest, error) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate kdf params: %w", err)
+	}
+
+	authKey, kek, err := encryptor.DeriveClientKeys(password, kdf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keys: %w", err)
+	}
+
+	key, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate vault key: %w", err)
+	}
+
+	wrapped, err := encryptor.WrapKey(key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap vault key: %w", err)
+	}
+
+	return &pb.RegisterUserRequest{
+		Login:          login,
+		Password:       hex.EncodeToString(authKey),
+		EncryptionMode: pb.EncryptionMode_ENCRYPTION_MODE_E2E,
+		KdfParams: &pb.KDFParams{
+			Salt:    kdf.Salt,
+			Time:    kdf.Time,
+			Memory:  kdf.Memory,
+			Threads: uint32(kdf.Threads),
+		},
+		WrappedVaultKey: wrapped,
+	}, nil
+}
+
+// deriveE2ELogin получение ключа аутентификации и KEK из мастер-пароля по параметрам с сервера.
+func deriveE2ELogin(password string, params *pb.KDFParams) (string, []byte, error) {
+	kdf := &encryptor.KDFParams{
+		Salt:    params.GetSalt(),
+		Time:    params.GetTime(),
+		Memory:  params.GetMemory(),
+		Threads: uint8(min(params.GetThreads(), 255)),
+	}
+
+	authKey, kek, err := encryptor.DeriveClientKeys(password, kdf)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to derive keys: %w", err)
+	}
+
+	return hex.EncodeToString(authKey), kek, nil
+}
+
+// prepareE2ECredentials подготовка запроса смены учетных данных E2E аккаунта: пароли заменяются
+// ключами аутентификации, а при смене мастер-пароля ключ хранилища оборачивается KEK из нового пароля
+// с новыми параметрами KDF. Сам ключ хранилища не меняется, поэтому секреты перешифровывать не нужно.
+func prepareE2ECredentials(req *pb.UpdateCredentialsRequest, oldParams *pb.KDFParams) error {
+	oldAuthKey, _, err := deriveE2ELogin(req.GetOldPassword(), oldParams)
+	if err != nil {
+		return err
+	}
+	req.OldPassword = oldAuthKey
+
+	if req.GetNewPassword() == "" {
+		return nil
+	}
+
+	req.NewPassword, req.NewKdfParams, req.NewWrappedVaultKey, err = rewrapVaultKey(req.GetNewPassword(), vaultKey)
+
+	return err
+}
+
+// rewrapVaultKey ключ аутентификации нового мастер-пароля, новые параметры KDF и ключ хранилища key,
+// обернутый KEK из нового пароля.
+func rewrapVaultKey(password string, key []byte) (string, *pb.KDFParams, []byte, error) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to generate kdf params: %w", err)
+	}
+
+	authKey, kek, err := encryptor.DeriveClientKeys(password, kdf)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to derive keys: %w", err)
+	}
+
+	wrapped, err := encryptor.WrapKey(key, kek)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to wrap vault key: %w", err)
+	}
+
+	params := &pb.KDFParams{
+		Salt:    kdf.Salt,
+		Time:    kdf.Time,
+		Memory:  kdf.Memory,
+		Threads: uint32(kdf.Threads),
+	}
+
+	return hex.EncodeToString(authKey), params, wrapped, nil
+}
+
+// sealField шифрование поля ключом хранилища, пустые поля остаются пустыми.
+func sealField(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	enc, err := encryptor.EncryptWithMasterKey([]byte(value), vaultKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt field: %w", err)
+	}
+
+	return enc, nil
+}
+
+// openField расшифровка поля ключом хранилища key.
+func openField(value string, key []byte) string {
+	if value == "" {
+		return ""
+	}
+
+	dec, err := encryptor.DecryptWithMasterKey([]byte(value), key)
+	if err != nil {
+		return "<failed to decrypt>"
+	}
+
+	return dec
+}
+
+// sealSecretData шифрование всех полей секрета перед отправкой на сервер.
+func sealSecretData(data interface{}) error {
+	var err error
+
+	seal := func(fields ...*string) {
+		for _, f := range fields {
+			if err != nil || f == nil {
+				continue
+			}
+			*f, err = sealField(*f)
+		}
+	}
+
+	switch d := data.(type) {
+	case *pb.PasswordData:
+		seal(&d.Username, &d.Password, &d.Url, d.Notes)
+	case *pb.CardData:
+		seal(&d.Owner, &d.Number, &d.CVV, &d.ExpireDate, d.Notes)
+	case *pb.BinaryData:
+		content := string(d.GetContent())
+		seal(&d.Filename, &content, d.Notes)
+		d.Content = []byte(content)
+	}
+
+	return err
+}
+
+// openSecretData расшифровка полей секрета, полученного с сервера.
+func openSecretData(secret *pb.GetSecret) {
+	openSecretDataWithKey(secret, vaultKey)
+}
+
+// openSecretDataWithKey расшифровка полей секрета ключом хранилища key, например,
+// ключом чужого хранилища при экстренном доступе.
+func openSecretDataWithKey(secret *pb.GetSecret, key []byte) {
+	open := func(fields ...*string) {
+		for _, f := range fields {
+			if f != nil {
+				*f = openField(*f, key)
+			}
+		}
+	}
+
+	switch {
+	case secret.GetPasswordData() != nil:
+		d := secret.GetPasswordData()
+		open(&d.Username, &d.Password, &d.Url, d.Notes)
+	case secret.GetCardData() != nil:
+		d := secret.GetCardData()
+		open(&d.Owner, &d.Number, &d.CVV, &d.ExpireDate, d.Notes)
+	case secret.GetBinaryData() != nil:
+		d := secret.GetBinaryData()
+		content := string(d.GetContent())
+		open(&d.Filename, &content, d.Notes)
+		d.Content = []byte(content)
+	}
+}
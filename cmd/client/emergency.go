@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func manageEmergencyAccess() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\n1. Nominate trusted contact")
+	fmt.Println("2. My trusted contacts")
+	fmt.Println("3. Approve access request")
+	fmt.Println("4. Reject request or revoke access")
+	fmt.Println("5. Vaults I can request")
+	fmt.Println("6. Request access")
+	fmt.Println("7. Open vault")
+	fmt.Println("8. Take over account")
+	fmt.Println("9. Remove contact")
+	fmt.Println("10. Event log")
+	fmt.Print("Select an option: ")
+	option, _ := reader.ReadString('\n')
+
+	switch strings.TrimSpace(option) {
+	case "1":
+		nominateContact(reader)
+	case "2":
+		listTrustedContacts()
+	case "3":
+		decideAccess(reader, true)
+	case "4":
+		decideAccess(reader, false)
+	case "5":
+		listGrantors()
+	case "6":
+		requestAccess(reader)
+	case "7":
+		openEmergencyVault(reader)
+	case "8":
+		takeoverAccount(reader)
+	case "9":
+		removeContact(reader)
+	case "10":
+		listEmergencyEvents()
+	default:
+		fmt.Println("Invalid option")
+	}
+}
+
+func nominateContact(reader *bufio.Reader) {
+	ctx := withToken(context.Background())
+
+	fmt.Print("Contact login: ")
+	login, _ := reader.ReadString('\n')
+	login = strings.TrimSpace(login)
+
+	fmt.Print("Access (view/takeover, default view): ")
+	accessInput, _ := reader.ReadString('\n')
+	accessType := pb.EmergencyAccessType_EMERGENCY_ACCESS_VIEW
+	switch strings.ToLower(strings.TrimSpace(accessInput)) {
+	case "", "view":
+	case "takeover":
+		accessType = pb.EmergencyAccessType_EMERGENCY_ACCESS_TAKEOVER
+	default:
+		fmt.Println("Invalid access type")
+		return
+	}
+
+	fmt.Print("Waiting period in hours (leave empty for default): ")
+	waitInput, _ := reader.ReadString('\n')
+	wait, err := parseOptionalUint(waitInput)
+	if err != nil {
+		fmt.Println("Invalid waiting period")
+		return
+	}
+
+	req := &pb.NominateContactRequest{GranteeLogin: login, AccessType: accessType, WaitHours: wait}
+
+	// Ключ E2E хранилища передается контакту зашифрованным его открытым ключом
+	if vaultKey != nil {
+		keyRes, err := userClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Login: login})
+		if err != nil {
+			fmt.Printf("Failed to get contact public key: %v\n", err)
+			return
+		}
+		if len(keyRes.GetPublicKey()) == 0 {
+			fmt.Println("Contact has no share keys: only end-to-end encrypted accounts " +
+				"that have logged in at least once can be trusted with an end-to-end encrypted vault")
+			return
+		}
+
+		req.WrappedVaultKey, err = encryptor.SealToPublicKey(vaultKey, keyRes.GetPublicKey())
+		if err != nil {
+			fmt.Printf("Failed to wrap vault key: %v\n", err)
+			return
+		}
+	}
+
+	res, err := emergencyClient.NominateContact(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to nominate contact: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s can now request %s access, waiting period %d hours (contact ID %d)\n",
+		res.GetGranteeLogin(), accessTypeName(res.GetAccessType()), res.GetWaitHours(), res.GetId())
+}
+
+func listTrustedContacts() {
+	res, err := emergencyClient.ListTrustedContacts(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get trusted contacts: %v\n", err)
+		return
+	}
+
+	if len(res.GetContacts()) == 0 {
+		fmt.Println("No trusted contacts")
+		return
+	}
+
+	for _, c := range res.GetContacts() {
+		fmt.Printf("%d. %s, %s access, waiting period %d hours: %s\n",
+			c.GetId(), c.GetGranteeLogin(), accessTypeName(c.GetAccessType()), c.GetWaitHours(), contactState(c))
+	}
+}
+
+func listGrantors() {
+	res, err := emergencyClient.ListGrantors(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get vaults: %v\n", err)
+		return
+	}
+
+	if len(res.GetContacts()) == 0 {
+		fmt.Println("Nobody has nominated you as a trusted contact")
+		return
+	}
+
+	for _, c := range res.GetContacts() {
+		fmt.Printf("%d. %s, %s access, waiting period %d hours: %s\n",
+			c.GetId(), c.GetGrantorLogin(), accessTypeName(c.GetAccessType()), c.GetWaitHours(), contactState(c))
+	}
+}
+
+// decideAccess одобрение запроса или отказ в нем (отзыв открытого доступа) владельцем.
+func decideAccess(reader *bufio.Reader, approve bool) {
+	id, ok := readID(reader, "Contact ID: ")
+	if !ok {
+		return
+	}
+
+	ctx := withToken(context.Background())
+	req := &pb.EmergencyContactRequest{ContactId: id}
+
+	var (
+		res *pb.EmergencyContact
+		err error
+	)
+	if approve {
+		res, err = emergencyClient.ApproveAccess(ctx, req)
+	} else {
+		res, err = emergencyClient.RejectAccess(ctx, req)
+	}
+	if err != nil {
+		fmt.Printf("Failed to update access: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%s: %s\n", res.GetGranteeLogin(), contactState(res))
+}
+
+func requestAccess(reader *bufio.Reader) {
+	id, ok := readID(reader, "Contact ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := emergencyClient.RequestAccess(withToken(context.Background()), &pb.EmergencyContactRequest{ContactId: id})
+	if err != nil {
+		fmt.Printf("Failed to request access: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Access to %s's vault requested: %s\n", res.GetGrantorLogin(), contactState(res))
+}
+
+func openEmergencyVault(reader *bufio.Reader) {
+	id, ok := readID(reader, "Contact ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := emergencyClient.GetEmergencyVault(
+		withToken(context.Background()), &pb.EmergencyContactRequest{ContactId: id},
+	)
+	if err != nil {
+		fmt.Printf("Failed to open vault: %v\n", err)
+		return
+	}
+
+	if len(res.GetContact().GetWrappedVaultKey()) != 0 {
+		key, err := openGrantorVaultKey(res.GetContact())
+		if err != nil {
+			fmt.Printf("Failed to open vault: %v\n", err)
+			return
+		}
+
+		// Данные уже расшифрованы, printSecrets не должен расшифровывать их своим ключом хранилища
+		for _, secret := range res.GetSecrets() {
+			if secret.GetClientEncrypted() {
+				openSecretDataWithKey(secret, key)
+				secret.ClientEncrypted = false
+			}
+		}
+	}
+
+	if len(res.GetSecrets()) == 0 {
+		fmt.Printf("%s's vault is empty\n", res.GetContact().GetGrantorLogin())
+		return
+	}
+
+	fmt.Printf("\nVault of %s", res.GetContact().GetGrantorLogin())
+	printSecrets(res.GetSecrets())
+}
+
+func takeoverAccount(reader *bufio.Reader) {
+	id, ok := readID(reader, "Contact ID: ")
+	if !ok {
+		return
+	}
+
+	contact, err := findGrantor(id)
+	if err != nil {
+		fmt.Printf("Takeover failed: %v\n", err)
+		return
+	}
+
+	fmt.Printf("This changes the password of %s, signs them out everywhere and disables their "+
+		"two-factor authentication. Type the login to confirm: ", contact.GetGrantorLogin())
+	confirm, _ := reader.ReadString('\n')
+	if strings.TrimSpace(confirm) != contact.GetGrantorLogin() {
+		fmt.Println("Takeover cancelled")
+		return
+	}
+
+	fmt.Print("New password for the account: ")
+	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		fmt.Printf("Failed to read password: %v\n", err)
+		return
+	}
+
+	req := &pb.TakeoverAccountRequest{ContactId: id, NewPassword: strings.TrimSpace(string(bytePassword))}
+	if req.GetNewPassword() == "" {
+		fmt.Println("Error: Password cannot be empty")
+		return
+	}
+
+	// Ключ E2E хранилища владельца оборачивается ключом из нового мастер-пароля
+	if len(contact.GetWrappedVaultKey()) != 0 {
+		if err = checkMasterPassword(req.GetNewPassword(), contact.GetGrantorLogin()); err != nil {
+			fmt.Printf("Takeover failed: %v\n", err)
+			return
+		}
+
+		key, err := openGrantorVaultKey(contact)
+		if err != nil {
+			fmt.Printf("Takeover failed: %v\n", err)
+			return
+		}
+
+		req.NewPassword, req.NewKdfParams, req.NewWrappedVaultKey, err = rewrapVaultKey(req.GetNewPassword(), key)
+		if err != nil {
+			fmt.Printf("Takeover failed: %v\n", err)
+			return
+		}
+	}
+
+	if _, err = emergencyClient.TakeoverAccount(withToken(context.Background()), req); err != nil {
+		fmt.Printf("Takeover failed: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Password of %s changed, you can now log in to the account\n", contact.GetGrantorLogin())
+}
+
+func removeContact(reader *bufio.Reader) {
+	id, ok := readID(reader, "Contact ID: ")
+	if !ok {
+		return
+	}
+
+	_, err := emergencyClient.RemoveContact(withToken(context.Background()), &pb.EmergencyContactRequest{ContactId: id})
+	if err != nil {
+		fmt.Printf("Failed to remove contact: %v\n", err)
+		return
+	}
+
+	fmt.Println("Contact removed")
+}
+
+func listEmergencyEvents() {
+	res, err := emergencyClient.ListEmergencyEvents(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get event log: %v\n", err)
+		return
+	}
+
+	if len(res.GetEvents()) == 0 {
+		fmt.Println("Event log is empty")
+		return
+	}
+
+	for _, ev := range res.GetEvents() {
+		actor := ev.GetActorLogin()
+		if actor == "" {
+			actor = "server"
+		}
+		fmt.Printf("%s  %s -> %s  %s by %s\n", ev.GetCreatedAt().AsTime().Local().Format(time.DateTime),
+			ev.GetGrantorLogin(), ev.GetGranteeLogin(), ev.GetAction(), actor)
+	}
+}
+
+// warnEmergencyRequests предупреждение владельца о запросах доступа к его хранилищу после входа.
+func warnEmergencyRequests() {
+	res, err := emergencyClient.ListTrustedContacts(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		return
+	}
+
+	for _, c := range res.GetContacts() {
+		if c.GetStatus() == pb.EmergencyAccessStatus_EMERGENCY_STATUS_PENDING {
+			fmt.Printf("Warning: %s requested emergency access to your vault, it opens at %s "+
+				"unless you reject it (Emergency access -> Reject, contact ID %d)\n",
+				c.GetGranteeLogin(), c.GetAccessAt().AsTime().Local().Format(time.DateTime), c.GetId())
+		}
+	}
+}
+
+// findGrantor назначение текущего пользователя доверенным контактом по ID.
+func findGrantor(id int64) (*pb.EmergencyContact, error) {
+	res, err := emergencyClient.ListGrantors(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range res.GetContacts() {
+		if c.GetId() == id {
+			return c, nil
+		}
+	}
+
+	return nil, errors.New("contact not found")
+}
+
+// openGrantorVaultKey расшифровка ключа E2E хранилища владельца своим закрытым ключом обмена.
+func openGrantorVaultKey(c *pb.EmergencyContact) ([]byte, error) {
+	if sharePrivateKey == nil {
+		return nil, errors.New("share keys are locked")
+	}
+
+	key, err := encryptor.OpenWithPrivateKey(c.GetWrappedVaultKey(), sharePrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap vault key: %w", err)
+	}
+
+	return key, nil
+}
+
+// contactState состояние контакта для вывода.
+func contactState(c *pb.EmergencyContact) string {
+	switch c.GetStatus() {
+	case pb.EmergencyAccessStatus_EMERGENCY_STATUS_PENDING:
+		return "requested, opens at " + c.GetAccessAt().AsTime().Local().Format(time.DateTime)
+	case pb.EmergencyAccessStatus_EMERGENCY_STATUS_APPROVED:
+		return "access granted"
+	case pb.EmergencyAccessStatus_EMERGENCY_STATUS_REJECTED:
+		return "rejected"
+	default:
+		return "not requested"
+	}
+}
+
+func accessTypeName(t pb.EmergencyAccessType) string {
+	if t == pb.EmergencyAccessType_EMERGENCY_ACCESS_TAKEOVER {
+		return "takeover"
+	}
+
+	return "view"
+}
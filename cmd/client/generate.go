@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/passgen"
+)
+
+// generatePassword генерация пароля с возможностью сразу сохранить его как секрет-пароль.
+func generatePassword() {
+	reader := bufio.NewReader(os.Stdin)
+
+	req, err := readGenerateRules(reader)
+	if err != nil {
+		fmt.Printf("Failed to generate password: %v\n", err)
+		return
+	}
+
+	fmt.Print("Save as password secret? Enter secret name (leave empty to only show): ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	var save *pb.SaveGeneratedPassword
+	if name != "" {
+		fmt.Print("Enter username: ")
+		username, _ := reader.ReadString('\n')
+
+		fmt.Print("Enter URL (optional): ")
+		url, _ := reader.ReadString('\n')
+
+		fmt.Print("Enter notes (optional): ")
+		notes, _ := reader.ReadString('\n')
+		notes = strings.TrimSpace(notes)
+
+		save = &pb.SaveGeneratedPassword{
+			Name:     name,
+			Username: strings.TrimSpace(username),
+			Url:      strings.TrimSpace(url),
+			Notes:    &notes,
+		}
+	}
+
+	// Для E2E аккаунта пароль не должен попасть на сервер в открытом виде
+	if vaultKey != nil {
+		res, err := generateLocally(req)
+		if err != nil {
+			fmt.Printf("Failed to generate password: %v\n", err)
+			return
+		}
+		printGenerated(res)
+
+		if save != nil {
+			submitSecret(save.GetName(), pb.SecretType_SECRET_TYPE_PASSWORD, &pb.PasswordData{
+				Username: save.GetUsername(),
+				Password: res.GetPassword(),
+				Url:      save.GetUrl(),
+				Notes:    save.Notes,
+			}, nil)
+		}
+		return
+	}
+
+	req.Save = save
+	res, err := secretClient.GeneratePassword(withToken(context.Background()), req)
+	if err != nil {
+		fmt.Printf("Failed to generate password: %v\n", err)
+		return
+	}
+	printGenerated(res)
+
+	if res.GetSecretId() != 0 {
+		fmt.Printf("Secret created successfully with ID: %d\n", res.GetSecretId())
+	}
+}
+
+// generateForSecret генерация пароля при создании секрета-пароля.
+func generateForSecret(reader *bufio.Reader) (string, error) {
+	req, err := readGenerateRules(reader)
+	if err != nil {
+		return "", err
+	}
+
+	var res *pb.GeneratePasswordResponse
+	if vaultKey != nil {
+		res, err = generateLocally(req)
+	} else {
+		res, err = secretClient.GeneratePassword(withToken(context.Background()), req)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Generated password: %s (%.0f bits of entropy)\n", res.GetPassword(), res.GetEntropyBits())
+
+	return res.GetPassword(), nil
+}
+
+// readGenerateRules чтение правил генерации, пустой ввод оставляет значение по умолчанию.
+func readGenerateRules(reader *bufio.Reader) (*pb.GeneratePasswordRequest, error) {
+	fmt.Println("1. Random password")
+	fmt.Println("2. Passphrase")
+	fmt.Print("Your choice (default 1): ")
+	kind, _ := reader.ReadString('\n')
+
+	if strings.TrimSpace(kind) == "2" {
+		words, err := readUint(reader, fmt.Sprintf("Number of words (default %d): ", passgen.DefaultWords))
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Printf("Separator (default %q): ", passgen.DefaultSeparator)
+		separator, _ := reader.ReadString('\n')
+
+		return &pb.GeneratePasswordRequest{
+			Rules: &pb.GeneratePasswordRequest_Passphrase{Passphrase: &pb.PassphraseRules{
+				Words:         words,
+				Separator:     strings.Trim(separator, "\r\n"),
+				Capitalize:    readYes(reader, "Capitalize words? (y/N): "),
+				IncludeNumber: readYes(reader, "Include a number? (y/N): "),
+			}},
+		}, nil
+	}
+
+	length, err := readUint(reader, fmt.Sprintf("Length (default %d): ", passgen.DefaultLength))
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Print("Character sets: l - lowercase, u - uppercase, d - digits, s - symbols (default lud): ")
+	sets, _ := reader.ReadString('\n')
+	sets = strings.ToLower(strings.TrimSpace(sets))
+
+	return &pb.GeneratePasswordRequest{
+		Rules: &pb.GeneratePasswordRequest_Password{Password: &pb.PasswordRules{
+			Length:           length,
+			Lowercase:        strings.Contains(sets, "l"),
+			Uppercase:        strings.Contains(sets, "u"),
+			Digits:           strings.Contains(sets, "d"),
+			Symbols:          strings.Contains(sets, "s"),
+			ExcludeAmbiguous: readYes(reader, "Exclude ambiguous characters (I, l, 1, O, 0)? (y/N): "),
+			RequireEachClass: readYes(reader, "Require each character set? (y/N): "),
+		}},
+	}, nil
+}
+
+// generateLocally генерация на клиенте по тем же правилам, что и на сервере.
+func generateLocally(req *pb.GeneratePasswordRequest) (*pb.GeneratePasswordResponse, error) {
+	var (
+		password string
+		entropy  float64
+		err      error
+	)
+	if rules := req.GetPassphrase(); rules != nil {
+		password, entropy, err = passgen.Passphrase(passgen.PassphraseRules{
+			Words:         int(rules.GetWords()),
+			Separator:     rules.GetSeparator(),
+			Capitalize:    rules.GetCapitalize(),
+			IncludeNumber: rules.GetIncludeNumber(),
+		})
+	} else {
+		rules := req.GetPassword()
+		password, entropy, err = passgen.Password(passgen.Rules{
+			Length:           int(rules.GetLength()),
+			Lowercase:        rules.GetLowercase(),
+			Uppercase:        rules.GetUppercase(),
+			Digits:           rules.GetDigits(),
+			Symbols:          rules.GetSymbols(),
+			ExcludeAmbiguous: rules.GetExcludeAmbiguous(),
+			RequireEachClass: rules.GetRequireEachClass(),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	return &pb.GeneratePasswordResponse{
+		Password:    password,
+		EntropyBits: entropy,
+		Strength:    estimateLocally(&pb.PasswordData{Password: password}),
+	}, nil
+}
+
+func printGenerated(res *pb.GeneratePasswordResponse) {
+	fmt.Printf("Generated password: %s\n", res.GetPassword())
+	fmt.Printf("Entropy: %.0f bits\n", res.GetEntropyBits())
+	printPasswordStrength(res.GetStrength())
+}
+
+func readUint(reader *bufio.Reader, prompt string) (uint32, error) {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseUint(input, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", input)
+	}
+
+	return uint32(v), nil
+}
+
+func readYes(reader *bufio.Reader, prompt string) bool {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+
+	return strings.EqualFold(strings.TrimSpace(input), "y")
+}
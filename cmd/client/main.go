@@ -10,6 +10,7 @@ import (
 	"syscall"
 
 	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
 	"golang.org/x/term"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -22,9 +23,16 @@ const (
 )
 
 var (
-	userClient   pb.UserServiceClient
-	secretClient pb.SecretServiceClient
-	token        string
+	userClient    pb.UserServiceClient
+	secretClient  pb.SecretServiceClient
+	orgClient     pb.OrganizationServiceClient
+	oneTimeClient pb.OneTimeShareServiceClient
+	// emergencyClient клиент экстренного доступа к хранилищам.
+	emergencyClient pb.EmergencyAccessServiceClient
+	auditClient     pb.AuditServiceClient
+	token           string
+	// currentLogin логин, под которым выполнен вход, нужен для параметров KDF при смене пароля.
+	currentLogin string
 )
 
 func main() {
@@ -32,7 +40,11 @@ func main() {
 		err  error
 		conn *grpc.ClientConn
 	)
-	conn, err = grpc.NewClient(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err = grpc.NewClient(
+		serverAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(refreshInterceptor),
+	)
 	if err != nil {
 		fmt.Printf("did not connect: %v\n", err)
 		return
@@ -43,6 +55,10 @@ func main() {
 
 	userClient = pb.NewUserServiceClient(conn)
 	secretClient = pb.NewSecretServiceClient(conn)
+	orgClient = pb.NewOrganizationServiceClient(conn)
+	oneTimeClient = pb.NewOneTimeShareServiceClient(conn)
+	emergencyClient = pb.NewEmergencyAccessServiceClient(conn)
+	auditClient = pb.NewAuditServiceClient(conn)
 
 	showMainMenu()
 }
@@ -55,9 +71,22 @@ func showMainMenu() {
 			fmt.Println("2. Login")
 			fmt.Println("3. Exit")
 		} else {
-			fmt.Println("\n4. Create secret")
-			fmt.Println("5. Get secrets")
-			fmt.Println("6. Logout")
+			fmt.Println("\n4. Update credentials")
+			fmt.Println("5. Create secret")
+			fmt.Println("6. Get secrets")
+			fmt.Println("7. Logout")
+			fmt.Println("8. Sessions")
+			fmt.Println("9. Enable two-factor authentication")
+			fmt.Println("10. Delete account")
+			fmt.Println("11. Generate password")
+			fmt.Println("12. Check breached passwords")
+			fmt.Println("13. Security report")
+			fmt.Println("14. Shared secrets")
+			fmt.Println("15. Organizations")
+			fmt.Println("16. One-time share link")
+			fmt.Println("17. Emergency access")
+			fmt.Println("18. Access approvals")
+			fmt.Println("19. Audit log")
 		}
 
 		fmt.Print("Select an option: ")
@@ -93,8 +122,79 @@ func showMainMenu() {
 			}
 		case "7":
 			if token != "" {
-				token = ""
-				fmt.Println("Logged out successfully")
+				logoutUser()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "8":
+			if token != "" {
+				manageSessions()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "9":
+			if token != "" {
+				enableTOTP()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "10":
+			if token != "" {
+				deleteAccount()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "11":
+			if token != "" {
+				generatePassword()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "12":
+			if token != "" {
+				checkBreaches()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "13":
+			if token != "" {
+				securityReport()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "14":
+			if token != "" {
+				manageShares()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "15":
+			if token != "" {
+				manageOrganizations()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "16":
+			if token != "" {
+				createOneTimeShare()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "17":
+			if token != "" {
+				manageEmergencyAccess()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "18":
+			if token != "" {
+				manageAccessApprovals()
+			} else {
+				fmt.Println("Invalid option")
+			}
+		case "19":
+			if token != "" {
+				showAuditLog()
 			} else {
 				fmt.Println("Invalid option")
 			}
@@ -115,11 +215,33 @@ func registerUser() {
 	password, _ := reader.ReadString('\n')
 	password = strings.TrimSpace(password)
 
+	fmt.Print("Enable end-to-end encryption? Server will never see your data, " +
+		"but a lost password cannot be recovered (y/N): ")
+	e2eChoice, _ := reader.ReadString('\n')
+
+	fmt.Print("Invite code (leave empty if the server does not require it): ")
+	inviteCode, _ := reader.ReadString('\n')
+
 	req := &pb.RegisterUserRequest{
 		Login:    login,
 		Password: password,
 	}
 
+	if strings.EqualFold(strings.TrimSpace(e2eChoice), "y") {
+		if err := checkMasterPassword(password, login); err != nil {
+			fmt.Printf("Registration failed: %v\n", err)
+			return
+		}
+
+		var err error
+		req, err = prepareE2ERegistration(login, password)
+		if err != nil {
+			fmt.Printf("Registration failed: %v\n", err)
+			return
+		}
+	}
+	req.InviteCode = strings.TrimSpace(inviteCode)
+
 	res, err := userClient.Register(context.Background(), req)
 	if err != nil {
 		fmt.Printf("Registration failed: %v\n", err)
@@ -157,6 +279,21 @@ func loginUser() {
 		Password: password, // Уже обрезаны пробелы
 	}
 
+	// Для E2E аккаунта вместо пароля отправляется ключ аутентификации, полученный из него
+	var kek []byte
+	kdfRes, err := userClient.GetKDFParams(context.Background(), &pb.GetKDFParamsRequest{Login: login})
+	if err != nil {
+		fmt.Printf("\nLogin failed: %v\n", err)
+		return
+	}
+	if kdfRes.GetEncryptionMode() == pb.EncryptionMode_ENCRYPTION_MODE_E2E {
+		req.Password, kek, err = deriveE2ELogin(password, kdfRes.GetKdfParams())
+		if err != nil {
+			fmt.Printf("\nLogin failed: %v\n", err)
+			return
+		}
+	}
+
 	var header metadata.MD
 	res, err := userClient.Login(
 		context.Background(),
@@ -168,10 +305,37 @@ func loginUser() {
 		return
 	}
 
+	if res.GetMfaRequired() {
+		res, err = verifyMFA(res, &header)
+		if err != nil {
+			fmt.Printf("\nLogin failed: %v\n", err)
+			return
+		}
+	}
+
+	if res.GetEncryptionMode() == pb.EncryptionMode_ENCRYPTION_MODE_E2E {
+		vaultKey, err = encryptor.UnwrapKey(res.GetWrappedVaultKey(), kek)
+		if err != nil {
+			fmt.Printf("\nFailed to unlock vault: %v\n", err)
+			return
+		}
+	}
+
 	// Получаем токен из заголовков
 	if authHeaders := header.Get("authorization"); len(authHeaders) > 0 {
 		token = authHeaders[0]
+		refreshToken = res.GetRefreshToken()
+		currentLogin = res.GetUser().GetLogin()
 		fmt.Printf("\nLogged in successfully. Welcome, %s!\n", res.GetUser().GetLogin())
+		if vaultKey != nil {
+			if err = unlockShareKeys(res); err != nil {
+				fmt.Printf("Warning: secrets shared with you cannot be opened: %v\n", err)
+			}
+		}
+		if res.GetDeleteAfter() != nil {
+			offerCancelDeletion(res.GetDeleteAfter().AsTime())
+		}
+		warnEmergencyRequests()
 	} else {
 		fmt.Println("\nWarning: Server didn't return authorization token")
 	}
@@ -192,25 +356,51 @@ func updateUser() {
 	newPassword, _ := term.ReadPassword(int(syscall.Stdin))
 	fmt.Println()
 
-	req := &pb.UpdateUserRequest{
+	req := &pb.UpdateCredentialsRequest{
 		NewLogin:    newLogin,
 		OldPassword: strings.TrimSpace(string(oldPassword)),
 		NewPassword: strings.TrimSpace(string(newPassword)),
 	}
 
+	// Для E2E аккаунта ключ хранилища перешифровывается ключом из нового мастер-пароля
+	if vaultKey != nil {
+		if req.GetNewPassword() != "" {
+			if err := checkMasterPassword(req.GetNewPassword(), currentLogin, newLogin); err != nil {
+				fmt.Printf("Update failed: %v\n", err)
+				return
+			}
+		}
+
+		kdfRes, err := userClient.GetKDFParams(context.Background(), &pb.GetKDFParamsRequest{Login: currentLogin})
+		if err != nil {
+			fmt.Printf("Update failed: %v\n", err)
+			return
+		}
+		if err = prepareE2ECredentials(req, kdfRes.GetKdfParams()); err != nil {
+			fmt.Printf("Update failed: %v\n", err)
+			return
+		}
+	}
+
 	ctx := withToken(context.Background())
-	_, err := userClient.Update(ctx, req)
+	res, err := userClient.UpdateCredentials(ctx, req)
 	if err != nil {
 		fmt.Printf("Update failed: %v\n", err)
 		return
 	}
 
-	fmt.Println("Credentials updated successfully")
+	currentLogin = res.GetUser().GetLogin()
+	fmt.Printf("Credentials updated successfully, other sessions signed out: %d\n", res.GetRevokedSessions())
 }
 
 func createSecret() {
 	reader := bufio.NewReader(os.Stdin)
 
+	collectionID, ok := readCollectionID(reader)
+	if !ok {
+		return
+	}
+
 	fmt.Println("\nSelect secret type:")
 	fmt.Println("1. Password")
 	fmt.Println("2. Credit Card")
@@ -233,9 +423,16 @@ func createSecret() {
 		username, _ := reader.ReadString('\n')
 		username = strings.TrimSpace(username)
 
-		fmt.Print("Enter password: ")
+		fmt.Print("Enter password (leave empty to generate): ")
 		password, _ := reader.ReadString('\n')
 		password = strings.TrimSpace(password)
+		if password == "" {
+			var err error
+			if password, err = generateForSecret(reader); err != nil {
+				fmt.Printf("Failed to generate password: %v\n", err)
+				return
+			}
+		}
 
 		fmt.Print("Enter URL (optional): ")
 		url, _ := reader.ReadString('\n')
@@ -310,9 +507,32 @@ func createSecret() {
 		return
 	}
 
+	submitSecret(name, secretType, secretData, collectionID)
+}
+
+// submitSecret отправка нового секрета на сервер, для E2E аккаунта данные шифруются перед отправкой.
+// Секреты коллекций шифрует сервер ключом коллекции, поэтому они отправляются без шифрования на клиенте.
+func submitSecret(name string, secretType pb.SecretType, secretData interface{}, collectionID *int64) {
+	seal := vaultKey != nil && collectionID == nil
+
+	// Пароль, который уйдет на сервер зашифрованным, оценивается до шифрования
+	var strength *pb.PasswordStrength
+	if data, ok := secretData.(*pb.PasswordData); ok && seal {
+		strength = estimateLocally(data)
+		markBreachedLocally(strength, data.GetPassword())
+	}
+
+	if seal {
+		if err := sealSecretData(secretData); err != nil {
+			fmt.Printf("Failed to create secret: %v\n", err)
+			return
+		}
+	}
+
 	req := &pb.CreateSecretRequest{
-		Name: name,
-		Type: secretType,
+		Name:         name,
+		Type:         secretType,
+		CollectionId: collectionID,
 	}
 
 	switch data := secretData.(type) {
@@ -332,16 +552,25 @@ func createSecret() {
 	}
 
 	fmt.Printf("Secret created successfully with ID: %d\n", res.GetId())
+	if res.GetPasswordStrength() != nil {
+		strength = res.GetPasswordStrength()
+	}
+	printPasswordStrength(strength)
 }
 
 func getSecrets() {
 	reader := bufio.NewReader(os.Stdin)
 
+	collectionID, ok := readCollectionID(reader)
+	if !ok {
+		return
+	}
+
 	fmt.Print("Enter secret name to filter (leave empty for all): ")
 	name, _ := reader.ReadString('\n')
 	name = strings.TrimSpace(name)
 
-	req := &pb.GetSecretRequest{}
+	req := &pb.GetSecretRequest{CollectionId: collectionID}
 	if name != "" {
 		req.Name = &name
 	}
@@ -358,15 +587,40 @@ func getSecrets() {
 		return
 	}
 
+	printSecrets(res.GetSecrets())
+}
+
+func printSecrets(secrets []*pb.GetSecret) {
 	fmt.Println("\nSecrets:")
-	for i, secret := range res.GetSecrets() {
-		fmt.Printf("\n%d. Name: %s, Type: %s\n", i+1, secret.GetName(), secret.GetType().String())
+	for i, secret := range secrets {
+		fmt.Printf("\n%d. ID: %d, Name: %s, Type: %s\n", i+1, secret.GetId(), secret.GetName(), secret.GetType().String())
+
+		if share := secret.GetShare(); share != nil {
+			fmt.Printf("   Shared by %s (%s)\n", secret.GetOwnerLogin(), permissionName(share.GetPermission()))
+		}
+
+		if secret.GetApprovalRequired() {
+			fmt.Println("   Data hidden: request access and wait for approval (Access approvals menu)")
+			continue
+		}
+
+		switch {
+		case len(secret.GetShare().GetPayload()) != 0:
+			if err := openSharedSecret(secret); err != nil {
+				fmt.Printf("   Failed to open shared secret: %v\n", err)
+			}
+		case secret.GetClientEncrypted() && secret.GetShare() == nil && vaultKey != nil:
+			openSecretData(secret)
+		}
 
 		switch secret.GetData().(type) {
 		case *pb.GetSecret_PasswordData:
 			data := secret.GetPasswordData()
 			fmt.Printf("   Username: %s\n", data.GetUsername())
 			fmt.Printf("   Password: %s\n", data.GetPassword())
+			if data.GetBreached() {
+				fmt.Printf("   Warning: password appeared in data breaches %d times\n", data.GetBreachCount())
+			}
 			fmt.Printf("   URL: %s\n", data.GetUrl())
 			if data.GetNotes() != "" {
 				fmt.Printf("   Notes: %s\n", data.GetNotes())
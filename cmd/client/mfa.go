@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// verifyMFA второй шаг входа: подключение аутентификатора, если его требует политика, и ввод кода.
+// header заполняется заголовками ответа VerifyMFA с токеном доступа.
+func verifyMFA(login *pb.LoginUserResponse, header *metadata.MD) (*pb.LoginUserResponse, error) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", login.GetMfaToken())
+
+	if login.GetMfaEnrollmentRequired() {
+		fmt.Println("\nTwo-factor authentication is required for your account.")
+		if err := enrollTOTP(ctx); err != nil {
+			return nil, err
+		}
+		fmt.Println("\nWait for the next code to appear in your authenticator app.")
+	}
+
+	fmt.Print("Enter code from authenticator app or recovery code: ")
+	code, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	res, err := userClient.VerifyMFA(ctx, &pb.VerifyMFARequest{Code: strings.TrimSpace(code)}, grpc.Header(header))
+	if err != nil {
+		return nil, fmt.Errorf("verification failed: %w", err)
+	}
+
+	return res, nil
+}
+
+func enableTOTP() {
+	if err := enrollTOTP(withToken(context.Background())); err != nil {
+		fmt.Printf("Failed to enable two-factor authentication: %v\n", err)
+		return
+	}
+
+	fmt.Println("Two-factor authentication enabled")
+}
+
+// enrollTOTP подключение аутентификатора и вывод кодов восстановления.
+func enrollTOTP(ctx context.Context) error {
+	enrollment, err := userClient.EnrollTOTP(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("enrollment failed: %w", err)
+	}
+
+	fmt.Println("\nAdd this account to your authenticator app (scan as QR code or open on the phone):")
+	fmt.Printf("   %s\n", enrollment.GetQrPayload())
+	fmt.Printf("Or enter the secret manually: %s\n", enrollment.GetSecret())
+
+	fmt.Print("\nEnter code from authenticator app to confirm: ")
+	code, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	res, err := userClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{Code: strings.TrimSpace(code)})
+	if err != nil {
+		return fmt.Errorf("confirmation failed: %w", err)
+	}
+
+	fmt.Println("\nRecovery codes (each works once, store them somewhere safe, they will not be shown again):")
+	for _, c := range res.GetRecoveryCodes() {
+		fmt.Printf("   %s\n", c)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	"google.golang.org/protobuf/proto"
+)
+
+// createOneTimeShare одноразовая ссылка на секрет, текст или файл для человека без аккаунта.
+// Клиент E2E аккаунта шифрует содержимое сам, и сервер не видит его даже при создании ссылки.
+func createOneTimeShare() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\nShare:")
+	fmt.Println("1. Existing secret")
+	fmt.Println("2. Text")
+	fmt.Println("3. File")
+	fmt.Print("Your choice: ")
+	choice, _ := reader.ReadString('\n')
+
+	req := &pb.CreateOneTimeShareRequest{}
+	content := &pb.OneTimeContent{}
+
+	switch strings.TrimSpace(choice) {
+	case "1":
+		secret, ok := selectOwnSecret(reader)
+		if !ok {
+			return
+		}
+		req.Content = &pb.CreateOneTimeShareRequest_SecretId{SecretId: secret.GetId()}
+		content.Name = secret.GetName()
+		if secret.GetClientEncrypted() {
+			openSecretData(secret)
+		}
+		switch data := secret.GetData().(type) {
+		case *pb.GetSecret_PasswordData:
+			content.Data = &pb.OneTimeContent_PasswordData{PasswordData: data.PasswordData}
+		case *pb.GetSecret_CardData:
+			content.Data = &pb.OneTimeContent_CardData{CardData: data.CardData}
+		case *pb.GetSecret_BinaryData:
+			content.Data = &pb.OneTimeContent_BinaryData{BinaryData: data.BinaryData}
+		}
+	case "2":
+		fmt.Print("Enter text: ")
+		text, _ := reader.ReadString('\n')
+		req.Content = &pb.CreateOneTimeShareRequest_Text{Text: strings.TrimSpace(text)}
+		content.Data = &pb.OneTimeContent_Text{Text: strings.TrimSpace(text)}
+	case "3":
+		fmt.Print("Enter file path: ")
+		filePath, _ := reader.ReadString('\n')
+		filePath = strings.TrimSpace(filePath)
+
+		fileContent, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Printf("Error reading file: %v\n", err)
+			return
+		}
+		file := &pb.BinaryData{Filename: filePath[strings.LastIndexAny(filePath, `/\`)+1:], Content: fileContent}
+		req.Content = &pb.CreateOneTimeShareRequest_File{File: file}
+		content.Data = &pb.OneTimeContent_BinaryData{BinaryData: file}
+	default:
+		fmt.Println("Invalid option")
+		return
+	}
+
+	fmt.Print("Number of views (leave empty for 1): ")
+	views, _ := reader.ReadString('\n')
+	fmt.Print("Lifetime in hours (leave empty for server default): ")
+	hours, _ := reader.ReadString('\n')
+
+	var err error
+	if req.MaxViews, err = parseOptionalUint(views); err != nil {
+		fmt.Println("Invalid number of views")
+		return
+	}
+	if req.TtlHours, err = parseOptionalUint(hours); err != nil {
+		fmt.Println("Invalid lifetime")
+		return
+	}
+
+	var key []byte
+	if vaultKey != nil {
+		if key, err = sealOneTimeContent(req, content); err != nil {
+			fmt.Printf("Failed to create link: %v\n", err)
+			return
+		}
+	}
+
+	res, err := oneTimeClient.CreateOneTimeShare(withToken(context.Background()), req)
+	if err != nil {
+		fmt.Printf("Failed to create link: %v\n", err)
+		return
+	}
+	if key == nil {
+		key = res.GetKey()
+	}
+
+	fmt.Printf("\nLink ID: %s\nKey: %s\n", res.GetId(), onetime.EncodeKey(key))
+	// Для содержимого, зашифрованного клиентом, сервер возвращает ссылку без ключа
+	if link := res.GetUrl(); link != "" {
+		if !strings.Contains(link, "#") {
+			link += "#" + onetime.EncodeKey(key)
+		}
+		fmt.Printf("Link: %s\n", link)
+	}
+	fmt.Printf("Views: %d, expires: %s\n",
+		res.GetMaxViews(), res.GetExpiresAt().AsTime().Local().Format("2006-01-02 15:04"))
+	fmt.Println("The key is shown only once and is not stored on the server.")
+}
+
+// sealOneTimeContent шифрование содержимого ссылки на клиенте: в запрос попадает только шифротекст.
+func sealOneTimeContent(req *pb.CreateOneTimeShareRequest, content *pb.OneTimeContent) ([]byte, error) {
+	plain, err := proto.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
+	}
+
+	ciphertext, key, err := onetime.Seal(plain)
+	if err != nil {
+		return nil, err
+	}
+	req.Content = &pb.CreateOneTimeShareRequest_SealedContent{SealedContent: ciphertext}
+
+	return key, nil
+}
+
+func parseOptionalUint(s string) (uint32, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(n), nil
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func manageOrganizations() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\n1. Create organization")
+	fmt.Println("2. My organizations")
+	fmt.Println("3. Members")
+	fmt.Println("4. Add member")
+	fmt.Println("5. Change member role")
+	fmt.Println("6. Remove member")
+	fmt.Println("7. Create collection")
+	fmt.Println("8. Collections")
+	fmt.Println("9. Delete organization")
+	fmt.Println("10. Delete collection secret")
+	fmt.Print("Select an option: ")
+	option, _ := reader.ReadString('\n')
+
+	switch strings.TrimSpace(option) {
+	case "1":
+		createOrganization(reader)
+	case "2":
+		listOrganizations()
+	case "3":
+		listMembers(reader)
+	case "4":
+		addMember(reader)
+	case "5":
+		setMemberRole(reader)
+	case "6":
+		removeMember(reader)
+	case "7":
+		createCollection(reader)
+	case "8":
+		listCollections(reader)
+	case "9":
+		deleteOrganization(reader)
+	case "10":
+		deleteCollectionSecret(reader)
+	default:
+		fmt.Println("Invalid option")
+	}
+}
+
+func createOrganization(reader *bufio.Reader) {
+	fmt.Print("Organization name: ")
+	name, _ := reader.ReadString('\n')
+
+	res, err := orgClient.CreateOrganization(withToken(context.Background()),
+		&pb.CreateOrganizationRequest{Name: strings.TrimSpace(name)})
+	if err != nil {
+		fmt.Printf("Failed to create organization: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Organization created with ID: %d\n", res.GetId())
+}
+
+func listOrganizations() {
+	res, err := orgClient.ListOrganizations(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get organizations: %v\n", err)
+		return
+	}
+
+	if len(res.GetOrganizations()) == 0 {
+		fmt.Println("You are not a member of any organization")
+		return
+	}
+
+	fmt.Println("\nOrganizations:")
+	for _, o := range res.GetOrganizations() {
+		fmt.Printf("ID: %d, Name: %s, Role: %s\n", o.GetId(), o.GetName(), roleName(o.GetRole()))
+	}
+}
+
+func listMembers(reader *bufio.Reader) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := orgClient.ListMembers(withToken(context.Background()), &pb.OrganizationRequest{OrgId: orgID})
+	if err != nil {
+		fmt.Printf("Failed to get members: %v\n", err)
+		return
+	}
+
+	fmt.Println("\nMembers:")
+	for _, m := range res.GetMembers() {
+		fmt.Printf("%s, Role: %s, Since: %s\n",
+			m.GetLogin(), roleName(m.GetRole()), m.GetCreatedAt().AsTime().Format("2006-01-02 15:04"))
+	}
+}
+
+func addMember(reader *bufio.Reader) {
+	req, ok := readMemberRole(reader)
+	if !ok {
+		return
+	}
+
+	if _, err := orgClient.AddMember(withToken(context.Background()), req); err != nil {
+		fmt.Printf("Failed to add member: %v\n", err)
+		return
+	}
+
+	fmt.Println("Member added, collection keys were shared with them")
+}
+
+func setMemberRole(reader *bufio.Reader) {
+	req, ok := readMemberRole(reader)
+	if !ok {
+		return
+	}
+
+	if _, err := orgClient.SetMemberRole(withToken(context.Background()), req); err != nil {
+		fmt.Printf("Failed to change role: %v\n", err)
+		return
+	}
+
+	fmt.Println("Role changed")
+}
+
+func removeMember(reader *bufio.Reader) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("Member login (leave empty to leave the organization yourself): ")
+	login, _ := reader.ReadString('\n')
+	login = strings.TrimSpace(login)
+	if login == "" {
+		login = currentLogin
+	}
+
+	_, err := orgClient.RemoveMember(withToken(context.Background()), &pb.MemberRequest{OrgId: orgID, Login: login})
+	if err != nil {
+		fmt.Printf("Failed to remove member: %v\n", err)
+		return
+	}
+
+	fmt.Println("Member removed, collection keys were rotated")
+}
+
+func createCollection(reader *bufio.Reader) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("Collection name: ")
+	name, _ := reader.ReadString('\n')
+
+	res, err := orgClient.CreateCollection(withToken(context.Background()),
+		&pb.CreateCollectionRequest{OrgId: orgID, Name: strings.TrimSpace(name)})
+	if err != nil {
+		fmt.Printf("Failed to create collection: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Collection created with ID: %d\n", res.GetId())
+}
+
+func listCollections(reader *bufio.Reader) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return
+	}
+
+	res, err := orgClient.ListCollections(withToken(context.Background()), &pb.OrganizationRequest{OrgId: orgID})
+	if err != nil {
+		fmt.Printf("Failed to get collections: %v\n", err)
+		return
+	}
+
+	if len(res.GetCollections()) == 0 {
+		fmt.Println("No collections found")
+		return
+	}
+
+	fmt.Println("\nCollections:")
+	for _, c := range res.GetCollections() {
+		fmt.Printf("ID: %d, Name: %s, Key version: %d\n", c.GetId(), c.GetName(), c.GetKeyVersion())
+	}
+}
+
+func deleteOrganization(reader *bufio.Reader) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return
+	}
+
+	fmt.Print("All collections and their secrets will be deleted. Continue? (y/N): ")
+	confirm, _ := reader.ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(confirm), "y") {
+		return
+	}
+
+	_, err := orgClient.DeleteOrganization(withToken(context.Background()), &pb.OrganizationRequest{OrgId: orgID})
+	if err != nil {
+		fmt.Printf("Failed to delete organization: %v\n", err)
+		return
+	}
+
+	fmt.Println("Organization deleted")
+}
+
+func deleteCollectionSecret(reader *bufio.Reader) {
+	collectionID, ok := readID(reader, "Collection ID: ")
+	if !ok {
+		return
+	}
+
+	secretID, ok := readID(reader, "Secret ID: ")
+	if !ok {
+		return
+	}
+
+	_, err := secretClient.DeleteCollectionSecret(withToken(context.Background()),
+		&pb.DeleteCollectionSecretRequest{CollectionId: collectionID, SecretId: secretID})
+	if err != nil {
+		fmt.Printf("Failed to delete secret: %v\n", err)
+		return
+	}
+
+	fmt.Println("Secret deleted")
+}
+
+// readMemberRole чтение организации, логина участника и его роли.
+func readMemberRole(reader *bufio.Reader) (*pb.MemberRequest, bool) {
+	orgID, ok := readID(reader, "Organization ID: ")
+	if !ok {
+		return nil, false
+	}
+
+	fmt.Print("Member login: ")
+	login, _ := reader.ReadString('\n')
+
+	fmt.Print("Role (owner, admin, editor, viewer): ")
+	role, _ := reader.ReadString('\n')
+
+	req := &pb.MemberRequest{
+		OrgId: orgID,
+		Login: strings.TrimSpace(login),
+		Role:  roleFromName(strings.TrimSpace(role)),
+	}
+	if req.GetRole() == pb.OrgRole_ORG_ROLE_UNSPECIFIED {
+		fmt.Println("Invalid role")
+		return nil, false
+	}
+
+	return req, true
+}
+
+// readCollectionID чтение коллекции для операций с секретами, nil для личного хранилища.
+func readCollectionID(reader *bufio.Reader) (*int64, bool) {
+	fmt.Print("Collection ID (leave empty for personal vault): ")
+	input, _ := reader.ReadString('\n')
+	if strings.TrimSpace(input) == "" {
+		return nil, true
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+	if err != nil {
+		fmt.Println("Invalid collection ID")
+		return nil, false
+	}
+
+	return &id, true
+}
+
+func readID(reader *bufio.Reader, prompt string) (int64, bool) {
+	fmt.Print(prompt)
+	input, _ := reader.ReadString('\n')
+
+	id, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+	if err != nil {
+		fmt.Println("Invalid ID")
+		return 0, false
+	}
+
+	return id, true
+}
+
+func roleName(r pb.OrgRole) string {
+	return strings.ToLower(strings.TrimPrefix(r.String(), "ORG_ROLE_"))
+}
+
+func roleFromName(name string) pb.OrgRole {
+	r, ok := pb.OrgRole_value["ORG_ROLE_"+strings.ToUpper(name)]
+	if !ok {
+		return pb.OrgRole_ORG_ROLE_UNSPECIFIED
+	}
+
+	return pb.OrgRole(r)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// strengthLabels названия оценок стойкости пароля.
+var strengthLabels = [passpolicy.MaxScore + 1]string{"very weak", "weak", "fair", "good", "strong"}
+
+// checkMasterPassword проверка мастер-пароля E2E аккаунта по политике сервера. Сервер получает
+// только ключ аутентификации и не может проверить сам пароль, поэтому это делает клиент.
+func checkMasterPassword(password string, userInputs ...string) error {
+	res, err := userClient.GetPasswordPolicy(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("failed to get password policy: %w", err)
+	}
+
+	// Текст нарушений показывается пользователю как есть
+	policy := passpolicy.New(int(res.GetMinLength()), int(res.GetMinClasses()), int(res.GetMinScore()), nil)
+
+	return policy.Check(password, userInputs...)
+}
+
+// printPasswordStrength предупреждение о слабом пароле секрета. Оценка не мешает сохранению.
+func printPasswordStrength(s *pb.PasswordStrength) {
+	if s == nil {
+		return
+	}
+
+	score := min(int(s.GetScore()), passpolicy.MaxScore)
+	fmt.Printf("Password strength: %s (%d/%d)\n", strengthLabels[score], score, passpolicy.MaxScore)
+	if len(s.GetWarnings()) > 0 {
+		fmt.Printf("   Warning: %s\n", strings.Join(s.GetWarnings(), ", "))
+	}
+	if s.GetBreachCount() > 0 {
+		fmt.Printf("   Seen in data breaches %d times\n", s.GetBreachCount())
+	}
+}
+
+// estimateLocally оценка пароля секрета на клиенте, когда сервер получает только шифротекст.
+func estimateLocally(data *pb.PasswordData) *pb.PasswordStrength {
+	s := passpolicy.Estimate(data.GetPassword(), data.GetUsername(), data.GetUrl(), currentLogin)
+
+	return &pb.PasswordStrength{
+		Score:    uint32(max(s.Score, 0)), //nolint:gosec // отрицательные значения отсечены
+		Warnings: s.Warnings,
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+	"github.com/Melikhov-p/goph-keeper/internal/secreport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const day = 24 * time.Hour
+
+// securityReport отчет о безопасности хранилища. Для E2E аккаунта строится на клиенте.
+func securityReport() {
+	reader := bufio.NewReader(os.Stdin)
+
+	maxAge, err := readUint(reader, fmt.Sprintf("Max password age in days (default %d): ", secreport.DefaultMaxAge/day))
+	if err != nil {
+		fmt.Printf("Failed to build report: %v\n", err)
+		return
+	}
+	cardDays, err := readUint(reader,
+		fmt.Sprintf("Warn about cards expiring within days (default %d): ", secreport.DefaultCardExpiryWarning/day))
+	if err != nil {
+		fmt.Printf("Failed to build report: %v\n", err)
+		return
+	}
+
+	if vaultKey != nil {
+		report, err := buildReportLocally(time.Duration(maxAge)*day, time.Duration(cardDays)*day)
+		if err != nil {
+			fmt.Printf("Failed to build report: %v\n", err)
+			return
+		}
+		printReport(report)
+		return
+	}
+
+	res, err := secretClient.GetSecurityReport(withToken(context.Background()), &pb.SecurityReportRequest{
+		MaxAgeDays:     maxAge,
+		CardExpiryDays: cardDays,
+	})
+	if err != nil {
+		fmt.Printf("Failed to build report: %v\n", err)
+		return
+	}
+
+	printReport(reportFromPB(res))
+	if res.GetSkippedClientEncrypted() > 0 {
+		fmt.Printf("\n%d client-encrypted secrets were not checked\n", res.GetSkippedClientEncrypted())
+	}
+}
+
+// buildReportLocally отчет по секретам, расшифрованным ключом хранилища.
+func buildReportLocally(maxAge, cardExpiry time.Duration) (*secreport.Report, error) {
+	policy, err := userClient.GetPasswordPolicy(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password policy: %w", err)
+	}
+
+	res, err := secretClient.ExportSecrets(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+
+	var (
+		passwords []secreport.Password
+		cards     []secreport.Card
+	)
+	for _, secret := range res.GetSecrets() {
+		if secret.GetClientEncrypted() {
+			openSecretData(secret)
+		}
+
+		switch data := secret.GetData().(type) {
+		case *pb.GetSecret_PasswordData:
+			p := secreport.Password{
+				Name:     secret.GetName(),
+				Username: data.PasswordData.GetUsername(),
+				Password: data.PasswordData.GetPassword(),
+				URL:      data.PasswordData.GetUrl(),
+			}
+			if secret.GetUpdatedAt() != nil {
+				p.UpdatedAt = secret.GetUpdatedAt().AsTime()
+			}
+			passwords = append(passwords, p)
+		case *pb.GetSecret_CardData:
+			cards = append(cards, secreport.Card{Name: secret.GetName(), ExpireDate: data.CardData.GetExpireDate()})
+		}
+	}
+
+	plain := make([]string, len(passwords))
+	for i, p := range passwords {
+		plain[i] = p.Password
+	}
+	counts, err := lookupBreaches(plain)
+	switch {
+	case err == nil:
+		for i := range passwords {
+			passwords[i].BreachCount = counts[i]
+		}
+	case status.Code(err) != codes.FailedPrecondition:
+		return nil, err
+	}
+
+	return secreport.Build(passwords, cards, secreport.Options{
+		MaxAge:            maxAge,
+		CardExpiryWarning: cardExpiry,
+		MinScore:          int(policy.GetMinScore()),
+		UserInputs:        []string{currentLogin},
+	}), nil
+}
+
+func reportFromPB(res *pb.SecurityReport) *secreport.Report {
+	r := secreport.Report{
+		CheckedPasswords: int(res.GetCheckedPasswords()),
+		CheckedCards:     int(res.GetCheckedCards()),
+	}
+
+	for _, reused := range res.GetReused() {
+		r.Reused = append(r.Reused, reused.GetNames())
+	}
+	for _, w := range res.GetWeak() {
+		r.Weak = append(r.Weak, secreport.Weak{Name: w.GetName(), Score: int(w.GetScore()), Warnings: w.GetWarnings()})
+	}
+	for _, s := range res.GetStale() {
+		r.Stale = append(r.Stale, secreport.Stale{
+			Name:      s.GetName(),
+			UpdatedAt: s.GetUpdatedAt().AsTime(),
+			AgeDays:   int(s.GetAgeDays()),
+		})
+	}
+	for _, b := range res.GetBreached() {
+		r.Breached = append(r.Breached, secreport.Breached{Name: b.GetName(), Count: b.GetBreachCount()})
+	}
+	for _, i := range res.GetInsecureUrls() {
+		r.Insecure = append(r.Insecure, secreport.Insecure{Name: i.GetName(), URL: i.GetUrl()})
+	}
+	for _, c := range res.GetCards() {
+		card := secreport.CardExpiry{Name: c.GetName(), ExpireDate: c.GetExpireDate(), Expired: c.GetExpired()}
+		if c.GetExpires() != nil {
+			card.Expires = c.GetExpires().AsTime()
+		}
+		r.Cards = append(r.Cards, card)
+	}
+
+	return &r
+}
+
+func printReport(r *secreport.Report) {
+	fmt.Printf("\nSecurity report: %d passwords, %d cards checked\n", r.CheckedPasswords, r.CheckedCards)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() {
+		_ = w.Flush()
+	}()
+
+	section := func(title string, count int, header string) bool {
+		_, _ = fmt.Fprintf(w, "\n%s (%d)\n", title, count)
+		if count == 0 {
+			_, _ = fmt.Fprintln(w, "   none")
+			return false
+		}
+		_, _ = fmt.Fprintln(w, header)
+		return true
+	}
+
+	if section("Reused passwords", len(r.Reused), "   GROUP\tSECRETS") {
+		for i, names := range r.Reused {
+			_, _ = fmt.Fprintf(w, "   %d\t%s\n", i+1, strings.Join(names, ", "))
+		}
+	}
+	if section("Weak passwords", len(r.Weak), "   SECRET\tSCORE\tWARNINGS") {
+		for _, weak := range r.Weak {
+			_, _ = fmt.Fprintf(w, "   %s\t%d/%d\t%s\n", weak.Name, weak.Score, passpolicy.MaxScore,
+				strings.Join(weak.Warnings, ", "))
+		}
+	}
+	if section("Breached passwords", len(r.Breached), "   SECRET\tSEEN") {
+		for _, b := range r.Breached {
+			_, _ = fmt.Fprintf(w, "   %s\t%d times\n", b.Name, b.Count)
+		}
+	}
+	if section("Old passwords", len(r.Stale), "   SECRET\tUPDATED\tAGE") {
+		for _, s := range r.Stale {
+			_, _ = fmt.Fprintf(w, "   %s\t%s\t%d days\n", s.Name, s.UpdatedAt.Local().Format(time.DateOnly), s.AgeDays)
+		}
+	}
+	if section("Plain HTTP URLs", len(r.Insecure), "   SECRET\tURL") {
+		for _, i := range r.Insecure {
+			_, _ = fmt.Fprintf(w, "   %s\t%s\n", i.Name, i.URL)
+		}
+	}
+	if section("Expired or expiring cards", len(r.Cards), "   SECRET\tEXPIRE DATE\tSTATUS") {
+		for _, c := range r.Cards {
+			state := "expires soon"
+			switch {
+			case c.Expires.IsZero():
+				state = "unknown expire date format"
+			case c.Expired:
+				state = "expired"
+			}
+			_, _ = fmt.Fprintf(w, "   %s\t%s\t%s\n", c.Name, c.ExpireDate, state)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// refreshToken токен обновления текущей сессии.
+var refreshToken string
+
+// refreshInterceptor при истекшем токене доступа обновляет пару токенов и повторяет запрос один раз.
+func refreshInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if status.Code(err) != codes.Unauthenticated || refreshToken == "" ||
+		method == pb.UserService_RefreshToken_FullMethodName {
+		return err
+	}
+
+	res, refreshErr := userClient.RefreshToken(context.Background(), &pb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	})
+	if refreshErr != nil {
+		fmt.Println("\nSession expired, please login again")
+		clearSession()
+		return err
+	}
+
+	token = res.GetAccessToken()
+	refreshToken = res.GetRefreshToken()
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set("authorization", token)
+
+	return invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+}
+
+func logoutUser() {
+	if _, err := userClient.Logout(withToken(context.Background()), &emptypb.Empty{}); err != nil {
+		fmt.Printf("Warning: failed to end session on server: %v\n", err)
+	}
+
+	clearSession()
+	fmt.Println("Logged out successfully")
+}
+
+func clearSession() {
+	token = ""
+	refreshToken = ""
+	vaultKey = nil
+	sharePrivateKey = nil
+	currentLogin = ""
+}
+
+func manageSessions() {
+	res, err := userClient.ListSessions(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to list sessions: %v\n", err)
+		return
+	}
+
+	fmt.Println("\nActive sessions:")
+	for i, s := range res.GetSessions() {
+		current := ""
+		if s.GetCurrent() {
+			current = " (current)"
+		}
+		fmt.Printf("\n%d. %s%s\n", i+1, s.GetId(), current)
+		fmt.Printf("   Device: %s, IP: %s\n", s.GetUserAgent(), s.GetIp())
+		fmt.Printf("   Last used: %s\n", s.GetLastUsedAt().AsTime().Local().Format(time.DateTime))
+	}
+
+	fmt.Print("\nEnter session number to revoke (leave empty to go back): ")
+	reader := bufio.NewReader(os.Stdin)
+	choice, _ := reader.ReadString('\n')
+	choice = strings.TrimSpace(choice)
+	if choice == "" {
+		return
+	}
+
+	var n int
+	if _, err = fmt.Sscan(choice, &n); err != nil || n < 1 || n > len(res.GetSessions()) {
+		fmt.Println("Invalid session number")
+		return
+	}
+
+	s := res.GetSessions()[n-1]
+	_, err = userClient.RevokeSession(
+		withToken(context.Background()),
+		&pb.RevokeSessionRequest{SessionId: s.GetId()},
+	)
+	if err != nil {
+		fmt.Printf("Failed to revoke session: %v\n", err)
+		return
+	}
+
+	if s.GetCurrent() {
+		clearSession()
+	}
+	fmt.Println("Session revoked")
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// sharePrivateKey закрытый ключ X25519 E2E аккаунта для чтения чужих секретов E2E, живет только в памяти клиента.
+var sharePrivateKey []byte
+
+// unlockShareKeys расшифровка закрытого ключа после входа в E2E аккаунт. Если пары ключей еще нет,
+// она создается и сохраняется на сервере, чтобы другие пользователи могли открывать аккаунту свои секреты.
+func unlockShareKeys(res *pb.LoginUserResponse) error {
+	if len(res.GetPublicKey()) != 0 {
+		key, err := encryptor.UnwrapKey(res.GetWrappedPrivateKey(), vaultKey)
+		if err != nil {
+			return fmt.Errorf("failed to unwrap share key: %w", err)
+		}
+		sharePrivateKey = key
+		return nil
+	}
+
+	priv, pub, err := encryptor.NewShareKeyPair()
+	if err != nil {
+		return fmt.Errorf("failed to generate share keys: %w", err)
+	}
+
+	wrapped, err := encryptor.WrapKey(priv, vaultKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap share key: %w", err)
+	}
+
+	_, err = userClient.SetShareKeys(withToken(context.Background()), &pb.SetShareKeysRequest{
+		PublicKey:         pub,
+		WrappedPrivateKey: wrapped,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save share keys: %w", err)
+	}
+	sharePrivateKey = priv
+
+	return nil
+}
+
+func manageShares() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\n1. Share a secret")
+	fmt.Println("2. Secrets shared with me")
+	fmt.Println("3. My shares")
+	fmt.Print("Select an option: ")
+	option, _ := reader.ReadString('\n')
+
+	switch strings.TrimSpace(option) {
+	case "1":
+		shareSecret(reader)
+	case "2":
+		sharedWithMe()
+	case "3":
+		myShares(reader)
+	default:
+		fmt.Println("Invalid option")
+	}
+}
+
+func shareSecret(reader *bufio.Reader) {
+	ctx := withToken(context.Background())
+
+	secret, ok := selectOwnSecret(reader)
+	if !ok {
+		return
+	}
+
+	fmt.Print("Recipient login: ")
+	recipient, _ := reader.ReadString('\n')
+	recipient = strings.TrimSpace(recipient)
+
+	req := &pb.ShareSecretRequest{
+		SecretId:       secret.GetId(),
+		RecipientLogin: recipient,
+		Permission:     pb.SharePermission_SHARE_PERMISSION_READ,
+	}
+
+	var err error
+	if secret.GetClientEncrypted() {
+		if req.WrappedKey, req.Payload, err = sealForRecipient(ctx, secret, recipient); err != nil {
+			fmt.Printf("Failed to share secret: %v\n", err)
+			return
+		}
+	}
+
+	shareRes, err := secretClient.ShareSecret(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to share secret: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Secret shared with %s. Share ID: %d\n", recipient, shareRes.GetShareId())
+}
+
+// selectOwnSecret выбор собственного секрета по названию вместе с данными.
+func selectOwnSecret(reader *bufio.Reader) (*pb.GetSecret, bool) {
+	fmt.Print("Enter secret name: ")
+	name, _ := reader.ReadString('\n')
+	name = strings.TrimSpace(name)
+
+	res, err := secretClient.GetSecret(withToken(context.Background()), &pb.GetSecretRequest{Name: &name})
+	if err != nil {
+		fmt.Printf("Failed to get secret: %v\n", err)
+		return nil, false
+	}
+
+	own := make([]*pb.GetSecret, 0, len(res.GetSecrets()))
+	for _, s := range res.GetSecrets() {
+		if s.GetShare() == nil {
+			own = append(own, s)
+		}
+	}
+	if len(own) == 0 {
+		fmt.Println("No own secrets with this name found")
+		return nil, false
+	}
+
+	if len(own) == 1 {
+		return own[0], true
+	}
+
+	for i, s := range own {
+		fmt.Printf("%d. ID: %d, Type: %s, Updated: %s\n",
+			i+1, s.GetId(), s.GetType().String(), s.GetUpdatedAt().AsTime().Format("2006-01-02 15:04"))
+	}
+	fmt.Print("Select a secret: ")
+	choice, _ := reader.ReadString('\n')
+	i, err := strconv.Atoi(strings.TrimSpace(choice))
+	if err != nil || i < 1 || i > len(own) {
+		fmt.Println("Invalid option")
+		return nil, false
+	}
+
+	return own[i-1], true
+}
+
+// sealForRecipient перешифровка секрета E2E для получателя: данные шифруются случайным ключом доступа,
+// а ключ доступа открытым ключом получателя. Сервер не может прочитать ни то, ни другое.
+func sealForRecipient(ctx context.Context, secret *pb.GetSecret, recipient string) ([]byte, []byte, error) {
+	if vaultKey == nil {
+		return nil, nil, errors.New("vault is locked")
+	}
+
+	keyRes, err := userClient.GetPublicKey(ctx, &pb.GetPublicKeyRequest{Login: recipient})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get recipient public key: %w", err)
+	}
+	if len(keyRes.GetPublicKey()) == 0 {
+		return nil, nil, errors.New("recipient has no share keys: only end-to-end encrypted accounts " +
+			"that have logged in at least once can receive end-to-end encrypted secrets")
+	}
+
+	openSecretData(secret)
+	plain, err := proto.Marshal(&pb.GetSecret{Name: secret.GetName(), Type: secret.GetType(), Data: secret.GetData()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal secret: %w", err)
+	}
+
+	shareKey, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate share key: %w", err)
+	}
+
+	payload, err := encryptor.EncryptWithMasterKey(plain, shareKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	wrapped, err := encryptor.SealToPublicKey(shareKey, keyRes.GetPublicKey())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap share key: %w", err)
+	}
+
+	return wrapped, []byte(payload), nil
+}
+
+// openSharedSecret расшифровка данных чужого секрета E2E ключом доступа из Share.
+func openSharedSecret(secret *pb.GetSecret) error {
+	if sharePrivateKey == nil {
+		return errors.New("share keys are locked")
+	}
+
+	shareKey, err := encryptor.OpenWithPrivateKey(secret.GetShare().GetWrappedKey(), sharePrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap share key: %w", err)
+	}
+
+	plain, err := encryptor.DecryptWithMasterKey(secret.GetShare().GetPayload(), shareKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+
+	var shared pb.GetSecret
+	if err = proto.Unmarshal([]byte(plain), &shared); err != nil {
+		return fmt.Errorf("failed to unmarshal secret: %w", err)
+	}
+	secret.Data = shared.GetData()
+
+	return nil
+}
+
+func sharedWithMe() {
+	res, err := secretClient.ListSharedWithMe(withToken(context.Background()), &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get shared secrets: %v\n", err)
+		return
+	}
+
+	if len(res.GetSecrets()) == 0 {
+		fmt.Println("No secrets shared with you")
+		return
+	}
+
+	printSecrets(res.GetSecrets())
+}
+
+func myShares(reader *bufio.Reader) {
+	ctx := withToken(context.Background())
+
+	res, err := secretClient.ListMyShares(ctx, &emptypb.Empty{})
+	if err != nil {
+		fmt.Printf("Failed to get shares: %v\n", err)
+		return
+	}
+
+	if len(res.GetShares()) == 0 {
+		fmt.Println("You have not shared any secrets")
+		return
+	}
+
+	fmt.Println("\nShares:")
+	for _, sh := range res.GetShares() {
+		fmt.Printf("ID: %d, Secret: %s (%s), Recipient: %s, Permission: %s, Since: %s\n",
+			sh.GetId(), sh.GetSecretName(), sh.GetType().String(), sh.GetRecipientLogin(),
+			permissionName(sh.GetPermission()), sh.GetCreatedAt().AsTime().Format("2006-01-02 15:04"))
+	}
+
+	fmt.Print("Enter share ID to revoke (leave empty to go back): ")
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return
+	}
+
+	id, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		fmt.Println("Invalid share ID")
+		return
+	}
+
+	if _, err = secretClient.RevokeShare(ctx, &pb.RevokeShareRequest{ShareId: id}); err != nil {
+		fmt.Printf("Failed to revoke share: %v\n", err)
+		return
+	}
+
+	fmt.Println("Share revoked")
+}
+
+func permissionName(p pb.SharePermission) string {
+	if p == pb.SharePermission_SHARE_PERMISSION_READ {
+		return "read"
+	}
+
+	return p.String()
+}
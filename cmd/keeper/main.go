@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os/signal"
@@ -11,6 +12,10 @@ import (
 
 	application "github.com/Melikhov-p/goph-keeper/internal/app"
 	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/kms"
+	"github.com/Melikhov-p/goph-keeper/internal/logger"
+	"github.com/Melikhov-p/goph-keeper/internal/seal"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -19,6 +24,9 @@ const (
 	timeoutShutdown = time.Second * 10
 )
 
+// rotateKEK флаг разбирается вместе с --config в config.Load.
+var rotateKEK = flag.Bool("rotate-kek", false, "rotate key provider key, rewrap server and user data keys and exit")
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Println("work is stopped")
@@ -28,11 +36,12 @@ func main() {
 
 func run() error {
 	var (
-		app *application.App
-		cfg *config.Config
-		eg  *errgroup.Group
-		ctx context.Context
-		err error
+		cfg      *config.Config
+		barrier  *seal.Barrier
+		sealed   <-chan struct{}
+		unseal   *securemem.Buffer
+		provider kms.KeyProvider
+		err      error
 	)
 
 	cfg, err = config.Load()
@@ -40,45 +49,115 @@ func run() error {
 		return fmt.Errorf("failed to get config -> %w", err)
 	}
 
+	if *rotateKEK {
+		return rotateProviderKey(cfg)
+	}
+
 	rootCtx, cancelCtx := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
 	defer cancelCtx()
 
-	eg, ctx = errgroup.WithContext(rootCtx)
 	// нештатное завершение программы по таймауту
 	// происходит, если после завершения контекста
 	// приложение не смогло завершиться за отведенный промежуток времени
-	context.AfterFunc(ctx, func() {
-		ctx, cancelCtx = context.WithTimeout(context.Background(), timeoutShutdown)
-		defer cancelCtx()
+	context.AfterFunc(rootCtx, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeoutShutdown)
+		defer cancel()
 
 		<-ctx.Done()
 		log.Fatal("failed to gracefully shutdown the service")
 	})
 
-	app, err = application.New(cfg)
+	if cfg.Security.KeyProvider.Type == kms.ProviderShamir {
+		barrier, err = newBarrier(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to get seal barrier %w", err)
+		}
+	}
+
+	// Запечатанный сервер ждет долей ключа, распечатанный работает до сигнала или до запечатывания
+	for {
+		if barrier != nil {
+			unseal, sealed, err = waitUnseal(rootCtx, cfg, barrier)
+			if rootCtx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if provider, err = kms.NewShamirProvider(unseal.Bytes()); err != nil {
+				return fmt.Errorf("failed to get key provider %w", err)
+			}
+		}
+
+		err = serve(rootCtx, cfg, barrier, provider, sealed)
+		if barrier == nil || err != nil || rootCtx.Err() != nil {
+			return err
+		}
+
+		unseal.Wipe()
+		unseal, provider = nil, nil
+	}
+}
+
+// serve запуск приложения до завершения контекста или запечатывания сервера.
+func serve(
+	rootCtx context.Context,
+	cfg *config.Config,
+	barrier *seal.Barrier,
+	provider kms.KeyProvider,
+	sealed <-chan struct{},
+) error {
+	app, err := application.New(cfg, barrier, provider)
 	if err != nil {
 		return fmt.Errorf("failed to get app %w", err)
 	}
+	defer func() {
+		_ = app.Close()
+	}()
 
 	app.Log.Debug("app initialize with config", zap.Any("config", app.Cfg))
 
+	eg, ctx := errgroup.WithContext(rootCtx)
+
 	eg.Go(func() error {
-		err = app.RunGRPC()
-		if err != nil {
+		if err := app.RunGRPC(); err != nil {
 			return fmt.Errorf("error in gRPC server %w", err)
 		}
 
 		return nil
 	})
 
+	eg.Go(func() error {
+		if err := app.RunHTTP(); err != nil {
+			return fmt.Errorf("error in HTTP server %w", err)
+		}
+
+		return nil
+	})
+
+	purgeCtx, stopPurge := context.WithCancel(ctx)
+	eg.Go(func() error {
+		app.RunPurge(purgeCtx)
+
+		return nil
+	})
+
 	log.Println("server podnyalsya")
 
 	eg.Go(func() error {
-		<-ctx.Done()
+		select {
+		case <-ctx.Done():
+		case <-sealed:
+			log.Println("server sealed")
+		}
 
+		stopPurge()
 		app.StopGRPC()
 
-		return nil
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeoutShutdown)
+		defer cancel()
+
+		return app.StopHTTP(shutdownCtx)
 	})
 
 	if err = eg.Wait(); err != nil {
@@ -87,3 +166,56 @@ func run() error {
 
 	return nil
 }
+
+// newBarrier получение барьера для ключа, разделенного на доли Шамира.
+func newBarrier(cfg *config.Config) (*seal.Barrier, error) {
+	path := cfg.Security.KeyProvider.KeyringPath
+
+	shares, threshold, err := kms.ShamirParams(path)
+	if err != nil {
+		return nil, fmt.Errorf("keyring is not initialized, run keeperctl init: %w", err)
+	}
+
+	return seal.NewBarrier(shares, threshold, func(ctx context.Context, parts [][]byte) ([]byte, error) {
+		return kms.UnsealKey(ctx, path, parts)
+	}), nil
+}
+
+// waitUnseal запуск запечатанного сервера до распечатывания.
+func waitUnseal(
+	ctx context.Context,
+	cfg *config.Config,
+	barrier *seal.Barrier,
+) (*securemem.Buffer, <-chan struct{}, error) {
+	l, err := logger.BuildLogger(cfg.Logging.Level)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get logger %w", err)
+	}
+
+	key, sealed, err := application.WaitUnseal(ctx, cfg, barrier, l)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unseal %w", err)
+	}
+
+	return securemem.New(key), sealed, nil
+}
+
+// rotateProviderKey ротация ключа провайдера и переоборачивание ключей сервера и ключей данных пользователей
+// без запуска сервера.
+func rotateProviderKey(cfg *config.Config) error {
+	app, err := application.New(cfg, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get app %w", err)
+	}
+	defer func() {
+		_ = app.Close()
+	}()
+
+	if err = app.RotateKEK(context.Background()); err != nil {
+		return fmt.Errorf("failed to rotate key provider key %w", err)
+	}
+
+	log.Println("key provider key rotated, server and user data keys rewrapped")
+
+	return nil
+}
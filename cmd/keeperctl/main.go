@@ -0,0 +1,351 @@
+// main package of operator cli for keeper server.
+//
+// Команды:
+//
+//	keeperctl init -keyring path [-shares 5] [-threshold 3]   разделение нового ключа распечатывания на доли
+//	keeperctl status [-addr host:port]                         состояние сервера
+//	keeperctl unseal [-addr host:port] [-reset] [share]        передача доли (без аргумента читается из stdin)
+//	keeperctl seal [-addr host:port]                           запечатывание сервера, нужен GK_OPERATOR_TOKEN
+//	keeperctl jwt-keygen -out path [-alg EdDSA|ES256]          генерация ключа подписи токенов доступа
+//	keeperctl lockouts [-addr host:port]                       заблокированные логины и адреса
+//	keeperctl unlock [-addr host:port] key                     снятие блокировки, например unlock login:john
+//	keeperctl hash-report [-addr host:port]                    число аккаунтов по схемам хэширования паролей
+//	keeperctl invite [-addr host:port] [-ttl 168h] [-note s]   приглашение на регистрацию
+//	keeperctl users [-addr host:port]                          список пользователей
+//	keeperctl disable|enable|logout [-addr host:port] user-id  блокировка, разблокировка, отзыв всех сессий
+//	keeperctl set-admin [-addr host:port] [-revoke] user-id    выдача или снятие прав администратора
+//	keeperctl breach-import -src path -out path                построение индекса утечек из выгрузки HIBP
+//
+// Административные команды авторизуются токеном оператора из GK_OPERATOR_TOKEN
+// или токеном доступа администратора из GK_ACCESS_TOKEN.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/auth"
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
+	"github.com/Melikhov-p/goph-keeper/internal/kms"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	defaultAddress = "localhost:50051"
+	requestTimeout = 10 * time.Second
+	masterKeyLen   = 32
+	privateKeyPerm = 0o600
+	publicKeyPerm  = 0o644
+)
+
+var errUsage = errors.New("usage: keeperctl init|status|unseal|seal|jwt-keygen|lockouts|unlock|hash-report|invite|users|disable|enable|logout|set-admin|breach-import [flags]")
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "init":
+		return initShares(args[1:])
+	case "status", "unseal", "seal":
+		return callSystem(args[0], args[1:])
+	case "jwt-keygen":
+		return generateJWTKey(args[1:])
+	case "lockouts", "unlock", "hash-report", "invite", "users", "disable", "enable", "logout", "set-admin":
+		return callAdmin(args[0], args[1:])
+	case "breach-import":
+		return importBreaches(args[1:])
+	default:
+		return errUsage
+	}
+}
+
+// initShares генерация ключа распечатывания и вывод долей. Выполняется на остановленном сервере.
+func initShares(args []string) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	keyringPath := fs.String("keyring", "", "path to server keyring file")
+	shares := fs.Int("shares", 5, "number of key shares")
+	threshold := fs.Int("threshold", 3, "number of shares required to unseal")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyringPath == "" {
+		return errors.New("keyring path is required")
+	}
+
+	// Ключ провайдера local нужен, чтобы перенести ключи, уже обернутые этим провайдером
+	var legacyKey []byte
+	if keyHex := os.Getenv("GK_MASTER_KEY"); keyHex != "" {
+		key, err := hex.DecodeString(keyHex)
+		if err != nil || len(key) != masterKeyLen {
+			return errors.New("GK_MASTER_KEY must be 32 bytes in hex")
+		}
+		legacyKey = key
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	parts, err := kms.InitShamir(ctx, *keyringPath, *shares, *threshold, legacyKey)
+	if err != nil {
+		return fmt.Errorf("failed to init shares: %w", err)
+	}
+
+	fmt.Printf("Unseal key split into %d shares, %d required to unseal.\n", *shares, *threshold)
+	fmt.Println("Give each share to a different operator, they are not stored anywhere.")
+	for i, p := range parts {
+		fmt.Printf("Share %d: %s\n", i+1, hex.EncodeToString(p))
+	}
+
+	return nil
+}
+
+// generateJWTKey генерация ключа подписи токенов: закрытый ключ в out, открытый в out.pub.
+func generateJWTKey(args []string) error {
+	fs := flag.NewFlagSet("jwt-keygen", flag.ContinueOnError)
+	out := fs.String("out", "", "path to write private key PEM")
+	alg := fs.String("alg", auth.AlgorithmEdDSA, "signing algorithm: EdDSA or ES256")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("output path is required")
+	}
+
+	key, err := auth.GenerateKey(*alg)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	private, err := key.MarshalPrivatePEM()
+	if err != nil {
+		return err
+	}
+	public, err := key.MarshalPublicPEM()
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(*out, private, privateKeyPerm); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err = os.WriteFile(*out+".pub", public, publicKeyPerm); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	fmt.Printf("%s key written to %s, kid: %s\n", key.Algorithm, *out, key.ID)
+
+	return nil
+}
+
+// importBreaches построение индекса утечек из каталога файлов диапазонов HIBP или одного файла SHA1:COUNT.
+// Выполняется без сети, индекс подключается к серверу через GK_BREACH_INDEX.
+func importBreaches(args []string) error {
+	fs := flag.NewFlagSet("breach-import", flag.ContinueOnError)
+	src := fs.String("src", "", "HIBP range files directory or sorted SHA1:COUNT file")
+	out := fs.String("out", "", "path to write breach index")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *src == "" || *out == "" {
+		return errors.New("source and output paths are required")
+	}
+
+	start := time.Now()
+	n, err := breach.Build(*src, *out)
+	if err != nil {
+		return fmt.Errorf("failed to build breach index: %w", err)
+	}
+
+	fmt.Printf("%d hashes written to %s in %s\n", n, *out, time.Since(start).Round(time.Second))
+
+	return nil
+}
+
+// callSystem вызов административных методов запущенного сервера.
+func callSystem(cmd string, args []string) error {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	addr := fs.String("addr", defaultAddress, "server address")
+	reset := fs.Bool("reset", false, "reset unseal progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	client := pb.NewSystemServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	var res *pb.SealStatusResponse
+
+	switch cmd {
+	case "status":
+		res, err = client.SealStatus(ctx, &emptypb.Empty{})
+	case "seal":
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-operator-token", os.Getenv("GK_OPERATOR_TOKEN"))
+		res, err = client.Seal(ctx, &emptypb.Empty{})
+	case "unseal":
+		req := &pb.UnsealRequest{ResetProgress: *reset}
+		if !*reset {
+			if req.Share, err = readShare(fs.Arg(0)); err != nil {
+				return err
+			}
+		}
+		res, err = client.Unseal(ctx, req)
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", cmd, err)
+	}
+
+	fmt.Printf("Sealed: %t\n", res.GetSealed())
+	fmt.Printf("Shares: %d, threshold: %d\n", res.GetShares(), res.GetThreshold())
+	if res.GetSealed() {
+		fmt.Printf("Unseal progress: %d/%d\n", res.GetProgress(), res.GetThreshold())
+	}
+
+	return nil
+}
+
+// callAdmin вызов методов AdminService с токеном оператора из GK_OPERATOR_TOKEN
+// или токеном доступа администратора из GK_ACCESS_TOKEN.
+func callAdmin(cmd string, args []string) error {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	addr := fs.String("addr", defaultAddress, "server address")
+	var opts userAdminOptions
+	switch cmd {
+	case "invite":
+		fs.DurationVar(&opts.inviteTTL, "ttl", 0, "invite lifetime, server default if zero")
+		fs.StringVar(&opts.note, "note", "", "who the invite is for")
+	case "set-admin":
+		fs.BoolVar(&opts.revoke, "revoke", false, "revoke administrator rights")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if cmd == "unlock" && fs.Arg(0) == "" {
+		return errors.New("lockout key is required, e.g. login:john or ip:10.0.0.1")
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	client := pb.NewAdminServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if token := os.Getenv("GK_ACCESS_TOKEN"); token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	} else {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-operator-token", os.Getenv("GK_OPERATOR_TOKEN"))
+	}
+
+	switch cmd {
+	case "hash-report":
+		return printHashReport(ctx, client)
+	case "invite", "users", "disable", "enable", "logout", "set-admin":
+		return manageUsers(ctx, client, cmd, fs.Arg(0), opts)
+	}
+
+	if cmd == "unlock" {
+		if _, err = client.ClearLockout(ctx, &pb.ClearLockoutRequest{Key: fs.Arg(0)}); err != nil {
+			return fmt.Errorf("unlock failed: %w", err)
+		}
+		fmt.Printf("Lockout %s cleared\n", fs.Arg(0))
+		return nil
+	}
+
+	res, err := client.ListLockouts(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("lockouts failed: %w", err)
+	}
+
+	if len(res.GetLockouts()) == 0 {
+		fmt.Println("No active lockouts")
+		return nil
+	}
+	for _, l := range res.GetLockouts() {
+		kind := "backoff"
+		if l.GetLocked() {
+			kind = "locked"
+		}
+		fmt.Printf("%s\t%s\tfailures: %d\tuntil: %s\n",
+			l.GetKey(), kind, l.GetFailures(), l.GetBlockedUntil().AsTime().Local().Format(time.DateTime))
+	}
+
+	return nil
+}
+
+// printHashReport вывод числа аккаунтов по схемам хэширования и перцам. Старый перец можно убрать из конфига,
+// когда с ним не осталось аккаунтов.
+func printHashReport(ctx context.Context, client pb.AdminServiceClient) error {
+	res, err := client.GetPasswordHashReport(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("hash-report failed: %w", err)
+	}
+
+	for _, c := range res.GetCounts() {
+		pepper := c.GetPepperId()
+		if pepper == "" {
+			pepper = "(security.pepper)"
+		}
+		state := "legacy"
+		if c.GetCurrent() {
+			state = "current"
+		}
+		fmt.Printf("%s\tpepper: %s\tusers: %d\t%s\n", c.GetScheme(), pepper, c.GetUsers(), state)
+	}
+	fmt.Printf("Total: %d, on legacy schemes: %d\n", res.GetTotal(), res.GetLegacy())
+
+	return nil
+}
+
+// readShare разбор доли из аргумента или stdin, чтобы доля не попадала в историю shell.
+func readShare(arg string) ([]byte, error) {
+	if arg == "" {
+		fmt.Print("Unseal share (hex): ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("failed to read share: %w", err)
+		}
+		arg = line
+	}
+
+	share, err := hex.DecodeString(strings.TrimSpace(arg))
+	if err != nil {
+		return nil, fmt.Errorf("share must be hex: %w", err)
+	}
+
+	return share, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// userAdminOptions флаги команд управления пользователями.
+type userAdminOptions struct {
+	inviteTTL time.Duration
+	note      string
+	revoke    bool
+}
+
+// manageUsers команды управления пользователями и приглашениями.
+func manageUsers(ctx context.Context, client pb.AdminServiceClient, cmd, arg string, opts userAdminOptions) error {
+	switch cmd {
+	case "invite":
+		return createInvite(ctx, client, opts)
+	case "users":
+		return listUsers(ctx, client)
+	}
+
+	userID, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil || userID <= 0 {
+		return errors.New("user id is required, see keeperctl users")
+	}
+	req := &pb.AdminUserRequest{UserId: int32(userID)}
+
+	switch cmd {
+	case "disable":
+		res, err := client.DisableUser(ctx, req)
+		if err != nil {
+			return fmt.Errorf("disable failed: %w", err)
+		}
+		fmt.Printf("User %d disabled, %d sessions revoked\n", userID, res.GetRevoked())
+	case "enable":
+		if _, err = client.EnableUser(ctx, req); err != nil {
+			return fmt.Errorf("enable failed: %w", err)
+		}
+		fmt.Printf("User %d enabled\n", userID)
+	case "logout":
+		res, err := client.ForceLogout(ctx, req)
+		if err != nil {
+			return fmt.Errorf("logout failed: %w", err)
+		}
+		fmt.Printf("%d sessions of user %d revoked\n", res.GetRevoked(), userID)
+	case "set-admin":
+		if _, err = client.SetAdmin(ctx, &pb.SetAdminRequest{UserId: int32(userID), Admin: !opts.revoke}); err != nil {
+			return fmt.Errorf("set-admin failed: %w", err)
+		}
+		if opts.revoke {
+			fmt.Printf("Administrator rights of user %d revoked\n", userID)
+		} else {
+			fmt.Printf("User %d is now an administrator\n", userID)
+		}
+	default:
+		return errUsage
+	}
+
+	return nil
+}
+
+func createInvite(ctx context.Context, client pb.AdminServiceClient, opts userAdminOptions) error {
+	hours := math.Ceil(opts.inviteTTL.Hours())
+	if hours < 0 || hours > math.MaxUint32 {
+		return errors.New("invalid invite ttl")
+	}
+
+	res, err := client.CreateInvite(ctx, &pb.CreateInviteRequest{TtlHours: uint32(hours), Note: opts.note})
+	if err != nil {
+		return fmt.Errorf("invite failed: %w", err)
+	}
+
+	fmt.Printf("Invite code: %s\nExpires: %s\n", res.GetCode(), res.GetExpiresAt().AsTime().Local().Format(time.DateTime))
+
+	return nil
+}
+
+func listUsers(ctx context.Context, client pb.AdminServiceClient) error {
+	res, err := client.ListUsers(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("users failed: %w", err)
+	}
+
+	for _, u := range res.GetUsers() {
+		flags := ""
+		if u.GetIsAdmin() {
+			flags += " admin"
+		}
+		if u.GetEncryptionMode() == pb.EncryptionMode_ENCRYPTION_MODE_E2E {
+			flags += " e2e"
+		}
+		if u.GetDisabledAt() != nil {
+			flags += " disabled since " + u.GetDisabledAt().AsTime().Local().Format(time.DateTime)
+		}
+		if u.GetDeleteAfter() != nil {
+			flags += " deleting " + u.GetDeleteAfter().AsTime().Local().Format(time.DateTime)
+		}
+		fmt.Printf("%d\t%s\tcreated: %s%s\n",
+			u.GetId(), u.GetLogin(), u.GetCreatedAt().AsTime().Local().Format(time.DateOnly), flags)
+	}
+
+	return nil
+}
@@ -21,9 +21,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_Register_FullMethodName = "/gophkeeper.v1.UserService/Register"
-	UserService_Login_FullMethodName    = "/gophkeeper.v1.UserService/Login"
-	UserService_Update_FullMethodName   = "/gophkeeper.v1.UserService/Update"
+	UserService_Register_FullMethodName              = "/gophkeeper.v1.UserService/Register"
+	UserService_Login_FullMethodName                 = "/gophkeeper.v1.UserService/Login"
+	UserService_UpdateCredentials_FullMethodName     = "/gophkeeper.v1.UserService/UpdateCredentials"
+	UserService_GetKDFParams_FullMethodName          = "/gophkeeper.v1.UserService/GetKDFParams"
+	UserService_GetPasswordPolicy_FullMethodName     = "/gophkeeper.v1.UserService/GetPasswordPolicy"
+	UserService_RefreshToken_FullMethodName          = "/gophkeeper.v1.UserService/RefreshToken"
+	UserService_Logout_FullMethodName                = "/gophkeeper.v1.UserService/Logout"
+	UserService_ListSessions_FullMethodName          = "/gophkeeper.v1.UserService/ListSessions"
+	UserService_RevokeSession_FullMethodName         = "/gophkeeper.v1.UserService/RevokeSession"
+	UserService_EnrollTOTP_FullMethodName            = "/gophkeeper.v1.UserService/EnrollTOTP"
+	UserService_ConfirmTOTP_FullMethodName           = "/gophkeeper.v1.UserService/ConfirmTOTP"
+	UserService_VerifyMFA_FullMethodName             = "/gophkeeper.v1.UserService/VerifyMFA"
+	UserService_DeleteAccount_FullMethodName         = "/gophkeeper.v1.UserService/DeleteAccount"
+	UserService_CancelAccountDeletion_FullMethodName = "/gophkeeper.v1.UserService/CancelAccountDeletion"
+	UserService_SetShareKeys_FullMethodName          = "/gophkeeper.v1.UserService/SetShareKeys"
+	UserService_GetPublicKey_FullMethodName          = "/gophkeeper.v1.UserService/GetPublicKey"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -32,7 +45,20 @@ const (
 type UserServiceClient interface {
 	Register(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
 	Login(ctx context.Context, in *LoginUserRequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
-	Update(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateCredentials(ctx context.Context, in *UpdateCredentialsRequest, opts ...grpc.CallOption) (*UpdateCredentialsResponse, error)
+	GetKDFParams(ctx context.Context, in *GetKDFParamsRequest, opts ...grpc.CallOption) (*GetKDFParamsResponse, error)
+	GetPasswordPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasswordPolicy, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EnrollTOTP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error)
+	VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*LoginUserResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	CancelAccountDeletion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetShareKeys(ctx context.Context, in *SetShareKeysRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
 }
 
 type userServiceClient struct {
@@ -63,137 +89,3010 @@ func (c *userServiceClient) Login(ctx context.Context, in *LoginUserRequest, opt
 	return out, nil
 }
 
-func (c *userServiceClient) Update(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *userServiceClient) UpdateCredentials(ctx context.Context, in *UpdateCredentialsRequest, opts ...grpc.CallOption) (*UpdateCredentialsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCredentialsResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateCredentials_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetKDFParams(ctx context.Context, in *GetKDFParamsRequest, opts ...grpc.CallOption) (*GetKDFParamsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKDFParamsResponse)
+	err := c.cc.Invoke(ctx, UserService_GetKDFParams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPasswordPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasswordPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasswordPolicy)
+	err := c.cc.Invoke(ctx, UserService_GetPasswordPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EnrollTOTP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EnrollTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*ConfirmTOTPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTOTPResponse)
+	err := c.cc.Invoke(ctx, UserService_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*LoginUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginUserResponse)
+	err := c.cc.Invoke(ctx, UserService_VerifyMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CancelAccountDeletion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_CancelAccountDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetShareKeys(ctx context.Context, in *SetShareKeysRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_SetShareKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility.
+type UserServiceServer interface {
+	Register(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
+	Login(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
+	UpdateCredentials(context.Context, *UpdateCredentialsRequest) (*UpdateCredentialsResponse, error)
+	GetKDFParams(context.Context, *GetKDFParamsRequest) (*GetKDFParamsResponse, error)
+	GetPasswordPolicy(context.Context, *emptypb.Empty) (*PasswordPolicy, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	ListSessions(context.Context, *emptypb.Empty) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*emptypb.Empty, error)
+	EnrollTOTP(context.Context, *emptypb.Empty) (*EnrollTOTPResponse, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error)
+	VerifyMFA(context.Context, *VerifyMFARequest) (*LoginUserResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	CancelAccountDeletion(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	SetShareKeys(context.Context, *SetShareKeysRequest) (*emptypb.Empty, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) Register(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateCredentials(context.Context, *UpdateCredentialsRequest) (*UpdateCredentialsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredentials not implemented")
+}
+func (UnimplementedUserServiceServer) GetKDFParams(context.Context, *GetKDFParamsRequest) (*GetKDFParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKDFParams not implemented")
+}
+func (UnimplementedUserServiceServer) GetPasswordPolicy(context.Context, *emptypb.Empty) (*PasswordPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasswordPolicy not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedUserServiceServer) ListSessions(context.Context, *emptypb.Empty) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedUserServiceServer) EnrollTOTP(context.Context, *emptypb.Empty) (*EnrollTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*ConfirmTOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyMFA(context.Context, *VerifyMFARequest) (*LoginUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyMFA not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedUserServiceServer) CancelAccountDeletion(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAccountDeletion not implemented")
+}
+func (UnimplementedUserServiceServer) SetShareKeys(context.Context, *SetShareKeysRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShareKeys not implemented")
+}
+func (UnimplementedUserServiceServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateCredentials(ctx, req.(*UpdateCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetKDFParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKDFParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetKDFParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetKDFParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetKDFParams(ctx, req.(*GetKDFParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPasswordPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPasswordPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPasswordPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPasswordPolicy(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnrollTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_EnrollTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnrollTOTP(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConfirmTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_VerifyMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyMFA(ctx, req.(*VerifyMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CancelAccountDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CancelAccountDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CancelAccountDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CancelAccountDeletion(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetShareKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetShareKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetShareKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetShareKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetShareKeys(ctx, req.(*SetShareKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "UpdateCredentials",
+			Handler:    _UserService_UpdateCredentials_Handler,
+		},
+		{
+			MethodName: "GetKDFParams",
+			Handler:    _UserService_GetKDFParams_Handler,
+		},
+		{
+			MethodName: "GetPasswordPolicy",
+			Handler:    _UserService_GetPasswordPolicy_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _UserService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _UserService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _UserService_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _UserService_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "VerifyMFA",
+			Handler:    _UserService_VerifyMFA_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _UserService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "CancelAccountDeletion",
+			Handler:    _UserService_CancelAccountDeletion_Handler,
+		},
+		{
+			MethodName: "SetShareKeys",
+			Handler:    _UserService_SetShareKeys_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _UserService_GetPublicKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	SystemService_Unseal_FullMethodName     = "/gophkeeper.v1.SystemService/Unseal"
+	SystemService_Seal_FullMethodName       = "/gophkeeper.v1.SystemService/Seal"
+	SystemService_SealStatus_FullMethodName = "/gophkeeper.v1.SystemService/SealStatus"
+)
+
+// SystemServiceClient is the client API for SystemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SystemServiceClient interface {
+	Unseal(ctx context.Context, in *UnsealRequest, opts ...grpc.CallOption) (*SealStatusResponse, error)
+	Seal(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealStatusResponse, error)
+	SealStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealStatusResponse, error)
+}
+
+type systemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSystemServiceClient(cc grpc.ClientConnInterface) SystemServiceClient {
+	return &systemServiceClient{cc}
+}
+
+func (c *systemServiceClient) Unseal(ctx context.Context, in *UnsealRequest, opts ...grpc.CallOption) (*SealStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SealStatusResponse)
+	err := c.cc.Invoke(ctx, SystemService_Unseal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) Seal(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SealStatusResponse)
+	err := c.cc.Invoke(ctx, SystemService_Seal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemServiceClient) SealStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SealStatusResponse)
+	err := c.cc.Invoke(ctx, SystemService_SealStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SystemServiceServer is the server API for SystemService service.
+// All implementations must embed UnimplementedSystemServiceServer
+// for forward compatibility.
+type SystemServiceServer interface {
+	Unseal(context.Context, *UnsealRequest) (*SealStatusResponse, error)
+	Seal(context.Context, *emptypb.Empty) (*SealStatusResponse, error)
+	SealStatus(context.Context, *emptypb.Empty) (*SealStatusResponse, error)
+	mustEmbedUnimplementedSystemServiceServer()
+}
+
+// UnimplementedSystemServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSystemServiceServer struct{}
+
+func (UnimplementedSystemServiceServer) Unseal(context.Context, *UnsealRequest) (*SealStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unseal not implemented")
+}
+func (UnimplementedSystemServiceServer) Seal(context.Context, *emptypb.Empty) (*SealStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Seal not implemented")
+}
+func (UnimplementedSystemServiceServer) SealStatus(context.Context, *emptypb.Empty) (*SealStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SealStatus not implemented")
+}
+func (UnimplementedSystemServiceServer) mustEmbedUnimplementedSystemServiceServer() {}
+func (UnimplementedSystemServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSystemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SystemServiceServer will
+// result in compilation errors.
+type UnsafeSystemServiceServer interface {
+	mustEmbedUnimplementedSystemServiceServer()
+}
+
+func RegisterSystemServiceServer(s grpc.ServiceRegistrar, srv SystemServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSystemServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SystemService_ServiceDesc, srv)
+}
+
+func _SystemService_Unseal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).Unseal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_Unseal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).Unseal(ctx, req.(*UnsealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_Seal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).Seal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_Seal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).Seal(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SystemService_SealStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServiceServer).SealStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SystemService_SealStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServiceServer).SealStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SystemService_ServiceDesc is the grpc.ServiceDesc for SystemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SystemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.SystemService",
+	HandlerType: (*SystemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Unseal",
+			Handler:    _SystemService_Unseal_Handler,
+		},
+		{
+			MethodName: "Seal",
+			Handler:    _SystemService_Seal_Handler,
+		},
+		{
+			MethodName: "SealStatus",
+			Handler:    _SystemService_SealStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	AdminService_ListLockouts_FullMethodName          = "/gophkeeper.v1.AdminService/ListLockouts"
+	AdminService_ClearLockout_FullMethodName          = "/gophkeeper.v1.AdminService/ClearLockout"
+	AdminService_GetPasswordHashReport_FullMethodName = "/gophkeeper.v1.AdminService/GetPasswordHashReport"
+	AdminService_CreateInvite_FullMethodName          = "/gophkeeper.v1.AdminService/CreateInvite"
+	AdminService_ListUsers_FullMethodName             = "/gophkeeper.v1.AdminService/ListUsers"
+	AdminService_DisableUser_FullMethodName           = "/gophkeeper.v1.AdminService/DisableUser"
+	AdminService_EnableUser_FullMethodName            = "/gophkeeper.v1.AdminService/EnableUser"
+	AdminService_ForceLogout_FullMethodName           = "/gophkeeper.v1.AdminService/ForceLogout"
+	AdminService_SetAdmin_FullMethodName              = "/gophkeeper.v1.AdminService/SetAdmin"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Административные методы, требуют токен оператора в заголовке x-operator-token.
+type AdminServiceClient interface {
+	ListLockouts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLockoutsResponse, error)
+	ClearLockout(ctx context.Context, in *ClearLockoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetPasswordHashReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasswordHashReport, error)
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error)
+	ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	DisableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*ForceLogoutResponse, error)
+	EnableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ForceLogout(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*ForceLogoutResponse, error)
+	SetAdmin(ctx context.Context, in *SetAdminRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListLockouts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListLockoutsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLockoutsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListLockouts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClearLockout(ctx context.Context, in *ClearLockoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_ClearLockout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetPasswordHashReport(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PasswordHashReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PasswordHashReport)
+	err := c.cc.Invoke(ctx, AdminService_GetPasswordHashReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInviteResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*ForceLogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceLogoutResponse)
+	err := c.cc.Invoke(ctx, AdminService_DisableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EnableUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_EnableUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ForceLogout(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*ForceLogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceLogoutResponse)
+	err := c.cc.Invoke(ctx, AdminService_ForceLogout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetAdmin(ctx context.Context, in *SetAdminRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_SetAdmin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// Административные методы, требуют токен оператора в заголовке x-operator-token.
+type AdminServiceServer interface {
+	ListLockouts(context.Context, *emptypb.Empty) (*ListLockoutsResponse, error)
+	ClearLockout(context.Context, *ClearLockoutRequest) (*emptypb.Empty, error)
+	GetPasswordHashReport(context.Context, *emptypb.Empty) (*PasswordHashReport, error)
+	CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error)
+	ListUsers(context.Context, *emptypb.Empty) (*ListUsersResponse, error)
+	DisableUser(context.Context, *AdminUserRequest) (*ForceLogoutResponse, error)
+	EnableUser(context.Context, *AdminUserRequest) (*emptypb.Empty, error)
+	ForceLogout(context.Context, *AdminUserRequest) (*ForceLogoutResponse, error)
+	SetAdmin(context.Context, *SetAdminRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ListLockouts(context.Context, *emptypb.Empty) (*ListLockoutsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLockouts not implemented")
+}
+func (UnimplementedAdminServiceServer) ClearLockout(context.Context, *ClearLockoutRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearLockout not implemented")
+}
+func (UnimplementedAdminServiceServer) GetPasswordHashReport(context.Context, *emptypb.Empty) (*PasswordHashReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasswordHashReport not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedAdminServiceServer) ListUsers(context.Context, *emptypb.Empty) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAdminServiceServer) DisableUser(context.Context, *AdminUserRequest) (*ForceLogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableUser not implemented")
+}
+func (UnimplementedAdminServiceServer) EnableUser(context.Context, *AdminUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableUser not implemented")
+}
+func (UnimplementedAdminServiceServer) ForceLogout(context.Context, *AdminUserRequest) (*ForceLogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceLogout not implemented")
+}
+func (UnimplementedAdminServiceServer) SetAdmin(context.Context, *SetAdminRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAdmin not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListLockouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListLockouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListLockouts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListLockouts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClearLockout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearLockoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClearLockout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ClearLockout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClearLockout(ctx, req.(*ClearLockoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetPasswordHashReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPasswordHashReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetPasswordHashReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPasswordHashReport(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUsers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DisableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisableUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EnableUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EnableUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_EnableUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EnableUser(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ForceLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ForceLogout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ForceLogout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ForceLogout(ctx, req.(*AdminUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetAdmin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetAdmin(ctx, req.(*SetAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListLockouts",
+			Handler:    _AdminService_ListLockouts_Handler,
+		},
+		{
+			MethodName: "ClearLockout",
+			Handler:    _AdminService_ClearLockout_Handler,
+		},
+		{
+			MethodName: "GetPasswordHashReport",
+			Handler:    _AdminService_GetPasswordHashReport_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _AdminService_CreateInvite_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
+		{
+			MethodName: "DisableUser",
+			Handler:    _AdminService_DisableUser_Handler,
+		},
+		{
+			MethodName: "EnableUser",
+			Handler:    _AdminService_EnableUser_Handler,
+		},
+		{
+			MethodName: "ForceLogout",
+			Handler:    _AdminService_ForceLogout_Handler,
+		},
+		{
+			MethodName: "SetAdmin",
+			Handler:    _AdminService_SetAdmin_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	SecretService_CreateSecret_FullMethodName           = "/gophkeeper.v1.SecretService/CreateSecret"
+	SecretService_GetSecret_FullMethodName              = "/gophkeeper.v1.SecretService/GetSecret"
+	SecretService_ExportSecrets_FullMethodName          = "/gophkeeper.v1.SecretService/ExportSecrets"
+	SecretService_GeneratePassword_FullMethodName       = "/gophkeeper.v1.SecretService/GeneratePassword"
+	SecretService_CheckBreaches_FullMethodName          = "/gophkeeper.v1.SecretService/CheckBreaches"
+	SecretService_GetSecurityReport_FullMethodName      = "/gophkeeper.v1.SecretService/GetSecurityReport"
+	SecretService_ShareSecret_FullMethodName            = "/gophkeeper.v1.SecretService/ShareSecret"
+	SecretService_RevokeShare_FullMethodName            = "/gophkeeper.v1.SecretService/RevokeShare"
+	SecretService_ListSharedWithMe_FullMethodName       = "/gophkeeper.v1.SecretService/ListSharedWithMe"
+	SecretService_ListMyShares_FullMethodName           = "/gophkeeper.v1.SecretService/ListMyShares"
+	SecretService_DeleteCollectionSecret_FullMethodName = "/gophkeeper.v1.SecretService/DeleteCollectionSecret"
+	SecretService_SetApprovalPolicy_FullMethodName      = "/gophkeeper.v1.SecretService/SetApprovalPolicy"
+	SecretService_GetApprovalPolicy_FullMethodName      = "/gophkeeper.v1.SecretService/GetApprovalPolicy"
+	SecretService_RemoveApprovalPolicy_FullMethodName   = "/gophkeeper.v1.SecretService/RemoveApprovalPolicy"
+	SecretService_RequestAccess_FullMethodName          = "/gophkeeper.v1.SecretService/RequestAccess"
+	SecretService_ApproveAccess_FullMethodName          = "/gophkeeper.v1.SecretService/ApproveAccess"
+	SecretService_DenyAccess_FullMethodName             = "/gophkeeper.v1.SecretService/DenyAccess"
+	SecretService_ListAccessRequests_FullMethodName     = "/gophkeeper.v1.SecretService/ListAccessRequests"
+	SecretService_ListAccessEvents_FullMethodName       = "/gophkeeper.v1.SecretService/ListAccessEvents"
+)
+
+// SecretServiceClient is the client API for SecretService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SecretServiceClient interface {
+	CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error)
+	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	ExportSecrets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	GeneratePassword(ctx context.Context, in *GeneratePasswordRequest, opts ...grpc.CallOption) (*GeneratePasswordResponse, error)
+	CheckBreaches(ctx context.Context, in *CheckBreachesRequest, opts ...grpc.CallOption) (*CheckBreachesResponse, error)
+	GetSecurityReport(ctx context.Context, in *SecurityReportRequest, opts ...grpc.CallOption) (*SecurityReport, error)
+	ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*ShareSecretResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSharedWithMe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	ListMyShares(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSharesResponse, error)
+	// Удаление секрета коллекции организации, нужна роль admin или owner.
+	DeleteCollectionSecret(ctx context.Context, in *DeleteCollectionSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Одобрение доступа к критичным секретам: данные секрета с политикой одобрения раскрываются только
+	// по запросу, одобренному утверждающим, и только в течение окна доступа. Все шаги пишутся в журнал.
+	SetApprovalPolicy(ctx context.Context, in *SetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error)
+	GetApprovalPolicy(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error)
+	RemoveApprovalPolicy(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RequestAccess(ctx context.Context, in *RequestSecretAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error)
+	ApproveAccess(ctx context.Context, in *DecideAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error)
+	DenyAccess(ctx context.Context, in *DecideAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error)
+	// Запросы пользователя и запросы к секретам, где он утверждающий.
+	ListAccessRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error)
+	// Журнал доступа к собственному секрету.
+	ListAccessEvents(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*ListAccessEventsResponse, error)
+}
+
+type secretServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSecretServiceClient(cc grpc.ClientConnInterface) SecretServiceClient {
+	return &secretServiceClient{cc}
+}
+
+func (c *secretServiceClient) CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_CreateSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_GetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ExportSecrets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_ExportSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GeneratePassword(ctx context.Context, in *GeneratePasswordRequest, opts ...grpc.CallOption) (*GeneratePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeneratePasswordResponse)
+	err := c.cc.Invoke(ctx, SecretService_GeneratePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) CheckBreaches(ctx context.Context, in *CheckBreachesRequest, opts ...grpc.CallOption) (*CheckBreachesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckBreachesResponse)
+	err := c.cc.Invoke(ctx, SecretService_CheckBreaches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetSecurityReport(ctx context.Context, in *SecurityReportRequest, opts ...grpc.CallOption) (*SecurityReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecurityReport)
+	err := c.cc.Invoke(ctx, SecretService_GetSecurityReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ShareSecret(ctx context.Context, in *ShareSecretRequest, opts ...grpc.CallOption) (*ShareSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_ShareSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SecretService_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListSharedWithMe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListMyShares(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSharesResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListMyShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) DeleteCollectionSecret(ctx context.Context, in *DeleteCollectionSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SecretService_DeleteCollectionSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) SetApprovalPolicy(ctx context.Context, in *SetApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApprovalPolicy)
+	err := c.cc.Invoke(ctx, SecretService_SetApprovalPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) GetApprovalPolicy(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*ApprovalPolicy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApprovalPolicy)
+	err := c.cc.Invoke(ctx, SecretService_GetApprovalPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) RemoveApprovalPolicy(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SecretService_RemoveApprovalPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) RequestAccess(ctx context.Context, in *RequestSecretAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretAccessRequest)
+	err := c.cc.Invoke(ctx, SecretService_RequestAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ApproveAccess(ctx context.Context, in *DecideAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretAccessRequest)
+	err := c.cc.Invoke(ctx, SecretService_ApproveAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) DenyAccess(ctx context.Context, in *DecideAccessRequest, opts ...grpc.CallOption) (*SecretAccessRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretAccessRequest)
+	err := c.cc.Invoke(ctx, SecretService_DenyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListAccessRequests(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccessRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessRequestsResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListAccessRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretServiceClient) ListAccessEvents(ctx context.Context, in *ApprovalPolicyRequest, opts ...grpc.CallOption) (*ListAccessEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessEventsResponse)
+	err := c.cc.Invoke(ctx, SecretService_ListAccessEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SecretServiceServer is the server API for SecretService service.
+// All implementations must embed UnimplementedSecretServiceServer
+// for forward compatibility.
+type SecretServiceServer interface {
+	CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error)
+	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	ExportSecrets(context.Context, *emptypb.Empty) (*GetSecretResponse, error)
+	GeneratePassword(context.Context, *GeneratePasswordRequest) (*GeneratePasswordResponse, error)
+	CheckBreaches(context.Context, *CheckBreachesRequest) (*CheckBreachesResponse, error)
+	GetSecurityReport(context.Context, *SecurityReportRequest) (*SecurityReport, error)
+	ShareSecret(context.Context, *ShareSecretRequest) (*ShareSecretResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*emptypb.Empty, error)
+	ListSharedWithMe(context.Context, *emptypb.Empty) (*GetSecretResponse, error)
+	ListMyShares(context.Context, *emptypb.Empty) (*ListSharesResponse, error)
+	// Удаление секрета коллекции организации, нужна роль admin или owner.
+	DeleteCollectionSecret(context.Context, *DeleteCollectionSecretRequest) (*emptypb.Empty, error)
+	// Одобрение доступа к критичным секретам: данные секрета с политикой одобрения раскрываются только
+	// по запросу, одобренному утверждающим, и только в течение окна доступа. Все шаги пишутся в журнал.
+	SetApprovalPolicy(context.Context, *SetApprovalPolicyRequest) (*ApprovalPolicy, error)
+	GetApprovalPolicy(context.Context, *ApprovalPolicyRequest) (*ApprovalPolicy, error)
+	RemoveApprovalPolicy(context.Context, *ApprovalPolicyRequest) (*emptypb.Empty, error)
+	RequestAccess(context.Context, *RequestSecretAccessRequest) (*SecretAccessRequest, error)
+	ApproveAccess(context.Context, *DecideAccessRequest) (*SecretAccessRequest, error)
+	DenyAccess(context.Context, *DecideAccessRequest) (*SecretAccessRequest, error)
+	// Запросы пользователя и запросы к секретам, где он утверждающий.
+	ListAccessRequests(context.Context, *emptypb.Empty) (*ListAccessRequestsResponse, error)
+	// Журнал доступа к собственному секрету.
+	ListAccessEvents(context.Context, *ApprovalPolicyRequest) (*ListAccessEventsResponse, error)
+	mustEmbedUnimplementedSecretServiceServer()
+}
+
+// UnimplementedSecretServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSecretServiceServer struct{}
+
+func (UnimplementedSecretServiceServer) CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) ExportSecrets(context.Context, *emptypb.Empty) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSecrets not implemented")
+}
+func (UnimplementedSecretServiceServer) GeneratePassword(context.Context, *GeneratePasswordRequest) (*GeneratePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GeneratePassword not implemented")
+}
+func (UnimplementedSecretServiceServer) CheckBreaches(context.Context, *CheckBreachesRequest) (*CheckBreachesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckBreaches not implemented")
+}
+func (UnimplementedSecretServiceServer) GetSecurityReport(context.Context, *SecurityReportRequest) (*SecurityReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecurityReport not implemented")
+}
+func (UnimplementedSecretServiceServer) ShareSecret(context.Context, *ShareSecretRequest) (*ShareSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) RevokeShare(context.Context, *RevokeShareRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedSecretServiceServer) ListSharedWithMe(context.Context, *emptypb.Empty) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedWithMe not implemented")
+}
+func (UnimplementedSecretServiceServer) ListMyShares(context.Context, *emptypb.Empty) (*ListSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyShares not implemented")
+}
+func (UnimplementedSecretServiceServer) DeleteCollectionSecret(context.Context, *DeleteCollectionSecretRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollectionSecret not implemented")
+}
+func (UnimplementedSecretServiceServer) SetApprovalPolicy(context.Context, *SetApprovalPolicyRequest) (*ApprovalPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetApprovalPolicy not implemented")
+}
+func (UnimplementedSecretServiceServer) GetApprovalPolicy(context.Context, *ApprovalPolicyRequest) (*ApprovalPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApprovalPolicy not implemented")
+}
+func (UnimplementedSecretServiceServer) RemoveApprovalPolicy(context.Context, *ApprovalPolicyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveApprovalPolicy not implemented")
+}
+func (UnimplementedSecretServiceServer) RequestAccess(context.Context, *RequestSecretAccessRequest) (*SecretAccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
+func (UnimplementedSecretServiceServer) ApproveAccess(context.Context, *DecideAccessRequest) (*SecretAccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccess not implemented")
+}
+func (UnimplementedSecretServiceServer) DenyAccess(context.Context, *DecideAccessRequest) (*SecretAccessRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccess not implemented")
+}
+func (UnimplementedSecretServiceServer) ListAccessRequests(context.Context, *emptypb.Empty) (*ListAccessRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessRequests not implemented")
+}
+func (UnimplementedSecretServiceServer) ListAccessEvents(context.Context, *ApprovalPolicyRequest) (*ListAccessEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessEvents not implemented")
+}
+func (UnimplementedSecretServiceServer) mustEmbedUnimplementedSecretServiceServer() {}
+func (UnimplementedSecretServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSecretServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SecretServiceServer will
+// result in compilation errors.
+type UnsafeSecretServiceServer interface {
+	mustEmbedUnimplementedSecretServiceServer()
+}
+
+func RegisterSecretServiceServer(s grpc.ServiceRegistrar, srv SecretServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSecretServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SecretService_ServiceDesc, srv)
+}
+
+func _SecretService_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).CreateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_CreateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).CreateSecret(ctx, req.(*CreateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetSecret(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ExportSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ExportSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ExportSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ExportSecrets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GeneratePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GeneratePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GeneratePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GeneratePassword(ctx, req.(*GeneratePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_CheckBreaches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckBreachesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).CheckBreaches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_CheckBreaches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).CheckBreaches(ctx, req.(*CheckBreachesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetSecurityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecurityReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetSecurityReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GetSecurityReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetSecurityReport(ctx, req.(*SecurityReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ShareSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ShareSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ShareSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ShareSecret(ctx, req.(*ShareSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListSharedWithMe(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListMyShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListMyShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListMyShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListMyShares(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_DeleteCollectionSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).DeleteCollectionSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_DeleteCollectionSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).DeleteCollectionSecret(ctx, req.(*DeleteCollectionSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_SetApprovalPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).SetApprovalPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_SetApprovalPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).SetApprovalPolicy(ctx, req.(*SetApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_GetApprovalPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).GetApprovalPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_GetApprovalPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).GetApprovalPolicy(ctx, req.(*ApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_RemoveApprovalPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RemoveApprovalPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_RemoveApprovalPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RemoveApprovalPolicy(ctx, req.(*ApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSecretAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).RequestAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_RequestAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).RequestAccess(ctx, req.(*RequestSecretAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ApproveAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ApproveAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ApproveAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ApproveAccess(ctx, req.(*DecideAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_DenyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).DenyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_DenyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).DenyAccess(ctx, req.(*DecideAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListAccessRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListAccessRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListAccessRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListAccessRequests(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretService_ListAccessEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServiceServer).ListAccessEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretService_ListAccessEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServiceServer).ListAccessEvents(ctx, req.(*ApprovalPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SecretService_ServiceDesc is the grpc.ServiceDesc for SecretService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SecretService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.SecretService",
+	HandlerType: (*SecretServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSecret",
+			Handler:    _SecretService_CreateSecret_Handler,
+		},
+		{
+			MethodName: "GetSecret",
+			Handler:    _SecretService_GetSecret_Handler,
+		},
+		{
+			MethodName: "ExportSecrets",
+			Handler:    _SecretService_ExportSecrets_Handler,
+		},
+		{
+			MethodName: "GeneratePassword",
+			Handler:    _SecretService_GeneratePassword_Handler,
+		},
+		{
+			MethodName: "CheckBreaches",
+			Handler:    _SecretService_CheckBreaches_Handler,
+		},
+		{
+			MethodName: "GetSecurityReport",
+			Handler:    _SecretService_GetSecurityReport_Handler,
+		},
+		{
+			MethodName: "ShareSecret",
+			Handler:    _SecretService_ShareSecret_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _SecretService_RevokeShare_Handler,
+		},
+		{
+			MethodName: "ListSharedWithMe",
+			Handler:    _SecretService_ListSharedWithMe_Handler,
+		},
+		{
+			MethodName: "ListMyShares",
+			Handler:    _SecretService_ListMyShares_Handler,
+		},
+		{
+			MethodName: "DeleteCollectionSecret",
+			Handler:    _SecretService_DeleteCollectionSecret_Handler,
+		},
+		{
+			MethodName: "SetApprovalPolicy",
+			Handler:    _SecretService_SetApprovalPolicy_Handler,
+		},
+		{
+			MethodName: "GetApprovalPolicy",
+			Handler:    _SecretService_GetApprovalPolicy_Handler,
+		},
+		{
+			MethodName: "RemoveApprovalPolicy",
+			Handler:    _SecretService_RemoveApprovalPolicy_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _SecretService_RequestAccess_Handler,
+		},
+		{
+			MethodName: "ApproveAccess",
+			Handler:    _SecretService_ApproveAccess_Handler,
+		},
+		{
+			MethodName: "DenyAccess",
+			Handler:    _SecretService_DenyAccess_Handler,
+		},
+		{
+			MethodName: "ListAccessRequests",
+			Handler:    _SecretService_ListAccessRequests_Handler,
+		},
+		{
+			MethodName: "ListAccessEvents",
+			Handler:    _SecretService_ListAccessEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	OrganizationService_CreateOrganization_FullMethodName = "/gophkeeper.v1.OrganizationService/CreateOrganization"
+	OrganizationService_ListOrganizations_FullMethodName  = "/gophkeeper.v1.OrganizationService/ListOrganizations"
+	OrganizationService_DeleteOrganization_FullMethodName = "/gophkeeper.v1.OrganizationService/DeleteOrganization"
+	OrganizationService_ListMembers_FullMethodName        = "/gophkeeper.v1.OrganizationService/ListMembers"
+	OrganizationService_AddMember_FullMethodName          = "/gophkeeper.v1.OrganizationService/AddMember"
+	OrganizationService_SetMemberRole_FullMethodName      = "/gophkeeper.v1.OrganizationService/SetMemberRole"
+	OrganizationService_RemoveMember_FullMethodName       = "/gophkeeper.v1.OrganizationService/RemoveMember"
+	OrganizationService_CreateCollection_FullMethodName   = "/gophkeeper.v1.OrganizationService/CreateCollection"
+	OrganizationService_ListCollections_FullMethodName    = "/gophkeeper.v1.OrganizationService/ListCollections"
+)
+
+// OrganizationServiceClient is the client API for OrganizationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Организации и их общие хранилища (коллекции). Права проверяются по роли участника:
+// owner и admin управляют участниками и коллекциями, editor создает секреты, viewer только читает.
+// Секреты коллекций создаются и читаются через SecretService с заполненным collection_id.
+type OrganizationServiceClient interface {
+	CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	ListOrganizations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrganizationsResponse, error)
+	DeleteOrganization(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListMembers(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*OrgMember, error)
+	SetMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Удаление участника или выход из организации. Ключи всех коллекций организации ротируются.
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	ListCollections(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error)
+}
+
+type organizationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrganizationServiceClient(cc grpc.ClientConnInterface) OrganizationServiceClient {
+	return &organizationServiceClient{cc}
+}
+
+func (c *organizationServiceClient) CreateOrganization(ctx context.Context, in *CreateOrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, OrganizationService_CreateOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListOrganizations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListOrganizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOrganizationsResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListOrganizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) DeleteOrganization(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrganizationService_DeleteOrganization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListMembers(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*OrgMember, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrgMember)
+	err := c.cc.Invoke(ctx, OrganizationService_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) SetMemberRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrganizationService_SetMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrganizationService_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, OrganizationService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationServiceClient) ListCollections(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*ListCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollectionsResponse)
+	err := c.cc.Invoke(ctx, OrganizationService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizationServiceServer is the server API for OrganizationService service.
+// All implementations must embed UnimplementedOrganizationServiceServer
+// for forward compatibility.
+//
+// Организации и их общие хранилища (коллекции). Права проверяются по роли участника:
+// owner и admin управляют участниками и коллекциями, editor создает секреты, viewer только читает.
+// Секреты коллекций создаются и читаются через SecretService с заполненным collection_id.
+type OrganizationServiceServer interface {
+	CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error)
+	ListOrganizations(context.Context, *emptypb.Empty) (*ListOrganizationsResponse, error)
+	DeleteOrganization(context.Context, *OrganizationRequest) (*emptypb.Empty, error)
+	ListMembers(context.Context, *OrganizationRequest) (*ListMembersResponse, error)
+	AddMember(context.Context, *MemberRequest) (*OrgMember, error)
+	SetMemberRole(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	// Удаление участника или выход из организации. Ключи всех коллекций организации ротируются.
+	RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*Collection, error)
+	ListCollections(context.Context, *OrganizationRequest) (*ListCollectionsResponse, error)
+	mustEmbedUnimplementedOrganizationServiceServer()
+}
+
+// UnimplementedOrganizationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrganizationServiceServer struct{}
+
+func (UnimplementedOrganizationServiceServer) CreateOrganization(context.Context, *CreateOrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrganization not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListOrganizations(context.Context, *emptypb.Empty) (*ListOrganizationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrganizations not implemented")
+}
+func (UnimplementedOrganizationServiceServer) DeleteOrganization(context.Context, *OrganizationRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrganization not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListMembers(context.Context, *OrganizationRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedOrganizationServiceServer) AddMember(context.Context, *MemberRequest) (*OrgMember, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedOrganizationServiceServer) SetMemberRole(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberRole not implemented")
+}
+func (UnimplementedOrganizationServiceServer) RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedOrganizationServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedOrganizationServiceServer) ListCollections(context.Context, *OrganizationRequest) (*ListCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedOrganizationServiceServer) mustEmbedUnimplementedOrganizationServiceServer() {}
+func (UnimplementedOrganizationServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeOrganizationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrganizationServiceServer will
+// result in compilation errors.
+type UnsafeOrganizationServiceServer interface {
+	mustEmbedUnimplementedOrganizationServiceServer()
+}
+
+func RegisterOrganizationServiceServer(s grpc.ServiceRegistrar, srv OrganizationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOrganizationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrganizationService_ServiceDesc, srv)
+}
+
+func _OrganizationService_CreateOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).CreateOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_CreateOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).CreateOrganization(ctx, req.(*CreateOrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListOrganizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListOrganizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListOrganizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListOrganizations(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_DeleteOrganization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).DeleteOrganization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_DeleteOrganization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).DeleteOrganization(ctx, req.(*OrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListMembers(ctx, req.(*OrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).AddMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).SetMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_SetMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).SetMemberRole(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).RemoveMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizationService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizationService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationServiceServer).ListCollections(ctx, req.(*OrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrganizationService_ServiceDesc is the grpc.ServiceDesc for OrganizationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrganizationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.OrganizationService",
+	HandlerType: (*OrganizationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrganization",
+			Handler:    _OrganizationService_CreateOrganization_Handler,
+		},
+		{
+			MethodName: "ListOrganizations",
+			Handler:    _OrganizationService_ListOrganizations_Handler,
+		},
+		{
+			MethodName: "DeleteOrganization",
+			Handler:    _OrganizationService_DeleteOrganization_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _OrganizationService_ListMembers_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _OrganizationService_AddMember_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _OrganizationService_SetMemberRole_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _OrganizationService_RemoveMember_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _OrganizationService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _OrganizationService_ListCollections_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	OneTimeShareService_CreateOneTimeShare_FullMethodName = "/gophkeeper.v1.OneTimeShareService/CreateOneTimeShare"
+	OneTimeShareService_RedeemShare_FullMethodName        = "/gophkeeper.v1.OneTimeShareService/RedeemShare"
+)
+
+// OneTimeShareServiceClient is the client API for OneTimeShareService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Одноразовые ссылки на секреты для тех, у кого нет аккаунта. Содержимое хранится зашифрованным ключом,
+// который знает только создатель ссылки, и уничтожается после последнего просмотра или по истечении срока.
+type OneTimeShareServiceClient interface {
+	CreateOneTimeShare(ctx context.Context, in *CreateOneTimeShareRequest, opts ...grpc.CallOption) (*CreateOneTimeShareResponse, error)
+	// Публичный метод без аутентификации: открыть ссылку может любой, у кого есть ее ID и ключ.
+	RedeemShare(ctx context.Context, in *RedeemShareRequest, opts ...grpc.CallOption) (*RedeemShareResponse, error)
+}
+
+type oneTimeShareServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOneTimeShareServiceClient(cc grpc.ClientConnInterface) OneTimeShareServiceClient {
+	return &oneTimeShareServiceClient{cc}
+}
+
+func (c *oneTimeShareServiceClient) CreateOneTimeShare(ctx context.Context, in *CreateOneTimeShareRequest, opts ...grpc.CallOption) (*CreateOneTimeShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOneTimeShareResponse)
+	err := c.cc.Invoke(ctx, OneTimeShareService_CreateOneTimeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oneTimeShareServiceClient) RedeemShare(ctx context.Context, in *RedeemShareRequest, opts ...grpc.CallOption) (*RedeemShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeemShareResponse)
+	err := c.cc.Invoke(ctx, OneTimeShareService_RedeemShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OneTimeShareServiceServer is the server API for OneTimeShareService service.
+// All implementations must embed UnimplementedOneTimeShareServiceServer
+// for forward compatibility.
+//
+// Одноразовые ссылки на секреты для тех, у кого нет аккаунта. Содержимое хранится зашифрованным ключом,
+// который знает только создатель ссылки, и уничтожается после последнего просмотра или по истечении срока.
+type OneTimeShareServiceServer interface {
+	CreateOneTimeShare(context.Context, *CreateOneTimeShareRequest) (*CreateOneTimeShareResponse, error)
+	// Публичный метод без аутентификации: открыть ссылку может любой, у кого есть ее ID и ключ.
+	RedeemShare(context.Context, *RedeemShareRequest) (*RedeemShareResponse, error)
+	mustEmbedUnimplementedOneTimeShareServiceServer()
+}
+
+// UnimplementedOneTimeShareServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOneTimeShareServiceServer struct{}
+
+func (UnimplementedOneTimeShareServiceServer) CreateOneTimeShare(context.Context, *CreateOneTimeShareRequest) (*CreateOneTimeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOneTimeShare not implemented")
+}
+func (UnimplementedOneTimeShareServiceServer) RedeemShare(context.Context, *RedeemShareRequest) (*RedeemShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemShare not implemented")
+}
+func (UnimplementedOneTimeShareServiceServer) mustEmbedUnimplementedOneTimeShareServiceServer() {}
+func (UnimplementedOneTimeShareServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeOneTimeShareServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OneTimeShareServiceServer will
+// result in compilation errors.
+type UnsafeOneTimeShareServiceServer interface {
+	mustEmbedUnimplementedOneTimeShareServiceServer()
+}
+
+func RegisterOneTimeShareServiceServer(s grpc.ServiceRegistrar, srv OneTimeShareServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOneTimeShareServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OneTimeShareService_ServiceDesc, srv)
+}
+
+func _OneTimeShareService_CreateOneTimeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOneTimeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OneTimeShareServiceServer).CreateOneTimeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OneTimeShareService_CreateOneTimeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OneTimeShareServiceServer).CreateOneTimeShare(ctx, req.(*CreateOneTimeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OneTimeShareService_RedeemShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OneTimeShareServiceServer).RedeemShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OneTimeShareService_RedeemShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OneTimeShareServiceServer).RedeemShare(ctx, req.(*RedeemShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OneTimeShareService_ServiceDesc is the grpc.ServiceDesc for OneTimeShareService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OneTimeShareService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.OneTimeShareService",
+	HandlerType: (*OneTimeShareServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOneTimeShare",
+			Handler:    _OneTimeShareService_CreateOneTimeShare_Handler,
+		},
+		{
+			MethodName: "RedeemShare",
+			Handler:    _OneTimeShareService_RedeemShare_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/proto/gophkeeper.proto",
+}
+
+const (
+	EmergencyAccessService_NominateContact_FullMethodName     = "/gophkeeper.v1.EmergencyAccessService/NominateContact"
+	EmergencyAccessService_ListTrustedContacts_FullMethodName = "/gophkeeper.v1.EmergencyAccessService/ListTrustedContacts"
+	EmergencyAccessService_ListGrantors_FullMethodName        = "/gophkeeper.v1.EmergencyAccessService/ListGrantors"
+	EmergencyAccessService_RemoveContact_FullMethodName       = "/gophkeeper.v1.EmergencyAccessService/RemoveContact"
+	EmergencyAccessService_RequestAccess_FullMethodName       = "/gophkeeper.v1.EmergencyAccessService/RequestAccess"
+	EmergencyAccessService_ApproveAccess_FullMethodName       = "/gophkeeper.v1.EmergencyAccessService/ApproveAccess"
+	EmergencyAccessService_RejectAccess_FullMethodName        = "/gophkeeper.v1.EmergencyAccessService/RejectAccess"
+	EmergencyAccessService_GetEmergencyVault_FullMethodName   = "/gophkeeper.v1.EmergencyAccessService/GetEmergencyVault"
+	EmergencyAccessService_TakeoverAccount_FullMethodName     = "/gophkeeper.v1.EmergencyAccessService/TakeoverAccount"
+	EmergencyAccessService_ListEmergencyEvents_FullMethodName = "/gophkeeper.v1.EmergencyAccessService/ListEmergencyEvents"
+)
+
+// EmergencyAccessServiceClient is the client API for EmergencyAccessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Экстренный доступ: пользователь назначает доверенный контакт, который может запросить доступ к его хранилищу.
+// Доступ открывается после периода ожидания, если владелец не отклонил запрос, или сразу после одобрения.
+// Для E2E аккаунта владелец передает контакту ключ хранилища, зашифрованный открытым ключом контакта.
+type EmergencyAccessServiceClient interface {
+	NominateContact(ctx context.Context, in *NominateContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error)
+	ListTrustedContacts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	ListGrantors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error)
+	// Удаление назначения владельцем или отказ от него контактом.
+	RemoveContact(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RequestAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error)
+	ApproveAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error)
+	// Отказ в запросе или отзыв уже открытого доступа.
+	RejectAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error)
+	GetEmergencyVault(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyVaultResponse, error)
+	// Смена пароля владельца контактом с доступом takeover. Сессии и второй фактор владельца отключаются.
+	TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*EmergencyContact, error)
+	ListEmergencyEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyEventsResponse, error)
+}
+
+type emergencyAccessServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyAccessServiceClient(cc grpc.ClientConnInterface) EmergencyAccessServiceClient {
+	return &emergencyAccessServiceClient{cc}
+}
+
+func (c *emergencyAccessServiceClient) NominateContact(ctx context.Context, in *NominateContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContact)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_NominateContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) ListTrustedContacts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_ListTrustedContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) ListGrantors(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyContactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyContactsResponse)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_ListGrantors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) RemoveContact(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, UserService_Update_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_RemoveContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) RequestAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContact)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_RequestAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) ApproveAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContact)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_ApproveAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) RejectAccess(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContact)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_RejectAccess_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-// All implementations must embed UnimplementedUserServiceServer
+func (c *emergencyAccessServiceClient) GetEmergencyVault(ctx context.Context, in *EmergencyContactRequest, opts ...grpc.CallOption) (*EmergencyVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_GetEmergencyVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) TakeoverAccount(ctx context.Context, in *TakeoverAccountRequest, opts ...grpc.CallOption) (*EmergencyContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmergencyContact)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_TakeoverAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyAccessServiceClient) ListEmergencyEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListEmergencyEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEmergencyEventsResponse)
+	err := c.cc.Invoke(ctx, EmergencyAccessService_ListEmergencyEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyAccessServiceServer is the server API for EmergencyAccessService service.
+// All implementations must embed UnimplementedEmergencyAccessServiceServer
 // for forward compatibility.
-type UserServiceServer interface {
-	Register(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
-	Login(context.Context, *LoginUserRequest) (*LoginUserResponse, error)
-	Update(context.Context, *UpdateUserRequest) (*emptypb.Empty, error)
-	mustEmbedUnimplementedUserServiceServer()
+//
+// Экстренный доступ: пользователь назначает доверенный контакт, который может запросить доступ к его хранилищу.
+// Доступ открывается после периода ожидания, если владелец не отклонил запрос, или сразу после одобрения.
+// Для E2E аккаунта владелец передает контакту ключ хранилища, зашифрованный открытым ключом контакта.
+type EmergencyAccessServiceServer interface {
+	NominateContact(context.Context, *NominateContactRequest) (*EmergencyContact, error)
+	ListTrustedContacts(context.Context, *emptypb.Empty) (*ListEmergencyContactsResponse, error)
+	ListGrantors(context.Context, *emptypb.Empty) (*ListEmergencyContactsResponse, error)
+	// Удаление назначения владельцем или отказ от него контактом.
+	RemoveContact(context.Context, *EmergencyContactRequest) (*emptypb.Empty, error)
+	RequestAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error)
+	ApproveAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error)
+	// Отказ в запросе или отзыв уже открытого доступа.
+	RejectAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error)
+	GetEmergencyVault(context.Context, *EmergencyContactRequest) (*EmergencyVaultResponse, error)
+	// Смена пароля владельца контактом с доступом takeover. Сессии и второй фактор владельца отключаются.
+	TakeoverAccount(context.Context, *TakeoverAccountRequest) (*EmergencyContact, error)
+	ListEmergencyEvents(context.Context, *emptypb.Empty) (*ListEmergencyEventsResponse, error)
+	mustEmbedUnimplementedEmergencyAccessServiceServer()
 }
 
-// UnimplementedUserServiceServer must be embedded to have
+// UnimplementedEmergencyAccessServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedUserServiceServer struct{}
+type UnimplementedEmergencyAccessServiceServer struct{}
 
-func (UnimplementedUserServiceServer) Register(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+func (UnimplementedEmergencyAccessServiceServer) NominateContact(context.Context, *NominateContactRequest) (*EmergencyContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NominateContact not implemented")
 }
-
-func (UnimplementedUserServiceServer) Login(context.Context, *LoginUserRequest) (*LoginUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+func (UnimplementedEmergencyAccessServiceServer) ListTrustedContacts(context.Context, *emptypb.Empty) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrustedContacts not implemented")
 }
-
-func (UnimplementedUserServiceServer) Update(context.Context, *UpdateUserRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+func (UnimplementedEmergencyAccessServiceServer) ListGrantors(context.Context, *emptypb.Empty) (*ListEmergencyContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrantors not implemented")
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
-func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
+func (UnimplementedEmergencyAccessServiceServer) RemoveContact(context.Context, *EmergencyContactRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveContact not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) RequestAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) ApproveAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveAccess not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) RejectAccess(context.Context, *EmergencyContactRequest) (*EmergencyContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectAccess not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) GetEmergencyVault(context.Context, *EmergencyContactRequest) (*EmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) TakeoverAccount(context.Context, *TakeoverAccountRequest) (*EmergencyContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeoverAccount not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) ListEmergencyEvents(context.Context, *emptypb.Empty) (*ListEmergencyEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEmergencyEvents not implemented")
+}
+func (UnimplementedEmergencyAccessServiceServer) mustEmbedUnimplementedEmergencyAccessServiceServer() {
+}
+func (UnimplementedEmergencyAccessServiceServer) testEmbeddedByValue() {}
 
-// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to UserServiceServer will
+// UnsafeEmergencyAccessServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyAccessServiceServer will
 // result in compilation errors.
-type UnsafeUserServiceServer interface {
-	mustEmbedUnimplementedUserServiceServer()
+type UnsafeEmergencyAccessServiceServer interface {
+	mustEmbedUnimplementedEmergencyAccessServiceServer()
 }
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	// If the following call pancis, it indicates UnimplementedUserServiceServer was
+func RegisterEmergencyAccessServiceServer(s grpc.ServiceRegistrar, srv EmergencyAccessServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEmergencyAccessServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&UserService_ServiceDesc, srv)
+	s.RegisterService(&EmergencyAccessService_ServiceDesc, srv)
 }
 
-func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterUserRequest)
+func _EmergencyAccessService_NominateContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NominateContactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).Register(ctx, in)
+		return srv.(EmergencyAccessServiceServer).NominateContact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_Register_FullMethodName,
+		FullMethod: EmergencyAccessService_NominateContact_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Register(ctx, req.(*RegisterUserRequest))
+		return srv.(EmergencyAccessServiceServer).NominateContact(ctx, req.(*NominateContactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginUserRequest)
+func _EmergencyAccessService_ListTrustedContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).Login(ctx, in)
+		return srv.(EmergencyAccessServiceServer).ListTrustedContacts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_Login_FullMethodName,
+		FullMethod: EmergencyAccessService_ListTrustedContacts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Login(ctx, req.(*LoginUserRequest))
+		return srv.(EmergencyAccessServiceServer).ListTrustedContacts(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
+func _EmergencyAccessService_ListGrantors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).Update(ctx, in)
+		return srv.(EmergencyAccessServiceServer).ListGrantors(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_Update_FullMethodName,
+		FullMethod: EmergencyAccessService_ListGrantors_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Update(ctx, req.(*UpdateUserRequest))
+		return srv.(EmergencyAccessServiceServer).ListGrantors(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+func _EmergencyAccessService_RemoveContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).RemoveContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_RemoveContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).RemoveContact(ctx, req.(*EmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).RequestAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_RequestAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).RequestAccess(ctx, req.(*EmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_ApproveAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).ApproveAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_ApproveAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).ApproveAccess(ctx, req.(*EmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_RejectAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).RejectAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_RejectAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).RejectAccess(ctx, req.(*EmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).GetEmergencyVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_GetEmergencyVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).GetEmergencyVault(ctx, req.(*EmergencyContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_TakeoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeoverAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).TakeoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_TakeoverAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).TakeoverAccount(ctx, req.(*TakeoverAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyAccessService_ListEmergencyEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyAccessServiceServer).ListEmergencyEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyAccessService_ListEmergencyEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyAccessServiceServer).ListEmergencyEvents(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmergencyAccessService_ServiceDesc is the grpc.ServiceDesc for EmergencyAccessService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var UserService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gophkeeper.v1.UserService",
-	HandlerType: (*UserServiceServer)(nil),
+var EmergencyAccessService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.EmergencyAccessService",
+	HandlerType: (*EmergencyAccessServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Register",
-			Handler:    _UserService_Register_Handler,
+			MethodName: "NominateContact",
+			Handler:    _EmergencyAccessService_NominateContact_Handler,
 		},
 		{
-			MethodName: "Login",
-			Handler:    _UserService_Login_Handler,
+			MethodName: "ListTrustedContacts",
+			Handler:    _EmergencyAccessService_ListTrustedContacts_Handler,
+		},
+		{
+			MethodName: "ListGrantors",
+			Handler:    _EmergencyAccessService_ListGrantors_Handler,
+		},
+		{
+			MethodName: "RemoveContact",
+			Handler:    _EmergencyAccessService_RemoveContact_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _EmergencyAccessService_RequestAccess_Handler,
+		},
+		{
+			MethodName: "ApproveAccess",
+			Handler:    _EmergencyAccessService_ApproveAccess_Handler,
+		},
+		{
+			MethodName: "RejectAccess",
+			Handler:    _EmergencyAccessService_RejectAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _EmergencyAccessService_GetEmergencyVault_Handler,
 		},
 		{
-			MethodName: "Update",
-			Handler:    _UserService_Update_Handler,
+			MethodName: "TakeoverAccount",
+			Handler:    _EmergencyAccessService_TakeoverAccount_Handler,
+		},
+		{
+			MethodName: "ListEmergencyEvents",
+			Handler:    _EmergencyAccessService_ListEmergencyEvents_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -201,140 +3100,107 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	SecretService_CreateSecret_FullMethodName = "/gophkeeper.v1.SecretService/CreateSecret"
-	SecretService_GetSecret_FullMethodName    = "/gophkeeper.v1.SecretService/GetSecret"
+	AuditService_QueryAuditLog_FullMethodName = "/gophkeeper.v1.AuditService/QueryAuditLog"
 )
 
-// SecretServiceClient is the client API for SecretService service.
+// AuditServiceClient is the client API for AuditService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type SecretServiceClient interface {
-	CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error)
-	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+//
+// Журнал аудита: входы, изменения аккаунта, создание, чтение и передача секретов. Пользователь видит
+// свои записи, администратор записи любого пользователя или всех сразу.
+type AuditServiceClient interface {
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
-type secretServiceClient struct {
+type auditServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewSecretServiceClient(cc grpc.ClientConnInterface) SecretServiceClient {
-	return &secretServiceClient{cc}
-}
-
-func (c *secretServiceClient) CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateSecretResponse)
-	err := c.cc.Invoke(ctx, SecretService_CreateSecret_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func NewAuditServiceClient(cc grpc.ClientConnInterface) AuditServiceClient {
+	return &auditServiceClient{cc}
 }
 
-func (c *secretServiceClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+func (c *auditServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetSecretResponse)
-	err := c.cc.Invoke(ctx, SecretService_GetSecret_FullMethodName, in, out, cOpts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, AuditService_QueryAuditLog_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// SecretServiceServer is the server API for SecretService service.
-// All implementations must embed UnimplementedSecretServiceServer
+// AuditServiceServer is the server API for AuditService service.
+// All implementations must embed UnimplementedAuditServiceServer
 // for forward compatibility.
-type SecretServiceServer interface {
-	CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error)
-	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
-	mustEmbedUnimplementedSecretServiceServer()
+//
+// Журнал аудита: входы, изменения аккаунта, создание, чтение и передача секретов. Пользователь видит
+// свои записи, администратор записи любого пользователя или всех сразу.
+type AuditServiceServer interface {
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	mustEmbedUnimplementedAuditServiceServer()
 }
 
-// UnimplementedSecretServiceServer must be embedded to have
+// UnimplementedAuditServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedSecretServiceServer struct{}
+type UnimplementedAuditServiceServer struct{}
 
-func (UnimplementedSecretServiceServer) CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
-}
-
-func (UnimplementedSecretServiceServer) GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+func (UnimplementedAuditServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
 }
-func (UnimplementedSecretServiceServer) mustEmbedUnimplementedSecretServiceServer() {}
-func (UnimplementedSecretServiceServer) testEmbeddedByValue()                       {}
+func (UnimplementedAuditServiceServer) mustEmbedUnimplementedAuditServiceServer() {}
+func (UnimplementedAuditServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeSecretServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to SecretServiceServer will
+// UnsafeAuditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditServiceServer will
 // result in compilation errors.
-type UnsafeSecretServiceServer interface {
-	mustEmbedUnimplementedSecretServiceServer()
+type UnsafeAuditServiceServer interface {
+	mustEmbedUnimplementedAuditServiceServer()
 }
 
-func RegisterSecretServiceServer(s grpc.ServiceRegistrar, srv SecretServiceServer) {
-	// If the following call pancis, it indicates UnimplementedSecretServiceServer was
+func RegisterAuditServiceServer(s grpc.ServiceRegistrar, srv AuditServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuditServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&SecretService_ServiceDesc, srv)
-}
-
-func _SecretService_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSecretRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SecretServiceServer).CreateSecret(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: SecretService_CreateSecret_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SecretServiceServer).CreateSecret(ctx, req.(*CreateSecretRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	s.RegisterService(&AuditService_ServiceDesc, srv)
 }
 
-func _SecretService_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSecretRequest)
+func _AuditService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SecretServiceServer).GetSecret(ctx, in)
+		return srv.(AuditServiceServer).QueryAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SecretService_GetSecret_FullMethodName,
+		FullMethod: AuditService_QueryAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SecretServiceServer).GetSecret(ctx, req.(*GetSecretRequest))
+		return srv.(AuditServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// SecretService_ServiceDesc is the grpc.ServiceDesc for SecretService service.
+// AuditService_ServiceDesc is the grpc.ServiceDesc for AuditService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var SecretService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gophkeeper.v1.SecretService",
-	HandlerType: (*SecretServiceServer)(nil),
+var AuditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.v1.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateSecret",
-			Handler:    _SecretService_CreateSecret_Handler,
-		},
-		{
-			MethodName: "GetSecret",
-			Handler:    _SecretService_GetSecret_Handler,
+			MethodName: "QueryAuditLog",
+			Handler:    _AuditService_QueryAuditLog_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
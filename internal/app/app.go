@@ -2,82 +2,417 @@
 package app
 
 import (
-	"encoding/hex"
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
 	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/auth"
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
 	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/approval"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/datakey"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/emergency"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/mfa"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/org"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/session"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
 	"github.com/Melikhov-p/goph-keeper/internal/interceptors"
+	"github.com/Melikhov-p/goph-keeper/internal/kms"
 	"github.com/Melikhov-p/goph-keeper/internal/logger"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+	"github.com/Melikhov-p/goph-keeper/internal/repository/external_storage"
 	"github.com/Melikhov-p/goph-keeper/internal/repository/postgres"
+	"github.com/Melikhov-p/goph-keeper/internal/seal"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
 	grpc2 "github.com/Melikhov-p/goph-keeper/internal/transport/grpc"
+	http2 "github.com/Melikhov-p/goph-keeper/internal/transport/http"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
+const httpReadHeaderTimeout = 5 * time.Second
+
 // App структура приложения.
 type App struct {
 	Cfg *config.Config
 
+	KeyProvider kms.KeyProvider
+
+	DataKeyRepository datakey.Repository
+	DataKeyService    *datakey.Service
+
+	TokenKeys *auth.KeySet
+
+	AuditRepository audit.Repository
+	AuditService    *audit.Service
+
 	UserRepository user.Repository
 	UserService    *user.Service
 
+	SessionRepository session.Repository
+	SessionService    *session.Service
+
+	MFARepository mfa.Repository
+	MFAService    *mfa.Service
+
+	LockoutRepository lockout.Repository
+	LockoutService    *lockout.Service
+
+	OrgRepository org.Repository
+	OrgService    *org.Service
+
+	ApprovalRepository approval.Repository
+	ApprovalService    *approval.Service
+
 	SecretRepository secret.Repository
 	SecretService    *secret.Service
 
+	OneTimeShareRepository onetime.Repository
+	OneTimeShareService    *onetime.Service
+
+	EmergencyRepository emergency.Repository
+	EmergencyService    *emergency.Service
+
+	// BreachIndex локальная база утечек паролей, nil если не подключена.
+	BreachIndex *breach.Index
+
 	GRPCServer *grpc.Server
+	HTTPServer *http.Server
 
 	Log *zap.Logger
+
+	db *sql.DB
+	// fields политика шифрования полей, ее ключ затирается в Close.
+	fields *secret.FieldEncryption
 }
 
 // New создание нового приложения.
-func New(cfg *config.Config) (*App, error) {
+// Для провайдера ключей shamir provider собран из ключа распечатывания, полученного через barrier;
+// для остальных провайдеров barrier и provider равны nil, провайдер создается по конфигу.
+func New(cfg *config.Config, barrier *seal.Barrier, provider kms.KeyProvider) (*App, error) {
 	op := "app.New"
 
 	var err error
 
 	app := App{}
 	app.Cfg = cfg
+	app.KeyProvider = provider
+
+	// Ключ провайдера local нужен для переноса ключей, обернутых им, на другой провайдер
+	localKey, err := app.Cfg.Security.KeyProvider.LocalMasterKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: error getting local master key %w", op, err)
+	}
+	defer securemem.Wipe(localKey)
+
+	keys, err := app.loadKeys(localKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = encryptor.SetDefaultAlgorithm(encryptor.Algorithm(app.Cfg.Security.Cipher)); err != nil {
+		keys.Wipe()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	migration, err := app.registerMigrations(keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer migration.Wipe()
 
 	db, err := postgres.NewConnection(app.Cfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s: error getting connection to db %w", op, err)
 	}
+	app.db = db
 
 	app.Log, err = logger.BuildLogger(app.Cfg.Logging.Level)
 	if err != nil {
 		return nil, fmt.Errorf("%s: error getting logger %w", op, err)
 	}
 
-	app.Log.Debug("generate new master key", zap.String("KEY", hex.EncodeToString(app.Cfg.Security.MasterKey)))
+	// Данные перешифрованы ключами данных пользователей, прежний корневой ключ больше не нужен.
+	// Ключ удаляется, только если файлы, зашифрованные им, удалены: иначе сервер не запускается,
+	// а пути неудаленных файлов есть в ошибке, и их нужно удалить вручную
+	if err = migration.RemoveObsoleteFiles(); err != nil {
+		return nil, fmt.Errorf("%s: error removing reencrypted files, legacy root key was not dropped %w", op, err)
+	}
+	if keys.Legacy != nil {
+		if err = kms.DropLegacyKey(app.Cfg.Security.KeyProvider.KeyringPath); err != nil {
+			return nil, fmt.Errorf("%s: error dropping legacy root key %w", op, err)
+		}
+	}
+
+	app.DataKeyRepository = postgres.NewDataKeyRepository(db)
+	app.DataKeyService = datakey.NewService(app.DataKeyRepository, app.KeyProvider)
+
+	if err = app.adoptDataKeys(localKey); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	app.TokenKeys, err = auth.LoadKeySet(
+		app.Cfg.Security.JWT.SigningKeyPath, app.Cfg.Security.JWT.VerificationKeyPaths, app.Cfg.Security.TokenKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error loading token signing keys %w", op, err)
+	}
+
+	policy := app.Cfg.Security.PasswordPolicy
+	banned, err := passpolicy.LoadBannedList(policy.BannedListPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error loading banned passwords %w", op, err)
+	}
+
+	if mode := user.RegistrationMode(app.Cfg.Security.Registration.Mode); !mode.Valid() {
+		return nil, fmt.Errorf("%s: unknown registration mode %q", op, mode)
+	}
+
+	hasher, err := newPasswordHasher(app.Cfg.Security)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error configuring password hashing %w", op, err)
+	}
+
+	app.AuditRepository = postgres.NewAuditRepository(db)
+	app.AuditService = audit.NewService(app.AuditRepository)
 
 	app.UserRepository = postgres.NewUserRepository(db)
-	app.UserService = user.NewService(app.UserRepository)
+	app.UserService = user.NewService(
+		app.UserRepository,
+		passpolicy.New(policy.MinLength, policy.MinClasses, policy.MinScore, banned),
+		hasher,
+		app.AuditService,
+		[]byte(app.Cfg.Security.Pepper),
+	)
+
+	app.SessionRepository = postgres.NewSessionRepository(db)
+	app.SessionService = session.NewService(app.SessionRepository, app.UserService, app.Cfg.Security.RefreshTokenTTL)
+
+	app.MFARepository = postgres.NewMFARepository(db)
+	app.MFAService = mfa.NewService(app.MFARepository, app.Cfg, app.DataKeyService)
+
+	app.LockoutRepository = postgres.NewLockoutRepository(db)
+	app.LockoutService = lockout.NewService(app.LockoutRepository, app.Cfg)
+
+	app.OrgRepository = postgres.NewOrgRepository(db)
+	app.OrgService = org.NewService(app.OrgRepository, app.DataKeyService)
 
 	app.SecretRepository = postgres.NewSecretRepository(db, app.Log)
-	app.SecretService = secret.NewService(app.SecretRepository, app.Cfg)
+
+	// Политики одобрения проверяют секреты через репозиторий, чтобы сервис секретов мог зависеть от них
+	app.ApprovalRepository = postgres.NewApprovalRepository(db)
+	app.ApprovalService = approval.NewService(app.ApprovalRepository, app.Cfg, app.SecretRepository)
+
+	app.SecretService = secret.NewService(
+		app.SecretRepository,
+		app.Cfg,
+		app.fields,
+		app.DataKeyService,
+		app.OrgService,
+		app.ApprovalService,
+		app.AuditService,
+	)
+
+	app.OneTimeShareRepository = postgres.NewOneTimeShareRepository(db)
+	app.OneTimeShareService = onetime.NewService(app.OneTimeShareRepository, app.Cfg)
+
+	app.EmergencyRepository = postgres.NewEmergencyRepository(db)
+	app.EmergencyService = emergency.NewService(
+		app.EmergencyRepository, app.Cfg, app.UserService, app.SecretService, app.SessionService, app.MFAService,
+	)
 
 	// Создание gRPC-сервера
 	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(interceptors.WipeHandler{}),
 		grpc.ChainUnaryInterceptor(
 			interceptors.LogInterceptor(app.Log),
-			interceptors.AuthInterceptor(cfg.Security.TokenKey),
+			interceptors.ClientInfoInterceptor(),
+			interceptors.AuthInterceptor(app.TokenKeys, app.SessionService, app.UserService),
 		),
 	)
 
-	userServer := grpc2.NewUserServer(app.UserService, app.Log, app.Cfg)
-	secretServer := grpc2.NewSecretServer(app.SecretService, app.UserService, app.Cfg, app.Log)
+	userServer := grpc2.NewUserServer(
+		app.UserService, app.SessionService, app.MFAService, app.LockoutService, app.TokenKeys, app.Log, app.Cfg,
+	)
+	var breaches grpc2.BreachChecker
+	if path := app.Cfg.Security.BreachIndexPath; path != "" {
+		app.BreachIndex, err = breach.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: error opening breach index %w", op, err)
+		}
+		breaches = app.BreachIndex
+		app.Log.Info("breach index loaded", zap.Uint64("hashes", app.BreachIndex.Len()))
+	}
+
+	secretServer := grpc2.NewSecretServer(
+		app.SecretService, app.UserService, breaches, app.ApprovalService, app.Cfg, app.Log,
+	)
 	pb.RegisterUserServiceServer(grpcServer, userServer)
 	pb.RegisterSecretServiceServer(grpcServer, secretServer)
+	pb.RegisterOrganizationServiceServer(grpcServer, grpc2.NewOrgServer(app.OrgService, app.UserService, app.Log))
+	oneTimeServer := grpc2.NewOneTimeServer(
+		app.OneTimeShareService, app.SecretService, app.UserService, app.Log, app.Cfg,
+	)
+	pb.RegisterOneTimeShareServiceServer(grpcServer, oneTimeServer)
+	pb.RegisterEmergencyAccessServiceServer(grpcServer, grpc2.NewEmergencyServer(
+		app.EmergencyService, app.UserService, app.Log,
+	))
+	pb.RegisterAuditServiceServer(grpcServer, grpc2.NewAuditServer(app.AuditService, app.UserService, app.Log))
+	pb.RegisterAdminServiceServer(
+		grpcServer, grpc2.NewAdminServer(app.LockoutService, app.UserService, app.SessionService, app.Log, app.Cfg),
+	)
+
+	if barrier != nil {
+		pb.RegisterSystemServiceServer(grpcServer, grpc2.NewSystemServer(barrier, app.Log, app.Cfg))
+	}
 
 	app.GRPCServer = grpcServer
 
+	app.HTTPServer = &http.Server{
+		Addr:              app.Cfg.HTTP.Address,
+		Handler:           http2.NewRouter(app.TokenKeys, oneTimeServer, app.Log),
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
 	return &app, nil
 }
 
+// newPasswordHasher хэшер паролей аккаунтов по конфигу. Перец security.pepper доступен под пустым ID:
+// с ним получены все хэши, созданные до появления ID перцев.
+func newPasswordHasher(cfg config.SecurityConfig) (*passhash.Hasher, error) {
+	hashing := cfg.PasswordHashing
+
+	peppers := make(map[string]string, len(hashing.Peppers)+1)
+	for id, p := range hashing.Peppers {
+		peppers[id] = p
+	}
+	peppers[""] = cfg.Pepper
+
+	hasher, err := passhash.New(passhash.Params{
+		Scheme:     passhash.Scheme(hashing.Scheme),
+		BcryptCost: hashing.BcryptCost,
+		Argon2: passhash.Argon2Params{
+			Memory:  hashing.Argon2Memory,
+			Time:    hashing.Argon2Time,
+			Threads: hashing.Argon2Threads,
+		},
+		PepperID: hashing.PepperID,
+	}, peppers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password hasher %w", err)
+	}
+
+	return hasher, nil
+}
+
+// loadKeys получение ключей сервера через провайдер ключей.
+func (a *App) loadKeys(localKey []byte) (*kms.Keys, error) {
+	if a.KeyProvider == nil {
+		if a.Cfg.Security.KeyProvider.Type == kms.ProviderShamir {
+			return nil, fmt.Errorf("error loading keys %w", kms.ErrSealed)
+		}
+
+		var err error
+
+		a.KeyProvider, err = kms.New(&a.Cfg.Security.KeyProvider)
+		if err != nil {
+			return nil, fmt.Errorf("error getting key provider %w", err)
+		}
+	}
+
+	keys, err := kms.LoadKeys(
+		context.Background(), a.KeyProvider, a.Cfg.Security.KeyProvider.KeyringPath, localKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error loading keys %w", err)
+	}
+
+	return keys, nil
+}
+
+// registerMigrations регистрация Go-миграций, которые шифруют данные. Ключ полей остается у приложения,
+// устаревший корневой ключ затирается после миграций.
+func (a *App) registerMigrations(keys *kms.Keys) (*postgres.DataKeyMigration, error) {
+	var err error
+
+	a.fields, err = secret.NewFieldEncryption(keys.Field, a.Cfg.Security.FieldEncryption)
+	if err != nil {
+		keys.Wipe()
+		return nil, fmt.Errorf("error getting field encryption policy %w", err)
+	}
+
+	// Данные уже перешифрованы, и миграции на прежнем ключе не выполнятся, но регистрируются всегда
+	legacy := keys.Legacy
+	if legacy == nil {
+		if legacy, err = encryptor.NewVaultKey(); err != nil {
+			return nil, fmt.Errorf("error generating legacy key placeholder %w", err)
+		}
+	}
+
+	legacyFields, err := secret.NewFieldEncryption(legacy, a.Cfg.Security.FieldEncryption)
+	if err != nil {
+		securemem.Wipe(legacy)
+		return nil, fmt.Errorf("error getting legacy field encryption policy %w", err)
+	}
+	postgres.RegisterFieldEncryptionMigration(legacyFields)
+
+	migration := postgres.NewDataKeyMigration(legacy, legacyFields, a.fields, a.KeyProvider)
+	postgres.RegisterDataKeyMigration(migration)
+	postgres.RegisterFieldScopeMigration(a.fields)
+
+	return migration, nil
+}
+
+// adoptDataKeys переоборачивание ключей данных, обернутых провайдером local, выбранным провайдером.
+func (a *App) adoptDataKeys(localKey []byte) error {
+	if a.KeyProvider.Name() == kms.ProviderLocal || localKey == nil {
+		return nil
+	}
+
+	local, err := kms.NewLocalProvider(localKey)
+	if err != nil {
+		return fmt.Errorf("error getting local key provider %w", err)
+	}
+
+	n, err := a.DataKeyService.Adopt(context.Background(), local)
+	if err != nil {
+		return fmt.Errorf("error adopting data keys %w", err)
+	}
+	if n > 0 {
+		a.Log.Info("data keys moved from local key provider", zap.Int("count", n))
+	}
+
+	return nil
+}
+
+// RotateKEK ротация ключа провайдера, переоборачивание ключей сервера и ключей данных пользователей.
+func (a *App) RotateKEK(ctx context.Context) error {
+	op := "app.RotateKEK"
+
+	if err := kms.RotateKEK(ctx, a.KeyProvider, a.Cfg.Security.KeyProvider.KeyringPath); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := a.DataKeyService.Rewrap(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	a.Log.Info("key provider key rotated", zap.Int("data_keys", n))
+
+	return nil
+}
+
 // RunGRPC запуск gRPC сервера.
 func (a *App) RunGRPC() error {
 	op := "app.RunGRPC"
@@ -94,7 +429,81 @@ func (a *App) RunGRPC() error {
 	return nil
 }
 
+// RunHTTP запуск HTTP сервера служебных эндпоинтов.
+func (a *App) RunHTTP() error {
+	if err := a.HTTPServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("app.RunHTTP: error serving HTTP server %w", err)
+	}
+
+	return nil
+}
+
+// StopHTTP graceful shutdown HTTP сервера.
+func (a *App) StopHTTP(ctx context.Context) error {
+	if err := a.HTTPServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("app.StopHTTP: %w", err)
+	}
+
+	return nil
+}
+
+// RunPurge периодическое окончательное удаление аккаунтов, период отмены удаления которых истек,
+// и истекших одноразовых ссылок. Работает до завершения контекста.
+func (a *App) RunPurge(ctx context.Context) {
+	erase := func(ctx context.Context, userID int) error {
+		// Секреты коллекций принадлежат организациям и остаются, но ключи коллекций ротируются
+		if err := a.OrgService.LeaveAll(ctx, userID); err != nil {
+			return err
+		}
+		return external_storage.DeleteUserData(ctx, userID, a.Cfg.Database.ExternalStoragePath)
+	}
+
+	ticker := time.NewTicker(a.Cfg.Security.AccountDeletion.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := a.UserService.PurgeDeleted(ctx, time.Now(), erase)
+		if err != nil {
+			a.Log.Error("error purging deleted accounts", zap.Error(err))
+		}
+		if purged > 0 {
+			a.Log.Info("deleted accounts purged", zap.Int("count", purged))
+		}
+
+		expired, err := a.OneTimeShareService.PurgeExpired(ctx, time.Now())
+		if err != nil {
+			a.Log.Error("error purging expired one-time shares", zap.Error(err))
+		}
+		if expired > 0 {
+			a.Log.Info("expired one-time shares purged", zap.Int("count", expired))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // StopGRPC graceful shutdown gRPC сервера.
 func (a *App) StopGRPC() {
 	a.GRPCServer.GracefulStop()
 }
+
+// Close закрытие соединения с БД и индекса утечек, затирание ключа полей после остановки сервера.
+func (a *App) Close() error {
+	defer a.fields.Wipe()
+
+	if a.BreachIndex != nil {
+		if err := a.BreachIndex.Close(); err != nil {
+			a.Log.Error("error closing breach index", zap.Error(err))
+		}
+	}
+
+	if err := a.db.Close(); err != nil {
+		return fmt.Errorf("app.Close: failed to close db %w", err)
+	}
+
+	return nil
+}
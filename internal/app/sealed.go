@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/interceptors"
+	"github.com/Melikhov-p/goph-keeper/internal/seal"
+	grpc2 "github.com/Melikhov-p/goph-keeper/internal/transport/grpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewSealedServer gRPC сервер запечатанного состояния: доступны только административные методы,
+// на остальные запросы отвечает codes.Unavailable.
+func NewSealedServer(cfg *config.Config, barrier *seal.Barrier, log *zap.Logger) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors.LogInterceptor(log)),
+		grpc.UnknownServiceHandler(func(_ any, _ grpc.ServerStream) error {
+			return status.Error(codes.Unavailable, "server is sealed")
+		}),
+	)
+
+	pb.RegisterSystemServiceServer(srv, grpc2.NewSystemServer(barrier, log, cfg))
+
+	return srv
+}
+
+// WaitUnseal запуск запечатанного сервера и ожидание, пока операторы его распечатают.
+// Возвращает ключ распечатывания и канал, который закроется при следующем запечатывании.
+func WaitUnseal(
+	ctx context.Context,
+	cfg *config.Config,
+	barrier *seal.Barrier,
+	log *zap.Logger,
+) ([]byte, <-chan struct{}, error) {
+	op := "app.WaitUnseal"
+
+	listen, err := net.Listen("tcp", cfg.RPC.Address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get listen for sealed gRPC server %w", op, err)
+	}
+
+	srv := NewSealedServer(cfg, barrier, log)
+	defer srv.GracefulStop()
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listen)
+		cancel()
+	}()
+
+	log.Info("server is sealed, waiting for unseal shares", zap.Int("threshold", barrier.Status().Threshold))
+
+	for {
+		key, sealed, err := barrier.WaitUnseal(waitCtx)
+		if errors.Is(err, seal.ErrSealed) {
+			continue
+		}
+		if err != nil {
+			select {
+			case serr := <-serveErr:
+				return nil, nil, fmt.Errorf("%s: error serving sealed gRPC server %w", op, serr)
+			default:
+				return nil, nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return key, sealed, nil
+	}
+}
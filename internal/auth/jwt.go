@@ -2,31 +2,87 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+const tokenIDLen = 16
+
+// PurposeMFA назначение токена подтверждения второго фактора. Такой токен выдается после проверки пароля
+// и принимается только методами второго шага входа.
+const PurposeMFA = "mfa"
+
 // Claims структура утверждений для JWT токена.
 type Claims struct {
 	jwt.RegisteredClaims
 	UserID int
+	// SessionID сессия, к которой привязан токен доступа. Отзыв сессии делает токен недействительным.
+	SessionID string `json:"sid,omitempty"`
+	// Purpose назначение токена, пусто для токена доступа.
+	Purpose string `json:"pur,omitempty"`
 }
 
 // BuildJWTToken строит JWT токен.
 func BuildJWTToken(userID int, secretKey string, tokenLifeTime time.Duration) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+	return BuildSessionToken(userID, "", secretKey, tokenLifeTime)
+}
+
+// BuildSessionToken строит токен доступа сессии, подписанный общим ключом HS256.
+func BuildSessionToken(userID int, sessionID, secretKey string, tokenLifeTime time.Duration) (string, error) {
+	return NewHMACKeySet(secretKey).BuildSessionToken(userID, sessionID, tokenLifeTime)
+}
+
+// BuildSessionToken строит токен доступа сессии с уникальным ID (jti), подписанный активным ключом набора.
+func (ks *KeySet) BuildSessionToken(userID int, sessionID string, tokenLifeTime time.Duration) (string, error) {
+	jti := make([]byte, tokenIDLen)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("error generating token id %w", err)
+	}
+
+	now := time.Now()
+	tokenString, err := ks.sign(Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenLifeTime)),
+			ID:        hex.EncodeToString(jti),
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifeTime)),
 		},
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 	})
+	if err != nil {
+		return "", fmt.Errorf("error signing token %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// BuildMFAToken строит короткоживущий токен второго шага входа, не привязанный к сессии.
+func (ks *KeySet) BuildMFAToken(userID int, tokenLifeTime time.Duration) (string, error) {
+	jti := make([]byte, tokenIDLen)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("error generating token id %w", err)
+	}
 
-	tokenString, err := token.SignedString([]byte(secretKey))
+	now := time.Now()
+	tokenString, err := ks.sign(Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(jti),
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifeTime)),
+		},
+		UserID:  userID,
+		Purpose: PurposeMFA,
+	})
 	if err != nil {
-		return "", fmt.Errorf("error signed string from []byte %w", err)
+		return "", fmt.Errorf("error signing mfa token %w", err)
 	}
 
 	return tokenString, nil
@@ -34,23 +90,33 @@ func BuildJWTToken(userID int, secretKey string, tokenLifeTime time.Duration) (s
 
 // GetUserIDbyToken вытаскивает из токена ID пользователя.
 func GetUserIDbyToken(tokenString string, secretKey string) (int, error) {
+	claims, err := ParseToken(tokenString, secretKey)
+	if err != nil {
+		return -1, err
+	}
+
+	return claims.UserID, nil
+}
+
+// ParseToken проверка подписи HS256 и срока действия токена и получение его утверждений.
+func ParseToken(tokenString string, secretKey string) (*Claims, error) {
+	return NewHMACKeySet(secretKey).ParseToken(tokenString)
+}
+
+// ParseToken проверка подписи ключом набора по kid и срока действия токена и получение его утверждений.
+func (ks *KeySet) ParseToken(tokenString string) (*Claims, error) {
 	claims := Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		if token.Method != jwt.SigningMethodHS256 {
-			return nil, fmt.Errorf("unexpected token singing method: %s", token.Method)
-		}
-		return []byte(secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &claims, ks.keyFunc)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return -1, errors.New("token expired")
+			return nil, errors.New("token expired")
 		}
-		return -1, fmt.Errorf("error parsing token with claims %w", err)
+		return nil, fmt.Errorf("error parsing token with claims %w", err)
 	}
 
 	if !token.Valid {
-		return -1, errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
-	return claims.UserID, nil
+	return &claims, nil
 }
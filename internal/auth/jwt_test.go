@@ -84,3 +84,48 @@ func TestGetUserIDbyToken(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSessionToken(t *testing.T) {
+	first, err := auth.BuildSessionToken(1, "session", secretKey, toketTTL)
+	require.NoError(t, err)
+
+	second, err := auth.BuildSessionToken(1, "session", secretKey, toketTTL)
+	require.NoError(t, err)
+
+	claims, err := auth.ParseToken(first, secretKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claims.UserID)
+	assert.Equal(t, "session", claims.SessionID)
+	assert.NotEmpty(t, claims.ID)
+
+	other, err := auth.ParseToken(second, secretKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, claims.ID, other.ID)
+
+	_, err = auth.ParseToken(first, "another key")
+	require.Error(t, err)
+
+	expired, err := auth.BuildSessionToken(1, "session", secretKey, -time.Minute)
+	require.NoError(t, err)
+	_, err = auth.ParseToken(expired, secretKey)
+	require.Error(t, err)
+}
+
+func TestBuildMFAToken(t *testing.T) {
+	ks := auth.NewHMACKeySet(secretKey)
+
+	token, err := ks.BuildMFAToken(1, toketTTL)
+	require.NoError(t, err)
+
+	claims, err := ks.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claims.UserID)
+	assert.Equal(t, auth.PurposeMFA, claims.Purpose)
+	assert.Empty(t, claims.SessionID)
+
+	access, err := ks.BuildSessionToken(1, "session", toketTTL)
+	require.NoError(t, err)
+	claims, err = ks.ParseToken(access)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Purpose)
+}
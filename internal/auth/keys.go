@@ -0,0 +1,346 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Алгоритмы подписи токенов.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmES256 = "ES256"
+)
+
+const (
+	pemPrivateKey = "PRIVATE KEY"
+	pemPublicKey  = "PUBLIC KEY"
+	es256CoordLen = 32
+)
+
+var (
+	// ErrUnsupportedKey тип ключа не подходит ни для EdDSA, ни для ES256.
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+	// ErrUnknownKey в заголовке токена указан kid, которого нет среди ключей проверки.
+	ErrUnknownKey = errors.New("unknown token key id")
+)
+
+// Key ключ подписи или проверки токенов. ID (kid) вычисляется по открытому ключу (RFC 7638),
+// поэтому один и тот же ключ всегда получает одинаковый kid.
+type Key struct {
+	ID        string
+	Algorithm string
+
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet набор ключей: активный ключ подписи и все ключи, которыми еще можно проверить токен.
+// Старый ключ остается в наборе проверки на время ротации, пока не истекут подписанные им токены.
+type KeySet struct {
+	signing *Key
+	verify  map[string]*Key
+
+	// hmacSecret общий ключ HS256, если асимметричные ключи не настроены.
+	hmacSecret []byte
+}
+
+// NewHMACKeySet набор из одного общего ключа HS256.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{hmacSecret: []byte(secret)}
+}
+
+// NewKeySet набор с ключом подписи signing и дополнительными ключами проверки.
+func NewKeySet(signing *Key, verification ...*Key) (*KeySet, error) {
+	if signing == nil || signing.private == nil {
+		return nil, errors.New("signing key must contain private key")
+	}
+
+	ks := KeySet{
+		signing: signing,
+		verify:  map[string]*Key{signing.ID: signing},
+	}
+	for _, k := range verification {
+		ks.verify[k.ID] = k
+	}
+
+	return &ks, nil
+}
+
+// LoadKeySet загрузка набора ключей из PEM файлов. Если путь к ключу подписи пуст,
+// используется HS256 с общим ключом hmacSecret.
+func LoadKeySet(signingPath string, verificationPaths []string, hmacSecret string) (*KeySet, error) {
+	op := "auth.LoadKeySet"
+
+	if signingPath == "" {
+		if len(verificationPaths) > 0 {
+			return nil, fmt.Errorf("%s: verification keys require a signing key", op)
+		}
+		return NewHMACKeySet(hmacSecret), nil
+	}
+
+	signing, err := readKeyFile(signingPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	verification := make([]*Key, 0, len(verificationPaths))
+	for _, path := range verificationPaths {
+		k, err := readKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		verification = append(verification, k)
+	}
+
+	ks, err := NewKeySet(signing, verification...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ks, nil
+}
+
+// GenerateKey генерация нового ключа подписи для алгоритма EdDSA или ES256.
+func GenerateKey(alg string) (*Key, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+
+	switch alg {
+	case AlgorithmEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	case AlgorithmES256:
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: algorithm %q", ErrUnsupportedKey, alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error generating %s key %w", alg, err)
+	}
+
+	return newKey(signer, signer.Public())
+}
+
+// ParseKeyPEM разбор ключа из PEM: закрытого (PKCS#8) для подписи или открытого (PKIX) только для проверки.
+func ParseKeyPEM(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case pemPrivateKey:
+		priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key %w", err)
+		}
+		signer, ok := priv.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKey
+		}
+		return newKey(signer, signer.Public())
+	case pemPublicKey:
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing public key %w", err)
+		}
+		return newKey(nil, pub)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+}
+
+// MarshalPrivatePEM закрытый ключ в формате PKCS#8 PEM.
+func (k *Key) MarshalPrivatePEM() ([]byte, error) {
+	if k.private == nil {
+		return nil, errors.New("key has no private part")
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(k.private)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: der}), nil
+}
+
+// MarshalPublicPEM открытый ключ в формате PKIX PEM, для раздачи сервисам, проверяющим токены.
+func (k *Key) MarshalPublicPEM() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(k.public)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling public key %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: der}), nil
+}
+
+// Algorithm алгоритм подписи новых токенов.
+func (ks *KeySet) Algorithm() string {
+	if ks.signing == nil {
+		return AlgorithmHS256
+	}
+	return ks.signing.Algorithm
+}
+
+// sign подпись утверждений активным ключом с указанием его kid в заголовке.
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	if ks.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(ks.signing.Algorithm), claims)
+	token.Header["kid"] = ks.signing.ID
+
+	return token.SignedString(ks.signing.private)
+}
+
+// keyFunc выбор ключа проверки по kid. Алгоритм токена должен совпадать с алгоритмом ключа,
+// а HS256 принимается, только если асимметричные ключи не настроены.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	if ks.signing == nil {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected token singing method: %s", token.Method.Alg())
+		}
+		return ks.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	k, ok := ks.verify[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != k.Algorithm {
+		return nil, fmt.Errorf("unexpected token singing method: %s", token.Method.Alg())
+	}
+
+	return k.public, nil
+}
+
+// jwk открытый ключ в формате JSON Web Key (RFC 7517).
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// JWKS документ JSON Web Key Set со всеми ключами проверки. Для HS256 набор пуст:
+// общий ключ не публикуется.
+func (ks *KeySet) JWKS() ([]byte, error) {
+	keys := make([]jwk, 0, len(ks.verify))
+	for _, k := range ks.verify {
+		j, err := publicJWK(k.public)
+		if err != nil {
+			return nil, err
+		}
+		j.Kid, j.Alg, j.Use = k.ID, k.Algorithm, "sig"
+		keys = append(keys, j)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+
+	doc, err := json.Marshal(struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: keys})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JWKS %w", err)
+	}
+
+	return doc, nil
+}
+
+func newKey(private crypto.Signer, public crypto.PublicKey) (*Key, error) {
+	j, err := publicJWK(public)
+	if err != nil {
+		return nil, err
+	}
+
+	k := Key{private: private, public: public}
+	switch j.Kty {
+	case "OKP":
+		k.Algorithm = AlgorithmEdDSA
+	default:
+		k.Algorithm = AlgorithmES256
+	}
+
+	k.ID, err = thumbprint(j)
+	if err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+func publicJWK(public crypto.PublicKey) (jwk, error) {
+	switch pub := public.(type) {
+	case ed25519.PublicKey:
+		return jwk{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub)}, nil
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return jwk{}, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, pub.Curve.Params().Name)
+		}
+		ecdhKey, err := pub.ECDH()
+		if err != nil {
+			return jwk{}, fmt.Errorf("error converting ecdsa key %w", err)
+		}
+		// Несжатая точка: 0x04 || X || Y
+		point := ecdhKey.Bytes()[1:]
+		return jwk{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(point[:es256CoordLen]),
+			Y:   base64.RawURLEncoding.EncodeToString(point[es256CoordLen:]),
+		}, nil
+	default:
+		return jwk{}, fmt.Errorf("%w: %T", ErrUnsupportedKey, public)
+	}
+}
+
+// thumbprint отпечаток JWK по RFC 7638: sha256 от обязательных полей в лексикографическом порядке.
+func thumbprint(j jwk) (string, error) {
+	members := map[string]string{"kty": j.Kty, "crv": j.Crv, "x": j.X}
+	if j.Y != "" {
+		members["y"] = j.Y
+	}
+
+	// encoding/json сортирует ключи map, что и требуется для канонической формы
+	canonical, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("error computing key thumbprint %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func readKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key file %s %w", path, err)
+	}
+
+	k, err := ParseKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing key file %s %w", path, err)
+	}
+
+	return k, nil
+}
@@ -0,0 +1,182 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/auth"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet(t *testing.T) {
+	testCases := []struct {
+		name string
+		alg  string
+	}{
+		{name: "EdDSA", alg: auth.AlgorithmEdDSA},
+		{name: "ES256", alg: auth.AlgorithmES256},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := auth.GenerateKey(test.alg)
+			require.NoError(t, err)
+			assert.Equal(t, test.alg, key.Algorithm)
+			assert.NotEmpty(t, key.ID)
+
+			ks, err := auth.NewKeySet(key)
+			require.NoError(t, err)
+
+			token, err := ks.BuildSessionToken(1, "session", toketTTL)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &auth.Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, test.alg, parsed.Method.Alg())
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+
+			claims, err := ks.ParseToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, 1, claims.UserID)
+			assert.Equal(t, "1", claims.Subject)
+			assert.Equal(t, "session", claims.SessionID)
+
+			other, err := auth.GenerateKey(test.alg)
+			require.NoError(t, err)
+			otherKS, err := auth.NewKeySet(other)
+			require.NoError(t, err)
+			_, err = otherKS.ParseToken(token)
+			require.ErrorIs(t, err, auth.ErrUnknownKey)
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey, err := auth.GenerateKey(auth.AlgorithmEdDSA)
+	require.NoError(t, err)
+	newKey, err := auth.GenerateKey(auth.AlgorithmES256)
+	require.NoError(t, err)
+
+	before, err := auth.NewKeySet(oldKey)
+	require.NoError(t, err)
+	oldToken, err := before.BuildSessionToken(1, "session", toketTTL)
+	require.NoError(t, err)
+
+	rotated, err := auth.NewKeySet(newKey, oldKey)
+	require.NoError(t, err)
+	newToken, err := rotated.BuildSessionToken(1, "session", toketTTL)
+	require.NoError(t, err)
+
+	_, err = rotated.ParseToken(oldToken)
+	require.NoError(t, err)
+	_, err = rotated.ParseToken(newToken)
+	require.NoError(t, err)
+
+	retired, err := auth.NewKeySet(newKey)
+	require.NoError(t, err)
+	_, err = retired.ParseToken(oldToken)
+	require.ErrorIs(t, err, auth.ErrUnknownKey)
+
+	doc, err := rotated.JWKS()
+	require.NoError(t, err)
+
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(doc, &jwks))
+	require.Len(t, jwks.Keys, 2)
+
+	kids := map[string]string{}
+	for _, k := range jwks.Keys {
+		kids[k["kid"]] = k["alg"]
+		assert.Equal(t, "sig", k["use"])
+		assert.NotContains(t, k, "d")
+	}
+	assert.Equal(t, map[string]string{
+		oldKey.ID: auth.AlgorithmEdDSA,
+		newKey.ID: auth.AlgorithmES256,
+	}, kids)
+}
+
+func TestKeySetRejectsHMAC(t *testing.T) {
+	key, err := auth.GenerateKey(auth.AlgorithmEdDSA)
+	require.NoError(t, err)
+	ks, err := auth.NewKeySet(key)
+	require.NoError(t, err)
+
+	// Токен HS256 с kid асимметричного ключа не должен проходить проверку
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{UserID: 1, SessionID: "session"})
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString([]byte(secretKey))
+	require.NoError(t, err)
+
+	_, err = ks.ParseToken(signed)
+	require.Error(t, err)
+
+	hmacToken, err := auth.BuildSessionToken(1, "session", secretKey, toketTTL)
+	require.NoError(t, err)
+	_, err = ks.ParseToken(hmacToken)
+	require.Error(t, err)
+
+	doc, err := auth.NewHMACKeySet(secretKey).JWKS()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":[]}`, string(doc))
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	writeKey := func(name, alg string) *auth.Key {
+		key, err := auth.GenerateKey(alg)
+		require.NoError(t, err)
+
+		private, err := key.MarshalPrivatePEM()
+		require.NoError(t, err)
+		public, err := key.MarshalPublicPEM()
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), private, 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".pub"), public, 0o600))
+
+		return key
+	}
+
+	current := writeKey("current.pem", auth.AlgorithmES256)
+	previous := writeKey("previous.pem", auth.AlgorithmEdDSA)
+
+	ks, err := auth.LoadKeySet(
+		filepath.Join(dir, "current.pem"), []string{filepath.Join(dir, "previous.pem.pub")}, "",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, auth.AlgorithmES256, ks.Algorithm())
+
+	prevKS, err := auth.NewKeySet(previous)
+	require.NoError(t, err)
+	prevToken, err := prevKS.BuildSessionToken(2, "session", toketTTL)
+	require.NoError(t, err)
+
+	claims, err := ks.ParseToken(prevToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claims.UserID)
+
+	token, err := ks.BuildSessionToken(3, "session", toketTTL)
+	require.NoError(t, err)
+	currentKS, err := auth.NewKeySet(current)
+	require.NoError(t, err)
+	_, err = currentKS.ParseToken(token)
+	require.NoError(t, err)
+
+	_, err = auth.LoadKeySet(filepath.Join(dir, "current.pem.pub"), nil, "")
+	require.Error(t, err)
+
+	_, err = auth.LoadKeySet("", []string{filepath.Join(dir, "previous.pem.pub")}, secretKey)
+	require.Error(t, err)
+
+	hmac, err := auth.LoadKeySet("", nil, secretKey)
+	require.NoError(t, err)
+	assert.Equal(t, auth.AlgorithmHS256, hmac.Algorithm())
+}
@@ -0,0 +1,128 @@
+package breach_test
+
+import (
+	"crypto/sha1" //nolint:gosec // формат базы HIBP
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var breached = map[string]uint32{
+	"password": 9545824,
+	"123456":   37359195,
+	"qwerty":   10556095,
+	"dragon":   1000,
+	"letmein":  500,
+}
+
+func hashOf(password string) string {
+	return fmt.Sprintf("%X", sha1.Sum([]byte(password))) //nolint:gosec // формат базы HIBP
+}
+
+func sortedHashes() []string {
+	var hashes []string
+	for p, n := range breached {
+		hashes = append(hashes, fmt.Sprintf("%s:%d", hashOf(p), n))
+	}
+	sort.Strings(hashes)
+
+	return hashes
+}
+
+// writeRangeDir каталог в формате PwnedPasswordsDownloader: файл на префикс, строки SUFFIX:COUNT.
+func writeRangeDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, line := range sortedHashes() {
+		path := filepath.Join(dir, line[:breach.PrefixLen]+".txt")
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = fmt.Fprintln(f, line[breach.PrefixLen:])
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	return dir
+}
+
+func buildIndex(t *testing.T, src string) *breach.Index {
+	t.Helper()
+
+	dst := filepath.Join(t.TempDir(), "hibp.idx")
+	n, err := breach.Build(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(breached)), n)
+
+	ix, err := breach.Open(dst)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ix.Close() })
+
+	return ix
+}
+
+func TestIndex(t *testing.T) {
+	single := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(single, []byte(strings.Join(sortedHashes(), "\r\n")+"\r\n"), 0o600))
+
+	sources := map[string]string{
+		"range directory": writeRangeDir(t),
+		"single file":     single,
+	}
+
+	for name, src := range sources {
+		t.Run(name, func(t *testing.T) {
+			ix := buildIndex(t, src)
+			assert.Equal(t, uint64(len(breached)), ix.Len())
+
+			for p, want := range breached {
+				got, err := ix.CheckPassword(p)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, p)
+			}
+
+			got, err := ix.CheckPassword("correct horse battery staple 42")
+			require.NoError(t, err)
+			assert.Zero(t, got)
+
+			hash := hashOf("password")
+			matches, err := ix.Range(strings.ToLower(hash[:breach.PrefixLen]))
+			require.NoError(t, err)
+			assert.Contains(t, matches, breach.Match{Suffix: hash[breach.PrefixLen:], Count: breached["password"]})
+			for _, m := range matches {
+				assert.Len(t, m.Suffix, 2*sha1.Size-breach.PrefixLen)
+			}
+
+			_, err = ix.Range("ABC")
+			require.ErrorIs(t, err, breach.ErrInvalidPrefix)
+		})
+	}
+}
+
+func TestBuildUnsorted(t *testing.T) {
+	hashes := sortedHashes()
+	hashes[0], hashes[1] = hashes[1], hashes[0]
+
+	src := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(src, []byte(strings.Join(hashes, "\n")), 0o600))
+
+	dst := filepath.Join(t.TempDir(), "hibp.idx")
+	_, err := breach.Build(src, dst)
+	require.ErrorIs(t, err, breach.ErrUnsorted)
+	assert.NoFileExists(t, dst)
+}
+
+func TestOpenInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hibp.idx")
+	require.NoError(t, os.WriteFile(path, []byte("not an index at all"), 0o600))
+
+	_, err := breach.Open(path)
+	require.ErrorIs(t, err, breach.ErrInvalidIndex)
+}
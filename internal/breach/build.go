@@ -0,0 +1,218 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 формат базы HIBP
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const indexFilePerm = 0o644
+
+// ErrUnsorted хеши источника не отсортированы или повторяются.
+var ErrUnsorted = errors.New("breach source is not sorted")
+
+// Build построение индекса dst из источника src и возврат числа хешей. Источник либо каталог файлов
+// диапазонов HIBP (имя файла префикс из PrefixLen символов, строки "SUFFIX:COUNT"), как их выгружает
+// PwnedPasswordsDownloader, либо один файл со строками "SHA1:COUNT". Хеши должны идти по возрастанию.
+// Индекс пишется во временный файл и заменяет dst только после успешного построения.
+func Build(src, dst string) (uint64, error) {
+	op := "breach.Build"
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return 0, fmt.Errorf("%s: error reading source %w", op, err)
+	}
+
+	tmp := dst + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_RDWR, indexFilePerm)
+	if err != nil {
+		return 0, fmt.Errorf("%s: error creating index %w", op, err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+	}()
+
+	w := newWriter(f)
+	if info.IsDir() {
+		err = w.addRangeDir(src)
+	} else {
+		err = w.addFile(src, "")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = w.finish(); err != nil {
+		return 0, fmt.Errorf("%s: error writing index %w", op, err)
+	}
+	if err = f.Close(); err != nil {
+		return 0, fmt.Errorf("%s: error closing index %w", op, err)
+	}
+	if err = os.Rename(tmp, dst); err != nil {
+		return 0, fmt.Errorf("%s: error replacing index %w", op, err)
+	}
+
+	return w.count, nil
+}
+
+// writer последовательная запись отсортированных хешей в индекс.
+type writer struct {
+	f       *os.File
+	buf     *bufio.Writer
+	count   uint64
+	buckets []uint64
+	last    []byte
+}
+
+func newWriter(f *os.File) *writer {
+	w := writer{
+		f:       f,
+		buf:     bufio.NewWriter(f),
+		buckets: make([]uint64, fanoutSize),
+	}
+	// Место под заголовок, число записей известно только в конце
+	_, _ = w.buf.Write(make([]byte, headerSize))
+
+	return &w
+}
+
+func (w *writer) add(hash []byte, count uint32) error {
+	if w.last != nil && bytes.Compare(hash, w.last) <= 0 {
+		return fmt.Errorf("%w: %X after %X", ErrUnsorted, hash, w.last)
+	}
+	w.last = append(w.last[:0], hash...)
+
+	rec := make([]byte, recordSize)
+	copy(rec, hash[fanoutBytes:])
+	binary.BigEndian.PutUint32(rec[suffixSize:], count)
+	if _, err := w.buf.Write(rec); err != nil {
+		return fmt.Errorf("error writing record %w", err)
+	}
+
+	w.buckets[binary.BigEndian.Uint16(hash)]++
+	w.count++
+
+	return nil
+}
+
+// addRangeDir добавление файлов диапазонов из каталога в порядке префиксов.
+func (w *writer) addRangeDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error reading range directory %w", err)
+	}
+
+	var prefixes []string
+	files := make(map[string]string, len(entries))
+	for _, e := range entries {
+		prefix := strings.ToUpper(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())))
+		if e.IsDir() || len(prefix) != PrefixLen {
+			continue
+		}
+		if _, err = hex.DecodeString(prefix + "0"); err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+		files[prefix] = filepath.Join(dir, e.Name())
+	}
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		if err = w.addFile(files[prefix], prefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addFile добавление строк "SUFFIX:COUNT" файла диапазона prefix или строк "SHA1:COUNT" при пустом prefix.
+func (w *writer) addFile(path, prefix string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		hash, count, parseErr := parseLine(prefix + text)
+		if parseErr != nil {
+			return fmt.Errorf("%s:%d: %w", path, line, parseErr)
+		}
+		if err = w.add(hash, count); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s %w", path, err)
+	}
+
+	return nil
+}
+
+func (w *writer) finish() error {
+	fanout := make([]byte, fanoutSize*8)
+	var total uint64
+	for i, n := range w.buckets {
+		total += n
+		binary.BigEndian.PutUint64(fanout[i*8:], total)
+	}
+	if _, err := w.buf.Write(fanout); err != nil {
+		return fmt.Errorf("error writing fanout %w", err)
+	}
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("error flushing index %w", err)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.BigEndian.PutUint64(header[len(magic):], w.count)
+	if _, err := w.f.WriteAt(header, 0); err != nil {
+		return fmt.Errorf("error writing header %w", err)
+	}
+
+	if err := w.f.Sync(); err != nil {
+		return fmt.Errorf("error syncing index %w", err)
+	}
+
+	return nil
+}
+
+// parseLine разбор строки "SHA1:COUNT". Число утечек больше MaxUint32 ограничивается.
+func parseLine(line string) ([]byte, uint32, error) {
+	hexHash, rawCount, ok := strings.Cut(line, ":")
+	if !ok {
+		return nil, 0, errors.New("expected HASH:COUNT")
+	}
+
+	hash, err := hex.DecodeString(hexHash)
+	if err != nil || len(hash) != sha1.Size {
+		return nil, 0, fmt.Errorf("invalid SHA-1 hash %q", hexHash)
+	}
+
+	count, err := strconv.ParseUint(rawCount, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid count %q", rawCount)
+	}
+
+	return hash, uint32(min(count, math.MaxUint32)), nil
+}
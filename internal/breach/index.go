@@ -0,0 +1,223 @@
+// Package breach пакет проверки паролей по локальной копии базы утечек Have I Been Pwned.
+// Хеши SHA-1 из файлов диапазонов HIBP импортируются в индекс на диске, поиск по которому не требует сети:
+// таблица смещений по первым двум байтам хеша хранится в памяти, записи внутри блока ищутся двоичным поиском
+// чтением с диска.
+//
+// Формат индекса: заголовок (magic, число записей), отсортированные записи по recordSize байт
+// (байты хеша со 2-го по 19-й и число утечек в big-endian), таблица смещений из fanoutSize значений uint64:
+// i-е значение равно числу записей, первые два байта хеша которых не больше i.
+package breach
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 формат базы HIBP, не используется для защиты данных
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	magic      = "GKHIBP1\n"
+	headerSize = len(magic) + 8
+
+	fanoutBytes = 2
+	fanoutSize  = 1 << (8 * fanoutBytes)
+	suffixSize  = sha1.Size - fanoutBytes
+	countSize   = 4
+	recordSize  = suffixSize + countSize
+	nibbleShift = 4
+
+	// PrefixLen длина префикса хеша в hex для k-анонимного запроса диапазона, как в API HIBP.
+	PrefixLen = 5
+)
+
+var (
+	// ErrInvalidIndex файл не является индексом утечек или поврежден.
+	ErrInvalidIndex = errors.New("invalid breach index")
+	// ErrInvalidPrefix неверный префикс хеша для запроса диапазона.
+	ErrInvalidPrefix = errors.New("invalid hash prefix")
+)
+
+// Match хеш из диапазона и число его появлений в утечках.
+type Match struct {
+	// Suffix оставшиеся после префикса символы хеша SHA-1 в hex верхнего регистра.
+	Suffix string
+	Count  uint32
+}
+
+// Index индекс утечек на диске. Безопасен для конкурентного использования.
+type Index struct {
+	f      *os.File
+	count  uint64
+	fanout []uint64
+}
+
+// Open открытие индекса, построенного Build.
+func Open(path string) (*Index, error) {
+	op := "breach.Open"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: error opening breach index %w", op, err)
+	}
+
+	ix, err := load(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ix, nil
+}
+
+func load(f *os.File) (*Index, error) {
+	header := make([]byte, headerSize)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("%w: error reading header %w", ErrInvalidIndex, err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, fmt.Errorf("%w: unknown format", ErrInvalidIndex)
+	}
+
+	ix := Index{
+		f:      f,
+		count:  binary.BigEndian.Uint64(header[len(magic):]),
+		fanout: make([]uint64, fanoutSize),
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error getting breach index size %w", err)
+	}
+	fanoutOffset := int64(headerSize) + int64(ix.count)*recordSize //nolint:gosec // размер проверяется ниже
+	if info.Size() != fanoutOffset+fanoutSize*8 {
+		return nil, fmt.Errorf("%w: unexpected size", ErrInvalidIndex)
+	}
+
+	raw := make([]byte, fanoutSize*8)
+	if _, err = f.ReadAt(raw, fanoutOffset); err != nil {
+		return nil, fmt.Errorf("%w: error reading fanout %w", ErrInvalidIndex, err)
+	}
+	for i := range ix.fanout {
+		ix.fanout[i] = binary.BigEndian.Uint64(raw[i*8:])
+		if i > 0 && ix.fanout[i] < ix.fanout[i-1] {
+			return nil, fmt.Errorf("%w: fanout is not sorted", ErrInvalidIndex)
+		}
+	}
+	if ix.fanout[fanoutSize-1] != ix.count {
+		return nil, fmt.Errorf("%w: fanout does not match records count", ErrInvalidIndex)
+	}
+
+	return &ix, nil
+}
+
+// Close закрытие файла индекса.
+func (ix *Index) Close() error {
+	if err := ix.f.Close(); err != nil {
+		return fmt.Errorf("breach.Index.Close: %w", err)
+	}
+
+	return nil
+}
+
+// Len число хешей в индексе.
+func (ix *Index) Len() uint64 {
+	return ix.count
+}
+
+// CheckPassword число появлений пароля в утечках, 0 если пароль не найден.
+func (ix *Index) CheckPassword(password string) (uint32, error) {
+	return ix.Lookup(sha1.Sum([]byte(password))) //nolint:gosec // формат базы HIBP
+}
+
+// Lookup число появлений хеша SHA-1 в утечках, 0 если хеш не найден.
+func (ix *Index) Lookup(hash [sha1.Size]byte) (uint32, error) {
+	lo, hi := ix.bucket(hash[:fanoutBytes])
+
+	var lookupErr error
+	rec := make([]byte, recordSize)
+	i := sort.Search(int(hi-lo), func(i int) bool { //nolint:gosec // число записей блока мало
+		if lookupErr != nil {
+			return true
+		}
+		if lookupErr = ix.readRecord(lo+uint64(i), rec); lookupErr != nil { //nolint:gosec // i >= 0
+			return true
+		}
+		return bytes.Compare(rec[:suffixSize], hash[fanoutBytes:]) >= 0
+	})
+	if lookupErr != nil {
+		return 0, fmt.Errorf("breach.Index.Lookup: %w", lookupErr)
+	}
+
+	pos := lo + uint64(i) //nolint:gosec // i >= 0
+	if pos >= hi {
+		return 0, nil
+	}
+	if err := ix.readRecord(pos, rec); err != nil {
+		return 0, fmt.Errorf("breach.Index.Lookup: %w", err)
+	}
+	if !bytes.Equal(rec[:suffixSize], hash[fanoutBytes:]) {
+		return 0, nil
+	}
+
+	return binary.BigEndian.Uint32(rec[suffixSize:]), nil
+}
+
+// Range все хеши с префиксом из PrefixLen символов hex. Позволяет клиенту проверить пароль, не раскрывая
+// его хеш: сервер видит только префикс, общий для сотен хешей.
+func (ix *Index) Range(prefix string) ([]Match, error) {
+	op := "breach.Index.Range"
+
+	prefix = strings.ToUpper(prefix)
+	if len(prefix) != PrefixLen {
+		return nil, fmt.Errorf("%s: %w: must be %d hex characters", op, ErrInvalidPrefix, PrefixLen)
+	}
+	// Нечетный префикс дополняется нулем, последний полубайт сравнивается отдельно
+	head, err := hex.DecodeString(prefix + "0")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidPrefix, err)
+	}
+	nibble := head[fanoutBytes] >> nibbleShift
+
+	lo, hi := ix.bucket(head[:fanoutBytes])
+	raw := make([]byte, (hi-lo)*recordSize)
+	if _, err = ix.f.ReadAt(raw, int64(headerSize)+int64(lo)*recordSize); err != nil { //nolint:gosec // в пределах файла
+		return nil, fmt.Errorf("%s: error reading records %w", op, err)
+	}
+
+	var matches []Match
+	for rec := raw; len(rec) >= recordSize; rec = rec[recordSize:] {
+		if rec[0]>>nibbleShift != nibble {
+			continue
+		}
+		matches = append(matches, Match{
+			Suffix: strings.ToUpper(hex.EncodeToString(rec[:suffixSize]))[1:],
+			Count:  binary.BigEndian.Uint32(rec[suffixSize:]),
+		})
+	}
+
+	return matches, nil
+}
+
+// bucket номера записей [lo, hi), первые два байта хеша которых равны head.
+func (ix *Index) bucket(head []byte) (uint64, uint64) {
+	i := binary.BigEndian.Uint16(head)
+	if i == 0 {
+		return 0, ix.fanout[0]
+	}
+
+	return ix.fanout[i-1], ix.fanout[i]
+}
+
+func (ix *Index) readRecord(i uint64, rec []byte) error {
+	// Записи всегда предшествуют таблице смещений, поэтому чтение не доходит до конца файла
+	if _, err := ix.f.ReadAt(rec, int64(headerSize)+int64(i)*recordSize); err != nil { //nolint:gosec // в пределах файла
+		return fmt.Errorf("error reading record %w", err)
+	}
+
+	return nil
+}
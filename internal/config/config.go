@@ -1,12 +1,15 @@
 // Package config пакет с конфигом приложения.
 package config
 
-import "time"
+import (
+	"time"
+)
 
 // Config структура конфиг файла.
 type Config struct {
 	Env      string         `yaml:"env"      env-default:"local"`
 	RPC      RPCConfig      `yaml:"rpc"                          env-required:"true"`
+	HTTP     HTTPConfig     `yaml:"http"`
 	Database DatabaseConfig `yaml:"database"                     env-required:"true"`
 	Logging  LoggingConfig  `yaml:"logging"                      env-required:"false"`
 	Security SecurityConfig `yaml:"security"`
@@ -17,9 +20,14 @@ type RPCConfig struct {
 	Address string `yaml:"address" env:"GK_GRPC_ADDR" env-default:":50051"`
 }
 
+// HTTPConfig структура конфига для HTTP сервера служебных эндпоинтов (JWKS).
+type HTTPConfig struct {
+	Address string `yaml:"address" env:"GK_HTTP_ADDR" env-default:":8080"`
+}
+
 // DatabaseConfig структура конфига для базы данных.
 type DatabaseConfig struct {
-	URI                 string `yaml:"uri"                   env:"GK_DATABASE_URI"             env-required:"true"`
+	URI                 string `yaml:"uri"                   env:"GK_DATABASE_URI"             env-required:"true" json:"-"`
 	ExternalStoragePath string `yaml:"external_storage_path"                                   env-required:"true"`
 	MigrationsPath      string `yaml:"migrations_path"       env:"GK_DATABASE_MIGRATIONS_PATH" env-required:"true"`
 	MaxCons             int    `yaml:"max_cons"              env:"GK_DATABASE_MAX_CONS"        env-default:"20"`
@@ -31,9 +39,170 @@ type LoggingConfig struct {
 }
 
 // SecurityConfig структура конфига параметров безопасности.
+// TokenTTL время жизни токена доступа, RefreshTokenTTL время жизни сессии без обновления токена.
 type SecurityConfig struct {
-	Pepper    string        `yaml:"pepper" env-required:"true"`
-	TokenKey  string        `yaml:"token_key" env:"GK_TOKEN_KEY" env-required:"true"`
-	TokenTTL  time.Duration `yaml:"token_ttl" env:"GK_TOKEN_TTL" env-default:"12h"`
-	MasterKey []byte
+	Pepper          string                `yaml:"pepper" env-required:"true" json:"-"`
+	TokenKey        string                `yaml:"token_key" env:"GK_TOKEN_KEY" env-required:"true" json:"-"`
+	TokenTTL        time.Duration         `yaml:"token_ttl" env:"GK_TOKEN_TTL" env-default:"15m"`
+	RefreshTokenTTL time.Duration         `yaml:"refresh_token_ttl" env:"GK_REFRESH_TOKEN_TTL" env-default:"720h"`
+	JWT             JWTConfig             `yaml:"jwt"`
+	MFA             MFAConfig             `yaml:"mfa"`
+	Throttle        ThrottleConfig        `yaml:"throttle"`
+	PasswordPolicy  PasswordPolicyConfig  `yaml:"password_policy"`
+	PasswordHashing PasswordHashingConfig `yaml:"password_hashing"`
+	AccountDeletion AccountDeletionConfig `yaml:"account_deletion"`
+	Registration    RegistrationConfig    `yaml:"registration"`
+	OneTimeShare    OneTimeShareConfig    `yaml:"one_time_share"`
+	EmergencyAccess EmergencyAccessConfig `yaml:"emergency_access"`
+	SecretApproval  SecretApprovalConfig  `yaml:"secret_approval"`
+	FieldEncryption FieldEncryptionConfig `yaml:"field_encryption"`
+	KeyProvider     KeyProviderConfig     `yaml:"key_provider"`
+	// BreachIndexPath индекс локальной базы утечек HIBP, построенный keeperctl breach-import.
+	// Если не задан, проверка паролей по утечкам отключена.
+	BreachIndexPath string `yaml:"breach_index_path" env:"GK_BREACH_INDEX"`
+	// Cipher алгоритм шифрования новых данных: aes-256-gcm или xchacha20-poly1305.
+	// Уже зашифрованные данные читаются любым из них, алгоритм записан в самом шифротексте.
+	Cipher string `yaml:"cipher" env:"GK_CIPHER" env-default:"aes-256-gcm"`
+	// OperatorToken токен оператора для административных методов (например, запечатывания сервера).
+	// Если не задан, такие методы отключены.
+	OperatorToken string `yaml:"-" env:"GK_OPERATOR_TOKEN" json:"-"`
+}
+
+// JWTConfig структура конфига ключей подписи токенов доступа.
+// Если SigningKeyPath не задан, токены подписываются общим ключом token_key (HS256).
+type JWTConfig struct {
+	// SigningKeyPath закрытый ключ Ed25519 или P-256 в PKCS#8 PEM, алгоритм (EdDSA/ES256) определяется по ключу.
+	SigningKeyPath string `yaml:"signing_key_path" env:"GK_JWT_SIGNING_KEY_PATH"`
+	// VerificationKeyPaths ключи, которыми токены только проверяются: предыдущий ключ после ротации
+	// или следующий, заранее опубликованный в JWKS.
+	VerificationKeyPaths []string `yaml:"verification_key_paths" env:"GK_JWT_VERIFICATION_KEY_PATHS" env-separator:","`
+}
+
+// MFAConfig структура конфига второго фактора входа.
+type MFAConfig struct {
+	// Required политика обязательного второго фактора: вход только по паролю запрещен,
+	// пользователи без TOTP подключают его при следующем входе.
+	Required     bool          `yaml:"required"      env:"GK_MFA_REQUIRED"      env-default:"false"`
+	Issuer       string        `yaml:"issuer"        env:"GK_MFA_ISSUER"        env-default:"GophKeeper"`
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" env:"GK_MFA_CHALLENGE_TTL" env-default:"5m"`
+}
+
+// ThrottleConfig структура конфига защиты входа от перебора. Неудачи считаются отдельно по логину и по IP.
+// После FreeAttempts неудач подряд каждая следующая попытка откладывается на BaseDelay с удвоением до MaxDelay,
+// после LockoutAttempts вход блокируется на LockoutDuration. Счетчик забывается через ResetAfter без неудач.
+type ThrottleConfig struct {
+	LoginFreeAttempts    int           `yaml:"login_free_attempts"    env:"GK_THROTTLE_LOGIN_FREE"    env-default:"5"`
+	LoginLockoutAttempts int           `yaml:"login_lockout_attempts" env:"GK_THROTTLE_LOGIN_LOCKOUT" env-default:"10"`
+	IPFreeAttempts       int           `yaml:"ip_free_attempts"       env:"GK_THROTTLE_IP_FREE"       env-default:"20"`
+	IPLockoutAttempts    int           `yaml:"ip_lockout_attempts"    env:"GK_THROTTLE_IP_LOCKOUT"    env-default:"100"`
+	BaseDelay            time.Duration `yaml:"base_delay"             env:"GK_THROTTLE_BASE_DELAY"    env-default:"1s"`
+	MaxDelay             time.Duration `yaml:"max_delay"              env:"GK_THROTTLE_MAX_DELAY"     env-default:"5m"`
+	LockoutDuration      time.Duration `yaml:"lockout_duration"       env:"GK_THROTTLE_LOCKOUT"       env-default:"15m"`
+	ResetAfter           time.Duration `yaml:"reset_after"            env:"GK_THROTTLE_RESET_AFTER"   env-default:"1h"`
+}
+
+// PasswordPolicyConfig структура конфига политики паролей аккаунтов. Применяется к новым паролям при регистрации
+// и смене пароля; для E2E аккаунтов ту же политику, полученную через GetPasswordPolicy, проверяет клиент.
+// MinScore оценка стойкости от 0 до 4, BannedListPath файл с запрещенными паролями по одному в строке.
+type PasswordPolicyConfig struct {
+	MinLength      int    `yaml:"min_length"       env:"GK_PASSWORD_MIN_LENGTH"  env-default:"10"`
+	MinClasses     int    `yaml:"min_classes"      env:"GK_PASSWORD_MIN_CLASSES" env-default:"2"`
+	MinScore       int    `yaml:"min_score"        env:"GK_PASSWORD_MIN_SCORE"   env-default:"3"`
+	BannedListPath string `yaml:"banned_list_path" env:"GK_PASSWORD_BANNED_LIST"`
+}
+
+// PasswordHashingConfig структура конфига хэширования паролей аккаунтов. Новые хэши считаются схемой Scheme
+// (argon2id, bcrypt-sha256 или bcrypt) с перцем PepperID, старые хэши пересчитываются при успешном входе.
+// Пустой PepperID это security.pepper, Peppers дополнительные перцы по ID: перец нельзя убирать,
+// пока отчет keeperctl hash-report показывает хэши с ним. Argon2Memory задается в КиБ.
+type PasswordHashingConfig struct {
+	Scheme        string            `yaml:"scheme"         env:"GK_PASSWORD_HASH_SCHEME"     env-default:"argon2id"`
+	BcryptCost    int               `yaml:"bcrypt_cost"    env:"GK_PASSWORD_BCRYPT_COST"     env-default:"10"`
+	Argon2Memory  uint32            `yaml:"argon2_memory"  env:"GK_PASSWORD_ARGON2_MEMORY"   env-default:"65536"`
+	Argon2Time    uint32            `yaml:"argon2_time"    env:"GK_PASSWORD_ARGON2_TIME"     env-default:"3"`
+	Argon2Threads uint8             `yaml:"argon2_threads" env:"GK_PASSWORD_ARGON2_THREADS"  env-default:"2"`
+	PepperID      string            `yaml:"pepper_id"      env:"GK_PEPPER_ID"`
+	Peppers       map[string]string `yaml:"peppers"        env:"GK_PEPPERS"                  json:"-"`
+}
+
+// RegistrationConfig структура конфига регистрации. Mode: open (любой, кто может подключиться к серверу),
+// invite (только по коду приглашения администратора или оператора) или disabled.
+// InviteTTL срок действия приглашения по умолчанию.
+type RegistrationConfig struct {
+	Mode      string        `yaml:"mode"       env:"GK_REGISTRATION_MODE" env-default:"open"`
+	InviteTTL time.Duration `yaml:"invite_ttl" env:"GK_INVITE_TTL"        env-default:"168h"`
+}
+
+// AccountDeletionConfig структура конфига удаления аккаунтов. Запрошенное удаление можно отменить
+// в течение GracePeriod, после чего данные удаляются при очередной очистке раз в PurgeInterval.
+type AccountDeletionConfig struct {
+	GracePeriod   time.Duration `yaml:"grace_period"   env:"GK_ACCOUNT_DELETION_GRACE"  env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"GK_ACCOUNT_PURGE_INTERVAL"  env-default:"1h"`
+}
+
+// OneTimeShareConfig структура конфига одноразовых ссылок на секреты. BaseURL внешний адрес HTTP сервера,
+// от которого строятся ссылки (например, https://keeper.example.com); если он не задан, клиент получает
+// только ID и ключ ссылки. MaxSize наибольший размер содержимого ссылки в байтах.
+type OneTimeShareConfig struct {
+	BaseURL    string        `yaml:"base_url"    env:"GK_ONE_TIME_SHARE_URL"`
+	DefaultTTL time.Duration `yaml:"default_ttl" env:"GK_ONE_TIME_SHARE_TTL"       env-default:"24h"`
+	MaxTTL     time.Duration `yaml:"max_ttl"     env:"GK_ONE_TIME_SHARE_MAX_TTL"   env-default:"168h"`
+	MaxViews   int           `yaml:"max_views"   env:"GK_ONE_TIME_SHARE_MAX_VIEWS" env-default:"10"`
+	MaxSize    int           `yaml:"max_size"    env:"GK_ONE_TIME_SHARE_MAX_SIZE"  env-default:"1048576"`
+}
+
+// EmergencyAccessConfig структура конфига экстренного доступа. Владелец выбирает период ожидания
+// для каждого доверенного контакта в пределах MinWait..MaxWait, по умолчанию DefaultWait.
+type EmergencyAccessConfig struct {
+	DefaultWait time.Duration `yaml:"default_wait" env:"GK_EMERGENCY_DEFAULT_WAIT" env-default:"72h"`
+	MinWait     time.Duration `yaml:"min_wait"     env:"GK_EMERGENCY_MIN_WAIT"     env-default:"1h"`
+	MaxWait     time.Duration `yaml:"max_wait"     env:"GK_EMERGENCY_MAX_WAIT"     env-default:"720h"`
+}
+
+// SecretApprovalConfig структура конфига одобрения доступа к критичным секретам. Владелец выбирает
+// длительность доступа после одобрения не больше MaxGrant, по умолчанию DefaultGrant. Запрос без ответа
+// истекает через RequestTTL.
+type SecretApprovalConfig struct {
+	DefaultGrant time.Duration `yaml:"default_grant" env:"GK_APPROVAL_DEFAULT_GRANT" env-default:"1h"`
+	MaxGrant     time.Duration `yaml:"max_grant"     env:"GK_APPROVAL_MAX_GRANT"     env-default:"24h"`
+	RequestTTL   time.Duration `yaml:"request_ttl"   env:"GK_APPROVAL_REQUEST_TTL"   env-default:"24h"`
+}
+
+// KeyProviderConfig структура конфига провайдера ключей, которым шифруются ключи данных пользователей.
+// Допустимые типы: local, file, vault, shamir.
+type KeyProviderConfig struct {
+	Type        string `yaml:"type"         env:"GK_KEY_PROVIDER" env-default:"local"`
+	KeyringPath string `yaml:"keyring_path" env:"GK_KEYRING_PATH" env-default:"keyring.json"`
+	// MasterKey ключ провайдера local в hex. Если задан при другом провайдере,
+	// используется один раз для переноса ключей, обернутых провайдером local.
+	MasterKey string             `yaml:"master_key" env:"GK_MASTER_KEY" json:"-"`
+	File      FileKeystoreConfig `yaml:"file"`
+	Vault     VaultTransitConfig `yaml:"vault"`
+}
+
+// FileKeystoreConfig структура конфига файлового хранилища ключей, зашифрованного паролем.
+type FileKeystoreConfig struct {
+	Path       string `yaml:"path" env:"GK_KEYSTORE_PATH" env-default:"keystore.json"`
+	Passphrase string `yaml:"-"    env:"GK_KEYSTORE_PASSPHRASE" json:"-"`
+}
+
+// VaultTransitConfig структура конфига для transit secrets engine HashiCorp Vault.
+type VaultTransitConfig struct {
+	Address   string        `yaml:"address"   env:"VAULT_ADDR"`
+	Token     string        `yaml:"-"         env:"VAULT_TOKEN"            json:"-"`
+	Namespace string        `yaml:"namespace" env:"VAULT_NAMESPACE"`
+	Mount     string        `yaml:"mount"     env:"GK_VAULT_TRANSIT_MOUNT" env-default:"transit"`
+	KeyName   string        `yaml:"key_name"  env:"GK_VAULT_TRANSIT_KEY"   env-default:"goph-keeper"`
+	Timeout   time.Duration `yaml:"timeout"   env:"GK_VAULT_TIMEOUT"       env-default:"5s"`
+}
+
+// FieldEncryptionConfig политика шифрования полей секретов, не являющихся секретными данными.
+// Допустимые значения: plain, randomized, deterministic; для secret_name также blind_index.
+type FieldEncryptionConfig struct {
+	SecretName       string `yaml:"secret_name"       env-default:"blind_index"`
+	PasswordUsername string `yaml:"password_username" env-default:"randomized"`
+	PasswordURL      string `yaml:"password_url"      env-default:"randomized"`
+	CardExpireDate   string `yaml:"card_expire_date"  env-default:"randomized"`
+	MetaData         string `yaml:"metadata"          env-default:"randomized"`
+	FileName         string `yaml:"file_name"         env-default:"randomized"`
 }
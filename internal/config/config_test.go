@@ -14,3 +14,48 @@ func TestLoad(t *testing.T) {
 		require.NotNil(t, cfg)
 	})
 }
+
+func TestLocalMasterKey(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.KeyProviderConfig
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "legacy key for local provider",
+			cfg:     config.KeyProviderConfig{Type: "local"},
+			wantLen: 32,
+		},
+		{
+			name:    "no key for vault provider",
+			cfg:     config.KeyProviderConfig{Type: "vault"},
+			wantLen: 0,
+		},
+		{
+			name: "configured key",
+			cfg: config.KeyProviderConfig{
+				Type:      "vault",
+				MasterKey: "f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8",
+			},
+			wantLen: 32,
+		},
+		{
+			name:    "invalid length",
+			cfg:     config.KeyProviderConfig{Type: "local", MasterKey: "f8f2"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := test.cfg.LocalMasterKey()
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, key, test.wantLen)
+		})
+	}
+}
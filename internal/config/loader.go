@@ -28,11 +28,6 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = cfg.GetMasterKey()
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to get master key with error %w", op, err)
-	}
-
 	return &cfg, nil
 }
 
@@ -51,20 +46,30 @@ func fetchConfigPath() string {
 	return path
 }
 
-// GetMasterKey получение мастер-ключа для (де-)шифрования.
-func (c *Config) GetMasterKey() error {
-	op := "config.Loader.GetMasterKey"
+// legacyMasterKey ключ, которым шифровались данные до появления провайдеров ключей.
+// Используется провайдером local, если ключ не задан в конфиге.
+const legacyMasterKey = "f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8"
 
-	var err error
+// LocalMasterKey получение ключа провайдера local.
+func (c *KeyProviderConfig) LocalMasterKey() ([]byte, error) {
+	op := "config.KeyProviderConfig.LocalMasterKey"
+
+	keyHex := c.MasterKey
+	if keyHex == "" {
+		if c.Type != "local" {
+			return nil, nil
+		}
+		keyHex = legacyMasterKey
+	}
 
-	c.Security.MasterKey, err = hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	key, err := hex.DecodeString(keyHex)
 	if err != nil {
-		return fmt.Errorf("%s: failed to decode string for master key with error %w", op, err)
+		return nil, fmt.Errorf("%s: failed to decode string for master key with error %w", op, err)
 	}
 
-	if len(c.Security.MasterKey) != masterKeyByteLen {
-		return fmt.Errorf("decoded master key has invalid length: %d bytes, expected 32", len(c.Security.MasterKey))
+	if len(key) != masterKeyByteLen {
+		return nil, fmt.Errorf("decoded master key has invalid length: %d bytes, expected 32", len(key))
 	}
 
-	return nil
+	return key, nil
 }
@@ -7,4 +7,12 @@ type ContextKey string
 const (
 	// UserID ключ для значения ID пользователя в контексте.
 	UserID ContextKey = "UserID"
+	// SessionID ключ для значения ID сессии в контексте.
+	SessionID ContextKey = "SessionID"
+	// ChallengeID ключ для значения ID токена второго шага входа в контексте.
+	ChallengeID ContextKey = "ChallengeID"
+	// ClientIP ключ для значения адреса клиента в контексте.
+	ClientIP ContextKey = "ClientIP"
+	// UserAgent ключ для значения клиента (user-agent) в контексте.
+	UserAgent ContextKey = "UserAgent"
 )
@@ -0,0 +1,464 @@
+package approval_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/approval"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	ownerID = iota + 1
+	approverID
+	readerID
+	outsiderID
+)
+
+const (
+	rootSecretID = iota + 1
+	plainSecretID
+)
+
+const requestID = 10
+
+var errStorage = errors.New("storage error")
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Security.SecretApproval = config.SecretApprovalConfig{
+		DefaultGrant: time.Hour,
+		MaxGrant:     24 * time.Hour,
+		RequestTTL:   24 * time.Hour,
+	}
+
+	return cfg
+}
+
+// newSecrets секреты владельца, оба открыты читателю.
+func newSecrets(ctrl *gomock.Controller) *mocks.MockApprovalSecrets {
+	stored := map[int]*secret.Secret{
+		rootSecretID:  {ID: rootSecretID, UserID: ownerID, Name: "aws root"},
+		plainSecretID: {ID: plainSecretID, UserID: ownerID, Name: "wifi"},
+	}
+
+	secrets := mocks.NewMockApprovalSecrets(ctrl)
+	secrets.EXPECT().GetSecretByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id, userID int) (*secret.Secret, error) {
+			sec, ok := stored[id]
+			if !ok || sec.UserID != userID {
+				return nil, secret.ErrSecretNotFound
+			}
+			return sec, nil
+		}).AnyTimes()
+	secrets.EXPECT().GetSharedSecrets(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, recipientID int) ([]*secret.Secret, error) {
+			if recipientID != readerID {
+				return nil, nil
+			}
+			return []*secret.Secret{stored[rootSecretID], stored[plainSecretID]}, nil
+		}).AnyTimes()
+
+	return secrets
+}
+
+// newPolicy политика корневого секрета с одним утверждающим.
+func newPolicy(t *testing.T) *approval.Policy {
+	t.Helper()
+
+	p, err := approval.NewPolicy(rootSecretID, ownerID, []approval.Approver{{UserID: approverID, Login: "approver"}}, time.Hour)
+	require.NoError(t, err)
+
+	return p
+}
+
+// newRequest сохраненный запрос читателя к корневому секрету.
+func newRequest(t *testing.T) *approval.Request {
+	t.Helper()
+
+	r := approval.NewRequest(newPolicy(t), readerID, "incident 42", time.Hour)
+	r.ID = requestID
+
+	return r
+}
+
+func TestService_SetPolicy(t *testing.T) {
+	approvers := []approval.Approver{{UserID: approverID, Login: "approver"}}
+	created := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		userID    int
+		secretID  int
+		approvers []approval.Approver
+		grant     time.Duration
+		prev      *approval.Policy
+		wantGrant time.Duration
+		wantErr   error
+	}{
+		{name: "not owner", userID: readerID, secretID: rootSecretID, approvers: approvers, wantErr: approval.ErrNotFound},
+		{name: "unknown secret", userID: ownerID, secretID: 100, approvers: approvers, wantErr: approval.ErrNotFound},
+		{name: "no approvers", userID: ownerID, secretID: rootSecretID, wantErr: approval.ErrNoApprovers},
+		{
+			name: "owner approver", userID: ownerID, secretID: rootSecretID,
+			approvers: []approval.Approver{{UserID: ownerID}}, wantErr: approval.ErrOwnerApprover,
+		},
+		{
+			name: "grant too long", userID: ownerID, secretID: rootSecretID, approvers: approvers,
+			grant: 48 * time.Hour, wantErr: approval.ErrInvalidGrant,
+		},
+		{
+			name: "negative grant", userID: ownerID, secretID: rootSecretID, approvers: approvers,
+			grant: -time.Hour, wantErr: approval.ErrInvalidGrant,
+		},
+		{name: "default grant", userID: ownerID, secretID: rootSecretID, approvers: approvers, wantGrant: time.Hour},
+		{
+			// Замена политики сохраняет момент ее включения
+			name: "replace", userID: ownerID, secretID: rootSecretID, approvers: approvers, grant: 2 * time.Hour,
+			prev: &approval.Policy{SecretID: rootSecretID, CreatedAt: created}, wantGrant: 2 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			if tt.wantErr == nil {
+				if tt.prev != nil {
+					repo.EXPECT().GetPolicy(gomock.Any(), tt.secretID).Return(tt.prev, nil)
+				} else {
+					repo.EXPECT().GetPolicy(gomock.Any(), tt.secretID).Return(nil, approval.ErrNoPolicy)
+				}
+				repo.EXPECT().SavePolicy(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *approval.Policy, ev *approval.Event) error {
+						assert.Equal(t, ownerID, ev.ActorID)
+						assert.Equal(t, approval.ActionPolicySet, ev.Action)
+						return nil
+					})
+			}
+
+			p, err := s.SetPolicy(context.Background(), tt.userID, tt.secretID, tt.approvers, tt.grant)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGrant, p.Grant)
+			assert.True(t, p.IsApprover(approverID))
+			if tt.prev != nil {
+				assert.Equal(t, created, p.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestService_GetPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   int
+		secretID int
+		stored   *approval.Policy
+		wantErr  error
+	}{
+		{name: "owner", userID: ownerID, secretID: rootSecretID, stored: &approval.Policy{SecretID: rootSecretID}},
+		// Читатель секрета видит политику, посторонний нет
+		{name: "reader", userID: readerID, secretID: rootSecretID, stored: &approval.Policy{SecretID: rootSecretID}},
+		{name: "outsider", userID: outsiderID, secretID: rootSecretID, wantErr: approval.ErrNotFound},
+		{name: "no policy", userID: ownerID, secretID: plainSecretID, wantErr: approval.ErrNoPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			if tt.userID != outsiderID {
+				var err error
+				if tt.stored == nil {
+					err = approval.ErrNoPolicy
+				}
+				repo.EXPECT().GetPolicy(gomock.Any(), tt.secretID).Return(tt.stored, err)
+			}
+
+			p, err := s.GetPolicy(context.Background(), tt.userID, tt.secretID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stored, p)
+		})
+	}
+}
+
+func TestService_RemovePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int
+		stored  *approval.Policy
+		wantErr error
+	}{
+		{name: "owner", userID: ownerID, stored: &approval.Policy{SecretID: rootSecretID}},
+		{name: "reader", userID: readerID, wantErr: approval.ErrNotFound},
+		{name: "no policy", userID: ownerID, wantErr: approval.ErrNoPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			if tt.userID == ownerID {
+				var err error
+				if tt.stored == nil {
+					err = approval.ErrNoPolicy
+				}
+				repo.EXPECT().GetPolicy(gomock.Any(), rootSecretID).Return(tt.stored, err)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().DeletePolicy(gomock.Any(), tt.stored, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *approval.Policy, ev *approval.Event) error {
+						assert.Equal(t, approval.ActionPolicyRemoved, ev.Action)
+						return nil
+					})
+			}
+
+			err := s.RemovePolicy(context.Background(), tt.userID, rootSecretID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RequestAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   int
+		secretID int
+		noPolicy bool
+		open     bool
+		wantErr  error
+	}{
+		{name: "reader", userID: readerID, secretID: rootSecretID},
+		// Владелец тоже видит данные только после одобрения
+		{name: "owner", userID: ownerID, secretID: rootSecretID},
+		{name: "outsider", userID: outsiderID, secretID: rootSecretID, wantErr: approval.ErrNotFound},
+		{name: "no policy", userID: readerID, secretID: plainSecretID, noPolicy: true, wantErr: approval.ErrNoPolicy},
+		{name: "already requested", userID: readerID, secretID: rootSecretID, open: true, wantErr: approval.ErrAlreadyRequested},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			if tt.userID != outsiderID {
+				if tt.noPolicy {
+					repo.EXPECT().GetPolicy(gomock.Any(), tt.secretID).Return(nil, approval.ErrNoPolicy)
+				} else {
+					repo.EXPECT().GetPolicy(gomock.Any(), tt.secretID).Return(newPolicy(t), nil)
+					repo.EXPECT().OpenRequest(gomock.Any(), tt.secretID, tt.userID, gomock.Any()).Return(tt.open, nil)
+				}
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().CreateRequest(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *approval.Request, ev *approval.Event) error {
+						assert.Equal(t, tt.userID, ev.ActorID)
+						assert.Equal(t, approval.ActionRequested, ev.Action)
+						return nil
+					})
+			}
+
+			r, err := s.RequestAccess(context.Background(), tt.userID, tt.secretID, "incident 42")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, approval.StatusPending, r.Status)
+			assert.Equal(t, ownerID, r.OwnerID)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), r.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestService_Decide(t *testing.T) {
+	pending := newRequest(t)
+	expired := newRequest(t)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	denied := newRequest(t)
+	require.NoError(t, denied.Deny(approverID, time.Now()))
+
+	tests := []struct {
+		name       string
+		approve    bool
+		userID     int
+		stored     *approval.Request
+		decideErr  error
+		wantStatus approval.Status
+		wantErr    error
+	}{
+		{name: "approve", approve: true, userID: approverID, stored: pending, wantStatus: approval.StatusApproved},
+		{name: "deny", userID: approverID, stored: pending, wantStatus: approval.StatusDenied},
+		{name: "requester", approve: true, userID: readerID, stored: pending, wantErr: approval.ErrNotApprover},
+		{name: "owner", approve: true, userID: ownerID, stored: pending, wantErr: approval.ErrNotFound},
+		{name: "already decided", userID: approverID, stored: denied, wantErr: approval.ErrNotPending},
+		// Истекший запрос одобрить нельзя
+		{name: "expired", approve: true, userID: approverID, stored: expired, wantErr: approval.ErrNotPending},
+		{
+			name: "decided concurrently", approve: true, userID: approverID, stored: pending,
+			decideErr: approval.ErrStateChanged, wantErr: approval.ErrStateChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			stored := *tt.stored
+			repo.EXPECT().GetRequest(gomock.Any(), requestID).Return(&stored, nil)
+			repo.EXPECT().GetPolicy(gomock.Any(), rootSecretID).Return(newPolicy(t), nil)
+			if tt.wantStatus != "" || tt.decideErr != nil {
+				repo.EXPECT().Decide(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *approval.Request, ev *approval.Event) error {
+						assert.Equal(t, approverID, ev.ActorID)
+						assert.Equal(t, requestID, ev.RequestID)
+						return tt.decideErr
+					})
+			}
+
+			decide := s.Deny
+			if tt.approve {
+				decide = s.Approve
+			}
+
+			r, err := decide(context.Background(), tt.userID, requestID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, r.Status)
+			assert.Equal(t, approverID, r.DecidedBy)
+			assert.Equal(t, "approver", r.DeciderLogin)
+			if tt.approve {
+				assert.WithinDuration(t, time.Now().Add(time.Hour), r.GrantedUntil, time.Minute)
+			}
+		})
+	}
+}
+
+func TestService_LockedSecrets(t *testing.T) {
+	ids := []int{rootSecretID, plainSecretID}
+
+	tests := []struct {
+		name       string
+		protected  []int
+		grants     map[int]int
+		wantLocked map[int]bool
+	}{
+		{name: "no policies"},
+		// До одобрения данные скрыты от всех, включая владельца
+		{
+			name: "no grant", protected: []int{rootSecretID}, grants: map[int]int{},
+			wantLocked: map[int]bool{rootSecretID: true},
+		},
+		{
+			name: "active grant", protected: []int{rootSecretID}, grants: map[int]int{rootSecretID: requestID},
+			wantLocked: map[int]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			repo.EXPECT().ProtectedSecrets(gomock.Any(), ids).Return(tt.protected, nil)
+			if len(tt.protected) > 0 {
+				repo.EXPECT().ActiveGrants(gomock.Any(), readerID, tt.protected, gomock.Any()).Return(tt.grants, nil)
+			}
+			if len(tt.grants) > 0 {
+				// Раскрытие записывается в журнал с запросом, открывшим доступ
+				repo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []*approval.Event) error {
+					require.Len(t, events, 1)
+					assert.Equal(t, approval.ActionRevealed, events[0].Action)
+					assert.Equal(t, readerID, events[0].ActorID)
+					assert.Equal(t, requestID, events[0].RequestID)
+					return nil
+				})
+			}
+
+			locked, err := s.LockedSecrets(context.Background(), readerID, ids)
+			require.NoError(t, err)
+			if tt.wantLocked == nil {
+				assert.Empty(t, locked)
+				return
+			}
+			assert.Equal(t, tt.wantLocked, locked)
+		})
+	}
+}
+
+func TestService_Events(t *testing.T) {
+	events := []*approval.Event{{SecretID: rootSecretID, Action: approval.ActionPolicySet}}
+
+	tests := []struct {
+		name    string
+		userID  int
+		repoErr error
+		wantErr error
+	}{
+		{name: "owner", userID: ownerID},
+		// Журнал доступен только владельцу
+		{name: "reader", userID: readerID, wantErr: approval.ErrNotFound},
+		{name: "storage error", userID: ownerID, repoErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockApprovalRepository(ctrl)
+			s := approval.NewService(repo, testConfig(), newSecrets(ctrl))
+
+			if tt.userID == ownerID {
+				repo.EXPECT().ListEvents(gomock.Any(), rootSecretID).Return(events, tt.repoErr)
+			}
+
+			got, err := s.Events(context.Background(), tt.userID, rootSecretID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, events, got)
+		})
+	}
+}
+
+func TestRequest_SelfApproval(t *testing.T) {
+	p, err := approval.NewPolicy(rootSecretID, ownerID, []approval.Approver{{UserID: approverID}}, time.Hour)
+	require.NoError(t, err)
+
+	r := approval.NewRequest(p, approverID, "", time.Hour)
+	require.ErrorIs(t, r.Approve(approverID, p.Grant, time.Now()), approval.ErrSelfApproval)
+	assert.Equal(t, approval.StatusPending, r.Status)
+}
@@ -0,0 +1,229 @@
+// Package approval пакет уровня домена одобрения доступа к критичным секретам: владелец назначает секрету
+// утверждающих, и каждое раскрытие данных секрета требует одобренного ими запроса. Одобрение открывает
+// доступ на ограниченное время, все шаги записываются в журнал.
+package approval
+
+import (
+	"errors"
+	"time"
+)
+
+// Status состояние запроса доступа.
+type Status string
+
+const (
+	// StatusPending запрос ждет решения утверждающего.
+	StatusPending Status = "pending"
+	// StatusApproved запрос одобрен, доступ открыт до GrantedUntil.
+	StatusApproved Status = "approved"
+	// StatusDenied утверждающий отклонил запрос.
+	StatusDenied Status = "denied"
+)
+
+// Action действие в журнале доступа к секрету.
+type Action string
+
+const (
+	// ActionPolicySet владелец включил или изменил политику одобрения.
+	ActionPolicySet Action = "policy_set"
+	// ActionPolicyRemoved владелец отключил политику одобрения.
+	ActionPolicyRemoved Action = "policy_removed"
+	// ActionRequested пользователь запросил доступ.
+	ActionRequested Action = "requested"
+	// ActionApproved утверждающий одобрил запрос.
+	ActionApproved Action = "approved"
+	// ActionDenied утверждающий отклонил запрос.
+	ActionDenied Action = "denied"
+	// ActionRevealed данные секрета выданы пользователю по одобренному запросу.
+	ActionRevealed Action = "revealed"
+)
+
+var (
+	// ErrNotFound секрета или запроса нет, или у пользователя нет к нему доступа.
+	ErrNotFound = errors.New("not found")
+	// ErrNoPolicy для секрета не включено одобрение доступа.
+	ErrNoPolicy = errors.New("secret does not require approval")
+	// ErrNoApprovers не указан ни один утверждающий.
+	ErrNoApprovers = errors.New("at least one approver is required")
+	// ErrOwnerApprover владелец не может быть утверждающим собственного секрета.
+	ErrOwnerApprover = errors.New("secret owner cannot be an approver")
+	// ErrInvalidGrant длительность доступа вне допустимых пределов.
+	ErrInvalidGrant = errors.New("invalid grant duration")
+	// ErrAlreadyRequested у пользователя уже есть запрос без ответа или действующий доступ к секрету.
+	ErrAlreadyRequested = errors.New("access is already requested")
+	// ErrNotApprover пользователь не утверждающий секрета.
+	ErrNotApprover = errors.New("user is not an approver of the secret")
+	// ErrSelfApproval нельзя одобрить собственный запрос.
+	ErrSelfApproval = errors.New("cannot decide on your own request")
+	// ErrNotPending запрос уже решен или истек.
+	ErrNotPending = errors.New("request is not pending")
+	// ErrStateChanged запрос изменился параллельно.
+	ErrStateChanged = errors.New("request state changed concurrently")
+)
+
+// Approver утверждающий секрета.
+type Approver struct {
+	UserID int
+	Login  string
+}
+
+// Policy политика одобрения доступа к секрету.
+type Policy struct {
+	SecretID  int
+	OwnerID   int
+	Approvers []Approver
+	// Grant длительность доступа после одобрения.
+	Grant     time.Duration
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPolicy новая политика секрета secretID владельца ownerID.
+func NewPolicy(secretID, ownerID int, approvers []Approver, grant time.Duration) (*Policy, error) {
+	if len(approvers) == 0 {
+		return nil, ErrNoApprovers
+	}
+	for _, a := range approvers {
+		if a.UserID == ownerID {
+			return nil, ErrOwnerApprover
+		}
+	}
+	if grant <= 0 {
+		return nil, ErrInvalidGrant
+	}
+
+	now := time.Now()
+
+	return &Policy{
+		SecretID:  secretID,
+		OwnerID:   ownerID,
+		Approvers: approvers,
+		Grant:     grant,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsApprover входит ли пользователь в утверждающие.
+func (p *Policy) IsApprover(userID int) bool {
+	for _, a := range p.Approvers {
+		if a.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// approverLogin логин утверждающего userID.
+func (p *Policy) approverLogin(userID int) string {
+	for _, a := range p.Approvers {
+		if a.UserID == userID {
+			return a.Login
+		}
+	}
+
+	return ""
+}
+
+// Request запрос доступа к секрету.
+type Request struct {
+	ID             int
+	SecretID       int
+	OwnerID        int
+	RequesterID    int
+	RequesterLogin string
+	Reason         string
+	Status         Status
+	// DecidedBy утверждающий, принявший решение; 0, если решения нет или его аккаунт удален.
+	DecidedBy    int
+	DeciderLogin string
+	DecidedAt    time.Time
+	// GrantedUntil конец доступа для одобренного запроса.
+	GrantedUntil time.Time
+	// ExpiresAt срок, до которого запрос ждет решения.
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewRequest новый запрос requesterID к секрету policy, ждущий решения ttl.
+func NewRequest(p *Policy, requesterID int, reason string, ttl time.Duration) *Request {
+	now := time.Now()
+
+	return &Request{
+		SecretID:    p.SecretID,
+		OwnerID:     p.OwnerID,
+		RequesterID: requesterID,
+		Reason:      reason,
+		Status:      StatusPending,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// Expired истек ли запрос без решения.
+func (r *Request) Expired(now time.Time) bool {
+	return r.Status == StatusPending && !now.Before(r.ExpiresAt)
+}
+
+// Active открыт ли доступ по запросу в момент now.
+func (r *Request) Active(now time.Time) bool {
+	return r.Status == StatusApproved && now.Before(r.GrantedUntil)
+}
+
+// Approve одобрение запроса утверждающим approverID с доступом на grant.
+func (r *Request) Approve(approverID int, grant time.Duration, now time.Time) error {
+	if err := r.decide(approverID, now); err != nil {
+		return err
+	}
+	r.Status, r.GrantedUntil = StatusApproved, now.Add(grant)
+
+	return nil
+}
+
+// Deny отказ в запросе утверждающим approverID.
+func (r *Request) Deny(approverID int, now time.Time) error {
+	if err := r.decide(approverID, now); err != nil {
+		return err
+	}
+	r.Status = StatusDenied
+
+	return nil
+}
+
+// decide общие проверки решения по запросу.
+func (r *Request) decide(approverID int, now time.Time) error {
+	switch {
+	case r.RequesterID == approverID:
+		return ErrSelfApproval
+	case r.Status != StatusPending || r.Expired(now):
+		return ErrNotPending
+	}
+	r.DecidedBy, r.DecidedAt = approverID, now
+
+	return nil
+}
+
+// Event запись журнала доступа к секрету.
+type Event struct {
+	ID       int
+	SecretID int
+	// RequestID запрос, к которому относится событие; 0 для изменений политики.
+	RequestID int
+	// ActorID автор действия; 0, если его аккаунт удален.
+	ActorID    int
+	ActorLogin string
+	Action     Action
+	CreatedAt  time.Time
+}
+
+// newEvent запись журнала по секрету secretID.
+func newEvent(secretID, requestID, actorID int, action Action, at time.Time) *Event {
+	return &Event{
+		SecretID:  secretID,
+		RequestID: requestID,
+		ActorID:   actorID,
+		Action:    action,
+		CreatedAt: at,
+	}
+}
@@ -0,0 +1,38 @@
+package approval
+
+//go:generate mockgen -destination=../../mocks/approval.go -package=mocks -mock_names=Repository=MockApprovalRepository,Secrets=MockApprovalSecrets . Repository,Secrets
+
+import (
+	"context"
+	"time"
+)
+
+// Repository интерфейс репозитория одобрения доступа к секретам.
+type Repository interface {
+	// SavePolicy сохранение политики секрета вместе с записью журнала, прежняя политика заменяется.
+	SavePolicy(ctx context.Context, p *Policy, ev *Event) error
+	// GetPolicy политика секрета с логинами утверждающих, ErrNoPolicy если ее нет.
+	GetPolicy(ctx context.Context, secretID int) (*Policy, error)
+	// DeletePolicy удаление политики секрета вместе с записью журнала.
+	DeletePolicy(ctx context.Context, p *Policy, ev *Event) error
+	// ProtectedSecrets секреты из secretIDs, для которых включена политика одобрения.
+	ProtectedSecrets(ctx context.Context, secretIDs []int) ([]int, error)
+	// ActiveGrants одобренные запросы userID к секретам secretIDs, действующие в момент now:
+	// ID секрета -> ID запроса.
+	ActiveGrants(ctx context.Context, userID int, secretIDs []int, now time.Time) (map[int]int, error)
+	// CreateRequest сохранение нового запроса вместе с записью журнала.
+	CreateRequest(ctx context.Context, r *Request, ev *Event) error
+	// GetRequest запрос с логинами запросившего и утверждающего, ErrNotFound если его нет.
+	GetRequest(ctx context.Context, id int) (*Request, error)
+	// OpenRequest есть ли у requesterID запрос к секрету без ответа или действующий доступ в момент now.
+	OpenRequest(ctx context.Context, secretID, requesterID int, now time.Time) (bool, error)
+	// Decide сохранение решения по запросу вместе с записью журнала,
+	// только если в базе запрос все еще ждет решения. Иначе ErrStateChanged.
+	Decide(ctx context.Context, r *Request, ev *Event) error
+	// ListRequests запросы, где пользователь запросивший или утверждающий секрета, от новых к старым.
+	ListRequests(ctx context.Context, userID int) ([]*Request, error)
+	// AddEvents запись журнала.
+	AddEvents(ctx context.Context, events []*Event) error
+	// ListEvents журнал доступа к секрету от новых записей к старым.
+	ListEvents(ctx context.Context, secretID int) ([]*Event, error)
+}
@@ -0,0 +1,321 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+)
+
+// Secrets секреты, к которым настраивается и запрашивается доступ.
+type Secrets interface {
+	// GetSecretByID собственный секрет пользователя без данных, secret.ErrSecretNotFound если его нет.
+	GetSecretByID(ctx context.Context, id, userID int) (*secret.Secret, error)
+	// GetSharedSecrets чужие секреты, доступные получателю, без данных.
+	GetSharedSecrets(ctx context.Context, recipientID int) ([]*secret.Secret, error)
+}
+
+// Service структура сервиса одобрения доступа к секретам.
+type Service struct {
+	repo    Repository
+	cfg     *config.Config
+	secrets Secrets
+}
+
+// NewService получение сервиса одобрения доступа к секретам.
+func NewService(r Repository, c *config.Config, s Secrets) *Service {
+	return &Service{
+		repo:    r,
+		cfg:     c,
+		secrets: s,
+	}
+}
+
+// SetPolicy включение или изменение политики одобрения собственного секрета. Нулевой grant заменяется
+// значением security.secret_approval.default_grant.
+func (s *Service) SetPolicy(
+	ctx context.Context,
+	ownerID, secretID int,
+	approvers []Approver,
+	grant time.Duration,
+) (*Policy, error) {
+	op := "domain.approval.Service.SetPolicy"
+
+	cfg := s.cfg.Security.SecretApproval
+	if grant == 0 {
+		grant = cfg.DefaultGrant
+	}
+	if grant > cfg.MaxGrant {
+		return nil, ErrInvalidGrant
+	}
+
+	if err := s.ownSecret(ctx, ownerID, secretID); err != nil {
+		return nil, err
+	}
+
+	p, err := NewPolicy(secretID, ownerID, approvers, grant)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.repo.GetPolicy(ctx, secretID)
+	switch {
+	case err == nil:
+		p.CreatedAt = prev.CreatedAt
+	case !errors.Is(err, ErrNoPolicy):
+		return nil, fmt.Errorf("%s: failed to get policy %w", op, err)
+	}
+
+	if err = s.repo.SavePolicy(ctx, p, newEvent(secretID, 0, ownerID, ActionPolicySet, p.UpdatedAt)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// GetPolicy политика секрета, доступного пользователю.
+func (s *Service) GetPolicy(ctx context.Context, userID, secretID int) (*Policy, error) {
+	if err := s.readableSecret(ctx, userID, secretID); err != nil {
+		return nil, err
+	}
+
+	return s.policy(ctx, secretID)
+}
+
+// RemovePolicy отключение политики одобрения собственного секрета.
+func (s *Service) RemovePolicy(ctx context.Context, ownerID, secretID int) error {
+	op := "domain.approval.Service.RemovePolicy"
+
+	if err := s.ownSecret(ctx, ownerID, secretID); err != nil {
+		return err
+	}
+
+	p, err := s.policy(ctx, secretID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeletePolicy(ctx, p, newEvent(secretID, 0, ownerID, ActionPolicyRemoved, time.Now())); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RequestAccess запрос доступа к секрету, доступному пользователю. Запрос ждет решения утверждающего
+// security.secret_approval.request_ttl.
+func (s *Service) RequestAccess(ctx context.Context, requesterID, secretID int, reason string) (*Request, error) {
+	op := "domain.approval.Service.RequestAccess"
+
+	if err := s.readableSecret(ctx, requesterID, secretID); err != nil {
+		return nil, err
+	}
+
+	p, err := s.policy(ctx, secretID)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.repo.OpenRequest(ctx, secretID, requesterID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to check open requests %w", op, err)
+	}
+	if open {
+		return nil, ErrAlreadyRequested
+	}
+
+	r := NewRequest(p, requesterID, reason, s.cfg.Security.SecretApproval.RequestTTL)
+	if err = s.repo.CreateRequest(ctx, r, newEvent(secretID, 0, requesterID, ActionRequested, r.CreatedAt)); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r, nil
+}
+
+// Approve одобрение запроса утверждающим: доступ открывается на длительность из политики секрета.
+func (s *Service) Approve(ctx context.Context, approverID, requestID int) (*Request, error) {
+	r, p, err := s.approverRequest(ctx, approverID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = r.Approve(approverID, p.Grant, now); err != nil {
+		return nil, err
+	}
+	r.DeciderLogin = p.approverLogin(approverID)
+
+	if err = s.decide(ctx, r, newEvent(r.SecretID, r.ID, approverID, ActionApproved, now)); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Deny отказ утверждающего в запросе.
+func (s *Service) Deny(ctx context.Context, approverID, requestID int) (*Request, error) {
+	r, p, err := s.approverRequest(ctx, approverID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = r.Deny(approverID, now); err != nil {
+		return nil, err
+	}
+	r.DeciderLogin = p.approverLogin(approverID)
+
+	if err = s.decide(ctx, r, newEvent(r.SecretID, r.ID, approverID, ActionDenied, now)); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ListRequests запросы пользователя и запросы, ждущие его решения как утверждающего.
+func (s *Service) ListRequests(ctx context.Context, userID int) ([]*Request, error) {
+	requests, err := s.repo.ListRequests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.approval.Service.ListRequests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// Events журнал доступа к собственному секрету.
+func (s *Service) Events(ctx context.Context, ownerID, secretID int) ([]*Event, error) {
+	if err := s.ownSecret(ctx, ownerID, secretID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListEvents(ctx, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.approval.Service.Events: %w", err)
+	}
+
+	return events, nil
+}
+
+// LockedSecrets секреты из secretIDs под политикой одобрения, к которым у userID нет действующего доступа.
+// Раскрытие остальных секретов под политикой записывается в журнал.
+func (s *Service) LockedSecrets(ctx context.Context, userID int, secretIDs []int) (map[int]bool, error) {
+	op := "domain.approval.Service.LockedSecrets"
+
+	protected, err := s.repo.ProtectedSecrets(ctx, secretIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get protected secrets %w", op, err)
+	}
+	if len(protected) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	grants, err := s.repo.ActiveGrants(ctx, userID, protected, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get active grants %w", op, err)
+	}
+
+	locked := make(map[int]bool, len(protected))
+	revealed := make([]*Event, 0, len(grants))
+	for _, id := range protected {
+		requestID, ok := grants[id]
+		if !ok {
+			locked[id] = true
+			continue
+		}
+		revealed = append(revealed, newEvent(id, requestID, userID, ActionRevealed, now))
+	}
+
+	if len(revealed) > 0 {
+		if err = s.repo.AddEvents(ctx, revealed); err != nil {
+			return nil, fmt.Errorf("%s: failed to save events %w", op, err)
+		}
+	}
+
+	return locked, nil
+}
+
+// policy политика секрета.
+func (s *Service) policy(ctx context.Context, secretID int) (*Policy, error) {
+	p, err := s.repo.GetPolicy(ctx, secretID)
+	if err != nil {
+		if errors.Is(err, ErrNoPolicy) {
+			return nil, ErrNoPolicy
+		}
+		return nil, fmt.Errorf("domain.approval.Service.policy: %w", err)
+	}
+
+	return p, nil
+}
+
+// ownSecret проверка, что секрет принадлежит пользователю.
+func (s *Service) ownSecret(ctx context.Context, userID, secretID int) error {
+	if _, err := s.secrets.GetSecretByID(ctx, secretID, userID); err != nil {
+		if errors.Is(err, secret.ErrSecretNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("domain.approval.Service.ownSecret: %w", err)
+	}
+
+	return nil
+}
+
+// readableSecret проверка, что секрет собственный или открыт пользователю.
+func (s *Service) readableSecret(ctx context.Context, userID, secretID int) error {
+	err := s.ownSecret(ctx, userID, secretID)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	shared, err := s.secrets.GetSharedSecrets(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("domain.approval.Service.readableSecret: failed to get shared secrets %w", err)
+	}
+	for _, sec := range shared {
+		if sec.ID == secretID {
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// approverRequest запрос, решение по которому может принять approverID, и политика его секрета.
+func (s *Service) approverRequest(ctx context.Context, approverID, requestID int) (*Request, *Policy, error) {
+	r, err := s.repo.GetRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("domain.approval.Service.approverRequest: %w", err)
+	}
+
+	p, err := s.policy(ctx, r.SecretID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !p.IsApprover(approverID) {
+		if r.RequesterID == approverID {
+			return nil, nil, ErrNotApprover
+		}
+		return nil, nil, ErrNotFound
+	}
+
+	return r, p, nil
+}
+
+// decide сохранение решения по запросу.
+func (s *Service) decide(ctx context.Context, r *Request, ev *Event) error {
+	if err := s.repo.Decide(ctx, r, ev); err != nil {
+		if errors.Is(err, ErrStateChanged) {
+			return ErrStateChanged
+		}
+		return fmt.Errorf("domain.approval.Service.decide: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memAuditRepo реализует Repository в памяти для тестирования.
+type memAuditRepo struct {
+	events []*audit.Event
+}
+
+func (m *memAuditRepo) Save(_ context.Context, events []*audit.Event) error {
+	for _, ev := range events {
+		ev.ID = len(m.events) + 1
+		m.events = append(m.events, ev)
+	}
+	return nil
+}
+
+func (m *memAuditRepo) Query(_ context.Context, f audit.Filter) ([]*audit.Event, error) {
+	var res []*audit.Event
+	for i := len(m.events) - 1; i >= 0 && len(res) < f.Limit; i-- {
+		ev := m.events[i]
+		if (f.UserID != 0 && ev.ActorID != f.UserID && ev.SubjectID != f.UserID) ||
+			(f.Action != "" && ev.Action != f.Action) ||
+			(f.BeforeID != 0 && ev.ID >= f.BeforeID) {
+			continue
+		}
+		res = append(res, ev)
+	}
+	return res, nil
+}
+
+func TestService_Record(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextkeys.ClientIP, "10.0.0.1")
+	ctx = context.WithValue(ctx, contextkeys.UserAgent, "grpc-go/1.70.0")
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		event       *audit.Event
+		wantActor   int
+		wantSubject int
+		wantLogin   string
+	}{
+		{
+			name:      "unauthenticated login",
+			ctx:       ctx,
+			event:     audit.NewEvent(0, "john", audit.ActionLoginFailed, 0, errors.New("invalid credentials")),
+			wantLogin: "john",
+		},
+		{
+			name:        "own secret read",
+			ctx:         context.WithValue(ctx, contextkeys.UserID, 1),
+			event:       audit.NewEvent(1, "john", audit.ActionRead, 7, nil),
+			wantActor:   1,
+			wantSubject: 1,
+			wantLogin:   "john",
+		},
+		{
+			name:        "read on behalf of another user",
+			ctx:         context.WithValue(ctx, contextkeys.UserID, 2),
+			event:       audit.NewEvent(1, "john", audit.ActionRead, 7, nil),
+			wantActor:   2,
+			wantSubject: 1,
+			wantLogin:   "john",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memAuditRepo{}
+			s := audit.NewService(repo)
+
+			require.NoError(t, s.Record(tt.ctx, tt.event))
+
+			require.Len(t, repo.events, 1)
+			ev := repo.events[0]
+			assert.Equal(t, tt.wantActor, ev.ActorID)
+			assert.Equal(t, tt.wantSubject, ev.SubjectID)
+			assert.Equal(t, tt.wantLogin, ev.Login)
+			assert.Equal(t, "10.0.0.1", ev.ClientIP)
+			assert.Equal(t, "grpc-go/1.70.0", ev.UserAgent)
+			assert.False(t, ev.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestNewEvent_Result(t *testing.T) {
+	assert.Equal(t, audit.ResultSuccess, audit.NewEvent(1, "john", audit.ActionLogin, 0, nil).Result)
+	assert.Equal(t, audit.ResultFailure, audit.NewEvent(1, "john", audit.ActionLogin, 0, errors.New("x")).Result)
+}
+
+func TestService_QueryPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := &memAuditRepo{}
+	s := audit.NewService(repo)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.Record(ctx, audit.NewEvent(1, "john", audit.ActionRead, i+1, nil)))
+	}
+	require.NoError(t, s.Record(ctx, audit.NewEvent(2, "jane", audit.ActionRead, 10, nil)))
+
+	page, err := s.Query(ctx, audit.Filter{UserID: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+	assert.Equal(t, 5, page.Events[0].SecretID)
+	assert.Equal(t, 4, page.Events[1].SecretID)
+	assert.Equal(t, page.Events[1].ID, page.NextBeforeID)
+
+	page, err = s.Query(ctx, audit.Filter{UserID: 1, Limit: 2, BeforeID: page.NextBeforeID})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+	assert.Equal(t, 3, page.Events[0].SecretID)
+
+	page, err = s.Query(ctx, audit.Filter{UserID: 1, Limit: 2, BeforeID: page.NextBeforeID})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, 1, page.Events[0].SecretID)
+	assert.Zero(t, page.NextBeforeID)
+}
+
+func TestService_QueryIncludesReadsByOthers(t *testing.T) {
+	repo := &memAuditRepo{}
+	s := audit.NewService(repo)
+
+	// Получатель общего доступа jane читает секрет john
+	ctx := context.WithValue(context.Background(), contextkeys.UserID, 2)
+	require.NoError(t, s.Record(ctx, audit.NewEvent(1, "john", audit.ActionRead, 7, nil)))
+
+	for _, userID := range []int{1, 2} {
+		page, err := s.Query(context.Background(), audit.Filter{UserID: userID})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 1, "user %d", userID)
+		assert.Equal(t, 2, page.Events[0].ActorID)
+		assert.Equal(t, 1, page.Events[0].SubjectID)
+	}
+
+	page, err := s.Query(context.Background(), audit.Filter{UserID: 3})
+	require.NoError(t, err)
+	assert.Empty(t, page.Events)
+}
+
+func TestService_QueryInvalidFilter(t *testing.T) {
+	s := audit.NewService(&memAuditRepo{})
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+	}{
+		{name: "unknown action", filter: audit.Filter{Action: "drop"}},
+		{name: "unknown result", filter: audit.Filter{Result: "maybe"}},
+		{name: "negative limit", filter: audit.Filter{Limit: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Query(context.Background(), tt.filter)
+			assert.ErrorIs(t, err, audit.ErrInvalidFilter)
+		})
+	}
+}
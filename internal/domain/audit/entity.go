@@ -0,0 +1,111 @@
+// Package audit пакет уровня домена журнала аудита: кто, когда и откуда выполнял операции с аккаунтом
+// и секретами и чем они закончились. Записи добавляет сервисный слой, журнал не изменяется.
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// Action операция в журнале аудита.
+type Action string
+
+const (
+	// ActionCreate создание секрета или регистрация аккаунта.
+	ActionCreate Action = "create"
+	// ActionRead чтение данных секрета.
+	ActionRead Action = "read"
+	// ActionUpdate изменение учетных данных аккаунта.
+	ActionUpdate Action = "update"
+	// ActionDelete запрос удаления аккаунта или удаление секрета коллекции.
+	ActionDelete Action = "delete"
+	// ActionShare открытие секрета другому пользователю.
+	ActionShare Action = "share"
+	// ActionLogin успешный вход.
+	ActionLogin Action = "login"
+	// ActionLoginFailed неудачная попытка входа.
+	ActionLoginFailed Action = "login_failed"
+)
+
+// Valid известна ли операция.
+func (a Action) Valid() bool {
+	switch a {
+	case ActionCreate, ActionRead, ActionUpdate, ActionDelete, ActionShare, ActionLogin, ActionLoginFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Result результат операции.
+type Result string
+
+const (
+	// ResultSuccess операция выполнена.
+	ResultSuccess Result = "success"
+	// ResultFailure операция отклонена или завершилась ошибкой.
+	ResultFailure Result = "failure"
+)
+
+// ErrInvalidFilter неизвестная операция или результат в фильтре.
+var ErrInvalidFilter = errors.New("invalid audit filter")
+
+// Event запись журнала аудита.
+type Event struct {
+	ID int
+	// ActorID пользователь, выполнивший операцию; 0 для входа с неизвестным логином или удаленного аккаунта.
+	ActorID int
+	// ActorLogin логин автора операции на момент операции.
+	ActorLogin string
+	// SubjectID пользователь, чей аккаунт или секрет затронут операцией. Отличается от ActorID, когда секрет
+	// читает получатель общего доступа или доверенный контакт.
+	SubjectID int
+	// Login логин пользователя SubjectID на момент операции, для неудачного входа введенный логин.
+	Login  string
+	Action Action
+	// SecretID секрет операции; 0 для операций с аккаунтом.
+	SecretID  int
+	ClientIP  string
+	UserAgent string
+	Result    Result
+	CreatedAt time.Time
+}
+
+// NewEvent запись об операции action пользователя userID со своим аккаунтом или секретом secretID.
+// Результат ResultFailure, если opErr не nil.
+func NewEvent(userID int, login string, action Action, secretID int, opErr error) *Event {
+	result := ResultSuccess
+	if opErr != nil {
+		result = ResultFailure
+	}
+
+	return &Event{
+		ActorID:    userID,
+		ActorLogin: login,
+		SubjectID:  userID,
+		Login:      login,
+		Action:     action,
+		SecretID:   secretID,
+		Result:     result,
+	}
+}
+
+// Filter условия выборки журнала. Нулевые поля не ограничивают выборку.
+type Filter struct {
+	// UserID записи, в которых пользователь автор или субъект операции.
+	UserID   int
+	Action   Action
+	SecretID int
+	Result   Result
+	From     time.Time
+	To       time.Time
+	// BeforeID курсор страницы: записи с ID меньше BeforeID; 0 с самой новой записи.
+	BeforeID int
+	Limit    int
+}
+
+// Page страница журнала от новых записей к старым. NextBeforeID курсор следующей страницы, 0 если она последняя.
+type Page struct {
+	Events       []*Event
+	NextBeforeID int
+}
@@ -0,0 +1,11 @@
+package audit
+
+import "context"
+
+// Repository интерфейс репозитория журнала аудита.
+type Repository interface {
+	// Save сохранение записей журнала.
+	Save(ctx context.Context, events []*Event) error
+	// Query записи по фильтру от новых к старым, не больше f.Limit.
+	Query(ctx context.Context, f Filter) ([]*Event, error)
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+)
+
+const (
+	// defaultPageSize размер страницы журнала, если он не задан.
+	defaultPageSize = 50
+	// maxPageSize наибольший размер страницы журнала.
+	maxPageSize = 500
+)
+
+// Service структура сервиса журнала аудита.
+type Service struct {
+	repo Repository
+}
+
+// NewService получение сервиса журнала аудита.
+func NewService(r Repository) *Service {
+	return &Service{repo: r}
+}
+
+// Record сохранение записей с адресом и клиентом из контекста запроса. Если запрос выполняет другой
+// аутентифицированный пользователь, например, доверенный контакт с экстренным доступом, автором записи
+// считается он, а субъект записи не меняется.
+func (s *Service) Record(ctx context.Context, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ip, _ := ctx.Value(contextkeys.ClientIP).(string)
+	userAgent, _ := ctx.Value(contextkeys.UserAgent).(string)
+	callerID, authenticated := ctx.Value(contextkeys.UserID).(int)
+
+	for _, ev := range events {
+		ev.ClientIP, ev.UserAgent, ev.CreatedAt = ip, userAgent, now
+		if authenticated && ev.ActorID != callerID {
+			ev.ActorID, ev.ActorLogin = callerID, ""
+		}
+	}
+
+	if err := s.repo.Save(ctx, events); err != nil {
+		return fmt.Errorf("domain.audit.Service.Record: %w", err)
+	}
+
+	return nil
+}
+
+// Query страница журнала по фильтру. Нулевой f.Limit заменяется размером по умолчанию,
+// слишком большой ограничивается.
+func (s *Service) Query(ctx context.Context, f Filter) (*Page, error) {
+	if (f.Action != "" && !f.Action.Valid()) ||
+		(f.Result != "" && f.Result != ResultSuccess && f.Result != ResultFailure) ||
+		f.Limit < 0 || f.BeforeID < 0 {
+		return nil, ErrInvalidFilter
+	}
+
+	switch {
+	case f.Limit == 0:
+		f.Limit = defaultPageSize
+	case f.Limit > maxPageSize:
+		f.Limit = maxPageSize
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница
+	limit := f.Limit
+	f.Limit++
+
+	events, err := s.repo.Query(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("domain.audit.Service.Query: %w", err)
+	}
+
+	page := &Page{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextBeforeID = page.Events[limit-1].ID
+	}
+
+	return page, nil
+}
@@ -0,0 +1,215 @@
+package datakey_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/datakey"
+	"github.com/Melikhov-p/goph-keeper/internal/kms"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var errStorage = errors.New("storage error")
+
+func newProvider(t *testing.T, name string, fill string) datakey.KeyWrapper {
+	t.Helper()
+
+	key := []byte(strings.Repeat(fill, 32))
+	if name == kms.ProviderShamir {
+		p, err := kms.NewShamirProvider(key)
+		require.NoError(t, err)
+		return p
+	}
+
+	p, err := kms.NewLocalProvider(key)
+	require.NoError(t, err)
+	return p
+}
+
+// wrappedKey ключ данных пользователя, обернутый провайдером kek, и его открытое значение.
+func wrappedKey(t *testing.T, kek datakey.KeyWrapper, userID int) (*datakey.DataKey, []byte) {
+	t.Helper()
+
+	k, key, err := datakey.NewDataKey(context.Background(), kek, userID)
+	require.NoError(t, err)
+
+	return k, key
+}
+
+func TestService_UserKey(t *testing.T) {
+	local := newProvider(t, kms.ProviderLocal, "k")
+	stored, storedKey := wrappedKey(t, local, 1)
+	raced, racedKey := wrappedKey(t, local, 1)
+	foreign, _ := wrappedKey(t, newProvider(t, kms.ProviderShamir, "s"), 1)
+
+	tests := []struct {
+		name string
+		// setup ожидаемые вызовы хранилища, возвращает ожидаемый ключ после вызова UserKey
+		setup   func(t *testing.T, repo *mocks.MockDataKeyRepository) func() []byte
+		wantErr error
+	}{
+		{
+			name: "existing key",
+			setup: func(_ *testing.T, repo *mocks.MockDataKeyRepository) func() []byte {
+				repo.EXPECT().Get(gomock.Any(), 1).Return(stored, nil)
+				return func() []byte { return storedKey }
+			},
+		},
+		{
+			// Ключ создается при первом обращении, в хранилище лежит только обернутый ключ
+			name: "first use",
+			setup: func(t *testing.T, repo *mocks.MockDataKeyRepository) func() []byte {
+				var created *datakey.DataKey
+				gomock.InOrder(
+					repo.EXPECT().Get(gomock.Any(), 1).Return(nil, datakey.ErrNotFound),
+					repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *datakey.DataKey) error {
+						assert.Equal(t, 1, k.UserID)
+						assert.Equal(t, kms.ProviderLocal, k.Provider)
+						created = k
+						return nil
+					}),
+					repo.EXPECT().Get(gomock.Any(), 1).DoAndReturn(func(context.Context, int) (*datakey.DataKey, error) {
+						return created, nil
+					}),
+				)
+
+				return func() []byte {
+					key, err := local.UnwrapKey(context.Background(), created.WrappedKey)
+					require.NoError(t, err)
+					assert.False(t, bytes.Contains(created.WrappedKey, key))
+					return key
+				}
+			},
+		},
+		{
+			// Ключ, созданный параллельным запросом, не перезаписывается
+			name: "created concurrently",
+			setup: func(_ *testing.T, repo *mocks.MockDataKeyRepository) func() []byte {
+				gomock.InOrder(
+					repo.EXPECT().Get(gomock.Any(), 1).Return(nil, datakey.ErrNotFound),
+					repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+					repo.EXPECT().Get(gomock.Any(), 1).Return(raced, nil),
+				)
+				return func() []byte { return racedKey }
+			},
+		},
+		{
+			name: "provider mismatch",
+			setup: func(_ *testing.T, repo *mocks.MockDataKeyRepository) func() []byte {
+				repo.EXPECT().Get(gomock.Any(), 1).Return(foreign, nil)
+				return nil
+			},
+			wantErr: datakey.ErrProviderMismatch,
+		},
+		{
+			name: "storage error",
+			setup: func(_ *testing.T, repo *mocks.MockDataKeyRepository) func() []byte {
+				repo.EXPECT().Get(gomock.Any(), 1).Return(nil, errStorage)
+				return nil
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockDataKeyRepository(ctrl)
+			want := tt.setup(t, repo)
+
+			s := datakey.NewService(repo, local)
+
+			key, err := s.UserKey(context.Background(), 1)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 32, key.Len())
+			assert.Equal(t, want(), key.Bytes())
+		})
+	}
+}
+
+func TestService_Rewrap(t *testing.T) {
+	local := newProvider(t, kms.ProviderLocal, "k")
+	shamir := newProvider(t, kms.ProviderShamir, "s")
+
+	tests := []struct {
+		name      string
+		kek       datakey.KeyWrapper
+		adopt     bool
+		updateErr error
+		wantN     int
+	}{
+		{name: "rewrap", kek: local, wantN: 2},
+		// Перенос ключей из провайдера local в другой провайдер
+		{name: "adopt", kek: shamir, adopt: true, wantN: 2},
+		{name: "update error", kek: local, updateErr: errStorage, wantN: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockDataKeyRepository(ctrl)
+
+			first, firstKey := wrappedKey(t, local, 1)
+			second, secondKey := wrappedKey(t, local, 2)
+			keys := map[int][]byte{1: firstKey, 2: secondKey}
+			before := map[int][]byte{1: bytes.Clone(first.WrappedKey), 2: bytes.Clone(second.WrappedKey)}
+
+			repo.EXPECT().ListByProvider(gomock.Any(), kms.ProviderLocal).Return([]*datakey.DataKey{first, second}, nil)
+			updates := repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *datakey.DataKey) error {
+				if tt.updateErr != nil {
+					return tt.updateErr
+				}
+
+				assert.Equal(t, tt.kek.Name(), k.Provider)
+				assert.False(t, k.RotatedAt.IsZero())
+				assert.NotEqual(t, before[k.UserID], k.WrappedKey)
+
+				key, err := tt.kek.UnwrapKey(context.Background(), k.WrappedKey)
+				require.NoError(t, err)
+				assert.Equal(t, keys[k.UserID], key)
+				return nil
+			})
+			if tt.updateErr == nil {
+				updates.Times(2)
+			}
+
+			s := datakey.NewService(repo, tt.kek)
+
+			var (
+				n   int
+				err error
+			)
+			if tt.adopt {
+				n, err = s.Adopt(context.Background(), local)
+			} else {
+				n, err = s.Rewrap(context.Background())
+			}
+			assert.Equal(t, tt.wantN, n)
+			if tt.updateErr != nil {
+				require.ErrorIs(t, err, tt.updateErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_AdoptSameProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	local := newProvider(t, kms.ProviderLocal, "k")
+
+	// Ключи уже обернуты текущим провайдером, хранилище не трогается
+	n, err := datakey.NewService(mocks.NewMockDataKeyRepository(ctrl), local).Adopt(context.Background(), local)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
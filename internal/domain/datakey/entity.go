@@ -0,0 +1,64 @@
+// Package datakey пакет уровня домена ключей данных пользователей.
+//
+// Секреты, второй фактор и копии ключей коллекций каждого пользователя шифруются его собственным
+// случайным ключом данных. В хранилище ключ лежит только обернутым провайдером ключей (KMS) и
+// разворачивается на время запроса, которому он нужен.
+package datakey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+var (
+	// ErrNotFound у пользователя еще нет ключа данных.
+	ErrNotFound = errors.New("data key not found")
+	// ErrProviderMismatch ключ обернут провайдером, которого сервис не знает.
+	ErrProviderMismatch = errors.New("data key is wrapped by another provider")
+)
+
+// DataKey ключ данных пользователя, обернутый провайдером ключей.
+type DataKey struct {
+	UserID int
+	// Provider тип провайдера, которым обернут ключ.
+	Provider   string
+	WrappedKey []byte
+	CreatedAt  time.Time
+	RotatedAt  time.Time
+}
+
+// KeyWrapper провайдер ключей, которым оборачиваются ключи данных (kms.KeyProvider).
+type KeyWrapper interface {
+	Name() string
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// NewDataKey генерация ключа данных пользователя. Возвращает обернутый ключ и открытый ключ,
+// который вызывающий затирает после использования.
+func NewDataKey(ctx context.Context, kek KeyWrapper, userID int) (*DataKey, []byte, error) {
+	op := "domain.datakey.NewDataKey"
+
+	key, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	wrapped, err := kek.WrapKey(ctx, key)
+	if err != nil {
+		securemem.Wipe(key)
+		return nil, nil, fmt.Errorf("%s: failed to wrap data key %w", op, err)
+	}
+
+	return &DataKey{
+		UserID:     userID,
+		Provider:   kek.Name(),
+		WrappedKey: wrapped,
+		CreatedAt:  time.Now(),
+	}, key, nil
+}
@@ -0,0 +1,18 @@
+package datakey
+
+//go:generate mockgen -destination=../../mocks/datakey.go -package=mocks -mock_names=Repository=MockDataKeyRepository . Repository
+
+import "context"
+
+// Repository интерфейс репозитория ключей данных.
+type Repository interface {
+	// Get ключ данных пользователя, ErrNotFound если его нет.
+	Get(ctx context.Context, userID int) (*DataKey, error)
+	// Create сохранение нового ключа. Если ключ пользователя уже сохранен параллельным запросом,
+	// он не перезаписывается.
+	Create(ctx context.Context, k *DataKey) error
+	// ListByProvider ключи, обернутые провайдером provider.
+	ListByProvider(ctx context.Context, provider string) ([]*DataKey, error)
+	// Update сохранение переобернутого ключа.
+	Update(ctx context.Context, k *DataKey) error
+}
@@ -0,0 +1,121 @@
+package datakey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+// Service сервисный слой ключей данных.
+type Service struct {
+	repo Repository
+	kek  KeyWrapper
+}
+
+// NewService получение сервиса ключей данных, kek провайдер, которым оборачиваются ключи.
+func NewService(r Repository, kek KeyWrapper) *Service {
+	return &Service{
+		repo: r,
+		kek:  kek,
+	}
+}
+
+// UserKey ключ данных пользователя, развернутый провайдером. Ключ создается при первом обращении.
+// Вызывающий затирает буфер, как только ключ больше не нужен.
+func (s *Service) UserKey(ctx context.Context, userID int) (*securemem.Buffer, error) {
+	op := "domain.datakey.Service.UserKey"
+
+	k, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, ErrNotFound) {
+		return s.create(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if k.Provider != s.kek.Name() {
+		return nil, fmt.Errorf("%s: %w: %s, configured %s", op, ErrProviderMismatch, k.Provider, s.kek.Name())
+	}
+
+	key, err := s.kek.UnwrapKey(ctx, k.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to unwrap data key %w", op, err)
+	}
+
+	return securemem.New(key), nil
+}
+
+// create генерация и сохранение ключа данных пользователя.
+func (s *Service) create(ctx context.Context, userID int) (*securemem.Buffer, error) {
+	op := "domain.datakey.Service.create"
+
+	k, key, err := NewDataKey(ctx, s.kek, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	securemem.Wipe(key)
+
+	if err = s.repo.Create(ctx, k); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Ключ, одновременно созданный другим запросом, не перезаписывается: возвращается сохраненный
+	return s.UserKey(ctx, userID)
+}
+
+// Rewrap переоборачивание всех ключей данных текущей версией ключа провайдера, например после его ротации.
+// Возвращает число переобернутых ключей.
+func (s *Service) Rewrap(ctx context.Context) (int, error) {
+	n, err := s.rewrap(ctx, s.kek)
+	if err != nil {
+		return n, fmt.Errorf("domain.datakey.Service.Rewrap: %w", err)
+	}
+
+	return n, nil
+}
+
+// Adopt переоборачивание ключей, обернутых прежним провайдером prev, например при переносе ключей
+// из провайдера local во внешний KMS. Возвращает число перенесенных ключей.
+func (s *Service) Adopt(ctx context.Context, prev KeyWrapper) (int, error) {
+	if prev.Name() == s.kek.Name() {
+		return 0, nil
+	}
+
+	n, err := s.rewrap(ctx, prev)
+	if err != nil {
+		return n, fmt.Errorf("domain.datakey.Service.Adopt: %w", err)
+	}
+
+	return n, nil
+}
+
+// rewrap переоборачивание текущим провайдером ключей, обернутых провайдером from.
+func (s *Service) rewrap(ctx context.Context, from KeyWrapper) (int, error) {
+	keys, err := s.repo.ListByProvider(ctx, from.Name())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list data keys %w", err)
+	}
+
+	for i, k := range keys {
+		key, err := from.UnwrapKey(ctx, k.WrappedKey)
+		if err != nil {
+			return i, fmt.Errorf("failed to unwrap data key of user %d %w", k.UserID, err)
+		}
+
+		k.WrappedKey, err = s.kek.WrapKey(ctx, key)
+		securemem.Wipe(key)
+		if err != nil {
+			return i, fmt.Errorf("failed to wrap data key of user %d %w", k.UserID, err)
+		}
+		k.Provider, k.RotatedAt = s.kek.Name(), time.Now()
+
+		if err = s.repo.Update(ctx, k); err != nil {
+			return i, fmt.Errorf("failed to update data key of user %d %w", k.UserID, err)
+		}
+	}
+
+	return len(keys), nil
+}
@@ -0,0 +1,606 @@
+package emergency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/emergency"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	grantorID = iota + 1
+	granteeID
+	outsiderID
+)
+
+const contactID = 10
+
+var errStorage = errors.New("storage error")
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Security.EmergencyAccess = config.EmergencyAccessConfig{
+		DefaultWait: 72 * time.Hour,
+		MinWait:     time.Hour,
+		MaxWait:     720 * time.Hour,
+	}
+
+	return cfg
+}
+
+// newContact сохраненный контакт в состоянии status, запрошенный requestedAgo назад.
+func newContact(accessType emergency.AccessType, status emergency.Status, requestedAgo time.Duration) *emergency.Contact {
+	c := &emergency.Contact{
+		ID:           contactID,
+		GrantorID:    grantorID,
+		GranteeID:    granteeID,
+		GrantorLogin: "owner",
+		GranteeLogin: "contact",
+		Type:         accessType,
+		WaitPeriod:   72 * time.Hour,
+		Status:       status,
+	}
+	if status != emergency.StatusNominated {
+		c.RequestedAt = time.Now().Add(-requestedAgo)
+	}
+
+	return c
+}
+
+// expectTransition ожидание перехода контакта из состояния from с записью журнала action от actorID.
+func expectTransition(t *testing.T, repo *mocks.MockEmergencyRepository, from emergency.Status, actorID int, action emergency.Action) {
+	t.Helper()
+
+	repo.EXPECT().Transition(gomock.Any(), gomock.Any(), from, gomock.Any()).
+		DoAndReturn(func(_ context.Context, c *emergency.Contact, _ emergency.Status, ev *emergency.Event) error {
+			assert.Equal(t, contactID, ev.ContactID)
+			assert.Equal(t, actorID, ev.ActorID)
+			assert.Equal(t, action, ev.Action)
+			return nil
+		})
+}
+
+func TestService_Nominate(t *testing.T) {
+	grantor := &user.User{ID: grantorID, Login: "owner"}
+	grantee := &user.User{ID: granteeID, Login: "contact"}
+	e2eGrantor := &user.User{ID: grantorID, Login: "e2e", EncryptionMode: user.EncryptionModeE2E}
+	keyedGrantee := &user.User{ID: granteeID, Login: "keyed", PublicKey: []byte("pub"), WrappedPrivateKey: []byte("priv")}
+
+	tests := []struct {
+		name      string
+		grantor   *user.User
+		grantee   *user.User
+		typ       emergency.AccessType
+		wait      time.Duration
+		key       []byte
+		createErr error
+		wantWait  time.Duration
+		wantKey   []byte
+		wantErr   error
+	}{
+		{name: "self", grantor: grantor, grantee: grantor, typ: emergency.AccessView, wantErr: emergency.ErrSelf},
+		{name: "invalid type", grantor: grantor, grantee: grantee, typ: "all", wantErr: emergency.ErrInvalidType},
+		{
+			name: "wait too short", grantor: grantor, grantee: grantee, typ: emergency.AccessView,
+			wait: time.Minute, wantErr: emergency.ErrInvalidWait,
+		},
+		{
+			name: "wait too long", grantor: grantor, grantee: grantee, typ: emergency.AccessView,
+			wait: 1000 * time.Hour, wantErr: emergency.ErrInvalidWait,
+		},
+		{name: "default wait", grantor: grantor, grantee: grantee, typ: emergency.AccessView, wantWait: 72 * time.Hour},
+		{
+			// Для аккаунта с шифрованием на сервере ключ хранилища не сохраняется
+			name: "server account", grantor: grantor, grantee: grantee, typ: emergency.AccessTakeover,
+			wait: 2 * time.Hour, key: []byte("sealed"), wantWait: 2 * time.Hour,
+		},
+		{
+			name: "duplicate", grantor: grantor, grantee: grantee, typ: emergency.AccessView,
+			createErr: emergency.ErrAlreadyNominated, wantErr: emergency.ErrAlreadyNominated,
+		},
+		{
+			name: "storage error", grantor: grantor, grantee: grantee, typ: emergency.AccessView,
+			createErr: errStorage, wantErr: errStorage,
+		},
+		{
+			name: "e2e contact without share keys", grantor: e2eGrantor, grantee: grantee, typ: emergency.AccessView,
+			key: []byte("sealed"), wantErr: emergency.ErrNoShareKeys,
+		},
+		{
+			name: "e2e without vault key", grantor: e2eGrantor, grantee: keyedGrantee, typ: emergency.AccessView,
+			wantErr: emergency.ErrVaultKeyRequired,
+		},
+		{
+			name: "e2e with vault key", grantor: e2eGrantor, grantee: keyedGrantee, typ: emergency.AccessView,
+			key: []byte("sealed"), wantWait: 72 * time.Hour, wantKey: []byte("sealed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			if tt.wantWait != 0 || tt.createErr != nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *emergency.Contact, ev *emergency.Event) error {
+						assert.Equal(t, tt.grantor.ID, ev.ActorID)
+						assert.Equal(t, emergency.ActionNominated, ev.Action)
+						return tt.createErr
+					})
+			}
+
+			c, err := s.Nominate(context.Background(), tt.grantor, tt.grantee, tt.typ, tt.wait, tt.key)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, emergency.StatusNominated, c.Status)
+			assert.Equal(t, tt.wantWait, c.WaitPeriod)
+			assert.Equal(t, tt.wantKey, c.WrappedVaultKey)
+			assert.Equal(t, tt.grantee.Login, c.GranteeLogin)
+		})
+	}
+}
+
+func TestService_RequestAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorID    int
+		stored     *emergency.Contact
+		getErr     error
+		transition bool
+		wantErr    error
+	}{
+		{name: "nominated", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusNominated, 0), transition: true},
+		// После отказа контакт может запросить доступ снова
+		{name: "rejected", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusRejected, time.Hour), transition: true},
+		{
+			name: "already requested", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			wantErr: emergency.ErrAlreadyRequested,
+		},
+		{
+			name: "already approved", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour),
+			wantErr: emergency.ErrAlreadyApproved,
+		},
+		{name: "grantor", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusNominated, 0), wantErr: emergency.ErrNotFound},
+		{name: "outsider", actorID: outsiderID, stored: newContact(emergency.AccessView, emergency.StatusNominated, 0), wantErr: emergency.ErrNotFound},
+		{name: "not found", actorID: granteeID, getErr: emergency.ErrNotFound, wantErr: emergency.ErrNotFound},
+		{name: "storage error", actorID: granteeID, getErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.stored, tt.getErr)
+			if tt.transition {
+				expectTransition(t, repo, tt.stored.Status, granteeID, emergency.ActionRequested)
+			}
+
+			c, err := s.RequestAccess(context.Background(), tt.actorID, contactID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, emergency.StatusPending, c.Status)
+			assert.WithinDuration(t, time.Now().Add(72*time.Hour), c.AccessAt(), time.Minute)
+		})
+	}
+}
+
+func TestService_Approve(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID int
+		stored  *emergency.Contact
+		trErr   error
+		wantErr error
+	}{
+		{name: "pending", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour)},
+		{
+			name: "not requested", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusNominated, 0),
+			wantErr: emergency.ErrNotPending,
+		},
+		{
+			name: "grantee", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			wantErr: emergency.ErrNotFound,
+		},
+		{
+			// Владелец и сервер ответили одновременно
+			name: "state changed", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			trErr: emergency.ErrStateChanged, wantErr: emergency.ErrStateChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.stored, nil)
+			if tt.stored.Status == emergency.StatusPending && tt.actorID == grantorID {
+				repo.EXPECT().Transition(gomock.Any(), gomock.Any(), emergency.StatusPending, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *emergency.Contact, _ emergency.Status, ev *emergency.Event) error {
+						assert.Equal(t, emergency.ActionApproved, ev.Action)
+						return tt.trErr
+					})
+			}
+
+			c, err := s.Approve(context.Background(), tt.actorID, contactID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, emergency.StatusApproved, c.Status)
+		})
+	}
+}
+
+func TestService_Reject(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorID    int
+		stored     *emergency.Contact
+		wantAction emergency.Action
+		wantErr    error
+	}{
+		{
+			name: "pending", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			wantAction: emergency.ActionRejected,
+		},
+		{
+			// Владелец может отозвать открытый доступ
+			name: "approved", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour),
+			wantAction: emergency.ActionRevoked,
+		},
+		{
+			name: "not requested", actorID: grantorID, stored: newContact(emergency.AccessView, emergency.StatusNominated, 0),
+			wantErr: emergency.ErrNotPending,
+		},
+		{
+			name: "grantee", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			wantErr: emergency.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.stored, nil)
+			if tt.wantErr == nil {
+				expectTransition(t, repo, tt.stored.Status, grantorID, tt.wantAction)
+			}
+
+			c, err := s.Reject(context.Background(), tt.actorID, contactID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, emergency.StatusRejected, c.Status)
+		})
+	}
+}
+
+func TestService_AutoApprove(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     *emergency.Contact
+		trErr      error
+		reloaded   *emergency.Contact
+		wantStatus emergency.Status
+		wantErr    error
+	}{
+		{
+			name:       "wait not expired",
+			stored:     newContact(emergency.AccessView, emergency.StatusPending, 71*time.Hour),
+			wantStatus: emergency.StatusPending,
+		},
+		{
+			// Период ожидания истек без ответа владельца
+			name:       "wait expired",
+			stored:     newContact(emergency.AccessView, emergency.StatusPending, 73*time.Hour),
+			wantStatus: emergency.StatusApproved,
+		},
+		{
+			// Владелец успел отклонить запрос параллельно, возвращается его решение
+			name:       "owner answered concurrently",
+			stored:     newContact(emergency.AccessView, emergency.StatusPending, 73*time.Hour),
+			trErr:      emergency.ErrStateChanged,
+			reloaded:   newContact(emergency.AccessView, emergency.StatusRejected, 73*time.Hour),
+			wantStatus: emergency.StatusRejected,
+		},
+		{
+			name:    "storage error",
+			stored:  newContact(emergency.AccessView, emergency.StatusPending, 73*time.Hour),
+			trErr:   errStorage,
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().ListByGrantor(gomock.Any(), grantorID).Return([]*emergency.Contact{tt.stored}, nil)
+			if tt.stored.AccessAt().Before(time.Now()) {
+				repo.EXPECT().Transition(gomock.Any(), gomock.Any(), emergency.StatusPending, gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *emergency.Contact, _ emergency.Status, ev *emergency.Event) error {
+						// Решение записывается от имени сервера моментом истечения ожидания
+						assert.Zero(t, ev.ActorID)
+						assert.Equal(t, emergency.ActionAutoApproved, ev.Action)
+						assert.Equal(t, c.AccessAt(), ev.CreatedAt)
+						return tt.trErr
+					})
+			}
+			if tt.reloaded != nil {
+				repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.reloaded, nil)
+			}
+
+			contacts, err := s.ListTrusted(context.Background(), grantorID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, contacts, 1)
+			assert.Equal(t, tt.wantStatus, contacts[0].Status)
+		})
+	}
+}
+
+func TestService_Vault(t *testing.T) {
+	grantor := &user.User{ID: grantorID, Login: "owner"}
+	grantee := &user.User{ID: granteeID, Login: "contact"}
+	secrets := []*secret.Secret{{ID: 1, UserID: grantorID, Name: "bank"}}
+
+	tests := []struct {
+		name      string
+		actorID   int
+		stored    *emergency.Contact
+		exportErr error
+		wantErr   error
+	}{
+		{name: "approved", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour)},
+		{
+			// До истечения периода ожидания секреты недоступны
+			name: "pending", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusPending, time.Hour),
+			wantErr: emergency.ErrNotApproved,
+		},
+		{
+			name: "rejected", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusRejected, time.Hour),
+			wantErr: emergency.ErrNotApproved,
+		},
+		{
+			name: "outsider", actorID: outsiderID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour),
+			wantErr: emergency.ErrNotFound,
+		},
+		{
+			name: "export error", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour),
+			exportErr: errStorage, wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.stored, nil)
+			if tt.actorID == granteeID && tt.stored.Status == emergency.StatusApproved {
+				accounts.EXPECT().GetUserByID(gomock.Any(), grantorID).Return(grantor, nil)
+				accounts.EXPECT().GetUserByID(gomock.Any(), granteeID).Return(grantee, nil)
+				// Одобрение доступа к критичным секретам проверяется для контакта, а не для владельца
+				vaults.EXPECT().ExportVault(gomock.Any(), grantor, grantee).Return(secrets, tt.exportErr)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().AddEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ev *emergency.Event) error {
+					assert.Equal(t, granteeID, ev.ActorID)
+					assert.Equal(t, emergency.ActionAccessed, ev.Action)
+					return nil
+				})
+			}
+
+			c, got, err := s.Vault(context.Background(), tt.actorID, contactID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, grantorID, c.GrantorID)
+			assert.Equal(t, secrets, got)
+		})
+	}
+}
+
+func TestService_Takeover(t *testing.T) {
+	upd := user.CredentialsUpdate{NewPassword: "new-password"}
+
+	tests := []struct {
+		name       string
+		actorID    int
+		stored     *emergency.Contact
+		checkErr   error
+		revokeErr  error
+		disableErr error
+		resetErr   error
+		wantErr    error
+	}{
+		{name: "approved", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour)},
+		{
+			name: "not approved", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusNominated, 0),
+			wantErr: emergency.ErrNotApproved,
+		},
+		{
+			// Доступ только на чтение не позволяет перехват аккаунта
+			name: "view access", actorID: granteeID, stored: newContact(emergency.AccessView, emergency.StatusApproved, time.Hour),
+			wantErr: emergency.ErrTakeoverNotAllowed,
+		},
+		{
+			name: "outsider", actorID: outsiderID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour),
+			wantErr: emergency.ErrNotFound,
+		},
+		{
+			// Слабый пароль не отключает защиту владельца
+			name: "weak password", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour),
+			checkErr: user.ErrWeakPassword, wantErr: user.ErrWeakPassword,
+		},
+		{
+			// Пароль не меняется, пока сессии владельца не отозваны
+			name: "revoke error", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour),
+			revokeErr: errStorage, wantErr: errStorage,
+		},
+		{
+			name: "disable mfa error", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour),
+			disableErr: errStorage, wantErr: errStorage,
+		},
+		{
+			name: "reset error", actorID: granteeID, stored: newContact(emergency.AccessTakeover, emergency.StatusApproved, time.Hour),
+			resetErr: errStorage, wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(tt.stored, nil)
+
+			// Каждый шаг выполняется, только если предыдущие прошли успешно
+			allowed := tt.actorID == granteeID && tt.stored.Status == emergency.StatusApproved &&
+				tt.stored.Type == emergency.AccessTakeover
+			var calls []any
+			if allowed {
+				calls = append(calls, accounts.EXPECT().CheckResetPassword(gomock.Any(), grantorID, upd).Return(tt.checkErr))
+			}
+			if allowed && tt.checkErr == nil {
+				calls = append(calls, sessions.EXPECT().RevokeOthers(gomock.Any(), grantorID, "").Return(2, tt.revokeErr))
+			}
+			if allowed && tt.checkErr == nil && tt.revokeErr == nil {
+				calls = append(calls, mfa.EXPECT().Disable(gomock.Any(), grantorID).Return(tt.disableErr))
+			}
+			if allowed && tt.checkErr == nil && tt.revokeErr == nil && tt.disableErr == nil {
+				calls = append(calls, accounts.EXPECT().ResetPassword(gomock.Any(), grantorID, upd).
+					Return(&user.User{ID: grantorID}, tt.resetErr))
+			}
+			if tt.wantErr == nil {
+				calls = append(calls, repo.EXPECT().AddEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, ev *emergency.Event) error {
+						assert.Equal(t, granteeID, ev.ActorID)
+						assert.Equal(t, emergency.ActionTakeover, ev.Action)
+						return nil
+					}))
+			}
+			gomock.InOrder(calls...)
+
+			c, err := s.Takeover(context.Background(), tt.actorID, contactID, upd)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, grantorID, c.GrantorID)
+		})
+	}
+}
+
+func TestService_Remove(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID int
+		getErr  error
+		wantErr error
+	}{
+		{name: "grantor", actorID: grantorID},
+		{name: "grantee", actorID: granteeID},
+		{name: "outsider", actorID: outsiderID, wantErr: emergency.ErrNotFound},
+		{name: "already removed", actorID: grantorID, getErr: emergency.ErrNotFound, wantErr: emergency.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockEmergencyRepository(ctrl)
+			accounts := mocks.NewMockEmergencyAccounts(ctrl)
+			vaults := mocks.NewMockEmergencyVaults(ctrl)
+			sessions := mocks.NewMockEmergencySessions(ctrl)
+			mfa := mocks.NewMockEmergencySecondFactors(ctrl)
+			s := emergency.NewService(repo, testConfig(), accounts, vaults, sessions, mfa)
+
+			var stored *emergency.Contact
+			if tt.getErr == nil {
+				stored = newContact(emergency.AccessView, emergency.StatusNominated, 0)
+			}
+			repo.EXPECT().Get(gomock.Any(), contactID).Return(stored, tt.getErr)
+			if tt.wantErr == nil {
+				// Журнал сохраняется вместе с удалением назначения
+				repo.EXPECT().Delete(gomock.Any(), stored, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *emergency.Contact, ev *emergency.Event) error {
+						assert.Equal(t, tt.actorID, ev.ActorID)
+						assert.Equal(t, emergency.ActionRemoved, ev.Action)
+						return nil
+					})
+			}
+
+			err := s.Remove(context.Background(), tt.actorID, contactID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Package emergency пакет уровня домена экстренного доступа: пользователь назначает доверенный контакт,
+// который может запросить доступ к его хранилищу. Доступ открывается после периода ожидания,
+// в течение которого владелец может отклонить запрос.
+package emergency
+
+import (
+	"errors"
+	"time"
+)
+
+// AccessType уровень доступа доверенного контакта.
+type AccessType string
+
+const (
+	// AccessView только чтение секретов владельца.
+	AccessView AccessType = "view"
+	// AccessTakeover чтение секретов и смена пароля владельца (перехват аккаунта).
+	AccessTakeover AccessType = "takeover"
+)
+
+// Valid известен ли уровень доступа.
+func (t AccessType) Valid() bool {
+	return t == AccessView || t == AccessTakeover
+}
+
+// Status состояние доверенного контакта.
+type Status string
+
+const (
+	// StatusNominated контакт назначен, доступ не запрашивался.
+	StatusNominated Status = "nominated"
+	// StatusPending доступ запрошен, идет период ожидания.
+	StatusPending Status = "pending"
+	// StatusApproved доступ открыт: владелец одобрил запрос или период ожидания истек.
+	StatusApproved Status = "approved"
+	// StatusRejected владелец отклонил запрос или отозвал открытый доступ. Контакт может запросить доступ снова.
+	StatusRejected Status = "rejected"
+)
+
+// Action действие в журнале экстренного доступа.
+type Action string
+
+const (
+	// ActionNominated владелец назначил доверенный контакт.
+	ActionNominated Action = "nominated"
+	// ActionRequested контакт запросил доступ.
+	ActionRequested Action = "requested"
+	// ActionApproved владелец одобрил запрос до истечения периода ожидания.
+	ActionApproved Action = "approved"
+	// ActionAutoApproved период ожидания истек без ответа владельца.
+	ActionAutoApproved Action = "auto_approved"
+	// ActionRejected владелец отклонил запрос.
+	ActionRejected Action = "rejected"
+	// ActionRevoked владелец отозвал открытый доступ.
+	ActionRevoked Action = "revoked"
+	// ActionRemoved владелец или контакт удалили назначение.
+	ActionRemoved Action = "removed"
+	// ActionAccessed контакт получил секреты владельца.
+	ActionAccessed Action = "accessed"
+	// ActionTakeover контакт сменил пароль владельца.
+	ActionTakeover Action = "takeover"
+)
+
+var (
+	// ErrNotFound контакта нет или пользователь не его владелец или получатель.
+	ErrNotFound = errors.New("emergency contact not found")
+	// ErrSelf нельзя назначить доверенным контактом самого себя.
+	ErrSelf = errors.New("cannot nominate yourself")
+	// ErrAlreadyNominated пользователь уже назначен доверенным контактом.
+	ErrAlreadyNominated = errors.New("user is already an emergency contact")
+	// ErrInvalidType неизвестный уровень доступа.
+	ErrInvalidType = errors.New("invalid access type")
+	// ErrInvalidWait период ожидания вне допустимых пределов.
+	ErrInvalidWait = errors.New("invalid wait period")
+	// ErrVaultKeyRequired для E2E аккаунта нужен ключ хранилища, зашифрованный открытым ключом контакта.
+	ErrVaultKeyRequired = errors.New("vault key sealed to the contact is required")
+	// ErrNoShareKeys у контакта нет ключей обмена, ему нельзя передать ключ E2E хранилища.
+	ErrNoShareKeys = errors.New("contact has no share keys")
+	// ErrAlreadyRequested доступ уже запрошен.
+	ErrAlreadyRequested = errors.New("access is already requested")
+	// ErrAlreadyApproved доступ уже открыт.
+	ErrAlreadyApproved = errors.New("access is already approved")
+	// ErrNotPending запроса доступа нет.
+	ErrNotPending = errors.New("access is not requested")
+	// ErrNotApproved доступ еще не открыт.
+	ErrNotApproved = errors.New("access is not approved")
+	// ErrTakeoverNotAllowed уровень доступа не позволяет перехват аккаунта.
+	ErrTakeoverNotAllowed = errors.New("access type does not allow takeover")
+	// ErrStateChanged состояние контакта изменилось параллельным запросом.
+	ErrStateChanged = errors.New("emergency contact state changed concurrently")
+)
+
+// Contact доверенный контакт: GranteeID может запросить доступ к хранилищу GrantorID.
+type Contact struct {
+	ID           int
+	GrantorID    int
+	GranteeID    int
+	GrantorLogin string
+	GranteeLogin string
+	Type         AccessType
+	WaitPeriod   time.Duration
+	Status       Status
+	// WrappedVaultKey ключ E2E хранилища владельца, зашифрованный открытым ключом контакта.
+	// Пустой, если сервер хранит данные владельца сам.
+	WrappedVaultKey []byte
+	RequestedAt     time.Time
+	DecidedAt       time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NewContact новый доверенный контакт в состоянии StatusNominated.
+func NewContact(
+	grantorID, granteeID int,
+	accessType AccessType,
+	wait time.Duration,
+	wrappedVaultKey []byte,
+) (*Contact, error) {
+	switch {
+	case grantorID == granteeID:
+		return nil, ErrSelf
+	case !accessType.Valid():
+		return nil, ErrInvalidType
+	}
+
+	now := time.Now()
+
+	return &Contact{
+		GrantorID:       grantorID,
+		GranteeID:       granteeID,
+		Type:            accessType,
+		WaitPeriod:      wait,
+		Status:          StatusNominated,
+		WrappedVaultKey: wrappedVaultKey,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// AccessAt момент, после которого запрошенный доступ открывается без ответа владельца.
+func (c *Contact) AccessAt() time.Time {
+	return c.RequestedAt.Add(c.WaitPeriod)
+}
+
+// Request запрос доступа контактом. Повторный запрос возможен после отказа владельца.
+func (c *Contact) Request(now time.Time) error {
+	switch c.Status {
+	case StatusPending:
+		return ErrAlreadyRequested
+	case StatusApproved:
+		return ErrAlreadyApproved
+	}
+
+	c.Status = StatusPending
+	c.RequestedAt = now
+	c.DecidedAt = time.Time{}
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// Approve одобрение запроса владельцем до истечения периода ожидания.
+func (c *Contact) Approve(now time.Time) error {
+	if c.Status != StatusPending {
+		return ErrNotPending
+	}
+
+	c.Status = StatusApproved
+	c.DecidedAt = now
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// Reject отказ владельца в запрошенном доступе или отзыв уже открытого.
+func (c *Contact) Reject(now time.Time) error {
+	if c.Status != StatusPending && c.Status != StatusApproved {
+		return ErrNotPending
+	}
+
+	c.Status = StatusRejected
+	c.DecidedAt = now
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// expire открытие доступа, если период ожидания истек без ответа владельца.
+func (c *Contact) expire(now time.Time) bool {
+	if c.Status != StatusPending || now.Before(c.AccessAt()) {
+		return false
+	}
+
+	c.Status = StatusApproved
+	c.DecidedAt = c.AccessAt()
+	c.UpdatedAt = now
+
+	return true
+}
+
+// Event запись журнала экстренного доступа. ActorID ноль для действий самого сервера.
+type Event struct {
+	ID           int
+	ContactID    int
+	GrantorID    int
+	GranteeID    int
+	ActorID      int
+	GrantorLogin string
+	GranteeLogin string
+	ActorLogin   string
+	Action       Action
+	CreatedAt    time.Time
+}
+
+// newEvent запись журнала о действии actorID с контактом c.
+func newEvent(c *Contact, actorID int, action Action, at time.Time) *Event {
+	return &Event{
+		ContactID: c.ID,
+		GrantorID: c.GrantorID,
+		GranteeID: c.GranteeID,
+		ActorID:   actorID,
+		Action:    action,
+		CreatedAt: at,
+	}
+}
@@ -0,0 +1,28 @@
+package emergency
+
+//go:generate mockgen -destination=../../mocks/emergency.go -package=mocks -mock_names=Repository=MockEmergencyRepository,Accounts=MockEmergencyAccounts,Vaults=MockEmergencyVaults,Sessions=MockEmergencySessions,SecondFactors=MockEmergencySecondFactors . Repository,Accounts,Vaults,Sessions,SecondFactors
+
+import (
+	"context"
+)
+
+// Repository интерфейс репозитория экстренного доступа.
+type Repository interface {
+	// Create сохранение нового контакта вместе с записью журнала, ErrAlreadyNominated при повторе.
+	Create(ctx context.Context, c *Contact, ev *Event) error
+	// Get контакт с логинами владельца и получателя, ErrNotFound если его нет.
+	Get(ctx context.Context, id int) (*Contact, error)
+	// ListByGrantor доверенные контакты владельца.
+	ListByGrantor(ctx context.Context, grantorID int) ([]*Contact, error)
+	// ListByGrantee назначения пользователя доверенным контактом.
+	ListByGrantee(ctx context.Context, granteeID int) ([]*Contact, error)
+	// Transition сохранение нового состояния контакта вместе с записью журнала,
+	// только если в базе контакт все еще в состоянии from. Иначе ErrStateChanged.
+	Transition(ctx context.Context, c *Contact, from Status, ev *Event) error
+	// Delete удаление контакта вместе с записью журнала.
+	Delete(ctx context.Context, c *Contact, ev *Event) error
+	// AddEvent запись журнала.
+	AddEvent(ctx context.Context, ev *Event) error
+	// ListEvents журнал событий, где пользователь владелец или контакт, от новых к старым.
+	ListEvents(ctx context.Context, userID int) ([]*Event, error)
+}
@@ -0,0 +1,392 @@
+package emergency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+)
+
+// Accounts учетные записи пользователей, нужные экстренному доступу.
+type Accounts interface {
+	// GetUserByID пользователь по ID.
+	GetUserByID(ctx context.Context, userID int) (*user.User, error)
+	// CheckResetPassword проверка параметров ResetPassword без сохранения.
+	CheckResetPassword(ctx context.Context, userID int, upd user.CredentialsUpdate) error
+	// ResetPassword установка нового пароля пользователя без проверки старого.
+	ResetPassword(ctx context.Context, userID int, upd user.CredentialsUpdate) (*user.User, error)
+}
+
+// Vaults секреты хранилищ пользователей.
+type Vaults interface {
+	// ExportVault все секреты owner с расшифрованными данными для reader. Одобрение доступа
+	// к критичным секретам проверяется для reader.
+	ExportVault(ctx context.Context, owner, reader *user.User) ([]*secret.Secret, error)
+}
+
+// Sessions сессии пользователей.
+type Sessions interface {
+	// RevokeOthers отзыв сессий пользователя, кроме keepID. С пустым keepID отзываются все сессии.
+	RevokeOthers(ctx context.Context, userID int, keepID string) (int, error)
+}
+
+// SecondFactors второй фактор пользователей.
+type SecondFactors interface {
+	// Disable отключение второго фактора пользователя.
+	Disable(ctx context.Context, userID int) error
+}
+
+// Service структура сервиса экстренного доступа.
+type Service struct {
+	repo     Repository
+	cfg      *config.Config
+	accounts Accounts
+	vaults   Vaults
+	sessions Sessions
+	mfa      SecondFactors
+}
+
+// NewService получение сервиса экстренного доступа.
+func NewService(r Repository, c *config.Config, a Accounts, v Vaults, ss Sessions, m SecondFactors) *Service {
+	return &Service{
+		repo:     r,
+		cfg:      c,
+		accounts: a,
+		vaults:   v,
+		sessions: ss,
+		mfa:      m,
+	}
+}
+
+// Nominate назначение grantee доверенным контактом grantor. Нулевой wait заменяется значением
+// security.emergency_access.default_wait. Для E2E аккаунта владельца нужен wrappedVaultKey: ключ хранилища,
+// зашифрованный открытым ключом контакта на клиенте владельца; для остальных аккаунтов он не сохраняется.
+func (s *Service) Nominate(
+	ctx context.Context,
+	grantor, grantee *user.User,
+	accessType AccessType,
+	wait time.Duration,
+	wrappedVaultKey []byte,
+) (*Contact, error) {
+	op := "domain.emergency.Service.Nominate"
+
+	cfg := s.cfg.Security.EmergencyAccess
+	if wait == 0 {
+		wait = cfg.DefaultWait
+	}
+	if wait < cfg.MinWait || wait > cfg.MaxWait {
+		return nil, ErrInvalidWait
+	}
+
+	if grantor.IsE2E() {
+		switch {
+		case !grantee.HasShareKeys():
+			return nil, ErrNoShareKeys
+		case len(wrappedVaultKey) == 0:
+			return nil, ErrVaultKeyRequired
+		}
+	} else {
+		wrappedVaultKey = nil
+	}
+
+	c, err := NewContact(grantor.ID, grantee.ID, accessType, wait, wrappedVaultKey)
+	if err != nil {
+		return nil, err
+	}
+	c.GrantorLogin, c.GranteeLogin = grantor.Login, grantee.Login
+
+	if err = s.repo.Create(ctx, c, newEvent(c, grantor.ID, ActionNominated, c.CreatedAt)); err != nil {
+		if errors.Is(err, ErrAlreadyNominated) {
+			return nil, ErrAlreadyNominated
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return c, nil
+}
+
+// ListTrusted доверенные контакты владельца.
+func (s *Service) ListTrusted(ctx context.Context, grantorID int) ([]*Contact, error) {
+	contacts, err := s.repo.ListByGrantor(ctx, grantorID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.emergency.Service.ListTrusted: %w", err)
+	}
+
+	return s.resolveAll(ctx, contacts)
+}
+
+// ListGrantors владельцы хранилищ, назначившие пользователя доверенным контактом.
+func (s *Service) ListGrantors(ctx context.Context, granteeID int) ([]*Contact, error) {
+	contacts, err := s.repo.ListByGrantee(ctx, granteeID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.emergency.Service.ListGrantors: %w", err)
+	}
+
+	return s.resolveAll(ctx, contacts)
+}
+
+// Remove удаление назначения владельцем или самим контактом.
+func (s *Service) Remove(ctx context.Context, actorID, contactID int) error {
+	op := "domain.emergency.Service.Remove"
+
+	c, err := s.get(ctx, contactID)
+	if err != nil {
+		return err
+	}
+	if c.GrantorID != actorID && c.GranteeID != actorID {
+		return ErrNotFound
+	}
+
+	if err = s.repo.Delete(ctx, c, newEvent(c, actorID, ActionRemoved, time.Now())); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RequestAccess запрос доступа контактом: начинается период ожидания, в течение которого владелец
+// может отклонить запрос.
+func (s *Service) RequestAccess(ctx context.Context, granteeID, contactID int) (*Contact, error) {
+	c, err := s.granteeContact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, now := c.Status, time.Now()
+	if err = c.Request(now); err != nil {
+		return nil, err
+	}
+
+	if err = s.transition(ctx, c, from, newEvent(c, granteeID, ActionRequested, now)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Approve одобрение запроса владельцем без ожидания.
+func (s *Service) Approve(ctx context.Context, grantorID, contactID int) (*Contact, error) {
+	c, err := s.grantorContact(ctx, grantorID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err = c.Approve(now); err != nil {
+		return nil, err
+	}
+
+	if err = s.transition(ctx, c, StatusPending, newEvent(c, grantorID, ActionApproved, now)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Reject отказ владельца в запросе или отзыв уже открытого доступа.
+func (s *Service) Reject(ctx context.Context, grantorID, contactID int) (*Contact, error) {
+	c, err := s.grantorContact(ctx, grantorID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, now := c.Status, time.Now()
+	if err = c.Reject(now); err != nil {
+		return nil, err
+	}
+
+	action := ActionRejected
+	if from == StatusApproved {
+		action = ActionRevoked
+	}
+
+	if err = s.transition(ctx, c, from, newEvent(c, grantorID, action, now)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Vault секреты владельца для контакта с открытым доступом. Данные E2E хранилища возвращаются
+// зашифрованными, контакт расшифровывает их ключом Contact.WrappedVaultKey.
+func (s *Service) Vault(ctx context.Context, granteeID, contactID int) (*Contact, []*secret.Secret, error) {
+	op := "domain.emergency.Service.Vault"
+
+	c, err := s.approvedContact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grantor, err := s.accounts.GetUserByID(ctx, c.GrantorID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get grantor %w", op, err)
+	}
+
+	grantee, err := s.accounts.GetUserByID(ctx, granteeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to get grantee %w", op, err)
+	}
+
+	// Секреты под политикой одобрения раскрываются контакту только по его собственному одобренному запросу
+	secrets, err := s.vaults.ExportVault(ctx, grantor, grantee)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.AddEvent(ctx, newEvent(c, granteeID, ActionAccessed, time.Now())); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to save event %w", op, err)
+	}
+
+	return c, secrets, nil
+}
+
+// Takeover смена пароля владельца контактом с доступом AccessTakeover. Для E2E аккаунта upd содержит
+// новые параметры KDF и ключ хранилища, обернутый ключом из нового пароля. До смены пароля все сессии
+// владельца отзываются, а второй фактор отключается: аутентификатор остается у прежнего владельца.
+// Оба шага повторяемы, поэтому при ошибке перехват можно повторить целиком.
+func (s *Service) Takeover(
+	ctx context.Context,
+	granteeID, contactID int,
+	upd user.CredentialsUpdate,
+) (*Contact, error) {
+	op := "domain.emergency.Service.Takeover"
+
+	c, err := s.approvedContact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Type != AccessTakeover {
+		return nil, ErrTakeoverNotAllowed
+	}
+
+	// Параметры проверяются заранее, чтобы неверный пароль не отключил защиту аккаунта без его перехвата
+	if err = s.accounts.CheckResetPassword(ctx, c.GrantorID, upd); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = s.sessions.RevokeOthers(ctx, c.GrantorID, ""); err != nil {
+		return nil, fmt.Errorf("%s: failed to revoke grantor sessions %w", op, err)
+	}
+	if err = s.mfa.Disable(ctx, c.GrantorID); err != nil {
+		return nil, fmt.Errorf("%s: failed to disable grantor MFA %w", op, err)
+	}
+
+	if _, err = s.accounts.ResetPassword(ctx, c.GrantorID, upd); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.AddEvent(ctx, newEvent(c, granteeID, ActionTakeover, time.Now())); err != nil {
+		return nil, fmt.Errorf("%s: failed to save event %w", op, err)
+	}
+
+	return c, nil
+}
+
+// Events журнал экстренного доступа пользователя как владельца и как контакта.
+func (s *Service) Events(ctx context.Context, userID int) ([]*Event, error) {
+	events, err := s.repo.ListEvents(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.emergency.Service.Events: %w", err)
+	}
+
+	return events, nil
+}
+
+// get контакт по ID.
+func (s *Service) get(ctx context.Context, contactID int) (*Contact, error) {
+	c, err := s.repo.Get(ctx, contactID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("domain.emergency.Service.get: %w", err)
+	}
+
+	return c, nil
+}
+
+// grantorContact контакт владельца grantorID с учетом истекшего периода ожидания.
+func (s *Service) grantorContact(ctx context.Context, grantorID, contactID int) (*Contact, error) {
+	c, err := s.get(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GrantorID != grantorID {
+		return nil, ErrNotFound
+	}
+
+	return s.resolve(ctx, c)
+}
+
+// granteeContact назначение контакта granteeID с учетом истекшего периода ожидания.
+func (s *Service) granteeContact(ctx context.Context, granteeID, contactID int) (*Contact, error) {
+	c, err := s.get(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.GranteeID != granteeID {
+		return nil, ErrNotFound
+	}
+
+	return s.resolve(ctx, c)
+}
+
+// approvedContact назначение контакта granteeID с открытым доступом.
+func (s *Service) approvedContact(ctx context.Context, granteeID, contactID int) (*Contact, error) {
+	c, err := s.granteeContact(ctx, granteeID, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status != StatusApproved {
+		return nil, ErrNotApproved
+	}
+
+	return c, nil
+}
+
+// resolve открытие доступа, если период ожидания истек. Решение сохраняется в журнал от имени сервера.
+// Если владелец ответил параллельно, возвращается сохраненное им состояние.
+func (s *Service) resolve(ctx context.Context, c *Contact) (*Contact, error) {
+	if !c.expire(time.Now()) {
+		return c, nil
+	}
+
+	err := s.repo.Transition(ctx, c, StatusPending, newEvent(c, 0, ActionAutoApproved, c.DecidedAt))
+	if errors.Is(err, ErrStateChanged) {
+		return s.get(ctx, c.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("domain.emergency.Service.resolve: %w", err)
+	}
+
+	return c, nil
+}
+
+// resolveAll resolve для списка контактов.
+func (s *Service) resolveAll(ctx context.Context, contacts []*Contact) ([]*Contact, error) {
+	for i, c := range contacts {
+		resolved, err := s.resolve(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		contacts[i] = resolved
+	}
+
+	return contacts, nil
+}
+
+// transition сохранение перехода контакта из состояния from.
+func (s *Service) transition(ctx context.Context, c *Contact, from Status, ev *Event) error {
+	if err := s.repo.Transition(ctx, c, from, ev); err != nil {
+		if errors.Is(err, ErrStateChanged) {
+			return ErrStateChanged
+		}
+		return fmt.Errorf("domain.emergency.Service.transition: %w", err)
+	}
+
+	return nil
+}
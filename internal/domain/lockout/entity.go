@@ -0,0 +1,89 @@
+// Package lockout пакет уровня домена защиты входа от перебора: учет неудачных попыток,
+// экспоненциальная задержка и временная блокировка.
+package lockout
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+)
+
+// Kind вид счетчика попыток.
+type Kind string
+
+const (
+	// KindLogin попытки входа под логином, с любых адресов.
+	KindLogin Kind = "login"
+	// KindIP попытки входа с адреса, под любыми логинами.
+	KindIP Kind = "ip"
+	// KindMFA попытки ввода второго фактора пользователем.
+	KindMFA Kind = "mfa"
+)
+
+const keySep = ":"
+
+// maxBackoffShift ограничение степени удвоения, чтобы задержка не переполнилась до сравнения с MaxDelay.
+const maxBackoffShift = 30
+
+// Key ключ счетчика попыток, например "login:john" или "ip:10.0.0.1".
+func Key(kind Kind, value string) string {
+	return string(kind) + keySep + value
+}
+
+func kindOf(key string) Kind {
+	kind, _, _ := strings.Cut(key, keySep)
+	return Kind(kind)
+}
+
+// Attempt неудачные попытки по ключу.
+type Attempt struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	// BlockedUntil до этого момента попытки по ключу не принимаются.
+	BlockedUntil time.Time
+	// Locked блокировка после порога неудач, а не очередная задержка.
+	Locked bool
+}
+
+// RetryAfter через сколько можно повторить попытку.
+func (a *Attempt) RetryAfter(now time.Time) time.Duration {
+	if now.Before(a.BlockedUntil) {
+		return a.BlockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// rule пороги неудач для вида счетчика.
+type rule struct {
+	free    int
+	lockout int
+}
+
+func rulesFromConfig(cfg config.ThrottleConfig) map[Kind]rule {
+	login := rule{free: cfg.LoginFreeAttempts, lockout: cfg.LoginLockoutAttempts}
+
+	return map[Kind]rule{
+		KindLogin: login,
+		KindMFA:   login,
+		KindIP:    {free: cfg.IPFreeAttempts, lockout: cfg.IPLockoutAttempts},
+	}
+}
+
+// block до какого момента блокировать ключ после failures неудач подряд и является ли это блокировкой.
+func block(cfg config.ThrottleConfig, r rule, failures int, now time.Time) (time.Time, bool) {
+	if r.lockout > 0 && failures >= r.lockout {
+		return now.Add(cfg.LockoutDuration), true
+	}
+	if failures < r.free {
+		return time.Time{}, false
+	}
+
+	delay := cfg.BaseDelay << min(failures-r.free, maxBackoffShift)
+	if delay > cfg.MaxDelay || delay <= 0 {
+		delay = cfg.MaxDelay
+	}
+
+	return now.Add(delay), false
+}
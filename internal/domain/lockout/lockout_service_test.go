@@ -0,0 +1,270 @@
+package lockout_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var errStorage = errors.New("storage error")
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Security.Throttle = config.ThrottleConfig{
+		LoginFreeAttempts:    3,
+		LoginLockoutAttempts: 6,
+		IPFreeAttempts:       5,
+		IPLockoutAttempts:    20,
+		BaseDelay:            time.Second,
+		MaxDelay:             10 * time.Second,
+		LockoutDuration:      time.Hour,
+		ResetAfter:           time.Hour,
+	}
+
+	return cfg
+}
+
+func TestService_Fail(t *testing.T) {
+	loginKey := lockout.Key(lockout.KindLogin, "john")
+	ipKey := lockout.Key(lockout.KindIP, "10.0.0.1")
+	mfaKey := lockout.Key(lockout.KindMFA, "1")
+
+	tests := []struct {
+		name      string
+		key       string
+		failures  int
+		wantBlock bool
+		wantDelay time.Duration
+		wantLock  bool
+	}{
+		{name: "first login failure", key: loginKey, failures: 1},
+		{name: "last free login failure", key: loginKey, failures: 2},
+		{name: "login delay", key: loginKey, failures: 3, wantBlock: true, wantDelay: time.Second},
+		{name: "login delay doubles", key: loginKey, failures: 4, wantBlock: true, wantDelay: 2 * time.Second},
+		{name: "login delay doubles again", key: loginKey, failures: 5, wantBlock: true, wantDelay: 4 * time.Second},
+		{name: "login lockout", key: loginKey, failures: 6, wantBlock: true, wantDelay: time.Hour, wantLock: true},
+		// У адреса свой, более высокий порог
+		{name: "free ip failure", key: ipKey, failures: 4},
+		{name: "ip delay", key: ipKey, failures: 6, wantBlock: true, wantDelay: 2 * time.Second},
+		{name: "ip max delay", key: ipKey, failures: 19, wantBlock: true, wantDelay: 10 * time.Second},
+		{name: "ip lockout", key: ipKey, failures: 20, wantBlock: true, wantDelay: time.Hour, wantLock: true},
+		// Второй фактор подчиняется порогам логина
+		{name: "mfa lockout", key: mfaKey, failures: 6, wantBlock: true, wantDelay: time.Hour, wantLock: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+
+			repo.EXPECT().RecordFailure(gomock.Any(), tt.key, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, now, staleBefore time.Time) (int, error) {
+					// Неудачи давнее ResetAfter не учитываются
+					assert.Equal(t, time.Hour, now.Sub(staleBefore))
+					return tt.failures, nil
+				})
+			if tt.wantBlock {
+				repo.EXPECT().Block(gomock.Any(), tt.key, gomock.Any(), tt.wantLock).
+					DoAndReturn(func(_ context.Context, _ string, until time.Time, _ bool) error {
+						assert.WithinDuration(t, time.Now().Add(tt.wantDelay), until, time.Second)
+						return nil
+					})
+			}
+
+			s := lockout.NewService(repo, testConfig())
+			require.NoError(t, s.Fail(context.Background(), tt.key))
+		})
+	}
+}
+
+func TestService_FailError(t *testing.T) {
+	loginKey := lockout.Key(lockout.KindLogin, "john")
+
+	tests := []struct {
+		name  string
+		setup func(repo *mocks.MockLockoutRepository)
+	}{
+		{
+			name: "record failure",
+			setup: func(repo *mocks.MockLockoutRepository) {
+				repo.EXPECT().RecordFailure(gomock.Any(), loginKey, gomock.Any(), gomock.Any()).Return(0, errStorage)
+			},
+		},
+		{
+			name: "block",
+			setup: func(repo *mocks.MockLockoutRepository) {
+				repo.EXPECT().RecordFailure(gomock.Any(), loginKey, gomock.Any(), gomock.Any()).Return(6, nil)
+				repo.EXPECT().Block(gomock.Any(), loginKey, gomock.Any(), true).Return(errStorage)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+			tt.setup(repo)
+
+			s := lockout.NewService(repo, testConfig())
+
+			// Ключ адреса после ошибки не обрабатывается
+			err := s.Fail(context.Background(), loginKey, lockout.Key(lockout.KindIP, "10.0.0.1"))
+			require.ErrorIs(t, err, errStorage)
+		})
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		attempts  []*lockout.Attempt
+		repoErr   error
+		wantErr   error
+		wantRetry time.Duration
+		wantLock  bool
+	}{
+		{name: "no attempts"},
+		{name: "free failures", attempts: []*lockout.Attempt{{Key: "login:john", Failures: 2}}},
+		{name: "delay expired", attempts: []*lockout.Attempt{{Key: "login:john", BlockedUntil: now.Add(-time.Second)}}},
+		{
+			name:      "delay",
+			attempts:  []*lockout.Attempt{{Key: "login:john", BlockedUntil: now.Add(4 * time.Second)}},
+			wantErr:   lockout.ErrThrottled,
+			wantRetry: 4 * time.Second,
+		},
+		{
+			// Ответ по самому долгому запрету среди ключей
+			name: "longest of keys",
+			attempts: []*lockout.Attempt{
+				{Key: "login:john", BlockedUntil: now.Add(4 * time.Second)},
+				{Key: "ip:10.0.0.1", BlockedUntil: now.Add(time.Hour), Locked: true},
+			},
+			wantErr:   lockout.ErrThrottled,
+			wantRetry: time.Hour,
+			wantLock:  true,
+		},
+		{name: "storage error", repoErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+			repo.EXPECT().Get(gomock.Any(), []string{"login:john", "ip:10.0.0.1"}).Return(tt.attempts, tt.repoErr)
+
+			s := lockout.NewService(repo, testConfig())
+
+			err := s.Check(context.Background(), "login:john", "ip:10.0.0.1")
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+
+			var throttled *lockout.ThrottledError
+			if errors.As(err, &throttled) {
+				assert.InDelta(t, tt.wantRetry, throttled.RetryAfter, float64(time.Second))
+				assert.Equal(t, tt.wantLock, throttled.Locked)
+			}
+		})
+	}
+}
+
+func TestService_Succeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "reset"},
+		// Счетчика могло и не быть
+		{name: "no failures", repoErr: lockout.ErrNotFound},
+		{name: "storage error", repoErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+			repo.EXPECT().Reset(gomock.Any(), "mfa:1").Return(tt.repoErr)
+
+			s := lockout.NewService(repo, testConfig())
+
+			err := s.Succeed(context.Background(), "mfa:1")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Clear(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "not blocked", repoErr: lockout.ErrNotFound},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+			repo.EXPECT().Reset(gomock.Any(), "login:john").Return(tt.repoErr)
+
+			s := lockout.NewService(repo, testConfig())
+
+			err := s.Clear(context.Background(), "login:john")
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ListBlocked(t *testing.T) {
+	blocked := []*lockout.Attempt{{Key: "login:john", BlockedUntil: time.Now().Add(time.Hour), Locked: true}}
+
+	tests := []struct {
+		name    string
+		stored  []*lockout.Attempt
+		repoErr error
+	}{
+		{name: "success", stored: blocked},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockLockoutRepository(ctrl)
+			repo.EXPECT().ListBlocked(gomock.Any(), gomock.Any()).Return(tt.stored, tt.repoErr)
+
+			s := lockout.NewService(repo, testConfig())
+
+			attempts, err := s.ListBlocked(context.Background())
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stored, attempts)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package lockout
+
+//go:generate mockgen -destination=../../mocks/lockout.go -package=mocks -mock_names=Repository=MockLockoutRepository . Repository
+
+import (
+	"context"
+	"time"
+)
+
+// Repository интерфейс репозитория счетчиков попыток.
+type Repository interface {
+	// Get счетчики по ключам, отсутствующие ключи пропускаются.
+	Get(ctx context.Context, keys []string) ([]*Attempt, error)
+	// RecordFailure атомарное увеличение счетчика неудач. Если последняя неудача была раньше staleBefore,
+	// счет начинается заново. Возвращает число неудач подряд.
+	RecordFailure(ctx context.Context, key string, now, staleBefore time.Time) (int, error)
+	// Block запрет попыток по ключу до until.
+	Block(ctx context.Context, key string, until time.Time, locked bool) error
+	// Reset удаление счетчика, ErrNotFound если его нет.
+	Reset(ctx context.Context, key string) error
+	// ListBlocked счетчики, попытки по которым сейчас запрещены.
+	ListBlocked(ctx context.Context, now time.Time) ([]*Attempt, error)
+}
@@ -0,0 +1,125 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+)
+
+var (
+	// ErrNotFound счетчик попыток не найден.
+	ErrNotFound = errors.New("lockout not found")
+	// ErrThrottled попытки временно запрещены, подробности в ThrottledError.
+	ErrThrottled = errors.New("too many attempts")
+)
+
+// ThrottledError попытки по одному из ключей временно запрещены.
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrThrottled, e.RetryAfter.Round(time.Second))
+}
+
+// Is для errors.Is(err, ErrThrottled).
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// Service сервисный слой защиты от перебора.
+type Service struct {
+	repo  Repository
+	cfg   *config.Config
+	rules map[Kind]rule
+}
+
+// NewService получение сервиса защиты от перебора.
+func NewService(r Repository, cfg *config.Config) *Service {
+	return &Service{
+		repo:  r,
+		cfg:   cfg,
+		rules: rulesFromConfig(cfg.Security.Throttle),
+	}
+}
+
+// Check можно ли сейчас делать попытку по всем ключам. Вызывается до проверки пароля или кода.
+func (s *Service) Check(ctx context.Context, keys ...string) error {
+	attempts, err := s.repo.Get(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("domain.lockout.Service.Check: %w", err)
+	}
+
+	now := time.Now()
+	var throttled *ThrottledError
+	for _, a := range attempts {
+		retry := a.RetryAfter(now)
+		if retry <= 0 {
+			continue
+		}
+		if throttled == nil || retry > throttled.RetryAfter {
+			throttled = &ThrottledError{RetryAfter: retry, Locked: a.Locked}
+		}
+	}
+	if throttled != nil {
+		return throttled
+	}
+
+	return nil
+}
+
+// Fail учет неудачной попытки по всем ключам.
+func (s *Service) Fail(ctx context.Context, keys ...string) error {
+	op := "domain.lockout.Service.Fail"
+
+	now := time.Now()
+	for _, key := range keys {
+		failures, err := s.repo.RecordFailure(ctx, key, now, now.Add(-s.cfg.Security.Throttle.ResetAfter))
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		until, locked := block(s.cfg.Security.Throttle, s.rules[kindOf(key)], failures, now)
+		if until.IsZero() {
+			continue
+		}
+		if err = s.repo.Block(ctx, key, until, locked); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Succeed сброс счетчика после успешной попытки. Сбрасывается только счетчик логина:
+// счетчик адреса не должен обнуляться входом злоумышленника в собственный аккаунт.
+func (s *Service) Succeed(ctx context.Context, key string) error {
+	if err := s.repo.Reset(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("domain.lockout.Service.Succeed: %w", err)
+	}
+
+	return nil
+}
+
+// ListBlocked ключи, попытки по которым сейчас запрещены.
+func (s *Service) ListBlocked(ctx context.Context) ([]*Attempt, error) {
+	attempts, err := s.repo.ListBlocked(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("domain.lockout.Service.ListBlocked: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Clear снятие блокировки администратором.
+func (s *Service) Clear(ctx context.Context, key string) error {
+	if err := s.repo.Reset(ctx, key); err != nil {
+		return fmt.Errorf("domain.lockout.Service.Clear: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package mfa пакет уровня домена второго фактора входа: TOTP и коды восстановления.
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodesCount = 10
+	// recoveryCodeBytes 80 бит случайности, 16 символов base32.
+	recoveryCodeBytes = 10
+	recoveryCodeGroup = 4
+)
+
+// TOTP подключенный к аккаунту аутентификатор.
+type TOTP struct {
+	UserID int
+	// Secret общий секрет аутентификатора, зашифрованный ключом данных пользователя.
+	Secret string
+	// LastStep шаг времени последнего принятого кода, коды этого и более ранних шагов повторно не принимаются.
+	LastStep int64
+	// ConfirmedAt момент подтверждения первым кодом. До этого второй фактор не действует.
+	ConfirmedAt time.Time
+	CreatedAt   time.Time
+}
+
+// IsConfirmed подтвержден ли аутентификатор.
+func (t *TOTP) IsConfirmed() bool {
+	return !t.ConfirmedAt.IsZero()
+}
+
+// newRecoveryCodes генерация кодов восстановления вида XXXX-XXXX-XXXX-XXXX и их хэшей для хранения.
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	raw := make([]byte, recoveryCodeBytes)
+	for range recoveryCodesCount {
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code %w", err)
+		}
+
+		plain := base32.StdEncoding.EncodeToString(raw)
+		groups := make([]string, 0, len(plain)/recoveryCodeGroup)
+		for i := 0; i < len(plain); i += recoveryCodeGroup {
+			groups = append(groups, plain[i:i+recoveryCodeGroup])
+		}
+
+		code := strings.Join(groups, "-")
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode приведение кода к виду без разделителей и регистра, как его ни ввел пользователь.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
+
+// isRecoveryCode похож ли введенный код на код восстановления, а не на код аутентификатора.
+func isRecoveryCode(code string) bool {
+	return len(normalizeRecoveryCode(code)) == base32.StdEncoding.EncodedLen(recoveryCodeBytes)
+}
+
+// hashRecoveryCode хэш кода восстановления. Коды случайны и длинны, поэтому медленный хэш не нужен.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+
+	return hex.EncodeToString(sum[:])
+}
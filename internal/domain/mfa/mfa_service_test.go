@@ -0,0 +1,408 @@
+package mfa_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/mfa"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/Melikhov-p/goph-keeper/internal/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	errStorage = errors.New("storage error")
+	// testKey ключ данных пользователя, которым зашифрован секрет аутентификатора.
+	testKey = bytes.Repeat([]byte{7}, 32)
+)
+
+// expectUserKey ключ данных пользователя, каждый раз в новом буфере: сервис затирает его после использования.
+func expectUserKey(keys *mocks.MockMFADataKeys) {
+	keys.EXPECT().UserKey(gomock.Any(), 1).DoAndReturn(func(context.Context, int) (*securemem.Buffer, error) {
+		return securemem.New(bytes.Clone(testKey)), nil
+	}).AnyTimes()
+}
+
+// sealedTOTP аутентификатор пользователя 1 с секретом secret, зашифрованным testKey.
+func sealedTOTP(t *testing.T, secret []byte, lastStep int64, confirmed bool) *mfa.TOTP {
+	t.Helper()
+
+	sealed, err := encryptor.EncryptWithMasterKey(secret, testKey)
+	require.NoError(t, err)
+
+	tp := &mfa.TOTP{UserID: 1, Secret: sealed, LastStep: lastStep, CreatedAt: time.Now()}
+	if confirmed {
+		tp.ConfirmedAt = time.Now()
+	}
+
+	return tp
+}
+
+func newSecret(t *testing.T) []byte {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	return secret
+}
+
+func TestService_Required(t *testing.T) {
+	secret := newSecret(t)
+
+	tests := []struct {
+		name     string
+		required bool
+		totp     *mfa.TOTP
+		repoErr  error
+		want     bool
+		wantErr  bool
+	}{
+		{name: "not enrolled", repoErr: mfa.ErrNotEnrolled, want: false},
+		{name: "not confirmed", totp: sealedTOTP(t, secret, 0, false), want: false},
+		{name: "confirmed", totp: sealedTOTP(t, secret, 0, true), want: true},
+		{name: "required by policy", required: true, repoErr: mfa.ErrNotEnrolled, want: true},
+		{name: "storage error", repoErr: errStorage, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+			repo.EXPECT().GetTOTP(gomock.Any(), 1).Return(tt.totp, tt.repoErr)
+
+			cfg := &config.Config{}
+			cfg.Security.MFA.Required = tt.required
+			s := mfa.NewService(repo, cfg, mocks.NewMockMFADataKeys(ctrl))
+
+			required, err := s.Required(context.Background(), 1)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, required)
+		})
+	}
+}
+
+func TestService_Enroll(t *testing.T) {
+	tests := []struct {
+		name    string
+		saveErr error
+		wantErr error
+	}{
+		{name: "success"},
+		{name: "already enrolled", saveErr: mfa.ErrAlreadyEnrolled, wantErr: mfa.ErrAlreadyEnrolled},
+		{name: "storage error", saveErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+			keys := mocks.NewMockMFADataKeys(ctrl)
+			expectUserKey(keys)
+
+			var saved *mfa.TOTP
+			repo.EXPECT().SaveTOTP(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tp *mfa.TOTP) error {
+				saved = tp
+				return tt.saveErr
+			})
+
+			cfg := &config.Config{}
+			cfg.Security.MFA.Issuer = "GophKeeper"
+			s := mfa.NewService(repo, cfg, keys)
+
+			enrollment, err := s.Enroll(context.Background(), 1, "john")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/GophKeeper:john?"))
+
+			// Секрет хранится только зашифрованным ключом данных пользователя
+			require.Equal(t, 1, saved.UserID)
+			assert.False(t, saved.IsConfirmed())
+			assert.NotContains(t, saved.Secret, enrollment.Secret)
+
+			stored, err := encryptor.DecryptToBuffer([]byte(saved.Secret), testKey)
+			require.NoError(t, err)
+			assert.Equal(t, enrollment.Secret, totp.EncodeSecret(stored.Bytes()))
+		})
+	}
+}
+
+func TestService_Confirm(t *testing.T) {
+	secret := newSecret(t)
+	now := totp.Step(time.Now())
+
+	tests := []struct {
+		name       string
+		totp       *mfa.TOTP
+		getErr     error
+		code       string
+		confirmErr error
+		confirm    bool
+		wantErr    error
+	}{
+		{name: "not enrolled", getErr: mfa.ErrNotEnrolled, code: "123456", wantErr: mfa.ErrNotEnrolled},
+		{
+			name:    "already confirmed",
+			totp:    sealedTOTP(t, secret, 0, true),
+			code:    totp.Code(secret, now),
+			wantErr: mfa.ErrAlreadyEnrolled,
+		},
+		{
+			name:    "wrong code",
+			totp:    sealedTOTP(t, secret, 0, false),
+			code:    totp.Code(secret, now-100),
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name:    "success",
+			totp:    sealedTOTP(t, secret, 0, false),
+			code:    totp.Code(secret, now),
+			confirm: true,
+		},
+		{
+			name:       "confirmed concurrently",
+			totp:       sealedTOTP(t, secret, 0, false),
+			code:       totp.Code(secret, now),
+			confirm:    true,
+			confirmErr: mfa.ErrAlreadyEnrolled,
+			wantErr:    mfa.ErrAlreadyEnrolled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+			keys := mocks.NewMockMFADataKeys(ctrl)
+			expectUserKey(keys)
+
+			repo.EXPECT().GetTOTP(gomock.Any(), 1).Return(tt.totp, tt.getErr)
+
+			var hashes []string
+			if tt.confirm {
+				repo.EXPECT().Confirm(gomock.Any(), 1, now, gomock.Any(), gomock.Len(10)).
+					DoAndReturn(func(_ context.Context, _ int, _ int64, _ time.Time, h []string) error {
+						hashes = h
+						return tt.confirmErr
+					})
+			}
+
+			s := mfa.NewService(repo, &config.Config{}, keys)
+
+			codes, err := s.Confirm(context.Background(), 1, tt.code)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, codes, 10)
+
+			// Коды восстановления хранятся только хэшами
+			for _, code := range codes {
+				assert.NotContains(t, hashes, code)
+			}
+		})
+	}
+}
+
+func TestService_Verify(t *testing.T) {
+	secret := newSecret(t)
+	now := totp.Step(time.Now())
+
+	tests := []struct {
+		name    string
+		totp    *mfa.TOTP
+		getErr  error
+		code    string
+		setup   func(repo *mocks.MockMFARepository)
+		wantErr error
+	}{
+		{name: "not enrolled", getErr: mfa.ErrNotEnrolled, code: "123456", wantErr: mfa.ErrNotEnrolled},
+		{name: "not confirmed", totp: sealedTOTP(t, secret, 0, false), code: "123456", wantErr: mfa.ErrNotEnrolled},
+		{
+			name: "valid code",
+			totp: sealedTOTP(t, secret, now-1, true),
+			code: totp.Code(secret, now),
+			setup: func(repo *mocks.MockMFARepository) {
+				repo.EXPECT().UseStep(gomock.Any(), 1, now-1, now).Return(nil)
+			},
+		},
+		{
+			// Код шага, который уже принят, повторно не принимается
+			name:    "code reused",
+			totp:    sealedTOTP(t, secret, now, true),
+			code:    totp.Code(secret, now),
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "code accepted concurrently",
+			totp: sealedTOTP(t, secret, now-1, true),
+			code: totp.Code(secret, now),
+			setup: func(repo *mocks.MockMFARepository) {
+				repo.EXPECT().UseStep(gomock.Any(), 1, now-1, now).Return(mfa.ErrInvalidCode)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{name: "wrong totp code", totp: sealedTOTP(t, secret, 0, true), code: totp.Code(secret, now-100), wantErr: mfa.ErrInvalidCode},
+		{
+			name: "recovery code",
+			totp: sealedTOTP(t, secret, 0, true),
+			code: "ABCD-EFGH-IJKL-MNOP",
+			setup: func(repo *mocks.MockMFARepository) {
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), 1, gomock.Not("ABCD-EFGH-IJKL-MNOP"), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "recovery code reused",
+			totp: sealedTOTP(t, secret, 0, true),
+			code: "ABCD-EFGH-IJKL-MNOP",
+			setup: func(repo *mocks.MockMFARepository) {
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(mfa.ErrInvalidCode)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "storage error",
+			totp: sealedTOTP(t, secret, 0, true),
+			code: "ABCD-EFGH-IJKL-MNOP",
+			setup: func(repo *mocks.MockMFARepository) {
+				repo.EXPECT().UseRecoveryCode(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(errStorage)
+			},
+			wantErr: errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+			keys := mocks.NewMockMFADataKeys(ctrl)
+			expectUserKey(keys)
+
+			repo.EXPECT().GetTOTP(gomock.Any(), 1).Return(tt.totp, tt.getErr)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			s := mfa.NewService(repo, &config.Config{}, keys)
+
+			err := s.Verify(context.Background(), 1, tt.code)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_VerifyRecoveryCodeFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockMFARepository(ctrl)
+	tp := sealedTOTP(t, newSecret(t), 0, true)
+
+	var hashes []string
+	repo.EXPECT().GetTOTP(gomock.Any(), 1).Return(tp, nil).Times(2)
+	repo.EXPECT().UseRecoveryCode(gomock.Any(), 1, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, hash string, _ time.Time) error {
+			hashes = append(hashes, hash)
+			return nil
+		}).Times(2)
+
+	s := mfa.NewService(repo, &config.Config{}, mocks.NewMockMFADataKeys(ctrl))
+
+	// Код восстановления принимается без дефисов и в нижнем регистре
+	require.NoError(t, s.Verify(context.Background(), 1, "ABCD-EFGH-IJKL-MNOP"))
+	require.NoError(t, s.Verify(context.Background(), 1, "abcdefghijklmnop"))
+	require.Len(t, hashes, 2)
+	assert.Equal(t, hashes[0], hashes[1])
+}
+
+func TestService_UseChallenge(t *testing.T) {
+	const ttl = 5 * time.Minute
+
+	tests := []struct {
+		name        string
+		challengeID string
+		repoErr     error
+		callsRepo   bool
+		wantErr     error
+	}{
+		{name: "first use", challengeID: "challenge", callsRepo: true},
+		// Повтор того же токена отклоняется даже с другим верным кодом
+		{name: "replay", challengeID: "challenge", callsRepo: true, repoErr: mfa.ErrChallengeUsed, wantErr: mfa.ErrChallengeUsed},
+		// Токен без идентификатора нельзя погасить, поэтому он не принимается
+		{name: "no challenge id", wantErr: mfa.ErrChallengeUsed},
+		{name: "storage error", challengeID: "challenge", callsRepo: true, repoErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+
+			if tt.callsRepo {
+				repo.EXPECT().UseChallenge(gomock.Any(), tt.challengeID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, expiresAt time.Time) error {
+						// Запись хранится, пока токен мог бы действовать
+						assert.WithinDuration(t, time.Now().Add(ttl), expiresAt, time.Minute)
+						return tt.repoErr
+					})
+			}
+
+			cfg := &config.Config{}
+			cfg.Security.MFA.ChallengeTTL = ttl
+			s := mfa.NewService(repo, cfg, mocks.NewMockMFADataKeys(ctrl))
+
+			err := s.UseChallenge(context.Background(), tt.challengeID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Disable(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockMFARepository(ctrl)
+			repo.EXPECT().Delete(gomock.Any(), 1).Return(tt.repoErr)
+
+			s := mfa.NewService(repo, &config.Config{}, mocks.NewMockMFADataKeys(ctrl))
+
+			err := s.Disable(context.Background(), 1)
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
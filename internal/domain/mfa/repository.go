@@ -0,0 +1,29 @@
+package mfa
+
+//go:generate mockgen -destination=../../mocks/mfa.go -package=mocks -mock_names=Repository=MockMFARepository,DataKeys=MockMFADataKeys . Repository,DataKeys
+
+import (
+	"context"
+	"time"
+)
+
+// Repository интерфейс репозитория второго фактора.
+type Repository interface {
+	// GetTOTP получение аутентификатора пользователя, ErrNotEnrolled если его нет.
+	GetTOTP(ctx context.Context, userID int) (*TOTP, error)
+	// SaveTOTP сохранение нового неподтвержденного аутентификатора взамен прежнего неподтвержденного.
+	// Если у пользователя уже есть подтвержденный, возвращается ErrAlreadyEnrolled.
+	SaveTOTP(ctx context.Context, t *TOTP) error
+	// Confirm подтверждение аутентификатора и замена кодов восстановления на новые.
+	Confirm(ctx context.Context, userID int, step int64, at time.Time, recoveryHashes []string) error
+	// UseStep запись шага принятого кода, только если последний шаг все еще lastStep.
+	// Иначе возвращается ErrInvalidCode: код уже принят параллельным запросом.
+	UseStep(ctx context.Context, userID int, lastStep, step int64) error
+	// UseRecoveryCode погашение неиспользованного кода восстановления, ErrInvalidCode если такого нет.
+	UseRecoveryCode(ctx context.Context, userID int, hash string, at time.Time) error
+	// UseChallenge запись погашенного токена второго шага входа до expiresAt, ErrChallengeUsed
+	// если он уже записан. Записи с истекшим сроком удаляются.
+	UseChallenge(ctx context.Context, challengeID string, expiresAt time.Time) error
+	// Delete удаление аутентификатора и кодов восстановления пользователя.
+	Delete(ctx context.Context, userID int) error
+}
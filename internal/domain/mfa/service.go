@@ -0,0 +1,243 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/Melikhov-p/goph-keeper/internal/totp"
+)
+
+var (
+	// ErrNotEnrolled у пользователя нет аутентификатора или он не подтвержден.
+	ErrNotEnrolled = errors.New("totp is not enrolled")
+	// ErrAlreadyEnrolled аутентификатор уже подключен и подтвержден.
+	ErrAlreadyEnrolled = errors.New("totp is already enrolled")
+	// ErrInvalidCode неверный, просроченный или уже использованный код.
+	ErrInvalidCode = errors.New("invalid mfa code")
+	// ErrChallengeUsed токен второго шага входа уже использован для входа.
+	ErrChallengeUsed = errors.New("mfa challenge already used")
+)
+
+// Enrollment данные для подключения аутентификатора.
+type Enrollment struct {
+	// URI ссылка otpauth:// для QR-кода.
+	URI string
+	// Secret секрет в base32 для ручного ввода.
+	Secret string
+}
+
+// DataKeys ключи данных пользователей, которыми шифруются секреты аутентификаторов.
+type DataKeys interface {
+	UserKey(ctx context.Context, userID int) (*securemem.Buffer, error)
+}
+
+// Service сервисный слой второго фактора.
+type Service struct {
+	repo Repository
+	cfg  *config.Config
+	keys DataKeys
+}
+
+// NewService получение сервиса второго фактора.
+func NewService(r Repository, cfg *config.Config, keys DataKeys) *Service {
+	return &Service{
+		repo: r,
+		cfg:  cfg,
+		keys: keys,
+	}
+}
+
+// Required требуется ли второй фактор для входа пользователя: подключен аутентификатор или этого требует политика.
+func (s *Service) Required(ctx context.Context, userID int) (bool, error) {
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return enabled || s.cfg.Security.MFA.Required, nil
+}
+
+// IsEnabled подключен ли у пользователя подтвержденный аутентификатор.
+func (s *Service) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	t, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotEnrolled) {
+			return false, nil
+		}
+		return false, fmt.Errorf("domain.mfa.Service.IsEnabled: %w", err)
+	}
+
+	return t.IsConfirmed(), nil
+}
+
+// Disable отключение второго фактора пользователя вместе с кодами восстановления, например,
+// при перехвате аккаунта доверенным контактом: прежний аутентификатор остается у владельца.
+func (s *Service) Disable(ctx context.Context, userID int) error {
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("domain.mfa.Service.Disable: %w", err)
+	}
+
+	return nil
+}
+
+// Enroll генерация секрета нового аутентификатора. account отображается в приложении-аутентификаторе.
+func (s *Service) Enroll(ctx context.Context, userID int, account string) (*Enrollment, error) {
+	op := "domain.mfa.Service.Enroll"
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer securemem.Wipe(secret)
+
+	key, err := s.keys.UserKey(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user data key %w", op, err)
+	}
+	defer key.Wipe()
+
+	sealed, err := encryptor.EncryptWithMasterKey(secret, key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encrypt totp secret %w", op, err)
+	}
+
+	err = s.repo.SaveTOTP(ctx, &TOTP{
+		UserID:    userID,
+		Secret:    sealed,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, ErrAlreadyEnrolled) {
+			return nil, ErrAlreadyEnrolled
+		}
+		return nil, fmt.Errorf("%s: failed to save totp %w", op, err)
+	}
+
+	return &Enrollment{
+		URI:    totp.URI(s.cfg.Security.MFA.Issuer, account, secret),
+		Secret: totp.EncodeSecret(secret),
+	}, nil
+}
+
+// Confirm подтверждение аутентификатора первым кодом. Возвращает коды восстановления,
+// которые больше нигде не хранятся в открытом виде.
+func (s *Service) Confirm(ctx context.Context, userID int, code string) ([]string, error) {
+	op := "domain.mfa.Service.Confirm"
+
+	t, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotEnrolled) {
+			return nil, ErrNotEnrolled
+		}
+		return nil, fmt.Errorf("%s: failed to get totp %w", op, err)
+	}
+	if t.IsConfirmed() {
+		return nil, ErrAlreadyEnrolled
+	}
+
+	step, err := s.checkCode(ctx, t, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.Confirm(ctx, userID, step, time.Now(), hashes); err != nil {
+		if errors.Is(err, ErrAlreadyEnrolled) {
+			return nil, ErrAlreadyEnrolled
+		}
+		return nil, fmt.Errorf("%s: failed to confirm totp %w", op, err)
+	}
+
+	return codes, nil
+}
+
+// Verify проверка второго фактора при входе: кода аутентификатора или одноразового кода восстановления.
+func (s *Service) Verify(ctx context.Context, userID int, code string) error {
+	op := "domain.mfa.Service.Verify"
+
+	t, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotEnrolled) {
+			return ErrNotEnrolled
+		}
+		return fmt.Errorf("%s: failed to get totp %w", op, err)
+	}
+	if !t.IsConfirmed() {
+		return ErrNotEnrolled
+	}
+
+	if isRecoveryCode(code) {
+		if err = s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), time.Now()); err != nil {
+			if errors.Is(err, ErrInvalidCode) {
+				return ErrInvalidCode
+			}
+			return fmt.Errorf("%s: failed to use recovery code %w", op, err)
+		}
+		return nil
+	}
+
+	step, err := s.checkCode(ctx, t, code)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.UseStep(ctx, userID, t.LastStep, step); err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			return ErrInvalidCode
+		}
+		return fmt.Errorf("%s: failed to save totp step %w", op, err)
+	}
+
+	return nil
+}
+
+// UseChallenge погашение токена второго шага входа challengeID после успешной проверки кода,
+// чтобы тот же токен нельзя было предъявить повторно до истечения его срока.
+// ErrChallengeUsed, если токен уже погашен.
+func (s *Service) UseChallenge(ctx context.Context, challengeID string) error {
+	if challengeID == "" {
+		return ErrChallengeUsed
+	}
+
+	// Токен действует не дольше ChallengeTTL с момента выпуска, после этого запись не нужна
+	expiresAt := time.Now().Add(s.cfg.Security.MFA.ChallengeTTL)
+	if err := s.repo.UseChallenge(ctx, challengeID, expiresAt); err != nil {
+		if errors.Is(err, ErrChallengeUsed) {
+			return ErrChallengeUsed
+		}
+		return fmt.Errorf("domain.mfa.Service.UseChallenge: %w", err)
+	}
+
+	return nil
+}
+
+// checkCode проверка кода аутентификатора, возвращает шаг принятого кода.
+func (s *Service) checkCode(ctx context.Context, t *TOTP, code string) (int64, error) {
+	key, err := s.keys.UserKey(ctx, t.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user data key %w", err)
+	}
+	defer key.Wipe()
+
+	secret, err := encryptor.DecryptToBuffer([]byte(t.Secret), key.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt totp secret %w", err)
+	}
+	defer secret.Wipe()
+
+	step, ok := totp.Validate(secret.Bytes(), code, time.Now(), t.LastStep)
+	if !ok {
+		return 0, ErrInvalidCode
+	}
+
+	return step, nil
+}
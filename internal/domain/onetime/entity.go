@@ -0,0 +1,140 @@
+// Package onetime пакет уровня домена одноразовых ссылок на секреты.
+package onetime
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+)
+
+const (
+	shareIDLen  = 16
+	shareKeyLen = 32
+)
+
+var (
+	// ErrNotFound ссылка не найдена, истекла, исчерпана или ключ не подходит. Причина не раскрывается,
+	// чтобы по ответу нельзя было понять, существовала ли ссылка.
+	ErrNotFound = errors.New("one-time share not found")
+	// ErrEmptyContent содержимое ссылки пустое.
+	ErrEmptyContent = errors.New("one-time share content is empty")
+	// ErrTooLarge содержимое ссылки больше допустимого.
+	ErrTooLarge = errors.New("one-time share content is too large")
+	// ErrInvalidViews недопустимое число просмотров.
+	ErrInvalidViews = errors.New("invalid number of views")
+	// ErrInvalidTTL недопустимый срок действия ссылки.
+	ErrInvalidTTL = errors.New("invalid one-time share lifetime")
+)
+
+// PagePath путь страницы просмотра ссылки на HTTP сервере, за ним следует ID ссылки.
+const PagePath = "/s/"
+
+// keyEncoding кодировка ID и ключа ссылки: оба попадают в URL.
+var keyEncoding = base64.RawURLEncoding
+
+// Share одноразовая ссылка. Содержимое хранится зашифрованным случайным ключом, который получает только
+// создатель ссылки (обычно он передается во фрагменте URL); сервер ключ не хранит.
+type Share struct {
+	ID         string
+	OwnerID    int
+	Ciphertext []byte
+	MaxViews   int
+	ViewsLeft  int
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
+
+// NewShare новая ссылка на уже зашифрованное содержимое ciphertext.
+func NewShare(ownerID int, ciphertext []byte, maxViews int, ttl time.Duration) (*Share, error) {
+	op := "domain.onetime.NewShare"
+
+	id := make([]byte, shareIDLen)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("%s: failed to generate share id %w", op, err)
+	}
+
+	now := time.Now()
+
+	return &Share{
+		ID:         keyEncoding.EncodeToString(id),
+		OwnerID:    ownerID,
+		Ciphertext: ciphertext,
+		MaxViews:   maxViews,
+		ViewsLeft:  maxViews,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}, nil
+}
+
+// Seal шифрование содержимого ссылки новым случайным ключом.
+// Возвращает шифротекст и ключ, который нужно передать получателю.
+func Seal(content []byte) ([]byte, []byte, error) {
+	op := "domain.onetime.Seal"
+
+	key := make([]byte, shareKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to generate share key %w", op, err)
+	}
+
+	enc, err := encryptor.EncryptWithMasterKey(content, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to encrypt content %w", op, err)
+	}
+
+	return []byte(enc), key, nil
+}
+
+// Open расшифровка содержимого ссылки ключом получателя, ErrNotFound если ключ не подходит.
+func (sh *Share) Open(key []byte) ([]byte, error) {
+	if len(key) != shareKeyLen {
+		return nil, ErrNotFound
+	}
+
+	content, err := encryptor.DecryptWithMasterKey(sh.Ciphertext, key)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	return []byte(content), nil
+}
+
+// IsActive можно ли еще открыть ссылку.
+func (sh *Share) IsActive(now time.Time) bool {
+	return sh.ViewsLeft > 0 && now.Before(sh.ExpiresAt)
+}
+
+// EncodeKey представление ключа ссылки для URL.
+func EncodeKey(key []byte) string {
+	return keyEncoding.EncodeToString(key)
+}
+
+// PageURL ссылка на страницу просмотра. Ключ передается во фрагменте URL: браузер не отправляет фрагмент
+// на сервер, и ключ не попадает в журналы прокси и сервера. Без ключа возвращается ссылка без фрагмента,
+// пустой baseURL дает пустую ссылку.
+func PageURL(baseURL, id string, key []byte) string {
+	if baseURL == "" {
+		return ""
+	}
+
+	u := strings.TrimRight(baseURL, "/") + PagePath + id
+	if len(key) != 0 {
+		u += "#" + EncodeKey(key)
+	}
+
+	return u
+}
+
+// DecodeKey ключ ссылки из URL, ErrNotFound если он искажен.
+func DecodeKey(encoded string) ([]byte, error) {
+	key, err := keyEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	return key, nil
+}
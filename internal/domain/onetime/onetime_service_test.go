@@ -0,0 +1,229 @@
+package onetime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var errStorage = errors.New("storage error")
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Security.OneTimeShare = config.OneTimeShareConfig{
+		DefaultTTL: time.Hour,
+		MaxTTL:     24 * time.Hour,
+		MaxViews:   5,
+		MaxSize:    64,
+	}
+
+	return cfg
+}
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   []byte
+		views     int
+		ttl       time.Duration
+		saves     bool
+		saveErr   error
+		wantViews int
+		wantTTL   time.Duration
+		wantErr   error
+	}{
+		{name: "defaults", content: []byte("hunter2"), saves: true, wantViews: 1, wantTTL: time.Hour},
+		{name: "custom limits", content: []byte("hunter2"), views: 5, ttl: 24 * time.Hour, saves: true, wantViews: 5, wantTTL: 24 * time.Hour},
+		{name: "empty", content: nil, wantErr: onetime.ErrEmptyContent},
+		{name: "too large", content: make([]byte, 65), wantErr: onetime.ErrTooLarge},
+		{name: "too many views", content: []byte("x"), views: 6, wantErr: onetime.ErrInvalidViews},
+		{name: "negative views", content: []byte("x"), views: -1, wantErr: onetime.ErrInvalidViews},
+		{name: "ttl too long", content: []byte("x"), ttl: 25 * time.Hour, wantErr: onetime.ErrInvalidTTL},
+		{name: "negative ttl", content: []byte("x"), ttl: -time.Second, wantErr: onetime.ErrInvalidTTL},
+		{name: "storage error", content: []byte("x"), saves: true, saveErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOneTimeRepository(ctrl)
+
+			var saved *onetime.Share
+			if tt.saves {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, sh *onetime.Share) error {
+					saved = sh
+					return tt.saveErr
+				})
+			}
+
+			s := onetime.NewService(repo, testConfig())
+
+			sh, key, err := s.Create(context.Background(), 1, tt.content, tt.views, tt.ttl)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, saved, sh)
+			assert.Equal(t, 1, sh.OwnerID)
+			assert.Equal(t, tt.wantViews, sh.MaxViews)
+			assert.Equal(t, tt.wantViews, sh.ViewsLeft)
+			assert.WithinDuration(t, time.Now().Add(tt.wantTTL), sh.ExpiresAt, time.Minute)
+
+			// Сервер хранит только шифротекст, ключ получает создатель ссылки
+			assert.NotContains(t, string(sh.Ciphertext), string(tt.content))
+			content, err := sh.Open(key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, content)
+		})
+	}
+}
+
+func TestService_CreateSealed(t *testing.T) {
+	ciphertext, _, err := onetime.Seal([]byte("client side"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		saves      bool
+		wantErr    error
+	}{
+		{name: "success", ciphertext: ciphertext, saves: true},
+		{name: "empty", wantErr: onetime.ErrEmptyContent},
+		{name: "too large", ciphertext: make([]byte, 1024), wantErr: onetime.ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOneTimeRepository(ctrl)
+			if tt.saves {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			s := onetime.NewService(repo, testConfig())
+
+			sh, err := s.CreateSealed(context.Background(), 1, tt.ciphertext, 0, 0)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, sh.MaxViews)
+			assert.Equal(t, tt.ciphertext, sh.Ciphertext)
+		})
+	}
+}
+
+func TestService_Redeem(t *testing.T) {
+	ciphertext, key, err := onetime.Seal([]byte("hunter2"))
+	require.NoError(t, err)
+	sh, err := onetime.NewShare(1, ciphertext, 2, time.Hour)
+	require.NoError(t, err)
+
+	wrong := append([]byte(nil), key...)
+	wrong[0] ^= 0xff
+
+	tests := []struct {
+		name       string
+		key        []byte
+		getErr     error
+		consumes   bool
+		left       int
+		consumeErr error
+		wantErr    error
+	}{
+		{name: "success", key: key, consumes: true, left: 1},
+		{name: "last view", key: key, consumes: true, left: 0},
+		// Неверный ключ не раскрывает содержимое и не тратит просмотр
+		{name: "wrong key", key: wrong, wantErr: onetime.ErrNotFound},
+		{name: "short key", key: key[:8], wantErr: onetime.ErrNotFound},
+		{name: "expired or used", key: key, getErr: onetime.ErrNotFound, wantErr: onetime.ErrNotFound},
+		// Последний просмотр списал параллельный запрос
+		{name: "consumed concurrently", key: key, consumes: true, consumeErr: onetime.ErrNotFound, wantErr: onetime.ErrNotFound},
+		{name: "storage error", key: key, getErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOneTimeRepository(ctrl)
+
+			if tt.getErr != nil {
+				repo.EXPECT().GetActive(gomock.Any(), sh.ID, gomock.Any()).Return(nil, tt.getErr)
+			} else {
+				repo.EXPECT().GetActive(gomock.Any(), sh.ID, gomock.Any()).Return(sh, nil)
+			}
+			if tt.consumes {
+				repo.EXPECT().Consume(gomock.Any(), sh.ID, gomock.Any()).Return(tt.left, tt.consumeErr)
+			}
+
+			s := onetime.NewService(repo, testConfig())
+
+			content, left, err := s.Redeem(context.Background(), sh.ID, tt.key)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, content)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "hunter2", string(content))
+			assert.Equal(t, tt.left, left)
+		})
+	}
+}
+
+func TestService_PurgeExpired(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted int
+		repoErr error
+	}{
+		{name: "success", deleted: 3},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOneTimeRepository(ctrl)
+
+			now := time.Now()
+			repo.EXPECT().DeleteExpired(gomock.Any(), now).Return(tt.deleted, tt.repoErr)
+
+			n, err := onetime.NewService(repo, testConfig()).PurgeExpired(context.Background(), now)
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.deleted, n)
+		})
+	}
+}
+
+func TestKeyEncoding(t *testing.T) {
+	_, key, err := onetime.Seal([]byte("client side"))
+	require.NoError(t, err)
+
+	decoded, err := onetime.DecodeKey(onetime.EncodeKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, decoded)
+}
+
+func TestPageURL(t *testing.T) {
+	key := []byte{0xfb, 0xff}
+
+	assert.Equal(t, "https://keeper.example.com/s/abc#-_8", onetime.PageURL("https://keeper.example.com/", "abc", key))
+	assert.Equal(t, "https://keeper.example.com/s/abc", onetime.PageURL("https://keeper.example.com", "abc", nil))
+	assert.Empty(t, onetime.PageURL("", "abc", key))
+}
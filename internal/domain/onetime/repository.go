@@ -0,0 +1,22 @@
+package onetime
+
+//go:generate mockgen -destination=../../mocks/onetime.go -package=mocks -mock_names=Repository=MockOneTimeRepository . Repository
+
+import (
+	"context"
+	"time"
+)
+
+// Repository интерфейс репозитория одноразовых ссылок.
+type Repository interface {
+	// Create сохранение новой ссылки.
+	Create(ctx context.Context, sh *Share) error
+	// GetActive ссылка, которую еще можно открыть на момент now, иначе ErrNotFound.
+	GetActive(ctx context.Context, id string, now time.Time) (*Share, error)
+	// Consume атомарное списание просмотра. Ссылка без оставшихся просмотров удаляется вместе с содержимым.
+	// Возвращает число оставшихся просмотров или ErrNotFound, если просмотры уже списаны
+	// (например, параллельным запросом) или ссылка истекла.
+	Consume(ctx context.Context, id string, now time.Time) (int, error)
+	// DeleteExpired удаление истекших ссылок, возвращает их число.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,169 @@
+package onetime
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+)
+
+// sealedOverhead запас на обернутый ключ данных, nonce и теги в шифротексте Seal.
+const sealedOverhead = 256
+
+// sealedSize наибольший размер шифротекста Seal для содержимого размером size: шифротекст в base64.
+func sealedSize(size int) int {
+	return base64.StdEncoding.EncodedLen(size) + sealedOverhead
+}
+
+// Service структура сервиса одноразовых ссылок.
+type Service struct {
+	repo Repository
+	cfg  *config.Config
+}
+
+// NewService получение сервиса одноразовых ссылок.
+func NewService(r Repository, c *config.Config) *Service {
+	return &Service{
+		repo: r,
+		cfg:  c,
+	}
+}
+
+// Create ссылка на содержимое content, которое сервер шифрует новым случайным ключом. Ключ возвращается
+// только создателю и не сохраняется. Нулевые maxViews и ttl заменяются значениями по умолчанию:
+// один просмотр и security.one_time_share.default_ttl.
+func (s *Service) Create(
+	ctx context.Context,
+	ownerID int,
+	content []byte,
+	maxViews int,
+	ttl time.Duration,
+) (*Share, []byte, error) {
+	op := "domain.onetime.Service.Create"
+
+	switch {
+	case len(content) == 0:
+		return nil, nil, ErrEmptyContent
+	case len(content) > s.cfg.Security.OneTimeShare.MaxSize:
+		return nil, nil, ErrTooLarge
+	}
+
+	ciphertext, key, err := Seal(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sh, err := s.create(ctx, ownerID, ciphertext, maxViews, ttl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sh, key, nil
+}
+
+// CreateSealed ссылка на содержимое, которое клиент зашифровал сам своим ключом в формате Seal,
+// например, для E2E аккаунта, чей секрет сервер прочитать не может.
+func (s *Service) CreateSealed(
+	ctx context.Context,
+	ownerID int,
+	ciphertext []byte,
+	maxViews int,
+	ttl time.Duration,
+) (*Share, error) {
+	op := "domain.onetime.Service.CreateSealed"
+
+	switch {
+	case len(ciphertext) == 0:
+		return nil, ErrEmptyContent
+	case len(ciphertext) > sealedSize(s.cfg.Security.OneTimeShare.MaxSize):
+		return nil, ErrTooLarge
+	}
+
+	sh, err := s.create(ctx, ownerID, ciphertext, maxViews, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sh, nil
+}
+
+// Redeem открытие ссылки: расшифровка содержимого ключом получателя и списание просмотра.
+// После последнего просмотра ссылка удаляется. Попытка с неверным ключом просмотр не списывает.
+// Возвращает содержимое и число оставшихся просмотров.
+func (s *Service) Redeem(ctx context.Context, id string, key []byte) ([]byte, int, error) {
+	op := "domain.onetime.Service.Redeem"
+
+	now := time.Now()
+
+	sh, err := s.repo.GetActive(ctx, id, now)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("%s: failed to get share %w", op, err)
+	}
+
+	content, err := sh.Open(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	left, err := s.repo.Consume(ctx, id, now)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("%s: failed to consume view %w", op, err)
+	}
+
+	return content, left, nil
+}
+
+// PurgeExpired удаление истекших ссылок вместе с содержимым.
+func (s *Service) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	n, err := s.repo.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("domain.onetime.Service.PurgeExpired: %w", err)
+	}
+
+	return n, nil
+}
+
+// create проверка ограничений и сохранение ссылки на зашифрованное содержимое.
+func (s *Service) create(
+	ctx context.Context,
+	ownerID int,
+	ciphertext []byte,
+	maxViews int,
+	ttl time.Duration,
+) (*Share, error) {
+	cfg := s.cfg.Security.OneTimeShare
+
+	if maxViews == 0 {
+		maxViews = 1
+	}
+	if maxViews < 0 || maxViews > cfg.MaxViews {
+		return nil, ErrInvalidViews
+	}
+
+	if ttl == 0 {
+		ttl = cfg.DefaultTTL
+	}
+	if ttl < 0 || ttl > cfg.MaxTTL {
+		return nil, ErrInvalidTTL
+	}
+
+	sh, err := NewShare(ownerID, ciphertext, maxViews, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.Create(ctx, sh); err != nil {
+		return nil, fmt.Errorf("failed to save share %w", err)
+	}
+
+	return sh, nil
+}
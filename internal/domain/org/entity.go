@@ -0,0 +1,160 @@
+// Package org пакет уровня домена организаций и общих хранилищ (коллекций) их участников.
+package org
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+)
+
+// Role роль участника в организации.
+type Role string
+
+const (
+	// RoleOwner владелец: все права, включая управление администраторами и удаление организации.
+	RoleOwner Role = "owner"
+	// RoleAdmin администратор: управление редакторами, читателями и коллекциями.
+	RoleAdmin Role = "admin"
+	// RoleEditor редактор: чтение и создание секретов в коллекциях.
+	RoleEditor Role = "editor"
+	// RoleViewer читатель: только чтение секретов коллекций.
+	RoleViewer Role = "viewer"
+)
+
+// roleRank порядок ролей, старшая роль включает права младших.
+var roleRank = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// Valid известна ли роль.
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast роль не младше minRole.
+func (r Role) AtLeast(minRole Role) bool {
+	return r.Valid() && roleRank[r] >= roleRank[minRole]
+}
+
+// CanWrite роль позволяет создавать секреты в коллекциях.
+func (r Role) CanWrite() bool {
+	return r.AtLeast(RoleEditor)
+}
+
+// CanManage роль позволяет управлять участниками и коллекциями.
+func (r Role) CanManage() bool {
+	return r.AtLeast(RoleAdmin)
+}
+
+// CanManageRole участник с ролью r может выдать или отозвать роль target.
+// Администраторы управляют только редакторами и читателями, роли admin и owner выдает владелец.
+func (r Role) CanManageRole(target Role) bool {
+	if !r.CanManage() || !target.Valid() {
+		return false
+	}
+
+	return r == RoleOwner || !target.CanManage()
+}
+
+var (
+	// ErrNotFound организации нет или пользователь не ее участник.
+	ErrNotFound = errors.New("organization not found")
+	// ErrForbidden роль не позволяет выполнить операцию.
+	ErrForbidden = errors.New("role does not allow this operation")
+	// ErrInvalidRole неизвестная роль.
+	ErrInvalidRole = errors.New("invalid role")
+	// ErrEmptyName пустое название организации или коллекции.
+	ErrEmptyName = errors.New("name is empty")
+	// ErrNotMember пользователь не участник организации.
+	ErrNotMember = errors.New("user is not a member of the organization")
+	// ErrAlreadyMember пользователь уже участник организации.
+	ErrAlreadyMember = errors.New("user is already a member of the organization")
+	// ErrLastOwner у организации должен остаться хотя бы один владелец.
+	ErrLastOwner = errors.New("organization must keep at least one owner")
+	// ErrCollectionExists коллекция с таким названием в организации уже есть.
+	ErrCollectionExists = errors.New("collection already exists")
+	// ErrConcurrentChange участники или коллекции организации изменились во время операции, ее нужно повторить.
+	ErrConcurrentChange = errors.New("organization changed concurrently")
+	// ErrCollectionNotFound коллекции нет в организации.
+	ErrCollectionNotFound = secret.ErrCollectionNotFound
+)
+
+// Organization организация. Role роль пользователя, для которого прочитан список организаций.
+type Organization struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	Role      Role
+}
+
+// NewOrganization новая организация.
+func NewOrganization(name string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	return &Organization{
+		ID:        -1,
+		Name:      name,
+		CreatedAt: time.Now(),
+		Role:      RoleOwner,
+	}, nil
+}
+
+// Member участник организации. Login заполняется при чтении списка участников.
+type Member struct {
+	OrgID     int
+	UserID    int
+	Login     string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Collection общее хранилище секретов организации. Данные секретов коллекции шифруются ключом коллекции
+// версии KeyVersion, который хранится зашифрованным отдельно для каждого участника.
+type Collection struct {
+	ID         int
+	OrgID      int
+	Name       string
+	KeyVersion int
+	CreatedAt  time.Time
+}
+
+// CollectionKey ключ коллекции версии Version, зашифрованный ключом участника UserID.
+type CollectionKey struct {
+	CollectionID int
+	Version      int
+	UserID       int
+	WrappedKey   []byte
+}
+
+// Access роль участника в организации коллекции и его копия ключа коллекции текущей версии.
+type Access struct {
+	Collection Collection
+	Role       Role
+	WrappedKey []byte
+}
+
+// KeyRotation новая версия ключа коллекции: копии ключа для оставшихся участников и
+// секреты коллекции, перешифрованные новым ключом.
+type KeyRotation struct {
+	CollectionID int
+	OldVersion   int
+	NewVersion   int
+	Keys         []*CollectionKey
+	Secrets      []*secret.Secret
+}
+
+// Wipe затирание перешифрованных секретов и закрытие их файлов, открытых для перешифровки.
+func (r *KeyRotation) Wipe() {
+	for _, s := range r.Secrets {
+		s.Wipe()
+	}
+}
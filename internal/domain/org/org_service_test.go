@@ -0,0 +1,647 @@
+package org_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/org"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	ownerID = iota + 1
+	adminID
+	editorID
+	viewerID
+	outsiderID
+)
+
+const (
+	orgID        = 10
+	collectionID = 20
+)
+
+var errStorage = errors.New("storage error")
+
+// userKey ключ данных пользователя в тестах, у каждого пользователя свой.
+func userKey(userID int) []byte {
+	return bytes.Repeat([]byte{byte(userID)}, 32)
+}
+
+// newDataKeys ключи данных пользователей, каждый раз в новом буфере: сервис затирает их после использования.
+func newDataKeys(ctrl *gomock.Controller) *mocks.MockOrgDataKeys {
+	keys := mocks.NewMockOrgDataKeys(ctrl)
+	keys.EXPECT().UserKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID int) (*securemem.Buffer, error) {
+			return securemem.New(userKey(userID)), nil
+		}).AnyTimes()
+
+	return keys
+}
+
+// wrapFor копия ключа коллекции участника userID.
+func wrapFor(t *testing.T, key []byte, userID int) []byte {
+	t.Helper()
+
+	kek, err := encryptor.MemberKey(userKey(userID), userID)
+	require.NoError(t, err)
+	wrapped, err := encryptor.WrapKey(key, kek)
+	require.NoError(t, err)
+
+	return wrapped
+}
+
+// unwrapFor ключ коллекции из копии участника userID.
+func unwrapFor(t *testing.T, wrapped []byte, userID int) []byte {
+	t.Helper()
+
+	kek, err := encryptor.MemberKey(userKey(userID), userID)
+	require.NoError(t, err)
+	key, err := encryptor.UnwrapKey(wrapped, kek)
+	require.NoError(t, err)
+
+	return key
+}
+
+func newCollectionKey(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := encryptor.NewVaultKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+// teamMembers участники организации, по одному каждой роли.
+func teamMembers(roles map[int]org.Role) []*org.Member {
+	created := time.Now().Add(-time.Hour)
+
+	members := make([]*org.Member, 0, len(roles))
+	for _, id := range []int{ownerID, adminID, editorID, viewerID, outsiderID} {
+		role, ok := roles[id]
+		if !ok {
+			continue
+		}
+		members = append(members, &org.Member{
+			OrgID:     orgID,
+			UserID:    id,
+			Role:      role,
+			CreatedAt: created.Add(time.Duration(id) * time.Minute),
+		})
+	}
+
+	return members
+}
+
+var team = map[int]org.Role{
+	ownerID:  org.RoleOwner,
+	adminID:  org.RoleAdmin,
+	editorID: org.RoleEditor,
+	viewerID: org.RoleViewer,
+}
+
+// expectMember ответ хранилища на запрос участника userID, не участник если его нет в team.
+func expectMember(repo *mocks.MockOrgRepository, userID int) {
+	role, ok := team[userID]
+	if !ok {
+		repo.EXPECT().GetMember(gomock.Any(), orgID, userID).Return(nil, org.ErrNotMember)
+		return
+	}
+
+	repo.EXPECT().GetMember(gomock.Any(), orgID, userID).Return(&org.Member{OrgID: orgID, UserID: userID, Role: role}, nil)
+}
+
+// expectAccess копия ключа коллекции версии version участника userID.
+func expectAccess(t *testing.T, repo *mocks.MockOrgRepository, userID int, key []byte, version int) {
+	t.Helper()
+
+	repo.EXPECT().GetAccess(gomock.Any(), collectionID, userID).Return(&org.Access{
+		Collection: org.Collection{ID: collectionID, OrgID: orgID, KeyVersion: version},
+		Role:       team[userID],
+		WrappedKey: wrapFor(t, key, userID),
+	}, nil)
+}
+
+func TestRole_CanManageRole(t *testing.T) {
+	tests := []struct {
+		role   org.Role
+		target org.Role
+		want   bool
+	}{
+		{role: org.RoleOwner, target: org.RoleOwner, want: true},
+		{role: org.RoleOwner, target: org.RoleAdmin, want: true},
+		{role: org.RoleAdmin, target: org.RoleEditor, want: true},
+		{role: org.RoleAdmin, target: org.RoleViewer, want: true},
+		{role: org.RoleAdmin, target: org.RoleAdmin, want: false},
+		{role: org.RoleAdmin, target: org.RoleOwner, want: false},
+		{role: org.RoleEditor, target: org.RoleViewer, want: false},
+		{role: org.RoleOwner, target: "root", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.target), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.CanManageRole(tt.target))
+		})
+	}
+
+	assert.True(t, org.RoleEditor.CanWrite())
+	assert.False(t, org.RoleViewer.CanWrite())
+	assert.False(t, org.Role("root").CanWrite())
+}
+
+func TestService_CreateOrganization(t *testing.T) {
+	tests := []struct {
+		name    string
+		orgName string
+		saves   bool
+		repoErr error
+		wantErr error
+	}{
+		{name: "success", orgName: " team ", saves: true},
+		{name: "empty name", orgName: "  ", wantErr: org.ErrEmptyName},
+		{name: "storage error", orgName: "team", saves: true, repoErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+			if tt.saves {
+				repo.EXPECT().CreateOrganization(gomock.Any(), gomock.Any(), ownerID).Return(tt.repoErr)
+			}
+
+			s := org.NewService(repo, newDataKeys(ctrl))
+
+			o, err := s.CreateOrganization(context.Background(), ownerID, tt.orgName)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "team", o.Name)
+			assert.Equal(t, org.RoleOwner, o.Role)
+		})
+	}
+}
+
+func TestService_DeleteOrganization(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID int
+		wantErr error
+	}{
+		{name: "owner", actorID: ownerID},
+		{name: "admin", actorID: adminID, wantErr: org.ErrForbidden},
+		// Не участнику организация не видна
+		{name: "outsider", actorID: outsiderID, wantErr: org.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+			expectMember(repo, tt.actorID)
+			if tt.wantErr == nil {
+				repo.EXPECT().DeleteOrganization(gomock.Any(), orgID).Return(nil)
+			}
+
+			err := org.NewService(repo, newDataKeys(ctrl)).DeleteOrganization(context.Background(), tt.actorID, orgID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ListMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID int
+		wantErr error
+	}{
+		{name: "viewer", actorID: viewerID},
+		{name: "outsider", actorID: outsiderID, wantErr: org.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+			expectMember(repo, tt.actorID)
+			if tt.wantErr == nil {
+				repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(team), nil)
+			}
+
+			members, err := org.NewService(repo, newDataKeys(ctrl)).ListMembers(context.Background(), tt.actorID, orgID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, members, len(team))
+		})
+	}
+}
+
+func TestService_AddMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		actorID int
+		role    org.Role
+		addErr  error
+		wantErr error
+	}{
+		{name: "owner adds admin", actorID: ownerID, role: org.RoleAdmin},
+		{name: "admin adds viewer", actorID: adminID, role: org.RoleViewer},
+		{name: "admin cannot grant admin", actorID: adminID, role: org.RoleAdmin, wantErr: org.ErrForbidden},
+		{name: "editor cannot add members", actorID: editorID, role: org.RoleViewer, wantErr: org.ErrForbidden},
+		{name: "organization is hidden from outsiders", actorID: outsiderID, role: org.RoleViewer, wantErr: org.ErrNotFound},
+		{name: "invalid role", actorID: ownerID, role: "root", wantErr: org.ErrInvalidRole},
+		{name: "already member", actorID: ownerID, role: org.RoleViewer, addErr: org.ErrAlreadyMember, wantErr: org.ErrAlreadyMember},
+		{name: "concurrent change", actorID: ownerID, role: org.RoleViewer, addErr: org.ErrConcurrentChange, wantErr: org.ErrConcurrentChange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+			key := newCollectionKey(t)
+
+			if tt.role.Valid() {
+				expectMember(repo, tt.actorID)
+			}
+
+			var added []*org.CollectionKey
+			if tt.wantErr == nil || tt.addErr != nil {
+				repo.EXPECT().ListCollections(gomock.Any(), orgID).
+					Return([]*org.Collection{{ID: collectionID, OrgID: orgID, KeyVersion: 3}}, nil)
+				expectAccess(t, repo, tt.actorID, key, 3)
+				repo.EXPECT().AddMember(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, m *org.Member, keys []*org.CollectionKey) error {
+						assert.Equal(t, outsiderID, m.UserID)
+						assert.Equal(t, tt.role, m.Role)
+						added = keys
+						return tt.addErr
+					})
+			}
+
+			m, err := org.NewService(repo, newDataKeys(ctrl)).AddMember(context.Background(), tt.actorID, orgID, outsiderID, tt.role)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, m.Role)
+
+			// Новый участник сразу получает копию ключа текущей версии каждой коллекции
+			require.Len(t, added, 1)
+			assert.Equal(t, collectionID, added[0].CollectionID)
+			assert.Equal(t, 3, added[0].Version)
+			assert.Equal(t, key, unwrapFor(t, added[0].WrappedKey, outsiderID))
+		})
+	}
+}
+
+func TestService_SetRole(t *testing.T) {
+	coOwners := map[int]org.Role{ownerID: org.RoleOwner, adminID: org.RoleOwner}
+
+	tests := []struct {
+		name    string
+		actorID int
+		userID  int
+		role    org.Role
+		members map[int]org.Role
+		wantErr error
+	}{
+		{name: "admin promotes viewer to editor", actorID: adminID, userID: viewerID, role: org.RoleEditor},
+		{name: "admin cannot grant admin", actorID: adminID, userID: viewerID, role: org.RoleAdmin, wantErr: org.ErrForbidden},
+		{name: "admin cannot demote owner", actorID: adminID, userID: ownerID, role: org.RoleViewer, wantErr: org.ErrForbidden},
+		{name: "last owner", actorID: ownerID, userID: ownerID, role: org.RoleAdmin, members: team, wantErr: org.ErrLastOwner},
+		{name: "one of owners", actorID: ownerID, userID: ownerID, role: org.RoleAdmin, members: coOwners},
+		{name: "not member", actorID: ownerID, userID: outsiderID, role: org.RoleViewer, wantErr: org.ErrNotMember},
+		{name: "invalid role", actorID: ownerID, userID: viewerID, role: "root", wantErr: org.ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+
+			if tt.role.Valid() {
+				expectMember(repo, tt.actorID)
+				expectMember(repo, tt.userID)
+			}
+			if tt.members != nil {
+				repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(tt.members), nil)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().SetRole(gomock.Any(), orgID, tt.userID, tt.role).Return(nil)
+			}
+
+			err := org.NewService(repo, newDataKeys(ctrl)).SetRole(context.Background(), tt.actorID, orgID, tt.userID, tt.role)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   int
+		userID    int
+		removeErr error
+		wantErr   error
+	}{
+		// Любой участник может выйти сам
+		{name: "viewer leaves", actorID: viewerID, userID: viewerID},
+		{name: "admin removes editor", actorID: adminID, userID: editorID},
+		{name: "last owner", actorID: ownerID, userID: ownerID, wantErr: org.ErrLastOwner},
+		{name: "admin cannot remove owner", actorID: adminID, userID: ownerID, wantErr: org.ErrForbidden},
+		{name: "editor cannot remove viewer", actorID: editorID, userID: viewerID, wantErr: org.ErrForbidden},
+		{name: "not member", actorID: ownerID, userID: outsiderID, wantErr: org.ErrNotMember},
+		{name: "outsider", actorID: outsiderID, userID: viewerID, wantErr: org.ErrNotFound},
+		{
+			name:      "concurrent change",
+			actorID:   adminID,
+			userID:    editorID,
+			removeErr: org.ErrConcurrentChange,
+			wantErr:   org.ErrConcurrentChange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+
+			expectMember(repo, tt.actorID)
+			if _, ok := team[tt.actorID]; ok {
+				expectMember(repo, tt.userID)
+			}
+			if tt.userID == ownerID && tt.actorID == ownerID {
+				repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(team), nil)
+			}
+			if tt.wantErr == nil || tt.removeErr != nil {
+				repo.EXPECT().ListCollections(gomock.Any(), orgID).Return(nil, nil)
+				repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(team), nil)
+				repo.EXPECT().RemoveMember(gomock.Any(), orgID, tt.userID, gomock.Len(0)).Return(tt.removeErr)
+			}
+
+			err := org.NewService(repo, newDataKeys(ctrl)).RemoveMember(context.Background(), tt.actorID, orgID, tt.userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RemoveMemberRotatesKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockOrgRepository(ctrl)
+	oldKey := newCollectionKey(t)
+
+	sec, err := secret.NewPasswordSecret(
+		&user.User{ID: editorID}, "db", "root", "pass", "", "", nil, bytes.Clone(oldKey),
+	)
+	require.NoError(t, err)
+	sec.ID, sec.CollectionID, sec.KeyVersion = 1, collectionID, 1
+
+	expectMember(repo, adminID)
+	expectMember(repo, viewerID)
+	repo.EXPECT().ListCollections(gomock.Any(), orgID).
+		Return([]*org.Collection{{ID: collectionID, OrgID: orgID, KeyVersion: 1}}, nil)
+	repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(team), nil)
+	expectAccess(t, repo, adminID, oldKey, 1)
+	repo.EXPECT().GetCollectionSecrets(gomock.Any(), collectionID).Return([]*secret.Secret{sec}, nil)
+
+	repo.EXPECT().RemoveMember(gomock.Any(), orgID, viewerID, gomock.Len(1)).
+		DoAndReturn(func(_ context.Context, _, _ int, rotations []*org.KeyRotation) error {
+			r := rotations[0]
+			assert.Equal(t, collectionID, r.CollectionID)
+			assert.Equal(t, 1, r.OldVersion)
+			assert.Equal(t, 2, r.NewVersion)
+
+			// Копии нового ключа выданы всем, кроме удаленного участника
+			var newKey []byte
+			holders := make([]int, 0, len(r.Keys))
+			for _, k := range r.Keys {
+				holders = append(holders, k.UserID)
+				assert.Equal(t, collectionID, k.CollectionID)
+				assert.Equal(t, 2, k.Version)
+
+				key := unwrapFor(t, k.WrappedKey, k.UserID)
+				if newKey == nil {
+					newKey = key
+				}
+				assert.Equal(t, newKey, key)
+			}
+			assert.ElementsMatch(t, []int{ownerID, adminID, editorID}, holders)
+			assert.NotEqual(t, oldKey, newKey)
+
+			// Секреты коллекции перешифрованы новым ключом
+			require.Len(t, r.Secrets, 1)
+			assert.Equal(t, 2, r.Secrets[0].KeyVersion)
+
+			data, ok := r.Secrets[0].Data.(*secret.PasswordData)
+			require.True(t, ok)
+			pass, err := encryptor.DecryptWithMasterKey(data.Pass.Bytes(), newKey)
+			require.NoError(t, err)
+			assert.Equal(t, "pass", pass)
+
+			_, err = encryptor.DecryptWithMasterKey(data.Pass.Bytes(), oldKey)
+			require.Error(t, err)
+
+			return nil
+		})
+
+	require.NoError(t, org.NewService(repo, newDataKeys(ctrl)).RemoveMember(context.Background(), adminID, orgID, viewerID))
+}
+
+func TestService_CreateCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		actorID   int
+		collName  string
+		createErr error
+		wantErr   error
+	}{
+		{name: "admin", actorID: adminID, collName: " infra "},
+		{name: "editor", actorID: editorID, collName: "infra", wantErr: org.ErrForbidden},
+		{name: "outsider", actorID: outsiderID, collName: "infra", wantErr: org.ErrNotFound},
+		{name: "empty name", actorID: adminID, collName: " ", wantErr: org.ErrEmptyName},
+		{name: "exists", actorID: adminID, collName: "infra", createErr: org.ErrCollectionExists, wantErr: org.ErrCollectionExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+
+			if tt.wantErr != org.ErrEmptyName {
+				expectMember(repo, tt.actorID)
+			}
+
+			var keys []*org.CollectionKey
+			if tt.wantErr == nil || tt.createErr != nil {
+				repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(team), nil)
+				repo.EXPECT().CreateCollection(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *org.Collection, k []*org.CollectionKey) error {
+						keys = k
+						return tt.createErr
+					})
+			}
+
+			c, err := org.NewService(repo, newDataKeys(ctrl)).CreateCollection(context.Background(), tt.actorID, orgID, tt.collName)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "infra", c.Name)
+			assert.Equal(t, 1, c.KeyVersion)
+
+			// Копия ключа выдается каждому участнику, у всех один ключ
+			require.Len(t, keys, len(team))
+			want := unwrapFor(t, keys[0].WrappedKey, keys[0].UserID)
+			for _, k := range keys {
+				assert.Equal(t, 1, k.Version)
+				assert.Equal(t, want, unwrapFor(t, k.WrappedKey, k.UserID))
+			}
+		})
+	}
+}
+
+func TestService_CollectionGrant(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     int
+		wantWrite  bool
+		wantManage bool
+		wantErr    error
+	}{
+		{name: "owner", userID: ownerID, wantWrite: true, wantManage: true},
+		{name: "admin", userID: adminID, wantWrite: true, wantManage: true},
+		{name: "editor", userID: editorID, wantWrite: true},
+		{name: "viewer", userID: viewerID},
+		{name: "outsider", userID: outsiderID, wantErr: secret.ErrCollectionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+			key := newCollectionKey(t)
+
+			if tt.wantErr != nil {
+				repo.EXPECT().GetAccess(gomock.Any(), collectionID, tt.userID).Return(nil, org.ErrCollectionNotFound)
+			} else {
+				expectAccess(t, repo, tt.userID, key, 2)
+			}
+
+			grant, err := org.NewService(repo, newDataKeys(ctrl)).CollectionGrant(context.Background(), collectionID, tt.userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, collectionID, grant.CollectionID)
+			assert.Equal(t, 2, grant.KeyVersion)
+			assert.Equal(t, key, grant.Key)
+			assert.Equal(t, tt.wantWrite, grant.CanWrite)
+			assert.Equal(t, tt.wantManage, grant.CanManage)
+		})
+	}
+}
+
+func TestService_LeaveAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  int
+		members map[int]org.Role
+		// successor участник, которому передается владение, 0 если не передается
+		successor int
+		deletes   bool
+		wantErr   error
+	}{
+		// Владельцем становится участник со старшей ролью
+		{name: "last owner", userID: ownerID, members: team, successor: adminID},
+		{
+			// При равных ролях владельцем становится участник, добавленный раньше
+			name:      "earliest of equal roles",
+			userID:    ownerID,
+			members:   map[int]org.Role{ownerID: org.RoleOwner, editorID: org.RoleEditor, viewerID: org.RoleEditor},
+			successor: editorID,
+		},
+		{name: "one of owners", userID: ownerID, members: map[int]org.Role{ownerID: org.RoleOwner, adminID: org.RoleOwner}},
+		{name: "viewer", userID: viewerID, members: team},
+		// Организация без участников удаляется
+		{name: "only member", userID: ownerID, members: map[int]org.Role{ownerID: org.RoleOwner}, deletes: true},
+		// Пользователя уже удалили из организации параллельно: чужая организация не удаляется
+		{
+			name: "only member is someone else", userID: ownerID,
+			members: map[int]org.Role{adminID: org.RoleOwner}, wantErr: org.ErrNotMember,
+		},
+		{
+			name: "not a member", userID: ownerID,
+			members: map[int]org.Role{adminID: org.RoleOwner, viewerID: org.RoleViewer}, wantErr: org.ErrNotMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockOrgRepository(ctrl)
+
+			repo.EXPECT().ListOrganizations(gomock.Any(), tt.userID).Return([]*org.Organization{{ID: orgID}}, nil)
+			repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(teamMembers(tt.members), nil).AnyTimes()
+
+			switch {
+			case tt.wantErr != nil:
+			case tt.deletes:
+				repo.EXPECT().DeleteOrganization(gomock.Any(), orgID).Return(nil)
+			default:
+				if tt.successor != 0 {
+					repo.EXPECT().SetRole(gomock.Any(), orgID, tt.successor, org.RoleOwner).Return(nil)
+				}
+				repo.EXPECT().ListCollections(gomock.Any(), orgID).Return(nil, nil)
+				repo.EXPECT().RemoveMember(gomock.Any(), orgID, tt.userID, gomock.Len(0)).Return(nil)
+			}
+
+			err := org.NewService(repo, newDataKeys(ctrl)).LeaveAll(context.Background(), tt.userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_LeaveAllError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockOrgRepository(ctrl)
+
+	repo.EXPECT().ListOrganizations(gomock.Any(), ownerID).Return([]*org.Organization{{ID: orgID}}, nil)
+	repo.EXPECT().ListMembers(gomock.Any(), orgID).Return(nil, errStorage)
+
+	err := org.NewService(repo, newDataKeys(ctrl)).LeaveAll(context.Background(), ownerID)
+	require.ErrorIs(t, err, errStorage)
+}
@@ -0,0 +1,40 @@
+package org
+
+//go:generate mockgen -destination=../../mocks/org.go -package=mocks -mock_names=Repository=MockOrgRepository,DataKeys=MockOrgDataKeys . Repository,DataKeys
+
+import (
+	"context"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+)
+
+// Repository интерфейс репозитория организаций.
+type Repository interface {
+	// CreateOrganization сохранение организации вместе с ownerID в роли владельца.
+	CreateOrganization(ctx context.Context, o *Organization, ownerID int) error
+	// DeleteOrganization удаление организации вместе с коллекциями и их секретами.
+	DeleteOrganization(ctx context.Context, orgID int) error
+	// ListOrganizations организации пользователя с его ролью в каждой.
+	ListOrganizations(ctx context.Context, userID int) ([]*Organization, error)
+	// GetMember участник организации, ErrNotMember если пользователь не участник.
+	GetMember(ctx context.Context, orgID, userID int) (*Member, error)
+	// ListMembers участники организации с логинами.
+	ListMembers(ctx context.Context, orgID int) ([]*Member, error)
+	// AddMember добавление участника вместе с копиями ключей коллекций, ErrAlreadyMember если он уже участник.
+	// ErrConcurrentChange, если копии ключей не соответствуют текущим коллекциям.
+	AddMember(ctx context.Context, m *Member, keys []*CollectionKey) error
+	// SetRole смена роли участника.
+	SetRole(ctx context.Context, orgID, userID int, role Role) error
+	// RemoveMember удаление участника и его копий ключей с ротацией ключей коллекций в одной транзакции.
+	// ErrConcurrentChange, если участники, коллекции или их секреты изменились после подготовки ротации.
+	RemoveMember(ctx context.Context, orgID, userID int, rotations []*KeyRotation) error
+	// CreateCollection сохранение коллекции с копиями ключа для участников, ErrCollectionExists при повторе названия.
+	// ErrConcurrentChange, если копии выданы не всем текущим участникам.
+	CreateCollection(ctx context.Context, c *Collection, keys []*CollectionKey) error
+	// ListCollections коллекции организации.
+	ListCollections(ctx context.Context, orgID int) ([]*Collection, error)
+	// GetAccess роль пользователя и его копия ключа коллекции, ErrCollectionNotFound если доступа нет.
+	GetAccess(ctx context.Context, collectionID, userID int) (*Access, error)
+	// GetCollectionSecrets все секреты коллекции вместе с зашифрованными данными.
+	GetCollectionSecrets(ctx context.Context, collectionID int) ([]*secret.Secret, error)
+}
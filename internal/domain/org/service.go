@@ -0,0 +1,594 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+// DataKeys ключи данных пользователей, из которых получаются ключи их копий ключей коллекций.
+type DataKeys interface {
+	UserKey(ctx context.Context, userID int) (*securemem.Buffer, error)
+}
+
+// Service сервисный слой организаций.
+// Ключи коллекций шифруются для каждого участника ключом, полученным из его ключа данных
+// (encryptor.MemberKey), поэтому удаленный участник теряет свою копию ключа, а ротация делает
+// бесполезными копии, сохраненные раньше.
+type Service struct {
+	repo Repository
+	keys DataKeys
+}
+
+// NewService получение сервиса организаций.
+func NewService(r Repository, keys DataKeys) *Service {
+	return &Service{
+		repo: r,
+		keys: keys,
+	}
+}
+
+// CreateOrganization создание организации, ownerID становится ее владельцем.
+func (s *Service) CreateOrganization(ctx context.Context, ownerID int, name string) (*Organization, error) {
+	o, err := NewOrganization(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.CreateOrganization(ctx, o, ownerID); err != nil {
+		return nil, fmt.Errorf("domain.org.Service.CreateOrganization: %w", err)
+	}
+
+	return o, nil
+}
+
+// DeleteOrganization удаление организации вместе с коллекциями и секретами, доступно только владельцу.
+func (s *Service) DeleteOrganization(ctx context.Context, actorID, orgID int) error {
+	actor, err := s.member(ctx, orgID, actorID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != RoleOwner {
+		return ErrForbidden
+	}
+
+	if err = s.repo.DeleteOrganization(ctx, orgID); err != nil {
+		return fmt.Errorf("domain.org.Service.DeleteOrganization: %w", err)
+	}
+
+	return nil
+}
+
+// ListOrganizations организации пользователя с его ролью.
+func (s *Service) ListOrganizations(ctx context.Context, userID int) ([]*Organization, error) {
+	orgs, err := s.repo.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.org.Service.ListOrganizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// ListMembers участники организации, доступно любому участнику.
+func (s *Service) ListMembers(ctx context.Context, actorID, orgID int) ([]*Member, error) {
+	if _, err := s.member(ctx, orgID, actorID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.org.Service.ListMembers: %w", err)
+	}
+
+	return members, nil
+}
+
+// AddMember добавление пользователя userID в организацию с ролью role.
+// Новому участнику сразу выдаются копии ключей всех коллекций организации.
+func (s *Service) AddMember(ctx context.Context, actorID, orgID, userID int, role Role) (*Member, error) {
+	op := "domain.org.Service.AddMember"
+
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+
+	actor, err := s.member(ctx, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.CanManageRole(role) {
+		return nil, ErrForbidden
+	}
+
+	collections, err := s.repo.ListCollections(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list collections %w", op, err)
+	}
+
+	keys := make([]*CollectionKey, 0, len(collections))
+	for _, c := range collections {
+		key, version, err := s.collectionKey(ctx, c.ID, actorID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		wrapped, err := s.wrapFor(ctx, key, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		keys = append(keys, &CollectionKey{
+			CollectionID: c.ID,
+			Version:      version,
+			UserID:       userID,
+			WrappedKey:   wrapped,
+		})
+	}
+
+	m := Member{
+		OrgID:     orgID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+	if err = s.repo.AddMember(ctx, &m, keys); err != nil {
+		if errors.Is(err, ErrAlreadyMember) || errors.Is(err, ErrConcurrentChange) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &m, nil
+}
+
+// SetRole смена роли участника. Роли admin и owner выдает и отзывает только владелец,
+// последнего владельца понизить нельзя.
+func (s *Service) SetRole(ctx context.Context, actorID, orgID, userID int, role Role) error {
+	op := "domain.org.Service.SetRole"
+
+	if !role.Valid() {
+		return ErrInvalidRole
+	}
+
+	actor, err := s.member(ctx, orgID, actorID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotMember) {
+			return ErrNotMember
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !actor.Role.CanManageRole(target.Role) || !actor.Role.CanManageRole(role) {
+		return ErrForbidden
+	}
+
+	if target.Role == RoleOwner && role != RoleOwner {
+		if err = s.checkNotLastOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err = s.repo.SetRole(ctx, orgID, userID, role); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveMember удаление участника из организации. Участник может выйти сам, остальных удаляет
+// участник, которому разрешено управлять ролью удаляемого. Ключи всех коллекций организации
+// при этом ротируются, а секреты коллекций перешифровываются новым ключом.
+func (s *Service) RemoveMember(ctx context.Context, actorID, orgID, userID int) error {
+	op := "domain.org.Service.RemoveMember"
+
+	actor, err := s.member(ctx, orgID, actorID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotMember) {
+			return ErrNotMember
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if actorID != userID && !actor.Role.CanManageRole(target.Role) {
+		return ErrForbidden
+	}
+
+	if target.Role == RoleOwner {
+		if err = s.checkNotLastOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	rotations, err := s.prepareRotations(ctx, orgID, actorID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.RemoveMember(ctx, orgID, userID, rotations); err != nil {
+		if errors.Is(err, ErrConcurrentChange) {
+			return ErrConcurrentChange
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LeaveAll выход пользователя из всех организаций перед окончательным удалением аккаунта с ротацией
+// ключей коллекций. Если уходит последний владелец, владельцем становится участник со старшей ролью
+// из оставшихся; организация без участников удаляется. ErrNotMember, если пользователя параллельно
+// удалили из организации.
+func (s *Service) LeaveAll(ctx context.Context, userID int) error {
+	op := "domain.org.Service.LeaveAll"
+
+	orgs, err := s.repo.ListOrganizations(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to list organizations %w", op, err)
+	}
+
+	for _, o := range orgs {
+		if err = s.leave(ctx, o.ID, userID); err != nil {
+			return fmt.Errorf("%s: organization %d: %w", op, o.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) leave(ctx context.Context, orgID, userID int) error {
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list members %w", err)
+	}
+
+	// Организация удаляется, только если ее единственный участник сам уходящий
+	if len(members) == 1 {
+		if members[0].UserID != userID {
+			return ErrNotMember
+		}
+		return s.repo.DeleteOrganization(ctx, orgID)
+	}
+
+	var (
+		leaving   *Member
+		successor *Member
+		owners    int
+	)
+	for _, m := range members {
+		if m.Role == RoleOwner {
+			owners++
+		}
+		if m.UserID == userID {
+			leaving = m
+			continue
+		}
+		if successor == nil || roleRank[m.Role] > roleRank[successor.Role] ||
+			(m.Role == successor.Role && m.CreatedAt.Before(successor.CreatedAt)) {
+			successor = m
+		}
+	}
+	if leaving == nil {
+		return ErrNotMember
+	}
+
+	if leaving.Role == RoleOwner && owners == 1 {
+		if err = s.repo.SetRole(ctx, orgID, successor.UserID, RoleOwner); err != nil {
+			return fmt.Errorf("failed to transfer ownership %w", err)
+		}
+	}
+
+	rotations, err := s.prepareRotations(ctx, orgID, userID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.RemoveMember(ctx, orgID, userID, rotations)
+}
+
+// CreateCollection создание коллекции со случайным ключом, копия которого выдается всем участникам.
+func (s *Service) CreateCollection(ctx context.Context, actorID, orgID int, name string) (*Collection, error) {
+	op := "domain.org.Service.CreateCollection"
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	actor, err := s.member(ctx, orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.CanManage() {
+		return nil, ErrForbidden
+	}
+
+	key, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate collection key %w", op, err)
+	}
+
+	c := Collection{
+		ID:         -1,
+		OrgID:      orgID,
+		Name:       name,
+		KeyVersion: 1,
+		CreatedAt:  time.Now(),
+	}
+
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list members %w", op, err)
+	}
+
+	keys, err := s.wrapForMembers(ctx, key, c.KeyVersion, members, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.CreateCollection(ctx, &c, keys); err != nil {
+		if errors.Is(err, ErrCollectionExists) || errors.Is(err, ErrConcurrentChange) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &c, nil
+}
+
+// ListCollections коллекции организации, доступно любому участнику.
+func (s *Service) ListCollections(ctx context.Context, actorID, orgID int) ([]*Collection, error) {
+	if _, err := s.member(ctx, orgID, actorID); err != nil {
+		return nil, err
+	}
+
+	collections, err := s.repo.ListCollections(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.org.Service.ListCollections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// CollectionGrant права пользователя в коллекции и ее ключ, реализует secret.CollectionAccess.
+func (s *Service) CollectionGrant(ctx context.Context, collectionID, userID int) (*secret.CollectionGrant, error) {
+	acc, err := s.repo.GetAccess(ctx, collectionID, userID)
+	if err != nil {
+		if errors.Is(err, ErrCollectionNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("domain.org.Service.CollectionGrant: %w", err)
+	}
+
+	key, err := s.unwrapFor(ctx, acc.WrappedKey, userID)
+	if err != nil {
+		return nil, fmt.Errorf("domain.org.Service.CollectionGrant: %w", err)
+	}
+
+	return &secret.CollectionGrant{
+		CollectionID: collectionID,
+		KeyVersion:   acc.Collection.KeyVersion,
+		Key:          key,
+		CanWrite:     acc.Role.CanWrite(),
+		CanManage:    acc.Role.CanManage(),
+	}, nil
+}
+
+// prepareRotations новые версии ключей всех коллекций организации без участника removedID.
+// Текущий ключ берется из копии actorID.
+func (s *Service) prepareRotations(
+	ctx context.Context,
+	orgID, actorID, removedID int,
+) (rotations []*KeyRotation, err error) {
+	collections, err := s.repo.ListCollections(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections %w", err)
+	}
+
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members %w", err)
+	}
+
+	// Файлы, открытые для перешифровки, закрываются, если ротацию подготовить не удалось
+	defer func() {
+		if err != nil {
+			for _, r := range rotations {
+				r.Wipe()
+			}
+		}
+	}()
+
+	rotations = make([]*KeyRotation, 0, len(collections))
+	for _, c := range collections {
+		var r *KeyRotation
+		if r, err = s.prepareRotation(ctx, c, members, actorID, removedID); err != nil {
+			return nil, err
+		}
+
+		rotations = append(rotations, r)
+	}
+
+	return rotations, nil
+}
+
+// prepareRotation новая версия ключа коллекции c для участников members без removedID.
+func (s *Service) prepareRotation(
+	ctx context.Context,
+	c *Collection,
+	members []*Member,
+	actorID, removedID int,
+) (*KeyRotation, error) {
+	oldKey, oldVersion, err := s.collectionKey(ctx, c.ID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(oldKey)
+
+	newKey, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate collection key %w", err)
+	}
+	defer securemem.Wipe(newKey)
+
+	r := KeyRotation{
+		CollectionID: c.ID,
+		OldVersion:   oldVersion,
+		NewVersion:   oldVersion + 1,
+	}
+
+	if r.Keys, err = s.wrapForMembers(ctx, newKey, r.NewVersion, members, removedID); err != nil {
+		return nil, err
+	}
+	for _, k := range r.Keys {
+		k.CollectionID = c.ID
+	}
+
+	if r.Secrets, err = s.repo.GetCollectionSecrets(ctx, c.ID); err != nil {
+		return nil, fmt.Errorf("failed to get collection secrets %w", err)
+	}
+	for _, sec := range r.Secrets {
+		// Секрет затирает ключ, которым зашифрован, поэтому получает свои копии ключей
+		if err = sec.Rekey(slices.Clone(oldKey), slices.Clone(newKey), r.NewVersion); err != nil {
+			r.Wipe()
+			return nil, fmt.Errorf("failed to rekey secret %d %w", sec.ID, err)
+		}
+	}
+
+	return &r, nil
+}
+
+// member участник организации. Не участнику организация не видна, поэтому возвращается ErrNotFound.
+func (s *Service) member(ctx context.Context, orgID, userID int) (*Member, error) {
+	m, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotMember) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get member %w", err)
+	}
+
+	return m, nil
+}
+
+// checkNotLastOwner ErrLastOwner, если в организации единственный владелец.
+func (s *Service) checkNotLastOwner(ctx context.Context, orgID int) error {
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list members %w", err)
+	}
+
+	owners := 0
+	for _, m := range members {
+		if m.Role == RoleOwner {
+			owners++
+		}
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
+
+// collectionKey расшифрованный ключ коллекции текущей версии из копии участника userID.
+func (s *Service) collectionKey(ctx context.Context, collectionID, userID int) ([]byte, int, error) {
+	acc, err := s.repo.GetAccess(ctx, collectionID, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get collection %d key %w", collectionID, err)
+	}
+
+	key, err := s.unwrapFor(ctx, acc.WrappedKey, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return key, acc.Collection.KeyVersion, nil
+}
+
+// wrapForMembers копии ключа коллекции для всех участников, кроме skipID. CollectionID копий не заполняется.
+func (s *Service) wrapForMembers(
+	ctx context.Context,
+	key []byte,
+	version int,
+	members []*Member,
+	skipID int,
+) ([]*CollectionKey, error) {
+	keys := make([]*CollectionKey, 0, len(members))
+	for _, m := range members {
+		if m.UserID == skipID {
+			continue
+		}
+
+		wrapped, err := s.wrapFor(ctx, key, m.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, &CollectionKey{Version: version, UserID: m.UserID, WrappedKey: wrapped})
+	}
+
+	return keys, nil
+}
+
+func (s *Service) wrapFor(ctx context.Context, key []byte, userID int) ([]byte, error) {
+	kek, err := s.memberKey(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(kek)
+
+	wrapped, err := encryptor.WrapKey(key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap collection key %w", err)
+	}
+
+	return wrapped, nil
+}
+
+func (s *Service) unwrapFor(ctx context.Context, wrapped []byte, userID int) ([]byte, error) {
+	kek, err := s.memberKey(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer securemem.Wipe(kek)
+
+	key, err := encryptor.UnwrapKey(wrapped, kek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap collection key %w", err)
+	}
+
+	return key, nil
+}
+
+// memberKey ключ копий ключей коллекций участника userID, полученный из его ключа данных.
+func (s *Service) memberKey(ctx context.Context, userID int) ([]byte, error) {
+	userKey, err := s.keys.UserKey(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data key of user %d %w", userID, err)
+	}
+	defer userKey.Wipe()
+
+	kek, err := encryptor.MemberKey(userKey.Bytes(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive member key %w", err)
+	}
+
+	return kek, nil
+}
@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrApprovalRequired для раскрытия данных секрета нужен одобренный запрос доступа.
+var ErrApprovalRequired = errors.New("secret access requires approval")
+
+// RevealApproval проверка одобрения доступа к критичным секретам.
+type RevealApproval interface {
+	// LockedSecrets секреты из secretIDs, данные которых нельзя раскрыть userID без одобренного запроса.
+	// Раскрытие остальных секретов под политикой одобрения записывается в журнал.
+	LockedSecrets(ctx context.Context, userID int, secretIDs []int) (map[int]bool, error)
+}
+
+// withholdLocked скрытие данных секретов, для раскрытия которых userID нужен одобренный запрос.
+// У таких секретов выставляется ApprovalRequired, а данные и содержимое доступа E2E сбрасываются.
+func (s *Service) withholdLocked(ctx context.Context, userID int, secrets []*Secret) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(secrets))
+	for _, secret := range secrets {
+		ids = append(ids, secret.ID)
+	}
+
+	locked, err := s.approvals.LockedSecrets(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to check access approvals %w", err)
+	}
+
+	for _, secret := range secrets {
+		if !locked[secret.ID] {
+			continue
+		}
+		secret.ApprovalRequired = true
+		secret.Data = nil
+		if secret.Access != nil {
+			secret.Access.Payload = nil
+		}
+	}
+
+	return nil
+}
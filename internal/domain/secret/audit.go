@@ -0,0 +1,58 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+)
+
+// Auditor журнал аудита операций с секретами.
+type Auditor interface {
+	Record(ctx context.Context, events ...*audit.Event) error
+}
+
+// record запись операции пользователя u с секретом secretID в журнал аудита, результат определяется по opErr.
+// Возвращается opErr вместе с ошибкой записи: операция без записи в журнале считается неудачной.
+func (s *Service) record(ctx context.Context, u *user.User, action audit.Action, secretID int, opErr error) error {
+	if s.auditor == nil {
+		return opErr
+	}
+
+	if err := s.auditor.Record(ctx, audit.NewEvent(u.ID, u.Login, action, secretID, opErr)); err != nil {
+		return errors.Join(opErr, fmt.Errorf("failed to record audit event %w", err))
+	}
+
+	return opErr
+}
+
+// recordReads запись чтения данных секретов пользователем u в журнал аудита. Субъект записи владелец
+// секрета, поэтому чтение общего секрета видно в журнале владельца. Секреты, данные которых скрыты
+// до одобрения доступа, записываются как неудачное чтение.
+func (s *Service) recordReads(ctx context.Context, u *user.User, secrets []*Secret) error {
+	if s.auditor == nil || len(secrets) == 0 {
+		return nil
+	}
+
+	events := make([]*audit.Event, 0, len(secrets))
+	for _, secret := range secrets {
+		var opErr error
+		if secret.ApprovalRequired {
+			opErr = ErrApprovalRequired
+		}
+		ev := audit.NewEvent(u.ID, u.Login, audit.ActionRead, secret.ID, opErr)
+		if secret.UserID != u.ID {
+			// Логин владельца подставит репозиторий журнала
+			ev.SubjectID, ev.Login = secret.UserID, ""
+		}
+		events = append(events, ev)
+	}
+
+	if err := s.auditor.Record(ctx, events...); err != nil {
+		return fmt.Errorf("failed to record audit events %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+var (
+	// ErrCollectionNotFound коллекции нет или пользователь не участник ее организации.
+	ErrCollectionNotFound = errors.New("collection not found")
+	// ErrCollectionReadOnly роль пользователя в организации не позволяет изменять секреты коллекции.
+	ErrCollectionReadOnly = errors.New("role does not allow changing collection secrets")
+	// ErrCollectionDeleteDenied роль пользователя в организации не позволяет удалять секреты коллекции.
+	ErrCollectionDeleteDenied = errors.New("role does not allow deleting collection secrets")
+	// ErrKeyRotated ключ коллекции сменился во время операции, операцию нужно повторить.
+	ErrKeyRotated = errors.New("collection key was rotated")
+)
+
+// CollectionOp операция с секретами коллекции, для которой проверяется роль участника.
+type CollectionOp int
+
+const (
+	// CollectionOpList список секретов без данных, доступен любому участнику.
+	CollectionOpList CollectionOp = iota
+	// CollectionOpRead чтение данных секретов, доступно любому участнику.
+	CollectionOpRead
+	// CollectionOpCreate создание секретов, нужна роль editor или выше.
+	CollectionOpCreate
+	// CollectionOpDelete удаление секретов, нужна роль admin или выше.
+	CollectionOpDelete
+)
+
+// CollectionGrant права пользователя в коллекции организации и расшифрованный ключ коллекции текущей версии.
+// Ключ затирается через Wipe.
+type CollectionGrant struct {
+	CollectionID int
+	KeyVersion   int
+	Key          []byte
+	// CanWrite роль позволяет создавать секреты в коллекции.
+	CanWrite bool
+	// CanManage роль позволяет удалять секреты коллекции.
+	CanManage bool
+}
+
+// Allow проверка, что роль позволяет операцию op.
+func (g *CollectionGrant) Allow(op CollectionOp) error {
+	switch {
+	case op == CollectionOpCreate && !g.CanWrite:
+		return ErrCollectionReadOnly
+	case op == CollectionOpDelete && !g.CanManage:
+		return ErrCollectionDeleteDenied
+	default:
+		return nil
+	}
+}
+
+// Wipe затирание ключа коллекции.
+func (g *CollectionGrant) Wipe() {
+	securemem.Wipe(g.Key)
+}
+
+// CollectionAccess проверка прав пользователя в коллекциях организаций.
+type CollectionAccess interface {
+	// CollectionGrant права userID в коллекции, ErrCollectionNotFound если пользователь не участник организации.
+	CollectionGrant(ctx context.Context, collectionID, userID int) (*CollectionGrant, error)
+}
+
+// CreateCollectionPassword создать секрет-пароль в коллекции организации, нужна роль editor или выше.
+func (s *Service) CreateCollectionPassword(
+	ctx context.Context,
+	u *user.User,
+	collectionID int,
+	secretName, username, password, url, notes string,
+	metaData []byte,
+) (*Secret, error) {
+	op := "domain.Secret.service.CreateCollectionPassword"
+
+	grant, err := s.collectionGrant(ctx, u, collectionID, CollectionOpCreate)
+	if err != nil {
+		return nil, err
+	}
+	defer grant.Wipe()
+
+	secret, err := NewPasswordSecret(u, secretName, username, password, url, notes, metaData, grant.Key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get new domain model for password secret %w", op, err)
+	}
+
+	if err = s.saveCollectionSecret(ctx, u, secret, grant); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
+
+// CreateCollectionCard создать секрет с данными банковской карты в коллекции организации,
+// нужна роль editor или выше.
+func (s *Service) CreateCollectionCard(
+	ctx context.Context,
+	u *user.User,
+	collectionID int,
+	secretName, number, owner, expireDate, cvv, notes string,
+	metaData []byte,
+) (*Secret, error) {
+	op := "domain.Secret.service.CreateCollectionCard"
+
+	grant, err := s.collectionGrant(ctx, u, collectionID, CollectionOpCreate)
+	if err != nil {
+		return nil, err
+	}
+	defer grant.Wipe()
+
+	secret, err := NewCardSecret(u, secretName, number, owner, expireDate, cvv, notes, metaData, grant.Key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get new domain model for card secret %w", op, err)
+	}
+
+	if err = s.saveCollectionSecret(ctx, u, secret, grant); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
+
+// CreateCollectionFile создать секретный файл в коллекции организации, нужна роль editor или выше.
+func (s *Service) CreateCollectionFile(
+	ctx context.Context,
+	u *user.User,
+	collectionID int,
+	secretName, fileName, notes string,
+	content io.Reader,
+	metaData []byte,
+) (*Secret, error) {
+	op := "domain.Secret.service.CreateCollectionFile"
+
+	grant, err := s.collectionGrant(ctx, u, collectionID, CollectionOpCreate)
+	if err != nil {
+		return nil, err
+	}
+	defer grant.Wipe()
+
+	secret, err := NewFileSecret(
+		u,
+		secretName,
+		s.cfg.Database.ExternalStoragePath,
+		fileName,
+		content,
+		notes,
+		metaData,
+		grant.Key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get new domain model for file secret %w", op, err)
+	}
+
+	if err = s.saveCollectionSecret(ctx, u, secret, grant); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
+
+// DeleteCollectionSecret удалить секрет коллекции организации, нужна роль admin или выше.
+func (s *Service) DeleteCollectionSecret(ctx context.Context, u *user.User, collectionID, secretID int) error {
+	op := "domain.Secret.service.DeleteCollectionSecret"
+
+	grant, err := s.collectionGrant(ctx, u, collectionID, CollectionOpDelete)
+	if err != nil {
+		return err
+	}
+	grant.Wipe()
+
+	if err = s.repo.DeleteCollectionSecret(ctx, collectionID, secretID); err != nil {
+		if errors.Is(err, ErrSecretNotFound) {
+			return s.record(ctx, u, audit.ActionDelete, secretID, ErrSecretNotFound)
+		}
+		return fmt.Errorf("%s: failed to delete secret %w", op, err)
+	}
+
+	if err = s.record(ctx, u, audit.ActionDelete, secretID, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetCollectionSecrets секреты коллекции, доступные любому участнику организации.
+// С непустым name возвращаются секреты с этим названием вместе с данными, иначе все секреты без данных.
+func (s *Service) GetCollectionSecrets(
+	ctx context.Context,
+	u *user.User,
+	collectionID int,
+	name string,
+) ([]*Secret, error) {
+	op := "domain.Secret.service.GetCollectionSecrets"
+
+	collectionOp := CollectionOpRead
+	if name == "" {
+		collectionOp = CollectionOpList
+	}
+
+	grant, err := s.collectionGrant(ctx, u, collectionID, collectionOp)
+	if err != nil {
+		return nil, err
+	}
+	defer grant.Wipe()
+
+	var lookups []string
+	if name != "" {
+		if lookups, err = s.fields.CollectionNameLookups(collectionID, name); err != nil {
+			return nil, fmt.Errorf("%s: failed to get name lookups %w", op, err)
+		}
+	}
+
+	secrets, err := s.repo.GetCollectionSecrets(ctx, collectionID, lookups)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get collection secrets %w", op, err)
+	}
+
+	if name == "" {
+		for _, secret := range secrets {
+			if err = s.fields.OpenSecret(secret); err != nil {
+				return nil, fmt.Errorf("%s: failed to open secret fields with error %w", op, err)
+			}
+		}
+
+		return secrets, nil
+	}
+
+	if err = s.repo.LoadSecretsData(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: failed to load secrets data %w", op, err)
+	}
+
+	for _, secret := range secrets {
+		// Секрет прочитан уже после ротации ключа, полученного в grant
+		if secret.KeyVersion != grant.KeyVersion {
+			return nil, ErrKeyRotated
+		}
+
+		// Копия ключа принадлежит секрету: содержимое файла расшифровывается уже после ответа сервиса
+		secret.Data.setMasterKey(slices.Clone(grant.Key))
+		if err = secret.DecryptData(); err != nil {
+			return nil, fmt.Errorf("%s: failed to decrypt data with error %w", op, err)
+		}
+
+		if err = s.fields.OpenSecret(secret); err != nil {
+			return nil, fmt.Errorf("%s: failed to open secret fields with error %w", op, err)
+		}
+	}
+
+	if err = s.recordReads(ctx, u, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secrets, nil
+}
+
+// collectionGrant права пользователя в коллекции, если его роль позволяет операцию op.
+// Ключ коллекции в ответе затирает вызывающий.
+func (s *Service) collectionGrant(
+	ctx context.Context,
+	u *user.User,
+	collectionID int,
+	op CollectionOp,
+) (*CollectionGrant, error) {
+	grant, err := s.collections.CollectionGrant(ctx, collectionID, u.ID)
+	if err != nil {
+		if errors.Is(err, ErrCollectionNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("domain.Secret.service.collectionGrant: %w", err)
+	}
+
+	if err = grant.Allow(op); err != nil {
+		grant.Wipe()
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// saveCollectionSecret перенос нового секрета в коллекцию и сохранение.
+func (s *Service) saveCollectionSecret(
+	ctx context.Context,
+	u *user.User,
+	secret *Secret,
+	grant *CollectionGrant,
+) error {
+	if err := secret.placeInCollection(grant); err != nil {
+		return fmt.Errorf("failed to encrypt collection secret %w", err)
+	}
+
+	return s.saveSecret(ctx, u, secret)
+}
@@ -0,0 +1,216 @@
+package secret_test
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/org"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memCollectionRepo реализует Repository для секретов одной коллекции в памяти.
+type memCollectionRepo struct {
+	secret.Repository
+	secrets []*secret.Secret
+}
+
+func (m *memCollectionRepo) SaveSecret(_ context.Context, s *secret.Secret) error {
+	s.ID = len(m.secrets) + 1
+	m.secrets = append(m.secrets, s)
+	return nil
+}
+
+func (m *memCollectionRepo) GetCollectionSecrets(context.Context, int, []string) ([]*secret.Secret, error) {
+	return slices.Clone(m.secrets), nil
+}
+
+func (m *memCollectionRepo) LoadSecretsData(context.Context, []*secret.Secret) error {
+	return nil
+}
+
+func (m *memCollectionRepo) DeleteCollectionSecret(_ context.Context, _, secretID int) error {
+	for i, s := range m.secrets {
+		if s.ID == secretID {
+			m.secrets = slices.Delete(m.secrets, i, i+1)
+			return nil
+		}
+	}
+	return secret.ErrSecretNotFound
+}
+
+// roleAccess реализует CollectionAccess: права участников определяются их ролью в организации.
+type roleAccess struct {
+	key   []byte
+	roles map[int]org.Role
+}
+
+func (r *roleAccess) CollectionGrant(_ context.Context, collectionID, userID int) (*secret.CollectionGrant, error) {
+	role, ok := r.roles[userID]
+	if !ok {
+		return nil, secret.ErrCollectionNotFound
+	}
+
+	return &secret.CollectionGrant{
+		CollectionID: collectionID,
+		KeyVersion:   1,
+		Key:          bytes.Clone(r.key),
+		CanWrite:     role.CanWrite(),
+		CanManage:    role.CanManage(),
+	}, nil
+}
+
+func TestService_CollectionRoles(t *testing.T) {
+	const collectionID = 7
+
+	members := map[org.Role]*user.User{
+		org.RoleOwner:  {ID: 1, Login: "owner"},
+		org.RoleAdmin:  {ID: 2, Login: "admin"},
+		org.RoleEditor: {ID: 3, Login: "editor"},
+		org.RoleViewer: {ID: 4, Login: "viewer"},
+	}
+	outsider := &user.User{ID: 5, Login: "outsider"}
+
+	operations := []struct {
+		name string
+		run  func(s *secret.Service, u *user.User, secretID int) error
+	}{
+		{
+			name: "list",
+			run: func(s *secret.Service, u *user.User, _ int) error {
+				_, err := s.GetCollectionSecrets(context.Background(), u, collectionID, "")
+				return err
+			},
+		},
+		{
+			name: "read",
+			run: func(s *secret.Service, u *user.User, _ int) error {
+				_, err := s.GetCollectionSecrets(context.Background(), u, collectionID, "db")
+				return err
+			},
+		},
+		{
+			name: "create password",
+			run: func(s *secret.Service, u *user.User, _ int) error {
+				_, err := s.CreateCollectionPassword(
+					context.Background(), u, collectionID, "mail", "john", "qwerty", "", "", nil,
+				)
+				return err
+			},
+		},
+		{
+			name: "create card",
+			run: func(s *secret.Service, u *user.User, _ int) error {
+				_, err := s.CreateCollectionCard(
+					context.Background(), u, collectionID, "card", "4111111111111111", "JOHN", "12/30", "123", "", nil,
+				)
+				return err
+			},
+		},
+		{
+			name: "create file",
+			run: func(s *secret.Service, u *user.User, _ int) error {
+				_, err := s.CreateCollectionFile(
+					context.Background(), u, collectionID, "keys", "id_rsa", "", bytes.NewReader([]byte("key")), nil,
+				)
+				return err
+			},
+		},
+		{
+			name: "delete",
+			run: func(s *secret.Service, u *user.User, secretID int) error {
+				return s.DeleteCollectionSecret(context.Background(), u, collectionID, secretID)
+			},
+		},
+	}
+
+	// Ожидаемая ошибка для каждой пары роль/операция, nil если операция разрешена
+	expected := map[string]map[org.Role]error{
+		"list":            {},
+		"read":            {},
+		"create password": {org.RoleViewer: secret.ErrCollectionReadOnly},
+		"create card":     {org.RoleViewer: secret.ErrCollectionReadOnly},
+		"create file":     {org.RoleViewer: secret.ErrCollectionReadOnly},
+		"delete": {
+			org.RoleEditor: secret.ErrCollectionDeleteDenied,
+			org.RoleViewer: secret.ErrCollectionDeleteDenied,
+		},
+	}
+
+	for _, op := range operations {
+		for _, role := range []org.Role{org.RoleOwner, org.RoleAdmin, org.RoleEditor, org.RoleViewer, ""} {
+			u, name := members[role], string(role)
+			if role == "" {
+				u, name = outsider, "not a member"
+			}
+
+			t.Run(op.name+"/"+name, func(t *testing.T) {
+				fe, md := newTestFieldEncryption(t)
+				cfg := &config.Config{}
+				cfg.Database.ExternalStoragePath = t.TempDir()
+
+				access := &roleAccess{key: md, roles: make(map[int]org.Role)}
+				for r, m := range members {
+					access.roles[m.ID] = r
+				}
+
+				repo := &memCollectionRepo{}
+				s := secret.NewService(repo, cfg, fe, staticDataKeys(md), access, lockedSecrets{}, nil)
+
+				seeded, err := s.CreateCollectionPassword(
+					context.Background(), members[org.RoleOwner], collectionID, "db", "admin", "secret", "", "", nil,
+				)
+				require.NoError(t, err)
+
+				err = op.run(s, u, seeded.ID)
+
+				switch {
+				case role == "":
+					assert.ErrorIs(t, err, secret.ErrCollectionNotFound)
+				case expected[op.name][role] != nil:
+					assert.ErrorIs(t, err, expected[op.name][role])
+				default:
+					assert.NoError(t, err)
+				}
+
+				// Отклоненная операция не меняет коллекцию
+				if err != nil {
+					require.Len(t, repo.secrets, 1)
+					assert.Equal(t, seeded.ID, repo.secrets[0].ID)
+				}
+			})
+		}
+	}
+}
+
+func TestService_DeleteCollectionSecretAudit(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+	admin := &user.User{ID: 2, Login: "admin"}
+
+	auditor := &recordingAuditor{}
+	access := &roleAccess{key: md, roles: map[int]org.Role{admin.ID: org.RoleAdmin}}
+	repo := &memCollectionRepo{}
+	s := secret.NewService(repo, &config.Config{}, fe, staticDataKeys(md), access, lockedSecrets{}, auditor)
+
+	created, err := s.CreateCollectionPassword(context.Background(), admin, 7, "db", "admin", "secret", "", "", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteCollectionSecret(context.Background(), admin, 7, created.ID))
+	assert.Empty(t, repo.secrets)
+
+	err = s.DeleteCollectionSecret(context.Background(), admin, 7, created.ID)
+	require.ErrorIs(t, err, secret.ErrSecretNotFound)
+
+	require.Len(t, auditor.events, 3)
+	for i, result := range []audit.Result{audit.ResultSuccess, audit.ResultSuccess, audit.ResultFailure} {
+		assert.Equal(t, result, auditor.events[i].Result)
+	}
+	assert.Equal(t, audit.ActionDelete, auditor.events[1].Action)
+	assert.Equal(t, created.ID, auditor.events[1].SecretID)
+}
@@ -0,0 +1,47 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+// DataKeys ключи данных пользователей, которыми шифруются данные их личных секретов.
+type DataKeys interface {
+	// UserKey развернутый ключ данных пользователя. Буфер затирает вызывающий.
+	UserKey(ctx context.Context, userID int) (*securemem.Buffer, error)
+}
+
+// ownerKeys ключи данных владельцев секретов, развернутые на время одного запроса.
+type ownerKeys struct {
+	keys DataKeys
+	byID map[int]*securemem.Buffer
+}
+
+func newOwnerKeys(keys DataKeys) *ownerKeys {
+	return &ownerKeys{keys: keys, byID: make(map[int]*securemem.Buffer)}
+}
+
+// keyFor копия ключа данных владельца секрета. Копия принадлежит секрету и затирается вместе с ним:
+// содержимое файла расшифровывается уже после ответа сервиса.
+func (ok *ownerKeys) keyFor(ctx context.Context, s *Secret) ([]byte, error) {
+	key, found := ok.byID[s.UserID]
+	if !found {
+		var err error
+		if key, err = ok.keys.UserKey(ctx, s.UserID); err != nil {
+			return nil, fmt.Errorf("failed to get data key of user %d %w", s.UserID, err)
+		}
+		ok.byID[s.UserID] = key
+	}
+
+	return slices.Clone(key.Bytes()), nil
+}
+
+// Wipe затирание развернутых ключей.
+func (ok *ownerKeys) Wipe() {
+	for _, key := range ok.byID {
+		key.Wipe()
+	}
+}
@@ -2,15 +2,18 @@
 package secret
 
 import (
+	"bytes"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
 	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
 )
 
 // TypeOfSecret тип секрета.
@@ -20,11 +23,17 @@ type TypeOfSecret string
 type SecretData interface {
 	Encrypt() error
 	Decrypt() error
+	// Wipe затирание расшифрованных данных после использования.
+	Wipe()
 	setDataFromRow(row *sql.Row) error
 	setMasterKey(mk []byte)
 }
 
-var errInvalidSecretType = errors.New("invalid secret type")
+var (
+	errInvalidSecretType = errors.New("invalid secret type")
+	errContentNotLoaded  = errors.New("file content is not loaded")
+	errContentEncrypted  = errors.New("file content is encrypted")
+)
 
 const (
 	// TypePassword секрет пароль.
@@ -58,15 +67,32 @@ func (tos *TypeOfSecret) Value() (driver.Value, error) {
 // Secret структура секрета.
 // Изначально секрету присваивается ID = -1 после записи в БД ID меняется на присвоенный в базе.
 type Secret struct {
-	ID        int
-	UserID    int
-	Name      string
+	ID     int
+	UserID int
+	Name   string
+	// NameIndex слепой индекс названия для поиска, если название хранится зашифрованным.
+	NameIndex string
 	Type      TypeOfSecret
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt time.Time
 	Version   uint32
 	Data      SecretData
+	// ClientEncrypted данные зашифрованы на клиенте (режим E2E) и сервер их не расшифровывает.
+	ClientEncrypted bool
+	// Access доступ, через который получен чужой секрет; nil для собственных секретов.
+	Access *Share
+	// CollectionID коллекция организации, которой принадлежит секрет; 0 для личных секретов.
+	// У секрета коллекции UserID равен 0, а автор хранится в CreatedBy.
+	CollectionID int
+	// KeyVersion версия ключа коллекции, которым зашифрованы данные секрета коллекции.
+	KeyVersion int
+	// CreatedBy автор секрета коллекции; 0, если аккаунт автора удален.
+	CreatedBy int
+	// ApprovalRequired данные скрыты: для их раскрытия нужен одобренный запрос доступа.
+	ApprovalRequired bool
+	// SealedFields поля, которые хранятся зашифрованными политикой FieldEncryption.
+	SealedFields SealedFields
 }
 
 // NewSecret получить новый секрет.
@@ -100,6 +126,20 @@ func (s *Secret) setData(data SecretData) {
 	s.Data = data
 }
 
+// baseData общие для всех типов секретные данные (заметки и метаданные).
+func (s *Secret) baseData() *baseSecretData {
+	switch data := s.Data.(type) {
+	case *PasswordData:
+		return data.baseSecretData
+	case *CardData:
+		return data.baseSecretData
+	case *FileData:
+		return data.baseSecretData
+	default:
+		return nil
+	}
+}
+
 // SetDataFromRow установить секрету секретные данные из строки из БД.
 func (s *Secret) SetDataFromRow(data *sql.Row) error {
 	switch s.Type {
@@ -125,8 +165,87 @@ func (s *Secret) SetDataFromRow(data *sql.Row) error {
 	return nil
 }
 
+// encryptData зашифровать данные нового секрета.
+// Для пользователей со сквозным шифрованием данные уже зашифрованы клиентом и сохраняются как есть.
+func (s *Secret) encryptData(u *user.User) error {
+	if u.IsE2E() {
+		s.ClientEncrypted = true
+		return nil
+	}
+
+	return s.Data.Encrypt()
+}
+
+// placeInCollection перенос нового секрета в коллекцию. Данные секрета коллекции шифруются на сервере
+// ключом коллекции даже для пользователей E2E, иначе остальные участники не смогут их прочитать.
+func (s *Secret) placeInCollection(grant *CollectionGrant) error {
+	s.CreatedBy, s.UserID = s.UserID, 0
+	s.CollectionID, s.KeyVersion = grant.CollectionID, grant.KeyVersion
+
+	if !s.ClientEncrypted {
+		return nil
+	}
+	s.ClientEncrypted = false
+
+	return s.Data.Encrypt()
+}
+
+// Rekey перешифровка данных секрета коллекции новой версией ключа коллекции. Перешифрованное содержимое
+// файла читается из ContentReader и сохраняется в новый файл, после чего секрет нужно затереть через Wipe.
+func (s *Secret) Rekey(oldKey, newKey []byte, newVersion int) error {
+	if s.CollectionID == 0 {
+		return errors.New("only collection secrets can be rekeyed")
+	}
+
+	var err error
+	if fd, ok := s.Data.(*FileData); ok {
+		err = fd.reencrypt(oldKey, newKey)
+	} else {
+		err = s.reencrypt(oldKey, newKey)
+	}
+	if err != nil {
+		return err
+	}
+	s.KeyVersion = newVersion
+
+	return nil
+}
+
+// Reencrypt перешифровка данных личного секрета другим ключом, например при переходе с корневого ключа
+// сервера на ключ данных пользователя. Перешифрованное содержимое файла читается из ContentReader
+// и сохраняется в новый файл, после чего секрет нужно затереть через Wipe.
+func (s *Secret) Reencrypt(oldKey, newKey []byte) error {
+	if s.CollectionID != 0 || s.ClientEncrypted {
+		return errors.New("only server encrypted personal secrets can be reencrypted")
+	}
+
+	if fd, ok := s.Data.(*FileData); ok {
+		return fd.reencrypt(oldKey, newKey)
+	}
+
+	return s.reencrypt(oldKey, newKey)
+}
+
+func (s *Secret) reencrypt(oldKey, newKey []byte) error {
+	s.Data.setMasterKey(oldKey)
+	if err := s.Data.Decrypt(); err != nil {
+		return fmt.Errorf("failed to decrypt secret data with error %w", err)
+	}
+
+	s.Data.setMasterKey(newKey)
+	if err := s.Data.Encrypt(); err != nil {
+		return fmt.Errorf("failed to encrypt secret data with error %w", err)
+	}
+
+	return nil
+}
+
 // DecryptData расшифровать данные.
 func (s *Secret) DecryptData() error {
+	if s.ClientEncrypted {
+		return nil
+	}
+
 	err := s.Data.Decrypt()
 	if err != nil {
 		return fmt.Errorf("failed to decrypt secret data with error %w", err)
@@ -135,9 +254,17 @@ func (s *Secret) DecryptData() error {
 	return nil
 }
 
+// Wipe затирание расшифрованных данных секрета.
+func (s *Secret) Wipe() {
+	if s.Data != nil {
+		s.Data.Wipe()
+	}
+}
+
 // Базовая структура данных секрета.
+// Секретные значения хранятся в securemem.Buffer: до расшифровки в них лежит шифротекст.
 type baseSecretData struct {
-	Notes     string
+	Notes     *securemem.Buffer
 	MetaData  []byte
 	Encrypted bool
 	masterKey []byte
@@ -147,7 +274,7 @@ type baseSecretData struct {
 // Изначально данные внутри секрета связываются с секретом ID = -1 после записи в БД ID меняется на присвоенный в базе.
 func newBaseSecretData(notes string, metaData []byte, mk []byte) *baseSecretData {
 	return &baseSecretData{
-		Notes:     notes,
+		Notes:     securemem.FromString(notes),
 		MetaData:  metaData,
 		Encrypted: false,
 		masterKey: mk,
@@ -156,7 +283,7 @@ func newBaseSecretData(notes string, metaData []byte, mk []byte) *baseSecretData
 
 func newEmptyBaseSecretData() *baseSecretData {
 	return &baseSecretData{
-		Notes:     "",
+		Notes:     nil,
 		MetaData:  nil,
 		Encrypted: true,
 		masterKey: nil,
@@ -172,7 +299,7 @@ func (bs *baseSecretData) Encrypt() error {
 
 	var err error
 
-	bs.Notes, err = encryptor.EncryptWithMasterKey([]byte(bs.Notes), bs.masterKey)
+	bs.Notes, err = sealBuffer(bs.Notes, bs.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt secret notes %w", op, err)
 	}
@@ -183,13 +310,13 @@ func (bs *baseSecretData) Encrypt() error {
 func (bs *baseSecretData) Decrypt() error {
 	op := "domain.service.baseSecretData.encrypt"
 
-	if bs.Notes == "" {
+	if bs.Notes.Len() == 0 {
 		return nil
 	}
 
 	var err error
 
-	bs.Notes, err = encryptor.DecryptWithMasterKey([]byte(bs.Notes), bs.masterKey)
+	bs.Notes, err = openBuffer(bs.Notes, bs.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt secret notes %w", op, err)
 	}
@@ -197,11 +324,40 @@ func (bs *baseSecretData) Decrypt() error {
 	return nil
 }
 
+// Wipe затирание заметок, метаданных и ключа, которым зашифрованы данные.
+func (bs *baseSecretData) Wipe() {
+	bs.Notes.Wipe()
+	securemem.Wipe(bs.MetaData)
+	securemem.Wipe(bs.masterKey)
+}
+
+// sealBuffer шифрование значения буфера. Открытое значение затирается.
+func sealBuffer(b *securemem.Buffer, mk []byte) (*securemem.Buffer, error) {
+	enc, err := encryptor.EncryptWithMasterKey(b.Bytes(), mk)
+	if err != nil {
+		return nil, err
+	}
+	b.Wipe()
+
+	return securemem.FromString(enc), nil
+}
+
+// openBuffer расшифровка значения буфера в новый буфер.
+func openBuffer(b *securemem.Buffer, mk []byte) (*securemem.Buffer, error) {
+	dec, err := encryptor.DecryptToBuffer(b.Bytes(), mk)
+	if err != nil {
+		return nil, err
+	}
+	b.Wipe()
+
+	return dec, nil
+}
+
 // PasswordData структура секрета для хранения пароля.
 type PasswordData struct {
 	*baseSecretData
 	Username  string
-	Pass      string
+	Pass      *securemem.Buffer
 	URL       string
 	masterKey []byte
 }
@@ -217,7 +373,7 @@ func NewPasswordData(
 	return &PasswordData{
 		baseSecretData: base,
 		Username:       username,
-		Pass:           password,
+		Pass:           securemem.FromString(password),
 		URL:            url,
 		masterKey:      masterKey,
 	}
@@ -252,7 +408,7 @@ func NewPasswordSecret(
 
 	secret.setData(data)
 
-	err = secret.Data.Encrypt()
+	err = secret.encryptData(u)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to encrypt PasswordData %w", op, err)
 	}
@@ -264,7 +420,7 @@ func newEmptyPasswordData() *PasswordData {
 	return &PasswordData{
 		baseSecretData: newEmptyBaseSecretData(),
 		Username:       "",
-		Pass:           "",
+		Pass:           nil,
 		URL:            "",
 	}
 }
@@ -275,9 +431,12 @@ func (pd *PasswordData) setMasterKey(mk []byte) {
 }
 
 func (pd *PasswordData) setDataFromRow(row *sql.Row) error {
-	if err := row.Scan(&pd.Username, &pd.Pass, &pd.URL, &pd.Notes, &pd.MetaData); err != nil {
+	var pass, notes string
+	if err := row.Scan(&pd.Username, &pass, &pd.URL, &notes, &pd.MetaData); err != nil {
 		return fmt.Errorf("failed to scan row for password data with error %w", err)
 	}
+	pd.Pass = securemem.FromString(pass)
+	pd.Notes = securemem.FromString(notes)
 	pd.Encrypted = true
 	return nil
 }
@@ -288,12 +447,12 @@ func (pd *PasswordData) Encrypt() error {
 
 	var err error
 
-	pd.Pass, err = encryptor.EncryptWithMasterKey([]byte(pd.Pass), pd.masterKey)
+	pd.Pass, err = sealBuffer(pd.Pass, pd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt password %w", op, err)
 	}
 
-	pd.Notes, err = encryptor.EncryptWithMasterKey([]byte(pd.Notes), pd.masterKey)
+	pd.Notes, err = sealBuffer(pd.Notes, pd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt password %w", op, err)
 	}
@@ -314,12 +473,12 @@ func (pd *PasswordData) Decrypt() error {
 
 	var err error
 
-	pd.Pass, err = encryptor.DecryptWithMasterKey([]byte(pd.Pass), pd.masterKey)
+	pd.Pass, err = openBuffer(pd.Pass, pd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt password %w", op, err)
 	}
 
-	pd.Notes, err = encryptor.DecryptWithMasterKey([]byte(pd.Notes), pd.masterKey)
+	pd.Notes, err = openBuffer(pd.Notes, pd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt notes %w", op, err)
 	}
@@ -333,13 +492,19 @@ func (pd *PasswordData) Decrypt() error {
 	return nil
 }
 
+// Wipe затирание пароля, заметок и метаданных.
+func (pd *PasswordData) Wipe() {
+	pd.Pass.Wipe()
+	pd.baseSecretData.Wipe()
+}
+
 // CardData структура для секрета с данными карты.
 type CardData struct {
 	*baseSecretData
-	Number     string
-	Owner      string
+	Number     *securemem.Buffer
+	Owner      *securemem.Buffer
 	ExpireDate string
-	CVV        string
+	CVV        *securemem.Buffer
 	masterKey  []byte
 }
 
@@ -354,10 +519,10 @@ func NewCardData(
 
 	return &CardData{
 		baseSecretData: base,
-		Number:         number,
-		Owner:          owner,
+		Number:         securemem.FromString(number),
+		Owner:          securemem.FromString(owner),
 		ExpireDate:     expireDate,
-		CVV:            cvv,
+		CVV:            securemem.FromString(cvv),
 		masterKey:      masterKey,
 	}
 }
@@ -365,10 +530,10 @@ func NewCardData(
 func newEmptyCardData() *CardData {
 	return &CardData{
 		baseSecretData: newEmptyBaseSecretData(),
-		Number:         "",
-		Owner:          "",
+		Number:         nil,
+		Owner:          nil,
 		ExpireDate:     "",
-		CVV:            "",
+		CVV:            nil,
 	}
 }
 
@@ -406,7 +571,7 @@ func NewCardSecret(
 
 	secret.setData(data)
 
-	err = secret.Data.Encrypt()
+	err = secret.encryptData(u)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to encrypt CardData %w", op, err)
 	}
@@ -415,9 +580,14 @@ func NewCardSecret(
 }
 
 func (cd *CardData) setDataFromRow(row *sql.Row) error {
-	if err := row.Scan(&cd.Number, &cd.Owner, &cd.ExpireDate, &cd.CVV, &cd.Notes, &cd.MetaData); err != nil {
+	var number, owner, cvv, notes string
+	if err := row.Scan(&number, &owner, &cd.ExpireDate, &cvv, &notes, &cd.MetaData); err != nil {
 		return fmt.Errorf("failed to scan row for password data with error %w", err)
 	}
+	cd.Number = securemem.FromString(number)
+	cd.Owner = securemem.FromString(owner)
+	cd.CVV = securemem.FromString(cvv)
+	cd.Notes = securemem.FromString(notes)
 	cd.Encrypted = true
 	return nil
 }
@@ -428,17 +598,17 @@ func (cd *CardData) Encrypt() error {
 
 	var err error
 
-	cd.Number, err = encryptor.EncryptWithMasterKey([]byte(cd.Number), cd.masterKey)
+	cd.Number, err = sealBuffer(cd.Number, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt card number %w", op, err)
 	}
 
-	cd.Owner, err = encryptor.EncryptWithMasterKey([]byte(cd.Owner), cd.masterKey)
+	cd.Owner, err = sealBuffer(cd.Owner, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt card owner %w", op, err)
 	}
 
-	cd.CVV, err = encryptor.EncryptWithMasterKey([]byte(cd.CVV), cd.masterKey)
+	cd.CVV, err = sealBuffer(cd.CVV, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to encrypt card cvv %w", op, err)
 	}
@@ -459,17 +629,17 @@ func (cd *CardData) Decrypt() error {
 
 	var err error
 
-	cd.Number, err = encryptor.DecryptWithMasterKey([]byte(cd.Number), cd.masterKey)
+	cd.Number, err = openBuffer(cd.Number, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt card number %w", op, err)
 	}
 
-	cd.Owner, err = encryptor.DecryptWithMasterKey([]byte(cd.Owner), cd.masterKey)
+	cd.Owner, err = openBuffer(cd.Owner, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt card owner %w", op, err)
 	}
 
-	cd.CVV, err = encryptor.DecryptWithMasterKey([]byte(cd.CVV), cd.masterKey)
+	cd.CVV, err = openBuffer(cd.CVV, cd.masterKey)
 	if err != nil {
 		return fmt.Errorf("%s: failed to decrypt card cvv %w", op, err)
 	}
@@ -484,19 +654,47 @@ func (cd *CardData) Decrypt() error {
 	return nil
 }
 
+// Wipe затирание данных карты, заметок и метаданных.
+func (cd *CardData) Wipe() {
+	cd.Number.Wipe()
+	cd.Owner.Wipe()
+	cd.CVV.Wipe()
+	cd.baseSecretData.Wipe()
+}
+
+// FileFormat формат хранения содержимого секретного файла.
+type FileFormat string
+
+const (
+	// FileFormatRaw содержимое хранится как есть (зашифровано на клиенте).
+	FileFormatRaw FileFormat = "raw"
+	// FileFormatEnvelope устаревший формат: все содержимое зашифровано одним вызовом AES-GCM и закодировано в base64.
+	FileFormatEnvelope FileFormat = "envelope"
+	// FileFormatStream содержимое зашифровано блоками через encryptor.NewEncryptWriter.
+	FileFormatStream FileFormat = "stream"
+)
+
 // FileData структура для секретных файлов.
+// Содержимое не держится в памяти целиком: новое читается из source при сохранении,
+// сохраненное расшифровывается из файла внешнего хранилища через WriteContent.
 type FileData struct {
 	*baseSecretData
-	Path      string
-	Name      string
+	Path string
+	Name string
+	// Content содержимое, загруженное в память: файлы устаревшего формата envelope
+	// или файлы, загруженные через LoadContent для передачи одним сообщением.
 	Content   []byte
+	Format    FileFormat
+	source    io.Reader
 	masterKey []byte
+	// opened сохраненный файл, открытый для перешифровки, закрывается в Wipe.
+	opened io.Closer
 }
 
 // NewFileData получение новой модели для данных внутри секрета с паролем.
 func NewFileData(
 	path, name string,
-	content []byte,
+	content io.Reader,
 	notes string,
 	metaData []byte,
 	masterKey []byte,
@@ -507,7 +705,8 @@ func NewFileData(
 		baseSecretData: base,
 		Path:           path,
 		Name:           name,
-		Content:        content,
+		Format:         FileFormatRaw,
+		source:         content,
 		masterKey:      masterKey,
 	}
 }
@@ -518,6 +717,7 @@ func newEmptyFileData() *FileData {
 		Path:           "",
 		Name:           "",
 		Content:        nil,
+		Format:         FileFormatRaw,
 	}
 }
 
@@ -531,7 +731,7 @@ func NewFileSecret(
 	u *user.User,
 	secretName,
 	path, name string,
-	content []byte,
+	content io.Reader,
 	notes string,
 	metaData []byte,
 	masterKey []byte,
@@ -544,7 +744,7 @@ func NewFileSecret(
 		err    error
 	)
 
-	if len(content) == 0 {
+	if content == nil {
 		return nil, fmt.Errorf("%s: empty content", op)
 	}
 
@@ -557,7 +757,7 @@ func NewFileSecret(
 
 	secret.setData(data)
 
-	err = secret.Data.Encrypt()
+	err = secret.encryptData(u)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to encrypt CardData %w", op, err)
 	}
@@ -570,10 +770,18 @@ func (fd *FileData) setDataFromRow(row *sql.Row) error {
 
 	var err error
 
-	if err = row.Scan(&fd.Path, &fd.Name); err != nil {
+	if err = row.Scan(&fd.Path, &fd.Name, &fd.Format); err != nil {
 		return fmt.Errorf("failed to scan row for password data with error %w", err)
 	}
 
+	fd.Encrypted = fd.Format != FileFormatRaw
+
+	// Содержимое читается из файла при записи через WriteContent, в память загружается только
+	// устаревший формат envelope: он зашифрован одним вызовом AES-GCM и по частям не расшифровывается
+	if fd.Format != FileFormatEnvelope {
+		return nil
+	}
+
 	fd.Content, err = fd.GetContentFromFile()
 	if err != nil {
 		return fmt.Errorf(
@@ -581,7 +789,6 @@ func (fd *FileData) setDataFromRow(row *sql.Row) error {
 			op, fd.Path, err)
 	}
 
-	fd.Encrypted = true
 	return nil
 }
 
@@ -595,20 +802,27 @@ func (fd *FileData) GetContentFromFile() ([]byte, error) {
 	return content, nil
 }
 
-// Encrypt шифрование данных.
+// ContentReader содержимое нового файла для записи во внешнее хранилище;
+// после Encrypt возвращает шифротекст. Читается один раз.
+func (fd *FileData) ContentReader() io.Reader {
+	return fd.source
+}
+
+// Encrypt шифрование данных. Содержимое шифруется по мере чтения из ContentReader.
 func (fd *FileData) Encrypt() error {
 	op := "domain.service.FileData.encrypt"
 
-	var (
-		contentEnc string
-		err        error
-	)
+	if fd.source == nil {
+		return fmt.Errorf("%s: %w", op, errContentNotLoaded)
+	}
+
+	var err error
 
-	contentEnc, err = encryptor.EncryptWithMasterKey(fd.Content, fd.masterKey)
+	fd.source, err = encryptor.NewEncryptReader(fd.source, fd.masterKey)
 	if err != nil {
-		return fmt.Errorf("%s: failed to encrypt file content %w", op, err)
+		return fmt.Errorf("%s: failed to get encrypt reader %w", op, err)
 	}
-	fd.Content = []byte(contentEnc)
+	fd.Format = FileFormatStream
 
 	err = fd.baseSecretData.Encrypt()
 	if err != nil {
@@ -620,20 +834,20 @@ func (fd *FileData) Encrypt() error {
 	return nil
 }
 
-// Decrypt дешифровка данных.
+// Decrypt дешифровка данных. Потоковое содержимое расшифровывается позже, при записи через WriteContent.
 func (fd *FileData) Decrypt() error {
 	op := "domain.service.FileData.decrypt"
 
-	var (
-		contentDec string
-		err        error
-	)
+	var err error
 
-	contentDec, err = encryptor.DecryptWithMasterKey(fd.Content, fd.masterKey)
-	if err != nil {
-		return fmt.Errorf("%s: failed to decrypt file content %w", op, err)
+	if fd.Format == FileFormatEnvelope {
+		var contentDec string
+		contentDec, err = encryptor.DecryptWithMasterKey(fd.Content, fd.masterKey)
+		if err != nil {
+			return fmt.Errorf("%s: failed to decrypt file content %w", op, err)
+		}
+		fd.Content = []byte(contentDec)
 	}
-	fd.Content = []byte(contentDec)
 
 	err = fd.baseSecretData.Decrypt()
 	if err != nil {
@@ -644,3 +858,102 @@ func (fd *FileData) Decrypt() error {
 
 	return nil
 }
+
+// WriteContent запись расшифрованного содержимого в w. Файл потокового формата расшифровывается
+// блоками прямо из внешнего хранилища, не загружаясь в память целиком.
+func (fd *FileData) WriteContent(w io.Writer) error {
+	op := "domain.service.FileData.WriteContent"
+
+	if fd.Encrypted {
+		return fmt.Errorf("%s: %w", op, errContentEncrypted)
+	}
+
+	if fd.Content != nil {
+		if _, err := w.Write(fd.Content); err != nil {
+			return fmt.Errorf("%s: failed to write file content %w", op, err)
+		}
+		return nil
+	}
+
+	f, src, err := fd.openContent()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err = io.Copy(w, src); err != nil {
+		return fmt.Errorf("%s: failed to write file content %w", op, err)
+	}
+
+	return nil
+}
+
+// openContent открытие сохраненного файла, возвращает файл и его расшифрованное содержимое.
+func (fd *FileData) openContent() (*os.File, io.Reader, error) {
+	f, err := os.Open(fd.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening content file %w", err)
+	}
+
+	if fd.Format != FileFormatStream {
+		return f, f, nil
+	}
+
+	src, err := encryptor.NewDecryptReader(f, fd.masterKey)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("failed to get decrypt reader %w", err)
+	}
+
+	return f, src, nil
+}
+
+// reencrypt перешифровка содержимого сохраненного файла: ContentReader возвращает содержимое,
+// расшифрованное старым ключом и зашифрованное новым, по мере чтения.
+func (fd *FileData) reencrypt(oldKey, newKey []byte) error {
+	fd.setMasterKey(oldKey)
+	if err := fd.Decrypt(); err != nil {
+		return err
+	}
+
+	if fd.Content != nil {
+		fd.source = bytes.NewReader(fd.Content)
+	} else {
+		f, src, err := fd.openContent()
+		if err != nil {
+			return err
+		}
+		fd.source, fd.opened = src, f
+	}
+
+	fd.setMasterKey(newKey)
+
+	return fd.Encrypt()
+}
+
+// LoadContent загрузка расшифрованного содержимого в Content для ответов, которые передают файл
+// одним сообщением. Загруженное содержимое затирается через Wipe.
+func (fd *FileData) LoadContent() error {
+	if fd.Content != nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := fd.WriteContent(&buf); err != nil {
+		return fmt.Errorf("domain.service.FileData.LoadContent: %w", err)
+	}
+	fd.Content = buf.Bytes()
+
+	return nil
+}
+
+// Wipe затирание содержимого файла, заметок и метаданных, закрытие файла, открытого для перешифровки.
+func (fd *FileData) Wipe() {
+	if fd.opened != nil {
+		_ = fd.opened.Close()
+	}
+	securemem.Wipe(fd.Content)
+	fd.baseSecretData.Wipe()
+}
@@ -1,13 +1,21 @@
 package secret_test
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestNewSecret(t *testing.T) {
@@ -63,7 +71,7 @@ func TestNewSecret(t *testing.T) {
 }
 
 func TestNewPasswordSecret(t *testing.T) {
-	u, err := user.NewUser("test", "test", "test")
+	u, err := user.NewUser("test", "test", testHasher(t))
 	require.NoError(t, err)
 
 	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
@@ -125,7 +133,7 @@ func TestNewPasswordSecret(t *testing.T) {
 }
 
 func TestNewCardSecret(t *testing.T) {
-	u, err := user.NewUser("test", "test", "test")
+	u, err := user.NewUser("test", "test", testHasher(t))
 	require.NoError(t, err)
 
 	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
@@ -192,7 +200,7 @@ func TestNewCardSecret(t *testing.T) {
 }
 
 func TestNewFileSecret(t *testing.T) {
-	u, err := user.NewUser("test", "test", "test")
+	u, err := user.NewUser("test", "test", testHasher(t))
 	require.NoError(t, err)
 
 	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
@@ -204,7 +212,7 @@ func TestNewFileSecret(t *testing.T) {
 		user       *user.User
 		path       string
 		fileName   string
-		content    []byte
+		content    io.Reader
 		notes      string
 		wantErr    bool
 	}{
@@ -214,7 +222,7 @@ func TestNewFileSecret(t *testing.T) {
 			user:       u,
 			path:       "path/to/file",
 			fileName:   "iam",
-			content:    []byte("hello world"),
+			content:    bytes.NewReader([]byte("hello world")),
 			notes:      "",
 			wantErr:    false,
 		},
@@ -224,7 +232,7 @@ func TestNewFileSecret(t *testing.T) {
 			user:       u,
 			path:       "path/to/file",
 			fileName:   "iam",
-			content:    make([]byte, 0),
+			content:    nil,
 			notes:      "",
 			wantErr:    true,
 		},
@@ -253,3 +261,189 @@ func TestNewFileSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestFileDataStream(t *testing.T) {
+	u, err := user.NewUser("test", "test", testHasher(t))
+	require.NoError(t, err)
+
+	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	content := bytes.Repeat([]byte("hello world"), 20000)
+
+	cs, err := secret.NewFileSecret(u, "backup", "path/to/file", "backup.tar", bytes.NewReader(content), "", nil, mk)
+	require.NoError(t, err)
+
+	data, ok := cs.Data.(*secret.FileData)
+	require.True(t, ok)
+	assert.Equal(t, secret.FileFormatStream, data.Format)
+
+	data.Path = saveContent(t, data.ContentReader())
+
+	sealed, err := os.ReadFile(data.Path)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(sealed, []byte("hello world")))
+
+	// Зашифрованное содержимое не отдается до расшифровки секрета
+	require.Error(t, data.WriteContent(io.Discard))
+
+	require.NoError(t, cs.DecryptData())
+	assert.Nil(t, data.Content)
+
+	var buf bytes.Buffer
+	require.NoError(t, data.WriteContent(&buf))
+	assert.Equal(t, content, buf.Bytes())
+
+	require.NoError(t, data.LoadContent())
+	assert.Equal(t, content, data.Content)
+}
+
+func TestFileDataStreamMemory(t *testing.T) {
+	const size = 32 << 20
+
+	u, err := user.NewUser("test", "test", testHasher(t))
+	require.NoError(t, err)
+
+	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	plain := sha256.New()
+	source := io.TeeReader(io.LimitReader(patternReader{}, size), plain)
+
+	cs, err := secret.NewFileSecret(u, "backup", "", "backup.tar", source, "", nil, mk)
+	require.NoError(t, err)
+
+	data, ok := cs.Data.(*secret.FileData)
+	require.True(t, ok)
+	data.Path = saveContent(t, data.ContentReader())
+
+	require.NoError(t, cs.DecryptData())
+
+	opened := sha256.New()
+	require.NoError(t, data.WriteContent(opened))
+
+	runtime.ReadMemStats(&after)
+
+	assert.Equal(t, plain.Sum(nil), opened.Sum(nil))
+	// Файл шифруется и расшифровывается блоками: за все время выделено намного меньше его размера
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(size/8))
+}
+
+// patternReader бесконечный источник данных, не занимающий память.
+type patternReader struct{}
+
+func (patternReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(i)
+	}
+
+	return len(p), nil
+}
+
+// saveContent запись содержимого во временный файл, возвращает путь к нему.
+func saveContent(t *testing.T, content io.Reader) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "content")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	_, err = io.Copy(f, content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	return path
+}
+
+func TestSecretReencrypt(t *testing.T) {
+	u, err := user.NewUser("test", "test", testHasher(t))
+	require.NoError(t, err)
+
+	oldKey, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+	newKey := bytes.Repeat([]byte("n"), 32)
+
+	t.Run("password", func(t *testing.T) {
+		ps, err := secret.NewPasswordSecret(u, "github", "login", "pass", "github.com", "notes", nil, oldKey)
+		require.NoError(t, err)
+
+		require.NoError(t, ps.Reencrypt(bytes.Clone(oldKey), bytes.Clone(newKey)))
+
+		data, ok := ps.Data.(*secret.PasswordData)
+		require.True(t, ok)
+		require.NoError(t, ps.DecryptData())
+		assert.Equal(t, "pass", data.Pass.Reveal())
+		assert.Equal(t, "notes", data.Notes.Reveal())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		content := bytes.Repeat([]byte("hello world"), 20000)
+
+		fs, err := secret.NewFileSecret(u, "backup", "", "backup.tar", bytes.NewReader(content), "", nil, oldKey)
+		require.NoError(t, err)
+
+		data, ok := fs.Data.(*secret.FileData)
+		require.True(t, ok)
+		data.Path = saveContent(t, data.ContentReader())
+
+		require.NoError(t, fs.Reencrypt(bytes.Clone(oldKey), bytes.Clone(newKey)))
+		assert.Equal(t, secret.FileFormatStream, data.Format)
+		data.Path = saveContent(t, data.ContentReader())
+
+		require.NoError(t, fs.DecryptData())
+
+		var buf bytes.Buffer
+		require.NoError(t, data.WriteContent(&buf))
+		assert.Equal(t, content, buf.Bytes())
+		fs.Wipe()
+	})
+
+	t.Run("collection secret", func(t *testing.T) {
+		cs := &secret.Secret{CollectionID: 1, Type: secret.TypePassword}
+		require.Error(t, cs.Reencrypt(oldKey, newKey))
+	})
+}
+
+func TestSecretWipe(t *testing.T) {
+	u, err := user.NewUser("test", "test", testHasher(t))
+	require.NoError(t, err)
+
+	mk, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	cs, err := secret.NewCardSecret(u, "card", "4111111111111111", "IVAN IVANOV", "12/30", "123", "pin 0000", nil, mk)
+	require.NoError(t, err)
+
+	data, ok := cs.Data.(*secret.CardData)
+	require.True(t, ok)
+	assert.NotEqual(t, "4111111111111111", data.Number.Reveal())
+
+	require.NoError(t, cs.DecryptData())
+	assert.Equal(t, "4111111111111111", data.Number.Reveal())
+	assert.Equal(t, "123", data.CVV.Reveal())
+	assert.Equal(t, "pin 0000", data.Notes.Reveal())
+
+	number := data.Number.Bytes()
+	cs.Wipe()
+
+	assert.Equal(t, make([]byte, len(number)), number)
+	assert.Zero(t, data.CVV.Len())
+	assert.Zero(t, data.Notes.Len())
+}
+
+// testHasher исходная схема bcrypt с минимальной стоимостью для владельцев секретов в тестах.
+func testHasher(t *testing.T) *passhash.Hasher {
+	t.Helper()
+
+	h, err := passhash.New(
+		passhash.Params{Scheme: passhash.SchemeBcrypt, BcryptCost: bcrypt.MinCost},
+		map[string]string{"": "test"},
+	)
+	require.NoError(t, err)
+
+	return h
+}
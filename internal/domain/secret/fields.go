@@ -0,0 +1,435 @@
+package secret
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+)
+
+// Field поле секрета, режим шифрования которого задается политикой.
+type Field string
+
+const (
+	// FieldName название секрета.
+	FieldName Field = "secret_name"
+	// FieldPasswordUsername имя пользователя в секрете с паролем.
+	FieldPasswordUsername Field = "password_username"
+	// FieldPasswordURL адрес в секрете с паролем.
+	FieldPasswordURL Field = "password_url"
+	// FieldCardExpireDate срок действия карты.
+	FieldCardExpireDate Field = "card_expire_date"
+	// FieldMetaData метаданные секрета.
+	FieldMetaData Field = "metadata"
+	// FieldFileName имя файла в бинарном секрете.
+	FieldFileName Field = "file_name"
+)
+
+// SealedFields набор полей секрета, хранящихся зашифрованными.
+// Признак хранится отдельно от значения: пользовательское значение может начинаться
+// с того же префикса, что и шифротекст FieldCipher.
+type SealedFields uint32
+
+// sealedFieldBits бит признака для каждого поля. Значения сохраняются в БД, поэтому не меняются.
+var sealedFieldBits = map[Field]SealedFields{
+	FieldName:             1 << 0,
+	FieldPasswordUsername: 1 << 1,
+	FieldPasswordURL:      1 << 2,
+	FieldCardExpireDate:   1 << 3,
+	FieldMetaData:         1 << 4,
+	FieldFileName:         1 << 5,
+}
+
+// Has хранится ли поле зашифрованным.
+func (sf SealedFields) Has(field Field) bool {
+	return sf&sealedFieldBits[field] != 0
+}
+
+// With набор с добавленным полем.
+func (sf SealedFields) With(field Field) SealedFields {
+	return sf | sealedFieldBits[field]
+}
+
+func (sf SealedFields) without(field Field) SealedFields {
+	return sf &^ sealedFieldBits[field]
+}
+
+// ErrInvalidMetaData метаданные секрета не являются корректным JSON.
+var ErrInvalidMetaData = errors.New("invalid json meta data")
+
+// FieldEncryption политика шифрования полей секретов, которые не относятся к секретным данным,
+// но не должны храниться в открытом виде (имена, логины, адреса, сроки действия карт).
+type FieldEncryption struct {
+	cipher *encryptor.FieldCipher
+	policy map[Field]encryptor.FieldMode
+}
+
+// NewFieldEncryption получение политики шифрования полей из конфига.
+func NewFieldEncryption(masterKey []byte, cfg config.FieldEncryptionConfig) (*FieldEncryption, error) {
+	op := "domain.secret.NewFieldEncryption"
+
+	fc, err := encryptor.NewFieldCipher(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fe := &FieldEncryption{
+		cipher: fc,
+		policy: make(map[Field]encryptor.FieldMode),
+	}
+
+	for field, mode := range map[Field]string{
+		FieldName:             cfg.SecretName,
+		FieldPasswordUsername: cfg.PasswordUsername,
+		FieldPasswordURL:      cfg.PasswordURL,
+		FieldCardExpireDate:   cfg.CardExpireDate,
+		FieldMetaData:         cfg.MetaData,
+		FieldFileName:         cfg.FileName,
+	} {
+		if fe.policy[field], err = encryptor.ParseFieldMode(mode); err != nil {
+			return nil, fmt.Errorf("%s: field %s: %w", op, field, err)
+		}
+		// Поиск выполняется только по названию секрета, для остальных полей индекс не нужен
+		if field != FieldName && fe.policy[field] == encryptor.FieldModeBlindIndex {
+			return nil, fmt.Errorf("%s: field %s: %w: blind index is supported only for %s",
+				op, field, encryptor.ErrInvalidFieldMode, FieldName)
+		}
+	}
+
+	return fe, nil
+}
+
+// SealValue шифрование значения поля по политике.
+// Для названия секрета в режиме blind_index вторым значением возвращается слепой индекс.
+// Зашифровано ли значение, показывает Seals.
+func (fe *FieldEncryption) SealValue(field Field, userID int, value string) (string, string, error) {
+	return fe.sealValue(field, fieldScope(field, userID), value)
+}
+
+// Seals будет ли значение поля зашифровано по политике: пустые значения и поля
+// в режиме plain хранятся как есть.
+func (fe *FieldEncryption) Seals(field Field, value string) bool {
+	return value != "" && fe.policy[field] != encryptor.FieldModePlain
+}
+
+func (fe *FieldEncryption) sealValue(field Field, scope, value string) (string, string, error) {
+	op := "domain.secret.FieldEncryption.SealValue"
+
+	mode := fe.policy[field]
+	if !fe.Seals(field, value) {
+		return value, "", nil
+	}
+
+	sealed, err := fe.cipher.Seal(value, mode, scope)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: failed to seal %s %w", op, field, err)
+	}
+
+	if mode != encryptor.FieldModeBlindIndex {
+		return sealed, "", nil
+	}
+
+	return sealed, fe.cipher.BlindIndex(value, scope), nil
+}
+
+// OpenValue расшифровка значения поля. Поля, которых нет в sealed, хранятся открытыми
+// и возвращаются как есть.
+func (fe *FieldEncryption) OpenValue(field Field, userID int, value string, sealed SealedFields) (string, error) {
+	return fe.openValue(field, fieldScope(field, userID), value, sealed)
+}
+
+func (fe *FieldEncryption) openValue(field Field, scope, value string, sealed SealedFields) (string, error) {
+	if !sealed.Has(field) {
+		return value, nil
+	}
+
+	opened, err := fe.cipher.Open(value, scope)
+	if err != nil {
+		return "", fmt.Errorf("domain.secret.FieldEncryption.OpenValue: failed to open %s %w", field, err)
+	}
+
+	return opened, nil
+}
+
+// SealMetaData шифрование метаданных. Зашифрованные метаданные хранятся как JSON-строка,
+// чтобы колонка оставалась валидным JSONB.
+func (fe *FieldEncryption) SealMetaData(userID int, metaData []byte) ([]byte, error) {
+	return fe.sealMetaData(fieldScope(FieldMetaData, userID), metaData)
+}
+
+func (fe *FieldEncryption) sealMetaData(scope string, metaData []byte) ([]byte, error) {
+	op := "domain.secret.FieldEncryption.SealMetaData"
+
+	if metaData == nil {
+		return nil, nil
+	}
+	if !json.Valid(metaData) {
+		return nil, ErrInvalidMetaData
+	}
+
+	if !fe.Seals(FieldMetaData, string(metaData)) {
+		return metaData, nil
+	}
+
+	sealed, _, err := fe.sealValue(FieldMetaData, scope, string(metaData))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := json.Marshal(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to marshal sealed metadata %w", op, err)
+	}
+
+	return res, nil
+}
+
+// OpenMetaData расшифровка метаданных, сохраненных через SealMetaData.
+func (fe *FieldEncryption) OpenMetaData(userID int, metaData []byte, sealedFields SealedFields) ([]byte, error) {
+	return fe.openMetaData(fieldScope(FieldMetaData, userID), metaData, sealedFields)
+}
+
+func (fe *FieldEncryption) openMetaData(scope string, metaData []byte, sealedFields SealedFields) ([]byte, error) {
+	op := "domain.secret.FieldEncryption.OpenMetaData"
+
+	if !sealedFields.Has(FieldMetaData) {
+		return metaData, nil
+	}
+
+	var sealed string
+	if err := json.Unmarshal(metaData, &sealed); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidMetaData)
+	}
+
+	opened, err := fe.openValue(FieldMetaData, scope, sealed, sealedFields)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []byte(opened), nil
+}
+
+// NameLookups значения, по которым ищется секрет с заданным названием
+// (открытое, детерминированно зашифрованное и слепой индекс).
+func (fe *FieldEncryption) NameLookups(userID int, name string) ([]string, error) {
+	return fe.nameLookups(fieldScope(FieldName, userID), name)
+}
+
+// CollectionNameLookups значения, по которым ищется секрет коллекции с заданным названием.
+func (fe *FieldEncryption) CollectionNameLookups(collectionID int, name string) ([]string, error) {
+	return fe.nameLookups(collectionScope(FieldName, collectionID), name)
+}
+
+func (fe *FieldEncryption) nameLookups(scope, name string) ([]string, error) {
+	lookups, err := fe.cipher.Lookups(name, scope)
+	if err != nil {
+		return nil, fmt.Errorf("domain.secret.FieldEncryption.NameLookups: %w", err)
+	}
+
+	return lookups, nil
+}
+
+// SealSecret шифрование полей секрета по политике перед сохранением.
+// Зашифрованные поля отмечаются в s.SealedFields, поэтому секрет должен быть открытым.
+// Данные секретов, зашифрованных клиентом, сервер не трогает, шифруется только название.
+func (fe *FieldEncryption) SealSecret(s *Secret) error {
+	op := "domain.secret.FieldEncryption.SealSecret"
+
+	var err error
+
+	if s.Name, s.NameIndex, err = fe.sealField(s, FieldName, s.Name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.ClientEncrypted {
+		return nil
+	}
+
+	switch data := s.Data.(type) {
+	case *PasswordData:
+		if data.Username, _, err = fe.sealField(s, FieldPasswordUsername, data.Username); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if data.URL, _, err = fe.sealField(s, FieldPasswordURL, data.URL); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case *CardData:
+		if data.ExpireDate, _, err = fe.sealField(s, FieldCardExpireDate, data.ExpireDate); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case *FileData:
+		if data.Name, _, err = fe.sealField(s, FieldFileName, data.Name); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if base := s.baseData(); base != nil {
+		sealed := fe.Seals(FieldMetaData, string(base.MetaData))
+		if base.MetaData, err = fe.sealMetaData(secretScope(FieldMetaData, s), base.MetaData); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if sealed {
+			s.SealedFields = s.SealedFields.With(FieldMetaData)
+		}
+	}
+
+	return nil
+}
+
+// sealField шифрование поля секрета с отметкой в s.SealedFields.
+func (fe *FieldEncryption) sealField(s *Secret, field Field, value string) (string, string, error) {
+	sealed, index, err := fe.sealValue(field, secretScope(field, s), value)
+	if err != nil {
+		return "", "", err
+	}
+	if fe.Seals(field, value) {
+		s.SealedFields = s.SealedFields.With(field)
+	}
+
+	return sealed, index, nil
+}
+
+// OpenSecret расшифровка полей секрета после чтения из хранилища.
+// Расшифрованные поля снимаются с s.SealedFields, поэтому повторный вызов безопасен.
+func (fe *FieldEncryption) OpenSecret(s *Secret) error {
+	op := "domain.secret.FieldEncryption.OpenSecret"
+
+	var err error
+
+	if s.Name, err = fe.openField(s, FieldName, s.Name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.ClientEncrypted || s.Data == nil {
+		return nil
+	}
+
+	switch data := s.Data.(type) {
+	case *PasswordData:
+		if data.Username, err = fe.openField(s, FieldPasswordUsername, data.Username); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if data.URL, err = fe.openField(s, FieldPasswordURL, data.URL); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case *CardData:
+		if data.ExpireDate, err = fe.openField(s, FieldCardExpireDate, data.ExpireDate); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case *FileData:
+		if data.Name, err = fe.openField(s, FieldFileName, data.Name); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if base := s.baseData(); base != nil {
+		scope := secretScope(FieldMetaData, s)
+		if base.MetaData, err = fe.openMetaData(scope, base.MetaData, s.SealedFields); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		s.SealedFields = s.SealedFields.without(FieldMetaData)
+	}
+
+	return nil
+}
+
+// openField расшифровка поля секрета, отмеченного в s.SealedFields.
+func (fe *FieldEncryption) openField(s *Secret, field Field, value string) (string, error) {
+	opened, err := fe.openValue(field, secretScope(field, s), value, s.SealedFields)
+	if err != nil {
+		return "", err
+	}
+	s.SealedFields = s.SealedFields.without(field)
+
+	return opened, nil
+}
+
+// DetectSealed зашифровано ли значение поля, сохраненное до появления признака SealedFields.
+// Значение с префиксом шифротекста считается зашифрованным, только если оно расшифровывается
+// в области секрета s: иначе это открытое значение пользователя с таким же префиксом.
+func (fe *FieldEncryption) DetectSealed(field Field, s *Secret, value string) bool {
+	// Зашифрованные метаданные хранятся как JSON-строка
+	if field == FieldMetaData {
+		var sealed string
+		if json.Unmarshal([]byte(value), &sealed) != nil {
+			return false
+		}
+		value = sealed
+	}
+	if !encryptor.IsSealedField(value) {
+		return false
+	}
+
+	_, err := fe.cipher.Open(value, secretScope(field, s))
+
+	return err == nil
+}
+
+// Reseal перешифровка поля секрета, зашифрованного политикой from, ключом и политикой fe,
+// например при смене ключа шифрования полей. s.SealedFields обновляется. Для названия секрета
+// вторым значением возвращается новый слепой индекс. Поля, которые хранятся открытыми, не меняются.
+func (fe *FieldEncryption) Reseal(from *FieldEncryption, s *Secret, field Field, value string) (string, string, error) {
+	op := "domain.secret.FieldEncryption.Reseal"
+
+	if !s.SealedFields.Has(field) {
+		return value, "", nil
+	}
+
+	if field != FieldMetaData {
+		opened, err := from.openField(s, field, value)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		sealed, index, err := fe.sealField(s, field, opened)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		return sealed, index, nil
+	}
+
+	scope := secretScope(field, s)
+	opened, err := from.openMetaData(scope, []byte(value), s.SealedFields)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	s.SealedFields = s.SealedFields.without(field)
+
+	sealed, err := fe.sealMetaData(scope, opened)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	if fe.Seals(field, string(opened)) {
+		s.SealedFields = s.SealedFields.With(field)
+	}
+
+	return string(sealed), "", nil
+}
+
+// Wipe затирание ключей шифрования полей. После затирания политика не используется.
+func (fe *FieldEncryption) Wipe() {
+	fe.cipher.Wipe()
+}
+
+// fieldScope область действия шифротекста: поле конкретного пользователя.
+func fieldScope(field Field, userID int) string {
+	return string(field) + ":" + strconv.Itoa(userID)
+}
+
+// collectionScope область действия шифротекста: поле секретов коллекции организации.
+func collectionScope(field Field, collectionID int) string {
+	return string(field) + ":collection:" + strconv.Itoa(collectionID)
+}
+
+// secretScope область действия шифротекста поля секрета: коллекция или владелец.
+func secretScope(field Field, s *Secret) string {
+	if s.CollectionID != 0 {
+		return collectionScope(field, s.CollectionID)
+	}
+
+	return fieldScope(field, s.UserID)
+}
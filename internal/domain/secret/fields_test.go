@@ -0,0 +1,282 @@
+package secret_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFieldEncryption(t *testing.T) (*secret.FieldEncryption, []byte) {
+	t.Helper()
+
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	fe, err := secret.NewFieldEncryption(md, config.FieldEncryptionConfig{
+		SecretName:       "blind_index",
+		PasswordUsername: "randomized",
+		PasswordURL:      "deterministic",
+		CardExpireDate:   "randomized",
+		MetaData:         "randomized",
+		FileName:         "plain",
+	})
+	require.NoError(t, err)
+
+	return fe, md
+}
+
+func TestNewFieldEncryption(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.FieldEncryptionConfig
+		wantErr bool
+	}{
+		{
+			name: "success",
+			cfg: config.FieldEncryptionConfig{
+				SecretName: "blind_index", PasswordUsername: "randomized", PasswordURL: "deterministic",
+				CardExpireDate: "plain", MetaData: "randomized", FileName: "randomized",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown mode",
+			cfg: config.FieldEncryptionConfig{
+				SecretName: "hashed", PasswordUsername: "randomized", PasswordURL: "randomized",
+				CardExpireDate: "randomized", MetaData: "randomized", FileName: "randomized",
+			},
+			wantErr: true,
+		},
+		{
+			name: "blind index on not searchable field",
+			cfg: config.FieldEncryptionConfig{
+				SecretName: "blind_index", PasswordUsername: "blind_index", PasswordURL: "randomized",
+				CardExpireDate: "randomized", MetaData: "randomized", FileName: "randomized",
+			},
+			wantErr: true,
+		},
+	}
+
+	md := make([]byte, 32)
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := secret.NewFieldEncryption(md, test.cfg)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFieldEncryptionSecret(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+	u := &user.User{ID: 1}
+
+	s, err := secret.NewPasswordSecret(u, "github", "login", "pass", "github.com", "notes", []byte(`{"tag":"work"}`), md)
+	require.NoError(t, err)
+
+	require.NoError(t, fe.SealSecret(s))
+
+	data := s.Data.(*secret.PasswordData)
+	assert.NotEqual(t, "github", s.Name)
+	assert.NotEmpty(t, s.NameIndex)
+	assert.NotEqual(t, "login", data.Username)
+	assert.NotEqual(t, "github.com", data.URL)
+	assert.NotEqual(t, `{"tag":"work"}`, string(data.MetaData))
+
+	lookups, err := fe.NameLookups(u.ID, "github")
+	require.NoError(t, err)
+	assert.Contains(t, lookups, s.NameIndex)
+
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "github", s.Name)
+	assert.Equal(t, "login", data.Username)
+	assert.Equal(t, "github.com", data.URL)
+	assert.JSONEq(t, `{"tag":"work"}`, string(data.MetaData))
+}
+
+func TestFieldEncryptionMovedValue(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+
+	newSealed := func(u *user.User) *secret.Secret {
+		s, err := secret.NewPasswordSecret(u, "github", "login", "pass", "github.com", "", nil, md)
+		require.NoError(t, err)
+		require.NoError(t, fe.SealSecret(s))
+		return s
+	}
+
+	t.Run("another field", func(t *testing.T) {
+		s := newSealed(&user.User{ID: 1})
+		s.Name = s.Data.(*secret.PasswordData).Username
+
+		require.Error(t, fe.OpenSecret(s))
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		s, other := newSealed(&user.User{ID: 1}), newSealed(&user.User{ID: 2})
+		other.Data.(*secret.PasswordData).Username = s.Data.(*secret.PasswordData).Username
+
+		require.Error(t, fe.OpenSecret(other))
+	})
+}
+
+func TestFieldEncryptionReseal(t *testing.T) {
+	legacy, md := newTestFieldEncryption(t)
+	u := &user.User{ID: 1}
+
+	fe, err := secret.NewFieldEncryption(bytes.Repeat([]byte("f"), 32), config.FieldEncryptionConfig{
+		SecretName:       "blind_index",
+		PasswordUsername: "randomized",
+		PasswordURL:      "plain",
+		CardExpireDate:   "randomized",
+		MetaData:         "randomized",
+		FileName:         "plain",
+	})
+	require.NoError(t, err)
+
+	s, err := secret.NewPasswordSecret(u, "github", "login", "pass", "github.com", "", []byte(`{"tag":"work"}`), md)
+	require.NoError(t, err)
+	require.NoError(t, legacy.SealSecret(s))
+
+	data := s.Data.(*secret.PasswordData)
+	oldIndex := s.NameIndex
+
+	s.Name, s.NameIndex, err = fe.Reseal(legacy, s, secret.FieldName, s.Name)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldIndex, s.NameIndex)
+
+	data.Username, _, err = fe.Reseal(legacy, s, secret.FieldPasswordUsername, data.Username)
+	require.NoError(t, err)
+
+	// Поле, которое по новой политике хранится открытым, снимается с SealedFields
+	data.URL, _, err = fe.Reseal(legacy, s, secret.FieldPasswordURL, data.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com", data.URL)
+	assert.False(t, s.SealedFields.Has(secret.FieldPasswordURL))
+
+	meta, _, err := fe.Reseal(legacy, s, secret.FieldMetaData, string(data.MetaData))
+	require.NoError(t, err)
+	data.MetaData = []byte(meta)
+
+	lookups, err := fe.NameLookups(u.ID, "github")
+	require.NoError(t, err)
+	assert.Contains(t, lookups, s.NameIndex)
+
+	_, err = legacy.OpenValue(secret.FieldName, u.ID, s.Name, s.SealedFields)
+	require.Error(t, err)
+
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "github", s.Name)
+	assert.Equal(t, "login", data.Username)
+	assert.JSONEq(t, `{"tag":"work"}`, string(data.MetaData))
+}
+
+func TestFieldEncryptionMetaData(t *testing.T) {
+	fe, _ := newTestFieldEncryption(t)
+
+	_, err := fe.SealMetaData(1, []byte("not json"))
+	require.ErrorIs(t, err, secret.ErrInvalidMetaData)
+
+	plain, err := fe.OpenMetaData(1, []byte(`{"tag":"work"}`), 0)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tag":"work"}`, string(plain))
+}
+
+func TestFieldEncryptionPrefixedValues(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+	u := &user.User{ID: 1}
+
+	// Значения пользователя с префиксом шифротекста шифруются, а в режиме plain остаются открытыми
+	s, err := secret.NewPasswordSecret(u, "enc1:github", "enc1:login", "pass", "det1:github.com", "", nil, md)
+	require.NoError(t, err)
+	s.Data.(*secret.PasswordData).MetaData = []byte(`"enc1:note"`)
+
+	require.NoError(t, fe.SealSecret(s))
+
+	data := s.Data.(*secret.PasswordData)
+	assert.NotEqual(t, "enc1:github", s.Name)
+	assert.NotEqual(t, "enc1:login", data.Username)
+	assert.NotEqual(t, "det1:github.com", data.URL)
+	assert.True(t, s.SealedFields.Has(secret.FieldName))
+	assert.True(t, s.SealedFields.Has(secret.FieldPasswordURL))
+
+	lookups, err := fe.NameLookups(u.ID, "enc1:github")
+	require.NoError(t, err)
+	assert.Contains(t, lookups, s.NameIndex)
+
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "enc1:github", s.Name)
+	assert.Equal(t, "enc1:login", data.Username)
+	assert.Equal(t, "det1:github.com", data.URL)
+	assert.JSONEq(t, `"enc1:note"`, string(data.MetaData))
+	assert.Zero(t, s.SealedFields)
+
+	// Повторное открытие не трогает уже открытые поля
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "enc1:github", s.Name)
+}
+
+func TestFieldEncryptionPlainPrefixedValue(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+
+	s, err := secret.NewFileSecret(
+		&user.User{ID: 1}, "report", "", "enc1:report.pdf", strings.NewReader("data"), "", nil, md,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, fe.SealSecret(s))
+	assert.Equal(t, "enc1:report.pdf", s.Data.(*secret.FileData).Name)
+	assert.False(t, s.SealedFields.Has(secret.FieldFileName))
+
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "enc1:report.pdf", s.Data.(*secret.FileData).Name)
+}
+
+func TestFieldEncryptionDetectSealed(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+	u := &user.User{ID: 1}
+
+	s, err := secret.NewPasswordSecret(u, "github", "login", "pass", "github.com", "", []byte(`{"tag":"work"}`), md)
+	require.NoError(t, err)
+	require.NoError(t, fe.SealSecret(s))
+
+	data := s.Data.(*secret.PasswordData)
+	assert.True(t, fe.DetectSealed(secret.FieldName, s, s.Name))
+	assert.True(t, fe.DetectSealed(secret.FieldPasswordURL, s, data.URL))
+	assert.True(t, fe.DetectSealed(secret.FieldMetaData, s, string(data.MetaData)))
+	assert.False(t, fe.DetectSealed(secret.FieldName, s, "enc1:github"))
+	assert.False(t, fe.DetectSealed(secret.FieldMetaData, s, `{"tag":"work"}`))
+}
+
+func TestFieldEncryptionCollectionSecret(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+
+	s, err := secret.NewPasswordSecret(&user.User{ID: 1}, "db", "root", "pass", "db.local", "", nil, md)
+	require.NoError(t, err)
+	s.UserID, s.CollectionID = 0, 7
+
+	require.NoError(t, fe.SealSecret(s))
+
+	// Название секрета коллекции ищется в области коллекции, а не автора
+	lookups, err := fe.CollectionNameLookups(7, "db")
+	require.NoError(t, err)
+	assert.Contains(t, lookups, s.NameIndex)
+
+	userLookups, err := fe.NameLookups(1, "db")
+	require.NoError(t, err)
+	assert.NotContains(t, userLookups, s.NameIndex)
+
+	require.NoError(t, fe.OpenSecret(s))
+	assert.Equal(t, "db", s.Name)
+	assert.Equal(t, "db.local", s.Data.(*secret.PasswordData).URL)
+}
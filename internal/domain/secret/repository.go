@@ -5,9 +5,32 @@ import "context"
 // Repository интерфейс для репозитория секретов.
 type Repository interface {
 	// SaveSecret сохраняет новый секрет в базу данных возвращая ошибку или её отсутствие.
+	// Для секрета коллекции ErrKeyRotated, если ключ коллекции сменился.
 	SaveSecret(ctx context.Context, secret *Secret) error
 	// GetSecretsByName поиск среди всех секретов по названию секрета.
-	GetSecretsByName(ctx context.Context, secretName string, userID int) ([]*Secret, error)
+	// nameLookups варианты хранимого представления названия (см. FieldEncryption.NameLookups).
+	GetSecretsByName(ctx context.Context, nameLookups []string, userID int) ([]*Secret, error)
 	// GetAllUserSecrets получение всех секретов пользователя (без данных).
 	GetAllUserSecrets(ctx context.Context, userID int) ([]*Secret, error)
+	// ExportUserSecrets получение всех секретов пользователя вместе с данными.
+	ExportUserSecrets(ctx context.Context, userID int) ([]*Secret, error)
+	// GetUserSecretsByTypes получение секретов пользователя указанных типов вместе с данными.
+	GetUserSecretsByTypes(ctx context.Context, userID int, types ...TypeOfSecret) ([]*Secret, error)
+	// GetSecretByID секрет пользователя без данных, ErrSecretNotFound если секрета нет или он чужой.
+	GetSecretByID(ctx context.Context, id, userID int) (*Secret, error)
+	// LoadSecretsData чтение данных секретов.
+	LoadSecretsData(ctx context.Context, secrets []*Secret) error
+	// SaveShare сохранение доступа, доступ того же получателя к тому же секрету заменяется.
+	SaveShare(ctx context.Context, share *Share) error
+	// DeleteShare удаление доступа, если userID его владелец или получатель, иначе ErrShareNotFound.
+	DeleteShare(ctx context.Context, id, userID int) error
+	// ListSharesByOwner доступы к секретам владельца с названиями секретов и логинами получателей.
+	ListSharesByOwner(ctx context.Context, ownerID int) ([]*Share, error)
+	// GetSharedSecrets чужие секреты, доступные получателю, без данных и с заполненным Access.
+	GetSharedSecrets(ctx context.Context, recipientID int) ([]*Secret, error)
+	// GetCollectionSecrets секреты коллекции без данных. Если nameLookups не nil, только с этим названием.
+	GetCollectionSecrets(ctx context.Context, collectionID int, nameLookups []string) ([]*Secret, error)
+	// DeleteCollectionSecret удаление секрета коллекции вместе с его файлом, ErrSecretNotFound если
+	// в коллекции нет такого секрета.
+	DeleteCollectionSecret(ctx context.Context, collectionID, secretID int) error
 }
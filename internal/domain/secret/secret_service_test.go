@@ -0,0 +1,206 @@
+package secret_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSecretRepo реализует Repository в памяти для тестирования: остальные методы не вызываются.
+type memSecretRepo struct {
+	secret.Repository
+	secrets []*secret.Secret
+}
+
+func (m *memSecretRepo) GetUserSecretsByTypes(
+	_ context.Context,
+	userID int,
+	_ ...secret.TypeOfSecret,
+) ([]*secret.Secret, error) {
+	var res []*secret.Secret
+	for _, s := range m.secrets {
+		if s.UserID == userID {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (m *memSecretRepo) GetSharedSecrets(_ context.Context, recipientID int) ([]*secret.Secret, error) {
+	var res []*secret.Secret
+	for _, s := range m.secrets {
+		if s.UserID != recipientID {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (m *memSecretRepo) ExportUserSecrets(ctx context.Context, userID int) ([]*secret.Secret, error) {
+	return m.GetUserSecretsByTypes(ctx, userID)
+}
+
+func (m *memSecretRepo) LoadSecretsData(context.Context, []*secret.Secret) error {
+	return nil
+}
+
+// lockedSecrets реализует RevealApproval, блокируя секреты с указанными ID.
+type lockedSecrets map[int]bool
+
+func (l lockedSecrets) LockedSecrets(_ context.Context, _ int, secretIDs []int) (map[int]bool, error) {
+	res := make(map[int]bool)
+	for _, id := range secretIDs {
+		if l[id] {
+			res[id] = true
+		}
+	}
+	return res, nil
+}
+
+// lockedFor реализует RevealApproval с заблокированными секретами для каждого пользователя.
+type lockedFor map[int]lockedSecrets
+
+func (l lockedFor) LockedSecrets(ctx context.Context, userID int, secretIDs []int) (map[int]bool, error) {
+	return l[userID].LockedSecrets(ctx, userID, secretIDs)
+}
+
+// staticDataKeys реализует DataKeys с общим ключом всех пользователей.
+type staticDataKeys []byte
+
+func (k staticDataKeys) UserKey(context.Context, int) (*securemem.Buffer, error) {
+	return securemem.New(bytes.Clone(k)), nil
+}
+
+// recordingAuditor реализует Auditor, запоминая записи журнала.
+type recordingAuditor struct {
+	events []*audit.Event
+}
+
+func (r *recordingAuditor) Record(_ context.Context, events ...*audit.Event) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func TestService_GetUserCredentials(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+	cfg := &config.Config{}
+
+	u := &user.User{ID: 1, Login: "john"}
+	repo := &memSecretRepo{}
+	for id, name := range map[int]string{1: "open", 2: "locked"} {
+		s, err := secret.NewPasswordSecret(u, name, "john", "qwerty", "https://example.com", "", nil, md)
+		require.NoError(t, err)
+		s.ID = id
+		require.NoError(t, fe.SealSecret(s))
+		repo.secrets = append(repo.secrets, s)
+	}
+
+	auditor := &recordingAuditor{}
+	s := secret.NewService(repo, cfg, fe, staticDataKeys(md), nil, lockedSecrets{2: true}, auditor)
+
+	secrets, err := s.GetUserCredentials(context.Background(), u)
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+
+	results := make(map[int]audit.Result)
+	for _, ev := range auditor.events {
+		assert.Equal(t, audit.ActionRead, ev.Action)
+		assert.Equal(t, u.ID, ev.ActorID)
+		results[ev.SecretID] = ev.Result
+	}
+	assert.Equal(t, map[int]audit.Result{1: audit.ResultSuccess, 2: audit.ResultFailure}, results)
+
+	for _, sec := range secrets {
+		if sec.ID == 2 {
+			assert.True(t, sec.ApprovalRequired)
+			assert.Nil(t, sec.Data)
+			continue
+		}
+		data, ok := sec.Data.(*secret.PasswordData)
+		require.True(t, ok)
+		assert.Equal(t, "qwerty", data.Pass.Reveal())
+		assert.Equal(t, "open", sec.Name)
+	}
+}
+
+func TestService_ListSharedWithMeAudit(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+
+	owner := &user.User{ID: 1, Login: "john"}
+	recipient := &user.User{ID: 2, Login: "jane"}
+	shared, err := secret.NewPasswordSecret(owner, "shared", "john", "qwerty", "", "", nil, md)
+	require.NoError(t, err)
+	shared.ID = 7
+	require.NoError(t, fe.SealSecret(shared))
+
+	auditor := &recordingAuditor{}
+	repo := &memSecretRepo{secrets: []*secret.Secret{shared}}
+	s := secret.NewService(repo, &config.Config{}, fe, staticDataKeys(md), nil, lockedSecrets{}, auditor)
+
+	secrets, err := s.ListSharedWithMe(context.Background(), recipient)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+
+	// Чтение записывается на получателя, но остается в журнале владельца
+	require.Len(t, auditor.events, 1)
+	ev := auditor.events[0]
+	assert.Equal(t, audit.ActionRead, ev.Action)
+	assert.Equal(t, recipient.ID, ev.ActorID)
+	assert.Equal(t, recipient.Login, ev.ActorLogin)
+	assert.Equal(t, owner.ID, ev.SubjectID)
+	assert.Equal(t, shared.ID, ev.SecretID)
+}
+
+func TestService_ExportVault(t *testing.T) {
+	fe, md := newTestFieldEncryption(t)
+
+	owner := &user.User{ID: 1, Login: "john"}
+	contact := &user.User{ID: 2, Login: "jane"}
+
+	testCases := []struct {
+		name       string
+		reader     *user.User
+		locked     lockedFor
+		wantLocked bool
+	}{
+		{name: "owner export", reader: owner, locked: lockedFor{contact.ID: {7: true}}},
+		// Одобренный запрос владельца не открывает секрет контакту
+		{name: "contact without approval", reader: contact, locked: lockedFor{contact.ID: {7: true}}, wantLocked: true},
+		{name: "contact with approval", reader: contact, locked: lockedFor{owner.ID: {7: true}}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			stored, err := secret.NewPasswordSecret(owner, "bank", "john", "qwerty", "", "", nil, md)
+			require.NoError(t, err)
+			stored.ID = 7
+			require.NoError(t, fe.SealSecret(stored))
+
+			auditor := &recordingAuditor{}
+			repo := &memSecretRepo{secrets: []*secret.Secret{stored}}
+			s := secret.NewService(repo, &config.Config{}, fe, staticDataKeys(md), nil, test.locked, auditor)
+
+			secrets, err := s.ExportVault(context.Background(), owner, test.reader)
+			require.NoError(t, err)
+			require.Len(t, secrets, 1)
+			assert.Equal(t, test.wantLocked, secrets[0].ApprovalRequired)
+			if test.wantLocked {
+				assert.Nil(t, secrets[0].Data)
+			}
+
+			// Чтение записывается на читателя и остается в журнале владельца
+			require.Len(t, auditor.events, 1)
+			ev := auditor.events[0]
+			assert.Equal(t, test.reader.ID, ev.ActorID)
+			assert.Equal(t, owner.ID, ev.SubjectID)
+		})
+	}
+}
@@ -4,25 +4,52 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
 )
 
-// ErrSecretNotFound секрет не найден.
-var ErrSecretNotFound = errors.New("secret not found")
+var (
+	// ErrSecretNotFound секрет не найден.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrClientEncrypted данные секрета зашифрованы клиентом, и сервер не может их прочитать.
+	ErrClientEncrypted = errors.New("secret data is encrypted by the client")
+)
 
 // Service структура сервиса.
 type Service struct {
-	repo Repository
-	cfg  *config.Config
+	repo        Repository
+	cfg         *config.Config
+	fields      *FieldEncryption
+	keys        DataKeys
+	collections CollectionAccess
+	approvals   RevealApproval
+	auditor     Auditor
 }
 
 // NewService получение сервиса для секретов.
-func NewService(r Repository, c *config.Config) *Service {
+// keys ключи данных пользователей, ca проверка прав в коллекциях организаций,
+// ra проверка одобрения доступа к критичным секретам,
+// auditor журнал аудита созданий, чтений и передачи секретов (nil отключает журнал).
+func NewService(
+	r Repository,
+	c *config.Config,
+	fe *FieldEncryption,
+	keys DataKeys,
+	ca CollectionAccess,
+	ra RevealApproval,
+	auditor Auditor,
+) *Service {
 	return &Service{
-		repo: r,
-		cfg:  c,
+		repo:        r,
+		cfg:         c,
+		fields:      fe,
+		keys:        keys,
+		collections: ca,
+		approvals:   ra,
+		auditor:     auditor,
 	}
 }
 
@@ -40,14 +67,20 @@ func (s *Service) CreateSecretPassword(
 		err    error
 	)
 
-	secret, err = NewPasswordSecret(u, secretName, username, password, url, notes, metaData, s.cfg.Security.MasterKey)
+	key, err := s.keys.UserKey(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user data key %w", op, err)
+	}
+	defer key.Wipe()
+
+	secret, err = NewPasswordSecret(u, secretName, username, password, url, notes, metaData, key.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get new domain model for password secret %w", op, err)
 	}
 
-	err = s.repo.SaveSecret(ctx, secret)
+	err = s.saveSecret(ctx, u, secret)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to save secret on storage with error %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return secret, nil
@@ -67,14 +100,20 @@ func (s *Service) CreateSecretCard(
 		err    error
 	)
 
-	secret, err = NewCardSecret(u, secretName, number, owner, expireDate, cvv, notes, metaData, s.cfg.Security.MasterKey)
+	key, err := s.keys.UserKey(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user data key %w", op, err)
+	}
+	defer key.Wipe()
+
+	secret, err = NewCardSecret(u, secretName, number, owner, expireDate, cvv, notes, metaData, key.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get new domain model for card secret %w", op, err)
 	}
 
-	err = s.repo.SaveSecret(ctx, secret)
+	err = s.saveSecret(ctx, u, secret)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to save secret on storage with error %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return secret, nil
@@ -85,7 +124,8 @@ func (s *Service) CreateSecretFile(
 	ctx context.Context,
 	u *user.User,
 	secretName, fileName, notes string,
-	content, metaData []byte,
+	content io.Reader,
+	metaData []byte,
 ) (*Secret, error) {
 	op := "domain.service.CreateSecretFile"
 
@@ -94,6 +134,13 @@ func (s *Service) CreateSecretFile(
 		err    error
 	)
 
+	// Содержимое шифруется по мере записи во внешнее хранилище, поэтому ключ нужен до сохранения секрета
+	key, err := s.keys.UserKey(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user data key %w", op, err)
+	}
+	defer key.Wipe()
+
 	secret, err = NewFileSecret(
 		u,
 		secretName,
@@ -102,21 +149,21 @@ func (s *Service) CreateSecretFile(
 		content,
 		notes,
 		metaData,
-		s.cfg.Security.MasterKey,
+		key.Bytes(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get new domain model for file secret %w", op, err)
 	}
 
-	err = s.repo.SaveSecret(ctx, secret)
+	err = s.saveSecret(ctx, u, secret)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to save secret on storage with error %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return secret, nil
 }
 
-// GetSecretsByName получить секреты по названию.
+// GetSecretsByName получить секреты по названию, включая открытые пользователю чужие секреты.
 func (s *Service) GetSecretsByName(
 	ctx context.Context,
 	u *user.User,
@@ -129,26 +176,42 @@ func (s *Service) GetSecretsByName(
 		err     error
 	)
 
-	secrets, err = s.repo.GetSecretsByName(ctx, secretName, u.ID)
+	lookups, err := s.fields.NameLookups(u.ID, secretName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get name lookups %w", op, err)
+	}
+
+	secrets, err = s.repo.GetSecretsByName(ctx, lookups, u.ID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: unable to get secret by name %s with error %w", op, secretName, err)
 	}
 
+	if err = s.withholdLocked(ctx, u.ID, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.openSecrets(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	shared, err := s.sharedSecrets(ctx, u, secretName, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	secrets = append(secrets, shared...)
+
 	if len(secrets) == 0 {
 		return nil, ErrSecretNotFound
 	}
 
-	for _, secret := range secrets {
-		secret.Data.setMasterKey(s.cfg.Security.MasterKey)
-		err = secret.DecryptData()
-		if err != nil {
-			return nil, fmt.Errorf("%s: failed to decrypt data with error %w", op, err)
-		}
+	if err = s.recordReads(ctx, u, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return secrets, nil
 }
 
+// GetAllUserSecrets получение всех секретов пользователя без данных, включая открытые ему чужие секреты.
 func (s *Service) GetAllUserSecrets(ctx context.Context, u *user.User) ([]*Secret, error) {
 	op := "domain.Secret.service.GetAllUserService"
 
@@ -162,5 +225,152 @@ func (s *Service) GetAllUserSecrets(ctx context.Context, u *user.User) ([]*Secre
 		return nil, fmt.Errorf("%s: failed to get all secrets with error %w", op, err)
 	}
 
+	for _, secret := range secrets {
+		if err = s.fields.OpenSecret(secret); err != nil {
+			return nil, fmt.Errorf("%s: failed to open secret fields with error %w", op, err)
+		}
+	}
+
+	shared, err := s.sharedSecrets(ctx, u, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return append(secrets, shared...), nil
+}
+
+// ExportUserSecrets выгрузка всех секретов пользователя с расшифрованными данными, например, перед удалением аккаунта.
+// Данные, зашифрованные клиентом, возвращаются как есть, данные секретов без одобренного доступа скрываются.
+func (s *Service) ExportUserSecrets(ctx context.Context, u *user.User) ([]*Secret, error) {
+	return s.ExportVault(ctx, u, u)
+}
+
+// ExportVault выгрузка всех секретов owner с расшифрованными данными для reader, например, доверенного контакта.
+// Одобрение доступа проверяется для reader, чтение записывается в журнал от его имени.
+func (s *Service) ExportVault(ctx context.Context, owner, reader *user.User) ([]*Secret, error) {
+	op := "domain.Secret.service.ExportVault"
+
+	secrets, err := s.repo.ExportUserSecrets(ctx, owner.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to export secrets with error %w", op, err)
+	}
+
+	if err = s.withholdLocked(ctx, reader.ID, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.openSecrets(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordReads(ctx, reader, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return secrets, nil
 }
+
+// GetSecret собственный секрет пользователя с расшифрованными данными, например, для одноразовой ссылки.
+// ErrClientEncrypted, если данные зашифрованы клиентом, ErrApprovalRequired, если нет одобренного доступа.
+func (s *Service) GetSecret(ctx context.Context, u *user.User, id int) (*Secret, error) {
+	op := "domain.Secret.service.GetSecret"
+
+	secret, err := s.repo.GetSecretByID(ctx, id, u.ID)
+	if err != nil {
+		if errors.Is(err, ErrSecretNotFound) {
+			return nil, s.record(ctx, u, audit.ActionRead, id, ErrSecretNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get secret %w", op, err)
+	}
+
+	if secret.ClientEncrypted {
+		return nil, ErrClientEncrypted
+	}
+
+	secrets := []*Secret{secret}
+	if err = s.withholdLocked(ctx, u.ID, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if secret.ApprovalRequired {
+		return nil, s.record(ctx, u, audit.ActionRead, id, ErrApprovalRequired)
+	}
+
+	if err = s.repo.LoadSecretsData(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: failed to load secret data %w", op, err)
+	}
+
+	if err = s.openSecrets(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.record(ctx, u, audit.ActionRead, id, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, nil
+}
+
+// GetUserCredentials секреты-пароли и банковские карты пользователя с расшифрованными данными,
+// например, для отчета о безопасности хранилища. Данные, зашифрованные клиентом, возвращаются как есть,
+// данные секретов без одобренного доступа скрываются.
+func (s *Service) GetUserCredentials(ctx context.Context, u *user.User) ([]*Secret, error) {
+	op := "domain.Secret.service.GetUserCredentials"
+
+	secrets, err := s.repo.GetUserSecretsByTypes(ctx, u.ID, TypePassword, TypeCard)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get secrets with error %w", op, err)
+	}
+
+	if err = s.withholdLocked(ctx, u.ID, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.openSecrets(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordReads(ctx, u, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secrets, nil
+}
+
+// openSecrets расшифровка данных и полей секретов, прочитанных из хранилища. Скрытые данные пропускаются.
+// Данные расшифровываются ключами данных их владельцев.
+func (s *Service) openSecrets(ctx context.Context, secrets []*Secret) error {
+	keys := newOwnerKeys(s.keys)
+	defer keys.Wipe()
+
+	for _, secret := range secrets {
+		if secret.Data != nil && !secret.ClientEncrypted {
+			key, err := keys.keyFor(ctx, secret)
+			if err != nil {
+				return err
+			}
+			secret.Data.setMasterKey(key)
+			if err = secret.DecryptData(); err != nil {
+				return fmt.Errorf("failed to decrypt data with error %w", err)
+			}
+		}
+
+		if err := s.fields.OpenSecret(secret); err != nil {
+			return fmt.Errorf("failed to open secret fields with error %w", err)
+		}
+	}
+
+	return nil
+}
+
+// saveSecret шифрование полей секрета по политике, сохранение в хранилище и запись создания в журнал аудита.
+func (s *Service) saveSecret(ctx context.Context, u *user.User, secret *Secret) error {
+	if err := s.fields.SealSecret(secret); err != nil {
+		return fmt.Errorf("failed to seal secret fields with error %w", err)
+	}
+
+	if err := s.repo.SaveSecret(ctx, secret); err != nil {
+		return fmt.Errorf("failed to save secret on storage with error %w", err)
+	}
+
+	return s.record(ctx, u, audit.ActionCreate, secret.ID, nil)
+}
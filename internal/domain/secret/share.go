@@ -0,0 +1,222 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+)
+
+// Permission права получателя на общий секрет.
+type Permission string
+
+// PermissionRead только чтение. Других прав нет: изменить общий секрет может только владелец.
+const PermissionRead Permission = "read"
+
+// Valid можно ли выдать такие права.
+func (p Permission) Valid() bool {
+	return p == PermissionRead
+}
+
+var (
+	// ErrShareNotFound общий доступ не найден.
+	ErrShareNotFound = errors.New("share not found")
+	// ErrShareToSelf секрет нельзя открыть самому себе.
+	ErrShareToSelf = errors.New("cannot share a secret with yourself")
+	// ErrInvalidPermission неизвестные права доступа.
+	ErrInvalidPermission = errors.New("invalid share permission")
+	// ErrShareKeyRequired для секрета, зашифрованного клиентом, нужны ключ доступа и перешифрованные данные.
+	ErrShareKeyRequired = errors.New("client encrypted secret requires a wrapped key and payload")
+	// ErrRecipientHasNoKeys у получателя нет пары ключей, поэтому секрет E2E для него не зашифровать.
+	ErrRecipientHasNoKeys = errors.New("recipient has no share keys")
+)
+
+// Share доступ получателя к чужому секрету.
+// Секреты с серверным шифрованием сервер расшифровывает для получателя сам. Для секретов E2E клиент
+// владельца перешифровывает данные случайным ключом доступа (Payload) и шифрует этот ключ открытым
+// ключом получателя (WrappedKey); сервер хранит оба значения как есть.
+// SecretName, SecretSealed, SecretType, OwnerLogin и RecipientLogin заполняются при чтении списков.
+type Share struct {
+	ID          int
+	SecretID    int
+	OwnerID     int
+	RecipientID int
+	Permission  Permission
+	WrappedKey  []byte
+	Payload     []byte
+	CreatedAt   time.Time
+
+	SecretName     string
+	SecretSealed   SealedFields
+	SecretType     TypeOfSecret
+	OwnerLogin     string
+	RecipientLogin string
+}
+
+// NewShare новый доступ recipient к секрету s пользователя owner.
+func NewShare(
+	owner *user.User,
+	s *Secret,
+	recipient *user.User,
+	perm Permission,
+	wrappedKey, payload []byte,
+) (*Share, error) {
+	switch {
+	case s.UserID != owner.ID:
+		return nil, ErrSecretNotFound
+	case recipient.ID == owner.ID:
+		return nil, ErrShareToSelf
+	case !perm.Valid():
+		return nil, ErrInvalidPermission
+	}
+
+	sh := Share{
+		SecretID:    s.ID,
+		OwnerID:     owner.ID,
+		RecipientID: recipient.ID,
+		Permission:  perm,
+		CreatedAt:   time.Now(),
+	}
+
+	if s.ClientEncrypted {
+		if len(wrappedKey) == 0 || len(payload) == 0 {
+			return nil, ErrShareKeyRequired
+		}
+		if !recipient.HasShareKeys() {
+			return nil, ErrRecipientHasNoKeys
+		}
+		sh.WrappedKey, sh.Payload = wrappedKey, payload
+	}
+
+	return &sh, nil
+}
+
+// ShareSecret открыть секрет secretID пользователя owner получателю recipient. Повторный вызов для того же
+// получателя заменяет права и ключ доступа.
+func (s *Service) ShareSecret(
+	ctx context.Context,
+	owner *user.User,
+	secretID int,
+	recipient *user.User,
+	perm Permission,
+	wrappedKey, payload []byte,
+) (*Share, error) {
+	op := "domain.Secret.service.ShareSecret"
+
+	secret, err := s.repo.GetSecretByID(ctx, secretID, owner.ID)
+	if err != nil {
+		if errors.Is(err, ErrSecretNotFound) {
+			return nil, ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("%s: failed to get secret %w", op, err)
+	}
+
+	sh, err := NewShare(owner, secret, recipient, perm, wrappedKey, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.SaveShare(ctx, sh); err != nil {
+		return nil, fmt.Errorf("%s: failed to save share %w", op, err)
+	}
+
+	if err = s.record(ctx, owner, audit.ActionShare, secretID, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sh, nil
+}
+
+// RevokeShare отзыв доступа. Отозвать доступ может владелец секрета, а получатель отказаться от него.
+func (s *Service) RevokeShare(ctx context.Context, userID, shareID int) error {
+	if err := s.repo.DeleteShare(ctx, shareID, userID); err != nil {
+		if errors.Is(err, ErrShareNotFound) {
+			return ErrShareNotFound
+		}
+		return fmt.Errorf("domain.Secret.service.RevokeShare: %w", err)
+	}
+
+	return nil
+}
+
+// ListMyShares доступы, выданные пользователем к его секретам.
+func (s *Service) ListMyShares(ctx context.Context, u *user.User) ([]*Share, error) {
+	op := "domain.Secret.service.ListMyShares"
+
+	shares, err := s.repo.ListSharesByOwner(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, sh := range shares {
+		if sh.SecretName, err = s.fields.OpenValue(FieldName, u.ID, sh.SecretName, sh.SecretSealed); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return shares, nil
+}
+
+// ListSharedWithMe секреты других пользователей, доступные пользователю, вместе с данными.
+func (s *Service) ListSharedWithMe(ctx context.Context, u *user.User) ([]*Secret, error) {
+	secrets, err := s.sharedSecrets(ctx, u, "", true)
+	if err != nil {
+		return nil, fmt.Errorf("domain.Secret.service.ListSharedWithMe: %w", err)
+	}
+
+	if err = s.recordReads(ctx, u, secrets); err != nil {
+		return nil, fmt.Errorf("domain.Secret.service.ListSharedWithMe: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// sharedSecrets чужие секреты, доступные пользователю, с названием name (все, если name пустое).
+// Данные секретов E2E не загружаются: получатель читает их из Share.Payload. Данные секретов
+// без одобренного доступа скрываются.
+func (s *Service) sharedSecrets(ctx context.Context, u *user.User, name string, withData bool) ([]*Secret, error) {
+	all, err := s.repo.GetSharedSecrets(ctx, u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared secrets %w", err)
+	}
+
+	secrets := make([]*Secret, 0, len(all))
+	for _, secret := range all {
+		// Название зашифровано в области владельца, поэтому поиск по слепому индексу здесь не работает
+		if secret.Name, err = s.fields.OpenValue(FieldName, secret.UserID, secret.Name, secret.SealedFields); err != nil {
+			return nil, fmt.Errorf("failed to open secret name %w", err)
+		}
+		secret.SealedFields = secret.SealedFields.without(FieldName)
+		if name == "" || secret.Name == name {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	if !withData {
+		return secrets, nil
+	}
+
+	if err = s.withholdLocked(ctx, u.ID, secrets); err != nil {
+		return nil, err
+	}
+
+	serverEncrypted := make([]*Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		if !secret.ClientEncrypted && !secret.ApprovalRequired {
+			serverEncrypted = append(serverEncrypted, secret)
+		}
+	}
+
+	if err = s.repo.LoadSecretsData(ctx, serverEncrypted); err != nil {
+		return nil, fmt.Errorf("failed to load secrets data %w", err)
+	}
+
+	if err = s.openSecrets(ctx, serverEncrypted); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
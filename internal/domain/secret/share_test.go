@@ -0,0 +1,116 @@
+package secret_test
+
+import (
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShare(t *testing.T) {
+	owner, err := user.NewUser("owner", "test", testHasher(t))
+	require.NoError(t, err)
+	owner.ID = 1
+
+	recipient, err := user.NewUser("recipient", "test", testHasher(t))
+	require.NoError(t, err)
+	recipient.ID = 2
+
+	e2eRecipient, err := user.NewUser("e2e", "test", testHasher(t))
+	require.NoError(t, err)
+	e2eRecipient.ID = 3
+	e2eRecipient.PublicKey = []byte("public")
+
+	serverSecret, err := secret.NewSecret("pass", secret.TypePassword, owner.ID)
+	require.NoError(t, err)
+	e2eSecret, err := secret.NewSecret("pass", secret.TypePassword, owner.ID)
+	require.NoError(t, err)
+	e2eSecret.ClientEncrypted = true
+
+	testCases := []struct {
+		name       string
+		secret     *secret.Secret
+		recipient  *user.User
+		perm       secret.Permission
+		wrappedKey []byte
+		wantErr    error
+	}{
+		{
+			name:      "server secret",
+			secret:    serverSecret,
+			recipient: recipient,
+			perm:      secret.PermissionRead,
+		},
+		{
+			name:       "e2e secret",
+			secret:     e2eSecret,
+			recipient:  e2eRecipient,
+			perm:       secret.PermissionRead,
+			wrappedKey: []byte("wrapped"),
+		},
+		{
+			// Права на изменение не выдаются: изменить общий секрет может только владелец
+			name:      "write permission",
+			secret:    serverSecret,
+			recipient: recipient,
+			perm:      secret.Permission("write"),
+			wantErr:   secret.ErrInvalidPermission,
+		},
+		{
+			name:      "self",
+			secret:    serverSecret,
+			recipient: owner,
+			perm:      secret.PermissionRead,
+			wantErr:   secret.ErrShareToSelf,
+		},
+		{
+			name:      "invalid permission",
+			secret:    serverSecret,
+			recipient: recipient,
+			perm:      "admin",
+			wantErr:   secret.ErrInvalidPermission,
+		},
+		{
+			name:      "e2e secret without key",
+			secret:    e2eSecret,
+			recipient: e2eRecipient,
+			perm:      secret.PermissionRead,
+			wantErr:   secret.ErrShareKeyRequired,
+		},
+		{
+			name:       "e2e secret to recipient without keys",
+			secret:     e2eSecret,
+			recipient:  recipient,
+			perm:       secret.PermissionRead,
+			wrappedKey: []byte("wrapped"),
+			wantErr:    secret.ErrRecipientHasNoKeys,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var payload []byte
+			if test.wrappedKey != nil {
+				payload = []byte("payload")
+			}
+
+			sh, err := secret.NewShare(owner, test.secret, test.recipient, test.perm, test.wrappedKey, payload)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, owner.ID, sh.OwnerID)
+			assert.Equal(t, test.recipient.ID, sh.RecipientID)
+			assert.Equal(t, test.perm, sh.Permission)
+			assert.Equal(t, test.wrappedKey, sh.WrappedKey)
+		})
+	}
+
+	// Чужой секрет открыть нельзя
+	_, err = secret.NewShare(recipient, serverSecret, e2eRecipient, secret.PermissionRead, nil, nil)
+	require.ErrorIs(t, err, secret.ErrSecretNotFound)
+}
@@ -0,0 +1,119 @@
+// Package session пакет уровня домена сессий пользователя и токенов обновления.
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	sessionIDLen    = 16
+	refreshTokenLen = 32
+	refreshTokenSep = "."
+)
+
+// Session сессия пользователя. Токен обновления хранится только в виде хэша.
+type Session struct {
+	ID          string
+	UserID      int
+	RefreshHash string
+	// PrevRefreshHash хэш предыдущего токена обновления. Повторное предъявление уже использованного
+	// токена означает, что он украден, и сессия отзывается.
+	PrevRefreshHash string
+	UserAgent       string
+	IP              string
+	CreatedAt       time.Time
+	LastUsedAt      time.Time
+	ExpiresAt       time.Time
+	RevokedAt       time.Time
+}
+
+// Meta сведения об устройстве, с которого открыта сессия.
+type Meta struct {
+	UserAgent string
+	IP        string
+}
+
+// NewSession получение новой сессии и токена обновления для нее.
+func NewSession(userID int, meta Meta, ttl time.Duration) (*Session, string, error) {
+	op := "domain.session.NewSession"
+
+	id := make([]byte, sessionIDLen)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", fmt.Errorf("%s: failed to generate session id %w", op, err)
+	}
+
+	now := time.Now()
+	s := Session{
+		ID:         hex.EncodeToString(id),
+		UserID:     userID,
+		UserAgent:  meta.UserAgent,
+		IP:         meta.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+
+	token, err := s.newRefreshToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &s, token, nil
+}
+
+// IsActive не отозвана и не истекла ли сессия.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
+
+// rotate выпуск нового токена обновления взамен предъявленного.
+func (s *Session) rotate(ttl time.Duration) (string, error) {
+	prev := s.RefreshHash
+
+	token, err := s.newRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	s.PrevRefreshHash = prev
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(ttl)
+
+	return token, nil
+}
+
+// newRefreshToken генерация токена обновления вида "<id сессии>.<случайная часть>" и сохранение его хэша.
+func (s *Session) newRefreshToken() (string, error) {
+	raw := make([]byte, refreshTokenLen)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token %w", err)
+	}
+
+	token := s.ID + refreshTokenSep + base64.RawURLEncoding.EncodeToString(raw)
+	s.RefreshHash = hashToken(token)
+
+	return token, nil
+}
+
+// parseRefreshToken получение ID сессии из токена обновления.
+func parseRefreshToken(token string) (string, error) {
+	id, secret, ok := strings.Cut(token, refreshTokenSep)
+	if !ok || id == "" || secret == "" {
+		return "", ErrInvalidToken
+	}
+
+	return id, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
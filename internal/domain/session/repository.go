@@ -0,0 +1,21 @@
+package session
+
+//go:generate mockgen -destination=../../mocks/session.go -package=mocks -mock_names=Repository=MockSessionRepository,Users=MockSessionUsers . Repository,Users
+
+import (
+	"context"
+	"time"
+)
+
+// Repository интерфейс репозитория сессий.
+type Repository interface {
+	Create(ctx context.Context, s *Session) error
+	GetByID(ctx context.Context, id string) (*Session, error)
+	// Rotate сохранение нового токена обновления, только если в хранилище все еще лежит oldHash.
+	// Иначе возвращается ErrInvalidToken: токен уже использован параллельным запросом.
+	Rotate(ctx context.Context, s *Session, oldHash string) error
+	ListActive(ctx context.Context, userID int, now time.Time) ([]*Session, error)
+	Revoke(ctx context.Context, userID int, id string, at time.Time) error
+	// RevokeAllExcept отзыв всех активных сессий пользователя, кроме keepID. Возвращает число отозванных.
+	RevokeAllExcept(ctx context.Context, userID int, keepID string, at time.Time) (int, error)
+}
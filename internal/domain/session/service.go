@@ -0,0 +1,163 @@
+package session
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotFound сессия не найдена.
+	ErrNotFound = errors.New("session not found")
+	// ErrInvalidToken токен обновления некорректен, истек или уже использован.
+	ErrInvalidToken = errors.New("invalid refresh token")
+	// ErrRevoked сессия отозвана или истекла.
+	ErrRevoked = errors.New("session revoked")
+	// ErrTokenReuse повторно предъявлен уже использованный токен обновления, сессия отозвана.
+	ErrTokenReuse = errors.New("refresh token reuse detected")
+)
+
+// Users проверка владельцев сессий.
+type Users interface {
+	// CheckActive ошибка, если пользователь удален или заблокирован администратором.
+	CheckActive(ctx context.Context, userID int) error
+}
+
+// Service сервисный слой сессий.
+type Service struct {
+	repo       Repository
+	users      Users
+	refreshTTL time.Duration
+}
+
+// NewService получение сервиса сессий. refreshTTL время жизни сессии без обновления токена.
+func NewService(r Repository, u Users, refreshTTL time.Duration) *Service {
+	return &Service{
+		repo:       r,
+		users:      u,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// Create открытие новой сессии при входе пользователя.
+// Возвращает сессию и токен обновления, который больше нигде не хранится в открытом виде.
+func (s *Service) Create(ctx context.Context, userID int, meta Meta) (*Session, string, error) {
+	op := "domain.session.Service.Create"
+
+	sess, token, err := NewSession(userID, meta, s.refreshTTL)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.Create(ctx, sess); err != nil {
+		return nil, "", fmt.Errorf("%s: failed to save session %w", op, err)
+	}
+
+	return sess, token, nil
+}
+
+// Refresh обмен токена обновления на новый. Старый токен после этого недействителен.
+// Для удаленного или заблокированного пользователя возвращается ошибка Users.CheckActive.
+func (s *Service) Refresh(ctx context.Context, token string) (*Session, string, error) {
+	op := "domain.session.Service.Refresh"
+
+	id, err := parseRefreshToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sess, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, "", ErrInvalidToken
+		}
+		return nil, "", fmt.Errorf("%s: failed to get session %w", op, err)
+	}
+
+	if !sess.IsActive(time.Now()) {
+		return nil, "", ErrInvalidToken
+	}
+
+	hash := hashToken(token)
+
+	if sess.PrevRefreshHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(sess.PrevRefreshHash)) == 1 {
+		if err = s.repo.Revoke(ctx, sess.UserID, sess.ID, time.Now()); err != nil {
+			return nil, "", fmt.Errorf("%s: failed to revoke session after token reuse %w", op, err)
+		}
+		return nil, "", ErrTokenReuse
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(sess.RefreshHash)) != 1 {
+		return nil, "", ErrInvalidToken
+	}
+
+	// Заблокированный или удаленный пользователь не продлевает сессию, токен остается прежним
+	if err = s.users.CheckActive(ctx, sess.UserID); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	newToken, err := sess.rotate(s.refreshTTL)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.Rotate(ctx, sess, hash); err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, "", ErrInvalidToken
+		}
+		return nil, "", fmt.Errorf("%s: failed to save rotated token %w", op, err)
+	}
+
+	return sess, newToken, nil
+}
+
+// Check проверка, что сессия пользователя активна. Вызывается на каждый запрос с токеном доступа.
+func (s *Service) Check(ctx context.Context, userID int, id string) error {
+	op := "domain.session.Service.Check"
+
+	sess, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrRevoked
+		}
+		return fmt.Errorf("%s: failed to get session %w", op, err)
+	}
+
+	if sess.UserID != userID || !sess.IsActive(time.Now()) {
+		return ErrRevoked
+	}
+
+	return nil
+}
+
+// List активные сессии пользователя.
+func (s *Service) List(ctx context.Context, userID int) ([]*Session, error) {
+	sessions, err := s.repo.ListActive(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("domain.session.Service.List: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke отзыв сессии пользователя. Токены доступа этой сессии перестают приниматься сразу.
+func (s *Service) Revoke(ctx context.Context, userID int, id string) error {
+	if err := s.repo.Revoke(ctx, userID, id, time.Now()); err != nil {
+		return fmt.Errorf("domain.session.Service.Revoke: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOthers отзыв всех сессий пользователя, кроме keepID, например, после смены пароля.
+// С пустым keepID отзываются все сессии.
+func (s *Service) RevokeOthers(ctx context.Context, userID int, keepID string) (int, error) {
+	n, err := s.repo.RevokeAllExcept(ctx, userID, keepID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("domain.session.Service.RevokeOthers: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,323 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/session"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var errStorage = errors.New("storage error")
+
+func newSession(t *testing.T, ttl time.Duration) (*session.Session, string) {
+	t.Helper()
+
+	sess, token, err := session.NewSession(1, session.Meta{UserAgent: "test", IP: "127.0.0.1"}, ttl)
+	require.NoError(t, err)
+
+	return sess, token
+}
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+
+			var saved *session.Session
+			repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *session.Session) error {
+				saved = s
+				return tt.repoErr
+			})
+
+			s := session.NewService(repo, mocks.NewMockSessionUsers(ctrl), time.Hour)
+
+			sess, token, err := s.Create(context.Background(), 1, session.Meta{UserAgent: "laptop"})
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, saved, sess)
+			assert.Equal(t, 1, sess.UserID)
+			assert.Equal(t, "laptop", sess.UserAgent)
+			assert.True(t, sess.IsActive(time.Now()))
+
+			// Токен обновления хранится только хэшем
+			assert.NotEmpty(t, sess.RefreshHash)
+			assert.NotContains(t, sess.RefreshHash, token)
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	active, token := newSession(t, time.Hour)
+	expired, expiredToken := newSession(t, -time.Second)
+	revoked, revokedToken := newSession(t, time.Hour)
+	revoked.RevokedAt = time.Now()
+
+	tests := []struct {
+		name      string
+		token     string
+		stored    *session.Session
+		getErr    error
+		checkUser bool
+		userErr   error
+		rotate    bool
+		rotateErr error
+		wantErr   error
+	}{
+		{name: "success", token: token, stored: active, checkUser: true, rotate: true},
+		{name: "empty token", token: "", wantErr: session.ErrInvalidToken},
+		{name: "no separator", token: "garbage", wantErr: session.ErrInvalidToken},
+		{name: "unknown session", token: "deadbeef.c2VjcmV0", getErr: session.ErrNotFound, wantErr: session.ErrInvalidToken},
+		{name: "wrong secret", token: active.ID + ".c2VjcmV0", stored: active, wantErr: session.ErrInvalidToken},
+		{name: "expired session", token: expiredToken, stored: expired, wantErr: session.ErrInvalidToken},
+		{name: "revoked session", token: revokedToken, stored: revoked, wantErr: session.ErrInvalidToken},
+		{
+			// Заблокированный пользователь не продлевает сессию
+			name:      "disabled user",
+			token:     token,
+			stored:    active,
+			checkUser: true,
+			userErr:   user.ErrDisabled,
+			wantErr:   user.ErrDisabled,
+		},
+		{name: "deleted user", token: token, stored: active, checkUser: true, userErr: user.ErrNotFound, wantErr: user.ErrNotFound},
+		{
+			// Тот же токен успел обменять параллельный запрос
+			name:      "rotated concurrently",
+			token:     token,
+			stored:    active,
+			checkUser: true,
+			rotate:    true,
+			rotateErr: session.ErrInvalidToken,
+			wantErr:   session.ErrInvalidToken,
+		},
+		{name: "storage error", token: token, getErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+			users := mocks.NewMockSessionUsers(ctrl)
+
+			if tt.stored != nil || tt.getErr != nil {
+				var stored *session.Session
+				if tt.stored != nil {
+					copied := *tt.stored
+					stored = &copied
+				}
+				repo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(stored, tt.getErr)
+			}
+
+			if tt.checkUser {
+				users.EXPECT().CheckActive(gomock.Any(), 1).Return(tt.userErr)
+			}
+
+			var oldHash string
+			if tt.rotate {
+				repo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *session.Session, hash string) error {
+						oldHash = hash
+						return tt.rotateErr
+					})
+			}
+
+			s := session.NewService(repo, users, time.Hour)
+
+			sess, newToken, err := s.Refresh(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stored.ID, sess.ID)
+			assert.NotEqual(t, tt.token, newToken)
+
+			// Ротация сохраняется, только если в хранилище все еще лежит хэш предъявленного токена
+			assert.Equal(t, tt.stored.RefreshHash, oldHash)
+			assert.Equal(t, tt.stored.RefreshHash, sess.PrevRefreshHash)
+			assert.NotEqual(t, tt.stored.RefreshHash, sess.RefreshHash)
+		})
+	}
+}
+
+func TestService_RefreshReuse(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSessionRepository(ctrl)
+	users := mocks.NewMockSessionUsers(ctrl)
+	s := session.NewService(repo, users, time.Hour)
+
+	sess, first := newSession(t, time.Hour)
+
+	var rotated *session.Session
+	gomock.InOrder(
+		repo.EXPECT().GetByID(gomock.Any(), sess.ID).Return(sess, nil),
+		users.EXPECT().CheckActive(gomock.Any(), 1).Return(nil),
+		repo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, s *session.Session, _ string) error {
+				copied := *s
+				rotated = &copied
+				return nil
+			}),
+		repo.EXPECT().GetByID(gomock.Any(), sess.ID).DoAndReturn(func(context.Context, string) (*session.Session, error) {
+			return rotated, nil
+		}),
+		// Повторно предъявленный токен означает кражу, сессия отзывается целиком
+		repo.EXPECT().Revoke(gomock.Any(), 1, sess.ID, gomock.Any()).Return(nil),
+	)
+
+	_, _, err := s.Refresh(ctx, first)
+	require.NoError(t, err)
+
+	_, _, err = s.Refresh(ctx, first)
+	require.ErrorIs(t, err, session.ErrTokenReuse)
+}
+
+func TestService_Check(t *testing.T) {
+	active, _ := newSession(t, time.Hour)
+	expired, _ := newSession(t, -time.Second)
+	revoked, _ := newSession(t, time.Hour)
+	revoked.RevokedAt = time.Now()
+
+	tests := []struct {
+		name    string
+		userID  int
+		stored  *session.Session
+		getErr  error
+		wantErr error
+	}{
+		{name: "active", userID: 1, stored: active},
+		{name: "other user", userID: 2, stored: active, wantErr: session.ErrRevoked},
+		{name: "not found", userID: 1, getErr: session.ErrNotFound, wantErr: session.ErrRevoked},
+		{name: "expired", userID: 1, stored: expired, wantErr: session.ErrRevoked},
+		{name: "revoked", userID: 1, stored: revoked, wantErr: session.ErrRevoked},
+		{name: "storage error", userID: 1, getErr: errStorage, wantErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+			repo.EXPECT().GetByID(gomock.Any(), "session").Return(tt.stored, tt.getErr)
+
+			s := session.NewService(repo, mocks.NewMockSessionUsers(ctrl), time.Hour)
+
+			err := s.Check(context.Background(), tt.userID, "session")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	active, _ := newSession(t, time.Hour)
+
+	tests := []struct {
+		name    string
+		stored  []*session.Session
+		repoErr error
+	}{
+		{name: "success", stored: []*session.Session{active}},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+			repo.EXPECT().ListActive(gomock.Any(), 1, gomock.Any()).Return(tt.stored, tt.repoErr)
+
+			s := session.NewService(repo, mocks.NewMockSessionUsers(ctrl), time.Hour)
+
+			list, err := s.List(context.Background(), 1)
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stored, list)
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "not found", repoErr: session.ErrNotFound},
+		{name: "storage error", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+			repo.EXPECT().Revoke(gomock.Any(), 1, "session", gomock.Any()).Return(tt.repoErr)
+
+			s := session.NewService(repo, mocks.NewMockSessionUsers(ctrl), time.Hour)
+
+			err := s.Revoke(context.Background(), 1, "session")
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RevokeOthers(t *testing.T) {
+	tests := []struct {
+		name    string
+		keepID  string
+		revoked int
+		repoErr error
+	}{
+		{name: "keep current", keepID: "current", revoked: 2},
+		// С пустым keepID отзываются все сессии
+		{name: "revoke all", revoked: 3},
+		{name: "storage error", keepID: "current", repoErr: errStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSessionRepository(ctrl)
+			repo.EXPECT().RevokeAllExcept(gomock.Any(), 1, tt.keepID, gomock.Any()).Return(tt.revoked, tt.repoErr)
+
+			s := session.NewService(repo, mocks.NewMockSessionUsers(ctrl), time.Hour)
+
+			n, err := s.RevokeOthers(context.Background(), 1, tt.keepID)
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.revoked, n)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+)
+
+// Auditor журнал аудита операций с аккаунтами.
+type Auditor interface {
+	Record(ctx context.Context, events ...*audit.Event) error
+}
+
+// record запись операции с аккаунтом userID в журнал аудита, результат операции определяется по opErr.
+// Возвращается opErr вместе с ошибкой записи: операция без записи в журнале считается неудачной.
+func (s *Service) record(ctx context.Context, userID int, login string, action audit.Action, opErr error) error {
+	if s.auditor == nil {
+		return opErr
+	}
+
+	if err := s.auditor.Record(ctx, audit.NewEvent(userID, login, action, 0, opErr)); err != nil {
+		return errors.Join(opErr, fmt.Errorf("failed to record audit event %w", err))
+	}
+
+	return opErr
+}
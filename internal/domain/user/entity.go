@@ -2,45 +2,163 @@
 package user
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
 )
 
+// EncryptionMode режим шифрования секретов аккаунта.
+type EncryptionMode string
+
+const (
+	// EncryptionModeServer секреты шифруются на сервере мастер-ключом.
+	EncryptionModeServer EncryptionMode = "server"
+	// EncryptionModeE2E секреты шифруются на клиенте, сервер хранит только шифротекст.
+	EncryptionModeE2E EncryptionMode = "e2e"
+)
+
+// ErrInvalidE2EParams неверные параметры для сквозного шифрования.
+var ErrInvalidE2EParams = errors.New("invalid end-to-end encryption params")
+
+// PasswordHasher хэширование паролей по текущей политике и проверка хэшей всех поддерживаемых схем.
+type PasswordHasher interface {
+	Hash(password string) (passhash.Hash, error)
+	Verify(stored passhash.Hash, password string) (bool, error)
+	NeedsRehash(stored passhash.Hash) bool
+	IsCurrent(scheme passhash.Scheme, pepperID string) bool
+	VerifyDummy(password string)
+}
+
 // User основная модель для пользователя.
+// PassScheme схема хэширования PassHash, PepperID перец, с которым он получен.
 type User struct {
-	ID        int
-	Login     string
-	PassHash  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             int
+	Login          string
+	PassHash       string
+	PassScheme     passhash.Scheme
+	PepperID       string
+	EncryptionMode EncryptionMode
+	// KDF параметры получения ключей из мастер-пароля на клиенте (только для E2E).
+	KDF *encryptor.KDFParams
+	// WrappedVaultKey ключ хранилища, зашифрованный ключом клиента (только для E2E).
+	WrappedVaultKey []byte
+	// PublicKey открытый ключ X25519, которым владельцы секретов шифруют ключи доступа к ним (только для E2E).
+	PublicKey []byte
+	// WrappedPrivateKey закрытый ключ X25519, зашифрованный ключом хранилища.
+	WrappedPrivateKey []byte
+	// DeleteAfter момент окончательного удаления аккаунта, нулевое значение если удаление не запрошено.
+	DeleteAfter time.Time
+	// IsAdmin администратор: управляет пользователями и приглашениями.
+	IsAdmin bool
+	// DisabledAt момент блокировки администратором, нулевое значение если аккаунт активен.
+	DisabledAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // NewUser создает нового пользователя.
-func NewUser(login, password, pepper string) (*User, error) {
-	var (
-		hash []byte
-		err  error
-		op   = "domain.User.NewUser"
-	)
-
-	hash, err = bcrypt.GenerateFromPassword([]byte(password+pepper), bcrypt.DefaultCost)
+func NewUser(login, password string, hasher PasswordHasher) (*User, error) {
+	op := "domain.User.NewUser"
+
+	hash, err := hasher.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	u := User{
+		Login:          login,
+		EncryptionMode: EncryptionModeServer,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	u.setPasswordHash(hash)
+
+	return &u, nil
+}
+
+// NewE2EUser создает нового пользователя со сквозным шифрованием.
+// authKey ключ аутентификации, полученный клиентом из мастер-пароля, сервер хранит только его хэш.
+func NewE2EUser(
+	login, authKey string,
+	hasher PasswordHasher,
+	kdf *encryptor.KDFParams,
+	wrappedVaultKey []byte,
+) (*User, error) {
+	op := "domain.User.NewE2EUser"
+
+	if err := kdf.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidE2EParams, err)
+	}
+	if len(wrappedVaultKey) == 0 {
+		return nil, fmt.Errorf("%s: %w: wrapped vault key is empty", op, ErrInvalidE2EParams)
+	}
+
+	u, err := NewUser(login, authKey, hasher)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &User{
-		Login:     login,
-		PassHash:  string(hash),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	u.EncryptionMode = EncryptionModeE2E
+	u.KDF = kdf
+	u.WrappedVaultKey = wrappedVaultKey
+
+	return u, nil
+}
+
+// IsE2E включено ли для пользователя сквозное шифрование.
+func (u *User) IsE2E() bool {
+	return u.EncryptionMode == EncryptionModeE2E
+}
+
+// HasShareKeys есть ли у пользователя пара ключей для получения доступа к чужим секретам E2E.
+func (u *User) HasShareKeys() bool {
+	return len(u.PublicKey) != 0
+}
+
+// Disabled заблокирован ли аккаунт администратором.
+func (u *User) Disabled() bool {
+	return !u.DisabledAt.IsZero()
+}
+
+// DeletionScheduled запрошено ли удаление аккаунта.
+func (u *User) DeletionScheduled() bool {
+	return !u.DeleteAfter.IsZero()
+}
+
+// VerifyUserPassword верификация пароля пользователя. Ошибка означает, что хэш нельзя проверить
+// при текущих настройках хэширования (например, удален его перец), а не неверный пароль.
+func (u *User) VerifyUserPassword(password string, hasher PasswordHasher) (bool, error) {
+	ok, err := hasher.Verify(u.PasswordHash(), password)
+	if err != nil {
+		return false, fmt.Errorf("domain.User.VerifyUserPassword: %w", err)
+	}
+
+	return ok, nil
+}
+
+// SetPassword замена хэша пароля (для E2E ключа аутентификации) на хэш нового значения по текущей политике.
+func (u *User) SetPassword(password string, hasher PasswordHasher) error {
+	hash, err := hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("domain.User.SetPassword: %w", err)
+	}
+
+	u.setPasswordHash(hash)
+	u.UpdatedAt = time.Now()
+
+	return nil
 }
 
-// VerifyUserPassword верификация пароля пользователя.
-func (u *User) VerifyUserPassword(password, pepper string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.PassHash), []byte(password+pepper))
+// PasswordHash хэш пароля вместе со схемой и перцем.
+func (u *User) PasswordHash() passhash.Hash {
+	return passhash.Hash{Value: u.PassHash, Scheme: u.PassScheme, PepperID: u.PepperID}
+}
 
-	return err == nil
+func (u *User) setPasswordHash(hash passhash.Hash) {
+	u.PassHash = hash.Value
+	u.PassScheme = hash.Scheme
+	u.PepperID = hash.PepperID
 }
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RegistrationMode режим регистрации новых пользователей.
+type RegistrationMode string
+
+const (
+	// RegistrationOpen регистрация доступна всем.
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationInvite регистрация только по коду приглашения.
+	RegistrationInvite RegistrationMode = "invite"
+	// RegistrationDisabled регистрация закрыта.
+	RegistrationDisabled RegistrationMode = "disabled"
+)
+
+// Valid известен ли режим регистрации.
+func (m RegistrationMode) Valid() bool {
+	switch m {
+	case RegistrationOpen, RegistrationInvite, RegistrationDisabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// inviteCodeLen число случайных байт кода приглашения.
+const inviteCodeLen = 20
+
+// ErrInvalidInvite код приглашения не найден, уже использован или истек.
+var ErrInvalidInvite = errors.New("invalid invite code")
+
+// inviteEncoding кодировка кода приглашения: base32 без паддинга, только заглавные буквы и цифры.
+var inviteEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Invite одноразовое приглашение на регистрацию. Сам код не хранится, только его хэш.
+// CreatedBy администратор, создавший приглашение, 0 если его создал оператор.
+type Invite struct {
+	ID        int
+	CodeHash  []byte
+	CreatedBy int
+	Note      string
+	ExpiresAt time.Time
+	UsedBy    int
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+// NewInvite новое приглашение со сроком действия ttl. Возвращает приглашение и код, который
+// нужно передать пользователю: повторно получить код нельзя.
+func NewInvite(createdBy int, note string, ttl time.Duration) (*Invite, string, error) {
+	raw := make([]byte, inviteCodeLen)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("domain.User.NewInvite: failed to generate code %w", err)
+	}
+	code := inviteEncoding.EncodeToString(raw)
+
+	now := time.Now()
+
+	return &Invite{
+		CodeHash:  HashInviteCode(code),
+		CreatedBy: createdBy,
+		Note:      note,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, code, nil
+}
+
+// HashInviteCode хэш кода приглашения. Регистр, пробелы и дефисы при вводе не важны.
+func HashInviteCode(code string) []byte {
+	normalized := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return sum[:]
+}
+
+// CreateInvite создание приглашения на регистрацию.
+func (s *Service) CreateInvite(
+	ctx context.Context,
+	createdBy int,
+	note string,
+	ttl time.Duration,
+) (*Invite, string, error) {
+	op := "domain.User.service.CreateInvite"
+
+	inv, code, err := NewInvite(createdBy, note, ttl)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.CreateInvite(ctx, inv); err != nil {
+		return nil, "", fmt.Errorf("%s: failed to save invite %w", op, err)
+	}
+
+	return inv, code, nil
+}
@@ -1,12 +1,37 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
+)
 
 // Repository интерфейс репозитория для пользователя.
 type Repository interface {
 	Create(ctx context.Context, user *User) error
+	// CreateWithInvite создание пользователя с погашением приглашения с хэшем кода codeHash в одной транзакции.
+	// Если приглашение не найдено, уже использовано или истекло к now, возвращается ErrInvalidInvite.
+	CreateWithInvite(ctx context.Context, user *User, codeHash []byte, now time.Time) error
+	CreateInvite(ctx context.Context, inv *Invite) error
+	List(ctx context.Context) ([]*User, error)
 	GetByID(ctx context.Context, id int) (*User, error)
 	GetByLogin(ctx context.Context, login string) (*User, error)
 	Update(ctx context.Context, user *User) error
+	// UpdatePasswordHash замена хэша пароля, если в базе все еще хэш old, иначе ErrNoRowsUpdated.
+	UpdatePasswordHash(ctx context.Context, id int, old string, hash passhash.Hash) error
+	// CountPasswordHashes число аккаунтов по схемам хэширования и перцам.
+	CountPasswordHashes(ctx context.Context) ([]HashCount, error)
+	// Delete удаление пользователя вместе со всеми его строками в базе (секреты, ключи, сессии, второй фактор).
 	Delete(ctx context.Context, id int) error
+	// SetDeleteAfter планирование удаления аккаунта, нулевое at отменяет удаление.
+	SetDeleteAfter(ctx context.Context, id int, at time.Time) error
+	// SetDisabledAt блокировка аккаунта, нулевое at снимает блокировку.
+	SetDisabledAt(ctx context.Context, id int, at time.Time) error
+	SetAdmin(ctx context.Context, id int, admin bool) error
+	// SetShareKeys сохранение пары ключей для получения доступа к чужим секретам, если она еще не задана,
+	// иначе ErrNoRowsUpdated.
+	SetShareKeys(ctx context.Context, id int, publicKey, wrappedPrivateKey []byte) error
+	// ListDueForDeletion пользователи, срок удаления которых наступил к now.
+	ListDueForDeletion(ctx context.Context, now time.Time) ([]*User, error)
 }
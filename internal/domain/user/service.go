@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
 )
 
 var (
@@ -15,22 +20,91 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	// ErrNoRowsUpdated не обновлено ни одной строки данных пользователя.
 	ErrNoRowsUpdated = errors.New("none rows was updated")
+	// ErrWeakPassword новый пароль не удовлетворяет политике паролей.
+	ErrWeakPassword = errors.New("weak password")
+	// ErrNothingToUpdate не указан ни новый логин, ни новый пароль.
+	ErrNothingToUpdate = errors.New("nothing to update")
+	// ErrDeletionNotScheduled удаление аккаунта не запрошено.
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+	// ErrDisabled аккаунт заблокирован администратором.
+	ErrDisabled = errors.New("user is disabled")
+	// ErrSelfAdminAction администратор не может заблокировать себя или снять с себя права.
+	ErrSelfAdminAction = errors.New("administrators cannot disable or demote themselves")
+	// ErrNotE2E операция доступна только аккаунтам со сквозным шифрованием.
+	ErrNotE2E = errors.New("account does not use end-to-end encryption")
+	// ErrRehashFailed вход выполнен, но хэш пароля не удалось пересчитать по текущей политике.
+	ErrRehashFailed = errors.New("failed to rehash password")
 )
 
+// PasswordPolicy проверка нового пароля аккаунта. userInputs данные пользователя, например, логин.
+type PasswordPolicy interface {
+	Check(password string, userInputs ...string) error
+}
+
+// DataEraser удаление данных пользователя вне базы, например, файлов бинарных секретов.
+type DataEraser func(ctx context.Context, userID int) error
+
+// CredentialsUpdate смена учетных данных. Пустые NewLogin и NewPassword не меняются.
+// Для E2E аккаунтов пароли это ключи аутентификации, а при смене пароля нужны новые KDF
+// и ключ хранилища, обернутый ключом из нового мастер-пароля.
+type CredentialsUpdate struct {
+	NewLogin        string
+	OldPassword     string
+	NewPassword     string
+	KDF             *encryptor.KDFParams
+	WrappedVaultKey []byte
+}
+
+// HashCount число аккаунтов, хэши паролей которых получены схемой Scheme с перцем PepperID.
+// Current соответствуют ли схема и перец текущей политике хэширования.
+type HashCount struct {
+	Scheme   passhash.Scheme
+	PepperID string
+	Users    int
+	Current  bool
+}
+
+// HashReport распределение хэшей паролей аккаунтов по схемам и перцам. Legacy число аккаунтов
+// на старых схемах или перцах, их хэши пересчитаются при следующем входе.
+type HashReport struct {
+	Counts []HashCount
+	Total  int
+	Legacy int
+}
+
 // Service сервисный слой пользователя.
 type Service struct {
-	repo Repository
+	repo     Repository
+	policy   PasswordPolicy
+	hasher   PasswordHasher
+	auditor  Auditor
+	decoyKey []byte
 }
 
 // NewService возвращает указатель на сервис для пользователя.
-func NewService(r Repository) *Service {
+// policy проверяет пароли аккаунтов с серверным шифрованием, nil отключает проверку.
+// hasher хэширует новые пароли и проверяет хэши всех поддерживаемых схем.
+// auditor записывает входы и изменения аккаунтов в журнал аудита, nil отключает журнал.
+// decoyKey секрет сервера, из которого получаются параметры KDF для неизвестных логинов.
+func NewService(
+	r Repository,
+	policy PasswordPolicy,
+	hasher PasswordHasher,
+	auditor Auditor,
+	decoyKey []byte,
+) *Service {
 	return &Service{
-		repo: r,
+		repo:     r,
+		policy:   policy,
+		hasher:   hasher,
+		auditor:  auditor,
+		decoyKey: decoyKey,
 	}
 }
 
-// Register регистрация нового пользователя.
-func (s *Service) Register(ctx context.Context, login, password, pepper string) (*User, error) {
+// Register регистрация нового пользователя. Непустой inviteCode погашается вместе с созданием пользователя,
+// недействительный код это ErrInvalidInvite.
+func (s *Service) Register(ctx context.Context, login, password, inviteCode string) (*User, error) {
 	op := "domain.User.Register"
 
 	var (
@@ -38,6 +112,10 @@ func (s *Service) Register(ctx context.Context, login, password, pepper string)
 		err  error
 	)
 
+	if err = s.checkPassword(password, login); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	user, err = s.repo.GetByLogin(ctx, login)
 	if err != nil && !errors.Is(err, ErrNotFound) {
 		return nil, fmt.Errorf("%s: failed to check existing of user %w", op, err)
@@ -46,21 +124,94 @@ func (s *Service) Register(ctx context.Context, login, password, pepper string)
 		return nil, ErrAlreadyExist
 	}
 
-	user, err = NewUser(login, password, pepper)
+	user, err = NewUser(login, password, s.hasher)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get new domain model %w", op, err)
 	}
 
-	err = s.repo.Create(ctx, user)
+	if err = s.create(ctx, user, inviteCode); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// RegisterE2E регистрация нового пользователя со сквозным шифрованием.
+func (s *Service) RegisterE2E(
+	ctx context.Context,
+	login, authKey, inviteCode string,
+	kdf *encryptor.KDFParams,
+	wrappedVaultKey []byte,
+) (*User, error) {
+	op := "domain.User.RegisterE2E"
+
+	var (
+		user *User
+		err  error
+	)
+
+	user, err = s.repo.GetByLogin(ctx, login)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%s: failed to check existing of user %w", op, err)
+	}
+	if user != nil {
+		return nil, ErrAlreadyExist
+	}
+
+	user, err = NewE2EUser(login, authKey, s.hasher, kdf, wrappedVaultKey)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to create new user in repo %w", op, err)
+		return nil, fmt.Errorf("%s: failed to get new domain model %w", op, err)
+	}
+
+	if err = s.create(ctx, user, inviteCode); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return user, nil
 }
 
+// create сохранение нового пользователя, с кодом приглашения в одной транзакции с его погашением.
+func (s *Service) create(ctx context.Context, u *User, inviteCode string) error {
+	if inviteCode == "" {
+		if err := s.repo.Create(ctx, u); err != nil {
+			return fmt.Errorf("failed to create new user in repo %w", err)
+		}
+		return s.record(ctx, u.ID, u.Login, audit.ActionCreate, nil)
+	}
+
+	if err := s.repo.CreateWithInvite(ctx, u, HashInviteCode(inviteCode), time.Now()); err != nil {
+		if errors.Is(err, ErrInvalidInvite) {
+			return s.record(ctx, 0, u.Login, audit.ActionCreate, ErrInvalidInvite)
+		}
+		return fmt.Errorf("failed to create new invited user in repo %w", err)
+	}
+
+	return s.record(ctx, u.ID, u.Login, audit.ActionCreate, nil)
+}
+
+// GetKDFParams получение режима шифрования и параметров KDF пользователя перед входом.
+// Для несуществующего логина возвращается E2E режим с постоянными для логина фиктивными параметрами
+// по умолчанию, поэтому по ответу нельзя узнать, существует ли E2E аккаунт с таким логином.
+func (s *Service) GetKDFParams(ctx context.Context, login string) (EncryptionMode, *encryptor.KDFParams, error) {
+	op := "domain.User.service.GetKDFParams"
+
+	user, err := s.repo.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return EncryptionModeE2E, encryptor.DecoyKDFParams(s.decoyKey, login), nil
+		}
+		return "", nil, fmt.Errorf("%s: failed to get user by login %w", op, err)
+	}
+
+	return user.EncryptionMode, user.KDF, nil
+}
+
 // Login авторизация пользователя.
-func (s *Service) Login(ctx context.Context, login, password, pepper string) (*User, error) {
+// Для неизвестного логина и неверного пароля возвращается одна и та же ошибка ErrInvalidCredentials,
+// а пароль неизвестного пользователя все равно сверяется с фиктивным хэшем, чтобы время ответа не отличалось.
+// Заблокированному пользователю после проверки пароля возвращается ErrDisabled. Хэш старой схемы, перца или параметров после успешной проверки пересчитывается по текущей политике.
+// Если сохранить новый хэш не удалось, вход не отклоняется: возвращаются пользователь и ErrRehashFailed.
+func (s *Service) Login(ctx context.Context, login, password string) (*User, error) {
 	op := "domain.User.service.Login"
 
 	var (
@@ -71,21 +222,89 @@ func (s *Service) Login(ctx context.Context, login, password, pepper string) (*U
 	user, err = s.repo.GetByLogin(ctx, login)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return nil, ErrNotFound
+			s.hasher.VerifyDummy(password)
+			return nil, s.record(ctx, 0, login, audit.ActionLoginFailed, ErrInvalidCredentials)
 		}
 		return nil, fmt.Errorf("%s: failed to get user by login %w", op, err)
 	}
 
-	if !user.VerifyUserPassword(password, pepper) {
-		if errors.Is(err, ErrInvalidCredentials) {
-			return nil, ErrInvalidCredentials
+	if err = s.verifyPassword(user, password); err != nil {
+		return nil, s.record(ctx, user.ID, login, audit.ActionLoginFailed, err)
+	}
+	if user.Disabled() {
+		return nil, s.record(ctx, user.ID, login, audit.ActionLoginFailed, ErrDisabled)
+	}
+
+	if err = s.record(ctx, user.ID, user.Login, audit.ActionLogin, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.hasher.NeedsRehash(user.PasswordHash()) {
+		if err = s.rehash(ctx, user, password); err != nil {
+			return user, fmt.Errorf("%s: %w: %w", op, ErrRehashFailed, err)
 		}
-		return nil, fmt.Errorf("%s: error verify user password %w", op, err)
 	}
 
 	return user, nil
 }
 
+// rehash пересчет хэша пароля по текущей политике. Хэш сохраняется, только если в базе все еще старый:
+// если пароль успели сменить параллельно, новый хэш не нужен.
+func (s *Service) rehash(ctx context.Context, u *User, password string) error {
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password %w", err)
+	}
+
+	if err = s.repo.UpdatePasswordHash(ctx, u.ID, u.PassHash, hash); err != nil {
+		if errors.Is(err, ErrNoRowsUpdated) {
+			return nil
+		}
+		return fmt.Errorf("failed to save password hash %w", err)
+	}
+
+	u.setPasswordHash(hash)
+
+	return nil
+}
+
+// verifyPassword проверка пароля пользователя, неверный пароль это ErrInvalidCredentials.
+func (s *Service) verifyPassword(u *User, password string) error {
+	ok, err := u.VerifyUserPassword(password, s.hasher)
+	if err != nil {
+		return fmt.Errorf("domain.User.service.verifyPassword: %w", err)
+	}
+	if !ok {
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+// PasswordHashReport распределение хэшей паролей по схемам и перцам, чтобы понять, сколько аккаунтов
+// еще на старых схемах и можно ли убрать старый перец. Изменение только параметров текущей схемы
+// (например, памяти Argon2id) отчет не учитывает.
+func (s *Service) PasswordHashReport(ctx context.Context) (*HashReport, error) {
+	op := "domain.User.service.PasswordHashReport"
+
+	counts, err := s.repo.CountPasswordHashes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to count password hashes %w", op, err)
+	}
+
+	report := HashReport{Counts: counts}
+	for i := range report.Counts {
+		c := &report.Counts[i]
+		c.Current = s.hasher.IsCurrent(c.Scheme, c.PepperID)
+		report.Total += c.Users
+		if !c.Current {
+			report.Legacy += c.Users
+		}
+	}
+
+	return &report, nil
+}
+
 // Update обновление данных о пользователе.
 func (s *Service) Update(ctx context.Context, u *User) error {
 	op := "domain.User.service.Update"
@@ -105,6 +324,167 @@ func (s *Service) Update(ctx context.Context, u *User) error {
 	return nil
 }
 
+// UpdateCredentials смена логина и/или пароля после проверки старого пароля.
+// Новый логин должен быть свободен, новый пароль хэшируется по текущей политике, а для E2E аккаунта
+// вместе с хэшем сохраняются новые параметры KDF и перешифрованный ключ хранилища.
+func (s *Service) UpdateCredentials(ctx context.Context, userID int, upd CredentialsUpdate) (*User, error) {
+	op := "domain.User.service.UpdateCredentials"
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user by ID %w", op, err)
+	}
+
+	if err = s.verifyPassword(u, upd.OldPassword); err != nil {
+		return nil, s.record(ctx, u.ID, u.Login, audit.ActionUpdate, err)
+	}
+
+	loginChanged := upd.NewLogin != "" && upd.NewLogin != u.Login
+	passwordChanged := upd.NewPassword != ""
+	if !loginChanged && !passwordChanged {
+		return nil, ErrNothingToUpdate
+	}
+
+	if err = s.checkNewPassword(u, upd, passwordChanged); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if loginChanged {
+		existing, err := s.repo.GetByLogin(ctx, upd.NewLogin)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%s: failed to check existing of user %w", op, err)
+		}
+		if existing != nil {
+			return nil, ErrAlreadyExist
+		}
+		u.Login = upd.NewLogin
+		u.UpdatedAt = time.Now()
+	}
+
+	if passwordChanged {
+		if err = s.applyPassword(u, upd); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = s.repo.Update(ctx, u); err != nil {
+		if errors.Is(err, ErrAlreadyExist) {
+			return nil, ErrAlreadyExist
+		}
+		return nil, fmt.Errorf("%s: error updating user %w", op, err)
+	}
+
+	if err = s.record(ctx, u.ID, u.Login, audit.ActionUpdate, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return u, nil
+}
+
+// ResetPassword установка нового пароля без проверки старого, например, при перехвате аккаунта доверенным
+// контактом. Для E2E аккаунта вместе с ключом аутентификации нужны новые параметры KDF и ключ хранилища,
+// обернутый ключом из нового пароля. Логин не меняется.
+func (s *Service) ResetPassword(ctx context.Context, userID int, upd CredentialsUpdate) (*User, error) {
+	op := "domain.User.service.ResetPassword"
+
+	upd.NewLogin = ""
+	u, err := s.resetTarget(ctx, userID, upd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = s.applyPassword(u, upd); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.Update(ctx, u); err != nil {
+		return nil, fmt.Errorf("%s: error updating user %w", op, err)
+	}
+
+	if err = s.record(ctx, u.ID, u.Login, audit.ActionUpdate, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return u, nil
+}
+
+// CheckResetPassword проверка параметров ResetPassword без сохранения, например, перед отзывом сессий
+// и второго фактора пользователя, которые должны предшествовать смене пароля.
+func (s *Service) CheckResetPassword(ctx context.Context, userID int, upd CredentialsUpdate) error {
+	upd.NewLogin = ""
+	if _, err := s.resetTarget(ctx, userID, upd); err != nil {
+		return fmt.Errorf("domain.User.service.CheckResetPassword: %w", err)
+	}
+
+	return nil
+}
+
+// resetTarget пользователь, которому ResetPassword установит пароль из upd, после проверки параметров.
+func (s *Service) resetTarget(ctx context.Context, userID int, upd CredentialsUpdate) (*User, error) {
+	if upd.NewPassword == "" {
+		return nil, ErrNothingToUpdate
+	}
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by ID %w", err)
+	}
+
+	if err = s.checkNewPassword(u, upd, true); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// checkNewPassword проверка параметров смены пароля: для E2E аккаунта нужны новые KDF и перешифрованный
+// ключ хранилища, для обычного аккаунта пароль проверяется по политике.
+func (s *Service) checkNewPassword(u *User, upd CredentialsUpdate, passwordChanged bool) error {
+	rewrap := upd.KDF != nil || len(upd.WrappedVaultKey) != 0
+	switch {
+	case u.IsE2E() && passwordChanged:
+		if err := upd.KDF.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidE2EParams, err)
+		}
+		if len(upd.WrappedVaultKey) == 0 {
+			return fmt.Errorf("%w: wrapped vault key is empty", ErrInvalidE2EParams)
+		}
+	case rewrap:
+		// Ключ хранилища перешифровывается только вместе со сменой мастер-пароля E2E аккаунта
+		return fmt.Errorf("%w: vault key is rewrapped only on E2E password change", ErrInvalidE2EParams)
+	case passwordChanged:
+		return s.checkPassword(upd.NewPassword, u.Login, upd.NewLogin)
+	}
+
+	return nil
+}
+
+// applyPassword установка нового пароля, а для E2E аккаунта и нового ключа хранилища.
+func (s *Service) applyPassword(u *User, upd CredentialsUpdate) error {
+	if err := u.SetPassword(upd.NewPassword, s.hasher); err != nil {
+		return err
+	}
+	if u.IsE2E() {
+		u.KDF = upd.KDF
+		u.WrappedVaultKey = upd.WrappedVaultKey
+	}
+
+	return nil
+}
+
+// checkPassword проверка нового пароля по политике. Пароль E2E аккаунта сервер не видит:
+// вместо него приходит ключ аутентификации, поэтому для таких аккаунтов политику проверяет клиент.
+func (s *Service) checkPassword(password string, userInputs ...string) error {
+	if s.policy == nil {
+		return nil
+	}
+
+	if err := s.policy.Check(password, userInputs...); err != nil {
+		return fmt.Errorf("%w: %w", ErrWeakPassword, err)
+	}
+
+	return nil
+}
+
 // GetUserByID получение пользователя по ID.
 func (s *Service) GetUserByID(ctx context.Context, userID int) (*User, error) {
 	op := "domani.User.service.GetUserByID"
@@ -121,3 +501,201 @@ func (s *Service) GetUserByID(ctx context.Context, userID int) (*User, error) {
 
 	return user, nil
 }
+
+// GetUserByLogin получение пользователя по логину.
+func (s *Service) GetUserByLogin(ctx context.Context, login string) (*User, error) {
+	u, err := s.repo.GetByLogin(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("domain.User.service.GetUserByLogin: failed to get user by login %w", err)
+	}
+
+	return u, nil
+}
+
+// SetShareKeys сохранение пары ключей E2E аккаунта для получения доступа к чужим секретам.
+// Пара создается клиентом один раз, закрытый ключ приходит зашифрованным ключом хранилища.
+func (s *Service) SetShareKeys(ctx context.Context, userID int, publicKey, wrappedPrivateKey []byte) error {
+	op := "domain.User.service.SetShareKeys"
+
+	if len(publicKey) == 0 || len(wrappedPrivateKey) == 0 {
+		return ErrInvalidE2EParams
+	}
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user by ID %w", op, err)
+	}
+	if !u.IsE2E() {
+		return ErrNotE2E
+	}
+	if u.HasShareKeys() {
+		return ErrAlreadyExist
+	}
+
+	if err = s.repo.SetShareKeys(ctx, userID, publicKey, wrappedPrivateKey); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ScheduleDeletion запрос удаления аккаунта после подтверждения паролем. Аккаунт удаляется окончательно
+// через grace, до этого удаление можно отменить. Повторный запрос не переносит уже назначенный срок.
+func (s *Service) ScheduleDeletion(
+	ctx context.Context,
+	userID int,
+	password string,
+	grace time.Duration,
+) (*User, error) {
+	op := "domain.User.service.ScheduleDeletion"
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get user by ID %w", op, err)
+	}
+
+	if err = s.verifyPassword(u, password); err != nil {
+		return nil, s.record(ctx, u.ID, u.Login, audit.ActionDelete, err)
+	}
+
+	if u.DeletionScheduled() {
+		return u, nil
+	}
+
+	u.DeleteAfter = time.Now().Add(grace)
+	if err = s.repo.SetDeleteAfter(ctx, u.ID, u.DeleteAfter); err != nil {
+		return nil, fmt.Errorf("%s: failed to schedule deletion %w", op, err)
+	}
+
+	if err = s.record(ctx, u.ID, u.Login, audit.ActionDelete, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return u, nil
+}
+
+// CancelDeletion отмена запрошенного удаления аккаунта.
+func (s *Service) CancelDeletion(ctx context.Context, userID int) error {
+	op := "domain.User.service.CancelDeletion"
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user by ID %w", op, err)
+	}
+
+	if !u.DeletionScheduled() {
+		return ErrDeletionNotScheduled
+	}
+
+	if err = s.repo.SetDeleteAfter(ctx, u.ID, time.Time{}); err != nil {
+		return fmt.Errorf("%s: failed to cancel deletion %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeDeleted окончательное удаление аккаунтов, срок удаления которых наступил к now.
+// Сначала стираются данные вне базы, затем строки в базе: если стереть файлы не удалось,
+// пользователь остается в очереди и удаляется при следующем запуске. Возвращает число удаленных аккаунтов.
+func (s *Service) PurgeDeleted(ctx context.Context, now time.Time, erase DataEraser) (int, error) {
+	op := "domain.User.service.PurgeDeleted"
+
+	users, err := s.repo.ListDueForDeletion(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to list accounts due for deletion %w", op, err)
+	}
+
+	var (
+		purged int
+		errs   []error
+	)
+	for _, u := range users {
+		if err = erase(ctx, u.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to erase data of user %d %w", u.ID, err))
+			continue
+		}
+		if err = s.repo.Delete(ctx, u.ID); err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, fmt.Errorf("failed to delete user %d %w", u.ID, err))
+			continue
+		}
+		purged++
+	}
+
+	if len(errs) > 0 {
+		return purged, fmt.Errorf("%s: %w", op, errors.Join(errs...))
+	}
+
+	return purged, nil
+}
+
+// CheckActive проверка, что пользователь существует и не заблокирован. Вызывается на каждый запрос с токеном,
+// поэтому блокировка действует сразу, даже для еще не истекших токенов доступа.
+func (s *Service) CheckActive(ctx context.Context, userID int) error {
+	op := "domain.User.service.CheckActive"
+
+	u, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: failed to get user by ID %w", op, err)
+	}
+
+	if u.Disabled() {
+		return ErrDisabled
+	}
+
+	return nil
+}
+
+// List все пользователи.
+func (s *Service) List(ctx context.Context) ([]*User, error) {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("domain.User.service.List: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetDisabled блокировка или разблокировка пользователя администратором actorID
+// (0 для оператора). Заблокировать себя нельзя, чтобы не остаться без администраторов.
+func (s *Service) SetDisabled(ctx context.Context, actorID, userID int, disabled bool) error {
+	op := "domain.User.service.SetDisabled"
+
+	if disabled && actorID == userID {
+		return ErrSelfAdminAction
+	}
+
+	var at time.Time
+	if disabled {
+		at = time.Now()
+	}
+
+	if err := s.repo.SetDisabledAt(ctx, userID, at); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetAdmin выдача или снятие прав администратора. Снять права с себя нельзя.
+func (s *Service) SetAdmin(ctx context.Context, actorID, userID int, admin bool) error {
+	op := "domain.User.service.SetAdmin"
+
+	if !admin && actorID == userID {
+		return ErrSelfAdminAction
+	}
+
+	if err := s.repo.SetAdmin(ctx, userID, admin); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
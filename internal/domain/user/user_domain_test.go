@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -16,6 +19,18 @@ const (
 	pepper   = "supersecrethashforpepper"
 )
 
+// hasher исходная схема bcrypt с минимальной стоимостью, чтобы тесты не тратили время на хэширование.
+var hasher = func() *passhash.Hasher {
+	h, err := passhash.New(
+		passhash.Params{Scheme: passhash.SchemeBcrypt, BcryptCost: bcrypt.MinCost},
+		map[string]string{"": pepper},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}()
+
 func TestNewUser(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -33,7 +48,7 @@ func TestNewUser(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			u, err := user.NewUser(test.login, test.password, pepper)
+			u, err := user.NewUser(test.login, test.password, hasher)
 
 			if test.wantErr {
 				require.Error(t, err)
@@ -48,7 +63,7 @@ func TestNewUser(t *testing.T) {
 }
 
 func ExampleNewUser() {
-	u, err := user.NewUser(login, password, pepper)
+	u, err := user.NewUser(login, password, hasher)
 	if err != nil {
 		panic("fail to create user")
 	}
@@ -59,7 +74,7 @@ func ExampleNewUser() {
 }
 
 func TestUser_VerifyUserPassword(t *testing.T) {
-	u, err := user.NewUser(login, password, pepper)
+	u, err := user.NewUser(login, password, hasher)
 	require.NoError(t, err)
 
 	wrongPass := "wrong"
@@ -83,7 +98,8 @@ func TestUser_VerifyUserPassword(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			ok := u.VerifyUserPassword(test.password, pepper)
+			ok, err := u.VerifyUserPassword(test.password, hasher)
+			require.NoError(t, err)
 
 			if test.verified {
 				require.True(t, ok)
@@ -93,3 +109,51 @@ func TestUser_VerifyUserPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestNewE2EUser(t *testing.T) {
+	kdf, err := encryptor.NewKDFParams()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		kdf        *encryptor.KDFParams
+		wrappedKey []byte
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			kdf:        kdf,
+			wrappedKey: []byte("wrapped"),
+			wantErr:    false,
+		},
+		{
+			name:       "empty kdf params",
+			kdf:        nil,
+			wrappedKey: []byte("wrapped"),
+			wantErr:    true,
+		},
+		{
+			name:       "empty vault key",
+			kdf:        kdf,
+			wrappedKey: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			u, err := user.NewE2EUser(login, "authkey", hasher, test.kdf, test.wrappedKey)
+
+			if test.wantErr {
+				require.ErrorIs(t, err, user.ErrInvalidE2EParams)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, u.IsE2E())
+			ok, err := u.VerifyUserPassword("authkey", hasher)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package user_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
 )
 
 // mockUserRepo реализует Repository для тестирования
@@ -15,6 +22,16 @@ type mockUserRepo struct {
 	getByLoginFunc func(ctx context.Context, login string) (*user.User, error)
 	updateFunc     func(ctx context.Context, user *user.User) error
 	deleteFunc     func(ctx context.Context, id int) error
+	deleteAfterFn  func(ctx context.Context, id int, at time.Time) error
+	listDueFunc    func(ctx context.Context, now time.Time) ([]*user.User, error)
+	updateHashFunc func(ctx context.Context, id int, old string, hash passhash.Hash) error
+	countHashFunc  func(ctx context.Context) ([]user.HashCount, error)
+	createInvited  func(ctx context.Context, user *user.User, codeHash []byte, now time.Time) error
+	createInvite   func(ctx context.Context, inv *user.Invite) error
+	listFunc       func(ctx context.Context) ([]*user.User, error)
+	disabledAtFunc func(ctx context.Context, id int, at time.Time) error
+	setAdminFunc   func(ctx context.Context, id int, admin bool) error
+	shareKeysFunc  func(ctx context.Context, id int, publicKey, wrappedPrivateKey []byte) error
 }
 
 func (m *mockUserRepo) Create(ctx context.Context, user *user.User) error {
@@ -37,13 +54,52 @@ func (m *mockUserRepo) Delete(ctx context.Context, id int) error {
 	return m.deleteFunc(ctx, id)
 }
 
+func (m *mockUserRepo) SetDeleteAfter(ctx context.Context, id int, at time.Time) error {
+	return m.deleteAfterFn(ctx, id, at)
+}
+
+func (m *mockUserRepo) ListDueForDeletion(ctx context.Context, now time.Time) ([]*user.User, error) {
+	return m.listDueFunc(ctx, now)
+}
+
+func (m *mockUserRepo) UpdatePasswordHash(ctx context.Context, id int, old string, hash passhash.Hash) error {
+	return m.updateHashFunc(ctx, id, old, hash)
+}
+
+func (m *mockUserRepo) CountPasswordHashes(ctx context.Context) ([]user.HashCount, error) {
+	return m.countHashFunc(ctx)
+}
+
+func (m *mockUserRepo) CreateWithInvite(ctx context.Context, user *user.User, codeHash []byte, now time.Time) error {
+	return m.createInvited(ctx, user, codeHash, now)
+}
+
+func (m *mockUserRepo) CreateInvite(ctx context.Context, inv *user.Invite) error {
+	return m.createInvite(ctx, inv)
+}
+
+func (m *mockUserRepo) List(ctx context.Context) ([]*user.User, error) {
+	return m.listFunc(ctx)
+}
+
+func (m *mockUserRepo) SetDisabledAt(ctx context.Context, id int, at time.Time) error {
+	return m.disabledAtFunc(ctx, id, at)
+}
+
+func (m *mockUserRepo) SetAdmin(ctx context.Context, id int, admin bool) error {
+	return m.setAdminFunc(ctx, id, admin)
+}
+
+func (m *mockUserRepo) SetShareKeys(ctx context.Context, id int, publicKey, wrappedPrivateKey []byte) error {
+	return m.shareKeysFunc(ctx, id, publicKey, wrappedPrivateKey)
+}
+
 func TestService_Register(t *testing.T) {
 	tests := []struct {
 		name      string
 		repoSetup func() *mockUserRepo
 		login     string
 		password  string
-		pepper    string
 		wantUser  bool
 		wantErr   error
 	}{
@@ -61,7 +117,6 @@ func TestService_Register(t *testing.T) {
 			},
 			login:    "newuser",
 			password: "secure123",
-			pepper:   "pepper",
 			wantUser: true,
 		},
 		{
@@ -75,15 +130,14 @@ func TestService_Register(t *testing.T) {
 			},
 			login:    "existing",
 			password: "password",
-			pepper:   "pepper",
 			wantErr:  user.ErrAlreadyExist,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := user.NewService(tt.repoSetup())
-			user, err := s.Register(context.Background(), tt.login, tt.password, tt.pepper)
+			s := user.NewService(tt.repoSetup(), nil, hasher, nil, nil)
+			user, err := s.Register(context.Background(), tt.login, tt.password, "")
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -111,7 +165,7 @@ func TestService_Register(t *testing.T) {
 }
 
 func TestService_Login(t *testing.T) {
-	validUser, _ := user.NewUser("valid", "password", "pepper")
+	validUser, _ := user.NewUser("valid", "password", hasher)
 	validUser.ID = 1
 
 	tests := []struct {
@@ -119,7 +173,6 @@ func TestService_Login(t *testing.T) {
 		repoSetup func() *mockUserRepo
 		login     string
 		password  string
-		pepper    string
 		wantUser  bool
 		wantErr   error
 	}{
@@ -134,7 +187,6 @@ func TestService_Login(t *testing.T) {
 			},
 			login:    "valid",
 			password: "password",
-			pepper:   "pepper",
 			wantUser: true,
 		},
 		{
@@ -148,15 +200,27 @@ func TestService_Login(t *testing.T) {
 			},
 			login:    "nonexistent",
 			password: "password",
-			pepper:   "pepper",
-			wantErr:  user.ErrNotFound,
+			wantErr:  user.ErrInvalidCredentials,
+		},
+		{
+			name: "wrong password",
+			repoSetup: func() *mockUserRepo {
+				return &mockUserRepo{
+					getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+						return validUser, nil
+					},
+				}
+			},
+			login:    "valid",
+			password: "wrong",
+			wantErr:  user.ErrInvalidCredentials,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := user.NewService(tt.repoSetup())
-			user, err := s.Login(context.Background(), tt.login, tt.password, tt.pepper)
+			s := user.NewService(tt.repoSetup(), nil, hasher, nil, nil)
+			user, err := s.Login(context.Background(), tt.login, tt.password)
 
 			if tt.wantErr != nil {
 				if err == nil {
@@ -183,6 +247,168 @@ func TestService_Login(t *testing.T) {
 	}
 }
 
+func TestService_GetKDFParams(t *testing.T) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e2eUser, err := user.NewE2EUser("e2e", "auth-key", hasher, kdf, []byte("wrapped"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverUser, err := user.NewUser("server", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			switch login {
+			case e2eUser.Login:
+				return e2eUser, nil
+			case serverUser.Login:
+				return serverUser, nil
+			}
+			return nil, user.ErrNotFound
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, []byte("decoy secret"))
+
+	t.Run("unknown login looks like e2e account", func(t *testing.T) {
+		e2eMode, e2eKDF, err := s.GetKDFParams(context.Background(), e2eUser.Login)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ghostMode, ghostKDF, err := s.GetKDFParams(context.Background(), "ghost")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Отличаться может только соль: параметры и ее длина те же, что у настоящего аккаунта
+		if ghostMode != e2eMode {
+			t.Errorf("unknown login mode differs: got %s, want %s", ghostMode, e2eMode)
+		}
+		if ghostKDF.Time != e2eKDF.Time || ghostKDF.Memory != e2eKDF.Memory || ghostKDF.Threads != e2eKDF.Threads ||
+			len(ghostKDF.Salt) != len(e2eKDF.Salt) {
+			t.Errorf("unknown login params differ: got %+v, want %+v", ghostKDF, e2eKDF)
+		}
+		if err = ghostKDF.Validate(); err != nil {
+			t.Errorf("decoy params are invalid: %v", err)
+		}
+
+		// Повторный запрос не выдает фиктивный ответ сменой соли
+		_, again, err := s.GetKDFParams(context.Background(), "ghost")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(again.Salt, ghostKDF.Salt) {
+			t.Error("decoy salt changes between requests")
+		}
+		_, other, err := s.GetKDFParams(context.Background(), "ghost2")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bytes.Equal(other.Salt, ghostKDF.Salt) {
+			t.Error("decoy salt is the same for different logins")
+		}
+	})
+
+	t.Run("server account", func(t *testing.T) {
+		mode, got, err := s.GetKDFParams(context.Background(), serverUser.Login)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mode != user.EncryptionModeServer || got != nil {
+			t.Errorf("unexpected response: got %s %v, want %s without params", mode, got, user.EncryptionModeServer)
+		}
+	})
+
+	t.Run("e2e account", func(t *testing.T) {
+		mode, got, err := s.GetKDFParams(context.Background(), e2eUser.Login)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mode != user.EncryptionModeE2E || got != kdf {
+			t.Errorf("unexpected response: got %s %v, want %s %v", mode, got, user.EncryptionModeE2E, kdf)
+		}
+	})
+}
+
+// recordingAuditor реализует Auditor для тестирования, запоминая записи журнала
+type recordingAuditor struct {
+	events []*audit.Event
+	err    error
+}
+
+func (r *recordingAuditor) Record(_ context.Context, events ...*audit.Event) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func TestService_LoginAudit(t *testing.T) {
+	validUser, _ := user.NewUser("valid", "password", hasher)
+	validUser.ID = 1
+
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			if login != validUser.Login {
+				return nil, user.ErrNotFound
+			}
+			return validUser, nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		login      string
+		password   string
+		wantActor  int
+		wantAction audit.Action
+		wantResult audit.Result
+	}{
+		{"success", "valid", "password", 1, audit.ActionLogin, audit.ResultSuccess},
+		{"wrong password", "valid", "wrong", 1, audit.ActionLoginFailed, audit.ResultFailure},
+		{"unknown login", "ghost", "password", 0, audit.ActionLoginFailed, audit.ResultFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditor := &recordingAuditor{}
+			s := user.NewService(repo, nil, hasher, auditor, nil)
+			_, _ = s.Login(context.Background(), tt.login, tt.password)
+
+			if len(auditor.events) != 1 {
+				t.Fatalf("expected 1 audit event, got %d", len(auditor.events))
+			}
+			ev := auditor.events[0]
+			if ev.ActorID != tt.wantActor || ev.Login != tt.login {
+				t.Errorf("unexpected actor: got %d %q, want %d %q", ev.ActorID, ev.Login, tt.wantActor, tt.login)
+			}
+			if ev.Action != tt.wantAction || ev.Result != tt.wantResult {
+				t.Errorf("unexpected event: got %s/%s, want %s/%s", ev.Action, ev.Result, tt.wantAction, tt.wantResult)
+			}
+		})
+	}
+
+	t.Run("audit failure fails login", func(t *testing.T) {
+		s := user.NewService(repo, nil, hasher, &recordingAuditor{err: errors.New("db down")}, nil)
+		if _, err := s.Login(context.Background(), "valid", "password"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("audit failure keeps login error", func(t *testing.T) {
+		s := user.NewService(repo, nil, hasher, &recordingAuditor{err: errors.New("db down")}, nil)
+		_, err := s.Login(context.Background(), "valid", "wrong")
+		if !errors.Is(err, user.ErrInvalidCredentials) {
+			t.Errorf("unexpected error: got %v, want %v", err, user.ErrInvalidCredentials)
+		}
+	})
+}
+
 func TestService_Update(t *testing.T) {
 	existingUser := &user.User{ID: 1, Login: "existing"}
 
@@ -222,7 +448,7 @@ func TestService_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := user.NewService(tt.repoSetup())
+			s := user.NewService(tt.repoSetup(), nil, hasher, nil, nil)
 			err := s.Update(context.Background(), tt.user)
 
 			if tt.wantErr != nil {
@@ -280,7 +506,7 @@ func TestService_GetUserByID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := user.NewService(tt.repoSetup())
+			s := user.NewService(tt.repoSetup(), nil, hasher, nil, nil)
 			user, err := s.GetUserByID(context.Background(), tt.userID)
 
 			if tt.wantErr != nil {
@@ -308,6 +534,652 @@ func TestService_GetUserByID(t *testing.T) {
 	}
 }
 
+func TestService_UpdateCredentials(t *testing.T) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		e2e       bool
+		taken     bool
+		upd       user.CredentialsUpdate
+		wantLogin string
+		wantPass  string
+		wantErr   error
+	}{
+		{
+			name:      "change login",
+			upd:       user.CredentialsUpdate{NewLogin: "renamed", OldPassword: "password"},
+			wantLogin: "renamed",
+			wantPass:  "password",
+		},
+		{
+			name:      "change password",
+			upd:       user.CredentialsUpdate{OldPassword: "password", NewPassword: "new-password"},
+			wantLogin: "valid",
+			wantPass:  "new-password",
+		},
+		{
+			name:    "wrong old password",
+			upd:     user.CredentialsUpdate{NewLogin: "renamed", OldPassword: "wrong"},
+			wantErr: user.ErrInvalidCredentials,
+		},
+		{
+			name:    "login taken",
+			taken:   true,
+			upd:     user.CredentialsUpdate{NewLogin: "renamed", OldPassword: "password"},
+			wantErr: user.ErrAlreadyExist,
+		},
+		{
+			name:    "nothing to update",
+			upd:     user.CredentialsUpdate{NewLogin: "valid", OldPassword: "password"},
+			wantErr: user.ErrNothingToUpdate,
+		},
+		{
+			name:    "vault key for server account",
+			upd:     user.CredentialsUpdate{OldPassword: "password", NewPassword: "new", KDF: kdf},
+			wantErr: user.ErrInvalidE2EParams,
+		},
+		{
+			name:    "e2e password without new vault key",
+			e2e:     true,
+			upd:     user.CredentialsUpdate{OldPassword: "password", NewPassword: "new", KDF: kdf},
+			wantErr: user.ErrInvalidE2EParams,
+		},
+		{
+			name: "e2e password with rewrapped vault key",
+			e2e:  true,
+			upd: user.CredentialsUpdate{
+				OldPassword: "password", NewPassword: "new-auth-key", KDF: kdf, WrappedVaultKey: []byte("rewrapped"),
+			},
+			wantLogin: "valid",
+			wantPass:  "new-auth-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				existing *user.User
+				err      error
+			)
+			if tt.e2e {
+				existing, err = user.NewE2EUser("valid", "password", hasher, kdf, []byte("wrapped"))
+			} else {
+				existing, err = user.NewUser("valid", "password", hasher)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			existing.ID = 1
+
+			var saved *user.User
+			repo := &mockUserRepo{
+				getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+					return existing, nil
+				},
+				getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+					if tt.taken {
+						return &user.User{ID: 2, Login: login}, nil
+					}
+					return nil, user.ErrNotFound
+				},
+				updateFunc: func(ctx context.Context, u *user.User) error {
+					saved = u
+					return nil
+				},
+			}
+
+			s := user.NewService(repo, nil, hasher, nil, nil)
+			_, err = s.UpdateCredentials(context.Background(), 1, tt.upd)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %v", err, tt.wantErr)
+				}
+				if saved != nil {
+					t.Error("user must not be saved on error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved == nil {
+				t.Fatal("expected user to be saved")
+			}
+			if saved.Login != tt.wantLogin {
+				t.Errorf("unexpected login: got %s, want %s", saved.Login, tt.wantLogin)
+			}
+			if ok, err := saved.VerifyUserPassword(tt.wantPass, hasher); err != nil || !ok {
+				t.Error("saved password hash does not match new password")
+			}
+			if tt.e2e && string(saved.WrappedVaultKey) != string(tt.upd.WrappedVaultKey) {
+				t.Error("vault key was not rewrapped")
+			}
+		})
+	}
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		e2e      bool
+		upd      user.CredentialsUpdate
+		wantPass string
+		wantErr  error
+	}{
+		{
+			name:     "reset without old password",
+			upd:      user.CredentialsUpdate{NewPassword: "new-password"},
+			wantPass: "new-password",
+		},
+		{
+			name:    "empty password",
+			upd:     user.CredentialsUpdate{},
+			wantErr: user.ErrNothingToUpdate,
+		},
+		{
+			name:    "e2e without new vault key",
+			e2e:     true,
+			upd:     user.CredentialsUpdate{NewPassword: "new-auth-key", KDF: kdf},
+			wantErr: user.ErrInvalidE2EParams,
+		},
+		{
+			name:     "e2e with rewrapped vault key",
+			e2e:      true,
+			upd:      user.CredentialsUpdate{NewPassword: "new-auth-key", KDF: kdf, WrappedVaultKey: []byte("rewrapped")},
+			wantPass: "new-auth-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var existing *user.User
+			if tt.e2e {
+				existing, err = user.NewE2EUser("valid", "password", hasher, kdf, []byte("wrapped"))
+			} else {
+				existing, err = user.NewUser("valid", "password", hasher)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			existing.ID = 1
+
+			var saved *user.User
+			repo := &mockUserRepo{
+				getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+					return existing, nil
+				},
+				updateFunc: func(ctx context.Context, u *user.User) error {
+					saved = u
+					return nil
+				},
+			}
+
+			s := user.NewService(repo, nil, hasher, nil, nil)
+
+			// Предварительная проверка дает тот же ответ и ничего не сохраняет
+			checkErr := s.CheckResetPassword(context.Background(), 1, tt.upd)
+			if !errors.Is(checkErr, tt.wantErr) {
+				t.Fatalf("unexpected check error: got %v, want %v", checkErr, tt.wantErr)
+			}
+			if saved != nil {
+				t.Fatal("user must not be saved by check")
+			}
+
+			_, err := s.ResetPassword(context.Background(), 1, tt.upd)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %v", err, tt.wantErr)
+				}
+				if saved != nil {
+					t.Error("user must not be saved on error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved == nil || saved.Login != "valid" {
+				t.Fatal("expected user to be saved with the same login")
+			}
+			if ok, err := saved.VerifyUserPassword(tt.wantPass, hasher); err != nil || !ok {
+				t.Error("saved password hash does not match new password")
+			}
+			if tt.e2e && string(saved.WrappedVaultKey) != string(tt.upd.WrappedVaultKey) {
+				t.Error("vault key was not rewrapped")
+			}
+		})
+	}
+}
+
+func TestService_ScheduleDeletion(t *testing.T) {
+	const grace = 24 * time.Hour
+
+	existing, err := user.NewUser("valid", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	existing.ID = 1
+
+	var scheduled time.Time
+	repo := &mockUserRepo{
+		getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+			u := *existing
+			u.DeleteAfter = scheduled
+			return &u, nil
+		},
+		deleteAfterFn: func(ctx context.Context, id int, at time.Time) error {
+			scheduled = at
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	_, err = s.ScheduleDeletion(context.Background(), 1, "wrong", grace)
+	if !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrInvalidCredentials)
+	}
+	if !scheduled.IsZero() {
+		t.Fatal("deletion must not be scheduled with wrong password")
+	}
+
+	before := time.Now()
+	u, err := s.ScheduleDeletion(context.Background(), 1, "password", grace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.DeleteAfter.Before(before.Add(grace)) || !u.DeleteAfter.Equal(scheduled) {
+		t.Errorf("unexpected deletion time: got %v, stored %v", u.DeleteAfter, scheduled)
+	}
+
+	// Повторный запрос не переносит срок удаления
+	first := scheduled
+	if _, err = s.ScheduleDeletion(context.Background(), 1, "password", 2*grace); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !scheduled.Equal(first) {
+		t.Error("repeated request must not move deletion time")
+	}
+
+	if err = s.CancelDeletion(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !scheduled.IsZero() {
+		t.Error("deletion must be canceled")
+	}
+
+	if err = s.CancelDeletion(context.Background(), 1); !errors.Is(err, user.ErrDeletionNotScheduled) {
+		t.Errorf("unexpected error: got %v, want %v", err, user.ErrDeletionNotScheduled)
+	}
+}
+
+func TestService_PurgeDeleted(t *testing.T) {
+	now := time.Now()
+	due := []*user.User{
+		{ID: 1, Login: "first", DeleteAfter: now.Add(-time.Hour)},
+		{ID: 2, Login: "second", DeleteAfter: now.Add(-time.Minute)},
+	}
+
+	deleted := map[int]bool{}
+	repo := &mockUserRepo{
+		listDueFunc: func(ctx context.Context, at time.Time) ([]*user.User, error) {
+			if !at.Equal(now) {
+				t.Errorf("unexpected purge time: got %v, want %v", at, now)
+			}
+			return due, nil
+		},
+		deleteFunc: func(ctx context.Context, id int) error {
+			deleted[id] = true
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	errErase := errors.New("disk is busy")
+	erased := map[int]bool{}
+	erase := func(ctx context.Context, userID int) error {
+		if userID == 2 {
+			return errErase
+		}
+		erased[userID] = true
+		return nil
+	}
+
+	purged, err := s.PurgeDeleted(context.Background(), now, erase)
+	if !errors.Is(err, errErase) {
+		t.Fatalf("unexpected error: got %v, want %v", err, errErase)
+	}
+	if purged != 1 {
+		t.Errorf("unexpected purged count: got %d, want 1", purged)
+	}
+	if !erased[1] || !deleted[1] {
+		t.Error("first user must be erased and deleted")
+	}
+	// Пользователь, файлы которого стереть не удалось, остается в базе до следующей очистки
+	if deleted[2] {
+		t.Error("second user must not be deleted before its data is erased")
+	}
+}
+
+func TestService_PasswordPolicy(t *testing.T) {
+	policy := passpolicy.New(10, 2, 3, nil)
+
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e2eUser, err := user.NewE2EUser("e2e", "auth-key", hasher, kdf, []byte("wrapped"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverUser, err := user.NewUser("john", "old-password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			return nil, user.ErrNotFound
+		},
+		getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+			if id == 2 {
+				u := *e2eUser
+				return &u, nil
+			}
+			u := *serverUser
+			return &u, nil
+		},
+		createFunc: func(ctx context.Context, u *user.User) error { return nil },
+		updateFunc: func(ctx context.Context, u *user.User) error { return nil },
+	}
+	s := user.NewService(repo, policy, hasher, nil, nil)
+
+	_, err = s.Register(context.Background(), "john", "qwerty123", "")
+	if !errors.Is(err, user.ErrWeakPassword) || !errors.Is(err, passpolicy.ErrWeakPassword) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrWeakPassword)
+	}
+
+	if _, err = s.Register(context.Background(), "john", "vT7#qLp9!wZ2", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = s.UpdateCredentials(context.Background(), 1, user.CredentialsUpdate{
+		OldPassword: "old-password", NewPassword: "john12345678",
+	})
+	if !errors.Is(err, user.ErrWeakPassword) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrWeakPassword)
+	}
+
+	// Смена только логина не проверяет действующий пароль по политике
+	if _, err = s.UpdateCredentials(context.Background(), 1, user.CredentialsUpdate{
+		NewLogin: "johnny", OldPassword: "old-password",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Для E2E аккаунта сервер получает ключ аутентификации, а не пароль, и политику не проверяет
+	if _, err = s.UpdateCredentials(context.Background(), 2, user.CredentialsUpdate{
+		OldPassword: "auth-key", NewPassword: "short", KDF: kdf, WrappedVaultKey: []byte("rewrapped"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestService_LoginRehash(t *testing.T) {
+	legacy, err := user.NewUser("john", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	legacy.ID = 1
+	oldHash := legacy.PassHash
+
+	current, err := passhash.New(passhash.Params{
+		Scheme:   passhash.SchemeArgon2id,
+		Argon2:   passhash.Argon2Params{Memory: 1024, Time: 1, Threads: 1},
+		PepperID: "2025",
+	}, map[string]string{"": pepper, "2025": "new-pepper"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var saved *passhash.Hash
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			return legacy, nil
+		},
+		updateHashFunc: func(ctx context.Context, id int, old string, hash passhash.Hash) error {
+			if old != oldHash {
+				t.Errorf("unexpected old hash: got %s, want %s", old, oldHash)
+			}
+			saved = &hash
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, current, nil, nil)
+
+	// Неверный пароль хэш не меняет
+	if _, err = s.Login(context.Background(), "john", "wrong"); !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrInvalidCredentials)
+	}
+	if saved != nil {
+		t.Fatal("hash must not be updated on failed login")
+	}
+
+	u, err := s.Login(context.Background(), "john", "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil {
+		t.Fatal("expected legacy hash to be rehashed")
+	}
+	if saved.Scheme != passhash.SchemeArgon2id || saved.PepperID != "2025" {
+		t.Errorf("unexpected new hash scheme %s and pepper %q", saved.Scheme, saved.PepperID)
+	}
+	if ok, err := u.VerifyUserPassword("password", current); err != nil || !ok {
+		t.Error("rehashed password does not match")
+	}
+
+	// Текущий хэш повторно не пересчитывается, а ошибка сохранения не отклоняет вход
+	saved = nil
+	if _, err = s.Login(context.Background(), "john", "password"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved != nil {
+		t.Error("current hash must not be rehashed")
+	}
+
+	stale, err := user.NewUser("jane", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo.getByLoginFunc = func(ctx context.Context, login string) (*user.User, error) {
+		return stale, nil
+	}
+	repo.updateHashFunc = func(ctx context.Context, id int, old string, hash passhash.Hash) error {
+		return errors.New("db is down")
+	}
+	u, err = s.Login(context.Background(), "jane", "password")
+	if !errors.Is(err, user.ErrRehashFailed) || u == nil {
+		t.Fatalf("unexpected result: user %v, error %v", u, err)
+	}
+}
+
+func TestService_PasswordHashReport(t *testing.T) {
+	repo := &mockUserRepo{
+		countHashFunc: func(ctx context.Context) ([]user.HashCount, error) {
+			return []user.HashCount{
+				{Scheme: passhash.SchemeBcrypt, Users: 5},
+				{Scheme: passhash.SchemeBcrypt, PepperID: "2025", Users: 2},
+			}, nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	report, err := s.PasswordHashReport(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Total != 7 || report.Legacy != 2 {
+		t.Errorf("unexpected totals: total %d, legacy %d", report.Total, report.Legacy)
+	}
+	if !report.Counts[0].Current || report.Counts[1].Current {
+		t.Errorf("unexpected current flags: %+v", report.Counts)
+	}
+}
+
+func TestService_RegisterWithInvite(t *testing.T) {
+	invites := map[string]bool{}
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			return nil, user.ErrNotFound
+		},
+		createInvite: func(ctx context.Context, inv *user.Invite) error {
+			invites[string(inv.CodeHash)] = true
+			return nil
+		},
+		createInvited: func(ctx context.Context, u *user.User, codeHash []byte, now time.Time) error {
+			if !invites[string(codeHash)] {
+				return user.ErrInvalidInvite
+			}
+			delete(invites, string(codeHash))
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	inv, code, err := s.CreateInvite(context.Background(), 1, "for john", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inv.CreatedBy != 1 || !inv.ExpiresAt.After(time.Now()) {
+		t.Errorf("unexpected invite: %+v", inv)
+	}
+
+	// Код вводится без учета регистра и с пробелами по краям и погашается один раз
+	if _, err = s.Register(context.Background(), "john", "password", " "+strings.ToLower(code)+" "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = s.Register(context.Background(), "jane", "password", code)
+	if !errors.Is(err, user.ErrInvalidInvite) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrInvalidInvite)
+	}
+}
+
+func TestService_DisabledUser(t *testing.T) {
+	u, err := user.NewUser("john", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u.ID = 2
+
+	repo := &mockUserRepo{
+		getByLoginFunc: func(ctx context.Context, login string) (*user.User, error) {
+			return u, nil
+		},
+		getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+			if id != u.ID {
+				return nil, user.ErrNotFound
+			}
+			return u, nil
+		},
+		disabledAtFunc: func(ctx context.Context, id int, at time.Time) error {
+			u.DisabledAt = at
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	if err = s.SetDisabled(context.Background(), 2, 2, true); !errors.Is(err, user.ErrSelfAdminAction) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrSelfAdminAction)
+	}
+
+	if err = s.SetDisabled(context.Background(), 1, 2, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = s.CheckActive(context.Background(), 2); !errors.Is(err, user.ErrDisabled) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrDisabled)
+	}
+	if _, err = s.Login(context.Background(), "john", "password"); !errors.Is(err, user.ErrDisabled) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrDisabled)
+	}
+	// Неверный пароль не раскрывает, что аккаунт заблокирован
+	if _, err = s.Login(context.Background(), "john", "wrong"); !errors.Is(err, user.ErrInvalidCredentials) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrInvalidCredentials)
+	}
+
+	if err = s.SetDisabled(context.Background(), 0, 2, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = s.CheckActive(context.Background(), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = s.CheckActive(context.Background(), 3); !errors.Is(err, user.ErrNotFound) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrNotFound)
+	}
+}
+
+func TestService_SetShareKeys(t *testing.T) {
+	kdf, err := encryptor.NewKDFParams()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e2eUser, err := user.NewE2EUser("e2e", "auth-key", hasher, kdf, []byte("wrapped"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e2eUser.ID = 1
+	serverUser, err := user.NewUser("john", "password", hasher)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverUser.ID = 2
+
+	repo := &mockUserRepo{
+		getByIDFunc: func(ctx context.Context, id int) (*user.User, error) {
+			if id == e2eUser.ID {
+				return e2eUser, nil
+			}
+			return serverUser, nil
+		},
+		shareKeysFunc: func(ctx context.Context, id int, publicKey, wrappedPrivateKey []byte) error {
+			e2eUser.PublicKey, e2eUser.WrappedPrivateKey = publicKey, wrappedPrivateKey
+			return nil
+		},
+	}
+	s := user.NewService(repo, nil, hasher, nil, nil)
+
+	if err = s.SetShareKeys(context.Background(), 1, nil, []byte("private")); !errors.Is(err, user.ErrInvalidE2EParams) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrInvalidE2EParams)
+	}
+	if err = s.SetShareKeys(context.Background(), 2, []byte("public"), []byte("private")); !errors.Is(err, user.ErrNotE2E) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrNotE2E)
+	}
+
+	if err = s.SetShareKeys(context.Background(), 1, []byte("public"), []byte("private")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !e2eUser.HasShareKeys() {
+		t.Fatal("expected share keys to be saved")
+	}
+
+	// Пару ключей нельзя заменить: владельцы секретов уже могли зашифровать для нее ключи доступа
+	if err = s.SetShareKeys(context.Background(), 1, []byte("other"), []byte("private")); !errors.Is(err, user.ErrAlreadyExist) {
+		t.Fatalf("unexpected error: got %v, want %v", err, user.ErrAlreadyExist)
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && s[:len(substr)] == substr
 }
@@ -0,0 +1,100 @@
+package encryptor
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithm алгоритм AEAD, которым зашифрован шифротекст.
+type Algorithm string
+
+const (
+	// AlgorithmAESGCM AES-256-GCM со случайным 96-битным nonce.
+	AlgorithmAESGCM Algorithm = "aes-256-gcm"
+	// AlgorithmXChaCha20Poly1305 XChaCha20-Poly1305 со случайным 192-битным nonce.
+	// Не ограничен числом сообщений на одном ключе и быстрее AES на процессорах без AES-NI.
+	AlgorithmXChaCha20Poly1305 Algorithm = "xchacha20-poly1305"
+)
+
+// xchachaTag метка шифротекста XChaCha20-Poly1305. Точки нет в алфавите base64, поэтому метка
+// однозначно отделяется от шифротекстов AES-GCM, которые по историческим причинам хранятся без метки.
+const xchachaTag = "xc20."
+
+const gcmNonceLen = 12
+
+// ErrInvalidAlgorithm неизвестный алгоритм шифрования.
+var ErrInvalidAlgorithm = errors.New("invalid cipher algorithm")
+
+var defaultAlgorithm atomic.Value
+
+func init() {
+	defaultAlgorithm.Store(AlgorithmAESGCM)
+}
+
+// ParseAlgorithm разбор алгоритма шифрования из конфига.
+func ParseAlgorithm(alg string) (Algorithm, error) {
+	switch a := Algorithm(alg); a {
+	case AlgorithmAESGCM, AlgorithmXChaCha20Poly1305:
+		return a, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidAlgorithm, alg)
+	}
+}
+
+// SetDefaultAlgorithm выбор алгоритма для новых шифротекстов.
+// Расшифровка не зависит от настройки: алгоритм определяется по самому шифротексту.
+func SetDefaultAlgorithm(alg Algorithm) error {
+	if _, err := ParseAlgorithm(string(alg)); err != nil {
+		return fmt.Errorf("encryptor.SetDefaultAlgorithm: %w", err)
+	}
+
+	defaultAlgorithm.Store(alg)
+
+	return nil
+}
+
+// DefaultAlgorithm алгоритм, которым шифруются новые данные.
+func DefaultAlgorithm() Algorithm {
+	alg, _ := defaultAlgorithm.Load().(Algorithm)
+
+	return alg
+}
+
+// newAEAD получение AEAD выбранного алгоритма.
+func newAEAD(alg Algorithm, key []byte) (cipher.AEAD, error) {
+	switch alg {
+	case AlgorithmAESGCM:
+		return newGCM(key)
+	case AlgorithmXChaCha20Poly1305:
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to NewX with error %w", err)
+		}
+		return aead, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAlgorithm, alg)
+	}
+}
+
+// tag метка алгоритма в начале закодированного шифротекста.
+func (a Algorithm) tag() string {
+	if a == AlgorithmXChaCha20Poly1305 {
+		return xchachaTag
+	}
+
+	return ""
+}
+
+// splitAlgorithm определение алгоритма по метке шифротекста.
+func splitAlgorithm(encoded string) (Algorithm, string) {
+	if rest, ok := strings.CutPrefix(encoded, xchachaTag); ok {
+		return AlgorithmXChaCha20Poly1305, rest
+	}
+
+	return AlgorithmAESGCM, encoded
+}
@@ -0,0 +1,141 @@
+package encryptor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var algorithms = []Algorithm{AlgorithmAESGCM, AlgorithmXChaCha20Poly1305}
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, alg := range algorithms {
+		parsed, err := ParseAlgorithm(string(alg))
+		require.NoError(t, err)
+		assert.Equal(t, alg, parsed)
+	}
+
+	_, err := ParseAlgorithm("des")
+	require.ErrorIs(t, err, ErrInvalidAlgorithm)
+
+	require.ErrorIs(t, SetDefaultAlgorithm("des"), ErrInvalidAlgorithm)
+	assert.Equal(t, AlgorithmAESGCM, DefaultAlgorithm())
+}
+
+func TestAlgorithms(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	// Данные, зашифрованные до смены алгоритма
+	legacy, err := EncryptWithMasterKey([]byte("legacy"), md)
+	require.NoError(t, err)
+
+	for _, alg := range algorithms {
+		t.Run(string(alg), func(t *testing.T) {
+			require.NoError(t, SetDefaultAlgorithm(alg))
+			t.Cleanup(func() {
+				require.NoError(t, SetDefaultAlgorithm(AlgorithmAESGCM))
+			})
+
+			encoded, err := EncryptWithMasterKey([]byte("hello world"), md)
+			require.NoError(t, err)
+			for _, part := range strings.Split(encoded, ":") {
+				gotAlg, _ := splitAlgorithm(part)
+				assert.Equal(t, alg, gotAlg)
+			}
+
+			decoded, err := DecryptWithMasterKey([]byte(encoded), md)
+			require.NoError(t, err)
+			assert.Equal(t, "hello world", decoded)
+
+			decoded, err = DecryptWithMasterKey([]byte(legacy), md)
+			require.NoError(t, err)
+			assert.Equal(t, "legacy", decoded)
+
+			_, err = DecryptWithMasterKey([]byte(encoded), make([]byte, 32))
+			require.Error(t, err)
+
+			plain := make([]byte, 2*StreamChunkSize+5)
+			_, err = rand.Read(plain)
+			require.NoError(t, err)
+
+			sealed := encryptStream(t, plain, md)
+			assert.Len(t, sealed, StreamSize(len(plain)))
+
+			r, err := NewDecryptReader(bytes.NewReader(sealed), md)
+			require.NoError(t, err)
+			opened, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, plain, opened)
+		})
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	key := make([]byte, masterKeyByteLen)
+
+	for _, alg := range algorithms {
+		for _, size := range []int{64, 4 * 1024, 1024 * 1024} {
+			plain := make([]byte, size)
+
+			b.Run(fmt.Sprintf("%s/%d", alg, size), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for range b.N {
+					if _, err := encryptWith(alg, plain, key); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	key := make([]byte, masterKeyByteLen)
+
+	for _, alg := range algorithms {
+		for _, size := range []int{64, 4 * 1024, 1024 * 1024} {
+			encoded, err := encryptWith(alg, make([]byte, size), key)
+			require.NoError(b, err)
+
+			b.Run(fmt.Sprintf("%s/%d", alg, size), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for range b.N {
+					if _, err := decryptToBytes(encoded, key); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkStream(b *testing.B) {
+	key := make([]byte, masterKeyByteLen)
+	plain := make([]byte, 16*StreamChunkSize)
+
+	for _, alg := range algorithms {
+		b.Run(string(alg), func(b *testing.B) {
+			b.SetBytes(int64(len(plain)))
+			for range b.N {
+				w, err := newEncryptWriter(io.Discard, key, alg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err = w.Write(plain); err != nil {
+					b.Fatal(err)
+				}
+				if err = w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,14 +2,14 @@
 package encryptor
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
 )
 
 const (
@@ -24,7 +24,14 @@ var (
 // EncryptWithMasterKey шифрует данные с использованием мастер-ключа.
 // Принимает: plaintext - данные для шифрования, masterKey - masterKeyByteLen-байтный ключ.
 // Возвращает: строку в формате "encryptedKey:encryptedData" или ошибку.
+// Обе части начинаются с метки алгоритма по умолчанию (у AES-GCM метка пустая).
 func EncryptWithMasterKey(plaintext []byte, masterKey []byte) (string, error) {
+	return encryptWithMasterKey(plaintext, masterKey, nil)
+}
+
+// encryptWithMasterKey шифрование с мастер-ключом, ad связывает шифротекст данных с контекстом
+// (AEAD associated data): расшифровать его можно только с тем же ad.
+func encryptWithMasterKey(plaintext []byte, masterKey []byte, ad []byte) (string, error) {
 	op := "encrypt.EncryptWithMasterKey"
 
 	if len(masterKey) != masterKeyByteLen {
@@ -36,9 +43,10 @@ func EncryptWithMasterKey(plaintext []byte, masterKey []byte) (string, error) {
 	if _, err := rand.Read(dataKey); err != nil {
 		return "", fmt.Errorf("failed to generate data key: %w", err)
 	}
+	defer securemem.Wipe(dataKey)
 
 	// Шифруем данные
-	encryptedData, err := encrypt(plaintext, dataKey)
+	encryptedData, err := sealWith(DefaultAlgorithm(), plaintext, dataKey, ad)
 	if err != nil {
 		return "", fmt.Errorf("failed to encrypt data: %w", err)
 	}
@@ -54,97 +62,115 @@ func EncryptWithMasterKey(plaintext []byte, masterKey []byte) (string, error) {
 
 // DecryptWithMasterKey расшифровывает данные, используя мастер-ключ.
 func DecryptWithMasterKey(encoded []byte, masterKey []byte) (string, error) {
+	plaintext, err := decryptWithMasterKey(encoded, masterKey, nil)
+	if err != nil {
+		return "", err
+	}
+	defer securemem.Wipe(plaintext)
+
+	return string(plaintext), nil
+}
+
+// DecryptToBuffer расшифровывает данные в затираемый буфер, минуя промежуточные строки.
+func DecryptToBuffer(encoded []byte, masterKey []byte) (*securemem.Buffer, error) {
+	plaintext, err := decryptWithMasterKey(encoded, masterKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return securemem.New(plaintext), nil
+}
+
+func decryptWithMasterKey(encoded []byte, masterKey []byte, ad []byte) ([]byte, error) {
 	op := "encrypt.DecryptWithMasterKey"
 
 	keyPartsCount := 2
 
 	if len(masterKey) != masterKeyByteLen {
-		return "", fmt.Errorf("%s: masterKey %w", op, errInvalidKeyLength)
+		return nil, fmt.Errorf("%s: masterKey %w", op, errInvalidKeyLength)
 	}
 
 	parts := strings.Split(string(encoded), ":")
 	if len(parts) != keyPartsCount {
-		return "", errDecryptionFailed
+		return nil, errDecryptionFailed
 	}
 
 	// Расшифровываем ключ данных (получаем []byte)
 	dataKeyBytes, err := decryptToBytes(parts[0], masterKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt data key: %w", err)
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
 	}
+	defer securemem.Wipe(dataKeyBytes)
 
 	// Проверяем длину ключа
 	if len(dataKeyBytes) != masterKeyByteLen {
-		return "", fmt.Errorf("%s: dataKeyBytes %w", op, errInvalidKeyLength)
+		return nil, fmt.Errorf("%s: dataKeyBytes %w", op, errInvalidKeyLength)
 	}
 
 	// Расшифровываем данные
-	plaintext, err := decrypt(parts[1], dataKeyBytes)
+	plaintext, err := openBytes(parts[1], dataKeyBytes, ad)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt data: %w", err)
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
 
 	return plaintext, nil
 }
 
-// encrypt выполняет AES-GCM шифрование.
+// encrypt выполняет шифрование алгоритмом по умолчанию.
 func encrypt(plaintext []byte, key []byte) (string, error) {
-	op := "encryptor.Encrypt.encrypt"
+	return encryptWith(DefaultAlgorithm(), plaintext, key)
+}
 
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", fmt.Errorf("%s: failed to NewCipher with error %w", op, err)
-	}
+// encryptWith выполняет AEAD шифрование заданным алгоритмом.
+// Возвращает метку алгоритма и base64(nonce | ciphertext).
+func encryptWith(alg Algorithm, plaintext []byte, key []byte) (string, error) {
+	return sealWith(alg, plaintext, key, nil)
+}
 
-	gcm, err := cipher.NewGCM(block)
+// sealWith AEAD шифрование заданным алгоритмом с дополнительными данными ad.
+func sealWith(alg Algorithm, plaintext []byte, key []byte, ad []byte) (string, error) {
+	op := "encryptor.Encrypt.encrypt"
+
+	aead, err := newAEAD(alg, key)
 	if err != nil {
-		return "", fmt.Errorf("%s: failed to NewGCM with error %w", op, err)
+		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	nonce := make([]byte, gcm.NonceSize())
+	nonce := make([]byte, aead.NonceSize())
 	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
 		return "", fmt.Errorf("%s: failed to read full with error %w", op, err)
 	}
 
-	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	ciphertext := aead.Seal(nonce, nonce, plaintext, ad)
+	return alg.tag() + base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// decrypt выполняет AES-GCM дешифрование и возвращает строку.
-func decrypt(encodedCiphertext string, key []byte) (string, error) {
-	op := "encryptor.Encrypt.decrypt"
-
-	plaintext, err := decryptToBytes(encodedCiphertext, key)
-	if err != nil {
-		return "", fmt.Errorf("%s: failed to decryptToBytes with error %w", op, err)
-	}
-	return string(plaintext), nil
+// decryptToBytes выполняет AEAD дешифрование алгоритмом из метки шифротекста и возвращает []byte.
+func decryptToBytes(encodedCiphertext string, key []byte) ([]byte, error) {
+	return openBytes(encodedCiphertext, key, nil)
 }
 
-// decryptToBytes выполняет AES-GCM дешифрование и возвращает []byte.
-func decryptToBytes(encodedCiphertext string, key []byte) ([]byte, error) {
+// openBytes AEAD дешифрование шифротекста, зашифрованного с дополнительными данными ad.
+func openBytes(encodedCiphertext string, key []byte, ad []byte) ([]byte, error) {
 	op := "encryptor.Encrypt.decryptToBytes"
 
+	alg, encodedCiphertext := splitAlgorithm(encodedCiphertext)
+
 	ciphertext, err := base64.StdEncoding.DecodeString(encodedCiphertext)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to DecodeString with error %w", op, err)
 	}
 
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to NewCipher with error %w", op, err)
-	}
-
-	gcm, err := cipher.NewGCM(block)
+	aead, err := newAEAD(alg, key)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to NewGCM with error %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	nonceSize := gcm.NonceSize()
+	nonceSize := aead.NonceSize()
 	if len(ciphertext) < nonceSize {
 		return nil, errDecryptionFailed
 	}
 
 	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
-	return gcm.Open(nil, nonce, ciphertext, nil)
+	return aead.Open(nil, nonce, ciphertext, ad)
 }
@@ -72,3 +72,18 @@ func TestEncryptor(t *testing.T) {
 		})
 	}
 }
+
+func TestDecryptToBuffer(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	encoded, err := EncryptWithMasterKey([]byte("hello world"), md)
+	require.NoError(t, err)
+
+	buf, err := DecryptToBuffer([]byte(encoded), md)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", buf.Reveal())
+
+	_, err = DecryptToBuffer([]byte(encoded), make([]byte, 32))
+	require.Error(t, err)
+}
@@ -0,0 +1,257 @@
+package encryptor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+// FieldMode режим шифрования отдельного поля.
+type FieldMode string
+
+const (
+	// FieldModePlain поле хранится в открытом виде.
+	FieldModePlain FieldMode = "plain"
+	// FieldModeRandomized вероятностное шифрование, одинаковые значения дают разный шифротекст.
+	FieldModeRandomized FieldMode = "randomized"
+	// FieldModeDeterministic детерминированное шифрование, позволяет искать по точному совпадению.
+	FieldModeDeterministic FieldMode = "deterministic"
+	// FieldModeBlindIndex вероятностное шифрование значения и отдельный слепой индекс (HMAC) для поиска.
+	FieldModeBlindIndex FieldMode = "blind_index"
+)
+
+const (
+	// randomizedPrefix шифротекст, связанный со scope, legacyRandomizedPrefix шифротекст без scope,
+	// сохраненный до его появления. Прежние значения перешифровываются миграцией.
+	randomizedPrefix       = "enc2:"
+	legacyRandomizedPrefix = "enc1:"
+	deterministicPrefix    = "det1:"
+	blindIndexPrefix       = "bidx1:"
+
+	fieldInfoDetMAC = "gophkeeper field deterministic mac"
+	fieldInfoDetEnc = "gophkeeper field deterministic enc"
+	fieldInfoIndex  = "gophkeeper field blind index"
+
+	deterministicNonceLen = 12
+)
+
+var (
+	// ErrInvalidFieldMode неизвестный режим шифрования поля.
+	ErrInvalidFieldMode = errors.New("invalid field encryption mode")
+	// ErrNotSealedField значение не является шифротекстом FieldCipher.
+	ErrNotSealedField = errors.New("field value is not sealed")
+)
+
+// ParseFieldMode разбор режима шифрования поля из конфига.
+func ParseFieldMode(mode string) (FieldMode, error) {
+	switch m := FieldMode(mode); m {
+	case FieldModePlain, FieldModeRandomized, FieldModeDeterministic, FieldModeBlindIndex:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidFieldMode, mode)
+	}
+}
+
+// FieldCipher шифровальщик отдельных полей с поддержкой детерминированного режима и слепых индексов.
+// Ключи для детерминированного шифрования и индексов выводятся из мастер-ключа через HKDF.
+type FieldCipher struct {
+	masterKey []byte
+	detMACKey []byte
+	detEncKey []byte
+	indexKey  []byte
+}
+
+// NewFieldCipher получение шифровальщика полей.
+func NewFieldCipher(masterKey []byte) (*FieldCipher, error) {
+	op := "encryptor.NewFieldCipher"
+
+	if len(masterKey) != masterKeyByteLen {
+		return nil, fmt.Errorf("%s: master key %w", op, errInvalidKeyLength)
+	}
+
+	var (
+		fc  = FieldCipher{masterKey: masterKey}
+		err error
+	)
+
+	if fc.detMACKey, err = expandKey(masterKey, fieldInfoDetMAC); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if fc.detEncKey, err = expandKey(masterKey, fieldInfoDetEnc); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if fc.indexKey, err = expandKey(masterKey, fieldInfoIndex); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &fc, nil
+}
+
+// Seal шифрование значения поля в заданном режиме.
+// scope связывает шифротекст и индекс с владельцем (например, ID пользователя),
+// чтобы одинаковые значения разных пользователей не совпадали. Во всех режимах scope передается
+// в AEAD как дополнительные данные, поэтому значение, перенесенное в другое поле или к другому
+// владельцу, не расшифровывается.
+// Для режима blind_index возвращается только шифротекст, индекс считается через BlindIndex.
+func (fc *FieldCipher) Seal(value string, mode FieldMode, scope string) (string, error) {
+	op := "encryptor.FieldCipher.Seal"
+
+	if value == "" {
+		return value, nil
+	}
+
+	switch mode {
+	case FieldModePlain:
+		return value, nil
+	case FieldModeRandomized, FieldModeBlindIndex:
+		enc, err := encryptWithMasterKey([]byte(value), fc.masterKey, []byte(scope))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return randomizedPrefix + enc, nil
+	case FieldModeDeterministic:
+		enc, err := fc.sealDeterministic(value, scope)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return deterministicPrefix + enc, nil
+	default:
+		return "", fmt.Errorf("%s: %w: %q", op, ErrInvalidFieldMode, mode)
+	}
+}
+
+// Open расшифровка значения поля, зашифрованного через Seal.
+// Открытые значения не передаются: признак шифрования хранится отдельно от значения.
+func (fc *FieldCipher) Open(value, scope string) (string, error) {
+	op := "encryptor.FieldCipher.Open"
+
+	switch {
+	case strings.HasPrefix(value, randomizedPrefix):
+		encoded := strings.TrimPrefix(value, randomizedPrefix)
+		dec, err := decryptWithMasterKey([]byte(encoded), fc.masterKey, []byte(scope))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		defer securemem.Wipe(dec)
+		return string(dec), nil
+	case strings.HasPrefix(value, legacyRandomizedPrefix):
+		dec, err := DecryptWithMasterKey([]byte(strings.TrimPrefix(value, legacyRandomizedPrefix)), fc.masterKey)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return dec, nil
+	case strings.HasPrefix(value, deterministicPrefix):
+		dec, err := fc.openDeterministic(strings.TrimPrefix(value, deterministicPrefix), scope)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return dec, nil
+	default:
+		return "", fmt.Errorf("%s: %w", op, ErrNotSealedField)
+	}
+}
+
+// BlindIndex слепой индекс значения для поиска по точному совпадению без расшифровки.
+func (fc *FieldCipher) BlindIndex(value, scope string) string {
+	mac := hmac.New(sha256.New, fc.indexKey)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+
+	return blindIndexPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Lookups все варианты хранимого представления значения, по которым его можно найти:
+// открытое значение, детерминированный шифротекст и слепой индекс.
+// Позволяет находить записи, сохраненные до смены политики шифрования поля.
+func (fc *FieldCipher) Lookups(value, scope string) ([]string, error) {
+	det, err := fc.Seal(value, FieldModeDeterministic, scope)
+	if err != nil {
+		return nil, fmt.Errorf("encryptor.FieldCipher.Lookups: %w", err)
+	}
+
+	return []string{value, det, fc.BlindIndex(value, scope)}, nil
+}
+
+// Wipe затирание мастер-ключа и выведенных из него ключей. После затирания шифровальщик не используется.
+func (fc *FieldCipher) Wipe() {
+	securemem.Wipe(fc.masterKey)
+	securemem.Wipe(fc.detMACKey)
+	securemem.Wipe(fc.detEncKey)
+	securemem.Wipe(fc.indexKey)
+}
+
+// IsSealedField похоже ли значение на шифротекст FieldCipher.
+// Открытое значение пользователя может иметь тот же префикс, поэтому признак годится только
+// для разбора данных, сохраненных до появления отдельного признака шифрования поля.
+func IsSealedField(value string) bool {
+	return strings.HasPrefix(value, randomizedPrefix) || strings.HasPrefix(value, legacyRandomizedPrefix) ||
+		strings.HasPrefix(value, deterministicPrefix)
+}
+
+// sealDeterministic SIV-подобное шифрование: nonce вычисляется как HMAC от значения,
+// поэтому одинаковые значения в одном scope дают одинаковый шифротекст.
+// Всегда AES-GCM независимо от алгоритма по умолчанию, иначе смена алгоритма сломает поиск.
+func (fc *FieldCipher) sealDeterministic(value, scope string) (string, error) {
+	gcm, err := newGCM(fc.detEncKey)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, fc.detMACKey)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:deterministicNonceLen]
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(value), []byte(scope))
+
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func (fc *FieldCipher) openDeterministic(encoded, scope string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode deterministic value %w", err)
+	}
+
+	gcm, err := newGCM(fc.detEncKey)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ciphertext) < deterministicNonceLen {
+		return "", errDecryptionFailed
+	}
+
+	nonce, ciphertext := ciphertext[:deterministicNonceLen], ciphertext[deterministicNonceLen:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(scope))
+	if err != nil {
+		return "", errDecryptionFailed
+	}
+
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to NewCipher with error %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to NewGCM with error %w", err)
+	}
+
+	return gcm, nil
+}
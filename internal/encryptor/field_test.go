@@ -0,0 +1,127 @@
+package encryptor
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldCipher(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	fc, err := NewFieldCipher(md)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		mode          FieldMode
+		value         string
+		wantPlain     bool
+		deterministic bool
+	}{
+		{name: "plain", mode: FieldModePlain, value: "github.com", wantPlain: true},
+		{name: "randomized", mode: FieldModeRandomized, value: "github.com"},
+		{name: "deterministic", mode: FieldModeDeterministic, value: "github.com", deterministic: true},
+		{name: "blind index", mode: FieldModeBlindIndex, value: "github.com"},
+		{name: "empty value", mode: FieldModeRandomized, value: "", wantPlain: true},
+		{name: "value with sealed prefix", mode: FieldModeRandomized, value: "enc1:github.com"},
+		{
+			name: "value with deterministic prefix", mode: FieldModeDeterministic, value: "det1:github.com",
+			deterministic: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sealed, err := fc.Seal(test.value, test.mode, "scope:1")
+			require.NoError(t, err)
+
+			if test.wantPlain {
+				assert.Equal(t, test.value, sealed)
+				return
+			}
+			assert.NotEqual(t, test.value, sealed)
+			assert.True(t, IsSealedField(sealed))
+
+			again, err := fc.Seal(test.value, test.mode, "scope:1")
+			require.NoError(t, err)
+			assert.Equal(t, test.deterministic, sealed == again)
+
+			opened, err := fc.Open(sealed, "scope:1")
+			require.NoError(t, err)
+			assert.Equal(t, test.value, opened)
+		})
+	}
+
+	t.Run("not sealed", func(t *testing.T) {
+		_, err := fc.Open("github.com", "scope:1")
+		require.ErrorIs(t, err, ErrNotSealedField)
+	})
+
+	t.Run("deterministic scope", func(t *testing.T) {
+		first, err := fc.Seal("github.com", FieldModeDeterministic, "scope:1")
+		require.NoError(t, err)
+		second, err := fc.Seal("github.com", FieldModeDeterministic, "scope:2")
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+
+		_, err = fc.Open(first, "scope:2")
+		require.Error(t, err)
+	})
+
+	t.Run("randomized scope", func(t *testing.T) {
+		for _, mode := range []FieldMode{FieldModeRandomized, FieldModeBlindIndex} {
+			sealed, err := fc.Seal("github.com", mode, "password_url:1")
+			require.NoError(t, err)
+
+			// Значение, перенесенное в другое поле или к другому пользователю, не расшифровывается
+			_, err = fc.Open(sealed, "password_username:1")
+			require.Error(t, err)
+			_, err = fc.Open(sealed, "password_url:2")
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("legacy randomized", func(t *testing.T) {
+		enc, err := EncryptWithMasterKey([]byte("github.com"), md)
+		require.NoError(t, err)
+
+		legacy := legacyRandomizedPrefix + enc
+		assert.True(t, IsSealedField(legacy))
+
+		opened, err := fc.Open(legacy, "scope:1")
+		require.NoError(t, err)
+		assert.Equal(t, "github.com", opened)
+	})
+
+	t.Run("blind index", func(t *testing.T) {
+		assert.Equal(t, fc.BlindIndex("github.com", "scope:1"), fc.BlindIndex("github.com", "scope:1"))
+		assert.NotEqual(t, fc.BlindIndex("github.com", "scope:1"), fc.BlindIndex("github.com", "scope:2"))
+		assert.NotEqual(t, fc.BlindIndex("github.com", "scope:1"), fc.BlindIndex("gitlab.com", "scope:1"))
+	})
+
+	t.Run("lookups", func(t *testing.T) {
+		det, err := fc.Seal("github.com", FieldModeDeterministic, "scope:1")
+		require.NoError(t, err)
+
+		lookups, err := fc.Lookups("github.com", "scope:1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"github.com", det, fc.BlindIndex("github.com", "scope:1")}, lookups)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := fc.Seal("github.com", FieldMode("unknown"), "scope:1")
+		require.ErrorIs(t, err, ErrInvalidFieldMode)
+
+		_, err = ParseFieldMode("unknown")
+		require.ErrorIs(t, err, ErrInvalidFieldMode)
+	})
+
+	t.Run("invalid key length", func(t *testing.T) {
+		_, err := NewFieldCipher(make([]byte, 16))
+		require.Error(t, err)
+	})
+}
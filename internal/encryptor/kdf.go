@@ -0,0 +1,179 @@
+package encryptor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	kdfSaltLen = 16
+
+	// Параметры Argon2id по умолчанию (рекомендации OWASP).
+	defaultKDFTime    = 3
+	defaultKDFMemory  = 64 * 1024
+	defaultKDFThreads = 2
+
+	// Минимально допустимые параметры, которые принимает сервер.
+	minKDFTime   = 1
+	minKDFMemory = 19 * 1024
+
+	kdfInfoAuth = "gophkeeper auth key"
+	kdfInfoKEK  = "gophkeeper key encryption key"
+
+	kdfInfoMember = "gophkeeper collection member key:"
+	kdfInfoDecoy  = "gophkeeper kdf decoy:"
+)
+
+var errInvalidKDFParams = errors.New("invalid kdf params")
+
+// KDFParams параметры Argon2id для получения ключей из мастер-пароля.
+type KDFParams struct {
+	Salt    []byte
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// NewKDFParams параметры Argon2id по умолчанию со случайной солью.
+func NewKDFParams() (*KDFParams, error) {
+	op := "encryptor.NewKDFParams"
+
+	salt := make([]byte, kdfSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%s: failed to generate salt %w", op, err)
+	}
+
+	return &KDFParams{
+		Salt:    salt,
+		Time:    defaultKDFTime,
+		Memory:  defaultKDFMemory,
+		Threads: defaultKDFThreads,
+	}, nil
+}
+
+// DecoyKDFParams параметры Argon2id по умолчанию с солью, полученной из секрета сервера и логина.
+// Соль не меняется между запросами, поэтому по ним нельзя отличить несуществующий логин от E2E аккаунта.
+func DecoyKDFParams(secret []byte, login string) *KDFParams {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(kdfInfoDecoy + login))
+
+	return &KDFParams{
+		Salt:    mac.Sum(nil)[:kdfSaltLen],
+		Time:    defaultKDFTime,
+		Memory:  defaultKDFMemory,
+		Threads: defaultKDFThreads,
+	}
+}
+
+// Validate проверка, что параметры не слабее минимально допустимых.
+func (p *KDFParams) Validate() error {
+	if p == nil {
+		return fmt.Errorf("%w: params are empty", errInvalidKDFParams)
+	}
+	if len(p.Salt) < kdfSaltLen {
+		return fmt.Errorf("%w: salt must be at least %d bytes", errInvalidKDFParams, kdfSaltLen)
+	}
+	if p.Time < minKDFTime || p.Memory < minKDFMemory || p.Threads == 0 {
+		return fmt.Errorf("%w: too weak argon2id parameters", errInvalidKDFParams)
+	}
+
+	return nil
+}
+
+// DeriveClientKeys получение из мастер-пароля ключа аутентификации и ключа шифрования ключей (KEK).
+// Ключ аутентификации отправляется серверу вместо пароля, KEK никогда не покидает клиента.
+func DeriveClientKeys(password string, p *KDFParams) ([]byte, []byte, error) {
+	op := "encryptor.DeriveClientKeys"
+
+	if err := p.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	root := argon2.IDKey([]byte(password), p.Salt, p.Time, p.Memory, p.Threads, masterKeyByteLen)
+
+	authKey, err := expandKey(root, kdfInfoAuth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to derive auth key %w", op, err)
+	}
+
+	kek, err := expandKey(root, kdfInfoKEK)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: failed to derive key encryption key %w", op, err)
+	}
+
+	return authKey, kek, nil
+}
+
+// NewVaultKey генерация случайного ключа хранилища пользователя.
+func NewVaultKey() ([]byte, error) {
+	key := make([]byte, masterKeyByteLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("encryptor.NewVaultKey: %w", err)
+	}
+
+	return key, nil
+}
+
+// WrapKey шифрование ключа другим ключом (KEK).
+func WrapKey(key, kek []byte) ([]byte, error) {
+	op := "encryptor.WrapKey"
+
+	if len(kek) != masterKeyByteLen {
+		return nil, fmt.Errorf("%s: kek %w", op, errInvalidKeyLength)
+	}
+
+	wrapped, err := encrypt(key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []byte(wrapped), nil
+}
+
+// UnwrapKey расшифровка ключа, зашифрованного через WrapKey.
+func UnwrapKey(wrapped, kek []byte) ([]byte, error) {
+	op := "encryptor.UnwrapKey"
+
+	if len(kek) != masterKeyByteLen {
+		return nil, fmt.Errorf("%s: kek %w", op, errInvalidKeyLength)
+	}
+
+	key, err := decryptToBytes(string(wrapped), kek)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, errDecryptionFailed)
+	}
+
+	return key, nil
+}
+
+// MemberKey ключ участника организации, которым на сервере шифруются ключи коллекций для этого участника.
+// Получается из мастер-ключа сервера, поэтому отдельно не хранится.
+func MemberKey(masterKey []byte, userID int) ([]byte, error) {
+	if len(masterKey) != masterKeyByteLen {
+		return nil, fmt.Errorf("encryptor.MemberKey: master key %w", errInvalidKeyLength)
+	}
+
+	key, err := expandKey(masterKey, kdfInfoMember+strconv.Itoa(userID))
+	if err != nil {
+		return nil, fmt.Errorf("encryptor.MemberKey: %w", err)
+	}
+
+	return key, nil
+}
+
+func expandKey(root []byte, info string) ([]byte, error) {
+	key := make([]byte, masterKeyByteLen)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, root, []byte(info)), key); err != nil {
+		return nil, fmt.Errorf("failed to expand key %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,101 @@
+package encryptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveClientKeys(t *testing.T) {
+	params, err := NewKDFParams()
+	require.NoError(t, err)
+	// Для теста используются минимальные параметры, чтобы не тратить время на Argon2id
+	params.Time, params.Memory = minKDFTime, minKDFMemory
+
+	testCases := []struct {
+		name    string
+		params  *KDFParams
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			params:  params,
+			wantErr: false,
+		},
+		{
+			name:    "short salt",
+			params:  &KDFParams{Salt: []byte("salt"), Time: 1, Memory: minKDFMemory, Threads: 1},
+			wantErr: true,
+		},
+		{
+			name:    "weak params",
+			params:  &KDFParams{Salt: params.Salt, Time: 1, Memory: 1024, Threads: 1},
+			wantErr: true,
+		},
+		{
+			name:    "empty params",
+			params:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			authKey, kek, err := DeriveClientKeys("master password", test.params)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, authKey, masterKeyByteLen)
+			assert.Len(t, kek, masterKeyByteLen)
+			assert.NotEqual(t, authKey, kek)
+
+			authKey2, kek2, err := DeriveClientKeys("master password", test.params)
+			require.NoError(t, err)
+			assert.Equal(t, authKey, authKey2)
+			assert.Equal(t, kek, kek2)
+		})
+	}
+}
+
+func TestWrapKey(t *testing.T) {
+	kek, err := NewVaultKey()
+	require.NoError(t, err)
+	vaultKey, err := NewVaultKey()
+	require.NoError(t, err)
+
+	wrapped, err := WrapKey(vaultKey, kek)
+	require.NoError(t, err)
+
+	unwrapped, err := UnwrapKey(wrapped, kek)
+	require.NoError(t, err)
+	assert.Equal(t, vaultKey, unwrapped)
+
+	_, err = UnwrapKey(wrapped, make([]byte, masterKeyByteLen))
+	require.Error(t, err)
+
+	_, err = WrapKey(vaultKey, []byte("short"))
+	require.Error(t, err)
+}
+
+func TestMemberKey(t *testing.T) {
+	masterKey, err := NewVaultKey()
+	require.NoError(t, err)
+
+	first, err := MemberKey(masterKey, 1)
+	require.NoError(t, err)
+	again, err := MemberKey(masterKey, 1)
+	require.NoError(t, err)
+	second, err := MemberKey(masterKey, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, masterKeyByteLen)
+
+	_, err = MemberKey([]byte("short"), 1)
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package encryptor
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const shareKeyInfo = "gophkeeper share key"
+
+// NewShareKeyPair генерация пары ключей X25519 для получения доступа к чужим секретам.
+// Возвращает закрытый и открытый ключи.
+func NewShareKeyPair() ([]byte, []byte, error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encryptor.NewShareKeyPair: %w", err)
+	}
+
+	return priv.Bytes(), priv.PublicKey().Bytes(), nil
+}
+
+// SealToPublicKey шифрование ключа для владельца открытого ключа X25519: общий секрет с одноразовой парой ключей
+// через HKDF дает ключ шифрования. Результат: одноразовый открытый ключ | шифротекст в формате WrapKey.
+func SealToPublicKey(key, publicKey []byte) ([]byte, error) {
+	op := "encryptor.SealToPublicKey"
+
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid public key %w", op, err)
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate ephemeral key %w", op, err)
+	}
+
+	kek, err := shareKEK(ephemeral, recipient, ephemeral.PublicKey().Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sealed, err := encrypt(key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return append(ephemeral.PublicKey().Bytes(), sealed...), nil
+}
+
+// OpenWithPrivateKey расшифровка ключа, зашифрованного через SealToPublicKey, закрытым ключом получателя.
+func OpenWithPrivateKey(sealed, privateKey []byte) ([]byte, error) {
+	op := "encryptor.OpenWithPrivateKey"
+
+	priv, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid private key %w", op, err)
+	}
+
+	pubLen := len(priv.PublicKey().Bytes())
+	if len(sealed) <= pubLen {
+		return nil, fmt.Errorf("%s: %w", op, errDecryptionFailed)
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed[:pubLen])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, errDecryptionFailed)
+	}
+
+	kek, err := shareKEK(priv, ephemeral, sealed[:pubLen])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key, err := decryptToBytes(string(sealed[pubLen:]), kek)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, errDecryptionFailed)
+	}
+
+	return key, nil
+}
+
+// shareKEK ключ шифрования из общего секрета X25519. Одноразовый открытый ключ используется как соль,
+// поэтому ключ уникален для каждой операции шифрования.
+func shareKEK(priv *ecdh.PrivateKey, pub *ecdh.PublicKey, ephemeral []byte) ([]byte, error) {
+	shared, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret %w", err)
+	}
+
+	kek := make([]byte, masterKeyByteLen)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, shared, ephemeral, []byte(shareKeyInfo)), kek); err != nil {
+		return nil, fmt.Errorf("failed to derive key %w", err)
+	}
+
+	return kek, nil
+}
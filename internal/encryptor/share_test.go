@@ -0,0 +1,43 @@
+package encryptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealToPublicKey(t *testing.T) {
+	priv, pub, err := NewShareKeyPair()
+	require.NoError(t, err)
+
+	key, err := NewVaultKey()
+	require.NoError(t, err)
+
+	sealed, err := SealToPublicKey(key, pub)
+	require.NoError(t, err)
+
+	opened, err := OpenWithPrivateKey(sealed, priv)
+	require.NoError(t, err)
+	assert.Equal(t, key, opened)
+
+	// Каждое шифрование использует новую одноразовую пару ключей
+	again, err := SealToPublicKey(key, pub)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	other, _, err := NewShareKeyPair()
+	require.NoError(t, err)
+	_, err = OpenWithPrivateKey(sealed, other)
+	require.Error(t, err)
+
+	sealed[len(sealed)-1] ^= 1
+	_, err = OpenWithPrivateKey(sealed, priv)
+	require.Error(t, err)
+
+	_, err = OpenWithPrivateKey(sealed[:10], priv)
+	require.Error(t, err)
+
+	_, err = SealToPublicKey(key, []byte("short"))
+	require.Error(t, err)
+}
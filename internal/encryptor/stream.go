@@ -0,0 +1,372 @@
+package encryptor
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Формат потока:
+//
+//	header: magic(4) | len(wrappedKey)(2) | wrappedKey | noncePrefix
+//	chunk:  AEAD(plaintext[<= StreamChunkSize]), nonce = noncePrefix | counter(4) | last(1)
+//
+// magic задает алгоритм: GKS1 — AES-256-GCM (noncePrefix 7 байт), GKX1 — XChaCha20-Poly1305 (19 байт).
+// Каждый поток шифруется своим случайным ключом, который хранится в заголовке зашифрованным мастер-ключом.
+// Заголовок передается как дополнительные данные (AAD) каждого блока. Флаг последнего блока
+// входит в nonce, поэтому обрезанный по границе блока поток не пройдет проверку.
+const (
+	// StreamChunkSize размер открытых данных в одном блоке потока.
+	StreamChunkSize = 64 * 1024
+
+	streamMagic        = "GKS1"
+	streamMagicXChaCha = "GKX1"
+	// streamNonceSuffixLen счетчик блока и флаг последнего блока в конце nonce.
+	streamNonceSuffixLen = 5
+	streamTagSize        = 16
+)
+
+var (
+	errInvalidStreamHeader = errors.New("invalid stream header")
+	errStreamClosed        = errors.New("stream writer is closed")
+	errStreamTooLong       = errors.New("stream is too long")
+)
+
+// StreamSize размер зашифрованного алгоритмом по умолчанию потока для plainLen байт открытых данных.
+func StreamSize(plainLen int) int {
+	return streamSize(DefaultAlgorithm(), plainLen)
+}
+
+func streamSize(alg Algorithm, plainLen int) int {
+	chunks := plainLen/StreamChunkSize + 1
+	if plainLen > 0 && plainLen%StreamChunkSize == 0 {
+		chunks--
+	}
+
+	nonceLen := streamNonceLen(alg)
+	// Ключ потока после encryptWith: метка | base64(nonce | key | tag)
+	wrappedKeyLen := len(alg.tag()) + base64.StdEncoding.EncodedLen(nonceLen+masterKeyByteLen+streamTagSize)
+
+	return len(streamMagic) + 2 + wrappedKeyLen + nonceLen - streamNonceSuffixLen + plainLen + chunks*streamTagSize
+}
+
+// streamWriter шифрующая обертка над io.Writer.
+type streamWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	out     []byte
+	closed  bool
+}
+
+// NewEncryptWriter получение io.WriteCloser, который шифрует поток блоками алгоритмом по умолчанию
+// и пишет его в w. Close обязателен: он записывает последний блок, без которого поток не расшифруется.
+func NewEncryptWriter(w io.Writer, masterKey []byte) (io.WriteCloser, error) {
+	return newEncryptWriter(w, masterKey, DefaultAlgorithm())
+}
+
+func newEncryptWriter(w io.Writer, masterKey []byte, alg Algorithm) (io.WriteCloser, error) {
+	op := "encryptor.NewEncryptWriter"
+
+	if len(masterKey) != masterKeyByteLen {
+		return nil, fmt.Errorf("%s: master key %w", op, errInvalidKeyLength)
+	}
+
+	dataKey, err := NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to generate data key %w", op, err)
+	}
+	defer securemem.Wipe(dataKey)
+
+	wrapped, err := encryptWith(alg, dataKey, masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to wrap data key %w", op, err)
+	}
+
+	aead, err := newAEAD(alg, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	prefix := make([]byte, aead.NonceSize()-streamNonceSuffixLen)
+	if _, err = rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("%s: failed to generate nonce prefix %w", op, err)
+	}
+
+	header := make([]byte, 0, len(streamMagic)+2+len(wrapped)+len(prefix))
+	header = append(header, streamMagicOf(alg)...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+	header = append(header, prefix...)
+
+	if _, err = w.Write(header); err != nil {
+		return nil, fmt.Errorf("%s: failed to write header %w", op, err)
+	}
+
+	return &streamWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		prefix: prefix,
+		buf:    make([]byte, 0, StreamChunkSize),
+		out:    make([]byte, 0, StreamChunkSize+streamTagSize),
+	}, nil
+}
+
+// Write шифрование данных. Полный блок записывается только когда известно, что он не последний.
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if sw.closed {
+		return 0, errStreamClosed
+	}
+
+	written := 0
+	for len(p) > 0 {
+		if len(sw.buf) == StreamChunkSize {
+			if err := sw.flush(false); err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(sw.buf[len(sw.buf):StreamChunkSize], p)
+		sw.buf = sw.buf[:len(sw.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close запись последнего блока. Базовый io.Writer не закрывается.
+func (sw *streamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+
+	return sw.flush(true)
+}
+
+func (sw *streamWriter) flush(last bool) error {
+	if sw.counter == math.MaxUint32 {
+		return errStreamTooLong
+	}
+
+	sw.out = sw.aead.Seal(sw.out[:0], streamNonce(sw.prefix, sw.counter, last), sw.buf, sw.header)
+	if _, err := sw.w.Write(sw.out); err != nil {
+		return fmt.Errorf("failed to write stream chunk %w", err)
+	}
+
+	sw.counter++
+	sw.buf = sw.buf[:0]
+
+	return nil
+}
+
+// streamEncryptReader шифрующая обертка над io.Reader: читает открытые данные блоками
+// и отдает шифротекст в формате NewEncryptWriter.
+type streamEncryptReader struct {
+	src   io.Reader
+	w     io.WriteCloser
+	out   bytes.Buffer
+	chunk []byte
+	done  bool
+}
+
+// NewEncryptReader получение io.Reader, который шифрует данные из r алгоритмом по умолчанию.
+// В памяти держится не больше пары блоков, поэтому размер r не ограничен.
+func NewEncryptReader(r io.Reader, masterKey []byte) (io.Reader, error) {
+	er := &streamEncryptReader{src: r, chunk: make([]byte, StreamChunkSize)}
+
+	w, err := newEncryptWriter(&er.out, masterKey, DefaultAlgorithm())
+	if err != nil {
+		return nil, fmt.Errorf("encryptor.NewEncryptReader: %w", err)
+	}
+	er.w = w
+
+	return er, nil
+}
+
+// Read чтение зашифрованных данных. Последний блок записывается, когда источник вернул io.EOF.
+func (er *streamEncryptReader) Read(p []byte) (int, error) {
+	for er.out.Len() == 0 {
+		if er.done {
+			return 0, io.EOF
+		}
+
+		n, err := er.src.Read(er.chunk)
+		if n > 0 {
+			if _, werr := er.w.Write(er.chunk[:n]); werr != nil {
+				return 0, werr
+			}
+		}
+
+		switch {
+		case errors.Is(err, io.EOF):
+			er.done = true
+			if err = er.w.Close(); err != nil {
+				return 0, err
+			}
+		case err != nil:
+			return 0, fmt.Errorf("failed to read stream source %w", err)
+		}
+	}
+
+	return er.out.Read(p)
+}
+
+// streamReader расшифровывающая обертка над io.Reader.
+type streamReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	header  []byte
+	prefix  []byte
+	counter uint32
+	chunk   []byte
+	buf     []byte
+	plain   []byte
+	done    bool
+	err     error
+}
+
+// NewDecryptReader получение io.Reader, который расшифровывает поток, записанный через NewEncryptWriter.
+// Ошибка проверки любого блока или обрезанный поток возвращаются из Read.
+func NewDecryptReader(r io.Reader, masterKey []byte) (io.Reader, error) {
+	op := "encryptor.NewDecryptReader"
+
+	if len(masterKey) != masterKeyByteLen {
+		return nil, fmt.Errorf("%s: master key %w", op, errInvalidKeyLength)
+	}
+
+	br := bufio.NewReaderSize(r, StreamChunkSize+streamTagSize)
+
+	fixed := make([]byte, len(streamMagic)+2)
+	if _, err := io.ReadFull(br, fixed); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, errInvalidStreamHeader)
+	}
+
+	var alg Algorithm
+	switch string(fixed[:len(streamMagic)]) {
+	case streamMagic:
+		alg = AlgorithmAESGCM
+	case streamMagicXChaCha:
+		alg = AlgorithmXChaCha20Poly1305
+	default:
+		return nil, fmt.Errorf("%s: %w", op, errInvalidStreamHeader)
+	}
+
+	wrappedLen := int(binary.BigEndian.Uint16(fixed[len(streamMagic):]))
+	rest := make([]byte, wrappedLen+streamNonceLen(alg)-streamNonceSuffixLen)
+	if _, err := io.ReadFull(br, rest); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, errInvalidStreamHeader)
+	}
+
+	dataKey, err := UnwrapKey(rest[:wrappedLen], masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer securemem.Wipe(dataKey)
+
+	aead, err := newAEAD(alg, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &streamReader{
+		r:      br,
+		aead:   aead,
+		header: append(fixed, rest...),
+		prefix: rest[wrappedLen:],
+		chunk:  make([]byte, StreamChunkSize+streamTagSize),
+		buf:    make([]byte, 0, StreamChunkSize),
+	}, nil
+}
+
+// Read чтение расшифрованных данных.
+func (sr *streamReader) Read(p []byte) (int, error) {
+	for len(sr.plain) == 0 {
+		if sr.err != nil {
+			return 0, sr.err
+		}
+		if sr.done {
+			return 0, io.EOF
+		}
+		sr.err = sr.readChunk()
+	}
+
+	n := copy(p, sr.plain)
+	sr.plain = sr.plain[n:]
+
+	return n, nil
+}
+
+func (sr *streamReader) readChunk() error {
+	n, err := io.ReadFull(sr.r, sr.chunk)
+
+	var last bool
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return fmt.Errorf("failed to read stream chunk %w", err)
+	default:
+		// Полный блок последний, только если за ним ничего нет
+		if _, err = sr.r.Peek(1); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read stream chunk %w", err)
+		}
+		last = errors.Is(err, io.EOF)
+	}
+
+	if n < streamTagSize {
+		return errDecryptionFailed
+	}
+
+	sr.plain, err = sr.aead.Open(sr.buf[:0], streamNonce(sr.prefix, sr.counter, last), sr.chunk[:n], sr.header)
+	if err != nil {
+		return errDecryptionFailed
+	}
+
+	sr.counter++
+	sr.done = last
+
+	return nil
+}
+
+func streamNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, len(prefix)+streamNonceSuffixLen)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+func streamMagicOf(alg Algorithm) string {
+	if alg == AlgorithmXChaCha20Poly1305 {
+		return streamMagicXChaCha
+	}
+
+	return streamMagic
+}
+
+func streamNonceLen(alg Algorithm) int {
+	if alg == AlgorithmXChaCha20Poly1305 {
+		return chacha20poly1305.NonceSizeX
+	}
+
+	return gcmNonceLen
+}
@@ -0,0 +1,181 @@
+package encryptor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptStream(t *testing.T, plain, key []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(&buf, key)
+	require.NoError(t, err)
+
+	// Запись неровными кусками, чтобы границы Write не совпадали с границами блоков
+	for len(plain) > 0 {
+		n := min(len(plain), 1000)
+		_, err = w.Write(plain[:n])
+		require.NoError(t, err)
+		plain = plain[n:]
+	}
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestStream(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "small", size: 11},
+		{name: "exact chunk", size: StreamChunkSize},
+		{name: "exact chunks", size: 3 * StreamChunkSize},
+		{name: "several chunks", size: 3*StreamChunkSize + 17},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			plain := make([]byte, test.size)
+			_, err = rand.Read(plain)
+			require.NoError(t, err)
+
+			sealed := encryptStream(t, plain, md)
+			assert.Len(t, sealed, StreamSize(test.size))
+
+			r, err := NewDecryptReader(bytes.NewReader(sealed), md)
+			require.NoError(t, err)
+
+			opened, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, plain, opened)
+		})
+	}
+}
+
+func TestEncryptReader(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	for _, size := range []int{0, 11, StreamChunkSize, 3*StreamChunkSize + 17} {
+		plain := make([]byte, size)
+		_, err = rand.Read(plain)
+		require.NoError(t, err)
+
+		// Источник отдает данные неровными кусками, чтобы границы Read не совпадали с границами блоков
+		r, err := NewEncryptReader(iotest.HalfReader(bytes.NewReader(plain)), md)
+		require.NoError(t, err)
+
+		sealed, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Len(t, sealed, StreamSize(size))
+
+		dr, err := NewDecryptReader(bytes.NewReader(sealed), md)
+		require.NoError(t, err)
+
+		opened, err := io.ReadAll(dr)
+		require.NoError(t, err)
+		assert.Equal(t, plain, opened)
+	}
+
+	_, err = NewEncryptReader(bytes.NewReader(nil), make([]byte, 16))
+	require.Error(t, err)
+}
+
+func TestStreamTampering(t *testing.T) {
+	md, err := hex.DecodeString("f8f2761b99775dac26e373e4942d6fd648f29325db7312158cc88205ff5e86b8")
+	require.NoError(t, err)
+
+	plain := make([]byte, 2*StreamChunkSize+100)
+	sealed := encryptStream(t, plain, md)
+	headerLen := StreamSize(0) - streamTagSize
+	chunkLen := StreamChunkSize + streamTagSize
+
+	testCases := []struct {
+		name    string
+		data    []byte
+		key     []byte
+		wantErr bool
+	}{
+		{
+			name:    "truncated at chunk boundary",
+			data:    sealed[:headerLen+2*chunkLen],
+			key:     md,
+			wantErr: true,
+		},
+		{
+			name:    "truncated inside chunk",
+			data:    sealed[:len(sealed)-10],
+			key:     md,
+			wantErr: true,
+		},
+		{
+			name:    "without chunks",
+			data:    sealed[:headerLen],
+			key:     md,
+			wantErr: true,
+		},
+		{
+			name: "swapped chunks",
+			data: bytes.Join([][]byte{
+				sealed[:headerLen],
+				sealed[headerLen+chunkLen : headerLen+2*chunkLen],
+				sealed[headerLen : headerLen+chunkLen],
+				sealed[headerLen+2*chunkLen:],
+			}, nil),
+			key:     md,
+			wantErr: true,
+		},
+		{
+			name: "modified byte",
+			data: func() []byte {
+				d := bytes.Clone(sealed)
+				d[headerLen+chunkLen+5] ^= 1
+				return d
+			}(),
+			key:     md,
+			wantErr: true,
+		},
+		{
+			name:    "wrong key",
+			data:    sealed,
+			key:     make([]byte, 32),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewDecryptReader(bytes.NewReader(test.data), test.key)
+			if err == nil {
+				_, err = io.ReadAll(r)
+			}
+
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStreamHeader(t *testing.T) {
+	_, err := NewDecryptReader(bytes.NewReader([]byte("not a stream")), make([]byte, 32))
+	require.ErrorIs(t, err, errInvalidStreamHeader)
+
+	_, err = NewEncryptWriter(io.Discard, make([]byte, 16))
+	require.Error(t, err)
+}
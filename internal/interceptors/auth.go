@@ -3,18 +3,45 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Melikhov-p/goph-keeper/internal/auth"
 	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/session"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-// AuthInterceptor перехватчик авторизации.
-func AuthInterceptor(secretKey string) grpc.UnaryServerInterceptor {
+// SessionChecker проверка, что сессия токена доступа не отозвана.
+type SessionChecker interface {
+	Check(ctx context.Context, userID int, sessionID string) error
+}
+
+// UserChecker проверка, что пользователь токена существует и не заблокирован.
+type UserChecker interface {
+	CheckActive(ctx context.Context, userID int) error
+}
+
+// TokenParser проверка подписи и срока действия токена доступа.
+type TokenParser interface {
+	ParseToken(token string) (*auth.Claims, error)
+}
+
+// mfaMethods методы второго шага входа, доступные по токену подтверждения второго фактора.
+var mfaMethods = map[string]bool{
+	"/gophkeeper.v1.UserService/EnrollTOTP":  true,
+	"/gophkeeper.v1.UserService/ConfirmTOTP": true,
+	"/gophkeeper.v1.UserService/VerifyMFA":   true,
+}
+
+// AuthInterceptor перехватчик авторизации. Токен заблокированного пользователя не принимается,
+// даже если его подпись и срок действия в порядке.
+func AuthInterceptor(tokens TokenParser, sessions SessionChecker, users UserChecker) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req any,
@@ -23,7 +50,16 @@ func AuthInterceptor(secretKey string) grpc.UnaryServerInterceptor {
 	) (any, error) {
 		// Пропускаем аутентификацию для публичных методов
 		if info.FullMethod == "/gophkeeper.v1.UserService/Register" ||
-			info.FullMethod == "/gophkeeper.v1.UserService/Login" {
+			info.FullMethod == "/gophkeeper.v1.UserService/Login" ||
+			info.FullMethod == "/gophkeeper.v1.UserService/GetKDFParams" ||
+			info.FullMethod == "/gophkeeper.v1.UserService/GetPasswordPolicy" ||
+			info.FullMethod == "/gophkeeper.v1.UserService/RefreshToken" ||
+			info.FullMethod == "/gophkeeper.v1.OneTimeShareService/RedeemShare" {
+			return handler(ctx, req)
+		}
+
+		// Методы сервера не привязаны к пользователю и проверяют токен оператора сами
+		if strings.HasPrefix(info.FullMethod, "/gophkeeper.v1.SystemService/") {
 			return handler(ctx, req)
 		}
 
@@ -34,6 +70,13 @@ func AuthInterceptor(secretKey string) grpc.UnaryServerInterceptor {
 		}
 
 		authHeader := md.Get("authorization")
+
+		// Административные методы доступны по токену оператора без пользователя
+		// или администратору с токеном доступа, который проверяется как обычно
+		if strings.HasPrefix(info.FullMethod, "/gophkeeper.v1.AdminService/") && len(authHeader) == 0 {
+			return handler(ctx, req)
+		}
+
 		if len(authHeader) == 0 {
 			return nil, status.Error(codes.Unauthenticated, "authorization token not provided: %v")
 		}
@@ -41,14 +84,60 @@ func AuthInterceptor(secretKey string) grpc.UnaryServerInterceptor {
 		token := authHeader[0]
 
 		// Валидация токена
-		userID, err := auth.GetUserIDbyToken(token, secretKey)
+		claims, err := tokens.ParseToken(token)
 		if err != nil {
 			return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("invalid token: %v", err))
 		}
 
-		// Добавляем userID в контекст
-		newCtx := context.WithValue(ctx, contextkeys.UserID, userID)
+		// Токен второго шага входа подтверждает только пароль и не открывает доступ к данным
+		if claims.Purpose == auth.PurposeMFA {
+			if !mfaMethods[info.FullMethod] {
+				return nil, status.Error(codes.Unauthenticated, "second factor required")
+			}
+			if err = checkUser(ctx, users, claims.UserID); err != nil {
+				return nil, err
+			}
+			newCtx := context.WithValue(ctx, contextkeys.UserID, claims.UserID)
+			newCtx = context.WithValue(newCtx, contextkeys.ChallengeID, claims.ID)
+			return handler(newCtx, req)
+		}
+		if claims.Purpose != "" || info.FullMethod == "/gophkeeper.v1.UserService/VerifyMFA" {
+			return nil, status.Error(codes.Unauthenticated, "invalid token: unexpected purpose")
+		}
+
+		// Токен без сессии нельзя отозвать, поэтому такие токены не принимаются
+		if claims.SessionID == "" {
+			return nil, status.Error(codes.Unauthenticated, "invalid token: session required")
+		}
+		if err = sessions.Check(ctx, claims.UserID, claims.SessionID); err != nil {
+			if errors.Is(err, session.ErrRevoked) {
+				return nil, status.Error(codes.Unauthenticated, "session revoked")
+			}
+			return nil, status.Error(codes.Internal, "failed to check session")
+		}
+		if err = checkUser(ctx, users, claims.UserID); err != nil {
+			return nil, err
+		}
+
+		// Добавляем userID и сессию в контекст
+		newCtx := context.WithValue(ctx, contextkeys.UserID, claims.UserID)
+		newCtx = context.WithValue(newCtx, contextkeys.SessionID, claims.SessionID)
 
 		return handler(newCtx, req)
 	}
 }
+
+// checkUser ответ на запрос пользователя, который удален или заблокирован администратором.
+func checkUser(ctx context.Context, users UserChecker, userID int) error {
+	err := users.CheckActive(ctx, userID)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, user.ErrDisabled):
+		return status.Error(codes.PermissionDenied, "account is disabled")
+	case errors.Is(err, user.ErrNotFound):
+		return status.Error(codes.Unauthenticated, "user not found")
+	default:
+		return status.Error(codes.Internal, "failed to check user")
+	}
+}
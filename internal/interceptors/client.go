@@ -0,0 +1,39 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientInfoInterceptor перехватчик, сохраняющий в контексте адрес клиента и его user-agent для журнала аудита.
+// Адрес берется из соединения: заголовкам вроде X-Forwarded-For клиент может подставить любое значение.
+func ClientInfoInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ip := p.Addr.String()
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+			ctx = context.WithValue(ctx, contextkeys.ClientIP, ip)
+		}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ua := md.Get("user-agent"); len(ua) > 0 {
+				ctx = context.WithValue(ctx, contextkeys.UserAgent, strings.Join(ua, " "))
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
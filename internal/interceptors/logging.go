@@ -22,9 +22,9 @@ func LogInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
 
 		duration := time.Since(startTime)
 
+		// Ответ не логируется: в нем могут быть расшифрованные секреты
 		log.Debug("",
 			zap.String("method", info.FullMethod),
-			zap.Any("response", res),
 			zap.Duration("duration", duration),
 			zap.Error(err))
 
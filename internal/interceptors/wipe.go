@@ -0,0 +1,32 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"google.golang.org/grpc/stats"
+)
+
+// WipeHandler обработчик событий gRPC, который затирает расшифрованные данные запроса после отправки ответа.
+// Обработчики привязывают данные к контексту через securemem.WipeAfter.
+type WipeHandler struct{}
+
+// TagRPC создание области затирания для запроса.
+func (WipeHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return securemem.WithScope(ctx)
+}
+
+// HandleRPC затирание данных по завершении запроса, когда ответ уже отправлен.
+func (WipeHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if _, ok := s.(*stats.End); ok {
+		securemem.WipeScope(ctx)
+	}
+}
+
+// TagConn не используется.
+func (WipeHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn не используется.
+func (WipeHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,261 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+)
+
+const fileKeyPrefix = "fks:v"
+
+// fileKeystoreData формат файла хранилища ключей.
+type fileKeystoreData struct {
+	KDF     fileKeystoreKDF   `json:"kdf"`
+	Current int               `json:"current"`
+	Keys    []fileKeystoreKey `json:"keys"`
+}
+
+type fileKeystoreKDF struct {
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// fileKeystoreKey версия ключа, зашифрованная ключом из пароля хранилища.
+type fileKeystoreKey struct {
+	Version   int       `json:"version"`
+	Key       []byte    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FileKeystore провайдер с версионированными ключами в файле, зашифрованном паролем (Argon2id).
+// Файл без пароля бесполезен, но после открытия ключи находятся в памяти процесса.
+type FileKeystore struct {
+	mu   sync.RWMutex
+	path string
+	kek  []byte
+	data fileKeystoreData
+	keys map[int][]byte
+}
+
+// OpenFileKeystore открытие хранилища ключей. Если файла нет, создается новое хранилище с одним ключом.
+func OpenFileKeystore(path, passphrase string) (*FileKeystore, error) {
+	op := "kms.OpenFileKeystore"
+
+	if passphrase == "" {
+		return nil, fmt.Errorf("%s: keystore passphrase is empty", op)
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createFileKeystore(path, passphrase)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read keystore %w", op, err)
+	}
+
+	fk := FileKeystore{path: path, keys: make(map[int][]byte)}
+	if err = json.Unmarshal(raw, &fk.data); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse keystore %w", op, err)
+	}
+
+	if fk.kek, err = fk.data.KDF.deriveKey(passphrase); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, k := range fk.data.Keys {
+		key, err := encryptor.UnwrapKey(k.Key, fk.kek)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to unseal key version %d (wrong passphrase?) %w", op, k.Version, err)
+		}
+		fk.keys[k.Version] = key
+	}
+
+	if _, ok := fk.keys[fk.data.Current]; !ok {
+		return nil, fmt.Errorf("%s: current key version %d not found", op, fk.data.Current)
+	}
+
+	return &fk, nil
+}
+
+func createFileKeystore(path, passphrase string) (*FileKeystore, error) {
+	op := "kms.createFileKeystore"
+
+	params, err := encryptor.NewKDFParams()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fk := FileKeystore{
+		path: path,
+		keys: make(map[int][]byte),
+		data: fileKeystoreData{
+			KDF: fileKeystoreKDF{
+				Salt:    params.Salt,
+				Time:    params.Time,
+				Memory:  params.Memory,
+				Threads: params.Threads,
+			},
+		},
+	}
+
+	if fk.kek, err = fk.data.KDF.deriveKey(passphrase); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = fk.addKey(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &fk, nil
+}
+
+// Name тип провайдера.
+func (fk *FileKeystore) Name() string {
+	return ProviderFile
+}
+
+// WrapKey шифрование ключа данных текущей версией ключа.
+func (fk *FileKeystore) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	fk.mu.RLock()
+	version := fk.data.Current
+	kek := fk.keys[version]
+	fk.mu.RUnlock()
+
+	wrapped, err := encryptor.WrapKey(key, kek)
+	if err != nil {
+		return nil, fmt.Errorf("kms.FileKeystore.WrapKey: %w", err)
+	}
+
+	return append([]byte(fileKeyPrefix+strconv.Itoa(version)+":"), wrapped...), nil
+}
+
+// UnwrapKey расшифровка ключа данных версией ключа, указанной в префиксе.
+func (fk *FileKeystore) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	op := "kms.FileKeystore.UnwrapKey"
+
+	rest, ok := strings.CutPrefix(string(wrapped), fileKeyPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidWrappedKey)
+	}
+
+	versionStr, payload, ok := strings.Cut(rest, ":")
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidWrappedKey)
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidWrappedKey)
+	}
+
+	fk.mu.RLock()
+	kek, ok := fk.keys[version]
+	fk.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: key version %d not found %w", op, version, ErrInvalidWrappedKey)
+	}
+
+	key, err := encryptor.UnwrapKey([]byte(payload), kek)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+// Rotate добавление новой версии ключа и сохранение хранилища.
+func (fk *FileKeystore) Rotate(_ context.Context) error {
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	if err := fk.addKey(); err != nil {
+		return fmt.Errorf("kms.FileKeystore.Rotate: %w", err)
+	}
+
+	return nil
+}
+
+// addKey генерация новой версии ключа и сохранение файла. Вызывается под блокировкой.
+func (fk *FileKeystore) addKey() error {
+	key, err := encryptor.NewVaultKey()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := encryptor.WrapKey(key, fk.kek)
+	if err != nil {
+		return err
+	}
+
+	data := fk.data
+	data.Current++
+	data.Keys = append(append([]fileKeystoreKey(nil), data.Keys...), fileKeystoreKey{
+		Version:   data.Current,
+		Key:       sealed,
+		CreatedAt: time.Now(),
+	})
+
+	if err = writeFileAtomic(fk.path, data); err != nil {
+		return err
+	}
+
+	fk.data = data
+	fk.keys[data.Current] = key
+
+	return nil
+}
+
+func (kdf fileKeystoreKDF) deriveKey(passphrase string) ([]byte, error) {
+	_, kek, err := encryptor.DeriveClientKeys(passphrase, &encryptor.KDFParams{
+		Salt:    kdf.Salt,
+		Time:    kdf.Time,
+		Memory:  kdf.Memory,
+		Threads: kdf.Threads,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keystore key %w", err)
+	}
+
+	return kek, nil
+}
+
+// writeFileAtomic запись JSON во временный файл и переименование, чтобы не оставить файл недописанным.
+func writeFileAtomic(path string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s %w", tmp.Name(), err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s %w", tmp.Name(), err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s %w", path, err)
+	}
+
+	return nil
+}
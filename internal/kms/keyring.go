@@ -0,0 +1,226 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+)
+
+// ErrProviderMismatch ключи keyring-файла обернуты другим провайдером.
+var ErrProviderMismatch = errors.New("keyring is wrapped by another provider")
+
+// keyring файл с ключами сервера, обернутыми провайдером ключей.
+// Хранится отдельно от БД: без провайдера он бесполезен, а миграциям БД нужны уже развернутые ключи.
+// Ключи данных пользователей хранятся в БД, обернутые тем же провайдером.
+type keyring struct {
+	Provider string `json:"provider"`
+	// FieldKey ключ шифрования полей секретов, по которым сервер ищет секреты (названия, логины, адреса).
+	FieldKey []byte `json:"field_key,omitempty"`
+	// WrappedKey корневой ключ, которым данные шифровались до появления ключей данных пользователей.
+	// Нужен только миграции, которая перешифровывает данные, после нее удаляется через DropLegacyKey.
+	WrappedKey []byte    `json:"wrapped_key,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	RotatedAt  time.Time `json:"rotated_at,omitempty"`
+	// Параметры разделения ключа провайдера shamir.
+	Shares    int `json:"shares,omitempty"`
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// Keys ключи сервера, развернутые из keyring-файла.
+type Keys struct {
+	// Field ключ шифрования полей секретов.
+	Field []byte
+	// Legacy корневой ключ, которым зашифрованы данные, сохраненные до появления ключей данных пользователей.
+	// nil, если данные уже перешифрованы.
+	Legacy []byte
+}
+
+// Wipe затирание ключей.
+func (k *Keys) Wipe() {
+	securemem.Wipe(k.Field)
+	securemem.Wipe(k.Legacy)
+}
+
+// LoadKeys получение ключей сервера из keyring-файла через провайдер.
+// Если файла нет, генерируется новый ключ полей, а legacyKey (ключ, которым уже могут быть зашифрованы
+// данные) сохраняется как устаревший корневой ключ до перешифровки данных.
+// Если ключи обернуты провайдером local, а выбран другой провайдер, они переоборачиваются при
+// заданном legacyKey — так выполняется перенос ключей из конфига во внешний KMS.
+func LoadKeys(ctx context.Context, p KeyProvider, path string, legacyKey []byte) (*Keys, error) {
+	op := "kms.LoadKeys"
+
+	kr, err := readKeyring(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createKeys(ctx, p, path, legacyKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	src, changed := KeyProvider(p), kr.Provider != p.Name()
+	if changed {
+		if kr.Provider != ProviderLocal || legacyKey == nil {
+			return nil, fmt.Errorf("%s: %w: %s, configured %s", op, ErrProviderMismatch, kr.Provider, p.Name())
+		}
+		if src, err = NewLocalProvider(legacyKey); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	keys, err := unwrapKeys(ctx, src, kr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Файл, созданный до появления ключа полей, хранит только корневой ключ
+	if keys.Field == nil {
+		if keys.Field, err = encryptor.NewVaultKey(); err != nil {
+			keys.Wipe()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		changed = true
+	}
+
+	if changed {
+		if err = wrapKeys(ctx, p, path, kr, keys); err != nil {
+			keys.Wipe()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return keys, nil
+}
+
+// DropLegacyKey удаление устаревшего корневого ключа из keyring-файла после перешифровки данных.
+func DropLegacyKey(path string) error {
+	op := "kms.DropLegacyKey"
+
+	kr, err := readKeyring(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if kr.WrappedKey == nil {
+		return nil
+	}
+	kr.WrappedKey = nil
+
+	if err = writeFileAtomic(path, kr); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateKEK ротация ключа провайдера и переоборачивание ключей keyring-файла новой версией.
+// Данные не перешифровываются, ключи данных пользователей в БД переоборачиваются отдельно.
+func RotateKEK(ctx context.Context, p KeyProvider, path string) error {
+	op := "kms.RotateKEK"
+
+	kr, err := readKeyring(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if kr.Provider != p.Name() {
+		return fmt.Errorf("%s: %w: %s, configured %s", op, ErrProviderMismatch, kr.Provider, p.Name())
+	}
+
+	keys, err := unwrapKeys(ctx, p, kr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer keys.Wipe()
+
+	if err = p.Rotate(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = wrapKeys(ctx, p, path, kr, keys); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func createKeys(ctx context.Context, p KeyProvider, path string, legacyKey []byte) (*Keys, error) {
+	op := "kms.createKeys"
+
+	field, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys := &Keys{Field: field, Legacy: slices.Clone(legacyKey)}
+
+	if err = wrapKeys(ctx, p, path, keyring{CreatedAt: time.Now()}, keys); err != nil {
+		keys.Wipe()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+func unwrapKeys(ctx context.Context, p KeyProvider, kr keyring) (*Keys, error) {
+	var (
+		keys Keys
+		err  error
+	)
+
+	if kr.FieldKey != nil {
+		if keys.Field, err = p.UnwrapKey(ctx, kr.FieldKey); err != nil {
+			return nil, fmt.Errorf("failed to unwrap field key %w", err)
+		}
+	}
+
+	if kr.WrappedKey != nil {
+		if keys.Legacy, err = p.UnwrapKey(ctx, kr.WrappedKey); err != nil {
+			keys.Wipe()
+			return nil, fmt.Errorf("failed to unwrap legacy root key %w", err)
+		}
+	}
+
+	return &keys, nil
+}
+
+func wrapKeys(ctx context.Context, p KeyProvider, path string, kr keyring, keys *Keys) error {
+	field, err := p.WrapKey(ctx, keys.Field)
+	if err != nil {
+		return fmt.Errorf("failed to wrap field key %w", err)
+	}
+
+	var legacy []byte
+	if keys.Legacy != nil {
+		if legacy, err = p.WrapKey(ctx, keys.Legacy); err != nil {
+			return fmt.Errorf("failed to wrap legacy root key %w", err)
+		}
+	}
+
+	if kr.Provider != "" {
+		kr.RotatedAt = time.Now()
+	}
+	kr.Provider = p.Name()
+	kr.FieldKey = field
+	kr.WrappedKey = legacy
+
+	return writeFileAtomic(path, kr)
+}
+
+func readKeyring(path string) (keyring, error) {
+	var kr keyring
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return kr, fmt.Errorf("failed to read keyring %w", err)
+	}
+
+	if err = json.Unmarshal(raw, &kr); err != nil {
+		return kr, fmt.Errorf("failed to parse keyring %w", err)
+	}
+
+	return kr, nil
+}
@@ -0,0 +1,73 @@
+// Package kms пакет провайдеров ключей, которыми шифруются (оборачиваются) ключи данных пользователей и ключи сервера.
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+)
+
+// Типы провайдеров ключей.
+const (
+	ProviderLocal = "local"
+	ProviderFile  = "file"
+	ProviderVault = "vault"
+	// ProviderShamir ключ провайдера разделен на доли, сервер стартует запечатанным.
+	ProviderShamir = "shamir"
+)
+
+var (
+	// ErrUnknownProvider неизвестный тип провайдера ключей.
+	ErrUnknownProvider = errors.New("unknown key provider")
+	// ErrRotationUnsupported провайдер не умеет ротировать ключ.
+	ErrRotationUnsupported = errors.New("key rotation is not supported by provider")
+	// ErrInvalidWrappedKey обернутый ключ не относится к провайдеру или поврежден.
+	ErrInvalidWrappedKey = errors.New("invalid wrapped key")
+	// ErrSealed ключ провайдера shamir появляется только после распечатывания сервера.
+	ErrSealed = errors.New("key provider is sealed")
+)
+
+// KeyProvider провайдер ключа шифрования ключей (KEK).
+// Ключ провайдера не покидает его: наружу выдаются только обернутые и развернутые ключи данных.
+type KeyProvider interface {
+	// Name тип провайдера, сохраняется вместе с обернутым ключом.
+	Name() string
+	// WrapKey шифрование ключа данных текущей версией ключа провайдера.
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	// UnwrapKey расшифровка ключа данных, обернутого любой версией ключа провайдера.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+	// Rotate создание новой версии ключа провайдера. Старые версии остаются доступны для UnwrapKey.
+	Rotate(ctx context.Context) error
+}
+
+// New получение провайдера ключей по конфигу.
+func New(cfg *config.KeyProviderConfig) (KeyProvider, error) {
+	op := "kms.New"
+
+	switch cfg.Type {
+	case ProviderLocal:
+		key, err := cfg.LocalMasterKey()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return NewLocalProvider(key)
+	case ProviderFile:
+		p, err := OpenFileKeystore(cfg.File.Path, cfg.File.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return p, nil
+	case ProviderVault:
+		p, err := NewVaultTransit(cfg.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return p, nil
+	case ProviderShamir:
+		return nil, fmt.Errorf("%s: %w", op, ErrSealed)
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnknownProvider, cfg.Type)
+	}
+}
@@ -0,0 +1,300 @@
+package kms_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vaultStub минимальная реализация transit API: ключи версионируются, "шифрование" обратимо
+// и привязано к версии, чтобы проверять работу провайдера, а не криптографию Vault.
+type vaultStub struct {
+	mu      sync.Mutex
+	token   string
+	version int
+}
+
+func (vs *vaultStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != vs.token {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	var req map[string]string
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	reply := func(data map[string]string) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}
+
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/gk":
+		reply(map[string]string{
+			"ciphertext": "vault:v" + strconv.Itoa(vs.version) + ":" + req["plaintext"],
+		})
+	case "/v1/transit/decrypt/gk":
+		parts := strings.SplitN(req["ciphertext"], ":", 3)
+		if len(parts) != 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+			return
+		}
+		version, _ := strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+		if version < 1 || version > vs.version {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid ciphertext"]}`))
+			return
+		}
+		reply(map[string]string{"plaintext": parts[2]})
+	case "/v1/transit/keys/gk/rotate":
+		vs.version++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+	}
+}
+
+func newVaultProvider(t *testing.T, token string) (*kms.VaultTransit, *vaultStub) {
+	t.Helper()
+
+	stub := &vaultStub{token: "root", version: 1}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+
+	p, err := kms.NewVaultTransit(config.VaultTransitConfig{
+		Address: srv.URL,
+		Token:   token,
+		Mount:   "transit",
+		KeyName: "gk",
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	return p, stub
+}
+
+func TestProviders(t *testing.T) {
+	local, err := kms.NewLocalProvider(make([]byte, 32))
+	require.NoError(t, err)
+
+	file, err := kms.OpenFileKeystore(filepath.Join(t.TempDir(), "keystore.json"), "passphrase")
+	require.NoError(t, err)
+
+	vault, _ := newVaultProvider(t, "root")
+
+	testCases := []struct {
+		name      string
+		provider  kms.KeyProvider
+		canRotate bool
+	}{
+		{name: "local", provider: local, canRotate: false},
+		{name: "file", provider: file, canRotate: true},
+		{name: "vault", provider: vault, canRotate: true},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := encryptor.NewVaultKey()
+			require.NoError(t, err)
+
+			wrapped, err := test.provider.WrapKey(ctx, key)
+			require.NoError(t, err)
+			assert.NotContains(t, string(wrapped), string(key))
+
+			unwrapped, err := test.provider.UnwrapKey(ctx, wrapped)
+			require.NoError(t, err)
+			assert.Equal(t, key, unwrapped)
+
+			err = test.provider.Rotate(ctx)
+			if !test.canRotate {
+				require.ErrorIs(t, err, kms.ErrRotationUnsupported)
+				return
+			}
+			require.NoError(t, err)
+
+			rewrapped, err := test.provider.WrapKey(ctx, key)
+			require.NoError(t, err)
+			assert.NotEqual(t, wrapped, rewrapped)
+
+			// Ключи, обернутые до ротации, остаются доступны
+			unwrapped, err = test.provider.UnwrapKey(ctx, wrapped)
+			require.NoError(t, err)
+			assert.Equal(t, key, unwrapped)
+		})
+	}
+}
+
+func TestFileKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	ctx := context.Background()
+
+	fk, err := kms.OpenFileKeystore(path, "passphrase")
+	require.NoError(t, err)
+	require.NoError(t, fk.Rotate(ctx))
+
+	wrapped, err := fk.WrapKey(ctx, []byte("data key"))
+	require.NoError(t, err)
+
+	reopened, err := kms.OpenFileKeystore(path, "passphrase")
+	require.NoError(t, err)
+
+	key, err := reopened.UnwrapKey(ctx, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data key"), key)
+
+	_, err = kms.OpenFileKeystore(path, "wrong passphrase")
+	require.Error(t, err)
+
+	_, err = kms.OpenFileKeystore(path, "")
+	require.Error(t, err)
+
+	_, err = reopened.UnwrapKey(ctx, []byte("vault:v1:abc"))
+	require.ErrorIs(t, err, kms.ErrInvalidWrappedKey)
+}
+
+func TestVaultTransitErrors(t *testing.T) {
+	p, _ := newVaultProvider(t, "bad token")
+
+	_, err := p.WrapKey(context.Background(), []byte("key"))
+	require.ErrorContains(t, err, "permission denied")
+
+	_, err = p.UnwrapKey(context.Background(), []byte("fks:v1:abc"))
+	require.ErrorIs(t, err, kms.ErrInvalidWrappedKey)
+
+	_, err = kms.NewVaultTransit(config.VaultTransitConfig{Address: "http://127.0.0.1:8200"})
+	require.Error(t, err)
+}
+
+func TestLoadKeys(t *testing.T) {
+	ctx := context.Background()
+	legacy := []byte(strings.Repeat("k", 32))
+
+	t.Run("new keyring", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		p, _ := newVaultProvider(t, "root")
+
+		keys, err := kms.LoadKeys(ctx, p, path, nil)
+		require.NoError(t, err)
+		assert.Len(t, keys.Field, 32)
+		assert.Nil(t, keys.Legacy)
+
+		again, err := kms.LoadKeys(ctx, p, path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keys.Field, again.Field)
+
+		// В keyring-файле лежат только обернутые ключи
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.False(t, bytes.Contains(raw, keys.Field))
+	})
+
+	t.Run("migrate from local", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+
+		local, err := kms.NewLocalProvider(legacy)
+		require.NoError(t, err)
+
+		keys, err := kms.LoadKeys(ctx, local, path, legacy)
+		require.NoError(t, err)
+		assert.Equal(t, legacy, keys.Legacy)
+		assert.NotEqual(t, legacy, keys.Field)
+
+		vault, _ := newVaultProvider(t, "root")
+
+		_, err = kms.LoadKeys(ctx, vault, path, nil)
+		require.ErrorIs(t, err, kms.ErrProviderMismatch)
+
+		migrated, err := kms.LoadKeys(ctx, vault, path, legacy)
+		require.NoError(t, err)
+		assert.Equal(t, keys.Field, migrated.Field)
+		assert.Equal(t, legacy, migrated.Legacy)
+
+		// После переноса ключ из конфига больше не нужен
+		loaded, err := kms.LoadKeys(ctx, vault, path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keys.Field, loaded.Field)
+
+		// После перешифровки данных устаревший корневой ключ удаляется из файла
+		require.NoError(t, kms.DropLegacyKey(path))
+		loaded, err = kms.LoadKeys(ctx, vault, path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keys.Field, loaded.Field)
+		assert.Nil(t, loaded.Legacy)
+	})
+
+	t.Run("rotate kek", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		p, stub := newVaultProvider(t, "root")
+
+		keys, err := kms.LoadKeys(ctx, p, path, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, kms.RotateKEK(ctx, p, path))
+		assert.Equal(t, 2, stub.version)
+
+		loaded, err := kms.LoadKeys(ctx, p, path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, keys.Field, loaded.Field)
+	})
+}
+
+func TestShamir(t *testing.T) {
+	ctx := context.Background()
+	legacy := []byte(strings.Repeat("k", 32))
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	local, err := kms.NewLocalProvider(legacy)
+	require.NoError(t, err)
+	keys, err := kms.LoadKeys(ctx, local, path, legacy)
+	require.NoError(t, err)
+
+	shares, err := kms.InitShamir(ctx, path, 5, 3, legacy)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	total, threshold, err := kms.ShamirParams(path)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, 3, threshold)
+
+	unsealKey, err := kms.UnsealKey(ctx, path, [][]byte{shares[4], shares[0], shares[2]})
+	require.NoError(t, err)
+
+	p, err := kms.NewShamirProvider(unsealKey)
+	require.NoError(t, err)
+	loaded, err := kms.LoadKeys(ctx, p, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, keys.Field, loaded.Field)
+	assert.Equal(t, legacy, loaded.Legacy)
+
+	_, err = kms.UnsealKey(ctx, path, shares[:2])
+	require.Error(t, err)
+
+	_, err = kms.InitShamir(ctx, path, 5, 3, legacy)
+	require.ErrorIs(t, err, kms.ErrAlreadyInitialized)
+
+	_, err = kms.New(&config.KeyProviderConfig{Type: kms.ProviderShamir})
+	require.ErrorIs(t, err, kms.ErrSealed)
+}
@@ -0,0 +1,64 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+)
+
+// LocalProvider провайдер с ключом из конфига приложения. Ключ хранится в памяти процесса,
+// поэтому провайдер подходит только для разработки и переноса данных на другой провайдер.
+type LocalProvider struct {
+	name string
+	key  []byte
+}
+
+// NewLocalProvider получение локального провайдера.
+func NewLocalProvider(key []byte) (*LocalProvider, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("kms.NewLocalProvider: master key is empty")
+	}
+
+	return &LocalProvider{name: ProviderLocal, key: key}, nil
+}
+
+// NewShamirProvider получение провайдера с ключом распечатывания, восстановленным из долей Шамира.
+// Ключ нигде не хранится и живет в памяти только пока сервер распечатан.
+func NewShamirProvider(key []byte) (*LocalProvider, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("kms.NewShamirProvider: unseal key is empty")
+	}
+
+	return &LocalProvider{name: ProviderShamir, key: key}, nil
+}
+
+// Name тип провайдера.
+func (lp *LocalProvider) Name() string {
+	return lp.name
+}
+
+// WrapKey шифрование ключа данных.
+func (lp *LocalProvider) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	wrapped, err := encryptor.WrapKey(key, lp.key)
+	if err != nil {
+		return nil, fmt.Errorf("kms.LocalProvider.WrapKey: %w", err)
+	}
+
+	return wrapped, nil
+}
+
+// UnwrapKey расшифровка ключа данных.
+func (lp *LocalProvider) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	key, err := encryptor.UnwrapKey(wrapped, lp.key)
+	if err != nil {
+		return nil, fmt.Errorf("kms.LocalProvider.UnwrapKey: %w", err)
+	}
+
+	return key, nil
+}
+
+// Rotate ключ задается в конфиге, ротация не поддерживается.
+func (lp *LocalProvider) Rotate(_ context.Context) error {
+	return fmt.Errorf("kms.LocalProvider.Rotate: %w", ErrRotationUnsupported)
+}
@@ -0,0 +1,114 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/Melikhov-p/goph-keeper/internal/shamir"
+)
+
+// ErrAlreadyInitialized ключи keyring-файла уже обернуты ключом, разделенным на доли.
+var ErrAlreadyInitialized = errors.New("keyring is already initialized with shamir shares")
+
+// InitShamir генерация нового ключа распечатывания, разделение его на доли и переоборачивание им
+// ключей keyring-файла. Ключи провайдера local переносятся при заданном legacyKey,
+// при отсутствии keyring-файла он создается, как в LoadKeys.
+// Возвращает доли, которые нужно раздать операторам: сам ключ распечатывания нигде не сохраняется.
+func InitShamir(ctx context.Context, path string, shares, threshold int, legacyKey []byte) ([][]byte, error) {
+	op := "kms.InitShamir"
+
+	kr, err := readKeyring(path)
+	if err == nil && kr.Provider == ProviderShamir {
+		return nil, fmt.Errorf("%s: %w", op, ErrAlreadyInitialized)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unsealKey, err := encryptor.NewVaultKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	parts, err := shamir.Split(unsealKey, shares, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := NewShamirProvider(unsealKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := LoadKeys(ctx, p, path, legacyKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys.Wipe()
+
+	if kr, err = readKeyring(path); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	kr.Shares = shares
+	kr.Threshold = threshold
+
+	if err = writeFileAtomic(path, kr); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return parts, nil
+}
+
+// ShamirParams число долей и порог из keyring-файла, инициализированного через InitShamir.
+func ShamirParams(path string) (int, int, error) {
+	op := "kms.ShamirParams"
+
+	kr, err := readKeyring(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if kr.Provider != ProviderShamir {
+		return 0, 0, fmt.Errorf("%s: %w: %s", op, ErrProviderMismatch, kr.Provider)
+	}
+
+	return kr.Shares, kr.Threshold, nil
+}
+
+// UnsealKey восстановление ключа распечатывания из долей. Ключ проверяется разворачиванием ключей
+// keyring-файла и возвращается для провайдера NewShamirProvider; сами ключи сразу затираются.
+func UnsealKey(ctx context.Context, path string, shares [][]byte) ([]byte, error) {
+	op := "kms.UnsealKey"
+
+	unsealKey, err := shamir.Combine(shares)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	p, err := NewShamirProvider(unsealKey)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	kr, err := readKeyring(path)
+	if err != nil {
+		securemem.Wipe(unsealKey)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if kr.Provider != ProviderShamir {
+		securemem.Wipe(unsealKey)
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrProviderMismatch, kr.Provider)
+	}
+
+	keys, err := unwrapKeys(ctx, p, kr)
+	if err != nil {
+		securemem.Wipe(unsealKey)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	keys.Wipe()
+
+	return unsealKey, nil
+}
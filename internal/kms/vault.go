@@ -0,0 +1,167 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+)
+
+const vaultCiphertextPrefix = "vault:v"
+
+// VaultTransit провайдер, который шифрует ключи через transit secrets engine HashiCorp Vault.
+// Ключ шифрования находится только в Vault, в процесс приходят лишь развернутые ключи данных.
+type VaultTransit struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+	mount     string
+	keyName   string
+}
+
+type vaultResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+// NewVaultTransit получение клиента Vault transit.
+func NewVaultTransit(cfg config.VaultTransitConfig) (*VaultTransit, error) {
+	op := "kms.NewVaultTransit"
+
+	if cfg.Address == "" || cfg.Token == "" {
+		return nil, fmt.Errorf("%s: vault address and token are required", op)
+	}
+	if _, err := url.ParseRequestURI(cfg.Address); err != nil {
+		return nil, fmt.Errorf("%s: invalid vault address %w", op, err)
+	}
+
+	return &VaultTransit{
+		client:    &http.Client{Timeout: cfg.Timeout},
+		address:   strings.TrimRight(cfg.Address, "/"),
+		token:     cfg.Token,
+		namespace: cfg.Namespace,
+		mount:     strings.Trim(cfg.Mount, "/"),
+		keyName:   cfg.KeyName,
+	}, nil
+}
+
+// Name тип провайдера.
+func (vt *VaultTransit) Name() string {
+	return ProviderVault
+}
+
+// WrapKey шифрование ключа данных через transit/encrypt.
+func (vt *VaultTransit) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	op := "kms.VaultTransit.WrapKey"
+
+	var res struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+
+	err := vt.do(ctx, "encrypt/"+url.PathEscape(vt.keyName), map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	}, &res)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []byte(res.Ciphertext), nil
+}
+
+// UnwrapKey расшифровка ключа данных через transit/decrypt.
+func (vt *VaultTransit) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	op := "kms.VaultTransit.UnwrapKey"
+
+	if !bytes.HasPrefix(wrapped, []byte(vaultCiphertextPrefix)) {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidWrappedKey)
+	}
+
+	var res struct {
+		Plaintext string `json:"plaintext"`
+	}
+
+	err := vt.do(ctx, "decrypt/"+url.PathEscape(vt.keyName), map[string]string{
+		"ciphertext": string(wrapped),
+	}, &res)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(res.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to decode plaintext %w", op, err)
+	}
+
+	return key, nil
+}
+
+// Rotate ротация ключа в Vault через transit/keys/:name/rotate.
+func (vt *VaultTransit) Rotate(ctx context.Context) error {
+	if err := vt.do(ctx, "keys/"+url.PathEscape(vt.keyName)+"/rotate", nil, nil); err != nil {
+		return fmt.Errorf("kms.VaultTransit.Rotate: %w", err)
+	}
+
+	return nil
+}
+
+// do выполнение POST запроса к transit API и разбор поля data из ответа.
+func (vt *VaultTransit) do(ctx context.Context, path string, body, out any) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request %w", err)
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, vt.address+"/v1/"+vt.mount+"/"+path, reqBody,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build request %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", vt.token)
+	req.Header.Set("Content-Type", "application/json")
+	if vt.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", vt.namespace)
+	}
+
+	resp, err := vt.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call vault %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var res vaultResponse
+	if resp.StatusCode != http.StatusNoContent {
+		if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return fmt.Errorf("failed to decode vault response with status %d %w", resp.StatusCode, err)
+		}
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(res.Errors, "; "))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(res.Data, out); err != nil {
+		return fmt.Errorf("failed to decode vault response data %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/approval (interfaces: Repository,Secrets)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/approval.go -package=mocks -mock_names=Repository=MockApprovalRepository,Secrets=MockApprovalSecrets . Repository,Secrets
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	approval "github.com/Melikhov-p/goph-keeper/internal/domain/approval"
+	secret "github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalRepository is a mock of Repository interface.
+type MockApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalRepositoryMockRecorder is the mock recorder for MockApprovalRepository.
+type MockApprovalRepositoryMockRecorder struct {
+	mock *MockApprovalRepository
+}
+
+// NewMockApprovalRepository creates a new mock instance.
+func NewMockApprovalRepository(ctrl *gomock.Controller) *MockApprovalRepository {
+	mock := &MockApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepository) EXPECT() *MockApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ActiveGrants mocks base method.
+func (m *MockApprovalRepository) ActiveGrants(ctx context.Context, userID int, secretIDs []int, now time.Time) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveGrants", ctx, userID, secretIDs, now)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveGrants indicates an expected call of ActiveGrants.
+func (mr *MockApprovalRepositoryMockRecorder) ActiveGrants(ctx, userID, secretIDs, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveGrants", reflect.TypeOf((*MockApprovalRepository)(nil).ActiveGrants), ctx, userID, secretIDs, now)
+}
+
+// AddEvents mocks base method.
+func (m *MockApprovalRepository) AddEvents(ctx context.Context, events []*approval.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvents indicates an expected call of AddEvents.
+func (mr *MockApprovalRepositoryMockRecorder) AddEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockApprovalRepository)(nil).AddEvents), ctx, events)
+}
+
+// CreateRequest mocks base method.
+func (m *MockApprovalRepository) CreateRequest(ctx context.Context, r *approval.Request, ev *approval.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRequest", ctx, r, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRequest indicates an expected call of CreateRequest.
+func (mr *MockApprovalRepositoryMockRecorder) CreateRequest(ctx, r, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRequest", reflect.TypeOf((*MockApprovalRepository)(nil).CreateRequest), ctx, r, ev)
+}
+
+// Decide mocks base method.
+func (m *MockApprovalRepository) Decide(ctx context.Context, r *approval.Request, ev *approval.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, r, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockApprovalRepositoryMockRecorder) Decide(ctx, r, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockApprovalRepository)(nil).Decide), ctx, r, ev)
+}
+
+// DeletePolicy mocks base method.
+func (m *MockApprovalRepository) DeletePolicy(ctx context.Context, p *approval.Policy, ev *approval.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", ctx, p, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockApprovalRepositoryMockRecorder) DeletePolicy(ctx, p, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockApprovalRepository)(nil).DeletePolicy), ctx, p, ev)
+}
+
+// GetPolicy mocks base method.
+func (m *MockApprovalRepository) GetPolicy(ctx context.Context, secretID int) (*approval.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, secretID)
+	ret0, _ := ret[0].(*approval.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockApprovalRepositoryMockRecorder) GetPolicy(ctx, secretID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockApprovalRepository)(nil).GetPolicy), ctx, secretID)
+}
+
+// GetRequest mocks base method.
+func (m *MockApprovalRepository) GetRequest(ctx context.Context, id int) (*approval.Request, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequest", ctx, id)
+	ret0, _ := ret[0].(*approval.Request)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequest indicates an expected call of GetRequest.
+func (mr *MockApprovalRepositoryMockRecorder) GetRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequest", reflect.TypeOf((*MockApprovalRepository)(nil).GetRequest), ctx, id)
+}
+
+// ListEvents mocks base method.
+func (m *MockApprovalRepository) ListEvents(ctx context.Context, secretID int) ([]*approval.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, secretID)
+	ret0, _ := ret[0].([]*approval.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockApprovalRepositoryMockRecorder) ListEvents(ctx, secretID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockApprovalRepository)(nil).ListEvents), ctx, secretID)
+}
+
+// ListRequests mocks base method.
+func (m *MockApprovalRepository) ListRequests(ctx context.Context, userID int) ([]*approval.Request, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRequests", ctx, userID)
+	ret0, _ := ret[0].([]*approval.Request)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRequests indicates an expected call of ListRequests.
+func (mr *MockApprovalRepositoryMockRecorder) ListRequests(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRequests", reflect.TypeOf((*MockApprovalRepository)(nil).ListRequests), ctx, userID)
+}
+
+// OpenRequest mocks base method.
+func (m *MockApprovalRepository) OpenRequest(ctx context.Context, secretID, requesterID int, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenRequest", ctx, secretID, requesterID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenRequest indicates an expected call of OpenRequest.
+func (mr *MockApprovalRepositoryMockRecorder) OpenRequest(ctx, secretID, requesterID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRequest", reflect.TypeOf((*MockApprovalRepository)(nil).OpenRequest), ctx, secretID, requesterID, now)
+}
+
+// ProtectedSecrets mocks base method.
+func (m *MockApprovalRepository) ProtectedSecrets(ctx context.Context, secretIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProtectedSecrets", ctx, secretIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProtectedSecrets indicates an expected call of ProtectedSecrets.
+func (mr *MockApprovalRepositoryMockRecorder) ProtectedSecrets(ctx, secretIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProtectedSecrets", reflect.TypeOf((*MockApprovalRepository)(nil).ProtectedSecrets), ctx, secretIDs)
+}
+
+// SavePolicy mocks base method.
+func (m *MockApprovalRepository) SavePolicy(ctx context.Context, p *approval.Policy, ev *approval.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePolicy", ctx, p, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePolicy indicates an expected call of SavePolicy.
+func (mr *MockApprovalRepositoryMockRecorder) SavePolicy(ctx, p, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePolicy", reflect.TypeOf((*MockApprovalRepository)(nil).SavePolicy), ctx, p, ev)
+}
+
+// MockApprovalSecrets is a mock of Secrets interface.
+type MockApprovalSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalSecretsMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalSecretsMockRecorder is the mock recorder for MockApprovalSecrets.
+type MockApprovalSecretsMockRecorder struct {
+	mock *MockApprovalSecrets
+}
+
+// NewMockApprovalSecrets creates a new mock instance.
+func NewMockApprovalSecrets(ctrl *gomock.Controller) *MockApprovalSecrets {
+	mock := &MockApprovalSecrets{ctrl: ctrl}
+	mock.recorder = &MockApprovalSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalSecrets) EXPECT() *MockApprovalSecretsMockRecorder {
+	return m.recorder
+}
+
+// GetSecretByID mocks base method.
+func (m *MockApprovalSecrets) GetSecretByID(ctx context.Context, id, userID int) (*secret.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretByID", ctx, id, userID)
+	ret0, _ := ret[0].(*secret.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretByID indicates an expected call of GetSecretByID.
+func (mr *MockApprovalSecretsMockRecorder) GetSecretByID(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretByID", reflect.TypeOf((*MockApprovalSecrets)(nil).GetSecretByID), ctx, id, userID)
+}
+
+// GetSharedSecrets mocks base method.
+func (m *MockApprovalSecrets) GetSharedSecrets(ctx context.Context, recipientID int) ([]*secret.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedSecrets", ctx, recipientID)
+	ret0, _ := ret[0].([]*secret.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedSecrets indicates an expected call of GetSharedSecrets.
+func (mr *MockApprovalSecretsMockRecorder) GetSharedSecrets(ctx, recipientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedSecrets", reflect.TypeOf((*MockApprovalSecrets)(nil).GetSharedSecrets), ctx, recipientID)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/datakey (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/datakey.go -package=mocks -mock_names=Repository=MockDataKeyRepository . Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	datakey "github.com/Melikhov-p/goph-keeper/internal/domain/datakey"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDataKeyRepository is a mock of Repository interface.
+type MockDataKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDataKeyRepositoryMockRecorder is the mock recorder for MockDataKeyRepository.
+type MockDataKeyRepositoryMockRecorder struct {
+	mock *MockDataKeyRepository
+}
+
+// NewMockDataKeyRepository creates a new mock instance.
+func NewMockDataKeyRepository(ctrl *gomock.Controller) *MockDataKeyRepository {
+	mock := &MockDataKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockDataKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeyRepository) EXPECT() *MockDataKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDataKeyRepository) Create(ctx context.Context, k *datakey.DataKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, k)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDataKeyRepositoryMockRecorder) Create(ctx, k any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDataKeyRepository)(nil).Create), ctx, k)
+}
+
+// Get mocks base method.
+func (m *MockDataKeyRepository) Get(ctx context.Context, userID int) (*datakey.DataKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*datakey.DataKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDataKeyRepositoryMockRecorder) Get(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataKeyRepository)(nil).Get), ctx, userID)
+}
+
+// ListByProvider mocks base method.
+func (m *MockDataKeyRepository) ListByProvider(ctx context.Context, provider string) ([]*datakey.DataKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProvider", ctx, provider)
+	ret0, _ := ret[0].([]*datakey.DataKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProvider indicates an expected call of ListByProvider.
+func (mr *MockDataKeyRepositoryMockRecorder) ListByProvider(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProvider", reflect.TypeOf((*MockDataKeyRepository)(nil).ListByProvider), ctx, provider)
+}
+
+// Update mocks base method.
+func (m *MockDataKeyRepository) Update(ctx context.Context, k *datakey.DataKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, k)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDataKeyRepositoryMockRecorder) Update(ctx, k any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDataKeyRepository)(nil).Update), ctx, k)
+}
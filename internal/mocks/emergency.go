@@ -0,0 +1,344 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/emergency (interfaces: Repository,Accounts,Vaults,Sessions,SecondFactors)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/emergency.go -package=mocks -mock_names=Repository=MockEmergencyRepository,Accounts=MockEmergencyAccounts,Vaults=MockEmergencyVaults,Sessions=MockEmergencySessions,SecondFactors=MockEmergencySecondFactors . Repository,Accounts,Vaults,Sessions,SecondFactors
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	emergency "github.com/Melikhov-p/goph-keeper/internal/domain/emergency"
+	secret "github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	user "github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmergencyRepository is a mock of Repository interface.
+type MockEmergencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEmergencyRepositoryMockRecorder is the mock recorder for MockEmergencyRepository.
+type MockEmergencyRepositoryMockRecorder struct {
+	mock *MockEmergencyRepository
+}
+
+// NewMockEmergencyRepository creates a new mock instance.
+func NewMockEmergencyRepository(ctrl *gomock.Controller) *MockEmergencyRepository {
+	mock := &MockEmergencyRepository{ctrl: ctrl}
+	mock.recorder = &MockEmergencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencyRepository) EXPECT() *MockEmergencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockEmergencyRepository) AddEvent(ctx context.Context, ev *emergency.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", ctx, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockEmergencyRepositoryMockRecorder) AddEvent(ctx, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockEmergencyRepository)(nil).AddEvent), ctx, ev)
+}
+
+// Create mocks base method.
+func (m *MockEmergencyRepository) Create(ctx context.Context, c *emergency.Contact, ev *emergency.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmergencyRepositoryMockRecorder) Create(ctx, c, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmergencyRepository)(nil).Create), ctx, c, ev)
+}
+
+// Delete mocks base method.
+func (m *MockEmergencyRepository) Delete(ctx context.Context, c *emergency.Contact, ev *emergency.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, c, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEmergencyRepositoryMockRecorder) Delete(ctx, c, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEmergencyRepository)(nil).Delete), ctx, c, ev)
+}
+
+// Get mocks base method.
+func (m *MockEmergencyRepository) Get(ctx context.Context, id int) (*emergency.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*emergency.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockEmergencyRepositoryMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockEmergencyRepository)(nil).Get), ctx, id)
+}
+
+// ListByGrantee mocks base method.
+func (m *MockEmergencyRepository) ListByGrantee(ctx context.Context, granteeID int) ([]*emergency.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGrantee", ctx, granteeID)
+	ret0, _ := ret[0].([]*emergency.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGrantee indicates an expected call of ListByGrantee.
+func (mr *MockEmergencyRepositoryMockRecorder) ListByGrantee(ctx, granteeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGrantee", reflect.TypeOf((*MockEmergencyRepository)(nil).ListByGrantee), ctx, granteeID)
+}
+
+// ListByGrantor mocks base method.
+func (m *MockEmergencyRepository) ListByGrantor(ctx context.Context, grantorID int) ([]*emergency.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGrantor", ctx, grantorID)
+	ret0, _ := ret[0].([]*emergency.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGrantor indicates an expected call of ListByGrantor.
+func (mr *MockEmergencyRepositoryMockRecorder) ListByGrantor(ctx, grantorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGrantor", reflect.TypeOf((*MockEmergencyRepository)(nil).ListByGrantor), ctx, grantorID)
+}
+
+// ListEvents mocks base method.
+func (m *MockEmergencyRepository) ListEvents(ctx context.Context, userID int) ([]*emergency.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, userID)
+	ret0, _ := ret[0].([]*emergency.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockEmergencyRepositoryMockRecorder) ListEvents(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockEmergencyRepository)(nil).ListEvents), ctx, userID)
+}
+
+// Transition mocks base method.
+func (m *MockEmergencyRepository) Transition(ctx context.Context, c *emergency.Contact, from emergency.Status, ev *emergency.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, c, from, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockEmergencyRepositoryMockRecorder) Transition(ctx, c, from, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockEmergencyRepository)(nil).Transition), ctx, c, from, ev)
+}
+
+// MockEmergencyAccounts is a mock of Accounts interface.
+type MockEmergencyAccounts struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencyAccountsMockRecorder
+	isgomock struct{}
+}
+
+// MockEmergencyAccountsMockRecorder is the mock recorder for MockEmergencyAccounts.
+type MockEmergencyAccountsMockRecorder struct {
+	mock *MockEmergencyAccounts
+}
+
+// NewMockEmergencyAccounts creates a new mock instance.
+func NewMockEmergencyAccounts(ctrl *gomock.Controller) *MockEmergencyAccounts {
+	mock := &MockEmergencyAccounts{ctrl: ctrl}
+	mock.recorder = &MockEmergencyAccountsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencyAccounts) EXPECT() *MockEmergencyAccountsMockRecorder {
+	return m.recorder
+}
+
+// CheckResetPassword mocks base method.
+func (m *MockEmergencyAccounts) CheckResetPassword(ctx context.Context, userID int, upd user.CredentialsUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckResetPassword", ctx, userID, upd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckResetPassword indicates an expected call of CheckResetPassword.
+func (mr *MockEmergencyAccountsMockRecorder) CheckResetPassword(ctx, userID, upd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResetPassword", reflect.TypeOf((*MockEmergencyAccounts)(nil).CheckResetPassword), ctx, userID, upd)
+}
+
+// GetUserByID mocks base method.
+func (m *MockEmergencyAccounts) GetUserByID(ctx context.Context, userID int) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockEmergencyAccountsMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockEmergencyAccounts)(nil).GetUserByID), ctx, userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockEmergencyAccounts) ResetPassword(ctx context.Context, userID int, upd user.CredentialsUpdate) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, userID, upd)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockEmergencyAccountsMockRecorder) ResetPassword(ctx, userID, upd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockEmergencyAccounts)(nil).ResetPassword), ctx, userID, upd)
+}
+
+// MockEmergencyVaults is a mock of Vaults interface.
+type MockEmergencyVaults struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencyVaultsMockRecorder
+	isgomock struct{}
+}
+
+// MockEmergencyVaultsMockRecorder is the mock recorder for MockEmergencyVaults.
+type MockEmergencyVaultsMockRecorder struct {
+	mock *MockEmergencyVaults
+}
+
+// NewMockEmergencyVaults creates a new mock instance.
+func NewMockEmergencyVaults(ctrl *gomock.Controller) *MockEmergencyVaults {
+	mock := &MockEmergencyVaults{ctrl: ctrl}
+	mock.recorder = &MockEmergencyVaultsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencyVaults) EXPECT() *MockEmergencyVaultsMockRecorder {
+	return m.recorder
+}
+
+// ExportVault mocks base method.
+func (m *MockEmergencyVaults) ExportVault(ctx context.Context, owner, reader *user.User) ([]*secret.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportVault", ctx, owner, reader)
+	ret0, _ := ret[0].([]*secret.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportVault indicates an expected call of ExportVault.
+func (mr *MockEmergencyVaultsMockRecorder) ExportVault(ctx, owner, reader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportVault", reflect.TypeOf((*MockEmergencyVaults)(nil).ExportVault), ctx, owner, reader)
+}
+
+// MockEmergencySessions is a mock of Sessions interface.
+type MockEmergencySessions struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencySessionsMockRecorder
+	isgomock struct{}
+}
+
+// MockEmergencySessionsMockRecorder is the mock recorder for MockEmergencySessions.
+type MockEmergencySessionsMockRecorder struct {
+	mock *MockEmergencySessions
+}
+
+// NewMockEmergencySessions creates a new mock instance.
+func NewMockEmergencySessions(ctrl *gomock.Controller) *MockEmergencySessions {
+	mock := &MockEmergencySessions{ctrl: ctrl}
+	mock.recorder = &MockEmergencySessionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencySessions) EXPECT() *MockEmergencySessionsMockRecorder {
+	return m.recorder
+}
+
+// RevokeOthers mocks base method.
+func (m *MockEmergencySessions) RevokeOthers(ctx context.Context, userID int, keepID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOthers", ctx, userID, keepID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOthers indicates an expected call of RevokeOthers.
+func (mr *MockEmergencySessionsMockRecorder) RevokeOthers(ctx, userID, keepID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOthers", reflect.TypeOf((*MockEmergencySessions)(nil).RevokeOthers), ctx, userID, keepID)
+}
+
+// MockEmergencySecondFactors is a mock of SecondFactors interface.
+type MockEmergencySecondFactors struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencySecondFactorsMockRecorder
+	isgomock struct{}
+}
+
+// MockEmergencySecondFactorsMockRecorder is the mock recorder for MockEmergencySecondFactors.
+type MockEmergencySecondFactorsMockRecorder struct {
+	mock *MockEmergencySecondFactors
+}
+
+// NewMockEmergencySecondFactors creates a new mock instance.
+func NewMockEmergencySecondFactors(ctrl *gomock.Controller) *MockEmergencySecondFactors {
+	mock := &MockEmergencySecondFactors{ctrl: ctrl}
+	mock.recorder = &MockEmergencySecondFactorsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencySecondFactors) EXPECT() *MockEmergencySecondFactorsMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockEmergencySecondFactors) Disable(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockEmergencySecondFactorsMockRecorder) Disable(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockEmergencySecondFactors)(nil).Disable), ctx, userID)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/lockout (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/lockout.go -package=mocks -mock_names=Repository=MockLockoutRepository . Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	lockout "github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLockoutRepository is a mock of Repository interface.
+type MockLockoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLockoutRepositoryMockRecorder is the mock recorder for MockLockoutRepository.
+type MockLockoutRepositoryMockRecorder struct {
+	mock *MockLockoutRepository
+}
+
+// NewMockLockoutRepository creates a new mock instance.
+func NewMockLockoutRepository(ctrl *gomock.Controller) *MockLockoutRepository {
+	mock := &MockLockoutRepository{ctrl: ctrl}
+	mock.recorder = &MockLockoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepository) EXPECT() *MockLockoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockLockoutRepository) Block(ctx context.Context, key string, until time.Time, locked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, key, until, locked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockLockoutRepositoryMockRecorder) Block(ctx, key, until, locked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockLockoutRepository)(nil).Block), ctx, key, until, locked)
+}
+
+// Get mocks base method.
+func (m *MockLockoutRepository) Get(ctx context.Context, keys []string) ([]*lockout.Attempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, keys)
+	ret0, _ := ret[0].([]*lockout.Attempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLockoutRepositoryMockRecorder) Get(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLockoutRepository)(nil).Get), ctx, keys)
+}
+
+// ListBlocked mocks base method.
+func (m *MockLockoutRepository) ListBlocked(ctx context.Context, now time.Time) ([]*lockout.Attempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBlocked", ctx, now)
+	ret0, _ := ret[0].([]*lockout.Attempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocked indicates an expected call of ListBlocked.
+func (mr *MockLockoutRepositoryMockRecorder) ListBlocked(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocked", reflect.TypeOf((*MockLockoutRepository)(nil).ListBlocked), ctx, now)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLockoutRepository) RecordFailure(ctx context.Context, key string, now, staleBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, key, now, staleBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLockoutRepositoryMockRecorder) RecordFailure(ctx, key, now, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLockoutRepository)(nil).RecordFailure), ctx, key, now, staleBefore)
+}
+
+// Reset mocks base method.
+func (m *MockLockoutRepository) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLockoutRepositoryMockRecorder) Reset(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLockoutRepository)(nil).Reset), ctx, key)
+}
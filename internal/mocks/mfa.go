@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/mfa (interfaces: Repository,DataKeys)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/mfa.go -package=mocks -mock_names=Repository=MockMFARepository,DataKeys=MockMFADataKeys . Repository,DataKeys
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	mfa "github.com/Melikhov-p/goph-keeper/internal/domain/mfa"
+	securemem "github.com/Melikhov-p/goph-keeper/internal/securemem"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFARepository is a mock of Repository interface.
+type MockMFARepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFARepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMFARepositoryMockRecorder is the mock recorder for MockMFARepository.
+type MockMFARepositoryMockRecorder struct {
+	mock *MockMFARepository
+}
+
+// NewMockMFARepository creates a new mock instance.
+func NewMockMFARepository(ctrl *gomock.Controller) *MockMFARepository {
+	mock := &MockMFARepository{ctrl: ctrl}
+	mock.recorder = &MockMFARepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFARepository) EXPECT() *MockMFARepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockMFARepository) Confirm(ctx context.Context, userID int, step int64, at time.Time, recoveryHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, userID, step, at, recoveryHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockMFARepositoryMockRecorder) Confirm(ctx, userID, step, at, recoveryHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockMFARepository)(nil).Confirm), ctx, userID, step, at, recoveryHashes)
+}
+
+// Delete mocks base method.
+func (m *MockMFARepository) Delete(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMFARepositoryMockRecorder) Delete(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMFARepository)(nil).Delete), ctx, userID)
+}
+
+// GetTOTP mocks base method.
+func (m *MockMFARepository) GetTOTP(ctx context.Context, userID int) (*mfa.TOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", ctx, userID)
+	ret0, _ := ret[0].(*mfa.TOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MockMFARepositoryMockRecorder) GetTOTP(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MockMFARepository)(nil).GetTOTP), ctx, userID)
+}
+
+// SaveTOTP mocks base method.
+func (m *MockMFARepository) SaveTOTP(ctx context.Context, t *mfa.TOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTP", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTP indicates an expected call of SaveTOTP.
+func (mr *MockMFARepositoryMockRecorder) SaveTOTP(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTP", reflect.TypeOf((*MockMFARepository)(nil).SaveTOTP), ctx, t)
+}
+
+// UseChallenge mocks base method.
+func (m *MockMFARepository) UseChallenge(ctx context.Context, challengeID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseChallenge", ctx, challengeID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseChallenge indicates an expected call of UseChallenge.
+func (mr *MockMFARepositoryMockRecorder) UseChallenge(ctx, challengeID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseChallenge", reflect.TypeOf((*MockMFARepository)(nil).UseChallenge), ctx, challengeID, expiresAt)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID int, hash string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, hash, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFARepositoryMockRecorder) UseRecoveryCode(ctx, userID, hash, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFARepository)(nil).UseRecoveryCode), ctx, userID, hash, at)
+}
+
+// UseStep mocks base method.
+func (m *MockMFARepository) UseStep(ctx context.Context, userID int, lastStep, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", ctx, userID, lastStep, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockMFARepositoryMockRecorder) UseStep(ctx, userID, lastStep, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockMFARepository)(nil).UseStep), ctx, userID, lastStep, step)
+}
+
+// MockMFADataKeys is a mock of DataKeys interface.
+type MockMFADataKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFADataKeysMockRecorder
+	isgomock struct{}
+}
+
+// MockMFADataKeysMockRecorder is the mock recorder for MockMFADataKeys.
+type MockMFADataKeysMockRecorder struct {
+	mock *MockMFADataKeys
+}
+
+// NewMockMFADataKeys creates a new mock instance.
+func NewMockMFADataKeys(ctrl *gomock.Controller) *MockMFADataKeys {
+	mock := &MockMFADataKeys{ctrl: ctrl}
+	mock.recorder = &MockMFADataKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFADataKeys) EXPECT() *MockMFADataKeysMockRecorder {
+	return m.recorder
+}
+
+// UserKey mocks base method.
+func (m *MockMFADataKeys) UserKey(ctx context.Context, userID int) (*securemem.Buffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserKey", ctx, userID)
+	ret0, _ := ret[0].(*securemem.Buffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserKey indicates an expected call of UserKey.
+func (mr *MockMFADataKeysMockRecorder) UserKey(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserKey", reflect.TypeOf((*MockMFADataKeys)(nil).UserKey), ctx, userID)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/onetime (interfaces: Repository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/onetime.go -package=mocks -mock_names=Repository=MockOneTimeRepository . Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	onetime "github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOneTimeRepository is a mock of Repository interface.
+type MockOneTimeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOneTimeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOneTimeRepositoryMockRecorder is the mock recorder for MockOneTimeRepository.
+type MockOneTimeRepositoryMockRecorder struct {
+	mock *MockOneTimeRepository
+}
+
+// NewMockOneTimeRepository creates a new mock instance.
+func NewMockOneTimeRepository(ctrl *gomock.Controller) *MockOneTimeRepository {
+	mock := &MockOneTimeRepository{ctrl: ctrl}
+	mock.recorder = &MockOneTimeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOneTimeRepository) EXPECT() *MockOneTimeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOneTimeRepository) Consume(ctx context.Context, id string, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, id, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOneTimeRepositoryMockRecorder) Consume(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOneTimeRepository)(nil).Consume), ctx, id, now)
+}
+
+// Create mocks base method.
+func (m *MockOneTimeRepository) Create(ctx context.Context, sh *onetime.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, sh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOneTimeRepositoryMockRecorder) Create(ctx, sh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOneTimeRepository)(nil).Create), ctx, sh)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockOneTimeRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockOneTimeRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockOneTimeRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// GetActive mocks base method.
+func (m *MockOneTimeRepository) GetActive(ctx context.Context, id string, now time.Time) (*onetime.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, id, now)
+	ret0, _ := ret[0].(*onetime.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockOneTimeRepositoryMockRecorder) GetActive(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockOneTimeRepository)(nil).GetActive), ctx, id, now)
+}
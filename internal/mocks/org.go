@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/org (interfaces: Repository,DataKeys)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/org.go -package=mocks -mock_names=Repository=MockOrgRepository,DataKeys=MockOrgDataKeys . Repository,DataKeys
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	org "github.com/Melikhov-p/goph-keeper/internal/domain/org"
+	secret "github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	securemem "github.com/Melikhov-p/goph-keeper/internal/securemem"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrgRepository is a mock of Repository interface.
+type MockOrgRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrgRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrgRepositoryMockRecorder is the mock recorder for MockOrgRepository.
+type MockOrgRepositoryMockRecorder struct {
+	mock *MockOrgRepository
+}
+
+// NewMockOrgRepository creates a new mock instance.
+func NewMockOrgRepository(ctrl *gomock.Controller) *MockOrgRepository {
+	mock := &MockOrgRepository{ctrl: ctrl}
+	mock.recorder = &MockOrgRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrgRepository) EXPECT() *MockOrgRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m_2 *MockOrgRepository) AddMember(ctx context.Context, m *org.Member, keys []*org.CollectionKey) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "AddMember", ctx, m, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrgRepositoryMockRecorder) AddMember(ctx, m, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrgRepository)(nil).AddMember), ctx, m, keys)
+}
+
+// CreateCollection mocks base method.
+func (m *MockOrgRepository) CreateCollection(ctx context.Context, c *org.Collection, keys []*org.CollectionKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", ctx, c, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockOrgRepositoryMockRecorder) CreateCollection(ctx, c, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockOrgRepository)(nil).CreateCollection), ctx, c, keys)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrgRepository) CreateOrganization(ctx context.Context, o *org.Organization, ownerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, o, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrgRepositoryMockRecorder) CreateOrganization(ctx, o, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrgRepository)(nil).CreateOrganization), ctx, o, ownerID)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockOrgRepository) DeleteOrganization(ctx context.Context, orgID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", ctx, orgID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockOrgRepositoryMockRecorder) DeleteOrganization(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockOrgRepository)(nil).DeleteOrganization), ctx, orgID)
+}
+
+// GetAccess mocks base method.
+func (m *MockOrgRepository) GetAccess(ctx context.Context, collectionID, userID int) (*org.Access, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccess", ctx, collectionID, userID)
+	ret0, _ := ret[0].(*org.Access)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccess indicates an expected call of GetAccess.
+func (mr *MockOrgRepositoryMockRecorder) GetAccess(ctx, collectionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccess", reflect.TypeOf((*MockOrgRepository)(nil).GetAccess), ctx, collectionID, userID)
+}
+
+// GetCollectionSecrets mocks base method.
+func (m *MockOrgRepository) GetCollectionSecrets(ctx context.Context, collectionID int) ([]*secret.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionSecrets", ctx, collectionID)
+	ret0, _ := ret[0].([]*secret.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionSecrets indicates an expected call of GetCollectionSecrets.
+func (mr *MockOrgRepositoryMockRecorder) GetCollectionSecrets(ctx, collectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionSecrets", reflect.TypeOf((*MockOrgRepository)(nil).GetCollectionSecrets), ctx, collectionID)
+}
+
+// GetMember mocks base method.
+func (m *MockOrgRepository) GetMember(ctx context.Context, orgID, userID int) (*org.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, orgID, userID)
+	ret0, _ := ret[0].(*org.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockOrgRepositoryMockRecorder) GetMember(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockOrgRepository)(nil).GetMember), ctx, orgID, userID)
+}
+
+// ListCollections mocks base method.
+func (m *MockOrgRepository) ListCollections(ctx context.Context, orgID int) ([]*org.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollections", ctx, orgID)
+	ret0, _ := ret[0].([]*org.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollections indicates an expected call of ListCollections.
+func (mr *MockOrgRepositoryMockRecorder) ListCollections(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollections", reflect.TypeOf((*MockOrgRepository)(nil).ListCollections), ctx, orgID)
+}
+
+// ListMembers mocks base method.
+func (m *MockOrgRepository) ListMembers(ctx context.Context, orgID int) ([]*org.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, orgID)
+	ret0, _ := ret[0].([]*org.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockOrgRepositoryMockRecorder) ListMembers(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockOrgRepository)(nil).ListMembers), ctx, orgID)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockOrgRepository) ListOrganizations(ctx context.Context, userID int) ([]*org.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", ctx, userID)
+	ret0, _ := ret[0].([]*org.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockOrgRepositoryMockRecorder) ListOrganizations(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockOrgRepository)(nil).ListOrganizations), ctx, userID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrgRepository) RemoveMember(ctx context.Context, orgID, userID int, rotations []*org.KeyRotation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, orgID, userID, rotations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrgRepositoryMockRecorder) RemoveMember(ctx, orgID, userID, rotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrgRepository)(nil).RemoveMember), ctx, orgID, userID, rotations)
+}
+
+// SetRole mocks base method.
+func (m *MockOrgRepository) SetRole(ctx context.Context, orgID, userID int, role org.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, orgID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockOrgRepositoryMockRecorder) SetRole(ctx, orgID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockOrgRepository)(nil).SetRole), ctx, orgID, userID, role)
+}
+
+// MockOrgDataKeys is a mock of DataKeys interface.
+type MockOrgDataKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrgDataKeysMockRecorder
+	isgomock struct{}
+}
+
+// MockOrgDataKeysMockRecorder is the mock recorder for MockOrgDataKeys.
+type MockOrgDataKeysMockRecorder struct {
+	mock *MockOrgDataKeys
+}
+
+// NewMockOrgDataKeys creates a new mock instance.
+func NewMockOrgDataKeys(ctrl *gomock.Controller) *MockOrgDataKeys {
+	mock := &MockOrgDataKeys{ctrl: ctrl}
+	mock.recorder = &MockOrgDataKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrgDataKeys) EXPECT() *MockOrgDataKeysMockRecorder {
+	return m.recorder
+}
+
+// UserKey mocks base method.
+func (m *MockOrgDataKeys) UserKey(ctx context.Context, userID int) (*securemem.Buffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserKey", ctx, userID)
+	ret0, _ := ret[0].(*securemem.Buffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserKey indicates an expected call of UserKey.
+func (mr *MockOrgDataKeysMockRecorder) UserKey(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserKey", reflect.TypeOf((*MockOrgDataKeys)(nil).UserKey), ctx, userID)
+}
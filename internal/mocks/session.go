@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Melikhov-p/goph-keeper/internal/domain/session (interfaces: Repository,Users)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/session.go -package=mocks -mock_names=Repository=MockSessionRepository,Users=MockSessionUsers . Repository,Users
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	session "github.com/Melikhov-p/goph-keeper/internal/domain/session"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionRepository is a mock of Repository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessionRepository) Create(ctx context.Context, s *session.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionRepositoryMockRecorder) Create(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRepository)(nil).Create), ctx, s)
+}
+
+// GetByID mocks base method.
+func (m *MockSessionRepository) GetByID(ctx context.Context, id string) (*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSessionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSessionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListActive mocks base method.
+func (m *MockSessionRepository) ListActive(ctx context.Context, userID int, now time.Time) ([]*session.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, userID, now)
+	ret0, _ := ret[0].([]*session.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockSessionRepositoryMockRecorder) ListActive(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockSessionRepository)(nil).ListActive), ctx, userID, now)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionRepository) Revoke(ctx context.Context, userID int, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionRepositoryMockRecorder) Revoke(ctx, userID, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionRepository)(nil).Revoke), ctx, userID, id, at)
+}
+
+// RevokeAllExcept mocks base method.
+func (m *MockSessionRepository) RevokeAllExcept(ctx context.Context, userID int, keepID string, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllExcept", ctx, userID, keepID, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllExcept indicates an expected call of RevokeAllExcept.
+func (mr *MockSessionRepositoryMockRecorder) RevokeAllExcept(ctx, userID, keepID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllExcept", reflect.TypeOf((*MockSessionRepository)(nil).RevokeAllExcept), ctx, userID, keepID, at)
+}
+
+// Rotate mocks base method.
+func (m *MockSessionRepository) Rotate(ctx context.Context, s *session.Session, oldHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, s, oldHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionRepositoryMockRecorder) Rotate(ctx, s, oldHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionRepository)(nil).Rotate), ctx, s, oldHash)
+}
+
+// MockSessionUsers is a mock of Users interface.
+type MockSessionUsers struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionUsersMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionUsersMockRecorder is the mock recorder for MockSessionUsers.
+type MockSessionUsersMockRecorder struct {
+	mock *MockSessionUsers
+}
+
+// NewMockSessionUsers creates a new mock instance.
+func NewMockSessionUsers(ctrl *gomock.Controller) *MockSessionUsers {
+	mock := &MockSessionUsers{ctrl: ctrl}
+	mock.recorder = &MockSessionUsersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionUsers) EXPECT() *MockSessionUsersMockRecorder {
+	return m.recorder
+}
+
+// CheckActive mocks base method.
+func (m *MockSessionUsers) CheckActive(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckActive", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckActive indicates an expected call of CheckActive.
+func (mr *MockSessionUsersMockRecorder) CheckActive(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckActive", reflect.TypeOf((*MockSessionUsers)(nil).CheckActive), ctx, userID)
+}
@@ -0,0 +1,252 @@
+// Package passgen пакет генерации случайных паролей и парольных фраз (diceware).
+// Все случайные значения берутся из crypto/rand.
+package passgen
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed" // Встроенный словарь EFF для парольных фраз.
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ограничения и значения по умолчанию.
+const (
+	DefaultLength = 20
+	MinLength     = 4
+	MaxLength     = 128
+
+	DefaultWords     = 6
+	MinWords         = 3
+	MaxWords         = 20
+	DefaultSeparator = "-"
+
+	digitsCount = 10
+)
+
+// Наборы символов.
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!@#$%^&*()-_=+[]{};:,.<>?/~"
+	// ambiguousChars символы, которые легко перепутать при чтении и ручном вводе.
+	ambiguousChars = "Il1O0o|`'\""
+)
+
+// ErrInvalidRules недопустимые правила генерации.
+var ErrInvalidRules = errors.New("invalid generation rules")
+
+//go:embed eff_large_wordlist.txt
+var wordlistRaw string
+
+// wordlist слова словаря EFF (7776 слов, 12.9 бит на слово).
+var wordlist = loadWordlist(wordlistRaw)
+
+// Rules правила генерации пароля. Нулевые значения заменяются значениями по умолчанию:
+// длина DefaultLength и строчные, заглавные буквы и цифры, если не выбран ни один набор.
+type Rules struct {
+	Length    int
+	Lowercase bool
+	Uppercase bool
+	Digits    bool
+	Symbols   bool
+	// ExcludeAmbiguous исключить похожие символы (I, l, 1, O, 0 и т.п.).
+	ExcludeAmbiguous bool
+	// RequireEachClass в пароле есть хотя бы один символ каждого выбранного набора.
+	RequireEachClass bool
+}
+
+// PassphraseRules правила генерации парольной фразы. Нулевые значения заменяются значениями по умолчанию.
+type PassphraseRules struct {
+	Words     int
+	Separator string
+	// Capitalize первая буква каждого слова заглавная.
+	Capitalize bool
+	// IncludeNumber добавить цифру в конец случайного слова.
+	IncludeNumber bool
+}
+
+// Password генерация пароля по правилам. Возвращает пароль и его энтропию в битах.
+func Password(r Rules) (string, float64, error) {
+	op := "passgen.Password"
+
+	r = r.withDefaults()
+	if r.Length < MinLength || r.Length > MaxLength {
+		return "", 0, fmt.Errorf("%s: %w: length must be between %d and %d", op, ErrInvalidRules, MinLength, MaxLength)
+	}
+
+	classes := r.classes()
+	if r.RequireEachClass && len(classes) > r.Length {
+		return "", 0, fmt.Errorf("%s: %w: length is less than number of required classes", op, ErrInvalidRules)
+	}
+	alphabet := []rune(strings.Join(classes, ""))
+
+	password := make([]rune, 0, r.Length)
+	if r.RequireEachClass {
+		for _, class := range classes {
+			c, err := pick([]rune(class))
+			if err != nil {
+				return "", 0, fmt.Errorf("%s: %w", op, err)
+			}
+			password = append(password, c)
+		}
+	}
+	for len(password) < r.Length {
+		c, err := pick(alphabet)
+		if err != nil {
+			return "", 0, fmt.Errorf("%s: %w", op, err)
+		}
+		password = append(password, c)
+	}
+
+	// Обязательные символы не должны стоять на предсказуемых позициях
+	if err := shuffle(password); err != nil {
+		return "", 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return string(password), float64(r.Length) * math.Log2(float64(len(alphabet))), nil
+}
+
+// Passphrase генерация парольной фразы из слов словаря EFF. Возвращает фразу и ее энтропию в битах.
+func Passphrase(r PassphraseRules) (string, float64, error) {
+	op := "passgen.Passphrase"
+
+	r = r.withDefaults()
+	if r.Words < MinWords || r.Words > MaxWords {
+		return "", 0, fmt.Errorf("%s: %w: words count must be between %d and %d", op, ErrInvalidRules, MinWords, MaxWords)
+	}
+
+	words := make([]string, r.Words)
+	for i := range words {
+		n, err := randInt(len(wordlist))
+		if err != nil {
+			return "", 0, fmt.Errorf("%s: %w", op, err)
+		}
+		words[i] = wordlist[n]
+		if r.Capitalize {
+			words[i] = capitalize(words[i])
+		}
+	}
+
+	entropy := float64(r.Words) * math.Log2(float64(len(wordlist)))
+	if r.IncludeNumber {
+		i, err := randInt(len(words))
+		if err != nil {
+			return "", 0, fmt.Errorf("%s: %w", op, err)
+		}
+		d, err := randInt(digitsCount)
+		if err != nil {
+			return "", 0, fmt.Errorf("%s: %w", op, err)
+		}
+		words[i] += digitChars[d : d+1]
+		entropy += math.Log2(float64(digitsCount * len(words)))
+	}
+
+	return strings.Join(words, r.Separator), entropy, nil
+}
+
+func (r Rules) withDefaults() Rules {
+	if r.Length == 0 {
+		r.Length = DefaultLength
+	}
+	if !r.Lowercase && !r.Uppercase && !r.Digits && !r.Symbols {
+		r.Lowercase, r.Uppercase, r.Digits = true, true, true
+	}
+
+	return r
+}
+
+// classes выбранные наборы символов без похожих символов, если они исключены.
+func (r Rules) classes() []string {
+	var classes []string
+	add := func(enabled bool, chars string) {
+		if !enabled {
+			return
+		}
+		if r.ExcludeAmbiguous {
+			chars = strings.Map(func(c rune) rune {
+				if strings.ContainsRune(ambiguousChars, c) {
+					return -1
+				}
+				return c
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+
+	add(r.Lowercase, lowerChars)
+	add(r.Uppercase, upperChars)
+	add(r.Digits, digitChars)
+	add(r.Symbols, symbolChars)
+
+	return classes
+}
+
+func (r PassphraseRules) withDefaults() PassphraseRules {
+	if r.Words == 0 {
+		r.Words = DefaultWords
+	}
+	if r.Separator == "" {
+		r.Separator = DefaultSeparator
+	}
+
+	return r
+}
+
+// randInt равномерно распределенное случайное число в [0, n).
+func randInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("error reading random %w", err)
+	}
+
+	return int(v.Int64()), nil
+}
+
+func pick(chars []rune) (rune, error) {
+	i, err := randInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[i], nil
+}
+
+// shuffle перемешивание Фишера-Йетса.
+func shuffle(s []rune) error {
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := randInt(i + 1)
+		if err != nil {
+			return err
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return nil
+}
+
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+func loadWordlist(raw string) []string {
+	var words []string
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+
+	return words
+}
@@ -0,0 +1,124 @@
+package passgen_test
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/Melikhov-p/goph-keeper/internal/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   passgen.Rules
+		wantLen int
+		allowed func(r rune) bool
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			rules:   passgen.Rules{},
+			wantLen: passgen.DefaultLength,
+			allowed: func(r rune) bool { return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) },
+		},
+		{
+			name:    "digits only",
+			rules:   passgen.Rules{Length: 8, Digits: true},
+			wantLen: 8,
+			allowed: unicode.IsDigit,
+		},
+		{
+			name:    "exclude ambiguous",
+			rules:   passgen.Rules{Length: 64, Lowercase: true, Uppercase: true, Digits: true, ExcludeAmbiguous: true},
+			wantLen: 64,
+			allowed: func(r rune) bool { return !strings.ContainsRune("Il1O0o", r) },
+		},
+		{
+			name:    "too short",
+			rules:   passgen.Rules{Length: passgen.MinLength - 1},
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			rules:   passgen.Rules{Length: passgen.MaxLength + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, entropy, err := passgen.Password(tt.rules)
+			if tt.wantErr {
+				require.ErrorIs(t, err, passgen.ErrInvalidRules)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, []rune(password), tt.wantLen)
+			assert.Positive(t, entropy)
+			for _, r := range password {
+				assert.True(t, tt.allowed(r), "unexpected character %q", r)
+			}
+		})
+	}
+}
+
+func TestPasswordRequireEachClass(t *testing.T) {
+	rules := passgen.Rules{Length: 4, Lowercase: true, Uppercase: true, Digits: true, Symbols: true, RequireEachClass: true}
+
+	for range 100 {
+		password, _, err := passgen.Password(rules)
+		require.NoError(t, err)
+
+		assert.True(t, strings.ContainsFunc(password, unicode.IsLower), password)
+		assert.True(t, strings.ContainsFunc(password, unicode.IsUpper), password)
+		assert.True(t, strings.ContainsFunc(password, unicode.IsDigit), password)
+		assert.True(t, strings.ContainsFunc(password, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}), password)
+	}
+
+	rules.Length = 3
+	_, _, err := passgen.Password(rules)
+	require.ErrorIs(t, err, passgen.ErrInvalidRules)
+}
+
+func TestPasswordUnique(t *testing.T) {
+	seen := make(map[string]struct{})
+	for range 100 {
+		password, _, err := passgen.Password(passgen.Rules{})
+		require.NoError(t, err)
+
+		_, dup := seen[password]
+		require.False(t, dup, "duplicate password %q", password)
+		seen[password] = struct{}{}
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	phrase, entropy, err := passgen.Passphrase(passgen.PassphraseRules{})
+	require.NoError(t, err)
+
+	words := strings.Split(phrase, passgen.DefaultSeparator)
+	assert.Len(t, words, passgen.DefaultWords)
+	// log2(7776) ≈ 12.9 бит на слово
+	assert.InDelta(t, 77.5, entropy, 0.1)
+
+	phrase, _, err = passgen.Passphrase(passgen.PassphraseRules{
+		Words: 4, Separator: " ", Capitalize: true, IncludeNumber: true,
+	})
+	require.NoError(t, err)
+
+	words = strings.Split(phrase, " ")
+	require.Len(t, words, 4)
+	for _, w := range words {
+		assert.True(t, unicode.IsUpper([]rune(w)[0]), w)
+	}
+	assert.True(t, strings.ContainsFunc(phrase, unicode.IsDigit), phrase)
+
+	_, _, err = passgen.Passphrase(passgen.PassphraseRules{Words: passgen.MaxWords + 1})
+	require.ErrorIs(t, err, passgen.ErrInvalidRules)
+}
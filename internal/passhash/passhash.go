@@ -0,0 +1,284 @@
+// Package passhash пакет хэширования паролей аккаунтов с несколькими схемами и перцами.
+// Схема и ID перца хранятся вместе с хэшем, поэтому хэши старых схем продолжают проверяться,
+// а NeedsRehash подсказывает, когда хэш стоит пересчитать по текущей политике (обычно при успешном входе).
+//
+// Схемы:
+//   - bcrypt: исходная схема bcrypt(password+pepper), пароль с перцем ограничен 72 байтами;
+//   - bcrypt-sha256: bcrypt от base64(HMAC-SHA256(pepper, password)), длина пароля не ограничена;
+//   - argon2id: Argon2id от HMAC-SHA256(pepper, password) в формате PHC $argon2id$v=19$m=..,t=..,p=..$salt$hash.
+package passhash
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Scheme идентификатор схемы хэширования.
+type Scheme string
+
+const (
+	// SchemeBcrypt исходная схема bcrypt(password+pepper).
+	SchemeBcrypt Scheme = "bcrypt"
+	// SchemeBcryptSHA256 bcrypt от HMAC-SHA256 пароля с перцем.
+	SchemeBcryptSHA256 Scheme = "bcrypt-sha256"
+	// SchemeArgon2id Argon2id от HMAC-SHA256 пароля с перцем.
+	SchemeArgon2id Scheme = "argon2id"
+)
+
+const (
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+	argon2Parts   = 6
+
+	dummyPassword = "goph-keeper-dummy-password"
+)
+
+var (
+	// ErrUnknownScheme неизвестная схема хэширования.
+	ErrUnknownScheme = errors.New("unknown password hash scheme")
+	// ErrUnknownPepper перец с таким ID не настроен.
+	ErrUnknownPepper = errors.New("unknown pepper id")
+	// ErrInvalidHash хэш не соответствует формату своей схемы.
+	ErrInvalidHash = errors.New("invalid password hash")
+	// ErrInvalidParams недопустимые параметры хэширования.
+	ErrInvalidParams = errors.New("invalid password hashing params")
+)
+
+// Hash хэш пароля вместе со схемой и ID перца, которыми он получен.
+type Hash struct {
+	Value    string
+	Scheme   Scheme
+	PepperID string
+}
+
+// Argon2Params параметры Argon2id. Memory в КиБ.
+type Argon2Params struct {
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+}
+
+// Params текущая политика хэширования новых паролей.
+type Params struct {
+	Scheme     Scheme
+	BcryptCost int
+	Argon2     Argon2Params
+	// PepperID перец для новых хэшей.
+	PepperID string
+}
+
+// Hasher хэширование и проверка паролей. Безопасен для конкурентного использования.
+type Hasher struct {
+	params  Params
+	peppers map[string][]byte
+
+	dummyOnce sync.Once
+	dummy     Hash
+}
+
+// New хэшер с текущей политикой params и перцами по ID. Перцы, которые больше не используются
+// для новых хэшей, нужно оставить, пока остаются хэши с ними (см. отчет по схемам).
+func New(params Params, peppers map[string]string) (*Hasher, error) {
+	op := "passhash.New"
+
+	h := Hasher{
+		params:  params,
+		peppers: make(map[string][]byte, len(peppers)),
+	}
+	for id, p := range peppers {
+		h.peppers[id] = []byte(p)
+	}
+
+	if _, ok := h.peppers[params.PepperID]; !ok {
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnknownPepper, params.PepperID)
+	}
+
+	switch params.Scheme {
+	case SchemeBcrypt, SchemeBcryptSHA256:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("%s: %w: bcrypt cost must be between %d and %d",
+				op, ErrInvalidParams, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case SchemeArgon2id:
+		if params.Argon2.Memory == 0 || params.Argon2.Time == 0 || params.Argon2.Threads == 0 {
+			return nil, fmt.Errorf("%s: %w: argon2 memory, time and threads must be positive", op, ErrInvalidParams)
+		}
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnknownScheme, params.Scheme)
+	}
+
+	return &h, nil
+}
+
+// Params текущая политика хэширования.
+func (h *Hasher) Params() Params {
+	return h.params
+}
+
+// Hash хэш пароля по текущей политике.
+func (h *Hasher) Hash(password string) (Hash, error) {
+	op := "passhash.Hasher.Hash"
+
+	pepper := h.peppers[h.params.PepperID]
+
+	var (
+		value string
+		err   error
+	)
+	switch h.params.Scheme {
+	case SchemeBcrypt:
+		value, err = hashBcrypt([]byte(password+string(pepper)), h.params.BcryptCost)
+	case SchemeBcryptSHA256:
+		value, err = hashBcrypt(prehash(password, pepper), h.params.BcryptCost)
+	case SchemeArgon2id:
+		value, err = hashArgon2id(prehash(password, pepper), h.params.Argon2)
+	default:
+		err = ErrUnknownScheme
+	}
+	if err != nil {
+		return Hash{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Hash{Value: value, Scheme: h.params.Scheme, PepperID: h.params.PepperID}, nil
+}
+
+// Verify проверка пароля по хэшу любой поддерживаемой схемы. Ошибка означает, что хэш нельзя проверить
+// при текущих настройках (неизвестная схема или перец), а не неверный пароль.
+func (h *Hasher) Verify(stored Hash, password string) (bool, error) {
+	op := "passhash.Hasher.Verify"
+
+	pepper, ok := h.peppers[stored.PepperID]
+	if !ok {
+		return false, fmt.Errorf("%s: %w: %q", op, ErrUnknownPepper, stored.PepperID)
+	}
+
+	switch stored.Scheme {
+	case SchemeBcrypt:
+		return bcrypt.CompareHashAndPassword([]byte(stored.Value), []byte(password+string(pepper))) == nil, nil
+	case SchemeBcryptSHA256:
+		return bcrypt.CompareHashAndPassword([]byte(stored.Value), prehash(password, pepper)) == nil, nil
+	case SchemeArgon2id:
+		ok, err := verifyArgon2id(stored.Value, prehash(password, pepper))
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", op, err)
+		}
+		return ok, nil
+	default:
+		return false, fmt.Errorf("%s: %w: %q", op, ErrUnknownScheme, stored.Scheme)
+	}
+}
+
+// NeedsRehash получен ли хэш не по текущей политике: другой схемой, перцем или параметрами.
+func (h *Hasher) NeedsRehash(stored Hash) bool {
+	if stored.Scheme != h.params.Scheme || stored.PepperID != h.params.PepperID {
+		return true
+	}
+
+	switch stored.Scheme {
+	case SchemeBcrypt, SchemeBcryptSHA256:
+		cost, err := bcrypt.Cost([]byte(stored.Value))
+		return err != nil || cost != h.params.BcryptCost
+	case SchemeArgon2id:
+		params, _, _, err := parseArgon2id(stored.Value)
+		return err != nil || params != h.params.Argon2
+	default:
+		return true
+	}
+}
+
+// IsCurrent соответствуют ли схема и перец текущей политике (без учета параметров).
+func (h *Hasher) IsCurrent(scheme Scheme, pepperID string) bool {
+	return scheme == h.params.Scheme && pepperID == h.params.PepperID
+}
+
+// VerifyDummy проверка пароля по фиктивному хэшу текущей политики, чтобы время ответа для неизвестного
+// пользователя не отличалось от времени проверки настоящего пароля. Хэш считается один раз.
+func (h *Hasher) VerifyDummy(password string) {
+	h.dummyOnce.Do(func() {
+		h.dummy, _ = h.Hash(dummyPassword)
+	})
+
+	_, _ = h.Verify(h.dummy, password)
+}
+
+// prehash HMAC-SHA256 пароля с перцем в base64: фиксированная длина снимает ограничение bcrypt в 72 байта,
+// а перец не хранится рядом с хэшем.
+func prehash(password string, pepper []byte) []byte {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write([]byte(password))
+
+	return []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+func hashBcrypt(password []byte, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword(password, cost)
+	if err != nil {
+		return "", fmt.Errorf("error hashing password %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func hashArgon2id(password []byte, p Argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error generating salt %w", err)
+	}
+
+	key := argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, argon2KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func verifyArgon2id(encoded string, password []byte) (bool, error) {
+	p, salt, key, err := parseArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	//nolint:gosec // длина ключа из хэша, записанного этим же пакетом
+	actual := argon2.IDKey(password, salt, p.Time, p.Memory, p.Threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+func parseArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != argon2Parts || parts[1] != string(SchemeArgon2id) {
+		return p, nil, nil, fmt.Errorf("%w: unexpected argon2id format", ErrInvalidHash)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("%w: unsupported argon2 version", ErrInvalidHash)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, fmt.Errorf("%w: invalid argon2 params %w", ErrInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("%w: invalid salt %w", ErrInvalidHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, fmt.Errorf("%w: invalid key", ErrInvalidHash)
+	}
+
+	return p, salt, key, nil
+}
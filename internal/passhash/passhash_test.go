@@ -0,0 +1,128 @@
+package passhash_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	peppers = map[string]string{"": "legacy-pepper", "2025": "new-pepper"}
+	argon2  = passhash.Argon2Params{Memory: 1024, Time: 1, Threads: 1}
+)
+
+func newHasher(t *testing.T, params passhash.Params) *passhash.Hasher {
+	t.Helper()
+
+	h, err := passhash.New(params, peppers)
+	require.NoError(t, err)
+
+	return h
+}
+
+func TestHasher(t *testing.T) {
+	long := strings.Repeat("a", 80)
+
+	tests := []struct {
+		name   string
+		params passhash.Params
+		prefix string
+		// limited пароль длиннее 72 байт не поддерживается
+		limited bool
+	}{
+		{
+			name:    "legacy bcrypt",
+			params:  passhash.Params{Scheme: passhash.SchemeBcrypt, BcryptCost: bcrypt.MinCost},
+			prefix:  "$2a$",
+			limited: true,
+		},
+		{
+			name:   "bcrypt sha256",
+			params: passhash.Params{Scheme: passhash.SchemeBcryptSHA256, BcryptCost: bcrypt.MinCost, PepperID: "2025"},
+			prefix: "$2a$",
+		},
+		{
+			name:   "argon2id",
+			params: passhash.Params{Scheme: passhash.SchemeArgon2id, Argon2: argon2, PepperID: "2025"},
+			prefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHasher(t, tt.params)
+
+			hash, err := h.Hash("password")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash.Value, tt.prefix), hash.Value)
+			assert.Equal(t, tt.params.Scheme, hash.Scheme)
+			assert.Equal(t, tt.params.PepperID, hash.PepperID)
+			assert.False(t, h.NeedsRehash(hash))
+
+			ok, err := h.Verify(hash, "password")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = h.Verify(hash, "wrong")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			hash, err = h.Hash(long)
+			if tt.limited {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			ok, err = h.Verify(hash, long+"b")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestHasherRehash(t *testing.T) {
+	legacy := newHasher(t, passhash.Params{Scheme: passhash.SchemeBcrypt, BcryptCost: bcrypt.MinCost})
+	current := newHasher(t, passhash.Params{Scheme: passhash.SchemeArgon2id, Argon2: argon2, PepperID: "2025"})
+
+	old, err := legacy.Hash("password")
+	require.NoError(t, err)
+
+	// Старый хэш проверяется новой политикой, пока его перец настроен
+	ok, err := current.Verify(old, "password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, current.NeedsRehash(old))
+
+	stronger := newHasher(t, passhash.Params{
+		Scheme: passhash.SchemeArgon2id, Argon2: passhash.Argon2Params{Memory: 2048, Time: 1, Threads: 1}, PepperID: "2025",
+	})
+	hash, err := current.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, stronger.NeedsRehash(hash))
+
+	withoutLegacy, err := passhash.New(
+		passhash.Params{Scheme: passhash.SchemeArgon2id, Argon2: argon2, PepperID: "2025"},
+		map[string]string{"2025": "new-pepper"},
+	)
+	require.NoError(t, err)
+	_, err = withoutLegacy.Verify(old, "password")
+	require.ErrorIs(t, err, passhash.ErrUnknownPepper)
+}
+
+func TestNew(t *testing.T) {
+	_, err := passhash.New(passhash.Params{Scheme: "md5"}, peppers)
+	require.ErrorIs(t, err, passhash.ErrUnknownScheme)
+
+	_, err = passhash.New(passhash.Params{Scheme: passhash.SchemeArgon2id, Argon2: argon2, PepperID: "missing"}, peppers)
+	require.ErrorIs(t, err, passhash.ErrUnknownPepper)
+
+	_, err = passhash.New(passhash.Params{Scheme: passhash.SchemeArgon2id}, peppers)
+	require.ErrorIs(t, err, passhash.ErrInvalidParams)
+
+	_, err = passhash.New(passhash.Params{Scheme: passhash.SchemeBcrypt, BcryptCost: 100}, peppers)
+	require.ErrorIs(t, err, passhash.ErrInvalidParams)
+}
@@ -0,0 +1,344 @@
+// Package passpolicy пакет оценки стойкости паролей и политики паролей аккаунта.
+// Оценка вычисляется локально по образцу zxcvbn: пароль разбирается на словарные слова, последовательности,
+// повторы и раскладки клавиатуры, стоимость перебора которых много меньше полного перебора символов.
+// Пароль никуда не передается, поэтому оценку можно считать и на сервере, и на клиенте.
+package passpolicy
+
+import (
+	"bufio"
+	_ "embed" // Встроенный список распространенных паролей.
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Пороги оценки в log10 числа попыток перебора, как в zxcvbn.
+const (
+	score1Guesses = 3
+	score2Guesses = 6
+	score3Guesses = 8
+	score4Guesses = 10
+
+	// MaxScore наибольшая оценка стойкости.
+	MaxScore = 4
+
+	minDictionaryLen = 4
+	minUserInputLen  = 3
+	minSequenceLen   = 3
+	minRepeatLen     = 3
+	minKeyboardLen   = 4
+
+	yearLen      = 4
+	minYear      = 1900
+	maxYear      = 2099
+	yearVariants = maxYear - minYear + 1
+)
+
+// Предупреждения оценки.
+const (
+	WarningCommon     = "this is a commonly used password"
+	WarningDictionary = "contains a common word"
+	WarningUserInput  = "contains your login or other personal data"
+	WarningSequence   = "contains a sequence like abc or 123"
+	WarningRepeat     = "contains repeated characters"
+	WarningKeyboard   = "contains a keyboard pattern like qwerty"
+	WarningYear       = "contains a year"
+	WarningShort      = "too short"
+)
+
+//go:embed common.txt
+var commonList string
+
+// common ранги распространенных паролей и слов: чем меньше ранг, тем раньше их пробуют при переборе.
+var common = parseList(commonList)
+
+// keyboardRows ряды клавиатуры, по которым набирают пароли вроде qwerty и asdf.
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// leet замены букв цифрами и символами, которые проверяются при поиске по словарю.
+var leet = strings.NewReplacer("0", "o", "1", "l", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s", "!", "i")
+
+// Strength оценка стойкости пароля.
+type Strength struct {
+	// Score от 0 (угадывается сразу) до MaxScore (стойкий).
+	Score int
+	// Guesses десятичный логарифм ожидаемого числа попыток перебора.
+	Guesses float64
+	// Warnings найденные слабые места.
+	Warnings []string
+}
+
+// Estimate оценка стойкости пароля. userInputs данные пользователя (логин, адрес сайта),
+// которые атакующий проверит в первую очередь.
+func Estimate(password string, userInputs ...string) Strength {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return Strength{Warnings: []string{WarningShort}}
+	}
+
+	lower := []rune(strings.ToLower(password))
+	normalized := []rune(leet.Replace(strings.ToLower(password)))
+
+	if rank, ok := common[string(lower)]; ok {
+		return newStrength(math.Log2(float64(rank)+1), WarningCommon)
+	}
+	if rank, ok := common[string(normalized)]; ok {
+		return newStrength(math.Log2(float64(rank)+1)+1, WarningCommon)
+	}
+
+	inputs := normalizeInputs(userInputs)
+	charBits := math.Log2(float64(charsetSize(runes)))
+
+	var (
+		bits     float64
+		warnings []string
+	)
+	for i := 0; i < len(runes); {
+		n, tokenBits, warning := matchAt(runes, lower, normalized, i, inputs)
+		if n == 0 {
+			bits += charBits
+			i++
+			continue
+		}
+
+		bits += tokenBits
+		warnings = appendUnique(warnings, warning)
+		i += n
+	}
+
+	if len(runes) < minDictionaryLen*2 {
+		warnings = appendUnique(warnings, WarningShort)
+	}
+
+	return newStrength(bits, warnings...)
+}
+
+// matchAt поиск самого длинного шаблона, начинающегося в позиции i. Возвращает длину шаблона,
+// его стоимость в битах и предупреждение, либо 0, если шаблона нет.
+func matchAt(runes, lower, normalized []rune, i int, inputs []string) (int, float64, string) {
+	var (
+		best     int
+		bestBits float64
+		warning  string
+	)
+	consider := func(n int, bits float64, w string) {
+		if n > best || (n == best && bits < bestBits) {
+			best, bestBits, warning = n, bits, w
+		}
+	}
+
+	for _, input := range inputs {
+		if strings.HasPrefix(string(lower[i:]), input) || strings.HasPrefix(string(normalized[i:]), input) {
+			n := len([]rune(input))
+			consider(n, 1+caseBits(runes[i:i+n]), WarningUserInput)
+		}
+	}
+
+	for j := len(runes); j-i >= minDictionaryLen; j-- {
+		if rank, ok := common[string(lower[i:j])]; ok {
+			consider(j-i, math.Log2(float64(rank)+1)+caseBits(runes[i:j]), WarningDictionary)
+			break
+		}
+		if rank, ok := common[string(normalized[i:j])]; ok {
+			consider(j-i, math.Log2(float64(rank)+1)+1+caseBits(runes[i:j]), WarningDictionary)
+			break
+		}
+	}
+
+	if n := repeatLen(lower, i); n >= minRepeatLen {
+		consider(n, math.Log2(float64(charsetSize(runes[i:i+1])))+math.Log2(float64(n)), WarningRepeat)
+	}
+
+	if n := sequenceLen(lower, i); n >= minSequenceLen {
+		// Начало последовательности и направление угадываются почти сразу
+		consider(n, math.Log2(float64(charsetSize(runes[i:i+1])))+math.Log2(float64(n))+1, WarningSequence)
+	}
+
+	if n := keyboardLen(lower, i); n >= minKeyboardLen {
+		consider(n, math.Log2(float64(len(keyboardRows)*10))+math.Log2(float64(n))+1, WarningKeyboard)
+	}
+
+	if isYear(lower, i) {
+		consider(yearLen, math.Log2(yearVariants), WarningYear)
+	}
+
+	return best, bestBits, warning
+}
+
+// isYear начинается ли в позиции i год от minYear до maxYear.
+func isYear(s []rune, i int) bool {
+	if i+yearLen > len(s) {
+		return false
+	}
+
+	year := 0
+	for _, r := range s[i : i+yearLen] {
+		if r < '0' || r > '9' {
+			return false
+		}
+		year = year*10 + int(r-'0')
+	}
+
+	return year >= minYear && year <= maxYear
+}
+
+// repeatLen длина повтора одного символа, начиная с позиции i.
+func repeatLen(s []rune, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// sequenceLen длина последовательности с шагом ±1 (abc, 987), начиная с позиции i.
+func sequenceLen(s []rune, i int) int {
+	if i+1 >= len(s) {
+		return 1
+	}
+
+	delta := s[i+1] - s[i]
+	if delta != 1 && delta != -1 {
+		return 1
+	}
+
+	n := 2
+	for i+n < len(s) && s[i+n]-s[i+n-1] == delta {
+		n++
+	}
+	return n
+}
+
+// keyboardLen длина набора подряд идущих клавиш одного ряда в любом направлении, начиная с позиции i.
+func keyboardLen(s []rune, i int) int {
+	best := 1
+	for _, row := range keyboardRows {
+		for _, dir := range []int{1, -1} {
+			pos := strings.IndexRune(row, s[i])
+			if pos < 0 {
+				break
+			}
+
+			n := 1
+			for i+n < len(s) {
+				pos += dir
+				if pos < 0 || pos >= len(row) || rune(row[pos]) != s[i+n] {
+					break
+				}
+				n++
+			}
+			best = max(best, n)
+		}
+	}
+	return best
+}
+
+// caseBits дополнительная стоимость перебора вариантов регистра слова.
+func caseBits(word []rune) float64 {
+	var upper int
+	for _, r := range word {
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+
+	switch {
+	case upper == 0:
+		return 0
+	case upper == 1 && unicode.IsUpper(word[0]), upper == len(word):
+		// Заглавная первая буква или все заглавные перебираются первыми
+		return 1
+	default:
+		return float64(len(word))
+	}
+}
+
+// charsetSize размер алфавита, из которого, судя по паролю, выбирались символы.
+func charsetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	for _, c := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if c.present {
+			size += c.size
+		}
+	}
+
+	return max(size, 1)
+}
+
+func newStrength(bits float64, warnings ...string) Strength {
+	guesses := bits * math.Log10(2)
+
+	var score int
+	switch {
+	case guesses < score1Guesses:
+		score = 0
+	case guesses < score2Guesses:
+		score = 1
+	case guesses < score3Guesses:
+		score = 2
+	case guesses < score4Guesses:
+		score = 3
+	default:
+		score = MaxScore
+	}
+
+	return Strength{Score: score, Guesses: guesses, Warnings: warnings}
+}
+
+func normalizeInputs(userInputs []string) []string {
+	inputs := make([]string, 0, len(userInputs))
+	for _, in := range userInputs {
+		in = strings.ToLower(strings.TrimSpace(in))
+		if len([]rune(in)) >= minUserInputLen {
+			inputs = append(inputs, in)
+		}
+	}
+	return inputs
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// parseList разбор списка паролей по одному в строке, ранг равен номеру строки.
+func parseList(list string) map[string]int {
+	res := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for rank := 1; scanner.Scan(); {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if _, ok := res[word]; !ok {
+			res[word] = rank
+			rank++
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,118 @@
+package passpolicy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		password  string
+		inputs    []string
+		maxScore  int
+		minScore  int
+		wantWarns []string
+	}{
+		{name: "empty", password: "", maxScore: 0, wantWarns: []string{passpolicy.WarningShort}},
+		{name: "common", password: "password", maxScore: 0, wantWarns: []string{passpolicy.WarningCommon}},
+		{name: "common leet", password: "P@ssw0rd", maxScore: 0, wantWarns: []string{passpolicy.WarningCommon}},
+		{name: "sequence", password: "abcdefgh", maxScore: 1, wantWarns: []string{passpolicy.WarningSequence}},
+		{name: "repeat", password: "zzzzzzzzzz", maxScore: 1, wantWarns: []string{passpolicy.WarningRepeat}},
+		{name: "keyboard", password: "asdfghjk", maxScore: 1},
+		{
+			name:      "word with year",
+			password:  "Dragon2024",
+			maxScore:  1,
+			wantWarns: []string{passpolicy.WarningDictionary, passpolicy.WarningYear},
+		},
+		{
+			name:      "login",
+			password:  "johnsmith1990",
+			inputs:    []string{"JohnSmith"},
+			maxScore:  2,
+			wantWarns: []string{passpolicy.WarningUserInput},
+		},
+		{name: "random", password: "vT7#qLp9!wZ2", minScore: passpolicy.MaxScore, maxScore: passpolicy.MaxScore},
+		{
+			name:     "passphrase",
+			password: "correct horse battery staple",
+			minScore: passpolicy.MaxScore,
+			maxScore: passpolicy.MaxScore,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			s := passpolicy.Estimate(test.password, test.inputs...)
+			assert.LessOrEqual(t, s.Score, test.maxScore)
+			assert.GreaterOrEqual(t, s.Score, test.minScore)
+			for _, w := range test.wantWarns {
+				assert.Contains(t, s.Warnings, w)
+			}
+		})
+	}
+}
+
+func TestEstimateMonotonic(t *testing.T) {
+	weak := passpolicy.Estimate("monkey123")
+	strong := passpolicy.Estimate("monkey123-Tz8#kq")
+
+	assert.Greater(t, strong.Guesses, weak.Guesses)
+}
+
+func TestPolicyCheck(t *testing.T) {
+	p := passpolicy.New(10, 3, 3, []string{"Gopher-Keeper-2025"})
+
+	testCases := []struct {
+		name     string
+		password string
+		inputs   []string
+		wantErr  bool
+	}{
+		{name: "strong", password: "vT7#qLp9!wZ2"},
+		{name: "short", password: "aB3$", wantErr: true},
+		{name: "one class", password: "qhvmzkwtrplxbn", wantErr: true},
+		{name: "banned", password: "gopher-keeper-2025", wantErr: true},
+		{name: "common", password: "Password123!", wantErr: true},
+		{name: "login", password: "Johnsmith-2", inputs: []string{"johnsmith"}, wantErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := p.Check(test.password, test.inputs...)
+			if !test.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, passpolicy.ErrWeakPassword)
+			var violation *passpolicy.ViolationError
+			require.ErrorAs(t, err, &violation)
+			assert.NotEmpty(t, violation.Reasons)
+		})
+	}
+
+	require.NoError(t, passpolicy.New(0, 0, 0, nil).Check("x"))
+}
+
+func TestLoadBannedList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# company names\nGophKeeper\n\n  acme2025  \n"), 0o600))
+
+	list, err := passpolicy.LoadBannedList(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GophKeeper", "acme2025"}, list)
+
+	list, err = passpolicy.LoadBannedList("")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = passpolicy.LoadBannedList(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
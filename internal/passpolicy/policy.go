@@ -0,0 +1,149 @@
+package passpolicy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// ErrWeakPassword пароль не удовлетворяет политике.
+var ErrWeakPassword = errors.New("password does not satisfy policy")
+
+// ViolationError нарушения политики паролей, которые можно показать пользователю.
+type ViolationError struct {
+	Reasons []string
+}
+
+// Error текст со всеми нарушениями.
+func (e *ViolationError) Error() string {
+	return "weak password: " + strings.Join(e.Reasons, "; ")
+}
+
+// Is ViolationError соответствует ErrWeakPassword.
+func (e *ViolationError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+// Policy политика паролей аккаунта. Нулевые пороги не проверяются.
+type Policy struct {
+	// MinLength минимальная длина в символах.
+	MinLength int
+	// MinClasses минимальное число классов символов: строчные, заглавные, цифры, прочие.
+	MinClasses int
+	// MinScore минимальная оценка стойкости Estimate.
+	MinScore int
+
+	banned map[string]int
+}
+
+// New политика с дополнительным списком запрещенных паролей banned.
+// Распространенные пароли из встроенного списка запрещены всегда.
+func New(minLength, minClasses, minScore int, banned []string) *Policy {
+	p := Policy{
+		MinLength:  minLength,
+		MinClasses: minClasses,
+		MinScore:   minScore,
+		banned:     make(map[string]int, len(banned)),
+	}
+	for _, b := range banned {
+		if b = strings.ToLower(strings.TrimSpace(b)); b != "" {
+			p.banned[b] = 1
+		}
+	}
+
+	return &p
+}
+
+// LoadBannedList чтение списка запрещенных паролей: по одному в строке, строки с # пропускаются.
+// Пустой путь означает пустой список.
+func LoadBannedList(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening banned passwords list %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var list []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading banned passwords list %w", err)
+	}
+
+	return list, nil
+}
+
+// Check проверка нового пароля. userInputs данные пользователя (логин), которые не должны угадываться
+// в пароле. Возвращает *ViolationError со всеми нарушениями.
+func (p *Policy) Check(password string, userInputs ...string) error {
+	var reasons []string
+
+	if n := len([]rune(password)); n < p.MinLength {
+		reasons = append(reasons, fmt.Sprintf("must be at least %d characters long", p.MinLength))
+	}
+
+	if n := Classes(password); n < p.MinClasses {
+		reasons = append(reasons, fmt.Sprintf(
+			"must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses,
+		))
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.banned[lower]; ok {
+		reasons = append(reasons, "is in the list of banned passwords")
+	}
+
+	if strength := Estimate(password, userInputs...); strength.Score < p.MinScore {
+		reason := "is too easy to guess"
+		if len(strength.Warnings) > 0 {
+			reason += ": " + strings.Join(strength.Warnings, ", ")
+		}
+		reasons = append(reasons, reason)
+	}
+
+	if len(reasons) > 0 {
+		return &ViolationError{Reasons: reasons}
+	}
+
+	return nil
+}
+
+// Classes число классов символов в пароле: строчные, заглавные, цифры, прочие.
+func Classes(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	n := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			n++
+		}
+	}
+	return n
+}
@@ -7,7 +7,6 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -15,15 +14,23 @@ import (
 	"time"
 )
 
-// SaveFileData сохранить файл секретный, возвращает контрольную сумму файла, его полный путь и ошибку.
-func SaveFileData(_ context.Context, userID int, path string, content []byte) (string, string, error) {
-	op := "SaveFileData"
+// SaveFileData сохранить секретный файл, возвращает контрольную сумму файла, его полный путь и ошибку.
+// Содержимое копируется из content в файл по частям, не загружаясь в память целиком.
+func SaveFileData(_ context.Context, userID int, path string, content io.Reader) (string, string, error) {
+	return saveFile("SaveFileData", path, "user_"+strconv.Itoa(userID), content)
+}
+
+// SaveCollectionFileData сохранить секретный файл коллекции организации в папку коллекции,
+// возвращает контрольную сумму файла и его полный путь.
+func SaveCollectionFileData(_ context.Context, collectionID int, path string, content io.Reader) (string, string, error) {
+	return saveFile("SaveCollectionFileData", path, "collection_"+strconv.Itoa(collectionID), content)
+}
 
+// saveFile сохранение содержимого в новый файл папки dir внешнего хранилища path.
+func saveFile(op, path, dir string, content io.Reader) (string, string, error) {
 	var (
-		hasher   hash.Hash
-		out      *os.File
-		checksum string
-		err      error
+		out *os.File
+		err error
 	)
 
 	_, err = os.Stat(path)
@@ -34,34 +41,96 @@ func SaveFileData(_ context.Context, userID int, path string, content []byte) (s
 		return "", "", fmt.Errorf("%s: failed to chekc external storage path with error %w", op, err)
 	}
 
-	// Создаем папку пользователя
-	userDir := filepath.Join(path, "user_"+strconv.Itoa(userID))
-	if err = os.MkdirAll(userDir, 0o700); err != nil {
+	// Создаем папку владельца
+	ownerDir := filepath.Join(path, dir)
+	if err = os.MkdirAll(ownerDir, 0o700); err != nil {
 		return "", "", fmt.Errorf("%s: failed to create user folder with error %w", op, err)
 	}
 
-	filePath := filepath.Join(userDir, time.Now().Format("2006_01_02_15_04_05"))
+	filePath := filepath.Join(ownerDir, time.Now().Format("2006_01_02_15_04_05"))
 
 	// Сохраняем файл
-	out, err = os.Create(filePath)
+	out, err = os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
 	if err != nil {
 		return "", "", fmt.Errorf("%s: failed to create file with error %w", op, err)
 	}
+
+	checksum, err := writeFile(out, content)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checksum, filePath, nil
+}
+
+// RewriteFileData сохранение нового содержимого секретного файла рядом с файлом oldPath, например
+// после перешифровки. Прежний файл не удаляется. Возвращает контрольную сумму и полный путь нового файла.
+func RewriteFileData(_ context.Context, oldPath string, content io.Reader) (string, string, error) {
+	op := "RewriteFileData"
+
+	out, err := os.CreateTemp(filepath.Dir(oldPath), filepath.Base(oldPath)+"_*")
+	if err != nil {
+		return "", "", fmt.Errorf("%s: failed to create file with error %w", op, err)
+	}
+
+	checksum, err := writeFile(out, content)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checksum, out.Name(), nil
+}
+
+// writeFile запись содержимого в созданный файл с подсчетом контрольной суммы. Файл закрывается,
+// при ошибке записи удаляется.
+func writeFile(out *os.File, content io.Reader) (string, error) {
 	defer func() {
 		_ = out.Close()
 	}()
 
-	err = os.WriteFile(filePath, content, os.ModeAppend)
-	if err != nil {
-		return "", "", fmt.Errorf("%s: failed to write to file with error %w", op, err)
+	// Контрольная сумма считается по пути в файл, без повторного чтения
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hasher), content); err != nil {
+		_ = os.Remove(out.Name())
+		return "", fmt.Errorf("failed to write to file with error %w", err)
 	}
 
-	hasher = sha256.New()
-	if _, err = io.Copy(hasher, out); err != nil {
-		return "", "", fmt.Errorf("%s: failed to copy file content to hasher with error %w", op, err)
+	if err := out.Sync(); err != nil {
+		_ = os.Remove(out.Name())
+		return "", fmt.Errorf("failed to sync file with error %w", err)
 	}
 
-	checksum = hex.EncodeToString(hasher.Sum(nil))
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
 
-	return checksum, out.Name(), nil
+// DeleteUserData удаление папки пользователя со всеми его файлами. Отсутствие папки не считается ошибкой.
+func DeleteUserData(_ context.Context, userID int, path string) error {
+	op := "DeleteUserData"
+
+	if path == "" {
+		return fmt.Errorf("%s: external storage path is empty", op)
+	}
+
+	userDir := filepath.Join(path, "user_"+strconv.Itoa(userID))
+	if err := os.RemoveAll(userDir); err != nil {
+		return fmt.Errorf("%s: failed to remove user folder with error %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveFiles удаление файлов секретов по их полным путям. Отсутствующие файлы не считаются ошибкой.
+func RemoveFiles(paths []string) error {
+	var errs []error
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("RemoveFiles: %w", err)
+	}
+
+	return nil
 }
@@ -1,7 +1,13 @@
 package external_storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,7 +43,7 @@ func TestSaveFileData(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			_, filePath, err := SaveFileData(context.Background(), test.userID, test.path, test.content)
+			_, filePath, err := SaveFileData(context.Background(), test.userID, test.path, bytes.NewReader(test.content))
 
 			if test.wantErr {
 				require.Error(t, err)
@@ -48,3 +54,75 @@ func TestSaveFileData(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveFileDataChecksum(t *testing.T) {
+	path := t.TempDir()
+	content := bytes.Repeat([]byte("hello world"), 10000)
+
+	checksum, filePath, err := SaveFileData(context.Background(), 1, path, bytes.NewReader(content))
+	require.NoError(t, err)
+
+	saved, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, content, saved)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+}
+
+func TestSaveCollectionFileData(t *testing.T) {
+	path := t.TempDir()
+
+	_, filePath, err := SaveCollectionFileData(context.Background(), 7, path, bytes.NewReader([]byte("hello world")))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(path, "collection_7"), filepath.Dir(filePath))
+
+	// Файлы коллекций не попадают в папки пользователей, которые удаляются вместе с аккаунтом
+	require.NoError(t, DeleteUserData(context.Background(), 0, path))
+	assert.FileExists(t, filePath)
+
+	require.NoError(t, RemoveFiles([]string{filePath, filepath.Join(path, "missing")}))
+	assert.NoFileExists(t, filePath)
+}
+
+func TestRewriteFileData(t *testing.T) {
+	path := t.TempDir()
+
+	_, oldPath, err := SaveFileData(context.Background(), 1, path, bytes.NewReader([]byte("old content")))
+	require.NoError(t, err)
+
+	content := []byte("new content")
+	checksum, newPath, err := RewriteFileData(context.Background(), oldPath, bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.NotEqual(t, oldPath, newPath)
+	assert.Equal(t, filepath.Dir(oldPath), filepath.Dir(newPath))
+
+	saved, err := os.ReadFile(newPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, saved)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+	// Прежний файл удаляется только после сохранения нового пути
+	assert.FileExists(t, oldPath)
+}
+
+func TestDeleteUserData(t *testing.T) {
+	path := t.TempDir()
+
+	for _, userID := range []int{1, 2} {
+		userDir := filepath.Join(path, "user_"+strconv.Itoa(userID))
+		require.NoError(t, os.MkdirAll(userDir, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(userDir, "file"), []byte("hello world"), 0o600))
+	}
+
+	require.NoError(t, DeleteUserData(context.Background(), 1, path))
+	assert.NoDirExists(t, filepath.Join(path, "user_1"))
+	assert.FileExists(t, filepath.Join(path, "user_2", "file"))
+
+	// Повторное удаление после частично выполненной очистки
+	require.NoError(t, DeleteUserData(context.Background(), 1, path))
+
+	require.Error(t, DeleteUserData(context.Background(), 1, ""))
+}
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN encryption_mode TEXT NOT NULL DEFAULT 'server' CHECK ( encryption_mode IN ('server', 'e2e') ),
+    ADD COLUMN kdf_salt BYTEA,
+    ADD COLUMN kdf_time INTEGER,
+    ADD COLUMN kdf_memory INTEGER,
+    ADD COLUMN kdf_threads SMALLINT;
+
+ALTER TABLE secrets
+    ADD COLUMN client_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS client_encrypted;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS encryption_mode,
+    DROP COLUMN IF EXISTS kdf_salt,
+    DROP COLUMN IF EXISTS kdf_time,
+    DROP COLUMN IF EXISTS kdf_memory,
+    DROP COLUMN IF EXISTS kdf_threads;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE secrets
+    ADD COLUMN name_index TEXT;
+
+CREATE INDEX idx_secrets_user_name_index ON secrets(user_id, name_index);
+CREATE INDEX idx_secrets_user_name ON secrets(user_id, name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_secrets_user_name;
+DROP INDEX IF EXISTS idx_secrets_user_name_index;
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS name_index;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE external_storage
+    ADD COLUMN format TEXT NOT NULL DEFAULT 'envelope' CHECK ( format IN ('raw', 'envelope', 'stream') );
+
+UPDATE external_storage es
+SET format = 'raw'
+FROM secrets s
+WHERE s.id = es.secret_id AND s.client_encrypted;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE external_storage
+    DROP COLUMN IF EXISTS format;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sessions (
+                          id TEXT PRIMARY KEY,
+                          user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          refresh_hash TEXT NOT NULL,
+                          prev_refresh_hash TEXT NOT NULL DEFAULT '',
+                          user_agent TEXT NOT NULL DEFAULT '',
+                          ip TEXT NOT NULL DEFAULT '',
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                          revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sessions_user_id;
+DROP TABLE IF EXISTS sessions;
+-- +goose StatementEnd
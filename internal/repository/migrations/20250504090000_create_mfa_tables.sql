@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_totp (
+                          user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                          secret TEXT NOT NULL,
+                          last_step BIGINT NOT NULL DEFAULT 0,
+                          confirmed_at TIMESTAMP WITH TIME ZONE,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+                          id SERIAL PRIMARY KEY,
+                          user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          code_hash TEXT NOT NULL,
+                          used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_recovery_codes_user_hash ON recovery_codes(user_id, code_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_recovery_codes_user_hash;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+-- +goose StatementEnd
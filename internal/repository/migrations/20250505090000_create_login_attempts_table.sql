@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_attempts (
+                          key TEXT PRIMARY KEY,
+                          failures INT NOT NULL DEFAULT 0,
+                          last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                          blocked_until TIMESTAMP WITH TIME ZONE,
+                          locked BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_login_attempts_blocked_until ON login_attempts(blocked_until);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_login_attempts_blocked_until;
+DROP TABLE IF EXISTS login_attempts;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN delete_after TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_users_delete_after ON users(delete_after) WHERE delete_after IS NOT NULL;
+
+ALTER TABLE user_keys
+    DROP CONSTRAINT IF EXISTS user_keys_user_id_fkey,
+    ADD CONSTRAINT user_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_keys
+    DROP CONSTRAINT IF EXISTS user_keys_user_id_fkey,
+    ADD CONSTRAINT user_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+DROP INDEX IF EXISTS idx_users_delete_after;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS delete_after;
+-- +goose StatementEnd
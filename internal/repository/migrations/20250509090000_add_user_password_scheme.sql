@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Существующие хэши получены исходной схемой bcrypt(password+pepper) с перцем security.pepper
+ALTER TABLE users
+    ADD COLUMN password_scheme TEXT NOT NULL DEFAULT 'bcrypt',
+    ADD COLUMN pepper_id       TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS pepper_id,
+    DROP COLUMN IF EXISTS password_scheme;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN is_admin    BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN disabled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS invites (
+                          id SERIAL PRIMARY KEY,
+                          code_hash BYTEA NOT NULL UNIQUE,
+                          created_by INT REFERENCES users(id) ON DELETE SET NULL,
+                          note TEXT NOT NULL DEFAULT '',
+                          expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                          used_by INT REFERENCES users(id) ON DELETE SET NULL,
+                          used_at TIMESTAMP WITH TIME ZONE,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invites;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS is_admin;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_keys
+    ADD COLUMN public_key            BYTEA,
+    ADD COLUMN encrypted_private_key BYTEA;
+
+CREATE TABLE IF NOT EXISTS secret_shares (
+                          id SERIAL PRIMARY KEY,
+                          secret_id INT NOT NULL REFERENCES secrets(id) ON DELETE CASCADE,
+                          owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          recipient_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          permission TEXT NOT NULL DEFAULT 'read' CHECK ( permission IN ('read', 'write') ),
+                          wrapped_key BYTEA,
+                          payload BYTEA,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          UNIQUE (secret_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_shares_recipient_id ON secret_shares(recipient_id);
+CREATE INDEX IF NOT EXISTS idx_secret_shares_owner_id ON secret_shares(owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS secret_shares;
+
+ALTER TABLE user_keys
+    DROP COLUMN IF EXISTS encrypted_private_key,
+    DROP COLUMN IF EXISTS public_key;
+-- +goose StatementEnd
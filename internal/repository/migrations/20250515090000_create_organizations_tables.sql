@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS organizations (
+                         id SERIAL PRIMARY KEY,
+                         name TEXT NOT NULL,
+                         created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+                         org_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+                         user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         role TEXT NOT NULL CHECK ( role IN ('owner', 'admin', 'editor', 'viewer') ),
+                         created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                         PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user_id ON org_members(user_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+                         id SERIAL PRIMARY KEY,
+                         org_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+                         name TEXT NOT NULL,
+                         key_version INT NOT NULL DEFAULT 1,
+                         created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                         UNIQUE (org_id, name)
+);
+
+-- Ключ коллекции, зашифрованный ключом участника; строка на каждого участника и версию ключа.
+CREATE TABLE IF NOT EXISTS collection_keys (
+                         collection_id INT NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+                         version INT NOT NULL,
+                         user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         wrapped_key BYTEA NOT NULL,
+                         PRIMARY KEY (collection_id, version, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_keys_user_id ON collection_keys(user_id);
+
+-- Секрет коллекции принадлежит организации и переживает удаление аккаунта автора.
+ALTER TABLE secrets
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN collection_id INT REFERENCES collections(id) ON DELETE CASCADE,
+    ADD COLUMN key_version INT,
+    ADD COLUMN created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    ADD CONSTRAINT secrets_owner_check CHECK ( (user_id IS NULL) <> (collection_id IS NULL) );
+
+CREATE INDEX IF NOT EXISTS idx_secrets_collection_id ON secrets(collection_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM secrets WHERE collection_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_secrets_collection_id;
+
+ALTER TABLE secrets
+    DROP CONSTRAINT IF EXISTS secrets_owner_check,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS key_version,
+    DROP COLUMN IF EXISTS collection_id,
+    ALTER COLUMN user_id SET NOT NULL;
+
+DROP TABLE IF EXISTS collection_keys;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS one_time_shares (
+                          id TEXT PRIMARY KEY,
+                          owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          ciphertext BYTEA NOT NULL,
+                          max_views INT NOT NULL CHECK ( max_views > 0 ),
+                          views_left INT NOT NULL CHECK ( views_left >= 0 ),
+                          expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_one_time_shares_expires_at ON one_time_shares(expires_at);
+CREATE INDEX IF NOT EXISTS idx_one_time_shares_owner_id ON one_time_shares(owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS one_time_shares;
+-- +goose StatementEnd
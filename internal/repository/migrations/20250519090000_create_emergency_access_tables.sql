@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS emergency_contacts (
+                          id SERIAL PRIMARY KEY,
+                          grantor_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          grantee_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          access_type TEXT NOT NULL CHECK ( access_type IN ('view', 'takeover') ),
+                          wait_seconds BIGINT NOT NULL CHECK ( wait_seconds > 0 ),
+                          status TEXT NOT NULL DEFAULT 'nominated'
+                              CHECK ( status IN ('nominated', 'pending', 'approved', 'rejected') ),
+                          wrapped_vault_key BYTEA,
+                          requested_at TIMESTAMP WITH TIME ZONE,
+                          decided_at TIMESTAMP WITH TIME ZONE,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          UNIQUE (grantor_id, grantee_id),
+                          CHECK ( grantor_id <> grantee_id )
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_contacts_grantee_id ON emergency_contacts(grantee_id);
+
+CREATE TABLE IF NOT EXISTS emergency_access_events (
+                          id BIGSERIAL PRIMARY KEY,
+                          contact_id INT REFERENCES emergency_contacts(id) ON DELETE SET NULL,
+                          grantor_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          grantee_id INT REFERENCES users(id) ON DELETE SET NULL,
+                          actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+                          action TEXT NOT NULL,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_access_events_grantor_id ON emergency_access_events(grantor_id);
+CREATE INDEX IF NOT EXISTS idx_emergency_access_events_grantee_id ON emergency_access_events(grantee_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS emergency_access_events;
+DROP TABLE IF EXISTS emergency_contacts;
+-- +goose StatementEnd
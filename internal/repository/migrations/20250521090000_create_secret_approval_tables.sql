@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS secret_approval_policies (
+                          secret_id INT PRIMARY KEY REFERENCES secrets(id) ON DELETE CASCADE,
+                          owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          grant_seconds BIGINT NOT NULL CHECK ( grant_seconds > 0 ),
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS secret_approvers (
+                          secret_id INT NOT NULL REFERENCES secret_approval_policies(secret_id) ON DELETE CASCADE,
+                          user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          PRIMARY KEY (secret_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_approvers_user_id ON secret_approvers(user_id);
+
+CREATE TABLE IF NOT EXISTS secret_access_requests (
+                          id SERIAL PRIMARY KEY,
+                          secret_id INT NOT NULL REFERENCES secrets(id) ON DELETE CASCADE,
+                          requester_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          reason TEXT NOT NULL DEFAULT '',
+                          status TEXT NOT NULL DEFAULT 'pending'
+                              CHECK ( status IN ('pending', 'approved', 'denied') ),
+                          decided_by INT REFERENCES users(id) ON DELETE SET NULL,
+                          decided_at TIMESTAMP WITH TIME ZONE,
+                          granted_until TIMESTAMP WITH TIME ZONE,
+                          expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_access_requests_secret_requester
+    ON secret_access_requests(secret_id, requester_id);
+CREATE INDEX IF NOT EXISTS idx_secret_access_requests_requester_id ON secret_access_requests(requester_id);
+
+-- Журнал не ссылается на секрет и запрос внешним ключом, чтобы пережить их удаление
+CREATE TABLE IF NOT EXISTS secret_access_events (
+                          id BIGSERIAL PRIMARY KEY,
+                          secret_id INT NOT NULL,
+                          request_id INT,
+                          actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+                          action TEXT NOT NULL,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_access_events_secret_id ON secret_access_events(secret_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS secret_access_events;
+DROP TABLE IF EXISTS secret_access_requests;
+DROP TABLE IF EXISTS secret_approvers;
+DROP TABLE IF EXISTS secret_approval_policies;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Журнал не ссылается на секрет внешним ключом, чтобы пережить его удаление
+CREATE TABLE IF NOT EXISTS audit_events (
+                          id BIGSERIAL PRIMARY KEY,
+                          actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+                          login TEXT NOT NULL DEFAULT '',
+                          action TEXT NOT NULL,
+                          secret_id INT,
+                          client_ip TEXT NOT NULL DEFAULT '',
+                          user_agent TEXT NOT NULL DEFAULT '',
+                          result TEXT NOT NULL CHECK ( result IN ('success', 'failure') ),
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_secret_id ON audit_events(secret_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
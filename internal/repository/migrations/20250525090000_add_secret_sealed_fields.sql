@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Битовая маска полей секрета, зашифрованных политикой шифрования полей (secret.SealedFields).
+-- Заполняется для уже сохраненных секретов Go-миграцией 20250525090100_track_sealed_fields.
+ALTER TABLE secrets
+    ADD COLUMN sealed_fields INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS sealed_fields;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключи данных пользователей, обернутые провайдером ключей (KMS). Открытые ключи в БД не хранятся.
+CREATE TABLE IF NOT EXISTS user_data_keys (
+                          user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                          provider TEXT NOT NULL,
+                          wrapped_key BYTEA NOT NULL,
+                          created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                          rotated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_keys_provider ON user_data_keys(provider);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_user_data_keys_provider;
+DROP TABLE IF EXISTS user_data_keys;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- actor_id автор операции, subject_user_id пользователь, чей аккаунт или секрет затронут. Они различаются,
+-- когда секрет читает получатель общего доступа или доверенный контакт; прежние записи относятся к автору.
+ALTER TABLE audit_events
+    ADD COLUMN subject_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN actor_login TEXT NOT NULL DEFAULT '';
+
+UPDATE audit_events SET subject_user_id = actor_id, actor_login = login;
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_subject_user_id ON audit_events(subject_user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_events_subject_user_id;
+
+ALTER TABLE audit_events
+    DROP COLUMN IF EXISTS actor_login,
+    DROP COLUMN IF EXISTS subject_user_id;
+-- +goose StatementEnd
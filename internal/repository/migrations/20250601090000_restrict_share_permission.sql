@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE secret_shares DROP CONSTRAINT IF EXISTS secret_shares_permission_check;
+ALTER TABLE secret_shares ADD CONSTRAINT secret_shares_permission_check CHECK ( permission = 'read' );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE secret_shares DROP CONSTRAINT IF EXISTS secret_shares_permission_check;
+ALTER TABLE secret_shares ADD CONSTRAINT secret_shares_permission_check CHECK ( permission IN ('read', 'write') );
+-- +goose StatementEnd
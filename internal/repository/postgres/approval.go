@@ -0,0 +1,484 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/approval"
+)
+
+// ApprovalRepository репозиторий политик одобрения, запросов доступа к секретам и их журнала.
+type ApprovalRepository struct {
+	db *sql.DB
+}
+
+// NewApprovalRepository получение репозитория одобрения доступа к секретам.
+func NewApprovalRepository(db *sql.DB) *ApprovalRepository {
+	return &ApprovalRepository{db: db}
+}
+
+// SavePolicy сохранение политики и списка утверждающих вместе с записью журнала.
+func (ar *ApprovalRepository) SavePolicy(ctx context.Context, p *approval.Policy, ev *approval.Event) error {
+	op := "repository.Postgres.Approval.SavePolicy"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO secret_approval_policies (secret_id, owner_id, grant_seconds, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (secret_id) DO UPDATE SET grant_seconds = EXCLUDED.grant_seconds, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err = tx.ExecContext(ctx, query, p.SecretID, p.OwnerID, int64(p.Grant/time.Second), p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: failed to upsert policy %w", op, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM secret_approvers WHERE secret_id = $1`, p.SecretID); err != nil {
+		return fmt.Errorf("%s: failed to delete approvers %w", op, err)
+	}
+
+	for _, a := range p.Approvers {
+		_, err = tx.ExecContext(
+			ctx, `INSERT INTO secret_approvers (secret_id, user_id) VALUES ($1, $2)`, p.SecretID, a.UserID,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: failed to insert approver %w", op, err)
+		}
+	}
+
+	if err = addAccessEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetPolicy политика секрета с логинами утверждающих.
+func (ar *ApprovalRepository) GetPolicy(ctx context.Context, secretID int) (*approval.Policy, error) {
+	op := "repository.Postgres.Approval.GetPolicy"
+
+	var (
+		p            approval.Policy
+		grantSeconds int64
+	)
+
+	query := `
+		SELECT secret_id, owner_id, grant_seconds, created_at, updated_at
+		FROM secret_approval_policies WHERE secret_id = $1
+	`
+
+	err := ar.db.QueryRowContext(ctx, query, secretID).Scan(
+		&p.SecretID, &p.OwnerID, &grantSeconds, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, approval.ErrNoPolicy
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	p.Grant = time.Duration(grantSeconds) * time.Second
+
+	query = `
+		SELECT a.user_id, u.login FROM secret_approvers a
+		JOIN users u ON u.id = a.user_id
+		WHERE a.secret_id = $1 ORDER BY u.login
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get approvers %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var a approval.Approver
+		if err = rows.Scan(&a.UserID, &a.Login); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan approver row %w", op, err)
+		}
+		p.Approvers = append(p.Approvers, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return &p, nil
+}
+
+// DeletePolicy удаление политики вместе с записью журнала. Утверждающие удаляются каскадно.
+func (ar *ApprovalRepository) DeletePolicy(ctx context.Context, p *approval.Policy, ev *approval.Event) error {
+	op := "repository.Postgres.Approval.DeletePolicy"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM secret_approval_policies WHERE secret_id = $1`, p.SecretID); err != nil {
+		return fmt.Errorf("%s: failed to delete policy %w", op, err)
+	}
+
+	if err = addAccessEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ProtectedSecrets секреты из secretIDs с политикой одобрения.
+func (ar *ApprovalRepository) ProtectedSecrets(ctx context.Context, secretIDs []int) ([]int, error) {
+	op := "repository.Postgres.Approval.ProtectedSecrets"
+
+	query := `SELECT secret_id FROM secret_approval_policies WHERE secret_id = ANY($1)`
+
+	rows, err := ar.db.QueryContext(ctx, query, secretIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan secret id row %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// ActiveGrants одобренные запросы userID к секретам secretIDs, действующие в момент now.
+func (ar *ApprovalRepository) ActiveGrants(
+	ctx context.Context,
+	userID int,
+	secretIDs []int,
+	now time.Time,
+) (map[int]int, error) {
+	op := "repository.Postgres.Approval.ActiveGrants"
+
+	query := `
+		SELECT secret_id, MAX(id) FROM secret_access_requests
+		WHERE requester_id = $1 AND secret_id = ANY($2) AND status = $3 AND granted_until > $4
+		GROUP BY secret_id
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, userID, secretIDs, approval.StatusApproved, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	grants := make(map[int]int)
+	for rows.Next() {
+		var secretID, requestID int
+		if err = rows.Scan(&secretID, &requestID); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan grant row %w", op, err)
+		}
+		grants[secretID] = requestID
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return grants, nil
+}
+
+// CreateRequest сохранение запроса вместе с записью журнала.
+func (ar *ApprovalRepository) CreateRequest(ctx context.Context, r *approval.Request, ev *approval.Event) error {
+	op := "repository.Postgres.Approval.CreateRequest"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO secret_access_requests (secret_id, requester_id, reason, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(
+		ctx, query, r.SecretID, r.RequesterID, r.Reason, r.Status, r.ExpiresAt, r.CreatedAt,
+	).Scan(&r.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert request %w", op, err)
+	}
+
+	ev.RequestID = r.ID
+	if err = addAccessEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const accessRequestColumns = `
+	r.id, r.secret_id, s.user_id, r.requester_id, rq.login, r.reason, r.status,
+	r.decided_by, d.login, r.decided_at, r.granted_until, r.expires_at, r.created_at
+`
+
+const accessRequestFrom = `
+	FROM secret_access_requests r
+	JOIN secrets s ON s.id = r.secret_id
+	JOIN users rq ON rq.id = r.requester_id
+	LEFT JOIN users d ON d.id = r.decided_by
+`
+
+// scanAccessRequest чтение запроса из строки с колонками accessRequestColumns.
+func scanAccessRequest(row rowScanner) (*approval.Request, error) {
+	var (
+		r                       approval.Request
+		decidedBy               sql.NullInt64
+		deciderLogin            sql.NullString
+		decidedAt, grantedUntil sql.NullTime
+	)
+
+	err := row.Scan(
+		&r.ID, &r.SecretID, &r.OwnerID, &r.RequesterID, &r.RequesterLogin, &r.Reason, &r.Status,
+		&decidedBy, &deciderLogin, &decidedAt, &grantedUntil, &r.ExpiresAt, &r.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.DecidedBy, r.DeciderLogin = int(decidedBy.Int64), deciderLogin.String
+	r.DecidedAt, r.GrantedUntil = decidedAt.Time, grantedUntil.Time
+
+	return &r, nil
+}
+
+// GetRequest запрос по ID.
+func (ar *ApprovalRepository) GetRequest(ctx context.Context, id int) (*approval.Request, error) {
+	query := `SELECT ` + accessRequestColumns + accessRequestFrom + `WHERE r.id = $1`
+
+	r, err := scanAccessRequest(ar.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, approval.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.Postgres.Approval.GetRequest: %w", err)
+	}
+
+	return r, nil
+}
+
+// OpenRequest есть ли у requesterID запрос к секрету без ответа или действующий доступ.
+func (ar *ApprovalRepository) OpenRequest(ctx context.Context, secretID, requesterID int, now time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM secret_access_requests
+			WHERE secret_id = $1 AND requester_id = $2
+			  AND ((status = $3 AND expires_at > $5) OR (status = $4 AND granted_until > $5))
+		)
+	`
+
+	var exists bool
+	err := ar.db.QueryRowContext(
+		ctx, query, secretID, requesterID, approval.StatusPending, approval.StatusApproved, now,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("repository.Postgres.Approval.OpenRequest: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Decide сохранение решения по запросу с проверкой, что он ждал решения, и запись журнала в одной транзакции.
+func (ar *ApprovalRepository) Decide(ctx context.Context, r *approval.Request, ev *approval.Event) error {
+	op := "repository.Postgres.Approval.Decide"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		UPDATE secret_access_requests SET status = $1, decided_by = $2, decided_at = $3, granted_until = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	var res sql.Result
+	res, err = tx.ExecContext(
+		ctx, query, r.Status, r.DecidedBy, r.DecidedAt, nullTime(r.GrantedUntil), r.ID, approval.StatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = approval.ErrStateChanged
+		return err
+	}
+
+	if err = addAccessEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListRequests запросы, где пользователь запросивший или утверждающий секрета.
+func (ar *ApprovalRepository) ListRequests(ctx context.Context, userID int) ([]*approval.Request, error) {
+	op := "repository.Postgres.Approval.ListRequests"
+
+	query := `SELECT ` + accessRequestColumns + accessRequestFrom + `
+		WHERE r.requester_id = $1
+		   OR EXISTS (SELECT 1 FROM secret_approvers a WHERE a.secret_id = r.secret_id AND a.user_id = $1)
+		ORDER BY r.created_at DESC, r.id DESC
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	requests := make([]*approval.Request, 0)
+	for rows.Next() {
+		r, err := scanAccessRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan request row %w", op, err)
+		}
+
+		requests = append(requests, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return requests, nil
+}
+
+// AddEvents запись журнала в одной транзакции.
+func (ar *ApprovalRepository) AddEvents(ctx context.Context, events []*approval.Event) error {
+	op := "repository.Postgres.Approval.AddEvents"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	for _, ev := range events {
+		if err = addAccessEvent(ctx, tx, ev); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// addAccessEvent вставка записи журнала, нулевые RequestID и ActorID сохраняются как NULL.
+func addAccessEvent(ctx context.Context, db execer, ev *approval.Event) error {
+	query := `
+		INSERT INTO secret_access_events (secret_id, request_id, actor_id, action, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := db.ExecContext(ctx, query, ev.SecretID, nullID(ev.RequestID), nullID(ev.ActorID), ev.Action, ev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert event %w", err)
+	}
+
+	return nil
+}
+
+// ListEvents журнал доступа к секрету.
+func (ar *ApprovalRepository) ListEvents(ctx context.Context, secretID int) ([]*approval.Event, error) {
+	op := "repository.Postgres.Approval.ListEvents"
+
+	query := `
+		SELECT e.id, e.secret_id, e.request_id, e.actor_id, a.login, e.action, e.created_at
+		FROM secret_access_events e
+		LEFT JOIN users a ON a.id = e.actor_id
+		WHERE e.secret_id = $1
+		ORDER BY e.created_at DESC, e.id DESC
+	`
+
+	rows, err := ar.db.QueryContext(ctx, query, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	events := make([]*approval.Event, 0)
+	for rows.Next() {
+		var (
+			ev                 approval.Event
+			requestID, actorID sql.NullInt64
+			actorLogin         sql.NullString
+		)
+
+		err = rows.Scan(&ev.ID, &ev.SecretID, &requestID, &actorID, &actorLogin, &ev.Action, &ev.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row %w", op, err)
+		}
+		ev.RequestID, ev.ActorID, ev.ActorLogin = int(requestID.Int64), int(actorID.Int64), actorLogin.String
+
+		events = append(events, &ev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+)
+
+// AuditRepository репозиторий журнала аудита.
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository получение репозитория журнала аудита.
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Save сохранение записей журнала одной транзакцией. Пустые логины автора и субъекта заменяются
+// их текущими логинами, чтобы запись оставалась читаемой после удаления аккаунтов.
+func (ar *AuditRepository) Save(ctx context.Context, events []*audit.Event) error {
+	op := "repository.Postgres.Audit.Save"
+
+	tx, err := ar.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO audit_events (
+			actor_id, actor_login, subject_user_id, login, action, secret_id, client_ip, user_agent, result, created_at
+		)
+		VALUES (
+			$1, COALESCE(NULLIF($2, ''), (SELECT login FROM users WHERE id = $1), ''),
+			$3, COALESCE(NULLIF($4, ''), (SELECT login FROM users WHERE id = $3), ''),
+			$5, $6, $7, $8, $9, $10
+		)
+		RETURNING id
+	`
+
+	for _, ev := range events {
+		err = tx.QueryRowContext(
+			ctx, query,
+			nullID(ev.ActorID), ev.ActorLogin, nullID(ev.SubjectID), ev.Login, ev.Action, nullID(ev.SecretID),
+			ev.ClientIP, ev.UserAgent, ev.Result, ev.CreatedAt,
+		).Scan(&ev.ID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to insert event %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Query записи журнала по фильтру от новых к старым.
+func (ar *AuditRepository) Query(ctx context.Context, f audit.Filter) ([]*audit.Event, error) {
+	op := "repository.Postgres.Audit.Query"
+
+	var (
+		conds []string
+		args  []any
+	)
+	where := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.UserID != 0 {
+		where("(actor_id = $%[1]d OR subject_user_id = $%[1]d)", f.UserID)
+	}
+	if f.Action != "" {
+		where("action = $%d", f.Action)
+	}
+	if f.SecretID != 0 {
+		where("secret_id = $%d", f.SecretID)
+	}
+	if f.Result != "" {
+		where("result = $%d", f.Result)
+	}
+	if !f.From.IsZero() {
+		where("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		where("created_at < $%d", f.To)
+	}
+	if f.BeforeID != 0 {
+		where("id < $%d", f.BeforeID)
+	}
+
+	query := `
+		SELECT id, actor_id, actor_login, subject_user_id, login, action, secret_id, client_ip, user_agent, result,
+			created_at
+		FROM audit_events
+	`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := ar.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	events := make([]*audit.Event, 0)
+	for rows.Next() {
+		var (
+			ev                           audit.Event
+			actorID, subjectID, secretID sql.NullInt64
+		)
+
+		err = rows.Scan(
+			&ev.ID, &actorID, &ev.ActorLogin, &subjectID, &ev.Login, &ev.Action, &secretID, &ev.ClientIP,
+			&ev.UserAgent, &ev.Result, &ev.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row %w", op, err)
+		}
+		ev.ActorID, ev.SubjectID, ev.SecretID = int(actorID.Int64), int(subjectID.Int64), int(secretID.Int64)
+
+		events = append(events, &ev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return events, nil
+}
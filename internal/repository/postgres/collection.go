@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/repository/external_storage"
+)
+
+// GetCollectionSecrets секреты коллекции без данных. Если nameLookups не nil, только с этим названием.
+func (sr *SecretRepository) GetCollectionSecrets(
+	ctx context.Context,
+	collectionID int,
+	nameLookups []string,
+) ([]*secret.Secret, error) {
+	secrets, err := queryCollectionSecrets(ctx, sr.db, collectionID, nameLookups)
+	if err != nil {
+		return nil, fmt.Errorf("repository.Postgres.GetCollectionSecrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// DeleteCollectionSecret удаление секрета коллекции вместе с файлом, secret.ErrSecretNotFound,
+// если в коллекции нет такого секрета.
+func (sr *SecretRepository) DeleteCollectionSecret(ctx context.Context, collectionID, secretID int) error {
+	op := "repository.Postgres.DeleteCollectionSecret"
+
+	// Подзапрос в RETURNING видит строку external_storage до каскадного удаления
+	var path sql.NullString
+	err := sr.db.QueryRowContext(ctx, `
+		DELETE FROM secrets s WHERE s.id = $1 AND s.collection_id = $2
+		RETURNING (SELECT storage_path FROM external_storage WHERE secret_id = s.id)
+	`, secretID, collectionID).Scan(&path)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return secret.ErrSecretNotFound
+		}
+		return fmt.Errorf("%s: failed to delete secret %w", op, err)
+	}
+
+	if path.Valid {
+		if err = external_storage.RemoveFiles([]string{path.String}); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+func queryCollectionSecrets(
+	ctx context.Context,
+	db *sql.DB,
+	collectionID int,
+	nameLookups []string,
+) ([]*secret.Secret, error) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+
+	query := `
+		SELECT id, name, type, created_at, updated_at, version, client_encrypted,
+		       collection_id, key_version, COALESCE(created_by, 0), sealed_fields
+		FROM secrets WHERE collection_id = $1
+	`
+
+	if nameLookups == nil {
+		rows, err = db.QueryContext(ctx, query, collectionID)
+	} else {
+		rows, err = db.QueryContext(ctx, query+` AND (name = ANY($2) OR name_index = ANY($2))`, collectionID, nameLookups)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query collection secrets %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	secrets := make([]*secret.Secret, 0)
+	for rows.Next() {
+		var s secret.Secret
+		if err = rows.Scan(
+			&s.ID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version, &s.ClientEncrypted,
+			&s.CollectionID, &s.KeyVersion, &s.CreatedBy, &s.SealedFields,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row for secret with error %w", err)
+		}
+
+		secrets = append(secrets, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// lockCollectionKey блокировка коллекции от ротации ключа до конца транзакции.
+// secret.ErrKeyRotated, если текущая версия ключа уже не keyVersion.
+func lockCollectionKey(ctx context.Context, tx *sql.Tx, collectionID, keyVersion int) error {
+	var current int
+
+	err := tx.QueryRowContext(
+		ctx, `SELECT key_version FROM collections WHERE id = $1 FOR SHARE`, collectionID,
+	).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return secret.ErrCollectionNotFound
+		}
+		return fmt.Errorf("failed to lock collection %w", err)
+	}
+
+	if current != keyVersion {
+		return secret.ErrKeyRotated
+	}
+
+	return nil
+}
+
+// updateSecretKey сохранение данных секрета коллекции, перешифрованных ключом версии s.KeyVersion.
+// Содержимое файла записывается в новый файл, после чего секрет затирается.
+func updateSecretKey(ctx context.Context, tx *sql.Tx, s *secret.Secret, oldVersion int, files *fileRewrites) error {
+	var err error
+	if data, ok := s.Data.(*secret.FileData); ok {
+		err = files.rewrite(ctx, tx, s.ID, data)
+		s.Wipe()
+	} else {
+		err = updateSecretData(ctx, tx, s)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(
+		ctx, `UPDATE secrets SET key_version = $2 WHERE id = $1 AND key_version = $3`, s.ID, s.KeyVersion, oldVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update secret %d key version %w", s.ID, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return secret.ErrKeyRotated
+	}
+
+	return nil
+}
+
+// updateSecretData сохранение перешифрованных данных секрета с паролем или картой.
+func updateSecretData(ctx context.Context, tx *sql.Tx, s *secret.Secret) error {
+	var err error
+
+	switch data := s.Data.(type) {
+	case *secret.PasswordData:
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE password_data SET password_encrypted = $2, notes_encrypted = $3 WHERE secret_id = $1`,
+			s.ID, data.Pass, data.Notes,
+		)
+	case *secret.CardData:
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE card_data SET card_number_encrypted = $2, card_holder_encrypted = $3,
+			                      cvv_encrypted = $4, notes_encrypted = $5
+			WHERE secret_id = $1`,
+			s.ID, data.Number, data.Owner, data.CVV, data.Notes,
+		)
+	default:
+		return fmt.Errorf("unsupported secret type %s", s.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update secret %d data %w", s.ID, err)
+	}
+
+	return nil
+}
+
+// fileRewrites файлы, записанные при перешифровке секретов в транзакции: created новые файлы,
+// obsolete файлы, которые они заменили. Новые файлы нужны, только если транзакция закоммичена,
+// прежние, только если она откатилась.
+type fileRewrites struct {
+	created  []string
+	obsolete []string
+}
+
+// rewrite запись перешифрованного содержимого файла в новый файл и сохранение его пути.
+func (fr *fileRewrites) rewrite(ctx context.Context, tx *sql.Tx, secretID int, data *secret.FileData) error {
+	checksum, path, err := external_storage.RewriteFileData(ctx, data.Path, data.ContentReader())
+	if err != nil {
+		return err
+	}
+	fr.created = append(fr.created, path)
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE external_storage SET storage_path = $2, checksum = $3, format = $4 WHERE secret_id = $1`,
+		secretID, path, checksum, data.Format,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update file %w", err)
+	}
+	fr.obsolete = append(fr.obsolete, data.Path)
+
+	return nil
+}
+
+// finish удаление файлов, которые не нужны после окончания транзакции.
+func (fr *fileRewrites) finish(committed bool) error {
+	unused := fr.created
+	if committed {
+		unused = fr.obsolete
+	}
+	fr.created, fr.obsolete = nil, nil
+
+	return external_storage.RemoveFiles(unused)
+}
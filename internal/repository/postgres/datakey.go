@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/datakey"
+)
+
+// DataKeyRepository репозиторий ключей данных пользователей.
+type DataKeyRepository struct {
+	db *sql.DB
+}
+
+// NewDataKeyRepository получение репозитория ключей данных.
+func NewDataKeyRepository(db *sql.DB) *DataKeyRepository {
+	return &DataKeyRepository{db: db}
+}
+
+// Get ключ данных пользователя.
+func (dr *DataKeyRepository) Get(ctx context.Context, userID int) (*datakey.DataKey, error) {
+	op := "repository.Postgres.DataKey.Get"
+
+	query := `
+		SELECT user_id, provider, wrapped_key, created_at, rotated_at
+		FROM user_data_keys WHERE user_id = $1
+	`
+
+	k, err := scanDataKey(dr.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datakey.ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return k, nil
+}
+
+// Create сохранение нового ключа данных, уже сохраненный ключ пользователя не перезаписывается.
+func (dr *DataKeyRepository) Create(ctx context.Context, k *datakey.DataKey) error {
+	if err := insertDataKey(ctx, dr.db, k); err != nil {
+		return fmt.Errorf("repository.Postgres.DataKey.Create: %w", err)
+	}
+
+	return nil
+}
+
+// ListByProvider ключи данных, обернутые провайдером provider.
+func (dr *DataKeyRepository) ListByProvider(ctx context.Context, provider string) ([]*datakey.DataKey, error) {
+	op := "repository.Postgres.DataKey.ListByProvider"
+
+	query := `
+		SELECT user_id, provider, wrapped_key, created_at, rotated_at
+		FROM user_data_keys WHERE provider = $1 ORDER BY user_id
+	`
+
+	rows, err := dr.db.QueryContext(ctx, query, provider)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var keys []*datakey.DataKey
+	for rows.Next() {
+		k, err := scanDataKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan data key %w", op, err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// Update сохранение переобернутого ключа данных.
+func (dr *DataKeyRepository) Update(ctx context.Context, k *datakey.DataKey) error {
+	op := "repository.Postgres.DataKey.Update"
+
+	query := `UPDATE user_data_keys SET provider = $2, wrapped_key = $3, rotated_at = $4 WHERE user_id = $1`
+
+	if _, err := dr.db.ExecContext(ctx, query, k.UserID, k.Provider, k.WrappedKey, k.RotatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// insertDataKey сохранение ключа данных, если у пользователя его еще нет.
+func insertDataKey(ctx context.Context, db execer, k *datakey.DataKey) error {
+	query := `
+		INSERT INTO user_data_keys (user_id, provider, wrapped_key, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+
+	if _, err := db.ExecContext(ctx, query, k.UserID, k.Provider, k.WrappedKey, k.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert data key %w", err)
+	}
+
+	return nil
+}
+
+func scanDataKey(row rowScanner) (*datakey.DataKey, error) {
+	var (
+		k         datakey.DataKey
+		rotatedAt sql.NullTime
+	)
+
+	if err := row.Scan(&k.UserID, &k.Provider, &k.WrappedKey, &k.CreatedAt, &rotatedAt); err != nil {
+		return nil, err
+	}
+	k.RotatedAt = rotatedAt.Time
+
+	return &k, nil
+}
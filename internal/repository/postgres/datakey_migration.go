@@ -0,0 +1,469 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/datakey"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/pressly/goose"
+)
+
+// dataKeyMigrationSource имя Go-миграции, которая переводит данные на ключи данных пользователей.
+// Версия идет после миграции, создающей таблицу user_data_keys.
+const dataKeyMigrationSource = "20250527090100_encrypt_with_user_keys.go"
+
+var registerDataKeyMigration sync.Once
+
+// DataKeyMigration перевод данных, зашифрованных прежним корневым ключом сервера, на ключи данных
+// пользователей: секреты, содержимое файлов, секреты аутентификаторов и копии ключей коллекций.
+// Поля секретов перешифровываются отдельным ключом шифрования полей.
+type DataKeyMigration struct {
+	legacyKey    []byte
+	legacyFields *secret.FieldEncryption
+	fields       *secret.FieldEncryption
+	kek          datakey.KeyWrapper
+
+	userKeys map[int][]byte
+	files    fileRewrites
+}
+
+// NewDataKeyMigration получение миграции. legacyKey и legacyFields прежний корневой ключ и политика полей
+// на нем, fields политика полей на новом ключе, kek провайдер, которым оборачиваются новые ключи данных.
+func NewDataKeyMigration(
+	legacyKey []byte,
+	legacyFields, fields *secret.FieldEncryption,
+	kek datakey.KeyWrapper,
+) *DataKeyMigration {
+	return &DataKeyMigration{
+		legacyKey:    legacyKey,
+		legacyFields: legacyFields,
+		fields:       fields,
+		kek:          kek,
+	}
+}
+
+// RegisterDataKeyMigration регистрация миграции m. Должна вызываться до NewConnection.
+func RegisterDataKeyMigration(m *DataKeyMigration) {
+	registerDataKeyMigration.Do(func() {
+		goose.AddNamedMigration(
+			dataKeyMigrationSource,
+			m.up,
+			// Прежний корневой ключ удаляется после миграции, поэтому вернуть данные на него нельзя
+			func(*sql.Tx) error {
+				return errors.New("data key migration is irreversible")
+			},
+		)
+	})
+}
+
+// RemoveObsoleteFiles удаление файлов, замененных перешифрованными. Вызывается после того,
+// как миграция закоммичена.
+func (m *DataKeyMigration) RemoveObsoleteFiles() error {
+	if err := m.files.finish(true); err != nil {
+		return fmt.Errorf("repository.postgres.DataKeyMigration.RemoveObsoleteFiles: %w", err)
+	}
+
+	return nil
+}
+
+// Wipe затирание прежнего корневого ключа, после которого миграция не выполняется.
+func (m *DataKeyMigration) Wipe() {
+	m.legacyFields.Wipe()
+	securemem.Wipe(m.legacyKey)
+}
+
+func (m *DataKeyMigration) up(tx *sql.Tx) error {
+	op := "repository.postgres.DataKeyMigration.up"
+
+	ctx := context.Background()
+
+	defer func() {
+		for _, key := range m.userKeys {
+			securemem.Wipe(key)
+		}
+		m.userKeys = nil
+	}()
+
+	steps := []struct {
+		name string
+		run  func(ctx context.Context, tx *sql.Tx) error
+	}{
+		{name: "create user data keys", run: m.createUserKeys},
+		{name: "reencrypt secrets", run: m.reencryptSecrets},
+		{name: "reencrypt totp secrets", run: m.reencryptTOTP},
+		{name: "rewrap collection keys", run: m.rewrapCollectionKeys},
+		{name: "reseal secret fields", run: m.resealFields},
+	}
+
+	for _, step := range steps {
+		if err := step.run(ctx, tx); err != nil {
+			// Транзакция откатывается, поэтому новые файлы не нужны, а прежние остаются на месте
+			_ = m.files.finish(false)
+			return fmt.Errorf("%s: %s: %w", op, step.name, err)
+		}
+	}
+
+	return nil
+}
+
+// createUserKeys генерация ключей данных всех пользователей.
+func (m *DataKeyMigration) createUserKeys(ctx context.Context, tx *sql.Tx) error {
+	ids, err := queryIDs(ctx, tx, `SELECT id FROM users`)
+	if err != nil {
+		return err
+	}
+
+	m.userKeys = make(map[int][]byte, len(ids))
+	for _, id := range ids {
+		k, key, err := datakey.NewDataKey(ctx, m.kek, id)
+		if err != nil {
+			return fmt.Errorf("user %d: %w", id, err)
+		}
+		m.userKeys[id] = key
+
+		if err = insertDataKey(ctx, tx, k); err != nil {
+			return fmt.Errorf("user %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// reencryptSecrets перешифровка данных личных секретов, зашифрованных на сервере. Содержимое файлов
+// записывается в новые файлы, прежние удаляются через RemoveObsoleteFiles.
+func (m *DataKeyMigration) reencryptSecrets(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(
+		ctx, `SELECT id, user_id, type FROM secrets WHERE user_id IS NOT NULL AND NOT client_encrypted`,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query secrets %w", err)
+	}
+
+	var secrets []*secret.Secret
+	for rows.Next() {
+		var s secret.Secret
+		if err = rows.Scan(&s.ID, &s.UserID, &s.Type); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan secret %w", err)
+		}
+		secrets = append(secrets, &s)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	for _, s := range secrets {
+		if err = m.reencryptSecret(ctx, tx, s); err != nil {
+			return fmt.Errorf("secret %d: %w", s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *DataKeyMigration) reencryptSecret(ctx context.Context, tx *sql.Tx, s *secret.Secret) error {
+	if err := loadSecretsData(ctx, tx, []*secret.Secret{s}); err != nil {
+		return err
+	}
+	defer s.Wipe()
+
+	// Секрет затирает ключ, которым зашифрован, поэтому получает свои копии ключей
+	oldKey, newKey := slices.Clone(m.legacyKey), slices.Clone(m.userKeys[s.UserID])
+	err := s.Reencrypt(oldKey, newKey)
+	securemem.Wipe(oldKey)
+	if err != nil {
+		securemem.Wipe(newKey)
+		return err
+	}
+
+	data, ok := s.Data.(*secret.FileData)
+	if !ok {
+		return updateSecretData(ctx, tx, s)
+	}
+
+	return m.files.rewrite(ctx, tx, s.ID, data)
+}
+
+// reencryptTOTP перешифровка секретов аутентификаторов.
+func (m *DataKeyMigration) reencryptTOTP(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT user_id, secret FROM user_totp`)
+	if err != nil {
+		return fmt.Errorf("failed to query totp %w", err)
+	}
+
+	sealed := make(map[int]string)
+	for rows.Next() {
+		var (
+			userID int
+			value  string
+		)
+		if err = rows.Scan(&userID, &value); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan totp %w", err)
+		}
+		sealed[userID] = value
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	for userID, value := range sealed {
+		opened, err := encryptor.DecryptToBuffer([]byte(value), m.legacyKey)
+		if err != nil {
+			return fmt.Errorf("user %d: failed to decrypt totp secret %w", userID, err)
+		}
+
+		value, err = encryptor.EncryptWithMasterKey(opened.Bytes(), m.userKeys[userID])
+		opened.Wipe()
+		if err != nil {
+			return fmt.Errorf("user %d: failed to encrypt totp secret %w", userID, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, `UPDATE user_totp SET secret = $2 WHERE user_id = $1`, userID, value); err != nil {
+			return fmt.Errorf("user %d: failed to update totp %w", userID, err)
+		}
+	}
+
+	return nil
+}
+
+// rewrapCollectionKeys переоборачивание копий ключей коллекций ключами, полученными из ключей данных участников.
+func (m *DataKeyMigration) rewrapCollectionKeys(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT collection_id, version, user_id, wrapped_key FROM collection_keys`)
+	if err != nil {
+		return fmt.Errorf("failed to query collection keys %w", err)
+	}
+
+	type keyRow struct {
+		collectionID, version, userID int
+		wrapped                       []byte
+	}
+
+	var keys []keyRow
+	for rows.Next() {
+		var k keyRow
+		if err = rows.Scan(&k.collectionID, &k.version, &k.userID, &k.wrapped); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan collection key %w", err)
+		}
+		keys = append(keys, k)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	for _, k := range keys {
+		wrapped, err := rewrapMemberKey(k.wrapped, m.legacyKey, m.userKeys[k.userID], k.userID)
+		if err != nil {
+			return fmt.Errorf("collection %d user %d: %w", k.collectionID, k.userID, err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE collection_keys SET wrapped_key = $4 WHERE collection_id = $1 AND version = $2 AND user_id = $3`,
+			k.collectionID, k.version, k.userID, wrapped,
+		)
+		if err != nil {
+			return fmt.Errorf("collection %d user %d: failed to update key %w", k.collectionID, k.userID, err)
+		}
+	}
+
+	return nil
+}
+
+// rewrapMemberKey переоборачивание копии ключа коллекции участника userID.
+func rewrapMemberKey(wrapped, oldKey, newKey []byte, userID int) ([]byte, error) {
+	oldKEK, err := encryptor.MemberKey(oldKey, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive member key %w", err)
+	}
+	defer securemem.Wipe(oldKEK)
+
+	newKEK, err := encryptor.MemberKey(newKey, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive member key %w", err)
+	}
+	defer securemem.Wipe(newKEK)
+
+	key, err := encryptor.UnwrapKey(wrapped, oldKEK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap collection key %w", err)
+	}
+	defer securemem.Wipe(key)
+
+	rewrapped, err := encryptor.WrapKey(key, newKEK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap collection key %w", err)
+	}
+
+	return rewrapped, nil
+}
+
+// resealFields перешифровка зашифрованных полей секретов ключом шифрования полей.
+func (m *DataKeyMigration) resealFields(ctx context.Context, tx *sql.Tx) error {
+	return resealFields(ctx, tx, m.legacyFields, m.fields)
+}
+
+// resealFields перешифровка зашифрованных полей всех секретов, зашифрованных политикой from,
+// политикой to.
+func resealFields(ctx context.Context, tx *sql.Tx, from, to *secret.FieldEncryption) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, COALESCE(user_id, 0), COALESCE(collection_id, 0), name, sealed_fields
+		FROM secrets WHERE sealed_fields <> 0
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query secrets %w", err)
+	}
+
+	secrets := make(map[int]*secret.Secret)
+	for rows.Next() {
+		var s secret.Secret
+		if err = rows.Scan(&s.ID, &s.UserID, &s.CollectionID, &s.Name, &s.SealedFields); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan secret %w", err)
+		}
+		secrets[s.ID] = &s
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	for _, s := range secrets {
+		if s.Name, s.NameIndex, err = to.Reseal(from, s, secret.FieldName, s.Name); err != nil {
+			return fmt.Errorf("secret %d: %w", s.ID, err)
+		}
+	}
+
+	for _, t := range dataFieldTables {
+		if err = resealTable(ctx, tx, t, secrets, from, to); err != nil {
+			return fmt.Errorf("table %s: %w", t.table, err)
+		}
+	}
+
+	for _, s := range secrets {
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE secrets SET name = $2, name_index = NULLIF($3, ''), sealed_fields = $4 WHERE id = $1`,
+			s.ID, s.Name, s.NameIndex, s.SealedFields,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update secret %d %w", s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// resealTable перешифровка зашифрованных полей одной таблицы с данными секретов.
+func resealTable(
+	ctx context.Context,
+	tx *sql.Tx,
+	t fieldTable,
+	secrets map[int]*secret.Secret,
+	from, to *secret.FieldEncryption,
+) error {
+	columns := make([]string, 0, len(t.columns))
+	assignments := make([]string, 0, len(t.columns))
+	for i, c := range t.columns {
+		columns = append(columns, "t."+c.name)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", c.name, i+2))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.%s, t.secret_id, %s
+		FROM %s t JOIN secrets s ON s.id = t.secret_id
+		WHERE s.sealed_fields <> 0
+	`, t.key, strings.Join(columns, ", "), t.table)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query rows %w", err)
+	}
+
+	var (
+		fieldRows []fieldRow
+		secretIDs []int
+	)
+	for rows.Next() {
+		var secretID int
+		r := fieldRow{values: make([]sql.NullString, len(t.columns))}
+		dest := []any{&r.key, &secretID}
+		for i := range r.values {
+			dest = append(dest, &r.values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan row %w", err)
+		}
+		fieldRows = append(fieldRows, r)
+		secretIDs = append(secretIDs, secretID)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	update := fmt.Sprintf(`UPDATE %s SET %s WHERE %s = $1`, t.table, strings.Join(assignments, ", "), t.key)
+
+	for i, r := range fieldRows {
+		s := secrets[secretIDs[i]]
+
+		args := []any{r.key}
+		for j, c := range t.columns {
+			if !r.values[j].Valid {
+				args = append(args, nil)
+				continue
+			}
+
+			value, _, err := to.Reseal(from, s, c.field, r.values[j].String)
+			if err != nil {
+				return fmt.Errorf("row %d column %s: %w", r.key, c.name, err)
+			}
+			args = append(args, value)
+		}
+
+		if _, err = tx.ExecContext(ctx, update, args...); err != nil {
+			return fmt.Errorf("failed to update row %d %w", r.key, err)
+		}
+	}
+
+	return nil
+}
+
+// queryIDs чтение идентификаторов, возвращаемых запросом.
+func queryIDs(ctx context.Context, tx *sql.Tx, query string) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ids %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan id %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/emergency"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// EmergencyRepository репозиторий доверенных контактов и журнала экстренного доступа.
+type EmergencyRepository struct {
+	db *sql.DB
+}
+
+// NewEmergencyRepository получение репозитория экстренного доступа.
+func NewEmergencyRepository(db *sql.DB) *EmergencyRepository {
+	return &EmergencyRepository{db: db}
+}
+
+// execer общий интерфейс *sql.DB и *sql.Tx для записи журнала.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Create сохранение контакта вместе с записью журнала.
+func (er *EmergencyRepository) Create(ctx context.Context, c *emergency.Contact, ev *emergency.Event) error {
+	op := "repository.Postgres.Emergency.Create"
+
+	tx, err := er.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO emergency_contacts
+		    (grantor_id, grantee_id, access_type, wait_seconds, status, wrapped_vault_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	err = tx.QueryRowContext(
+		ctx, query, c.GrantorID, c.GranteeID, c.Type, int64(c.WaitPeriod/time.Second), c.Status,
+		c.WrappedVaultKey, c.CreatedAt, c.UpdatedAt,
+	).Scan(&c.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = emergency.ErrAlreadyNominated
+			return err
+		}
+		return fmt.Errorf("%s: failed to insert contact %w", op, err)
+	}
+
+	ev.ContactID = c.ID
+	if err = addEmergencyEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const contactColumns = `
+	c.id, c.grantor_id, c.grantee_id, gr.login, ge.login, c.access_type, c.wait_seconds, c.status,
+	c.wrapped_vault_key, c.requested_at, c.decided_at, c.created_at, c.updated_at
+`
+
+const contactFrom = `
+	FROM emergency_contacts c
+	JOIN users gr ON gr.id = c.grantor_id
+	JOIN users ge ON ge.id = c.grantee_id
+`
+
+// scanContact чтение контакта из строки с колонками contactColumns.
+func scanContact(row rowScanner) (*emergency.Contact, error) {
+	var (
+		c                      emergency.Contact
+		waitSeconds            int64
+		requestedAt, decidedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&c.ID, &c.GrantorID, &c.GranteeID, &c.GrantorLogin, &c.GranteeLogin, &c.Type, &waitSeconds, &c.Status,
+		&c.WrappedVaultKey, &requestedAt, &decidedAt, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.WaitPeriod = time.Duration(waitSeconds) * time.Second
+	c.RequestedAt, c.DecidedAt = requestedAt.Time, decidedAt.Time
+
+	return &c, nil
+}
+
+// Get контакт по ID.
+func (er *EmergencyRepository) Get(ctx context.Context, id int) (*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + contactFrom + `WHERE c.id = $1`
+
+	c, err := scanContact(er.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, emergency.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.Postgres.Emergency.Get: %w", err)
+	}
+
+	return c, nil
+}
+
+// ListByGrantor доверенные контакты владельца.
+func (er *EmergencyRepository) ListByGrantor(ctx context.Context, grantorID int) ([]*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + contactFrom + `WHERE c.grantor_id = $1 ORDER BY ge.login`
+
+	return er.listContacts(ctx, "repository.Postgres.Emergency.ListByGrantor", query, grantorID)
+}
+
+// ListByGrantee назначения пользователя доверенным контактом.
+func (er *EmergencyRepository) ListByGrantee(ctx context.Context, granteeID int) ([]*emergency.Contact, error) {
+	query := `SELECT ` + contactColumns + contactFrom + `WHERE c.grantee_id = $1 ORDER BY gr.login`
+
+	return er.listContacts(ctx, "repository.Postgres.Emergency.ListByGrantee", query, granteeID)
+}
+
+// listContacts контакты по запросу с одним параметром.
+func (er *EmergencyRepository) listContacts(
+	ctx context.Context,
+	op, query string,
+	userID int,
+) ([]*emergency.Contact, error) {
+	rows, err := er.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	contacts := make([]*emergency.Contact, 0)
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan contact row %w", op, err)
+		}
+
+		contacts = append(contacts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return contacts, nil
+}
+
+// Transition смена состояния контакта с проверкой прежнего состояния и запись журнала в одной транзакции.
+func (er *EmergencyRepository) Transition(
+	ctx context.Context,
+	c *emergency.Contact,
+	from emergency.Status,
+	ev *emergency.Event,
+) error {
+	op := "repository.Postgres.Emergency.Transition"
+
+	tx, err := er.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		UPDATE emergency_contacts SET status = $1, requested_at = $2, decided_at = $3, updated_at = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	var res sql.Result
+	res, err = tx.ExecContext(
+		ctx, query, c.Status, nullTime(c.RequestedAt), nullTime(c.DecidedAt), c.UpdatedAt, c.ID, from,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = emergency.ErrStateChanged
+		return err
+	}
+
+	if err = addEmergencyEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Delete удаление контакта и запись журнала в одной транзакции.
+func (er *EmergencyRepository) Delete(ctx context.Context, c *emergency.Contact, ev *emergency.Event) error {
+	op := "repository.Postgres.Emergency.Delete"
+
+	tx, err := er.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM emergency_contacts WHERE id = $1`, c.ID); err != nil {
+		return fmt.Errorf("%s: failed to delete contact %w", op, err)
+	}
+
+	// Запись журнала не ссылается на удаленный контакт
+	ev.ContactID = 0
+	if err = addEmergencyEvent(ctx, tx, ev); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddEvent запись журнала.
+func (er *EmergencyRepository) AddEvent(ctx context.Context, ev *emergency.Event) error {
+	if err := addEmergencyEvent(ctx, er.db, ev); err != nil {
+		return fmt.Errorf("repository.Postgres.Emergency.AddEvent: %w", err)
+	}
+
+	return nil
+}
+
+// addEmergencyEvent вставка записи журнала, нулевые ContactID и ActorID сохраняются как NULL.
+func addEmergencyEvent(ctx context.Context, db execer, ev *emergency.Event) error {
+	query := `
+		INSERT INTO emergency_access_events (contact_id, grantor_id, grantee_id, actor_id, action, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := db.ExecContext(
+		ctx, query, nullID(ev.ContactID), ev.GrantorID, ev.GranteeID, nullID(ev.ActorID), ev.Action, ev.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert event %w", err)
+	}
+
+	return nil
+}
+
+// ListEvents журнал событий, где пользователь владелец или контакт.
+func (er *EmergencyRepository) ListEvents(ctx context.Context, userID int) ([]*emergency.Event, error) {
+	op := "repository.Postgres.Emergency.ListEvents"
+
+	query := `
+		SELECT e.id, e.contact_id, e.grantor_id, e.grantee_id, e.actor_id,
+		       gr.login, ge.login, a.login, e.action, e.created_at
+		FROM emergency_access_events e
+		JOIN users gr ON gr.id = e.grantor_id
+		LEFT JOIN users ge ON ge.id = e.grantee_id
+		LEFT JOIN users a ON a.id = e.actor_id
+		WHERE e.grantor_id = $1 OR e.grantee_id = $1
+		ORDER BY e.created_at DESC, e.id DESC
+	`
+
+	rows, err := er.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	events := make([]*emergency.Event, 0)
+	for rows.Next() {
+		var (
+			ev                            emergency.Event
+			contactID, granteeID, actorID sql.NullInt64
+			granteeLogin, actorLogin      sql.NullString
+		)
+
+		err = rows.Scan(
+			&ev.ID, &contactID, &ev.GrantorID, &granteeID, &actorID,
+			&ev.GrantorLogin, &granteeLogin, &actorLogin, &ev.Action, &ev.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row %w", op, err)
+		}
+		ev.ContactID, ev.GranteeID, ev.ActorID = int(contactID.Int64), int(granteeID.Int64), int(actorID.Int64)
+		ev.GranteeLogin, ev.ActorLogin = granteeLogin.String, actorLogin.String
+
+		events = append(events, &ev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// nullTime NULL для нулевого времени.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullID NULL для нулевого ID.
+func nullID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
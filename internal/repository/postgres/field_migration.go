@@ -0,0 +1,379 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/pressly/goose"
+)
+
+// fieldMigrationSource имя Go-миграции, по нему goose определяет ее версию.
+// Версия идет после миграции, добавляющей secrets.name_index.
+const fieldMigrationSource = "20250425100000_encrypt_plain_fields.go"
+
+// sealedFieldsMigrationSource имя Go-миграции, которая заполняет secrets.sealed_fields.
+// Версия идет после миграции, добавляющей колонку.
+const sealedFieldsMigrationSource = "20250525090100_track_sealed_fields.go"
+
+// fieldScopeMigrationSource имя Go-миграции, которая перешифровывает поля в режиме randomized,
+// сохраненные до того, как шифротекст стал связан с полем и владельцем.
+// Версия идет после перехода на ключи данных пользователей, который использует прежнюю политику полей.
+const fieldScopeMigrationSource = "20250531090100_bind_field_scope.go"
+
+var (
+	registerFieldMigration      sync.Once
+	registerFieldScopeMigration sync.Once
+)
+
+// fieldColumn колонка таблицы и поле политики шифрования, которое в ней хранится.
+type fieldColumn struct {
+	name  string
+	field secret.Field
+}
+
+// fieldTable таблица с данными секретов, поля которой шифруются по политике.
+type fieldTable struct {
+	table   string
+	key     string
+	columns []fieldColumn
+}
+
+// dataFieldTables поля данных секретов, которые до введения политики хранились в открытом виде.
+var dataFieldTables = []fieldTable{
+	{
+		table: "password_data",
+		key:   "secret_id",
+		columns: []fieldColumn{
+			{name: "username", field: secret.FieldPasswordUsername},
+			{name: "url", field: secret.FieldPasswordURL},
+			{name: "metadata", field: secret.FieldMetaData},
+		},
+	},
+	{
+		table: "card_data",
+		key:   "secret_id",
+		columns: []fieldColumn{
+			{name: "expiry_date_encrypted", field: secret.FieldCardExpireDate},
+			{name: "metadata", field: secret.FieldMetaData},
+		},
+	},
+	{
+		table: "external_storage",
+		key:   "id",
+		columns: []fieldColumn{
+			{name: "filename", field: secret.FieldFileName},
+		},
+	},
+}
+
+// fieldTransform преобразование значения поля (шифрование или расшифровка).
+type fieldTransform func(field secret.Field, userID int, value string) (string, error)
+
+// fieldRow строка таблицы с шифруемыми полями.
+type fieldRow struct {
+	key    int
+	userID int
+	values []sql.NullString
+}
+
+// RegisterFieldEncryptionMigration регистрация миграций, которые шифруют по политике поля
+// уже сохраненных секретов и отмечают зашифрованные поля. Должна вызываться до NewConnection.
+func RegisterFieldEncryptionMigration(fe *secret.FieldEncryption) {
+	registerFieldMigration.Do(func() {
+		seal := func(field secret.Field, userID int, value string) (string, error) {
+			if field == secret.FieldMetaData {
+				sealed, err := fe.SealMetaData(userID, []byte(value))
+				return string(sealed), err
+			}
+			sealed, _, err := fe.SealValue(field, userID, value)
+			return sealed, err
+		}
+		// Откат выполняется уже без secrets.sealed_fields, поэтому зашифрованные поля определяются по значению
+		open := func(field secret.Field, userID int, value string) (string, error) {
+			var sealed secret.SealedFields
+			if fe.DetectSealed(field, &secret.Secret{UserID: userID}, value) {
+				sealed = sealed.With(field)
+			}
+			if field == secret.FieldMetaData {
+				opened, err := fe.OpenMetaData(userID, []byte(value), sealed)
+				return string(opened), err
+			}
+			return fe.OpenValue(field, userID, value, sealed)
+		}
+
+		goose.AddNamedMigration(
+			fieldMigrationSource,
+			func(tx *sql.Tx) error {
+				return migrateFields(tx, seal, func(userID int, name string) (string, string, error) {
+					return fe.SealValue(secret.FieldName, userID, name)
+				})
+			},
+			func(tx *sql.Tx) error {
+				return migrateFields(tx, open, func(userID int, name string) (string, string, error) {
+					opened, err := open(secret.FieldName, userID, name)
+					return opened, "", err
+				})
+			},
+		)
+
+		goose.AddNamedMigration(
+			sealedFieldsMigrationSource,
+			func(tx *sql.Tx) error {
+				return trackSealedFields(tx, fe)
+			},
+			// Колонку удаляет предыдущая SQL-миграция
+			func(*sql.Tx) error {
+				return nil
+			},
+		)
+	})
+}
+
+// RegisterFieldScopeMigration регистрация миграции, которая перешифровывает политикой fe поля,
+// зашифрованные без связи с полем и владельцем. Должна вызываться до NewConnection.
+func RegisterFieldScopeMigration(fe *secret.FieldEncryption) {
+	registerFieldScopeMigration.Do(func() {
+		goose.AddNamedMigration(
+			fieldScopeMigrationSource,
+			func(tx *sql.Tx) error {
+				if err := resealFields(context.Background(), tx, fe, fe); err != nil {
+					return fmt.Errorf("repository.postgres.bindFieldScope: %w", err)
+				}
+				return nil
+			},
+			// Прежний формат только читается, поэтому откатывать нечего
+			func(*sql.Tx) error {
+				return nil
+			},
+		)
+	})
+}
+
+// migrateFields преобразование названий всех секретов и полей данных секретов,
+// зашифрованных на сервере. Данные секретов, зашифрованных клиентом, не трогаются.
+func migrateFields(
+	tx *sql.Tx,
+	transform fieldTransform,
+	transformName func(userID int, name string) (string, string, error),
+) error {
+	op := "repository.postgres.migrateFields"
+
+	if err := migrateSecretNames(tx, transformName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, t := range dataFieldTables {
+		if err := migrateTable(tx, t, transform); err != nil {
+			return fmt.Errorf("%s: table %s: %w", op, t.table, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateSecretNames преобразование названий секретов и их слепых индексов.
+func migrateSecretNames(tx *sql.Tx, transform func(userID int, name string) (string, string, error)) error {
+	rows, err := tx.Query(`SELECT id, user_id, name FROM secrets`)
+	if err != nil {
+		return fmt.Errorf("failed to query secrets %w", err)
+	}
+
+	type nameRow struct {
+		id, userID int
+		name       string
+	}
+
+	var names []nameRow
+	for rows.Next() {
+		var r nameRow
+		if err = rows.Scan(&r.id, &r.userID, &r.name); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan secret name %w", err)
+		}
+		names = append(names, r)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	for _, r := range names {
+		name, index, err := transform(r.userID, r.name)
+		if err != nil {
+			return fmt.Errorf("secret %d: %w", r.id, err)
+		}
+
+		_, err = tx.Exec(`UPDATE secrets SET name = $1, name_index = NULLIF($2, '') WHERE id = $3`, name, index, r.id)
+		if err != nil {
+			return fmt.Errorf("failed to update secret %d name %w", r.id, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateTable преобразование шифруемых полей одной таблицы с данными секретов.
+func migrateTable(tx *sql.Tx, t fieldTable, transform fieldTransform) error {
+	columns := make([]string, 0, len(t.columns))
+	assignments := make([]string, 0, len(t.columns))
+	for i, c := range t.columns {
+		columns = append(columns, "t."+c.name)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", c.name, i+2))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.%s, s.user_id, %s
+		FROM %s t JOIN secrets s ON s.id = t.secret_id
+		WHERE NOT s.client_encrypted
+	`, t.key, strings.Join(columns, ", "), t.table)
+
+	rows, err := tx.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to query rows %w", err)
+	}
+
+	var fieldRows []fieldRow
+	for rows.Next() {
+		r := fieldRow{values: make([]sql.NullString, len(t.columns))}
+		dest := []any{&r.key, &r.userID}
+		for i := range r.values {
+			dest = append(dest, &r.values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan row %w", err)
+		}
+		fieldRows = append(fieldRows, r)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	update := fmt.Sprintf(`UPDATE %s SET %s WHERE %s = $1`, t.table, strings.Join(assignments, ", "), t.key)
+
+	for _, r := range fieldRows {
+		args := []any{r.key}
+		for i, c := range t.columns {
+			if !r.values[i].Valid {
+				args = append(args, nil)
+				continue
+			}
+
+			value, err := transform(c.field, r.userID, r.values[i].String)
+			if err != nil {
+				return fmt.Errorf("row %d column %s: %w", r.key, c.name, err)
+			}
+			args = append(args, value)
+		}
+
+		if _, err = tx.Exec(update, args...); err != nil {
+			return fmt.Errorf("failed to update row %d %w", r.key, err)
+		}
+	}
+
+	return nil
+}
+
+// trackSealedFields заполнение secrets.sealed_fields для секретов, сохраненных до появления колонки.
+func trackSealedFields(tx *sql.Tx, fe *secret.FieldEncryption) error {
+	op := "repository.postgres.trackSealedFields"
+
+	rows, err := tx.Query(`SELECT id, COALESCE(user_id, 0), COALESCE(collection_id, 0), name FROM secrets`)
+	if err != nil {
+		return fmt.Errorf("%s: failed to query secrets %w", op, err)
+	}
+
+	secrets := make(map[int]*secret.Secret)
+	for rows.Next() {
+		var s secret.Secret
+		if err = rows.Scan(&s.ID, &s.UserID, &s.CollectionID, &s.Name); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("%s: failed to scan secret %w", op, err)
+		}
+		if fe.DetectSealed(secret.FieldName, &s, s.Name) {
+			s.SealedFields = s.SealedFields.With(secret.FieldName)
+		}
+		secrets[s.ID] = &s
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	for _, t := range dataFieldTables {
+		if err = detectTableSealedFields(tx, fe, t, secrets); err != nil {
+			return fmt.Errorf("%s: table %s: %w", op, t.table, err)
+		}
+	}
+
+	for _, s := range secrets {
+		if s.SealedFields == 0 {
+			continue
+		}
+		if _, err = tx.Exec(`UPDATE secrets SET sealed_fields = $2 WHERE id = $1`, s.ID, s.SealedFields); err != nil {
+			return fmt.Errorf("%s: failed to update secret %d %w", op, s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// detectTableSealedFields отметка зашифрованных полей одной таблицы с данными секретов.
+func detectTableSealedFields(
+	tx *sql.Tx,
+	fe *secret.FieldEncryption,
+	t fieldTable,
+	secrets map[int]*secret.Secret,
+) error {
+	columns := make([]string, 0, len(t.columns))
+	for _, c := range t.columns {
+		columns = append(columns, "t."+c.name)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.secret_id, %s
+		FROM %s t JOIN secrets s ON s.id = t.secret_id
+		WHERE NOT s.client_encrypted
+	`, strings.Join(columns, ", "), t.table)
+
+	rows, err := tx.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to query rows %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var secretID int
+		values := make([]sql.NullString, len(t.columns))
+		dest := []any{&secretID}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan row %w", err)
+		}
+
+		s, ok := secrets[secretID]
+		if !ok {
+			continue
+		}
+		for i, c := range t.columns {
+			if values[i].Valid && fe.DetectSealed(c.field, s, values[i].String) {
+				s.SealedFields = s.SealedFields.With(c.field)
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("got rows.Err: %w", err)
+	}
+
+	return nil
+}
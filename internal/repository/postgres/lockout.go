@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+)
+
+// LockoutRepository репозиторий счетчиков неудачных попыток входа.
+type LockoutRepository struct {
+	db *sql.DB
+}
+
+// NewLockoutRepository получение репозитория счетчиков попыток.
+func NewLockoutRepository(db *sql.DB) *LockoutRepository {
+	return &LockoutRepository{db: db}
+}
+
+const attemptColumns = `key, failures, last_failure_at, blocked_until, locked`
+
+func scanAttempt(row rowScanner) (*lockout.Attempt, error) {
+	var (
+		a            lockout.Attempt
+		blockedUntil sql.NullTime
+	)
+
+	if err := row.Scan(&a.Key, &a.Failures, &a.LastFailureAt, &blockedUntil, &a.Locked); err != nil {
+		return nil, fmt.Errorf("failed to scan login attempt row %w", err)
+	}
+	a.BlockedUntil = blockedUntil.Time
+
+	return &a, nil
+}
+
+// Get получение счетчиков по ключам.
+func (lr *LockoutRepository) Get(ctx context.Context, keys []string) ([]*lockout.Attempt, error) {
+	op := "repository.Postgres.Lockout.Get"
+
+	query := `SELECT ` + attemptColumns + ` FROM login_attempts WHERE key = ANY($1)`
+
+	return lr.query(ctx, op, query, keys)
+}
+
+// RecordFailure увеличение счетчика неудач одним запросом, чтобы параллельные попытки не терялись.
+func (lr *LockoutRepository) RecordFailure(
+	ctx context.Context,
+	key string,
+	now, staleBefore time.Time,
+) (int, error) {
+	op := "repository.Postgres.Lockout.RecordFailure"
+
+	query := `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+		                                failures = CASE
+		                                    WHEN login_attempts.last_failure_at < $3 THEN 1
+		                                    ELSE login_attempts.failures + 1
+		                                END,
+		                                last_failure_at = EXCLUDED.last_failure_at,
+		                                locked = login_attempts.locked AND login_attempts.last_failure_at >= $3
+		RETURNING failures
+	`
+
+	var failures int
+	if err := lr.db.QueryRowContext(ctx, query, key, now, staleBefore).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failures, nil
+}
+
+// Block запрет попыток по ключу до until.
+func (lr *LockoutRepository) Block(ctx context.Context, key string, until time.Time, locked bool) error {
+	op := "repository.Postgres.Lockout.Block"
+
+	query := `UPDATE login_attempts SET blocked_until = $1, locked = $2 WHERE key = $3`
+
+	if _, err := lr.db.ExecContext(ctx, query, until, locked, key); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Reset удаление счетчика.
+func (lr *LockoutRepository) Reset(ctx context.Context, key string) error {
+	op := "repository.Postgres.Lockout.Reset"
+
+	res, err := lr.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return lockout.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListBlocked счетчики с действующим запретом попыток.
+func (lr *LockoutRepository) ListBlocked(ctx context.Context, now time.Time) ([]*lockout.Attempt, error) {
+	op := "repository.Postgres.Lockout.ListBlocked"
+
+	query := `
+		SELECT ` + attemptColumns + ` FROM login_attempts
+		WHERE blocked_until > $1
+		ORDER BY blocked_until DESC
+	`
+
+	return lr.query(ctx, op, query, now)
+}
+
+func (lr *LockoutRepository) query(ctx context.Context, op, query string, args ...any) ([]*lockout.Attempt, error) {
+	rows, err := lr.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var attempts []*lockout.Attempt
+	for rows.Next() {
+		a, err := scanAttempt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		attempts = append(attempts, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attempts, nil
+}
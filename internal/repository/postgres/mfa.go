@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/mfa"
+)
+
+// MFARepository репозиторий второго фактора.
+type MFARepository struct {
+	db *sql.DB
+}
+
+// NewMFARepository получение репозитория второго фактора.
+func NewMFARepository(db *sql.DB) *MFARepository {
+	return &MFARepository{db: db}
+}
+
+// GetTOTP получение аутентификатора пользователя.
+func (mr *MFARepository) GetTOTP(ctx context.Context, userID int) (*mfa.TOTP, error) {
+	op := "repository.Postgres.MFA.GetTOTP"
+
+	query := `
+		SELECT user_id, secret, last_step, confirmed_at, created_at
+		FROM user_totp WHERE user_id = $1
+	`
+
+	var (
+		t           mfa.TOTP
+		confirmedAt sql.NullTime
+	)
+
+	err := mr.db.QueryRowContext(ctx, query, userID).Scan(
+		&t.UserID, &t.Secret, &t.LastStep, &confirmedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, mfa.ErrNotEnrolled
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	t.ConfirmedAt = confirmedAt.Time
+
+	return &t, nil
+}
+
+// SaveTOTP сохранение неподтвержденного аутентификатора. Подтвержденный не перезаписывается.
+func (mr *MFARepository) SaveTOTP(ctx context.Context, t *mfa.TOTP) error {
+	op := "repository.Postgres.MFA.SaveTOTP"
+
+	query := `
+		INSERT INTO user_totp (user_id, secret, last_step, created_at)
+		VALUES ($1, $2, 0, $3)
+		ON CONFLICT (user_id) DO UPDATE SET
+		                                    secret = EXCLUDED.secret,
+		                                    last_step = 0,
+		                                    created_at = EXCLUDED.created_at
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	res, err := mr.db.ExecContext(ctx, query, t.UserID, t.Secret, t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return mfa.ErrAlreadyEnrolled
+	}
+
+	return nil
+}
+
+// Confirm подтверждение аутентификатора и выпуск новых кодов восстановления в одной транзакции.
+func (mr *MFARepository) Confirm(
+	ctx context.Context,
+	userID int,
+	step int64,
+	at time.Time,
+	recoveryHashes []string,
+) error {
+	op := "repository.Postgres.MFA.Confirm"
+
+	var (
+		tx  *sql.Tx
+		err error
+	)
+
+	tx, err = mr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+		UPDATE user_totp SET confirmed_at = $1, last_step = $2
+		WHERE user_id = $3 AND confirmed_at IS NULL
+	`
+
+	var res sql.Result
+	res, err = tx.ExecContext(ctx, query, at, step, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = mfa.ErrAlreadyEnrolled
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("%s: failed to delete old recovery codes %w", op, err)
+	}
+
+	for _, hash := range recoveryHashes {
+		query = `INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`
+		if _, err = tx.ExecContext(ctx, query, userID, hash); err != nil {
+			return fmt.Errorf("%s: failed to save recovery code %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// UseStep запись шага принятого кода с проверкой, что его не принял параллельный запрос.
+func (mr *MFARepository) UseStep(ctx context.Context, userID int, lastStep, step int64) error {
+	op := "repository.Postgres.MFA.UseStep"
+
+	query := `UPDATE user_totp SET last_step = $1 WHERE user_id = $2 AND last_step = $3`
+
+	res, err := mr.db.ExecContext(ctx, query, step, userID, lastStep)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return mfa.ErrInvalidCode
+	}
+
+	return nil
+}
+
+// UseChallenge запись погашенного токена второго шага входа. Первичный ключ не дает
+// погасить один токен дважды даже параллельными запросами.
+func (mr *MFARepository) UseChallenge(ctx context.Context, challengeID string, expiresAt time.Time) error {
+	op := "repository.Postgres.MFA.UseChallenge"
+
+	if _, err := mr.db.ExecContext(ctx, `DELETE FROM used_mfa_challenges WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("%s: failed to delete expired challenges %w", op, err)
+	}
+
+	query := `
+		INSERT INTO used_mfa_challenges (challenge_id, expires_at) VALUES ($1, $2)
+		ON CONFLICT (challenge_id) DO NOTHING
+	`
+
+	res, err := mr.db.ExecContext(ctx, query, challengeID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return mfa.ErrChallengeUsed
+	}
+
+	return nil
+}
+
+// UseRecoveryCode погашение кода восстановления.
+func (mr *MFARepository) UseRecoveryCode(ctx context.Context, userID int, hash string, at time.Time) error {
+	op := "repository.Postgres.MFA.UseRecoveryCode"
+
+	query := `
+		UPDATE recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+
+	res, err := mr.db.ExecContext(ctx, query, at, userID, hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return mfa.ErrInvalidCode
+	}
+
+	return nil
+}
+
+// Delete удаление аутентификатора и кодов восстановления в одной транзакции.
+func (mr *MFARepository) Delete(ctx context.Context, userID int) error {
+	op := "repository.Postgres.MFA.Delete"
+
+	var (
+		tx  *sql.Tx
+		err error
+	)
+
+	tx, err = mr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("%s: failed to delete recovery codes %w", op, err)
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("%s: failed to delete authenticator %w", op, err)
+	}
+
+	return nil
+}
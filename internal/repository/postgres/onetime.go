@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+)
+
+// OneTimeShareRepository репозиторий одноразовых ссылок.
+type OneTimeShareRepository struct {
+	db *sql.DB
+}
+
+// NewOneTimeShareRepository получение репозитория одноразовых ссылок.
+func NewOneTimeShareRepository(db *sql.DB) *OneTimeShareRepository {
+	return &OneTimeShareRepository{db: db}
+}
+
+// Create сохранение новой ссылки.
+func (otr *OneTimeShareRepository) Create(ctx context.Context, sh *onetime.Share) error {
+	op := "repository.Postgres.OneTimeShare.Create"
+
+	query := `
+		INSERT INTO one_time_shares (id, owner_id, ciphertext, max_views, views_left, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := otr.db.ExecContext(
+		ctx, query, sh.ID, sh.OwnerID, sh.Ciphertext, sh.MaxViews, sh.ViewsLeft, sh.ExpiresAt, sh.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetActive ссылка, которую еще можно открыть на момент now.
+func (otr *OneTimeShareRepository) GetActive(ctx context.Context, id string, now time.Time) (*onetime.Share, error) {
+	op := "repository.Postgres.OneTimeShare.GetActive"
+
+	query := `
+		SELECT id, owner_id, ciphertext, max_views, views_left, expires_at, created_at
+		FROM one_time_shares
+		WHERE id = $1 AND views_left > 0 AND expires_at > $2
+	`
+
+	var sh onetime.Share
+	err := otr.db.QueryRowContext(ctx, query, id, now).Scan(
+		&sh.ID, &sh.OwnerID, &sh.Ciphertext, &sh.MaxViews, &sh.ViewsLeft, &sh.ExpiresAt, &sh.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, onetime.ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &sh, nil
+}
+
+// Consume списание просмотра. Строка блокируется обновлением, поэтому параллельный запрос дождется его
+// и не спишет уже списанный последний просмотр. Исчерпанная ссылка удаляется сразу; если удаление
+// не удалось, ее содержимое все равно недоступно и будет удалено при очистке.
+func (otr *OneTimeShareRepository) Consume(ctx context.Context, id string, now time.Time) (int, error) {
+	op := "repository.Postgres.OneTimeShare.Consume"
+
+	query := `
+		UPDATE one_time_shares SET views_left = views_left - 1
+		WHERE id = $1 AND views_left > 0 AND expires_at > $2
+		RETURNING views_left
+	`
+
+	var left int
+	if err := otr.db.QueryRowContext(ctx, query, id, now).Scan(&left); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, onetime.ErrNotFound
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if left == 0 {
+		_, _ = otr.db.ExecContext(ctx, `DELETE FROM one_time_shares WHERE id = $1 AND views_left = 0`, id)
+	}
+
+	return left, nil
+}
+
+// DeleteExpired удаление истекших и исчерпанных ссылок.
+func (otr *OneTimeShareRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	op := "repository.Postgres.OneTimeShare.DeleteExpired"
+
+	res, err := otr.db.ExecContext(
+		ctx, `DELETE FROM one_time_shares WHERE expires_at <= $1 OR views_left = 0`, now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, _ := res.RowsAffected()
+
+	return int(n), nil
+}
@@ -0,0 +1,599 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/org"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/repository/external_storage"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// OrgRepository репозиторий организаций, их участников и коллекций.
+// Операции, меняющие состав участников или коллекций, блокируют строку организации, поэтому
+// копии ключей коллекций, подготовленные сервисом, проверяются на актуальность внутри транзакции.
+type OrgRepository struct {
+	db *sql.DB
+}
+
+// NewOrgRepository новый репозиторий организаций.
+func NewOrgRepository(db *sql.DB) *OrgRepository {
+	return &OrgRepository{db: db}
+}
+
+// CreateOrganization сохранение организации вместе с владельцем.
+func (or *OrgRepository) CreateOrganization(ctx context.Context, o *org.Organization, ownerID int) error {
+	op := "repository.Postgres.CreateOrganization"
+
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	err = tx.QueryRowContext(
+		ctx, `INSERT INTO organizations (name, created_at) VALUES ($1, $2) RETURNING id`, o.Name, o.CreatedAt,
+	).Scan(&o.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert organization %w", op, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx, `INSERT INTO org_members (org_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`,
+		o.ID, ownerID, org.RoleOwner, o.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert owner %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteOrganization удаление организации, коллекции и их секреты удаляются каскадно,
+// файлы секретов коллекций удаляются после коммита.
+func (or *OrgRepository) DeleteOrganization(ctx context.Context, orgID int) error {
+	op := "repository.Postgres.DeleteOrganization"
+
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = lockOrganization(ctx, tx, orgID); err != nil {
+		if errors.Is(err, org.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Новые секреты коллекций ждут удаления, см. lockCollectionKey, поэтому их файлы не теряются
+	if _, err = tx.ExecContext(ctx, `SELECT id FROM collections WHERE org_id = $1 FOR UPDATE`, orgID); err != nil {
+		return fmt.Errorf("%s: failed to lock collections %w", op, err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT es.storage_path
+		FROM external_storage es
+		JOIN secrets s ON s.id = es.secret_id
+		JOIN collections c ON c.id = s.collection_id
+		WHERE c.org_id = $1
+	`, orgID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to query collection files %w", op, err)
+	}
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err = rows.Scan(&path); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("%s: failed to scan collection file %w", op, err)
+		}
+		paths = append(paths, path)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM organizations WHERE id = $1`, orgID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit %w", op, err)
+	}
+
+	if err = external_storage.RemoveFiles(paths); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListOrganizations организации пользователя с его ролью.
+func (or *OrgRepository) ListOrganizations(ctx context.Context, userID int) ([]*org.Organization, error) {
+	op := "repository.Postgres.ListOrganizations"
+
+	query := `
+		SELECT o.id, o.name, o.created_at, m.role
+		FROM organizations o
+		JOIN org_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`
+
+	rows, err := or.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	orgs := make([]*org.Organization, 0)
+	for rows.Next() {
+		var o org.Organization
+		if err = rows.Scan(&o.ID, &o.Name, &o.CreatedAt, &o.Role); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan organization row %w", op, err)
+		}
+
+		orgs = append(orgs, &o)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return orgs, nil
+}
+
+const memberColumns = `m.org_id, m.user_id, u.login, m.role, m.created_at`
+
+// GetMember участник организации.
+func (or *OrgRepository) GetMember(ctx context.Context, orgID, userID int) (*org.Member, error) {
+	query := `SELECT ` + memberColumns + `
+		FROM org_members m JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1 AND m.user_id = $2
+	`
+
+	var m org.Member
+	err := or.db.QueryRowContext(ctx, query, orgID, userID).Scan(
+		&m.OrgID, &m.UserID, &m.Login, &m.Role, &m.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, org.ErrNotMember
+		}
+		return nil, fmt.Errorf("repository.Postgres.GetMember: %w", err)
+	}
+
+	return &m, nil
+}
+
+// ListMembers участники организации.
+func (or *OrgRepository) ListMembers(ctx context.Context, orgID int) ([]*org.Member, error) {
+	op := "repository.Postgres.ListMembers"
+
+	query := `SELECT ` + memberColumns + `
+		FROM org_members m JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY m.created_at
+	`
+
+	rows, err := or.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	members := make([]*org.Member, 0)
+	for rows.Next() {
+		var m org.Member
+		if err = rows.Scan(&m.OrgID, &m.UserID, &m.Login, &m.Role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan member row %w", op, err)
+		}
+
+		members = append(members, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// AddMember добавление участника с копиями ключей всех коллекций организации.
+func (or *OrgRepository) AddMember(ctx context.Context, m *org.Member, keys []*org.CollectionKey) error {
+	op := "repository.Postgres.AddMember"
+
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if err = lockOrganization(ctx, tx, m.OrgID); err != nil {
+		return err
+	}
+
+	// Копии должны быть выданы для каждой коллекции и текущей версии ее ключа
+	var stale int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM collections c
+		WHERE c.org_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM unnest($2::int[], $3::int[]) AS k(collection_id, version)
+		                  WHERE k.collection_id = c.id AND k.version = c.key_version)
+	`, m.OrgID, keyCollectionIDs(keys), keyVersions(keys)).Scan(&stale)
+	if err != nil {
+		return fmt.Errorf("%s: failed to check collection keys %w", op, err)
+	}
+	if stale != 0 {
+		err = org.ErrConcurrentChange
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx, `INSERT INTO org_members (org_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`,
+		m.OrgID, m.UserID, m.Role, m.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = org.ErrAlreadyMember
+			return err
+		}
+		return fmt.Errorf("%s: failed to insert member %w", op, err)
+	}
+
+	if err = insertCollectionKeys(ctx, tx, keys); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetRole смена роли участника.
+func (or *OrgRepository) SetRole(ctx context.Context, orgID, userID int, role org.Role) error {
+	res, err := or.db.ExecContext(
+		ctx, `UPDATE org_members SET role = $3 WHERE org_id = $1 AND user_id = $2`, orgID, userID, role,
+	)
+	if err != nil {
+		return fmt.Errorf("repository.Postgres.SetRole: %w", err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return org.ErrNotMember
+	}
+
+	return nil
+}
+
+// RemoveMember удаление участника и ротация ключей коллекций организации в одной транзакции.
+// Файлы коллекций перешифровываются в новые файлы, прежние удаляются после коммита.
+func (or *OrgRepository) RemoveMember(
+	ctx context.Context,
+	orgID, userID int,
+	rotations []*org.KeyRotation,
+) (err error) {
+	op := "repository.Postgres.RemoveMember"
+
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+
+	var files fileRewrites
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			_ = files.finish(false)
+			// Закрытие файлов секретов, до которых ротация не дошла
+			for _, r := range rotations {
+				r.Wipe()
+			}
+			return
+		}
+
+		// Прежние файлы удаляются, только если новые пути сохранены
+		if err = tx.Commit(); err != nil {
+			_ = files.finish(false)
+			err = fmt.Errorf("%s: failed to commit %w", op, err)
+			return
+		}
+		if err = files.finish(true); err != nil {
+			err = fmt.Errorf("%s: member removed, but replaced files were not deleted %w", op, err)
+		}
+	}()
+
+	if err = lockOrganization(ctx, tx, orgID); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete member %w", op, err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = org.ErrNotMember
+		return err
+	}
+
+	var members, collections int
+	err = tx.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM org_members WHERE org_id = $1),
+		       (SELECT COUNT(*) FROM collections WHERE org_id = $1)
+	`, orgID).Scan(&members, &collections)
+	if err != nil {
+		return fmt.Errorf("%s: failed to count members %w", op, err)
+	}
+	if collections != len(rotations) {
+		err = org.ErrConcurrentChange
+		return err
+	}
+
+	for _, r := range rotations {
+		if len(r.Keys) != members {
+			err = org.ErrConcurrentChange
+			return err
+		}
+
+		if err = or.rotateCollectionKey(ctx, tx, r, &files); err != nil {
+			return fmt.Errorf("%s: collection %d: %w", op, r.CollectionID, err)
+		}
+	}
+
+	return nil
+}
+
+// rotateCollectionKey сохранение перешифрованных секретов и новых копий ключа коллекции, удаление старых копий.
+func (or *OrgRepository) rotateCollectionKey(
+	ctx context.Context,
+	tx *sql.Tx,
+	r *org.KeyRotation,
+	files *fileRewrites,
+) error {
+	// Новые секреты коллекции ждут окончания ротации, см. lockCollectionKey
+	var current int
+	err := tx.QueryRowContext(
+		ctx, `SELECT key_version FROM collections WHERE id = $1 FOR UPDATE`, r.CollectionID,
+	).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to lock collection %w", err)
+	}
+	if current != r.OldVersion {
+		return org.ErrConcurrentChange
+	}
+
+	for _, s := range r.Secrets {
+		if err = updateSecretKey(ctx, tx, s, r.OldVersion, files); err != nil {
+			if errors.Is(err, secret.ErrKeyRotated) {
+				return org.ErrConcurrentChange
+			}
+			return err
+		}
+	}
+
+	// Секрет, сохраненный после подготовки ротации, остался бы под старым ключом
+	var stale int
+	err = tx.QueryRowContext(
+		ctx, `SELECT COUNT(*) FROM secrets WHERE collection_id = $1 AND key_version <> $2`,
+		r.CollectionID, r.NewVersion,
+	).Scan(&stale)
+	if err != nil {
+		return fmt.Errorf("failed to check collection secrets %w", err)
+	}
+	if stale != 0 {
+		return org.ErrConcurrentChange
+	}
+
+	if err = insertCollectionKeys(ctx, tx, r.Keys); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx, `DELETE FROM collection_keys WHERE collection_id = $1 AND version <> $2`, r.CollectionID, r.NewVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete old collection keys %w", err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx, `UPDATE collections SET key_version = $2 WHERE id = $1`, r.CollectionID, r.NewVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update collection key version %w", err)
+	}
+
+	return nil
+}
+
+// CreateCollection сохранение коллекции с копиями ключа для всех участников.
+func (or *OrgRepository) CreateCollection(ctx context.Context, c *org.Collection, keys []*org.CollectionKey) error {
+	op := "repository.Postgres.CreateCollection"
+
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if err = lockOrganization(ctx, tx, c.OrgID); err != nil {
+		return err
+	}
+
+	var members int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM org_members WHERE org_id = $1`, c.OrgID).Scan(&members)
+	if err != nil {
+		return fmt.Errorf("%s: failed to count members %w", op, err)
+	}
+	if members != len(keys) {
+		err = org.ErrConcurrentChange
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO collections (org_id, name, key_version, created_at) VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, c.OrgID, c.Name, c.KeyVersion, c.CreatedAt).Scan(&c.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = org.ErrCollectionExists
+			return err
+		}
+		return fmt.Errorf("%s: failed to insert collection %w", op, err)
+	}
+
+	for _, k := range keys {
+		k.CollectionID = c.ID
+	}
+	if err = insertCollectionKeys(ctx, tx, keys); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListCollections коллекции организации.
+func (or *OrgRepository) ListCollections(ctx context.Context, orgID int) ([]*org.Collection, error) {
+	op := "repository.Postgres.ListCollections"
+
+	rows, err := or.db.QueryContext(ctx, `
+		SELECT id, org_id, name, key_version, created_at FROM collections WHERE org_id = $1 ORDER BY name
+	`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	collections := make([]*org.Collection, 0)
+	for rows.Next() {
+		var c org.Collection
+		if err = rows.Scan(&c.ID, &c.OrgID, &c.Name, &c.KeyVersion, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan collection row %w", op, err)
+		}
+
+		collections = append(collections, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+// GetAccess роль пользователя в организации коллекции и его копия ключа текущей версии.
+func (or *OrgRepository) GetAccess(ctx context.Context, collectionID, userID int) (*org.Access, error) {
+	query := `
+		SELECT c.id, c.org_id, c.name, c.key_version, c.created_at, m.role, k.wrapped_key
+		FROM collections c
+		JOIN org_members m ON m.org_id = c.org_id AND m.user_id = $2
+		JOIN collection_keys k ON k.collection_id = c.id AND k.version = c.key_version AND k.user_id = $2
+		WHERE c.id = $1
+	`
+
+	var acc org.Access
+	err := or.db.QueryRowContext(ctx, query, collectionID, userID).Scan(
+		&acc.Collection.ID, &acc.Collection.OrgID, &acc.Collection.Name, &acc.Collection.KeyVersion,
+		&acc.Collection.CreatedAt, &acc.Role, &acc.WrappedKey,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, org.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("repository.Postgres.GetAccess: %w", err)
+	}
+
+	return &acc, nil
+}
+
+// GetCollectionSecrets все секреты коллекции с данными.
+func (or *OrgRepository) GetCollectionSecrets(ctx context.Context, collectionID int) ([]*secret.Secret, error) {
+	op := "repository.Postgres.GetCollectionSecrets"
+
+	secrets, err := queryCollectionSecrets(ctx, or.db, collectionID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = loadSecretsData(ctx, or.db, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secrets, nil
+}
+
+// lockOrganization блокировка строки организации до конца транзакции.
+func lockOrganization(ctx context.Context, tx *sql.Tx, orgID int) error {
+	var id int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM organizations WHERE id = $1 FOR UPDATE`, orgID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return org.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock organization %w", err)
+	}
+
+	return nil
+}
+
+func insertCollectionKeys(ctx context.Context, tx *sql.Tx, keys []*org.CollectionKey) error {
+	query := `INSERT INTO collection_keys (collection_id, version, user_id, wrapped_key) VALUES ($1, $2, $3, $4)`
+
+	for _, k := range keys {
+		if _, err := tx.ExecContext(ctx, query, k.CollectionID, k.Version, k.UserID, k.WrappedKey); err != nil {
+			return fmt.Errorf("failed to insert collection key %w", err)
+		}
+	}
+
+	return nil
+}
+
+func keyCollectionIDs(keys []*org.CollectionKey) []int64 {
+	ids := make([]int64, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, int64(k.CollectionID))
+	}
+
+	return ids
+}
+
+func keyVersions(keys []*org.CollectionKey) []int64 {
+	versions := make([]int64, 0, len(keys))
+	for _, k := range keys {
+		versions = append(versions, int64(k.Version))
+	}
+
+	return versions
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
 	"github.com/Melikhov-p/goph-keeper/internal/repository/external_storage"
@@ -47,13 +48,29 @@ func (sr *SecretRepository) SaveSecret(ctx context.Context, s *secret.Secret) er
 		}
 	}()
 
+	// Секрет коллекции сохраняется только под ключом текущей версии, ротация ждет окончания транзакции
+	if s.CollectionID != 0 {
+		if err = lockCollectionKey(ctx, tx, s.CollectionID, s.KeyVersion); err != nil {
+			return err
+		}
+	}
+
 	query = `
-		INSERT INTO secrets (user_id, name, type, created_at, updated_at, version) 
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO secrets (
+		                     user_id, name, name_index, type, created_at, updated_at, version, client_encrypted,
+		                     collection_id, key_version, created_by, sealed_fields
+		                     ) 
+		VALUES (
+		        NULLIF($1, 0), $2, NULLIF($3, ''), $4, $5, $6, $7, $8, NULLIF($9, 0), NULLIF($10, 0), NULLIF($11, 0), $12
+		        )
 		RETURNING id
 	`
 
-	row := tx.QueryRowContext(ctx, query, s.UserID, s.Name, s.Type, s.CreatedAt, s.UpdatedAt, s.Version)
+	row := tx.QueryRowContext(
+		ctx, query,
+		s.UserID, s.Name, s.NameIndex, s.Type, s.CreatedAt, s.UpdatedAt, s.Version, s.ClientEncrypted,
+		s.CollectionID, s.KeyVersion, s.CreatedBy, s.SealedFields,
+	)
 	if err = row.Scan(&s.ID); err != nil {
 		return fmt.Errorf("%s: failed to query row for secret with error %w", op, err)
 	}
@@ -128,16 +145,27 @@ func (sr *SecretRepository) saveSecretData(ctx context.Context, tx *sql.Tx, s *s
 			}
 
 			var checksum string
-			checksum, data.Path, err = external_storage.SaveFileData(ctx, s.UserID, data.Path, data.Content)
+			if s.CollectionID != 0 {
+				checksum, data.Path, err = external_storage.SaveCollectionFileData(
+					ctx, s.CollectionID, data.Path, data.ContentReader(),
+				)
+			} else {
+				checksum, data.Path, err = external_storage.SaveFileData(ctx, s.UserID, data.Path, data.ContentReader())
+			}
 			if err != nil {
 				return fmt.Errorf("%s: failed to save binary content to file with error %w", op, err)
 			}
 
 			query = `
-					INSERT INTO external_storage (secret_id, storage_path, storage_type, filename, checksum, created_at) 
-					VALUES ($1, $2, $3, $4, $5, $6)
+					INSERT INTO external_storage (
+					                              secret_id, storage_path, storage_type, filename, checksum, created_at, format
+					                              ) 
+					VALUES ($1, $2, $3, $4, $5, $6, $7)
 					`
-			_, err = tx.ExecContext(ctx, query, s.ID, data.Path, "note", data.Name, checksum, s.CreatedAt)
+			_, err = tx.ExecContext(
+				ctx, query,
+				s.ID, data.Path, "note", data.Name, checksum, s.CreatedAt, data.Format,
+			)
 			break
 		}
 		return fmt.Errorf("%s: failed to assert secret data to FileData %w", op, err)
@@ -158,7 +186,7 @@ func (sr *SecretRepository) saveSecretData(ctx context.Context, tx *sql.Tx, s *s
 // GetSecretsByName получить секреты с заданным названием.
 func (sr *SecretRepository) GetSecretsByName(
 	ctx context.Context,
-	secretName string,
+	nameLookups []string,
 	userID int,
 ) ([]*secret.Secret, error) {
 	op := "repository.postgres.GetSecretsByName"
@@ -170,11 +198,11 @@ func (sr *SecretRepository) GetSecretsByName(
 	secrets := make([]*secret.Secret, 0)
 
 	query := `
-		SELECT id, user_id, name, type, created_at, updated_at, version 
-		FROM secrets WHERE name = $1 AND user_id = $2
+		SELECT id, user_id, name, type, created_at, updated_at, version, client_encrypted, sealed_fields
+		FROM secrets WHERE (name = ANY($1) OR name_index = ANY($1)) AND user_id = $2
 	`
 
-	rows, err = sr.db.QueryContext(ctx, query, secretName, userID)
+	rows, err = sr.db.QueryContext(ctx, query, nameLookups, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, secret.ErrSecretNotFound
@@ -187,7 +215,10 @@ func (sr *SecretRepository) GetSecretsByName(
 
 	for rows.Next() {
 		var s secret.Secret
-		if err = rows.Scan(&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version); err != nil {
+		if err = rows.Scan(
+			&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version, &s.ClientEncrypted,
+			&s.SealedFields,
+		); err != nil {
 			return nil, fmt.Errorf("%s: failed to scan row for secret with error %w", op, err)
 		}
 
@@ -198,32 +229,8 @@ func (sr *SecretRepository) GetSecretsByName(
 		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
 	}
 
-	for _, s := range secrets {
-		switch s.Type {
-		case secret.TypePassword:
-			query = `SELECT username, password_encrypted, url, notes_encrypted, metadata 
-					FROM password_data WHERE secret_id = $1`
-		case secret.TypeCard:
-			query = `SELECT card_number_encrypted, card_holder_encrypted, 
-							expiry_date_encrypted, cvv_encrypted, notes_encrypted, metadata
-					FROM card_data WHERE secret_id = $1`
-		case secret.TypeBinary:
-			query = `
-					SELECT storage_path, filename
-					FROM external_storage WHERE secret_id = $1
-					`
-		default:
-			return nil, fmt.Errorf("%s: invalid secret type %s", op, s.Type)
-		}
-
-		row := sr.db.QueryRowContext(ctx, query, s.ID)
-		if err = s.SetDataFromRow(row); err != nil {
-			return nil, fmt.Errorf("%s: failed to set data from row with error %w", op, err)
-		}
-
-		if err = row.Err(); err != nil {
-			return nil, fmt.Errorf("%s: got row.Err: %w", op, err)
-		}
+	if err = sr.LoadSecretsData(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return secrets, nil
@@ -240,7 +247,7 @@ func (sr *SecretRepository) GetAllUserSecrets(ctx context.Context, userID int) (
 	)
 
 	query := `
-		SELECT id, user_id, name, type, created_at, updated_at, version 
+		SELECT id, user_id, name, type, created_at, updated_at, version, client_encrypted, sealed_fields
 		FROM secrets WHERE user_id = $1
 			`
 
@@ -254,7 +261,10 @@ func (sr *SecretRepository) GetAllUserSecrets(ctx context.Context, userID int) (
 
 	for rows.Next() {
 		var s secret.Secret
-		if err = rows.Scan(&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version); err != nil {
+		if err = rows.Scan(
+			&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version, &s.ClientEncrypted,
+			&s.SealedFields,
+		); err != nil {
 			return nil, fmt.Errorf("%s: failed to scan row for secret with error %w", op, err)
 		}
 
@@ -267,3 +277,90 @@ func (sr *SecretRepository) GetAllUserSecrets(ctx context.Context, userID int) (
 
 	return secrets, nil
 }
+
+// ExportUserSecrets получение всех секретов пользователя вместе с данными.
+func (sr *SecretRepository) ExportUserSecrets(ctx context.Context, userID int) ([]*secret.Secret, error) {
+	op := "repository.Postgres.ExportUserSecrets"
+
+	secrets, err := sr.GetAllUserSecrets(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = sr.LoadSecretsData(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secrets, nil
+}
+
+// GetUserSecretsByTypes получение секретов пользователя указанных типов вместе с данными.
+func (sr *SecretRepository) GetUserSecretsByTypes(
+	ctx context.Context,
+	userID int,
+	types ...secret.TypeOfSecret,
+) ([]*secret.Secret, error) {
+	op := "repository.Postgres.GetUserSecretsByTypes"
+
+	all, err := sr.GetAllUserSecrets(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	secrets := make([]*secret.Secret, 0, len(all))
+	for _, s := range all {
+		if slices.Contains(types, s.Type) {
+			secrets = append(secrets, s)
+		}
+	}
+
+	if err = sr.LoadSecretsData(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secrets, nil
+}
+
+// LoadSecretsData чтение данных секретов из таблицы их типа.
+func (sr *SecretRepository) LoadSecretsData(ctx context.Context, secrets []*secret.Secret) error {
+	return loadSecretsData(ctx, sr.db, secrets)
+}
+
+// rowQuerier общий интерфейс *sql.DB и *sql.Tx для чтения одной строки.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func loadSecretsData(ctx context.Context, db rowQuerier, secrets []*secret.Secret) error {
+	var query string
+
+	for _, s := range secrets {
+		switch s.Type {
+		case secret.TypePassword:
+			query = `SELECT username, password_encrypted, url, notes_encrypted, metadata 
+					FROM password_data WHERE secret_id = $1`
+		case secret.TypeCard:
+			query = `SELECT card_number_encrypted, card_holder_encrypted, 
+							expiry_date_encrypted, cvv_encrypted, notes_encrypted, metadata
+					FROM card_data WHERE secret_id = $1`
+		case secret.TypeBinary:
+			query = `
+					SELECT storage_path, filename, format
+					FROM external_storage WHERE secret_id = $1
+					`
+		default:
+			return fmt.Errorf("invalid secret type %s", s.Type)
+		}
+
+		row := db.QueryRowContext(ctx, query, s.ID)
+		if err := s.SetDataFromRow(row); err != nil {
+			return fmt.Errorf("failed to set data from row with error %w", err)
+		}
+
+		if err := row.Err(); err != nil {
+			return fmt.Errorf("got row.Err: %w", err)
+		}
+	}
+
+	return nil
+}
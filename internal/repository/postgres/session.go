@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/session"
+)
+
+// SessionRepository репозиторий сессий пользователей.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository получение репозитория сессий.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `
+	id, user_id, refresh_hash, prev_refresh_hash, user_agent, ip,
+	created_at, last_used_at, expires_at, revoked_at
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row rowScanner) (*session.Session, error) {
+	var (
+		s         session.Session
+		revokedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.RefreshHash, &s.PrevRefreshHash, &s.UserAgent, &s.IP,
+		&s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &revokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan session row %w", err)
+	}
+	s.RevokedAt = revokedAt.Time
+
+	return &s, nil
+}
+
+// Create сохранение новой сессии.
+func (sr *SessionRepository) Create(ctx context.Context, s *session.Session) error {
+	op := "repository.Postgres.Session.Create"
+
+	query := `
+		INSERT INTO sessions (
+		                      id, user_id, refresh_hash, user_agent, ip,
+		                      created_at, last_used_at, expires_at
+		                      )
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := sr.db.ExecContext(
+		ctx, query,
+		s.ID, s.UserID, s.RefreshHash, s.UserAgent, s.IP, s.CreatedAt, s.LastUsedAt, s.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetByID получение сессии по ID.
+func (sr *SessionRepository) GetByID(ctx context.Context, id string) (*session.Session, error) {
+	op := "repository.Postgres.Session.GetByID"
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE id = $1`
+
+	s, err := scanSession(sr.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, session.ErrNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+// Rotate сохранение нового токена обновления с проверкой, что старый еще не был заменен.
+func (sr *SessionRepository) Rotate(ctx context.Context, s *session.Session, oldHash string) error {
+	op := "repository.Postgres.Session.Rotate"
+
+	query := `
+		UPDATE sessions SET
+		                    refresh_hash = $1,
+		                    prev_refresh_hash = $2,
+		                    last_used_at = $3,
+		                    expires_at = $4
+		WHERE id = $5 AND refresh_hash = $6 AND revoked_at IS NULL
+	`
+
+	res, err := sr.db.ExecContext(
+		ctx, query, s.RefreshHash, s.PrevRefreshHash, s.LastUsedAt, s.ExpiresAt, s.ID, oldHash,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return session.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// ListActive активные сессии пользователя, последние использованные первыми.
+func (sr *SessionRepository) ListActive(ctx context.Context, userID int, now time.Time) ([]*session.Session, error) {
+	op := "repository.Postgres.Session.ListActive"
+
+	query := `
+		SELECT ` + sessionColumns + ` FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_used_at DESC
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var sessions []*session.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+// Revoke отзыв сессии пользователя.
+func (sr *SessionRepository) Revoke(ctx context.Context, userID int, id string, at time.Time) error {
+	op := "repository.Postgres.Session.Revoke"
+
+	query := `
+		UPDATE sessions SET revoked_at = COALESCE(revoked_at, $1)
+		WHERE id = $2 AND user_id = $3
+	`
+
+	res, err := sr.db.ExecContext(ctx, query, at, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return session.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllExcept отзыв всех активных сессий пользователя, кроме keepID.
+func (sr *SessionRepository) RevokeAllExcept(ctx context.Context, userID int, keepID string, at time.Time) (int, error) {
+	op := "repository.Postgres.Session.RevokeAllExcept"
+
+	query := `
+		UPDATE sessions SET revoked_at = $1
+		WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL
+	`
+
+	res, err := sr.db.ExecContext(ctx, query, at, userID, keepID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+)
+
+// GetSecretByID секрет пользователя без данных.
+func (sr *SecretRepository) GetSecretByID(ctx context.Context, id, userID int) (*secret.Secret, error) {
+	op := "repository.Postgres.GetSecretByID"
+
+	query := `
+		SELECT id, user_id, name, type, created_at, updated_at, version, client_encrypted, sealed_fields
+		FROM secrets WHERE id = $1 AND user_id = $2
+	`
+
+	var s secret.Secret
+	err := sr.db.QueryRowContext(ctx, query, id, userID).Scan(
+		&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version, &s.ClientEncrypted,
+		&s.SealedFields,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, secret.ErrSecretNotFound
+		}
+		return nil, fmt.Errorf("%s: failed to scan row for secret with error %w", op, err)
+	}
+
+	return &s, nil
+}
+
+// SaveShare сохранение доступа к секрету. Доступ того же получателя к тому же секрету заменяется.
+func (sr *SecretRepository) SaveShare(ctx context.Context, sh *secret.Share) error {
+	op := "repository.Postgres.SaveShare"
+
+	query := `
+		INSERT INTO secret_shares (secret_id, owner_id, recipient_id, permission, wrapped_key, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (secret_id, recipient_id) DO UPDATE SET
+			permission = EXCLUDED.permission,
+			wrapped_key = EXCLUDED.wrapped_key,
+			payload = EXCLUDED.payload
+		RETURNING id, created_at
+	`
+
+	err := sr.db.QueryRowContext(
+		ctx, query,
+		sh.SecretID, sh.OwnerID, sh.RecipientID, sh.Permission, sh.WrappedKey, sh.Payload, sh.CreatedAt,
+	).Scan(&sh.ID, &sh.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteShare удаление доступа владельцем секрета или получателем.
+func (sr *SecretRepository) DeleteShare(ctx context.Context, id, userID int) error {
+	op := "repository.Postgres.DeleteShare"
+
+	query := `DELETE FROM secret_shares WHERE id = $1 AND (owner_id = $2 OR recipient_id = $2)`
+
+	res, err := sr.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return secret.ErrShareNotFound
+	}
+
+	return nil
+}
+
+// ListSharesByOwner доступы к секретам владельца.
+func (sr *SecretRepository) ListSharesByOwner(ctx context.Context, ownerID int) ([]*secret.Share, error) {
+	op := "repository.Postgres.ListSharesByOwner"
+
+	query := `
+		SELECT sh.id, sh.secret_id, sh.owner_id, sh.recipient_id, sh.permission, sh.created_at,
+		       s.name, s.sealed_fields, s.type, r.login
+		FROM secret_shares sh
+		JOIN secrets s ON s.id = sh.secret_id
+		JOIN users r ON r.id = sh.recipient_id
+		WHERE sh.owner_id = $1
+		ORDER BY sh.created_at
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	shares := make([]*secret.Share, 0)
+	for rows.Next() {
+		var sh secret.Share
+		if err = rows.Scan(
+			&sh.ID, &sh.SecretID, &sh.OwnerID, &sh.RecipientID, &sh.Permission, &sh.CreatedAt,
+			&sh.SecretName, &sh.SecretSealed, &sh.SecretType, &sh.RecipientLogin,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan share row %w", op, err)
+		}
+
+		shares = append(shares, &sh)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return shares, nil
+}
+
+// GetSharedSecrets чужие секреты, доступные получателю, без данных.
+func (sr *SecretRepository) GetSharedSecrets(ctx context.Context, recipientID int) ([]*secret.Secret, error) {
+	op := "repository.Postgres.GetSharedSecrets"
+
+	query := `
+		SELECT s.id, s.user_id, s.name, s.type, s.created_at, s.updated_at, s.version, s.client_encrypted,
+		       s.sealed_fields, sh.id, sh.permission, sh.wrapped_key, sh.payload, sh.created_at, o.login
+		FROM secret_shares sh
+		JOIN secrets s ON s.id = sh.secret_id
+		JOIN users o ON o.id = sh.owner_id
+		WHERE sh.recipient_id = $1
+		ORDER BY sh.created_at
+	`
+
+	rows, err := sr.db.QueryContext(ctx, query, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	secrets := make([]*secret.Secret, 0)
+	for rows.Next() {
+		var (
+			s  secret.Secret
+			sh secret.Share
+		)
+		if err = rows.Scan(
+			&s.ID, &s.UserID, &s.Name, &s.Type, &s.CreatedAt, &s.UpdatedAt, &s.Version, &s.ClientEncrypted,
+			&s.SealedFields, &sh.ID, &sh.Permission, &sh.WrappedKey, &sh.Payload, &sh.CreatedAt, &sh.OwnerLogin,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan shared secret row %w", op, err)
+		}
+
+		sh.SecretID, sh.OwnerID, sh.RecipientID, sh.SecretType = s.ID, s.UserID, recipientID, s.Type
+		s.Access = &sh
+		secrets = append(secrets, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: got rows.Err: %w", op, err)
+	}
+
+	return secrets, nil
+}
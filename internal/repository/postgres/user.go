@@ -5,10 +5,18 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passhash"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueViolation код ошибки postgres при нарушении ограничения уникальности.
+const uniqueViolation = "23505"
+
 // UserRepository репозиторий пользователя.
 type UserRepository struct {
 	db *sql.DB
@@ -23,77 +31,296 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 func (ur *UserRepository) Create(ctx context.Context, u *user.User) error {
 	op := "repository.Postgres.User.Create"
 
+	var (
+		tx  *sql.Tx
+		err error
+	)
+
+	tx, err = ur.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	if err = insertUser(ctx, tx, u); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreateWithInvite создание пользователя с погашением приглашения в одной транзакции: если пользователя
+// создать не удалось (например, логин занят), приглашение остается действительным.
+func (ur *UserRepository) CreateWithInvite(ctx context.Context, u *user.User, codeHash []byte, now time.Time) error {
+	op := "repository.Postgres.User.CreateWithInvite"
+
+	var (
+		tx       *sql.Tx
+		inviteID int
+		err      error
+	)
+
+	tx, err = ur.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `
+	UPDATE invites SET used_at = $2
+	WHERE code_hash = $1 AND used_at IS NULL AND expires_at > $2
+	RETURNING id
+	`
+
+	if err = tx.QueryRowContext(ctx, query, codeHash, now).Scan(&inviteID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = user.ErrInvalidInvite
+			return err
+		}
+		return fmt.Errorf("%s: failed to claim invite %w", op, err)
+	}
+
+	if err = insertUser(ctx, tx, u); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query = `UPDATE invites SET used_by = $1 WHERE id = $2`
+
+	if _, err = tx.ExecContext(ctx, query, u.ID, inviteID); err != nil {
+		return fmt.Errorf("%s: failed to mark invite used %w", op, err)
+	}
+
+	return nil
+}
+
+// insertUser вставка пользователя и его ключа хранилища в транзакции tx.
+func insertUser(ctx context.Context, tx *sql.Tx, u *user.User) error {
 	query := `
-		INSERT INTO users (login, password_hash, created_at, updated_at)
-        VALUES ($1, $2, $3, $4)
+		INSERT INTO users (
+		                   login, password_hash, password_scheme, pepper_id, encryption_mode, 
+		                   kdf_salt, kdf_time, kdf_memory, kdf_threads, 
+		                   created_at, updated_at
+		                   )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
         RETURNING id
         `
 
-	row := ur.db.QueryRowContext(ctx, query, u.Login, u.PassHash, u.CreatedAt, u.UpdatedAt)
+	var salt []byte
+	var kdfTime, kdfMemory, kdfThreads sql.NullInt64
+	if u.KDF != nil {
+		salt = u.KDF.Salt
+		kdfTime = sql.NullInt64{Int64: int64(u.KDF.Time), Valid: true}
+		kdfMemory = sql.NullInt64{Int64: int64(u.KDF.Memory), Valid: true}
+		kdfThreads = sql.NullInt64{Int64: int64(u.KDF.Threads), Valid: true}
+	}
+
+	row := tx.QueryRowContext(
+		ctx, query,
+		u.Login, u.PassHash, u.PassScheme, u.PepperID, u.EncryptionMode,
+		salt, kdfTime, kdfMemory, kdfThreads, u.CreatedAt, u.UpdatedAt,
+	)
 	if err := row.Scan(&u.ID); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("failed to insert user %w", err)
+	}
+
+	if len(u.WrappedVaultKey) != 0 {
+		query = `INSERT INTO user_keys (user_id, encrypted_key) VALUES ($1, $2)`
+
+		if _, err := tx.ExecContext(ctx, query, u.ID, u.WrappedVaultKey); err != nil {
+			return fmt.Errorf("failed to save user vault key %w", err)
+		}
 	}
 
 	return nil
 }
 
+// userColumns колонки, которые читаются при получении пользователя.
+const userColumns = `
+	u.id, u.login, u.password_hash, u.password_scheme, u.pepper_id, u.encryption_mode,
+	u.kdf_salt, u.kdf_time, u.kdf_memory, u.kdf_threads, 
+	uk.encrypted_key, uk.public_key, uk.encrypted_private_key, u.delete_after, u.is_admin, u.disabled_at, u.created_at, u.updated_at
+	FROM users u LEFT JOIN user_keys uk ON uk.user_id = u.id
+`
+
+// scanUser чтение пользователя из строки, выбранной с колонками userColumns.
+func scanUser(row rowScanner) (*user.User, error) {
+	var (
+		u                           user.User
+		salt                        []byte
+		kdfTime, kdfMemory, threads sql.NullInt64
+		deleteAfter, disabledAt     sql.NullTime
+	)
+
+	err := row.Scan(
+		&u.ID, &u.Login, &u.PassHash, &u.PassScheme, &u.PepperID, &u.EncryptionMode,
+		&salt, &kdfTime, &kdfMemory, &threads,
+		&u.WrappedVaultKey, &u.PublicKey, &u.WrappedPrivateKey, &deleteAfter, &u.IsAdmin, &disabledAt, &u.CreatedAt, &u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan user row %w", err)
+	}
+
+	if deleteAfter.Valid {
+		u.DeleteAfter = deleteAfter.Time
+	}
+	if disabledAt.Valid {
+		u.DisabledAt = disabledAt.Time
+	}
+
+	if salt != nil {
+		u.KDF = &encryptor.KDFParams{
+			Salt:    salt,
+			Time:    uint32(kdfTime.Int64),
+			Memory:  uint32(kdfMemory.Int64),
+			Threads: uint8(threads.Int64),
+		}
+	}
+
+	return &u, nil
+}
+
 // GetByID получение пользователя по ID.
 func (ur *UserRepository) GetByID(ctx context.Context, id int) (*user.User, error) {
 	op := "repository.Postgres.User.GetByID"
 
-	var u user.User
-
-	query := `SELECT login, password_hash, created_at, updated_at from users WHERE id = $1`
+	query := `SELECT ` + userColumns + ` WHERE u.id = $1`
 
-	row := ur.db.QueryRowContext(ctx, query, id)
-	if err := row.Scan(&u.Login, &u.PassHash, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	u, err := scanUser(ur.db.QueryRowContext(ctx, query, id))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, user.ErrNotFound
 		}
 		return nil, fmt.Errorf("%s: error scanning row for user (%d) %w", op, id, err)
 	}
-	u.ID = id
 
-	return &u, nil
+	return u, nil
 }
 
 // GetByLogin получение пользователя по логину.
 func (ur *UserRepository) GetByLogin(ctx context.Context, login string) (*user.User, error) {
 	op := "repository.Postgres.User.GetByLogin"
 
-	var u user.User
-
-	query := `SELECT id, password_hash, created_at, updated_at from users WHERE login = $1`
+	query := `SELECT ` + userColumns + ` WHERE u.login = $1`
 
-	row := ur.db.QueryRowContext(ctx, query, login)
-	if err := row.Scan(&u.ID, &u.PassHash, &u.CreatedAt, &u.UpdatedAt); err != nil {
+	u, err := scanUser(ur.db.QueryRowContext(ctx, query, login))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, user.ErrNotFound
 		}
 		return nil, fmt.Errorf("%s: error scanning row for user (%s) %w", op, login, err)
 	}
-	u.Login = login
 
-	return &u, nil
+	return u, nil
 }
 
-// Update обновление информации пользователя.
+// Update обновление информации пользователя. Логин, хэш пароля, параметры KDF и обернутый
+// ключ хранилища сохраняются в одной транзакции, чтобы ключ не разошелся с паролем, из которого он получен.
 func (ur *UserRepository) Update(ctx context.Context, u *user.User) error {
 	op := "repository.Postgres.User.Update"
 
+	var (
+		tx  *sql.Tx
+		res sql.Result
+		err error
+	)
+
+	tx, err = ur.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
 	query := `
 	UPDATE users SET
 	                 login = $1,
 	                 updated_at = $2,
-	                 password_hash = $3
-	WHERE id = $4
+	                 password_hash = $3,
+	                 password_scheme = $4,
+	                 pepper_id = $5,
+	                 kdf_salt = $6,
+	                 kdf_time = $7,
+	                 kdf_memory = $8,
+	                 kdf_threads = $9
+	WHERE id = $10
 	`
 
-	res, err := ur.db.ExecContext(ctx, query, u.Login, u.UpdatedAt, u.PassHash, u.ID)
+	var salt []byte
+	var kdfTime, kdfMemory, kdfThreads sql.NullInt64
+	if u.KDF != nil {
+		salt = u.KDF.Salt
+		kdfTime = sql.NullInt64{Int64: int64(u.KDF.Time), Valid: true}
+		kdfMemory = sql.NullInt64{Int64: int64(u.KDF.Memory), Valid: true}
+		kdfThreads = sql.NullInt64{Int64: int64(u.KDF.Threads), Valid: true}
+	}
+
+	res, err = tx.ExecContext(
+		ctx, query,
+		u.Login, u.UpdatedAt, u.PassHash, u.PassScheme, u.PepperID, salt, kdfTime, kdfMemory, kdfThreads, u.ID,
+	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			err = user.ErrAlreadyExist
+			return err
+		}
 		return fmt.Errorf("%s: error executing context for update user %w", op, err)
 	}
 
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		err = user.ErrNoRowsUpdated
+		return err
+	}
+
+	if len(u.WrappedVaultKey) != 0 {
+		query = `
+			INSERT INTO user_keys (user_id, encrypted_key) VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE SET encrypted_key = EXCLUDED.encrypted_key
+		`
+
+		if _, err = tx.ExecContext(ctx, query, u.ID, u.WrappedVaultKey); err != nil {
+			return fmt.Errorf("%s: failed to save user vault key %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdatePasswordHash замена хэша пароля, пересчитанного по текущей политике. Условие на старый хэш
+// не дает затереть пароль, который успели сменить параллельно.
+func (ur *UserRepository) UpdatePasswordHash(ctx context.Context, id int, old string, hash passhash.Hash) error {
+	op := "repository.Postgres.User.UpdatePasswordHash"
+
+	query := `
+	UPDATE users SET
+	                 password_hash = $1,
+	                 password_scheme = $2,
+	                 pepper_id = $3
+	WHERE id = $4 AND password_hash = $5
+	`
+
+	res, err := ur.db.ExecContext(ctx, query, hash.Value, hash.Scheme, hash.PepperID, id, old)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if affected, _ := res.RowsAffected(); affected == 0 {
 		return user.ErrNoRowsUpdated
 	}
@@ -101,16 +328,217 @@ func (ur *UserRepository) Update(ctx context.Context, u *user.User) error {
 	return nil
 }
 
-// Delete удаление пользователя.
+// CountPasswordHashes число аккаунтов по схемам хэширования и перцам.
+func (ur *UserRepository) CountPasswordHashes(ctx context.Context) ([]user.HashCount, error) {
+	op := "repository.Postgres.User.CountPasswordHashes"
+
+	query := `
+	SELECT password_scheme, pepper_id, COUNT(*) FROM users
+	GROUP BY password_scheme, pepper_id
+	ORDER BY password_scheme, pepper_id
+	`
+
+	rows, err := ur.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var counts []user.HashCount
+	for rows.Next() {
+		var c user.HashCount
+		if err = rows.Scan(&c.Scheme, &c.PepperID, &c.Users); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		counts = append(counts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
+
+// Delete удаление пользователя. Секреты, ключ хранилища, сессии и второй фактор удаляются каскадно,
+// счетчик неудачных входов по логину удаляется в той же транзакции.
 func (ur *UserRepository) Delete(ctx context.Context, id int) error {
 	op := "repository.Postgres.User.Delete"
 
-	query := `DELETE FROM users WHERE id = $1`
+	var (
+		tx    *sql.Tx
+		login string
+		err   error
+	)
 
-	_, err := ur.db.ExecContext(ctx, query, id)
+	tx, err = ur.db.BeginTx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("%s: failed to start transaction %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	query := `DELETE FROM users WHERE id = $1 RETURNING login`
+
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&login); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = user.ErrNotFound
+			return err
+		}
 		return fmt.Errorf("%s: error executing context for delete %w", op, err)
 	}
 
+	query = `DELETE FROM login_attempts WHERE key = $1`
+
+	if _, err = tx.ExecContext(ctx, query, lockout.Key(lockout.KindLogin, login)); err != nil {
+		return fmt.Errorf("%s: failed to delete login attempts %w", op, err)
+	}
+
+	return nil
+}
+
+// SetDeleteAfter планирование или отмена (нулевое at) удаления аккаунта.
+func (ur *UserRepository) SetDeleteAfter(ctx context.Context, id int, at time.Time) error {
+	op := "repository.Postgres.User.SetDeleteAfter"
+
+	query := `UPDATE users SET delete_after = $1, updated_at = NOW() WHERE id = $2`
+
+	deleteAfter := sql.NullTime{Time: at, Valid: !at.IsZero()}
+
+	res, err := ur.db.ExecContext(ctx, query, deleteAfter, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetDisabledAt блокировка или разблокировка (нулевое at) аккаунта.
+func (ur *UserRepository) SetDisabledAt(ctx context.Context, id int, at time.Time) error {
+	op := "repository.Postgres.User.SetDisabledAt"
+
+	query := `UPDATE users SET disabled_at = $1, updated_at = NOW() WHERE id = $2`
+
+	disabledAt := sql.NullTime{Time: at, Valid: !at.IsZero()}
+
+	res, err := ur.db.ExecContext(ctx, query, disabledAt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return user.ErrNotFound
+	}
+
 	return nil
 }
+
+// SetAdmin выдача или снятие прав администратора.
+func (ur *UserRepository) SetAdmin(ctx context.Context, id int, admin bool) error {
+	op := "repository.Postgres.User.SetAdmin"
+
+	query := `UPDATE users SET is_admin = $1, updated_at = NOW() WHERE id = $2`
+
+	res, err := ur.db.ExecContext(ctx, query, admin, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetShareKeys сохранение пары ключей E2E аккаунта, если она еще не задана.
+func (ur *UserRepository) SetShareKeys(ctx context.Context, id int, publicKey, wrappedPrivateKey []byte) error {
+	op := "repository.Postgres.User.SetShareKeys"
+
+	query := `
+		UPDATE user_keys SET public_key = $1, encrypted_private_key = $2
+		WHERE user_id = $3 AND public_key IS NULL
+	`
+
+	res, err := ur.db.ExecContext(ctx, query, publicKey, wrappedPrivateKey, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return user.ErrNoRowsUpdated
+	}
+
+	return nil
+}
+
+// CreateInvite сохранение приглашения. CreatedBy 0 сохраняется как NULL (приглашение оператора).
+func (ur *UserRepository) CreateInvite(ctx context.Context, inv *user.Invite) error {
+	op := "repository.Postgres.User.CreateInvite"
+
+	query := `
+		INSERT INTO invites (code_hash, created_by, note, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	createdBy := sql.NullInt64{Int64: int64(inv.CreatedBy), Valid: inv.CreatedBy != 0}
+
+	err := ur.db.QueryRowContext(
+		ctx, query, inv.CodeHash, createdBy, inv.Note, inv.ExpiresAt, inv.CreatedAt,
+	).Scan(&inv.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// List все пользователи в порядке регистрации.
+func (ur *UserRepository) List(ctx context.Context) ([]*user.User, error) {
+	return ur.listUsers(ctx, "repository.Postgres.User.List", `SELECT `+userColumns+` ORDER BY u.id`)
+}
+
+// ListDueForDeletion пользователи, срок удаления которых наступил.
+func (ur *UserRepository) ListDueForDeletion(ctx context.Context, now time.Time) ([]*user.User, error) {
+	query := `SELECT ` + userColumns + ` WHERE u.delete_after <= $1 ORDER BY u.delete_after`
+
+	return ur.listUsers(ctx, "repository.Postgres.User.ListDueForDeletion", query, now)
+}
+
+// listUsers выборка пользователей запросом с колонками userColumns.
+func (ur *UserRepository) listUsers(ctx context.Context, op, query string, args ...any) ([]*user.User, error) {
+	rows, err := ur.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var users []*user.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,175 @@
+// Package seal пакет состояния запечатанного сервера.
+//
+// Запечатанный сервер не знает ключа провайдера и не обслуживает запросы к секретам.
+// Чтобы распечатать сервер, операторы по одной передают доли Шамира; когда долей набирается
+// порог, из них восстанавливается ключ распечатывания, которым разворачиваются ключи данных.
+package seal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Melikhov-p/goph-keeper/internal/shamir"
+)
+
+var (
+	// ErrSealed сервер уже запечатан.
+	ErrSealed = errors.New("server is sealed")
+	// ErrInvalidShares из переданных долей не удалось восстановить ключ, прогресс сброшен.
+	ErrInvalidShares = errors.New("invalid unseal shares")
+	// ErrEmptyShare передана пустая доля.
+	ErrEmptyShare = errors.New("empty unseal share")
+)
+
+// UnsealFunc получение ключа распечатывания из набранных долей.
+type UnsealFunc func(ctx context.Context, shares [][]byte) ([]byte, error)
+
+// Status состояние барьера.
+type Status struct {
+	Sealed    bool
+	Shares    int
+	Threshold int
+	// Progress число уже переданных долей.
+	Progress int
+}
+
+// Barrier барьер между сервером и ключом распечатывания.
+type Barrier struct {
+	mu        sync.Mutex
+	shares    int
+	threshold int
+	unseal    UnsealFunc
+	parts     [][]byte
+	key       []byte
+	sealed    bool
+	unsealed  chan struct{}
+	sealReq   chan struct{}
+}
+
+// NewBarrier получение барьера в запечатанном состоянии.
+func NewBarrier(shares, threshold int, unseal UnsealFunc) *Barrier {
+	return &Barrier{
+		shares:    shares,
+		threshold: threshold,
+		unseal:    unseal,
+		sealed:    true,
+		unsealed:  make(chan struct{}),
+		sealReq:   make(chan struct{}),
+	}
+}
+
+// Status текущее состояние барьера.
+func (b *Barrier) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.status()
+}
+
+// Unseal прием очередной доли. Повторная передача той же доли не увеличивает прогресс.
+// При наборе порога барьер пытается распечатать сервер; при неудаче прогресс сбрасывается.
+func (b *Barrier) Unseal(ctx context.Context, share []byte) (Status, error) {
+	op := "seal.Barrier.Unseal"
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.sealed {
+		return b.status(), nil
+	}
+	if len(share) == 0 {
+		return b.status(), ErrEmptyShare
+	}
+
+	for _, p := range b.parts {
+		if shamir.ShareID(p) == shamir.ShareID(share) {
+			return b.status(), nil
+		}
+	}
+	b.parts = append(b.parts, append([]byte(nil), share...))
+
+	if len(b.parts) < b.threshold {
+		return b.status(), nil
+	}
+
+	key, err := b.unseal(ctx, b.parts)
+	b.resetProgress()
+	if err != nil {
+		return b.status(), fmt.Errorf("%s: %w: %w", op, ErrInvalidShares, err)
+	}
+
+	b.key = key
+	b.sealed = false
+	b.sealReq = make(chan struct{})
+	close(b.unsealed)
+
+	return b.status(), nil
+}
+
+// ResetProgress сброс уже переданных долей.
+func (b *Barrier) ResetProgress() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.resetProgress()
+}
+
+// Seal запечатывание сервера. Ключ не затирается здесь: его владелец делает это после остановки
+// обработчиков, получив сигнал из канала, который вернул WaitUnseal.
+func (b *Barrier) Seal() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sealed {
+		return ErrSealed
+	}
+
+	b.key = nil
+	b.sealed = true
+	b.unsealed = make(chan struct{})
+	close(b.sealReq)
+
+	return nil
+}
+
+// WaitUnseal ожидание распечатывания. Возвращает ключ распечатывания и канал, который закроется
+// при следующем запечатывании.
+func (b *Barrier) WaitUnseal(ctx context.Context) ([]byte, <-chan struct{}, error) {
+	b.mu.Lock()
+	unsealed := b.unsealed
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, fmt.Errorf("seal.Barrier.WaitUnseal: %w", ctx.Err())
+	case <-unsealed:
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.sealed {
+		// Запечатали раньше, чем ожидающий успел забрать ключ
+		return nil, nil, ErrSealed
+	}
+
+	return b.key, b.sealReq, nil
+}
+
+func (b *Barrier) resetProgress() {
+	for _, p := range b.parts {
+		clear(p)
+	}
+	b.parts = nil
+}
+
+func (b *Barrier) status() Status {
+	return Status{
+		Sealed:    b.sealed,
+		Shares:    b.shares,
+		Threshold: b.threshold,
+		Progress:  len(b.parts),
+	}
+}
@@ -0,0 +1,102 @@
+package seal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/seal"
+	"github.com/Melikhov-p/goph-keeper/internal/shamir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBarrier(t *testing.T, secret []byte) (*seal.Barrier, [][]byte) {
+	t.Helper()
+
+	shares, err := shamir.Split(secret, 5, 3)
+	require.NoError(t, err)
+
+	b := seal.NewBarrier(5, 3, func(_ context.Context, parts [][]byte) ([]byte, error) {
+		key, err := shamir.Combine(parts)
+		if err != nil {
+			return nil, err
+		}
+		if !shamir.Equal(key, secret) {
+			return nil, errors.New("wrong key")
+		}
+		return key, nil
+	})
+
+	return b, shares
+}
+
+func TestBarrier(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	b, shares := newTestBarrier(t, secret)
+	assert.Equal(t, seal.Status{Sealed: true, Shares: 5, Threshold: 3}, b.Status())
+
+	st, err := b.Unseal(ctx, shares[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.Progress)
+
+	// Повторная доля не учитывается
+	st, err = b.Unseal(ctx, shares[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.Progress)
+
+	_, err = b.Unseal(ctx, nil)
+	require.ErrorIs(t, err, seal.ErrEmptyShare)
+
+	_, err = b.Unseal(ctx, shares[3])
+	require.NoError(t, err)
+
+	st, err = b.Unseal(ctx, shares[4])
+	require.NoError(t, err)
+	assert.False(t, st.Sealed)
+	assert.Equal(t, 0, st.Progress)
+
+	key, sealed, err := b.WaitUnseal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, secret, key)
+
+	require.NoError(t, b.Seal())
+	select {
+	case <-sealed:
+	case <-time.After(time.Second):
+		t.Fatal("seal was not signalled")
+	}
+	assert.True(t, b.Status().Sealed)
+	require.ErrorIs(t, b.Seal(), seal.ErrSealed)
+}
+
+func TestBarrierInvalidShares(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	b, _ := newTestBarrier(t, secret)
+	_, other := newTestBarrier(t, []byte("fedcba9876543210fedcba9876543210"))
+
+	for _, share := range other[:2] {
+		_, err := b.Unseal(ctx, share)
+		require.NoError(t, err)
+	}
+
+	st, err := b.Unseal(ctx, other[2])
+	require.ErrorIs(t, err, seal.ErrInvalidShares)
+	assert.True(t, st.Sealed)
+	assert.Equal(t, 0, st.Progress)
+}
+
+func TestBarrierWaitCanceled(t *testing.T) {
+	b, _ := newTestBarrier(t, []byte("secret"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := b.WaitUnseal(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
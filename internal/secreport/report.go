@@ -0,0 +1,195 @@
+// Package secreport пакет отчета о безопасности хранилища: повторяющиеся, слабые, давно не менявшиеся
+// и найденные в утечках пароли, пароли для сайтов без HTTPS и истекающие банковские карты.
+// Отчет строится по расшифрованным данным, поэтому для E2E аккаунтов его считает клиент.
+package secreport
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+)
+
+// Значения по умолчанию.
+const (
+	// DefaultMaxAge возраст пароля, после которого его стоит сменить.
+	DefaultMaxAge = 365 * 24 * time.Hour
+	// DefaultCardExpiryWarning за сколько до окончания срока действия карты предупреждать.
+	DefaultCardExpiryWarning = 60 * 24 * time.Hour
+
+	day = 24 * time.Hour
+)
+
+// Password секрет-пароль для отчета.
+type Password struct {
+	Name      string
+	Username  string
+	Password  string
+	URL       string
+	UpdatedAt time.Time
+	// BreachCount число появлений пароля в базе утечек, если проверка выполнялась.
+	BreachCount uint32
+}
+
+// Card банковская карта для отчета.
+type Card struct {
+	Name       string
+	ExpireDate string
+}
+
+// Options параметры отчета. Нулевые значения заменяются значениями по умолчанию.
+type Options struct {
+	MaxAge            time.Duration
+	CardExpiryWarning time.Duration
+	// MinScore пароли с оценкой стойкости ниже считаются слабыми.
+	MinScore int
+	// UserInputs данные пользователя (логин) для оценки стойкости.
+	UserInputs []string
+	Now        time.Time
+}
+
+// Report отчет о безопасности хранилища. Сами пароли в отчет не попадают.
+type Report struct {
+	// Reused группы названий секретов с одинаковым паролем.
+	Reused   [][]string
+	Weak     []Weak
+	Stale    []Stale
+	Breached []Breached
+	Insecure []Insecure
+	Cards    []CardExpiry
+
+	CheckedPasswords int
+	CheckedCards     int
+}
+
+// Weak слабый пароль.
+type Weak struct {
+	Name     string
+	Score    int
+	Warnings []string
+}
+
+// Stale пароль, который давно не менялся.
+type Stale struct {
+	Name      string
+	UpdatedAt time.Time
+	AgeDays   int
+}
+
+// Breached пароль, найденный в базе утечек.
+type Breached struct {
+	Name  string
+	Count uint32
+}
+
+// Insecure пароль для адреса без HTTPS.
+type Insecure struct {
+	Name string
+	URL  string
+}
+
+// CardExpiry карта, срок действия которой истек или скоро истекает.
+// Карта с нераспознанным сроком действия попадает в отчет с нулевым Expires.
+type CardExpiry struct {
+	Name       string
+	ExpireDate string
+	Expires    time.Time
+	Expired    bool
+}
+
+// Build построение отчета.
+func Build(passwords []Password, cards []Card, opts Options) *Report {
+	opts = opts.withDefaults()
+
+	r := Report{
+		CheckedPasswords: len(passwords),
+		CheckedCards:     len(cards),
+	}
+
+	byPassword := make(map[string][]string)
+	for _, p := range passwords {
+		if p.Password != "" {
+			byPassword[p.Password] = append(byPassword[p.Password], p.Name)
+		}
+
+		inputs := append([]string{p.Username, p.URL}, opts.UserInputs...)
+		if s := passpolicy.Estimate(p.Password, inputs...); s.Score < opts.MinScore {
+			r.Weak = append(r.Weak, Weak{Name: p.Name, Score: s.Score, Warnings: s.Warnings})
+		}
+
+		if age := opts.Now.Sub(p.UpdatedAt); !p.UpdatedAt.IsZero() && age > opts.MaxAge {
+			r.Stale = append(r.Stale, Stale{Name: p.Name, UpdatedAt: p.UpdatedAt, AgeDays: int(age / day)})
+		}
+
+		if p.BreachCount > 0 {
+			r.Breached = append(r.Breached, Breached{Name: p.Name, Count: p.BreachCount})
+		}
+
+		if insecureURL(p.URL) {
+			r.Insecure = append(r.Insecure, Insecure{Name: p.Name, URL: p.URL})
+		}
+	}
+
+	for _, names := range byPassword {
+		if len(names) > 1 {
+			sort.Strings(names)
+			r.Reused = append(r.Reused, names)
+		}
+	}
+	sort.Slice(r.Reused, func(i, j int) bool { return r.Reused[i][0] < r.Reused[j][0] })
+
+	for _, c := range cards {
+		expires, ok := ParseExpireDate(c.ExpireDate)
+		switch {
+		case !ok:
+			r.Cards = append(r.Cards, CardExpiry{Name: c.Name, ExpireDate: c.ExpireDate})
+		case !expires.After(opts.Now):
+			r.Cards = append(r.Cards, CardExpiry{Name: c.Name, ExpireDate: c.ExpireDate, Expires: expires, Expired: true})
+		case expires.Sub(opts.Now) <= opts.CardExpiryWarning:
+			r.Cards = append(r.Cards, CardExpiry{Name: c.Name, ExpireDate: c.ExpireDate, Expires: expires})
+		}
+	}
+
+	return &r
+}
+
+// ParseExpireDate разбор срока действия карты в форматах MM/YY, MM/YYYY, MM-YY, MM-YYYY и YYYY-MM.
+// Карта действует до конца указанного месяца, возвращается начало следующего месяца в UTC.
+func ParseExpireDate(s string) (time.Time, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	for _, layout := range []string{"01/06", "01/2006", "01-06", "01-2006", "2006-01", "0106"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.AddDate(0, 1, 0), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxAge == 0 {
+		o.MaxAge = DefaultMaxAge
+	}
+	if o.CardExpiryWarning == 0 {
+		o.CardExpiryWarning = DefaultCardExpiryWarning
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+
+	return o
+}
+
+// insecureURL адрес с явной схемой http (или ftp), пароль для которого передается в открытом виде.
+func insecureURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+
+	return scheme == "http" || scheme == "ftp"
+}
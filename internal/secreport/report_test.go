@@ -0,0 +1,67 @@
+package secreport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/secreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2025, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	passwords := []secreport.Password{
+		{Name: "mail", Username: "john", Password: "dragon2024", URL: "https://mail.example.com", UpdatedAt: now},
+		{Name: "forum", Password: "dragon2024", URL: "http://forum.example.com", UpdatedAt: now.AddDate(-2, 0, 0)},
+		{Name: "bank", Password: "q7#Vt9!mZr2$Lw8p", URL: "https://bank.example.com", UpdatedAt: now.AddDate(0, -1, 0)},
+		{Name: "wiki", Password: "correct-Horse-battery-9-staple", URL: "wiki.local", UpdatedAt: now, BreachCount: 3},
+	}
+	cards := []secreport.Card{
+		{Name: "old", ExpireDate: "04/25"},
+		{Name: "soon", ExpireDate: "06/2025"},
+		{Name: "fine", ExpireDate: "2027-01"},
+		{Name: "garbage", ExpireDate: "someday"},
+	}
+
+	r := secreport.Build(passwords, cards, secreport.Options{MinScore: 3, Now: now})
+
+	assert.Equal(t, 4, r.CheckedPasswords)
+	assert.Equal(t, 4, r.CheckedCards)
+	assert.Equal(t, [][]string{{"forum", "mail"}}, r.Reused)
+
+	var weak []string
+	for _, w := range r.Weak {
+		weak = append(weak, w.Name)
+	}
+	assert.ElementsMatch(t, []string{"mail", "forum"}, weak)
+
+	require.Len(t, r.Stale, 1)
+	assert.Equal(t, "forum", r.Stale[0].Name)
+	assert.Equal(t, 731, r.Stale[0].AgeDays)
+
+	assert.Equal(t, []secreport.Breached{{Name: "wiki", Count: 3}}, r.Breached)
+	assert.Equal(t, []secreport.Insecure{{Name: "forum", URL: "http://forum.example.com"}}, r.Insecure)
+
+	require.Len(t, r.Cards, 3)
+	assert.Equal(t, "old", r.Cards[0].Name)
+	assert.True(t, r.Cards[0].Expired)
+	assert.Equal(t, "soon", r.Cards[1].Name)
+	assert.False(t, r.Cards[1].Expired)
+	assert.Equal(t, "garbage", r.Cards[2].Name)
+	assert.True(t, r.Cards[2].Expires.IsZero())
+}
+
+func TestParseExpireDate(t *testing.T) {
+	want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, s := range []string{"12/25", "12/2025", "12-25", "2025-12", " 12 / 25 ", "1225"} {
+		got, ok := secreport.ParseExpireDate(s)
+		require.True(t, ok, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	_, ok := secreport.ParseExpireDate("13/25")
+	assert.False(t, ok)
+}
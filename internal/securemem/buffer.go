@@ -0,0 +1,119 @@
+// Package securemem пакет для хранения ключей и расшифрованных данных в памяти.
+//
+// Buffer хранит значение в отдельном срезе, который по возможности закрепляется в памяти (mlock),
+// чтобы не попасть в swap, и явно затирается через Wipe. Строковое и JSON представление буфера
+// всегда скрыто, поэтому случайное логирование через zap, fmt или encoding/json не раскрывает значение.
+package securemem
+
+import (
+	"database/sql/driver"
+)
+
+// Redacted представление буфера в логах.
+const Redacted = "[REDACTED]"
+
+// Buffer затираемый буфер с секретным значением.
+// Нулевой указатель допустим и ведет себя как пустой буфер.
+type Buffer struct {
+	data   []byte
+	locked bool
+}
+
+// New получение буфера с копией b. Исходный срез затирается.
+func New(b []byte) *Buffer {
+	buf := alloc(len(b))
+	copy(buf.data, b)
+	Wipe(b)
+
+	return buf
+}
+
+// FromString получение буфера с копией s. Сама строка остается в памяти до сборки мусора,
+// поэтому конструктор нужен только там, где значение уже пришло строкой (например, из protobuf).
+func FromString(s string) *Buffer {
+	buf := alloc(len(s))
+	copy(buf.data, s)
+
+	return buf
+}
+
+func alloc(size int) *Buffer {
+	buf := Buffer{data: make([]byte, size)}
+	if size > 0 {
+		buf.locked = lock(buf.data)
+	}
+
+	return &buf
+}
+
+// Bytes значение буфера без копирования. Срез становится недействительным после Wipe.
+func (b *Buffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+
+	return b.data
+}
+
+// Reveal копия значения в виде строки для передачи туда, где нужен string.
+// Копию затереть нельзя, поэтому вызывать только непосредственно перед отправкой значения.
+func (b *Buffer) Reveal() string {
+	if b == nil {
+		return ""
+	}
+
+	return string(b.data)
+}
+
+// Len длина значения.
+func (b *Buffer) Len() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.data)
+}
+
+// Wipe затирание значения и снятие закрепления в памяти. Повторный вызов безопасен.
+func (b *Buffer) Wipe() {
+	if b == nil || b.data == nil {
+		return
+	}
+
+	Wipe(b.data)
+	if b.locked {
+		unlock(b.data)
+	}
+
+	b.data = nil
+	b.locked = false
+}
+
+// String скрытое представление для fmt и zap.
+func (b *Buffer) String() string {
+	return Redacted
+}
+
+// GoString скрытое представление для %#v.
+func (b *Buffer) GoString() string {
+	return Redacted
+}
+
+// MarshalJSON скрытое представление для encoding/json и zap.Any.
+func (b *Buffer) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + Redacted + `"`), nil
+}
+
+// Value значение для записи в БД. В БД буфер пишется только в зашифрованном виде.
+func (b *Buffer) Value() (driver.Value, error) {
+	if b == nil {
+		return "", nil
+	}
+
+	return string(b.data), nil
+}
+
+// Wipe затирание произвольного среза нулями.
+func Wipe(b []byte) {
+	clear(b)
+}
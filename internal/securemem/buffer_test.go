@@ -0,0 +1,90 @@
+package securemem_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBuffer(t *testing.T) {
+	src := []byte("top secret")
+	buf := securemem.New(src)
+
+	assert.Equal(t, make([]byte, len(src)), src, "source must be wiped")
+	assert.Equal(t, "top secret", buf.Reveal())
+	assert.Equal(t, 10, buf.Len())
+
+	data := buf.Bytes()
+	buf.Wipe()
+	buf.Wipe()
+
+	assert.Equal(t, make([]byte, 10), data)
+	assert.Nil(t, buf.Bytes())
+	assert.Empty(t, buf.Reveal())
+
+	var empty *securemem.Buffer
+	assert.Zero(t, empty.Len())
+	assert.Empty(t, empty.Reveal())
+	empty.Wipe()
+}
+
+func TestBufferRedaction(t *testing.T) {
+	secret := securemem.FromString("top secret")
+
+	type config struct {
+		Name string
+		Key  *securemem.Buffer
+	}
+	cfg := config{Name: "keeper", Key: secret}
+
+	testCases := []struct {
+		name   string
+		format func() string
+	}{
+		{name: "fmt", format: func() string { return fmt.Sprintf("%v %s %+v %#v", secret, secret, cfg, cfg) }},
+		{name: "json", format: func() string {
+			raw, err := json.Marshal(cfg)
+			require.NoError(t, err)
+			return string(raw)
+		}},
+		{name: "zap", format: func() string {
+			var out bytes.Buffer
+			log := zap.New(zapcore.NewCore(
+				zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&out), zap.DebugLevel,
+			))
+			log.Debug("config", zap.Any("config", cfg), zap.Any("key", secret), zap.Stringer("key", secret))
+			return out.String()
+		}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			out := test.format()
+			assert.NotContains(t, out, "top secret")
+			assert.Contains(t, out, securemem.Redacted)
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	buf := securemem.FromString("secret")
+	raw := []byte("content")
+
+	assert.False(t, securemem.WipeAfter(context.Background(), buf))
+
+	ctx := securemem.WithScope(context.Background())
+	require.True(t, securemem.WipeAfter(ctx, buf, securemem.Bytes(raw)))
+	assert.Equal(t, "secret", buf.Reveal())
+
+	securemem.WipeScope(ctx)
+	assert.Empty(t, buf.Reveal())
+	assert.Equal(t, make([]byte, len(raw)), raw)
+}
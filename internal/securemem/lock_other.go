@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package securemem
+
+// lock на платформах без mlock память не закрепляется, буфер только затирается.
+func lock(_ []byte) bool {
+	return false
+}
+
+func unlock(_ []byte) {}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package securemem
+
+import "syscall"
+
+// lock закрепление страниц среза в памяти. Ошибка (например, превышен RLIMIT_MEMLOCK) не критична:
+// буфер все равно будет затерт, просто может попасть в swap.
+func lock(b []byte) bool {
+	return syscall.Mlock(b) == nil
+}
+
+// unlock снятие закрепления. Страницы могут быть общими с другими буферами,
+// поэтому закрепление является лучшим усилием, а не гарантией.
+func unlock(b []byte) {
+	_ = syscall.Munlock(b)
+}
@@ -0,0 +1,65 @@
+package securemem
+
+import (
+	"context"
+	"sync"
+)
+
+// Wiper значение, которое можно затереть.
+type Wiper interface {
+	Wipe()
+}
+
+// Bytes адаптер среза к Wiper.
+type Bytes []byte
+
+// Wipe затирание среза.
+func (b Bytes) Wipe() {
+	Wipe(b)
+}
+
+type scopeKey struct{}
+
+// scope значения, которые затираются вместе по завершении запроса.
+type scope struct {
+	mu     sync.Mutex
+	wipers []Wiper
+}
+
+// WithScope получение контекста, к которому можно привязать значения для отложенного затирания.
+func WithScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{})
+}
+
+// WipeAfter привязка значений к области контекста: они будут затерты в WipeScope,
+// например после отправки ответа, который на них ссылается.
+// Если в контексте нет области, значения не затираются и возвращается false.
+func WipeAfter(ctx context.Context, wipers ...Wiper) bool {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	s.wipers = append(s.wipers, wipers...)
+	s.mu.Unlock()
+
+	return true
+}
+
+// WipeScope затирание всех значений, привязанных к области контекста.
+func WipeScope(ctx context.Context) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	wipers := s.wipers
+	s.wipers = nil
+	s.mu.Unlock()
+
+	for _, w := range wipers {
+		w.Wipe()
+	}
+}
@@ -0,0 +1,38 @@
+package shamir
+
+// Арифметика в GF(2^8) по модулю x^8 + x^4 + x^3 + x + 1 (многочлен AES).
+// Умножение выполняется без таблиц и ветвлений по данным, чтобы не зависеть по времени от секрета.
+
+func add(a, b byte) byte {
+	return a ^ b
+}
+
+func mul(a, b byte) byte {
+	var res byte
+	for i := 0; i < 8; i++ {
+		// mask = 0xff, если младший бит b установлен, иначе 0
+		mask := -(b & 1)
+		res ^= a & mask
+
+		carry := -(a >> 7)
+		a = (a << 1) ^ (0x1b & carry)
+		b >>= 1
+	}
+
+	return res
+}
+
+// inverse обратный элемент как a^254 (малая теорема Ферма), для нуля возвращается ноль.
+func inverse(a byte) byte {
+	res := a
+	for i := 0; i < 6; i++ {
+		res = mul(res, res)
+		res = mul(res, a)
+	}
+
+	return mul(res, res)
+}
+
+func div(a, b byte) byte {
+	return mul(a, inverse(b))
+}
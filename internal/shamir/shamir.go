@@ -0,0 +1,174 @@
+// Package shamir пакет разделения секрета по схеме Шамира над полем GF(2^8).
+//
+// Каждый байт секрета разделяется отдельным случайным многочленом степени threshold-1.
+// Доля состоит из значений многочленов в точке x и самой точки x в последнем байте.
+package shamir
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+const maxShares = 255
+
+var (
+	// ErrInvalidParams некорректные параметры разделения.
+	ErrInvalidParams = errors.New("invalid shamir params")
+	// ErrInvalidShares доли повреждены, дублируются или имеют разную длину.
+	ErrInvalidShares = errors.New("invalid shamir shares")
+)
+
+// Split разделение секрета на shares долей, любые threshold из которых восстанавливают секрет.
+func Split(secret []byte, shares, threshold int) ([][]byte, error) {
+	op := "shamir.Split"
+
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("%s: %w: empty secret", op, ErrInvalidParams)
+	}
+	if threshold < 2 || shares < threshold || shares > maxShares {
+		return nil, fmt.Errorf("%s: %w: need 2 <= threshold <= shares <= %d", op, ErrInvalidParams, maxShares)
+	}
+
+	// Точки x — случайная перестановка 1..255, чтобы номер доли ничего не говорил о порядке выдачи
+	xs, err := randomXCoordinates(shares)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	out := make([][]byte, shares)
+	for i := range out {
+		out[i] = make([]byte, len(secret)+1)
+		out[i][len(secret)] = xs[i]
+	}
+
+	coeffs := make([]byte, threshold)
+	for idx, b := range secret {
+		coeffs[0] = b
+		if _, err = rand.Read(coeffs[1:]); err != nil {
+			return nil, fmt.Errorf("%s: failed to generate polynomial %w", op, err)
+		}
+
+		for i, x := range xs {
+			out[i][idx] = evaluate(coeffs, x)
+		}
+	}
+
+	return out, nil
+}
+
+// Combine восстановление секрета из долей. Проверить, что долей достаточно, можно только
+// по результату: при нехватке долей возвращается другой секрет.
+func Combine(shares [][]byte) ([]byte, error) {
+	op := "shamir.Combine"
+
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%s: %w: at least two shares required", op, ErrInvalidShares)
+	}
+
+	shareLen := len(shares[0])
+	if shareLen < 2 {
+		return nil, fmt.Errorf("%s: %w: share is too short", op, ErrInvalidShares)
+	}
+
+	xs := make([]byte, len(shares))
+	seen := make(map[byte]struct{}, len(shares))
+	for i, s := range shares {
+		if len(s) != shareLen {
+			return nil, fmt.Errorf("%s: %w: shares have different length", op, ErrInvalidShares)
+		}
+
+		x := s[shareLen-1]
+		if _, ok := seen[x]; ok || x == 0 {
+			return nil, fmt.Errorf("%s: %w: duplicate or zero share", op, ErrInvalidShares)
+		}
+		seen[x] = struct{}{}
+		xs[i] = x
+	}
+
+	secret := make([]byte, shareLen-1)
+	ys := make([]byte, len(shares))
+	for idx := range secret {
+		for i, s := range shares {
+			ys[i] = s[idx]
+		}
+		secret[idx] = interpolateAtZero(xs, ys)
+	}
+
+	return secret, nil
+}
+
+// ShareID номер доли (точка x), позволяет отличать доли без раскрытия их содержимого.
+func ShareID(share []byte) byte {
+	if len(share) == 0 {
+		return 0
+	}
+
+	return share[len(share)-1]
+}
+
+// Equal сравнение долей за постоянное время.
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+func randomXCoordinates(n int) ([]byte, error) {
+	xs := make([]byte, maxShares)
+	for i := range xs {
+		xs[i] = byte(i + 1)
+	}
+
+	// Тасование Фишера-Йетса на криптографически стойком генераторе
+	buf := make([]byte, 1)
+	for i := len(xs) - 1; i > 0; i-- {
+		j, err := randomIndex(buf, i+1)
+		if err != nil {
+			return nil, err
+		}
+		xs[i], xs[j] = xs[j], xs[i]
+	}
+
+	return xs[:n], nil
+}
+
+// randomIndex равномерное случайное число в [0, n) для n <= 256.
+func randomIndex(buf []byte, n int) (int, error) {
+	limit := 256 - 256%n
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return 0, fmt.Errorf("failed to read random %w", err)
+		}
+		if int(buf[0]) < limit {
+			return int(buf[0]) % n, nil
+		}
+	}
+}
+
+// evaluate значение многочлена в точке x по схеме Горнера.
+func evaluate(coeffs []byte, x byte) byte {
+	var res byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res = add(mul(res, x), coeffs[i])
+	}
+
+	return res
+}
+
+// interpolateAtZero значение интерполяционного многочлена Лагранжа в нуле.
+func interpolateAtZero(xs, ys []byte) byte {
+	var res byte
+	for i := range xs {
+		basis := byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			// l_i(0) = prod x_j / (x_j - x_i), вычитание в GF(2^8) — это XOR
+			basis = mul(basis, div(xs[j], add(xs[j], xs[i])))
+		}
+		res = add(res, mul(ys[i], basis))
+	}
+
+	return res
+}
@@ -0,0 +1,97 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGF256(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		assert.Equal(t, byte(1), mul(byte(a), inverse(byte(a))), "a=%d", a)
+	}
+
+	// Пример из FIPS-197: {57} * {83} = {c1}
+	assert.Equal(t, byte(0xc1), mul(0x57, 0x83))
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		shares    int
+		threshold int
+		use       []int
+		wantEqual bool
+	}{
+		{name: "threshold shares", shares: 5, threshold: 3, use: []int{0, 2, 4}, wantEqual: true},
+		{name: "all shares", shares: 5, threshold: 3, use: []int{0, 1, 2, 3, 4}, wantEqual: true},
+		{name: "other subset", shares: 5, threshold: 3, use: []int{3, 1, 4}, wantEqual: true},
+		{name: "not enough shares", shares: 5, threshold: 3, use: []int{0, 1}, wantEqual: false},
+		{name: "two of two", shares: 2, threshold: 2, use: []int{1, 0}, wantEqual: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			shares, err := Split(secret, test.shares, test.threshold)
+			require.NoError(t, err)
+			require.Len(t, shares, test.shares)
+
+			subset := make([][]byte, 0, len(test.use))
+			for _, i := range test.use {
+				subset = append(subset, shares[i])
+			}
+
+			combined, err := Combine(subset)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantEqual, Equal(secret, combined))
+		})
+	}
+}
+
+func TestSplitInvalidParams(t *testing.T) {
+	testCases := []struct {
+		name      string
+		secret    []byte
+		shares    int
+		threshold int
+	}{
+		{name: "empty secret", secret: nil, shares: 3, threshold: 2},
+		{name: "threshold one", secret: []byte("s"), shares: 3, threshold: 1},
+		{name: "threshold above shares", secret: []byte("s"), shares: 2, threshold: 3},
+		{name: "too many shares", secret: []byte("s"), shares: 256, threshold: 3},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Split(test.secret, test.shares, test.threshold)
+			require.ErrorIs(t, err, ErrInvalidParams)
+		})
+	}
+}
+
+func TestCombineInvalidShares(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		shares [][]byte
+	}{
+		{name: "single share", shares: shares[:1]},
+		{name: "duplicate share", shares: [][]byte{shares[0], shares[0]}},
+		{name: "different length", shares: [][]byte{shares[0], shares[1][1:]}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Combine(test.shares)
+			require.ErrorIs(t, err, ErrInvalidShares)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Melikhov-p/goph-keeper/internal/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret секрет из тестовых векторов RFC 6238 для SHA1.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestCode(t *testing.T) {
+	// Ожидаемые значения последние 6 цифр 8-значных кодов из приложения B RFC 6238
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.code, func(t *testing.T) {
+			assert.Equal(t, test.code, totp.Code(rfcSecret, totp.Step(time.Unix(test.unix, 0))))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := totp.Step(now)
+
+	testCases := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: totp.Code(rfcSecret, step), wantStep: step, wantOK: true},
+		{name: "with spaces", code: " 005 924 ", wantStep: step, wantOK: true},
+		{name: "previous step", code: totp.Code(rfcSecret, step-1), wantStep: step - 1, wantOK: true},
+		{name: "next step", code: totp.Code(rfcSecret, step+1), wantStep: step + 1, wantOK: true},
+		{name: "outside window", code: totp.Code(rfcSecret, step-2)},
+		{name: "already used", code: totp.Code(rfcSecret, step), lastStep: step},
+		{name: "wrong code", code: "000000"},
+		{name: "wrong length", code: "12345"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			gotStep, ok := totp.Validate(rfcSecret, test.code, now, test.lastStep)
+			require.Equal(t, test.wantOK, ok)
+			if test.wantOK {
+				assert.Equal(t, test.wantStep, gotStep)
+			}
+		})
+	}
+}
+
+func TestURI(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	uri, err := url.Parse(totp.URI("GophKeeper", "john doe", secret))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/GophKeeper:john doe", uri.Path)
+	assert.Equal(t, totp.EncodeSecret(secret), uri.Query().Get("secret"))
+	assert.Equal(t, "GophKeeper", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeleteAccount запрос удаления аккаунта после подтверждения паролем. Все сессии пользователя,
+// включая текущую, завершаются; данные удаляются окончательно по истечении периода отмены.
+func (us *UserServer) DeleteAccount(
+	ctx context.Context,
+	in *pb.DeleteAccountRequest,
+) (*pb.DeleteAccountResponse, error) {
+	userID, _, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := us.service.GetUserByID(ctx, userID)
+	if err != nil {
+		us.log.Error("error getting user for account deletion", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to delete account")
+	}
+
+	key := lockout.Key(lockout.KindLogin, current.Login)
+	if err = us.lockouts.Check(ctx, key); err != nil {
+		if errors.Is(err, lockout.ErrThrottled) {
+			us.log.Warn("account deletion throttled", zap.Error(err), zap.Int("UserID", userID))
+			return nil, throttledStatus(err)
+		}
+		us.log.Error("error checking login attempts", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete account")
+	}
+
+	u, err := us.service.ScheduleDeletion(
+		ctx, userID, in.GetPassword(), us.cfg.Security.AccountDeletion.GracePeriod,
+	)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			us.log.Warn("invalid password on account deletion", zap.Int("UserID", userID))
+			if err = us.lockouts.Fail(ctx, key); err != nil {
+				us.log.Error("error recording failed account deletion", zap.Error(err))
+			}
+			return nil, status.Error(codes.Unauthenticated, "invalid password")
+		}
+		us.log.Error("error scheduling account deletion", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to delete account")
+	}
+
+	if err = us.lockouts.Succeed(ctx, key); err != nil {
+		us.log.Error("error resetting login attempts", zap.Error(err), zap.Int("UserID", userID))
+	}
+
+	us.log.Info("account deletion scheduled", zap.Int("UserID", userID), zap.Time("DeleteAfter", u.DeleteAfter))
+
+	if _, err = us.sessions.RevokeOthers(ctx, userID, ""); err != nil {
+		us.log.Error("error revoking sessions after account deletion", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "account deletion scheduled, but failed to revoke sessions")
+	}
+
+	return &pb.DeleteAccountResponse{DeleteAfter: timestamppb.New(u.DeleteAfter)}, nil
+}
+
+// CancelAccountDeletion отмена запрошенного удаления аккаунта до его окончательного удаления.
+func (us *UserServer) CancelAccountDeletion(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	userID, _, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.service.CancelDeletion(ctx, userID); err != nil {
+		if errors.Is(err, user.ErrDeletionNotScheduled) {
+			return nil, status.Error(codes.FailedPrecondition, "account deletion is not scheduled")
+		}
+		us.log.Error("error canceling account deletion", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to cancel account deletion")
+	}
+
+	us.log.Info("account deletion canceled", zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
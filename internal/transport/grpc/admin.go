@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LockoutService интерфейс сервиса защиты входа от перебора.
+type LockoutService interface {
+	Check(ctx context.Context, keys ...string) error
+	Fail(ctx context.Context, keys ...string) error
+	Succeed(ctx context.Context, key string) error
+	ListBlocked(ctx context.Context) ([]*lockout.Attempt, error)
+	Clear(ctx context.Context, key string) error
+}
+
+// UserAdminService управление пользователями и приглашениями.
+type UserAdminService interface {
+	GetUserByID(ctx context.Context, userID int) (*user.User, error)
+	List(ctx context.Context) ([]*user.User, error)
+	CreateInvite(ctx context.Context, createdBy int, note string, ttl time.Duration) (*user.Invite, string, error)
+	SetDisabled(ctx context.Context, actorID, userID int, disabled bool) error
+	SetAdmin(ctx context.Context, actorID, userID int, admin bool) error
+	PasswordHashReport(ctx context.Context) (*user.HashReport, error)
+}
+
+// SessionRevoker отзыв сессий пользователя.
+type SessionRevoker interface {
+	RevokeOthers(ctx context.Context, userID int, keepID string) (int, error)
+}
+
+// AdminServer gRPC обработчик административных методов, доступных по токену оператора
+// или пользователю с правами администратора.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	lockouts LockoutService
+	users    UserAdminService
+	sessions SessionRevoker
+	log      *zap.Logger
+	cfg      *config.Config
+}
+
+// NewAdminServer новый gRPC обработчик административных методов.
+func NewAdminServer(
+	ls LockoutService,
+	us UserAdminService,
+	ss SessionRevoker,
+	log *zap.Logger,
+	cfg *config.Config,
+) *AdminServer {
+	return &AdminServer{
+		lockouts: ls,
+		users:    us,
+		sessions: ss,
+		log:      log,
+		cfg:      cfg,
+	}
+}
+
+// authorize проверка доступа к административному методу. Запрос без токена доступа должен нести токен
+// оператора (actorID 0), с токеном доступа пользователь должен быть администратором.
+func (as *AdminServer) authorize(ctx context.Context) (int, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return 0, checkOperatorToken(ctx, as.cfg)
+	}
+
+	u, err := as.users.GetUserByID(ctx, userID)
+	if err != nil {
+		as.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return 0, status.Error(codes.Internal, "failed to check administrator rights")
+	}
+	if !u.IsAdmin {
+		return 0, status.Error(codes.PermissionDenied, "administrator rights required")
+	}
+
+	return userID, nil
+}
+
+// ListLockouts ключи, попытки входа по которым сейчас запрещены.
+func (as *AdminServer) ListLockouts(ctx context.Context, _ *emptypb.Empty) (*pb.ListLockoutsResponse, error) {
+	if _, err := as.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	attempts, err := as.lockouts.ListBlocked(ctx)
+	if err != nil {
+		as.log.Error("error listing lockouts", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list lockouts")
+	}
+
+	res := pb.ListLockoutsResponse{Lockouts: make([]*pb.Lockout, 0, len(attempts))}
+	for _, a := range attempts {
+		res.Lockouts = append(res.Lockouts, &pb.Lockout{
+			Key:           a.Key,
+			Failures:      uint32(max(a.Failures, 0)),
+			LastFailureAt: timestamppb.New(a.LastFailureAt),
+			BlockedUntil:  timestamppb.New(a.BlockedUntil),
+			Locked:        a.Locked,
+		})
+	}
+
+	return &res, nil
+}
+
+// ClearLockout снятие блокировки по ключу.
+func (as *AdminServer) ClearLockout(ctx context.Context, in *pb.ClearLockoutRequest) (*emptypb.Empty, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = as.lockouts.Clear(ctx, in.GetKey()); err != nil {
+		if errors.Is(err, lockout.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "lockout not found")
+		}
+		as.log.Error("error clearing lockout", zap.Error(err), zap.String("Key", in.GetKey()))
+		return nil, status.Error(codes.Internal, "failed to clear lockout")
+	}
+
+	as.log.Info("lockout cleared", zap.String("Key", in.GetKey()), zap.Int("ActorID", actorID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetPasswordHashReport число аккаунтов по схемам хэширования и перцам паролей.
+//
+//nolint:gosec // число аккаунтов неотрицательно
+func (as *AdminServer) GetPasswordHashReport(ctx context.Context, _ *emptypb.Empty) (*pb.PasswordHashReport, error) {
+	if _, err := as.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	report, err := as.users.PasswordHashReport(ctx)
+	if err != nil {
+		as.log.Error("error building password hash report", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to build password hash report")
+	}
+
+	res := pb.PasswordHashReport{
+		Counts: make([]*pb.PasswordHashCount, 0, len(report.Counts)),
+		Total:  uint32(report.Total),
+		Legacy: uint32(report.Legacy),
+	}
+	for _, c := range report.Counts {
+		res.Counts = append(res.Counts, &pb.PasswordHashCount{
+			Scheme:   string(c.Scheme),
+			PepperId: c.PepperID,
+			Users:    uint32(c.Users),
+			Current:  c.Current,
+		})
+	}
+
+	return &res, nil
+}
+
+// throttledStatus ответ на попытку при действующей задержке или блокировке.
+func throttledStatus(err error) error {
+	var throttled *lockout.ThrottledError
+	if errors.As(err, &throttled) {
+		return status.Error(codes.ResourceExhausted, throttled.Error())
+	}
+
+	return status.Error(codes.ResourceExhausted, lockout.ErrThrottled.Error())
+}
@@ -0,0 +1,174 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/util"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateInvite создание одноразового приглашения на регистрацию. Код возвращается только в этом ответе.
+func (as *AdminServer) CreateInvite(ctx context.Context, in *pb.CreateInviteRequest) (*pb.CreateInviteResponse, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := as.cfg.Security.Registration.InviteTTL
+	if in.GetTtlHours() > 0 {
+		ttl = time.Duration(in.GetTtlHours()) * time.Hour
+	}
+
+	inv, code, err := as.users.CreateInvite(ctx, actorID, in.GetNote(), ttl)
+	if err != nil {
+		as.log.Error("error creating invite", zap.Error(err), zap.Int("ActorID", actorID))
+		return nil, status.Error(codes.Internal, "failed to create invite")
+	}
+
+	as.log.Info("invite created", zap.Int("InviteID", inv.ID), zap.Int("ActorID", actorID))
+
+	return &pb.CreateInviteResponse{
+		Code:      code,
+		ExpiresAt: timestamppb.New(inv.ExpiresAt),
+	}, nil
+}
+
+// ListUsers все пользователи сервера.
+func (as *AdminServer) ListUsers(ctx context.Context, _ *emptypb.Empty) (*pb.ListUsersResponse, error) {
+	if _, err := as.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	users, err := as.users.List(ctx)
+	if err != nil {
+		as.log.Error("error listing users", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list users")
+	}
+
+	res := pb.ListUsersResponse{Users: make([]*pb.AdminUser, 0, len(users))}
+	for _, u := range users {
+		id, err := util.SafeConvertToInt32(u.ID)
+		if err != nil {
+			as.log.Error("error convert userID to int32", zap.Error(err), zap.Int("UserID", u.ID))
+			return nil, status.Error(codes.Internal, "failed to build response")
+		}
+
+		au := pb.AdminUser{
+			Id:             id,
+			Login:          u.Login,
+			IsAdmin:        u.IsAdmin,
+			EncryptionMode: encryptionModeToPB(u.EncryptionMode),
+			CreatedAt:      timestamppb.New(u.CreatedAt),
+		}
+		if u.Disabled() {
+			au.DisabledAt = timestamppb.New(u.DisabledAt)
+		}
+		if u.DeletionScheduled() {
+			au.DeleteAfter = timestamppb.New(u.DeleteAfter)
+		}
+		res.Users = append(res.Users, &au)
+	}
+
+	return &res, nil
+}
+
+// DisableUser блокировка пользователя: все его сессии отзываются, а токены доступа перестают приниматься сразу.
+func (as *AdminServer) DisableUser(ctx context.Context, in *pb.AdminUserRequest) (*pb.ForceLogoutResponse, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := int(in.GetUserId())
+	if err = as.users.SetDisabled(ctx, actorID, userID, true); err != nil {
+		return nil, as.userAdminError("error disabling user", err, userID)
+	}
+
+	as.log.Info("user disabled", zap.Int("UserID", userID), zap.Int("ActorID", actorID))
+
+	return as.revokeSessions(ctx, userID)
+}
+
+// EnableUser снятие блокировки пользователя. Отозванные при блокировке сессии не восстанавливаются.
+func (as *AdminServer) EnableUser(ctx context.Context, in *pb.AdminUserRequest) (*emptypb.Empty, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := int(in.GetUserId())
+	if err = as.users.SetDisabled(ctx, actorID, userID, false); err != nil {
+		return nil, as.userAdminError("error enabling user", err, userID)
+	}
+
+	as.log.Info("user enabled", zap.Int("UserID", userID), zap.Int("ActorID", actorID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// ForceLogout отзыв всех сессий пользователя без блокировки: войти снова можно сразу.
+func (as *AdminServer) ForceLogout(ctx context.Context, in *pb.AdminUserRequest) (*pb.ForceLogoutResponse, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := int(in.GetUserId())
+	if _, err = as.users.GetUserByID(ctx, userID); err != nil {
+		return nil, as.userAdminError("error getting user by id", err, userID)
+	}
+
+	as.log.Info("user logged out by administrator", zap.Int("UserID", userID), zap.Int("ActorID", actorID))
+
+	return as.revokeSessions(ctx, userID)
+}
+
+// SetAdmin выдача или снятие прав администратора. Первого администратора назначает оператор.
+func (as *AdminServer) SetAdmin(ctx context.Context, in *pb.SetAdminRequest) (*emptypb.Empty, error) {
+	actorID, err := as.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := int(in.GetUserId())
+	if err = as.users.SetAdmin(ctx, actorID, userID, in.GetAdmin()); err != nil {
+		return nil, as.userAdminError("error changing administrator rights", err, userID)
+	}
+
+	as.log.Info("administrator rights changed",
+		zap.Int("UserID", userID), zap.Bool("Admin", in.GetAdmin()), zap.Int("ActorID", actorID))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (as *AdminServer) revokeSessions(ctx context.Context, userID int) (*pb.ForceLogoutResponse, error) {
+	n, err := as.sessions.RevokeOthers(ctx, userID, "")
+	if err != nil {
+		as.log.Error("error revoking user sessions", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to revoke sessions")
+	}
+
+	//nolint:gosec // число сессий неотрицательно
+	return &pb.ForceLogoutResponse{Revoked: uint32(n)}, nil
+}
+
+// userAdminError ответ на ошибку управления пользователем.
+func (as *AdminServer) userAdminError(msg string, err error, userID int) error {
+	switch {
+	case errors.Is(err, user.ErrNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, user.ErrSelfAdminAction):
+		return status.Error(codes.FailedPrecondition, user.ErrSelfAdminAction.Error())
+	default:
+		as.log.Error(msg, zap.Error(err), zap.Int("UserID", userID))
+		return status.Error(codes.Internal, "failed to update user")
+	}
+}
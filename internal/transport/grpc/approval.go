@@ -0,0 +1,289 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/approval"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ApprovalService методы одобрения доступа к критичным секретам.
+type ApprovalService interface {
+	SetPolicy(
+		ctx context.Context,
+		ownerID, secretID int,
+		approvers []approval.Approver,
+		grant time.Duration,
+	) (*approval.Policy, error)
+	GetPolicy(ctx context.Context, userID, secretID int) (*approval.Policy, error)
+	RemovePolicy(ctx context.Context, ownerID, secretID int) error
+	RequestAccess(ctx context.Context, requesterID, secretID int, reason string) (*approval.Request, error)
+	Approve(ctx context.Context, approverID, requestID int) (*approval.Request, error)
+	Deny(ctx context.Context, approverID, requestID int) (*approval.Request, error)
+	ListRequests(ctx context.Context, userID int) ([]*approval.Request, error)
+	Events(ctx context.Context, ownerID, secretID int) ([]*approval.Event, error)
+}
+
+// SetApprovalPolicy включение или изменение политики одобрения доступа к собственному секрету.
+func (ss *SecretServer) SetApprovalPolicy(
+	ctx context.Context,
+	in *pb.SetApprovalPolicyRequest,
+) (*pb.ApprovalPolicy, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := make([]approval.Approver, 0, len(in.GetApproverLogins()))
+	for _, login := range in.GetApproverLogins() {
+		u, err := ss.userProvider.GetUserByLogin(ctx, login)
+		if err != nil {
+			if errors.Is(err, user.ErrNotFound) {
+				return nil, status.Errorf(codes.NotFound, "approver %s not found", login)
+			}
+			ss.log.Error("error getting approver by login", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to set approval policy")
+		}
+		approvers = append(approvers, approval.Approver{UserID: u.ID, Login: u.Login})
+	}
+
+	p, err := ss.approvals.SetPolicy(
+		ctx, userID, int(in.GetSecretId()), approvers, time.Duration(in.GetGrantMinutes())*time.Minute,
+	)
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to set approval policy")
+	}
+
+	ss.log.Info("approval policy set",
+		zap.Int("SecretID", p.SecretID), zap.Int("UserID", userID), zap.Int("Approvers", len(p.Approvers)))
+
+	return policyToPB(p), nil
+}
+
+// GetApprovalPolicy политика одобрения секрета, доступного пользователю.
+func (ss *SecretServer) GetApprovalPolicy(
+	ctx context.Context,
+	in *pb.ApprovalPolicyRequest,
+) (*pb.ApprovalPolicy, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := ss.approvals.GetPolicy(ctx, userID, int(in.GetSecretId()))
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to get approval policy")
+	}
+
+	return policyToPB(p), nil
+}
+
+// RemoveApprovalPolicy отключение политики одобрения доступа к собственному секрету.
+func (ss *SecretServer) RemoveApprovalPolicy(
+	ctx context.Context,
+	in *pb.ApprovalPolicyRequest,
+) (*emptypb.Empty, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ss.approvals.RemovePolicy(ctx, userID, int(in.GetSecretId())); err != nil {
+		return nil, ss.approvalError(err, userID, "failed to remove approval policy")
+	}
+
+	ss.log.Info("approval policy removed", zap.Int64("SecretID", in.GetSecretId()), zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// RequestAccess запрос доступа к секрету с политикой одобрения.
+func (ss *SecretServer) RequestAccess(
+	ctx context.Context,
+	in *pb.RequestSecretAccessRequest,
+) (*pb.SecretAccessRequest, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := ss.approvals.RequestAccess(ctx, userID, int(in.GetSecretId()), in.GetReason())
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to request access")
+	}
+
+	ss.log.Info("secret access requested",
+		zap.Int("RequestID", r.ID), zap.Int("SecretID", r.SecretID), zap.Int("UserID", userID))
+
+	return accessRequestToPB(r), nil
+}
+
+// ApproveAccess одобрение запроса утверждающим.
+func (ss *SecretServer) ApproveAccess(
+	ctx context.Context,
+	in *pb.DecideAccessRequest,
+) (*pb.SecretAccessRequest, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := ss.approvals.Approve(ctx, userID, int(in.GetRequestId()))
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to approve access")
+	}
+
+	ss.log.Info("secret access approved",
+		zap.Int("RequestID", r.ID), zap.Int("SecretID", r.SecretID), zap.Int("UserID", userID),
+		zap.Time("GrantedUntil", r.GrantedUntil))
+
+	return accessRequestToPB(r), nil
+}
+
+// DenyAccess отказ утверждающего в запросе.
+func (ss *SecretServer) DenyAccess(
+	ctx context.Context,
+	in *pb.DecideAccessRequest,
+) (*pb.SecretAccessRequest, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := ss.approvals.Deny(ctx, userID, int(in.GetRequestId()))
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to deny access")
+	}
+
+	ss.log.Info("secret access denied",
+		zap.Int("RequestID", r.ID), zap.Int("SecretID", r.SecretID), zap.Int("UserID", userID))
+
+	return accessRequestToPB(r), nil
+}
+
+// ListAccessRequests запросы пользователя и запросы к секретам, где он утверждающий.
+func (ss *SecretServer) ListAccessRequests(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.ListAccessRequestsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := ss.approvals.ListRequests(ctx, userID)
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to list access requests")
+	}
+
+	res := pb.ListAccessRequestsResponse{Requests: make([]*pb.SecretAccessRequest, 0, len(requests))}
+	for _, r := range requests {
+		res.Requests = append(res.Requests, accessRequestToPB(r))
+	}
+
+	return &res, nil
+}
+
+// ListAccessEvents журнал доступа к собственному секрету.
+func (ss *SecretServer) ListAccessEvents(
+	ctx context.Context,
+	in *pb.ApprovalPolicyRequest,
+) (*pb.ListAccessEventsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := ss.approvals.Events(ctx, userID, int(in.GetSecretId()))
+	if err != nil {
+		return nil, ss.approvalError(err, userID, "failed to list access events")
+	}
+
+	res := pb.ListAccessEventsResponse{Events: make([]*pb.SecretAccessEvent, 0, len(events))}
+	for _, ev := range events {
+		res.Events = append(res.Events, &pb.SecretAccessEvent{
+			RequestId:  int64(ev.RequestID),
+			ActorLogin: ev.ActorLogin,
+			Action:     string(ev.Action),
+			CreatedAt:  timestamppb.New(ev.CreatedAt),
+		})
+	}
+
+	return &res, nil
+}
+
+// approvalError ошибка домена одобрения доступа в статус gRPC.
+func (ss *SecretServer) approvalError(err error, userID int, msg string) error {
+	switch {
+	case errors.Is(err, approval.ErrNotFound), errors.Is(err, approval.ErrNoPolicy):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, approval.ErrNoApprovers), errors.Is(err, approval.ErrOwnerApprover),
+		errors.Is(err, approval.ErrInvalidGrant):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, approval.ErrAlreadyRequested), errors.Is(err, approval.ErrNotPending):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, approval.ErrNotApprover), errors.Is(err, approval.ErrSelfApproval):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, approval.ErrStateChanged):
+		return status.Error(codes.Aborted, "access request changed concurrently, retry the request")
+	default:
+		ss.log.Error(msg, zap.Error(err), zap.Int("UserID", userID))
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+func policyToPB(p *approval.Policy) *pb.ApprovalPolicy {
+	res := &pb.ApprovalPolicy{
+		SecretId:       int64(p.SecretID),
+		ApproverLogins: make([]string, 0, len(p.Approvers)),
+		GrantMinutes:   uint32(p.Grant / time.Minute), //nolint:gosec // окно доступа ограничено конфигом
+		UpdatedAt:      timestamppb.New(p.UpdatedAt),
+	}
+	for _, a := range p.Approvers {
+		res.ApproverLogins = append(res.ApproverLogins, a.Login)
+	}
+
+	return res
+}
+
+// accessRequestToPB запрос доступа в ответ клиенту. Запрос без ответа после истечения срока
+// отдается как ACCESS_REQUEST_EXPIRED.
+func accessRequestToPB(r *approval.Request) *pb.SecretAccessRequest {
+	res := &pb.SecretAccessRequest{
+		Id:             int64(r.ID),
+		SecretId:       int64(r.SecretID),
+		RequesterLogin: r.RequesterLogin,
+		Reason:         r.Reason,
+		DecidedByLogin: r.DeciderLogin,
+		ExpiresAt:      timestamppb.New(r.ExpiresAt),
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+
+	switch {
+	case r.Expired(time.Now()):
+		res.Status = pb.AccessRequestStatus_ACCESS_REQUEST_EXPIRED
+	case r.Status == approval.StatusApproved:
+		res.Status = pb.AccessRequestStatus_ACCESS_REQUEST_APPROVED
+	case r.Status == approval.StatusDenied:
+		res.Status = pb.AccessRequestStatus_ACCESS_REQUEST_DENIED
+	default:
+		res.Status = pb.AccessRequestStatus_ACCESS_REQUEST_PENDING
+	}
+
+	if !r.DecidedAt.IsZero() {
+		res.DecidedAt = timestamppb.New(r.DecidedAt)
+	}
+	if !r.GrantedUntil.IsZero() {
+		res.GrantedUntil = timestamppb.New(r.GrantedUntil)
+	}
+
+	return res
+}
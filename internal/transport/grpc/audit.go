@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/audit"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AuditService выборка журнала аудита.
+type AuditService interface {
+	Query(ctx context.Context, f audit.Filter) (*audit.Page, error)
+}
+
+// AuditServer обработчик запросов к журналу аудита.
+type AuditServer struct {
+	pb.UnimplementedAuditServiceServer
+	auditService AuditService
+	userProvider UserProvider
+	log          *zap.Logger
+}
+
+// NewAuditServer получение обработчика запросов к журналу аудита.
+func NewAuditServer(aS AuditService, uP UserProvider, l *zap.Logger) *AuditServer {
+	return &AuditServer{
+		auditService: aS,
+		userProvider: uP,
+		log:          l,
+	}
+}
+
+// QueryAuditLog страница журнала аудита. Пользователь получает записи, где он автор или субъект операции,
+// например, чтение его общего секрета получателем. Администратор может запросить записи другого пользователя
+// по логину или всех пользователей.
+func (as *AuditServer) QueryAuditLog(
+	ctx context.Context,
+	in *pb.QueryAuditLogRequest,
+) (*pb.QueryAuditLogResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f := audit.Filter{
+		UserID:   userID,
+		Action:   audit.Action(in.GetAction()),
+		SecretID: int(in.GetSecretId()), //nolint:gosec // ID секрета помещается в int
+		Result:   audit.Result(in.GetResult()),
+		BeforeID: int(in.GetPageToken()), //nolint:gosec // курсор это ID записи журнала
+		Limit:    int(in.GetPageSize()),
+	}
+	if in.GetFrom() != nil {
+		f.From = in.GetFrom().AsTime()
+	}
+	if in.GetTo() != nil {
+		f.To = in.GetTo().AsTime()
+	}
+
+	if in.GetAllUsers() || in.GetActorLogin() != "" {
+		if f.UserID, err = as.adminScope(ctx, userID, in); err != nil {
+			return nil, err
+		}
+	}
+
+	page, err := as.auditService.Query(ctx, f)
+	if err != nil {
+		if errors.Is(err, audit.ErrInvalidFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		as.log.Error("error querying audit log", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to query audit log")
+	}
+
+	res := pb.QueryAuditLogResponse{
+		Events:        make([]*pb.AuditEvent, 0, len(page.Events)),
+		NextPageToken: int64(page.NextBeforeID),
+	}
+	for _, ev := range page.Events {
+		res.Events = append(res.Events, &pb.AuditEvent{
+			Id:         int64(ev.ID),
+			Login:      ev.Login,
+			ActorLogin: ev.ActorLogin,
+			Action:     string(ev.Action),
+			SecretId:   int64(ev.SecretID),
+			ClientIp:   ev.ClientIP,
+			UserAgent:  ev.UserAgent,
+			Result:     string(ev.Result),
+			CreatedAt:  timestamppb.New(ev.CreatedAt),
+		})
+	}
+
+	return &res, nil
+}
+
+// adminScope пользователь записей для запроса администратора: 0 для всех пользователей, иначе ID пользователя
+// с логином in.ActorLogin.
+func (as *AuditServer) adminScope(ctx context.Context, userID int, in *pb.QueryAuditLogRequest) (int, error) {
+	u, err := as.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		as.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return 0, status.Error(codes.Internal, "failed to check administrator rights")
+	}
+	if !u.IsAdmin {
+		return 0, status.Error(codes.PermissionDenied, "administrator rights required")
+	}
+
+	if in.GetActorLogin() == "" {
+		return 0, nil
+	}
+
+	actor, err := as.userProvider.GetUserByLogin(ctx, in.GetActorLogin())
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return 0, status.Errorf(codes.NotFound, "user %s not found", in.GetActorLogin())
+		}
+		as.log.Error("error getting user by login", zap.Error(err))
+		return 0, status.Error(codes.Internal, "failed to query audit log")
+	}
+
+	return actor.ID, nil
+}
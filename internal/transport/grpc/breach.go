@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	warningBreached = "this password appeared in a data breach"
+	// maxBreachPrefixes ограничение числа диапазонов в одном запросе.
+	maxBreachPrefixes = 1000
+)
+
+// BreachChecker проверка паролей по локальной базе утечек.
+type BreachChecker interface {
+	CheckPassword(password string) (uint32, error)
+	Range(prefix string) ([]breach.Match, error)
+}
+
+// CheckBreaches массовая проверка паролей по локальной базе утечек: диапазоны хешей по префиксам
+// для проверки на клиенте и, по запросу, все сохраненные пароли, которые сервер может расшифровать.
+func (ss *SecretServer) CheckBreaches(
+	ctx context.Context,
+	in *pb.CheckBreachesRequest,
+) (*pb.CheckBreachesResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+	if ss.breaches == nil {
+		return nil, status.Error(codes.FailedPrecondition, "breach database is not configured")
+	}
+	if len(in.GetPrefixes()) > maxBreachPrefixes {
+		return nil, status.Errorf(codes.InvalidArgument, "too many prefixes, at most %d allowed", maxBreachPrefixes)
+	}
+
+	var res pb.CheckBreachesResponse
+	for _, prefix := range in.GetPrefixes() {
+		matches, err := ss.breaches.Range(prefix)
+		if err != nil {
+			if errors.Is(err, breach.ErrInvalidPrefix) {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			ss.log.Error("error reading breach range", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to check breaches")
+		}
+
+		r := pb.BreachRange{Prefix: prefix, Matches: make([]*pb.BreachMatch, 0, len(matches))}
+		for _, m := range matches {
+			r.Matches = append(r.Matches, &pb.BreachMatch{Suffix: m.Suffix, Count: m.Count})
+		}
+		res.Ranges = append(res.Ranges, &r)
+	}
+
+	if !in.GetCheckStored() {
+		return &res, nil
+	}
+
+	u, err := ss.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		ss.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
+
+	secrets, err := ss.secretService.GetUserCredentials(ctx, u)
+	if err != nil {
+		ss.log.Error("error loading secrets for breach check", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to check breaches")
+	}
+
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+
+		data, ok := sec.Data.(*secret.PasswordData)
+		if sec.Type != secret.TypePassword || sec.ClientEncrypted || sec.ApprovalRequired || !ok {
+			continue
+		}
+
+		res.Checked++
+		if count := ss.breachCount(data.Pass.Reveal()); count > 0 {
+			res.Secrets = append(res.Secrets, &pb.BreachedSecret{
+				Name:        sec.Name,
+				Username:    data.Username,
+				Url:         data.URL,
+				BreachCount: count,
+			})
+		}
+	}
+
+	return &res, nil
+}
+
+// breachCount число появлений пароля в базе утечек. Ошибка чтения базы не мешает основному запросу.
+func (ss *SecretServer) breachCount(password string) uint32 {
+	if ss.breaches == nil || password == "" {
+		return 0
+	}
+
+	count, err := ss.breaches.CheckPassword(password)
+	if err != nil {
+		ss.log.Error("error checking password against breach database", zap.Error(err))
+		return 0
+	}
+
+	return count
+}
+
+// markBreached отметка пароля, найденного в базе утечек, в ответе клиенту.
+func (ss *SecretServer) markBreached(s *pb.GetSecret) *pb.GetSecret {
+	data := s.GetPasswordData()
+	if data == nil || s.GetClientEncrypted() {
+		return s
+	}
+
+	data.BreachCount = ss.breachCount(data.GetPassword())
+	data.Breached = data.GetBreachCount() > 0
+
+	return s
+}
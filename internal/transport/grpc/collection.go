@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// createCollectionSecret создание секрета в коллекции организации.
+func (ss *SecretServer) createCollectionSecret(
+	ctx context.Context,
+	u *user.User,
+	in *pb.CreateSecretRequest,
+) (*pb.CreateSecretResponse, error) {
+	var (
+		s   *secret.Secret
+		res pb.CreateSecretResponse
+		err error
+	)
+
+	collectionID := int(in.GetCollectionId())
+
+	switch in.GetType() {
+	case secretTypePassword:
+		data := in.GetPasswordData()
+		s, err = ss.secretService.CreateCollectionPassword(
+			ctx, u, collectionID,
+			in.GetName(), data.GetUsername(), data.GetPassword(), data.GetUrl(), data.GetNotes(), data.GetMetaData(),
+		)
+		if err == nil {
+			res.PasswordStrength = ss.passwordStrength(data.GetPassword(), data.GetUsername(), data.GetUrl(), u.Login)
+		}
+	case secretTypeCard:
+		data := in.GetCardData()
+		s, err = ss.secretService.CreateCollectionCard(
+			ctx, u, collectionID,
+			in.GetName(), data.GetNumber(), data.GetOwner(),
+			data.GetExpireDate(), data.GetCVV(), data.GetNotes(),
+			data.GetMetaData(),
+		)
+	case secretTypeBinary:
+		data := in.GetBinaryData()
+		if len(data.GetContent()) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "file content is empty")
+		}
+		s, err = ss.secretService.CreateCollectionFile(
+			ctx, u, collectionID, in.GetName(), data.GetFilename(), data.GetNotes(),
+			bytes.NewReader(data.GetContent()), data.GetMetaData(),
+		)
+	default:
+		ss.log.Warn("invalid secret type", zap.Any("type", in.GetType()))
+		return nil, status.Error(codes.InvalidArgument, "invalid secret type")
+	}
+	if err != nil {
+		return nil, ss.collectionError(err, u, collectionID, "failed to create collection secret")
+	}
+
+	ss.log.Info("collection secret created",
+		zap.Int("SecretID", s.ID), zap.Int("CollectionID", collectionID), zap.Int("UserID", u.ID))
+
+	res.Id = int64(s.ID)
+
+	return &res, nil
+}
+
+// getCollectionSecrets секреты коллекции: по названию с данными или все без данных.
+func (ss *SecretServer) getCollectionSecrets(
+	ctx context.Context,
+	u *user.User,
+	in *pb.GetSecretRequest,
+) (*pb.GetSecretResponse, error) {
+	collectionID := int(in.GetCollectionId())
+
+	secrets, err := ss.secretService.GetCollectionSecrets(ctx, u, collectionID, in.GetName())
+	if err != nil {
+		return nil, ss.collectionError(err, u, collectionID, "failed to get collection secrets")
+	}
+
+	if in.GetName() == "" {
+		return getAllUserSecrets(secrets, "")
+	}
+
+	if len(secrets) == 0 {
+		return nil, status.Error(codes.NotFound, "secrets not found")
+	}
+
+	// Расшифрованные данные затираются после отправки ответа
+	res := pb.GetSecretResponse{Secrets: make([]*pb.GetSecret, 0, len(secrets))}
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+		pbSecret, err := secretToPB(sec, "")
+		if err != nil {
+			ss.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
+		}
+		res.Secrets = append(res.Secrets, ss.markBreached(pbSecret))
+	}
+
+	return &res, nil
+}
+
+// DeleteCollectionSecret удаление секрета коллекции организации.
+func (ss *SecretServer) DeleteCollectionSecret(
+	ctx context.Context,
+	in *pb.DeleteCollectionSecretRequest,
+) (*emptypb.Empty, error) {
+	u, err := ss.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionID := int(in.GetCollectionId())
+
+	if err = ss.secretService.DeleteCollectionSecret(ctx, u, collectionID, int(in.GetSecretId())); err != nil {
+		if errors.Is(err, secret.ErrSecretNotFound) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+		return nil, ss.collectionError(err, u, collectionID, "failed to delete collection secret")
+	}
+
+	ss.log.Info("collection secret deleted",
+		zap.Int64("SecretID", in.GetSecretId()), zap.Int("CollectionID", collectionID), zap.Int("UserID", u.ID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// collectionError ошибка операции с секретами коллекции в ответ клиенту.
+func (ss *SecretServer) collectionError(err error, u *user.User, collectionID int, msg string) error {
+	switch {
+	case errors.Is(err, secret.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, "collection not found")
+	case errors.Is(err, secret.ErrCollectionReadOnly), errors.Is(err, secret.ErrCollectionDeleteDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, secret.ErrKeyRotated):
+		return status.Error(codes.Aborted, "collection key was rotated, retry the request")
+	default:
+		ss.log.Error(msg, zap.Error(err), zap.Int("UserID", u.ID), zap.Int("CollectionID", collectionID))
+		return status.Error(codes.Internal, msg)
+	}
+}
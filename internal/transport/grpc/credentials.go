@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateCredentials смена логина и/или пароля. Неверный старый пароль считается неудачной попыткой входа
+// для текущего логина, после успешной смены все остальные сессии пользователя отзываются.
+func (us *UserServer) UpdateCredentials(
+	ctx context.Context,
+	in *pb.UpdateCredentialsRequest,
+) (*pb.UpdateCredentialsResponse, error) {
+	userID, sessionID, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := us.service.GetUserByID(ctx, userID)
+	if err != nil {
+		us.log.Error("error getting user for credentials update", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to update credentials")
+	}
+
+	key := lockout.Key(lockout.KindLogin, current.Login)
+	if err = us.lockouts.Check(ctx, key); err != nil {
+		if errors.Is(err, lockout.ErrThrottled) {
+			us.log.Warn("credentials update throttled", zap.Error(err), zap.Int("UserID", userID))
+			return nil, throttledStatus(err)
+		}
+		us.log.Error("error checking login attempts", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update credentials")
+	}
+
+	u, err := us.service.UpdateCredentials(ctx, userID, user.CredentialsUpdate{
+		NewLogin:        in.GetNewLogin(),
+		OldPassword:     in.GetOldPassword(),
+		NewPassword:     in.GetNewPassword(),
+		KDF:             kdfParamsFromPB(in.GetNewKdfParams()),
+		WrappedVaultKey: in.GetNewWrappedVaultKey(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidCredentials):
+			us.log.Warn("invalid old password on credentials update", zap.Int("UserID", userID))
+			if err = us.lockouts.Fail(ctx, key); err != nil {
+				us.log.Error("error recording failed credentials update", zap.Error(err))
+			}
+			return nil, status.Error(codes.Unauthenticated, "invalid password")
+		case errors.Is(err, user.ErrAlreadyExist):
+			return nil, status.Error(codes.AlreadyExists, "user already exist")
+		case errors.Is(err, user.ErrNothingToUpdate):
+			return nil, status.Error(codes.InvalidArgument, "new login or password is required")
+		case errors.Is(err, user.ErrInvalidE2EParams):
+			return nil, status.Error(codes.InvalidArgument, "invalid end-to-end encryption params")
+		case errors.Is(err, user.ErrWeakPassword):
+			return nil, weakPasswordStatus(err)
+		default:
+			us.log.Error("error updating credentials", zap.Error(err), zap.Int("UserID", userID))
+			return nil, status.Error(codes.Internal, "failed to update credentials")
+		}
+	}
+
+	if err = us.lockouts.Succeed(ctx, key); err != nil {
+		us.log.Error("error resetting login attempts", zap.Error(err), zap.Int("UserID", userID))
+	}
+
+	// Украденный старый пароль не должен оставлять открытых сессий
+	revoked, err := us.sessions.RevokeOthers(ctx, userID, sessionID)
+	if err != nil {
+		us.log.Error("error revoking sessions after credentials update", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "credentials updated, but failed to revoke other sessions")
+	}
+
+	res := pb.UpdateCredentialsResponse{RevokedSessions: uint32(revoked)} //nolint:gosec // число сессий неотрицательно
+	res.User, err = userToPB(u)
+	if err != nil {
+		us.log.Error("error convert userID to int32", zap.Error(err), zap.Int("UserID", u.ID))
+		return nil, status.Error(codes.Internal, "failed to build response")
+	}
+
+	return &res, nil
+}
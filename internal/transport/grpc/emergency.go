@@ -0,0 +1,407 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/emergency"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EmergencyService методы экстренного доступа.
+type EmergencyService interface {
+	Nominate(
+		ctx context.Context,
+		grantor, grantee *user.User,
+		accessType emergency.AccessType,
+		wait time.Duration,
+		wrappedVaultKey []byte,
+	) (*emergency.Contact, error)
+	ListTrusted(ctx context.Context, grantorID int) ([]*emergency.Contact, error)
+	ListGrantors(ctx context.Context, granteeID int) ([]*emergency.Contact, error)
+	Remove(ctx context.Context, actorID, contactID int) error
+	RequestAccess(ctx context.Context, granteeID, contactID int) (*emergency.Contact, error)
+	Approve(ctx context.Context, grantorID, contactID int) (*emergency.Contact, error)
+	Reject(ctx context.Context, grantorID, contactID int) (*emergency.Contact, error)
+	Vault(ctx context.Context, granteeID, contactID int) (*emergency.Contact, []*secret.Secret, error)
+	Takeover(ctx context.Context, granteeID, contactID int, upd user.CredentialsUpdate) (*emergency.Contact, error)
+	Events(ctx context.Context, userID int) ([]*emergency.Event, error)
+}
+
+// EmergencyServer обработчик запросов экстренного доступа.
+type EmergencyServer struct {
+	pb.UnimplementedEmergencyAccessServiceServer
+	emergencyService EmergencyService
+	userProvider     UserProvider
+	log              *zap.Logger
+}
+
+// NewEmergencyServer получение обработчика запросов экстренного доступа.
+func NewEmergencyServer(
+	eS EmergencyService,
+	uP UserProvider,
+	l *zap.Logger,
+) *EmergencyServer {
+	return &EmergencyServer{
+		emergencyService: eS,
+		userProvider:     uP,
+		log:              l,
+	}
+}
+
+// NominateContact назначение пользователя доверенным контактом текущего пользователя.
+func (es *EmergencyServer) NominateContact(
+	ctx context.Context,
+	in *pb.NominateContactRequest,
+) (*pb.EmergencyContact, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grantor, err := es.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		es.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
+
+	if in.GetGranteeLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "contact login is required")
+	}
+	grantee, err := es.userProvider.GetUserByLogin(ctx, in.GetGranteeLogin())
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		es.log.Error("error getting user by login", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	c, err := es.emergencyService.Nominate(
+		ctx, grantor, grantee, accessTypeFromPB(in.GetAccessType()),
+		time.Duration(in.GetWaitHours())*time.Hour, in.GetWrappedVaultKey(),
+	)
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to nominate contact")
+	}
+
+	es.log.Info("emergency contact nominated",
+		zap.Int("ContactID", c.ID), zap.Int("UserID", userID), zap.Int("GranteeID", grantee.ID))
+
+	return contactToPB(c, false), nil
+}
+
+// ListTrustedContacts доверенные контакты текущего пользователя.
+func (es *EmergencyServer) ListTrustedContacts(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.ListEmergencyContactsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := es.emergencyService.ListTrusted(ctx, userID)
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to list trusted contacts")
+	}
+
+	return contactsToPB(contacts, false), nil
+}
+
+// ListGrantors владельцы хранилищ, назначившие текущего пользователя доверенным контактом.
+func (es *EmergencyServer) ListGrantors(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.ListEmergencyContactsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := es.emergencyService.ListGrantors(ctx, userID)
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to list grantors")
+	}
+
+	return contactsToPB(contacts, true), nil
+}
+
+// RemoveContact удаление назначения владельцем или контактом.
+func (es *EmergencyServer) RemoveContact(
+	ctx context.Context,
+	in *pb.EmergencyContactRequest,
+) (*emptypb.Empty, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = es.emergencyService.Remove(ctx, userID, int(in.GetContactId())); err != nil {
+		return nil, es.emergencyError(err, userID, "failed to remove contact")
+	}
+
+	es.log.Info("emergency contact removed", zap.Int64("ContactID", in.GetContactId()), zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// RequestAccess запрос доступа к хранилищу владельца, начинается период ожидания.
+func (es *EmergencyServer) RequestAccess(
+	ctx context.Context,
+	in *pb.EmergencyContactRequest,
+) (*pb.EmergencyContact, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := es.emergencyService.RequestAccess(ctx, userID, int(in.GetContactId()))
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to request access")
+	}
+
+	es.log.Info("emergency access requested",
+		zap.Int("ContactID", c.ID), zap.Int("UserID", userID), zap.Time("AccessAt", c.AccessAt()))
+
+	return contactToPB(c, true), nil
+}
+
+// ApproveAccess одобрение запроса владельцем без ожидания.
+func (es *EmergencyServer) ApproveAccess(
+	ctx context.Context,
+	in *pb.EmergencyContactRequest,
+) (*pb.EmergencyContact, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := es.emergencyService.Approve(ctx, userID, int(in.GetContactId()))
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to approve access")
+	}
+
+	es.log.Info("emergency access approved", zap.Int("ContactID", c.ID), zap.Int("UserID", userID))
+
+	return contactToPB(c, false), nil
+}
+
+// RejectAccess отказ в запросе или отзыв открытого доступа владельцем.
+func (es *EmergencyServer) RejectAccess(
+	ctx context.Context,
+	in *pb.EmergencyContactRequest,
+) (*pb.EmergencyContact, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := es.emergencyService.Reject(ctx, userID, int(in.GetContactId()))
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to reject access")
+	}
+
+	es.log.Info("emergency access rejected", zap.Int("ContactID", c.ID), zap.Int("UserID", userID))
+
+	return contactToPB(c, false), nil
+}
+
+// GetEmergencyVault секреты владельца для контакта с открытым доступом.
+func (es *EmergencyServer) GetEmergencyVault(
+	ctx context.Context,
+	in *pb.EmergencyContactRequest,
+) (*pb.EmergencyVaultResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, secrets, err := es.emergencyService.Vault(ctx, userID, int(in.GetContactId()))
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to get emergency vault")
+	}
+
+	es.log.Info("emergency vault accessed", zap.Int("ContactID", c.ID), zap.Int("UserID", userID))
+
+	// Расшифрованные данные затираются после отправки ответа
+	res := pb.EmergencyVaultResponse{
+		Contact: contactToPB(c, true),
+		Secrets: make([]*pb.GetSecret, 0, len(secrets)),
+	}
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+		pbSecret, err := secretToPB(sec, c.GrantorLogin)
+		if err != nil {
+			es.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
+		}
+		res.Secrets = append(res.Secrets, pbSecret)
+	}
+
+	return &res, nil
+}
+
+// TakeoverAccount смена пароля владельца контактом с доступом takeover. Сессии и второй фактор владельца
+// отключаются вместе со сменой пароля.
+func (es *EmergencyServer) TakeoverAccount(
+	ctx context.Context,
+	in *pb.TakeoverAccountRequest,
+) (*pb.EmergencyContact, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := es.emergencyService.Takeover(ctx, userID, int(in.GetContactId()), user.CredentialsUpdate{
+		NewPassword:     in.GetNewPassword(),
+		KDF:             kdfParamsFromPB(in.GetNewKdfParams()),
+		WrappedVaultKey: in.GetNewWrappedVaultKey(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrNothingToUpdate):
+			return nil, status.Error(codes.InvalidArgument, "new password is required")
+		case errors.Is(err, user.ErrInvalidE2EParams):
+			return nil, status.Error(codes.InvalidArgument, "invalid end-to-end encryption params")
+		case errors.Is(err, user.ErrWeakPassword):
+			return nil, weakPasswordStatus(err)
+		default:
+			return nil, es.emergencyError(err, userID, "failed to take over account")
+		}
+	}
+
+	es.log.Warn("account taken over by emergency contact",
+		zap.Int("ContactID", c.ID), zap.Int("GrantorID", c.GrantorID), zap.Int("UserID", userID))
+
+	return contactToPB(c, true), nil
+}
+
+// ListEmergencyEvents журнал экстренного доступа текущего пользователя как владельца и как контакта.
+func (es *EmergencyServer) ListEmergencyEvents(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.ListEmergencyEventsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := es.emergencyService.Events(ctx, userID)
+	if err != nil {
+		return nil, es.emergencyError(err, userID, "failed to list emergency events")
+	}
+
+	res := pb.ListEmergencyEventsResponse{Events: make([]*pb.EmergencyEvent, 0, len(events))}
+	for _, ev := range events {
+		res.Events = append(res.Events, &pb.EmergencyEvent{
+			ContactId:    int64(ev.ContactID),
+			GrantorLogin: ev.GrantorLogin,
+			GranteeLogin: ev.GranteeLogin,
+			ActorLogin:   ev.ActorLogin,
+			Action:       string(ev.Action),
+			CreatedAt:    timestamppb.New(ev.CreatedAt),
+		})
+	}
+
+	return &res, nil
+}
+
+// emergencyError ошибка сервиса экстренного доступа в ответ клиенту.
+func (es *EmergencyServer) emergencyError(err error, userID int, msg string) error {
+	switch {
+	case errors.Is(err, emergency.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, emergency.ErrSelf), errors.Is(err, emergency.ErrInvalidType),
+		errors.Is(err, emergency.ErrInvalidWait), errors.Is(err, emergency.ErrVaultKeyRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, emergency.ErrAlreadyNominated):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, emergency.ErrNoShareKeys), errors.Is(err, emergency.ErrAlreadyRequested),
+		errors.Is(err, emergency.ErrAlreadyApproved), errors.Is(err, emergency.ErrNotPending):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, emergency.ErrNotApproved), errors.Is(err, emergency.ErrTakeoverNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, emergency.ErrStateChanged):
+		return status.Error(codes.Aborted, "emergency access changed concurrently, retry the request")
+	default:
+		es.log.Error(msg, zap.Error(err), zap.Int("UserID", userID))
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// contactToPB контакт в ответ клиенту. Ключ E2E хранилища отдается только контакту с открытым доступом.
+func contactToPB(c *emergency.Contact, forGrantee bool) *pb.EmergencyContact {
+	res := &pb.EmergencyContact{
+		Id:           int64(c.ID),
+		GrantorLogin: c.GrantorLogin,
+		GranteeLogin: c.GranteeLogin,
+		AccessType:   accessTypeToPB(c.Type),
+		WaitHours:    uint32(c.WaitPeriod / time.Hour), //nolint:gosec // период ожидания ограничен конфигом
+		Status:       contactStatusToPB(c.Status),
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+	}
+	if !c.RequestedAt.IsZero() {
+		res.RequestedAt = timestamppb.New(c.RequestedAt)
+		res.AccessAt = timestamppb.New(c.AccessAt())
+	}
+	if !c.DecidedAt.IsZero() {
+		res.DecidedAt = timestamppb.New(c.DecidedAt)
+	}
+	if forGrantee && c.Status == emergency.StatusApproved {
+		res.WrappedVaultKey = c.WrappedVaultKey
+	}
+
+	return res
+}
+
+func contactsToPB(contacts []*emergency.Contact, forGrantee bool) *pb.ListEmergencyContactsResponse {
+	res := pb.ListEmergencyContactsResponse{Contacts: make([]*pb.EmergencyContact, 0, len(contacts))}
+	for _, c := range contacts {
+		res.Contacts = append(res.Contacts, contactToPB(c, forGrantee))
+	}
+
+	return &res
+}
+
+func accessTypeToPB(t emergency.AccessType) pb.EmergencyAccessType {
+	if t == emergency.AccessTakeover {
+		return pb.EmergencyAccessType_EMERGENCY_ACCESS_TAKEOVER
+	}
+
+	return pb.EmergencyAccessType_EMERGENCY_ACCESS_VIEW
+}
+
+// accessTypeFromPB уровень доступа из запроса, неизвестный отклоняется сервисом как emergency.ErrInvalidType.
+func accessTypeFromPB(t pb.EmergencyAccessType) emergency.AccessType {
+	switch t {
+	case pb.EmergencyAccessType_EMERGENCY_ACCESS_VIEW:
+		return emergency.AccessView
+	case pb.EmergencyAccessType_EMERGENCY_ACCESS_TAKEOVER:
+		return emergency.AccessTakeover
+	default:
+		return emergency.AccessType(t.String())
+	}
+}
+
+func contactStatusToPB(s emergency.Status) pb.EmergencyAccessStatus {
+	switch s {
+	case emergency.StatusPending:
+		return pb.EmergencyAccessStatus_EMERGENCY_STATUS_PENDING
+	case emergency.StatusApproved:
+		return pb.EmergencyAccessStatus_EMERGENCY_STATUS_APPROVED
+	case emergency.StatusRejected:
+		return pb.EmergencyAccessStatus_EMERGENCY_STATUS_REJECTED
+	default:
+		return pb.EmergencyAccessStatus_EMERGENCY_STATUS_NOMINATED
+	}
+}
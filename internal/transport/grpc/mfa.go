@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/mfa"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MFAService интерфейс сервиса второго фактора.
+type MFAService interface {
+	Required(ctx context.Context, userID int) (bool, error)
+	IsEnabled(ctx context.Context, userID int) (bool, error)
+	Enroll(ctx context.Context, userID int, account string) (*mfa.Enrollment, error)
+	Confirm(ctx context.Context, userID int, code string) ([]string, error)
+	Verify(ctx context.Context, userID int, code string) error
+	UseChallenge(ctx context.Context, challengeID string) error
+}
+
+// EnrollTOTP подключение аутентификатора. Доступно по токену доступа и по токену второго шага входа,
+// если политика требует второй фактор, а аутентификатор еще не подключен.
+func (us *UserServer) EnrollTOTP(ctx context.Context, _ *emptypb.Empty) (*pb.EnrollTOTPResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	u, err := us.service.GetUserByID(ctx, userID)
+	if err != nil {
+		us.log.Error("error getting user for totp enrollment", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to enroll totp")
+	}
+
+	enrollment, err := us.mfa.Enroll(ctx, userID, u.Login)
+	if err != nil {
+		if errors.Is(err, mfa.ErrAlreadyEnrolled) {
+			return nil, status.Error(codes.FailedPrecondition, "totp is already enrolled")
+		}
+		us.log.Error("error enrolling totp", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to enroll totp")
+	}
+
+	return &pb.EnrollTOTPResponse{
+		OtpauthUri: enrollment.URI,
+		QrPayload:  enrollment.URI,
+		Secret:     enrollment.Secret,
+	}, nil
+}
+
+// ConfirmTOTP подтверждение аутентификатора первым кодом и выпуск кодов восстановления.
+func (us *UserServer) ConfirmTOTP(ctx context.Context, in *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	codesList, err := us.mfa.Confirm(ctx, userID, in.GetCode())
+	if err != nil {
+		switch {
+		case errors.Is(err, mfa.ErrInvalidCode):
+			return nil, status.Error(codes.InvalidArgument, "invalid code")
+		case errors.Is(err, mfa.ErrNotEnrolled):
+			return nil, status.Error(codes.FailedPrecondition, "call EnrollTOTP first")
+		case errors.Is(err, mfa.ErrAlreadyEnrolled):
+			return nil, status.Error(codes.FailedPrecondition, "totp is already enrolled")
+		default:
+			us.log.Error("error confirming totp", zap.Error(err), zap.Int("UserID", userID))
+			return nil, status.Error(codes.Internal, "failed to confirm totp")
+		}
+	}
+
+	return &pb.ConfirmTOTPResponse{RecoveryCodes: codesList}, nil
+}
+
+// VerifyMFA второй шаг входа по токену, выданному Login.
+func (us *UserServer) VerifyMFA(ctx context.Context, in *pb.VerifyMFARequest) (*pb.LoginUserResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	key := lockout.Key(lockout.KindMFA, strconv.Itoa(userID))
+	if err := us.lockouts.Check(ctx, key); err != nil {
+		if errors.Is(err, lockout.ErrThrottled) {
+			us.log.Warn("mfa throttled", zap.Error(err), zap.Int("UserID", userID))
+			return nil, throttledStatus(err)
+		}
+		us.log.Error("error checking mfa attempts", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to verify code")
+	}
+
+	if err := us.mfa.Verify(ctx, userID, in.GetCode()); err != nil {
+		switch {
+		case errors.Is(err, mfa.ErrInvalidCode):
+			if err = us.lockouts.Fail(ctx, key); err != nil {
+				us.log.Error("error recording failed mfa", zap.Error(err))
+			}
+			return nil, status.Error(codes.Unauthenticated, "invalid code")
+		case errors.Is(err, mfa.ErrNotEnrolled):
+			return nil, status.Error(codes.FailedPrecondition, "totp enrollment required")
+		default:
+			us.log.Error("error verifying mfa", zap.Error(err), zap.Int("UserID", userID))
+			return nil, status.Error(codes.Internal, "failed to verify code")
+		}
+	}
+
+	// Токен второго шага входа одноразовый: повторно предъявить его с новым кодом нельзя
+	challengeID, _ := ctx.Value(contextkeys.ChallengeID).(string)
+	if err := us.mfa.UseChallenge(ctx, challengeID); err != nil {
+		if errors.Is(err, mfa.ErrChallengeUsed) {
+			return nil, status.Error(codes.Unauthenticated, "mfa token already used")
+		}
+		us.log.Error("error using mfa challenge", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to verify code")
+	}
+
+	if err := us.lockouts.Succeed(ctx, key); err != nil {
+		us.log.Error("error resetting mfa attempts", zap.Error(err), zap.Int("UserID", userID))
+	}
+
+	u, err := us.service.GetUserByID(ctx, userID)
+	if err != nil {
+		us.log.Error("error getting user after mfa", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to login")
+	}
+
+	return us.completeLogin(ctx, u)
+}
+
+// mfaChallenge ответ на вход по паролю, когда нужен второй фактор: без сессии и ключа хранилища.
+func (us *UserServer) mfaChallenge(ctx context.Context, u *user.User) (*pb.LoginUserResponse, error) {
+	enabled, err := us.mfa.IsEnabled(ctx, u.ID)
+	if err != nil {
+		us.log.Error("failed to check mfa for login user", zap.Error(err), zap.Int("UserID", u.ID))
+		return nil, status.Error(codes.Internal, "failed to login")
+	}
+
+	token, err := us.tokens.BuildMFAToken(u.ID, us.cfg.Security.MFA.ChallengeTTL)
+	if err != nil {
+		us.log.Error("error building mfa token", zap.Error(err), zap.Int("UserID", u.ID))
+		return nil, status.Error(codes.Internal, "failed to login")
+	}
+
+	pbUser, err := userToPB(u)
+	if err != nil {
+		us.log.Error("error convert userID to int32", zap.Error(err), zap.Int("UserID", u.ID))
+		err = status.Error(codes.Internal, "failed to build response")
+		return nil, fmt.Errorf("failed to write response: %w", err)
+	}
+
+	return &pb.LoginUserResponse{
+		User:                  pbUser,
+		EncryptionMode:        encryptionModeToPB(u.EncryptionMode),
+		MfaRequired:           true,
+		MfaToken:              token,
+		MfaEnrollmentRequired: !enabled,
+	}, nil
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OneTimeService методы одноразовых ссылок.
+type OneTimeService interface {
+	Create(
+		ctx context.Context, ownerID int, content []byte, maxViews int, ttl time.Duration,
+	) (*onetime.Share, []byte, error)
+	CreateSealed(
+		ctx context.Context, ownerID int, ciphertext []byte, maxViews int, ttl time.Duration,
+	) (*onetime.Share, error)
+	Redeem(ctx context.Context, id string, key []byte) ([]byte, int, error)
+}
+
+// SecretReader чтение собственного секрета пользователя с расшифрованными данными.
+type SecretReader interface {
+	GetSecret(ctx context.Context, u *user.User, id int) (*secret.Secret, error)
+}
+
+// OneTimeServer обработчик запросов по одноразовым ссылкам.
+type OneTimeServer struct {
+	pb.UnimplementedOneTimeShareServiceServer
+	shares       OneTimeService
+	secrets      SecretReader
+	userProvider UserProvider
+	log          *zap.Logger
+	cfg          *config.Config
+}
+
+// NewOneTimeServer получение обработчика запросов для одноразовых ссылок.
+func NewOneTimeServer(
+	otS OneTimeService,
+	sR SecretReader,
+	uP UserProvider,
+	l *zap.Logger,
+	cfg *config.Config,
+) *OneTimeServer {
+	return &OneTimeServer{
+		shares:       otS,
+		secrets:      sR,
+		userProvider: uP,
+		log:          l,
+		cfg:          cfg,
+	}
+}
+
+// CreateOneTimeShare создание одноразовой ссылки на собственный секрет, текст или файл.
+func (ots *OneTimeServer) CreateOneTimeShare(
+	ctx context.Context,
+	in *pb.CreateOneTimeShareRequest,
+) (*pb.CreateOneTimeShareResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sh  *onetime.Share
+		key []byte
+	)
+
+	maxViews := int(in.GetMaxViews())
+	ttl := time.Duration(in.GetTtlHours()) * time.Hour
+
+	if sealed := in.GetSealedContent(); sealed != nil {
+		sh, err = ots.shares.CreateSealed(ctx, userID, sealed, maxViews, ttl)
+	} else {
+		var content *pb.OneTimeContent
+		if content, err = ots.oneTimeContent(ctx, userID, in); err != nil {
+			return nil, err
+		}
+
+		var plain []byte
+		if plain, err = proto.Marshal(content); err != nil {
+			ots.log.Error("error marshaling one-time content", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to create one-time share")
+		}
+		sh, key, err = ots.shares.Create(ctx, userID, plain, maxViews, ttl)
+		securemem.Wipe(plain)
+	}
+	if err != nil {
+		return nil, ots.oneTimeError(err, "failed to create one-time share")
+	}
+
+	ots.log.Info("one-time share created",
+		zap.Int("UserID", userID), zap.Int("MaxViews", sh.MaxViews), zap.Time("ExpiresAt", sh.ExpiresAt))
+
+	return &pb.CreateOneTimeShareResponse{
+		Id:        sh.ID,
+		Key:       key,
+		Url:       onetime.PageURL(ots.cfg.Security.OneTimeShare.BaseURL, sh.ID, key),
+		MaxViews:  uint32(sh.MaxViews), //nolint:gosec // число просмотров ограничено конфигом
+		ExpiresAt: timestamppb.New(sh.ExpiresAt),
+	}, nil
+}
+
+// RedeemShare открытие одноразовой ссылки без аутентификации. Через этот же метод ссылку открывает
+// страница просмотра на HTTP сервере.
+func (ots *OneTimeServer) RedeemShare(ctx context.Context, in *pb.RedeemShareRequest) (*pb.RedeemShareResponse, error) {
+	plain, left, err := ots.shares.Redeem(ctx, in.GetId(), in.GetKey())
+	if err != nil {
+		return nil, ots.oneTimeError(err, "failed to redeem one-time share")
+	}
+
+	res, err := redeemResponse(plain, left)
+	if err != nil {
+		ots.log.Error("error reading one-time content", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to redeem one-time share")
+	}
+
+	return res, nil
+}
+
+// redeemResponse ответ с содержимым открытой ссылки. Содержимое, зашифрованное клиентом,
+// тоже должно быть сериализованным OneTimeContent; иначе возвращается ошибка.
+func redeemResponse(plain []byte, left int) (*pb.RedeemShareResponse, error) {
+	defer securemem.Wipe(plain)
+
+	var content pb.OneTimeContent
+	if err := proto.Unmarshal(plain, &content); err != nil {
+		return nil, err
+	}
+
+	return &pb.RedeemShareResponse{
+		Content:   &content,
+		ViewsLeft: uint32(left), //nolint:gosec // число просмотров ограничено конфигом
+	}, nil
+}
+
+// oneTimeContent содержимое ссылки из запроса. Секрет читается с расшифровкой на сервере,
+// поэтому секрет E2E нужно зашифровать на клиенте и передать в sealed_content.
+func (ots *OneTimeServer) oneTimeContent(
+	ctx context.Context,
+	userID int,
+	in *pb.CreateOneTimeShareRequest,
+) (*pb.OneTimeContent, error) {
+	content := pb.OneTimeContent{Name: in.GetName()}
+
+	switch data := in.GetContent().(type) {
+	case *pb.CreateOneTimeShareRequest_Text:
+		content.Data = &pb.OneTimeContent_Text{Text: data.Text}
+	case *pb.CreateOneTimeShareRequest_File:
+		content.Data = &pb.OneTimeContent_BinaryData{BinaryData: data.File}
+	case *pb.CreateOneTimeShareRequest_SecretId:
+		u, err := ots.userProvider.GetUserByID(ctx, userID)
+		if err != nil {
+			ots.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+			return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+		}
+
+		sec, err := ots.secrets.GetSecret(ctx, u, int(data.SecretId))
+		if err != nil {
+			return nil, ots.oneTimeError(err, "failed to get secret")
+		}
+		securemem.WipeAfter(ctx, sec)
+
+		if content.GetName() == "" {
+			content.Name = sec.Name
+		}
+
+		pbSecret, err := secretToPB(sec, "")
+		if err != nil {
+			ots.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
+		}
+
+		switch d := pbSecret.GetData().(type) {
+		case *pb.GetSecret_PasswordData:
+			content.Data = &pb.OneTimeContent_PasswordData{PasswordData: d.PasswordData}
+		case *pb.GetSecret_CardData:
+			content.Data = &pb.OneTimeContent_CardData{CardData: d.CardData}
+		case *pb.GetSecret_BinaryData:
+			content.Data = &pb.OneTimeContent_BinaryData{BinaryData: d.BinaryData}
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "one-time share content is empty")
+	}
+
+	return &content, nil
+}
+
+// oneTimeError ошибка операции с одноразовой ссылкой в ответ клиенту.
+func (ots *OneTimeServer) oneTimeError(err error, msg string) error {
+	switch {
+	case errors.Is(err, onetime.ErrNotFound):
+		return status.Error(codes.NotFound, "share not found or already used")
+	case errors.Is(err, secret.ErrSecretNotFound):
+		return status.Error(codes.NotFound, "secret not found")
+	case errors.Is(err, secret.ErrApprovalRequired):
+		return status.Error(codes.PermissionDenied, "secret requires an approved access request")
+	case errors.Is(err, secret.ErrClientEncrypted):
+		return status.Error(codes.FailedPrecondition,
+			"secret is end-to-end encrypted: encrypt it on the client and send sealed_content")
+	case errors.Is(err, onetime.ErrEmptyContent),
+		errors.Is(err, onetime.ErrTooLarge),
+		errors.Is(err, onetime.ErrInvalidViews),
+		errors.Is(err, onetime.ErrInvalidTTL):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		ots.log.Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, msg)
+	}
+}
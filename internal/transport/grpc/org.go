@@ -0,0 +1,341 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/org"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrgService методы организаций, участников и коллекций.
+type OrgService interface {
+	CreateOrganization(ctx context.Context, ownerID int, name string) (*org.Organization, error)
+	DeleteOrganization(ctx context.Context, actorID, orgID int) error
+	ListOrganizations(ctx context.Context, userID int) ([]*org.Organization, error)
+	ListMembers(ctx context.Context, actorID, orgID int) ([]*org.Member, error)
+	AddMember(ctx context.Context, actorID, orgID, userID int, role org.Role) (*org.Member, error)
+	SetRole(ctx context.Context, actorID, orgID, userID int, role org.Role) error
+	RemoveMember(ctx context.Context, actorID, orgID, userID int) error
+	CreateCollection(ctx context.Context, actorID, orgID int, name string) (*org.Collection, error)
+	ListCollections(ctx context.Context, actorID, orgID int) ([]*org.Collection, error)
+}
+
+// OrgServer обработчик запросов по организациям.
+type OrgServer struct {
+	pb.UnimplementedOrganizationServiceServer
+	orgService   OrgService
+	userProvider UserProvider
+	log          *zap.Logger
+}
+
+// NewOrgServer получение обработчика запросов для организаций.
+func NewOrgServer(oS OrgService, uP UserProvider, l *zap.Logger) *OrgServer {
+	return &OrgServer{
+		orgService:   oS,
+		userProvider: uP,
+		log:          l,
+	}
+}
+
+// CreateOrganization создание организации, текущий пользователь становится владельцем.
+func (ors *OrgServer) CreateOrganization(
+	ctx context.Context,
+	in *pb.CreateOrganizationRequest,
+) (*pb.Organization, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := ors.orgService.CreateOrganization(ctx, userID, in.GetName())
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to create organization")
+	}
+
+	ors.log.Info("organization created", zap.Int("OrgID", o.ID), zap.Int("UserID", userID))
+
+	return organizationToPB(o), nil
+}
+
+// ListOrganizations организации текущего пользователя.
+func (ors *OrgServer) ListOrganizations(ctx context.Context, _ *emptypb.Empty) (*pb.ListOrganizationsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := ors.orgService.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to list organizations")
+	}
+
+	res := pb.ListOrganizationsResponse{Organizations: make([]*pb.Organization, 0, len(orgs))}
+	for _, o := range orgs {
+		res.Organizations = append(res.Organizations, organizationToPB(o))
+	}
+
+	return &res, nil
+}
+
+// DeleteOrganization удаление организации вместе с коллекциями и их секретами.
+func (ors *OrgServer) DeleteOrganization(ctx context.Context, in *pb.OrganizationRequest) (*emptypb.Empty, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ors.orgService.DeleteOrganization(ctx, userID, int(in.GetOrgId())); err != nil {
+		return nil, ors.orgError(err, userID, "failed to delete organization")
+	}
+
+	ors.log.Info("organization deleted", zap.Int64("OrgID", in.GetOrgId()), zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListMembers участники организации.
+func (ors *OrgServer) ListMembers(ctx context.Context, in *pb.OrganizationRequest) (*pb.ListMembersResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := ors.orgService.ListMembers(ctx, userID, int(in.GetOrgId()))
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to list members")
+	}
+
+	res := pb.ListMembersResponse{Members: make([]*pb.OrgMember, 0, len(members))}
+	for _, m := range members {
+		res.Members = append(res.Members, memberToPB(m))
+	}
+
+	return &res, nil
+}
+
+// AddMember добавление пользователя в организацию.
+func (ors *OrgServer) AddMember(ctx context.Context, in *pb.MemberRequest) (*pb.OrgMember, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := ors.memberByLogin(ctx, in.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := ors.orgService.AddMember(ctx, userID, int(in.GetOrgId()), u.ID, roleFromPB(in.GetRole()))
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to add member")
+	}
+	m.Login = u.Login
+
+	ors.log.Info("organization member added",
+		zap.Int64("OrgID", in.GetOrgId()), zap.Int("UserID", userID), zap.Int("MemberID", u.ID),
+		zap.String("Role", string(m.Role)))
+
+	return memberToPB(m), nil
+}
+
+// SetMemberRole смена роли участника.
+func (ors *OrgServer) SetMemberRole(ctx context.Context, in *pb.MemberRequest) (*emptypb.Empty, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := ors.memberByLogin(ctx, in.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	role := roleFromPB(in.GetRole())
+	if err = ors.orgService.SetRole(ctx, userID, int(in.GetOrgId()), u.ID, role); err != nil {
+		return nil, ors.orgError(err, userID, "failed to set member role")
+	}
+
+	ors.log.Info("organization member role changed",
+		zap.Int64("OrgID", in.GetOrgId()), zap.Int("UserID", userID), zap.Int("MemberID", u.ID),
+		zap.String("Role", string(role)))
+
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveMember удаление участника или выход из организации с ротацией ключей коллекций.
+func (ors *OrgServer) RemoveMember(ctx context.Context, in *pb.MemberRequest) (*emptypb.Empty, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := ors.memberByLogin(ctx, in.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ors.orgService.RemoveMember(ctx, userID, int(in.GetOrgId()), u.ID); err != nil {
+		return nil, ors.orgError(err, userID, "failed to remove member")
+	}
+
+	ors.log.Info("organization member removed, collection keys rotated",
+		zap.Int64("OrgID", in.GetOrgId()), zap.Int("UserID", userID), zap.Int("MemberID", u.ID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// CreateCollection создание коллекции в организации.
+func (ors *OrgServer) CreateCollection(ctx context.Context, in *pb.CreateCollectionRequest) (*pb.Collection, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := ors.orgService.CreateCollection(ctx, userID, int(in.GetOrgId()), in.GetName())
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to create collection")
+	}
+
+	ors.log.Info("collection created",
+		zap.Int("CollectionID", c.ID), zap.Int64("OrgID", in.GetOrgId()), zap.Int("UserID", userID))
+
+	return collectionToPB(c), nil
+}
+
+// ListCollections коллекции организации.
+func (ors *OrgServer) ListCollections(
+	ctx context.Context,
+	in *pb.OrganizationRequest,
+) (*pb.ListCollectionsResponse, error) {
+	userID, err := userIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := ors.orgService.ListCollections(ctx, userID, int(in.GetOrgId()))
+	if err != nil {
+		return nil, ors.orgError(err, userID, "failed to list collections")
+	}
+
+	res := pb.ListCollectionsResponse{Collections: make([]*pb.Collection, 0, len(collections))}
+	for _, c := range collections {
+		res.Collections = append(res.Collections, collectionToPB(c))
+	}
+
+	return &res, nil
+}
+
+// memberByLogin пользователь, которого добавляют в организацию или чью роль меняют.
+func (ors *OrgServer) memberByLogin(ctx context.Context, login string) (*user.User, error) {
+	if login == "" {
+		return nil, status.Error(codes.InvalidArgument, "login is required")
+	}
+
+	u, err := ors.userProvider.GetUserByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		ors.log.Error("error getting user by login", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	return u, nil
+}
+
+// orgError ошибка сервиса организаций в ответ клиенту.
+func (ors *OrgServer) orgError(err error, userID int, msg string) error {
+	switch {
+	case errors.Is(err, org.ErrNotFound), errors.Is(err, org.ErrCollectionNotFound), errors.Is(err, org.ErrNotMember):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, org.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, org.ErrInvalidRole), errors.Is(err, org.ErrEmptyName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, org.ErrAlreadyMember), errors.Is(err, org.ErrCollectionExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, org.ErrLastOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, org.ErrConcurrentChange):
+		return status.Error(codes.Aborted, "organization changed concurrently, retry the request")
+	default:
+		ors.log.Error(msg, zap.Error(err), zap.Int("UserID", userID))
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// userIDFromCtx пользователь из токена доступа.
+func userIDFromCtx(ctx context.Context) (int, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	return userID, nil
+}
+
+func organizationToPB(o *org.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:        int64(o.ID),
+		Name:      o.Name,
+		Role:      roleToPB(o.Role),
+		CreatedAt: timestamppb.New(o.CreatedAt),
+	}
+}
+
+func memberToPB(m *org.Member) *pb.OrgMember {
+	return &pb.OrgMember{
+		Login:     m.Login,
+		Role:      roleToPB(m.Role),
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}
+}
+
+func collectionToPB(c *org.Collection) *pb.Collection {
+	return &pb.Collection{
+		Id:         int64(c.ID),
+		OrgId:      int64(c.OrgID),
+		Name:       c.Name,
+		KeyVersion: uint32(c.KeyVersion), //nolint:gosec // версия ключа начинается с 1 и только растет
+		CreatedAt:  timestamppb.New(c.CreatedAt),
+	}
+}
+
+func roleToPB(r org.Role) pb.OrgRole {
+	switch r {
+	case org.RoleOwner:
+		return pb.OrgRole_ORG_ROLE_OWNER
+	case org.RoleAdmin:
+		return pb.OrgRole_ORG_ROLE_ADMIN
+	case org.RoleEditor:
+		return pb.OrgRole_ORG_ROLE_EDITOR
+	case org.RoleViewer:
+		return pb.OrgRole_ORG_ROLE_VIEWER
+	default:
+		return pb.OrgRole_ORG_ROLE_UNSPECIFIED
+	}
+}
+
+// roleFromPB роль из запроса, неизвестная роль отклоняется сервисом как org.ErrInvalidRole.
+func roleFromPB(r pb.OrgRole) org.Role {
+	switch r {
+	case pb.OrgRole_ORG_ROLE_OWNER:
+		return org.RoleOwner
+	case pb.OrgRole_ORG_ROLE_ADMIN:
+		return org.RoleAdmin
+	case pb.OrgRole_ORG_ROLE_EDITOR:
+		return org.RoleEditor
+	case pb.OrgRole_ORG_ROLE_VIEWER:
+		return org.RoleViewer
+	default:
+		return org.Role(r.String())
+	}
+}
@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/passgen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GeneratePassword генерация пароля или парольной фразы с возможностью сразу сохранить ее как секрет.
+func (ss *SecretServer) GeneratePassword(
+	ctx context.Context,
+	in *pb.GeneratePasswordRequest,
+) (*pb.GeneratePasswordResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+	u, err := ss.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		ss.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
+
+	save := in.GetSave()
+	if save != nil && u.EncryptionMode == user.EncryptionModeE2E {
+		return nil, status.Error(codes.FailedPrecondition,
+			"secrets of end-to-end encrypted account must be generated and encrypted on client")
+	}
+
+	var (
+		password string
+		entropy  float64
+	)
+	if rules := in.GetPassphrase(); rules != nil {
+		password, entropy, err = passgen.Passphrase(passgen.PassphraseRules{
+			Words:         int(rules.GetWords()),
+			Separator:     rules.GetSeparator(),
+			Capitalize:    rules.GetCapitalize(),
+			IncludeNumber: rules.GetIncludeNumber(),
+		})
+	} else {
+		rules := in.GetPassword()
+		password, entropy, err = passgen.Password(passgen.Rules{
+			Length:           int(rules.GetLength()),
+			Lowercase:        rules.GetLowercase(),
+			Uppercase:        rules.GetUppercase(),
+			Digits:           rules.GetDigits(),
+			Symbols:          rules.GetSymbols(),
+			ExcludeAmbiguous: rules.GetExcludeAmbiguous(),
+			RequireEachClass: rules.GetRequireEachClass(),
+		})
+	}
+	if err != nil {
+		if errors.Is(err, passgen.ErrInvalidRules) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ss.log.Error("error generating password", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate password")
+	}
+
+	res := pb.GeneratePasswordResponse{
+		Password:    password,
+		EntropyBits: entropy,
+		Strength:    ss.passwordStrength(password, save.GetUsername(), save.GetUrl(), u.Login),
+	}
+
+	if save != nil {
+		s, err := ss.secretService.CreateSecretPassword(
+			ctx, u, save.GetName(), save.GetUsername(), password, save.GetUrl(), save.GetNotes(), nil,
+		)
+		if err != nil {
+			ss.log.Error("error creating generated secret password", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to create new secret password.")
+		}
+		res.SecretId = int64(s.ID)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/secreport"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const day = 24 * time.Hour
+
+// GetSecurityReport отчет о безопасности хранилища по секретам, которые сервер может расшифровать.
+// Слабыми считаются пароли с оценкой ниже порога политики паролей аккаунтов.
+func (ss *SecretServer) GetSecurityReport(
+	ctx context.Context,
+	in *pb.SecurityReportRequest,
+) (*pb.SecurityReport, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+	u, err := ss.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		ss.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
+
+	secrets, err := ss.secretService.GetUserCredentials(ctx, u)
+	if err != nil {
+		ss.log.Error("error loading secrets for security report", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to build security report")
+	}
+
+	var (
+		passwords []secreport.Password
+		cards     []secreport.Card
+		skipped   uint32
+	)
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+
+		if sec.ClientEncrypted {
+			skipped++
+			continue
+		}
+		// Данные секрета без одобренного доступа не раскрываются даже для отчета
+		if sec.ApprovalRequired {
+			continue
+		}
+
+		switch data := sec.Data.(type) {
+		case *secret.PasswordData:
+			password := data.Pass.Reveal()
+			passwords = append(passwords, secreport.Password{
+				Name:        sec.Name,
+				Username:    data.Username,
+				Password:    password,
+				URL:         data.URL,
+				UpdatedAt:   sec.UpdatedAt,
+				BreachCount: ss.breachCount(password),
+			})
+		case *secret.CardData:
+			cards = append(cards, secreport.Card{Name: sec.Name, ExpireDate: data.ExpireDate})
+		}
+	}
+
+	report := secreport.Build(passwords, cards, secreport.Options{
+		MaxAge:            time.Duration(in.GetMaxAgeDays()) * day,
+		CardExpiryWarning: time.Duration(in.GetCardExpiryDays()) * day,
+		MinScore:          ss.cfg.Security.PasswordPolicy.MinScore,
+		UserInputs:        []string{u.Login},
+	})
+
+	res := securityReportToPB(report)
+	res.SkippedClientEncrypted = skipped
+
+	return res, nil
+}
+
+//nolint:gosec // счетчики и оценки неотрицательны и малы
+func securityReportToPB(r *secreport.Report) *pb.SecurityReport {
+	res := pb.SecurityReport{
+		CheckedPasswords: uint32(r.CheckedPasswords),
+		CheckedCards:     uint32(r.CheckedCards),
+	}
+
+	for _, names := range r.Reused {
+		res.Reused = append(res.Reused, &pb.ReusedPassword{Names: names})
+	}
+	for _, w := range r.Weak {
+		res.Weak = append(res.Weak, &pb.WeakPassword{Name: w.Name, Score: uint32(max(w.Score, 0)), Warnings: w.Warnings})
+	}
+	for _, s := range r.Stale {
+		res.Stale = append(res.Stale, &pb.StalePassword{
+			Name:      s.Name,
+			UpdatedAt: timestamppb.New(s.UpdatedAt),
+			AgeDays:   uint32(s.AgeDays),
+		})
+	}
+	for _, b := range r.Breached {
+		res.Breached = append(res.Breached, &pb.BreachedSecret{Name: b.Name, BreachCount: b.Count})
+	}
+	for _, i := range r.Insecure {
+		res.InsecureUrls = append(res.InsecureUrls, &pb.InsecureURL{Name: i.Name, Url: i.URL})
+	}
+	for _, c := range r.Cards {
+		card := pb.CardExpiry{Name: c.Name, ExpireDate: c.ExpireDate, Expired: c.Expired}
+		if !c.Expires.IsZero() {
+			card.Expires = timestamppb.New(c.Expires)
+		}
+		res.Cards = append(res.Cards, &card)
+	}
+
+	return &res
+}
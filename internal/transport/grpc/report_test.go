@@ -0,0 +1,106 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/breach"
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	grpc2 "github.com/Melikhov-p/goph-keeper/internal/transport/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubCredentials реализует SecretService для тестирования: остальные методы не вызываются.
+type stubCredentials struct {
+	grpc2.SecretService
+	secrets []*secret.Secret
+}
+
+func (s *stubCredentials) GetUserCredentials(_ context.Context, _ *user.User) ([]*secret.Secret, error) {
+	return s.secrets, nil
+}
+
+// stubUsers реализует UserProvider для тестирования.
+type stubUsers struct {
+	u *user.User
+}
+
+func (s *stubUsers) GetUserByID(_ context.Context, _ int) (*user.User, error) {
+	return s.u, nil
+}
+
+func (s *stubUsers) GetUserByLogin(_ context.Context, _ string) (*user.User, error) {
+	return s.u, nil
+}
+
+// leakedEverything реализует BreachChecker, считая утекшим любой пароль.
+type leakedEverything struct{}
+
+func (leakedEverything) CheckPassword(_ string) (uint32, error) {
+	return 1, nil
+}
+
+func (leakedEverything) Range(_ string) ([]breach.Match, error) {
+	return nil, nil
+}
+
+// newCredentialsServer сервер с открытым и заблокированным до одобрения секретами-паролями.
+// У заблокированного секрета оставлены данные, чтобы проверить, что обработчик пропускает его сам.
+func newCredentialsServer(t *testing.T) (*grpc2.SecretServer, context.Context) {
+	t.Helper()
+
+	u := &user.User{ID: 1, Login: "john"}
+	open, err := secret.NewSecret("open", secret.TypePassword, u.ID)
+	require.NoError(t, err)
+	open.Data = secret.NewPasswordData("john", "qwerty", "http://open.example", "", nil, nil)
+
+	locked, err := secret.NewSecret("locked", secret.TypePassword, u.ID)
+	require.NoError(t, err)
+	locked.Data = secret.NewPasswordData("root", "qwerty", "http://locked.example", "", nil, nil)
+	locked.ApprovalRequired = true
+
+	ss := grpc2.NewSecretServer(
+		&stubCredentials{secrets: []*secret.Secret{open, locked}},
+		&stubUsers{u: u},
+		leakedEverything{},
+		nil,
+		&config.Config{},
+		zap.NewNop(),
+	)
+
+	return ss, context.WithValue(context.Background(), contextkeys.UserID, u.ID)
+}
+
+func TestSecretServer_SecurityReportSkipsLocked(t *testing.T) {
+	ss, ctx := newCredentialsServer(t)
+
+	res, err := ss.GetSecurityReport(ctx, &pb.SecurityReportRequest{})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, res.GetCheckedPasswords())
+	require.Len(t, res.GetBreached(), 1)
+	assert.Equal(t, "open", res.GetBreached()[0].GetName())
+	for _, w := range res.GetWeak() {
+		assert.NotEqual(t, "locked", w.GetName())
+	}
+	for _, i := range res.GetInsecureUrls() {
+		assert.NotEqual(t, "locked", i.GetName())
+	}
+}
+
+func TestSecretServer_CheckBreachesSkipsLocked(t *testing.T) {
+	ss, ctx := newCredentialsServer(t)
+
+	res, err := ss.CheckBreaches(ctx, &pb.CheckBreachesRequest{CheckStored: true})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, res.GetChecked())
+	require.Len(t, res.GetSecrets(), 1)
+	assert.Equal(t, "open", res.GetSecrets()[0].GetName())
+}
@@ -1,16 +1,23 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 
 	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
 	"github.com/Melikhov-p/goph-keeper/internal/config"
 	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -37,7 +44,8 @@ type SecretService interface {
 		ctx context.Context,
 		u *user.User,
 		secretName, fileName, notes string,
-		content, metaData []byte,
+		content io.Reader,
+		metaData []byte,
 	) (*secret.Secret, error)
 	GetSecretsByName(
 		ctx context.Context,
@@ -45,11 +53,49 @@ type SecretService interface {
 		secretName string,
 	) ([]*secret.Secret, error)
 	GetAllUserSecrets(ctx context.Context, u *user.User) ([]*secret.Secret, error)
+	ExportUserSecrets(ctx context.Context, u *user.User) ([]*secret.Secret, error)
+	GetUserCredentials(ctx context.Context, u *user.User) ([]*secret.Secret, error)
+	ShareSecret(
+		ctx context.Context,
+		owner *user.User,
+		secretID int,
+		recipient *user.User,
+		perm secret.Permission,
+		wrappedKey, payload []byte,
+	) (*secret.Share, error)
+	RevokeShare(ctx context.Context, userID, shareID int) error
+	ListMyShares(ctx context.Context, u *user.User) ([]*secret.Share, error)
+	ListSharedWithMe(ctx context.Context, u *user.User) ([]*secret.Secret, error)
+	CreateCollectionPassword(
+		ctx context.Context,
+		u *user.User,
+		collectionID int,
+		secretName, username, password, url, notes string,
+		metaData []byte,
+	) (*secret.Secret, error)
+	CreateCollectionCard(
+		ctx context.Context,
+		u *user.User,
+		collectionID int,
+		secretName, number, owner, expireDate, cvv, notes string,
+		metaData []byte,
+	) (*secret.Secret, error)
+	CreateCollectionFile(
+		ctx context.Context,
+		u *user.User,
+		collectionID int,
+		secretName, fileName, notes string,
+		content io.Reader,
+		metaData []byte,
+	) (*secret.Secret, error)
+	GetCollectionSecrets(ctx context.Context, u *user.User, collectionID int, name string) ([]*secret.Secret, error)
+	DeleteCollectionSecret(ctx context.Context, u *user.User, collectionID, secretID int) error
 }
 
 // UserProvider интерфейс провайдера пользователей.
 type UserProvider interface {
 	GetUserByID(ctx context.Context, userID int) (*user.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*user.User, error)
 }
 
 // SecretServer обработчик запросов по секретам.
@@ -57,20 +103,27 @@ type SecretServer struct {
 	pb.UnimplementedSecretServiceServer
 	secretService SecretService
 	userProvider  UserProvider
+	breaches      BreachChecker
+	approvals     ApprovalService
 	cfg           *config.Config
 	log           *zap.Logger
 }
 
 // NewSecretServer получение обработчика запросов для секретов.
+// bC проверка паролей по базе утечек, nil если база не подключена.
 func NewSecretServer(
 	sC SecretService,
 	uP UserProvider,
+	bC BreachChecker,
+	aS ApprovalService,
 	c *config.Config,
 	l *zap.Logger,
 ) *SecretServer {
 	return &SecretServer{
 		secretService: sC,
 		userProvider:  uP,
+		breaches:      bC,
+		approvals:     aS,
 		cfg:           c,
 		log:           l,
 	}
@@ -96,6 +149,10 @@ func (ss *SecretServer) CreateSecret(ctx context.Context, in *pb.CreateSecretReq
 		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
 	}
 
+	if in.CollectionId != nil {
+		return ss.createCollectionSecret(ctx, u, in)
+	}
+
 	switch in.GetType() {
 	case secretTypePassword:
 		data := in.GetPasswordData()
@@ -109,6 +166,10 @@ func (ss *SecretServer) CreateSecret(ctx context.Context, in *pb.CreateSecretReq
 		}
 
 		res.Id = int64(s.ID)
+		// Зашифрованный клиентом пароль оценивает сам клиент
+		if !s.ClientEncrypted {
+			res.PasswordStrength = ss.passwordStrength(data.GetPassword(), data.GetUsername(), data.GetUrl(), u.Login)
+		}
 	case secretTypeCard:
 		data := in.GetCardData()
 		s, err = ss.secretService.CreateSecretCard(
@@ -125,8 +186,12 @@ func (ss *SecretServer) CreateSecret(ctx context.Context, in *pb.CreateSecretReq
 		res.Id = int64(s.ID)
 	case secretTypeBinary:
 		data := in.GetBinaryData()
+		if len(data.GetContent()) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "file content is empty")
+		}
 		s, err = ss.secretService.CreateSecretFile(
-			ctx, u, in.GetName(), data.GetFilename(), data.GetNotes(), data.GetContent(), data.GetMetaData(),
+			ctx, u, in.GetName(), data.GetFilename(), data.GetNotes(),
+			bytes.NewReader(data.GetContent()), data.GetMetaData(),
 		)
 		if err != nil {
 			ss.log.Error("error creating secret file", zap.Error(err))
@@ -163,6 +228,10 @@ func (ss *SecretServer) GetSecret(ctx context.Context, in *pb.GetSecretRequest)
 		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
 	}
 
+	if in.CollectionId != nil {
+		return ss.getCollectionSecrets(ctx, u, in)
+	}
+
 	if secretName = in.GetName(); secretName != "" {
 		s, err = ss.secretService.GetSecretsByName(ctx, u, secretName)
 		if err != nil {
@@ -176,83 +245,172 @@ func (ss *SecretServer) GetSecret(ctx context.Context, in *pb.GetSecretRequest)
 			return nil, status.Error(codes.NotFound, "secrets not found")
 		}
 
-		return getAllUserSecrets(s)
+		return getAllUserSecrets(s, u.Login)
 	}
 
-	ss.log.Debug("found secrets", zap.Any("secrets", s))
+	ss.log.Debug("found secrets", zap.Int("count", len(s)))
 
 	if len(s) == 0 {
 		ss.log.Debug("len secrets is 0")
 		return nil, status.Error(codes.NotFound, "secrets not found")
 	}
 
+	// Расшифрованные данные затираются после отправки ответа
+	for _, sec := range s {
+		securemem.WipeAfter(ctx, sec)
+	}
+
 	for _, sec := range s {
-		foundResSecret := pb.GetSecret{}
-		foundResSecret.Name = sec.Name
-		switch sec.Type {
-		case secret.TypePassword:
-			data, _ := sec.Data.(*secret.PasswordData)
-			foundResSecret.Type = secretTypePassword
-			foundResSecret.Data = &pb.GetSecret_PasswordData{
-				PasswordData: &pb.PasswordData{
-					Username: data.Username,
-					Password: data.Pass,
-					Url:      data.URL,
-					Notes:    &data.Notes,
-					MetaData: data.MetaData,
-				},
-			}
-		case secret.TypeCard:
-			data, _ := sec.Data.(*secret.CardData)
-			foundResSecret.Type = secretTypeCard
-			foundResSecret.Data = &pb.GetSecret_CardData{
-				CardData: &pb.CardData{
-					Owner:      data.Owner,
-					CVV:        data.CVV,
-					ExpireDate: data.ExpireDate,
-					Number:     data.Number,
-					MetaData:   data.MetaData,
-					Notes:      &data.Notes,
-				},
-			}
-		case secret.TypeBinary:
-			data, _ := sec.Data.(*secret.FileData)
-			foundResSecret.Type = secretTypeBinary
-			foundResSecret.Data = &pb.GetSecret_BinaryData{
-				BinaryData: &pb.BinaryData{
-					Filename: data.Name,
-					Content:  data.Content,
-					MetaData: data.MetaData,
-					Notes:    &data.Notes,
-				},
-			}
+		pbSecret, err := secretToPB(sec, u.Login)
+		if err != nil {
+			ss.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
 		}
+		res.Secrets = append(res.Secrets, ss.markBreached(pbSecret))
+	}
+
+	return &res, nil
+}
+
+// ExportSecrets выгрузка всех секретов пользователя с данными, например, перед удалением аккаунта.
+func (ss *SecretServer) ExportSecrets(ctx context.Context, _ *emptypb.Empty) (*pb.GetSecretResponse, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+	u, err := ss.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		ss.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
 
-		res.Secrets = append(res.Secrets, &foundResSecret)
+	secrets, err := ss.secretService.ExportUserSecrets(ctx, u)
+	if err != nil {
+		ss.log.Error("error exporting secrets", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to export secrets")
+	}
+
+	// Расшифрованные данные затираются после отправки ответа
+	res := pb.GetSecretResponse{Secrets: make([]*pb.GetSecret, 0, len(secrets))}
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+		pbSecret, err := secretToPB(sec, u.Login)
+		if err != nil {
+			ss.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
+		}
+		res.Secrets = append(res.Secrets, ss.markBreached(pbSecret))
 	}
 
 	return &res, nil
 }
 
-func getAllUserSecrets(secrets []*secret.Secret) (*pb.GetSecretResponse, error) {
+// secretToPB секрет с расшифрованными данными в ответ клиенту. login владелец собственных секретов,
+// для чужих секретов владелец берется из доступа.
+// Ответ передается одним сообщением, поэтому содержимое файла загружается в память.
+func secretToPB(sec *secret.Secret, login string) (*pb.GetSecret, error) {
+	res := secretHeaderToPB(sec, login)
+
+	// Данные чужого секрета E2E получатель читает из доступа, данные без одобренного доступа скрыты
+	if sec.Data == nil {
+		return res, nil
+	}
+
+	switch sec.Type {
+	case secret.TypePassword:
+		data, _ := sec.Data.(*secret.PasswordData)
+		notes := data.Notes.Reveal()
+		res.Data = &pb.GetSecret_PasswordData{
+			PasswordData: &pb.PasswordData{
+				Username: data.Username,
+				Password: data.Pass.Reveal(),
+				Url:      data.URL,
+				Notes:    &notes,
+				MetaData: data.MetaData,
+			},
+		}
+	case secret.TypeCard:
+		data, _ := sec.Data.(*secret.CardData)
+		notes := data.Notes.Reveal()
+		res.Data = &pb.GetSecret_CardData{
+			CardData: &pb.CardData{
+				Owner:      data.Owner.Reveal(),
+				CVV:        data.CVV.Reveal(),
+				ExpireDate: data.ExpireDate,
+				Number:     data.Number.Reveal(),
+				MetaData:   data.MetaData,
+				Notes:      &notes,
+			},
+		}
+	case secret.TypeBinary:
+		data, _ := sec.Data.(*secret.FileData)
+		// Загруженное содержимое затирается вместе с секретом после отправки ответа
+		if err := data.LoadContent(); err != nil {
+			return nil, fmt.Errorf("failed to load file content %w", err)
+		}
+		notes := data.Notes.Reveal()
+		res.Data = &pb.GetSecret_BinaryData{
+			BinaryData: &pb.BinaryData{
+				Filename: data.Name,
+				Content:  data.Content,
+				MetaData: data.MetaData,
+				Notes:    &notes,
+			},
+		}
+	}
+
+	return res, nil
+}
+
+// secretHeaderToPB секрет без данных: тип, владелец и доступ, если секрет чужой.
+func secretHeaderToPB(sec *secret.Secret, login string) *pb.GetSecret {
+	res := pb.GetSecret{
+		Id:               int64(sec.ID),
+		Name:             sec.Name,
+		Type:             secretTypeToPB(sec.Type),
+		ClientEncrypted:  sec.ClientEncrypted,
+		UpdatedAt:        timestamppb.New(sec.UpdatedAt),
+		OwnerLogin:       login,
+		CollectionId:     int64(sec.CollectionID),
+		ApprovalRequired: sec.ApprovalRequired,
+	}
+
+	if sec.Access != nil {
+		res.OwnerLogin = sec.Access.OwnerLogin
+		res.Share = shareToPB(sec.Access)
+		res.Share.RecipientLogin = login
+	}
+
+	return &res
+}
+
+// passwordStrength оценка стойкости пароля, который сервер видит в открытом виде, с проверкой по базе утечек.
+func (ss *SecretServer) passwordStrength(password string, userInputs ...string) *pb.PasswordStrength {
+	s := passpolicy.Estimate(password, userInputs...)
+	res := pb.PasswordStrength{
+		Score:       uint32(max(s.Score, 0)), //nolint:gosec // отрицательные значения отсечены
+		Warnings:    s.Warnings,
+		BreachCount: ss.breachCount(password),
+	}
+	if res.GetBreachCount() > 0 {
+		res.Score = 0
+		res.Warnings = append(res.GetWarnings(), warningBreached)
+	}
+
+	return &res
+}
+
+func getAllUserSecrets(secrets []*secret.Secret, login string) (*pb.GetSecretResponse, error) {
 	var res pb.GetSecretResponse
 
 	for _, s := range secrets {
-		resSecret := pb.GetSecret{
-			Name: s.Name,
-		}
 		switch s.Type {
-		case secret.TypePassword:
-			resSecret.Type = secretTypePassword
-		case secret.TypeCard:
-			resSecret.Type = secretTypeCard
-		case secret.TypeBinary:
-			resSecret.Type = secretTypeBinary
+		case secret.TypePassword, secret.TypeCard, secret.TypeBinary:
 		default:
 			return nil, status.Error(codes.Internal, "failed to get all secrets by user")
 		}
 
-		res.Secrets = append(res.GetSecrets(), &resSecret)
+		res.Secrets = append(res.GetSecrets(), secretHeaderToPB(s, login))
 	}
 
 	return &res, nil
@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/session"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SessionService интерфейс сервиса сессий.
+type SessionService interface {
+	Create(ctx context.Context, userID int, meta session.Meta) (*session.Session, string, error)
+	Refresh(ctx context.Context, token string) (*session.Session, string, error)
+	List(ctx context.Context, userID int) ([]*session.Session, error)
+	Revoke(ctx context.Context, userID int, id string) error
+	RevokeOthers(ctx context.Context, userID int, keepID string) (int, error)
+}
+
+// TokenIssuer выпуск токенов доступа сессии и второго шага входа.
+type TokenIssuer interface {
+	BuildSessionToken(userID int, sessionID string, tokenLifeTime time.Duration) (string, error)
+	BuildMFAToken(userID int, tokenLifeTime time.Duration) (string, error)
+}
+
+// RefreshToken обмен токена обновления на новую пару токенов.
+func (us *UserServer) RefreshToken(
+	ctx context.Context,
+	in *pb.RefreshTokenRequest,
+) (*pb.RefreshTokenResponse, error) {
+	sess, refreshToken, err := us.sessions.Refresh(ctx, in.GetRefreshToken())
+	if err != nil {
+		if errors.Is(err, session.ErrTokenReuse) {
+			us.log.Warn("refresh token reuse, session revoked", zap.Error(err))
+		}
+		switch {
+		case errors.Is(err, session.ErrInvalidToken) || errors.Is(err, session.ErrTokenReuse):
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		case errors.Is(err, user.ErrDisabled):
+			return nil, status.Error(codes.PermissionDenied, "account is disabled")
+		case errors.Is(err, user.ErrNotFound):
+			return nil, status.Error(codes.Unauthenticated, "user not found")
+		}
+		us.log.Error("error refreshing token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to refresh token")
+	}
+
+	accessToken, err := us.tokens.BuildSessionToken(sess.UserID, sess.ID, us.cfg.Security.TokenTTL)
+	if err != nil {
+		us.log.Error("error building access token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to refresh token")
+	}
+
+	return &pb.RefreshTokenResponse{
+		AccessToken:     accessToken,
+		RefreshToken:    refreshToken,
+		AccessExpiresAt: timestamppb.New(sess.LastUsedAt.Add(us.cfg.Security.TokenTTL)),
+	}, nil
+}
+
+// Logout завершение текущей сессии.
+func (us *UserServer) Logout(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	userID, sessionID, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.sessions.Revoke(ctx, userID, sessionID); err != nil && !errors.Is(err, session.ErrNotFound) {
+		us.log.Error("error revoking session on logout", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to logout")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListSessions активные сессии пользователя.
+func (us *UserServer) ListSessions(ctx context.Context, _ *emptypb.Empty) (*pb.ListSessionsResponse, error) {
+	userID, sessionID, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := us.sessions.List(ctx, userID)
+	if err != nil {
+		us.log.Error("error listing sessions", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to list sessions")
+	}
+
+	res := pb.ListSessionsResponse{Sessions: make([]*pb.Session, 0, len(sessions))}
+	for _, s := range sessions {
+		res.Sessions = append(res.Sessions, &pb.Session{
+			Id:         s.ID,
+			UserAgent:  s.UserAgent,
+			Ip:         s.IP,
+			CreatedAt:  timestamppb.New(s.CreatedAt),
+			LastUsedAt: timestamppb.New(s.LastUsedAt),
+			ExpiresAt:  timestamppb.New(s.ExpiresAt),
+			Current:    s.ID == sessionID,
+		})
+	}
+
+	return &res, nil
+}
+
+// RevokeSession отзыв сессии пользователя, например, на потерянном устройстве.
+func (us *UserServer) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	userID, _, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.sessions.Revoke(ctx, userID, in.GetSessionId()); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "session not found")
+		}
+		us.log.Error("error revoking session", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to revoke session")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// startSession открытие сессии после входа: токен доступа отправляется в заголовке authorization,
+// токен обновления возвращается для ответа.
+func (us *UserServer) startSession(ctx context.Context, userID int) (string, error) {
+	op := "transport.gRPC.user.startSession"
+
+	sess, refreshToken, err := us.sessions.Create(ctx, userID, sessionMeta(ctx))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	accessToken, err := us.tokens.BuildSessionToken(userID, sess.ID, us.cfg.Security.TokenTTL)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = grpc.SendHeader(ctx, metadata.New(map[string]string{
+		"authorization": accessToken,
+	}))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refreshToken, nil
+}
+
+// sessionMeta сведения об устройстве клиента из метаданных и адреса соединения.
+func sessionMeta(ctx context.Context) session.Meta {
+	var meta session.Meta
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			meta.UserAgent = ua[0]
+		}
+	}
+	meta.IP = clientIP(ctx)
+
+	return meta
+}
+
+// clientIP адрес клиента без порта.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func sessionFromCtx(ctx context.Context) (int, string, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return 0, "", status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	sessionID, ok := ctx.Value(contextkeys.SessionID).(string)
+	if !ok {
+		return 0, "", status.Error(codes.Unauthenticated, "session not found in auth token.")
+	}
+
+	return userID, sessionID, nil
+}
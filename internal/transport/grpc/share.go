@@ -0,0 +1,236 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	contextkeys "github.com/Melikhov-p/goph-keeper/internal/context_keys"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/secret"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/securemem"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ShareSecret открыть свой секрет другому пользователю на чтение или на чтение и запись.
+func (ss *SecretServer) ShareSecret(ctx context.Context, in *pb.ShareSecretRequest) (*pb.ShareSecretResponse, error) {
+	owner, err := ss.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.GetRecipientLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipient login is required")
+	}
+	recipient, err := ss.userProvider.GetUserByLogin(ctx, in.GetRecipientLogin())
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "recipient not found")
+		}
+		ss.log.Error("error getting recipient by login", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to share secret")
+	}
+
+	sh, err := ss.secretService.ShareSecret(
+		ctx, owner, int(in.GetSecretId()), recipient,
+		permissionFromPB(in.GetPermission()), in.GetWrappedKey(), in.GetPayload(),
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, secret.ErrSecretNotFound):
+			return nil, status.Error(codes.NotFound, "secret not found")
+		case errors.Is(err, secret.ErrShareToSelf),
+			errors.Is(err, secret.ErrInvalidPermission),
+			errors.Is(err, secret.ErrShareKeyRequired):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, secret.ErrRecipientHasNoKeys):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			ss.log.Error("error sharing secret", zap.Error(err), zap.Int("UserID", owner.ID))
+			return nil, status.Error(codes.Internal, "failed to share secret")
+		}
+	}
+
+	ss.log.Info("secret shared",
+		zap.Int("SecretID", sh.SecretID), zap.Int("UserID", owner.ID), zap.Int("RecipientID", recipient.ID),
+		zap.String("Permission", string(sh.Permission)))
+
+	return &pb.ShareSecretResponse{ShareId: int64(sh.ID)}, nil
+}
+
+// RevokeShare отзыв доступа владельцем секрета или отказ от доступа получателем.
+func (ss *SecretServer) RevokeShare(ctx context.Context, in *pb.RevokeShareRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	if err := ss.secretService.RevokeShare(ctx, userID, int(in.GetShareId())); err != nil {
+		if errors.Is(err, secret.ErrShareNotFound) {
+			return nil, status.Error(codes.NotFound, "share not found")
+		}
+		ss.log.Error("error revoking share", zap.Error(err), zap.Int("UserID", userID))
+		return nil, status.Error(codes.Internal, "failed to revoke share")
+	}
+
+	ss.log.Info("share revoked", zap.Int64("ShareID", in.GetShareId()), zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListMyShares доступы, выданные пользователем к его секретам.
+func (ss *SecretServer) ListMyShares(ctx context.Context, _ *emptypb.Empty) (*pb.ListSharesResponse, error) {
+	u, err := ss.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := ss.secretService.ListMyShares(ctx, u)
+	if err != nil {
+		ss.log.Error("error listing shares", zap.Error(err), zap.Int("UserID", u.ID))
+		return nil, status.Error(codes.Internal, "failed to list shares")
+	}
+
+	res := pb.ListSharesResponse{Shares: make([]*pb.SecretShare, 0, len(shares))}
+	for _, sh := range shares {
+		share := shareToPB(sh)
+		share.OwnerLogin = u.Login
+		res.Shares = append(res.Shares, share)
+	}
+
+	return &res, nil
+}
+
+// ListSharedWithMe секреты других пользователей, открытые пользователю, вместе с данными.
+func (ss *SecretServer) ListSharedWithMe(ctx context.Context, _ *emptypb.Empty) (*pb.GetSecretResponse, error) {
+	u, err := ss.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := ss.secretService.ListSharedWithMe(ctx, u)
+	if err != nil {
+		ss.log.Error("error listing shared secrets", zap.Error(err), zap.Int("UserID", u.ID))
+		return nil, status.Error(codes.Internal, "failed to list shared secrets")
+	}
+
+	// Расшифрованные данные затираются после отправки ответа
+	res := pb.GetSecretResponse{Secrets: make([]*pb.GetSecret, 0, len(secrets))}
+	for _, sec := range secrets {
+		securemem.WipeAfter(ctx, sec)
+		pbSecret, err := secretToPB(sec, u.Login)
+		if err != nil {
+			ss.log.Error("error reading secret file", zap.Error(err), zap.Int("SecretID", sec.ID))
+			return nil, status.Error(codes.Internal, "failed to read secret file")
+		}
+		res.Secrets = append(res.Secrets, ss.markBreached(pbSecret))
+	}
+
+	return &res, nil
+}
+
+// currentUser пользователь из токена доступа.
+func (ss *SecretServer) currentUser(ctx context.Context) (*user.User, error) {
+	userID, ok := ctx.Value(contextkeys.UserID).(int)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in auth token.")
+	}
+
+	u, err := ss.userProvider.GetUserByID(ctx, userID)
+	if err != nil {
+		ss.log.Error("error getting user by id", zap.Int("ID", userID), zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "user with provided ID not found")
+	}
+
+	return u, nil
+}
+
+// SetShareKeys сохранение пары ключей E2E аккаунта, созданной клиентом.
+func (us *UserServer) SetShareKeys(ctx context.Context, in *pb.SetShareKeysRequest) (*emptypb.Empty, error) {
+	userID, _, err := sessionFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.service.SetShareKeys(ctx, userID, in.GetPublicKey(), in.GetWrappedPrivateKey()); err != nil {
+		switch {
+		case errors.Is(err, user.ErrInvalidE2EParams):
+			return nil, status.Error(codes.InvalidArgument, "public key and wrapped private key are required")
+		case errors.Is(err, user.ErrNotE2E):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, user.ErrAlreadyExist), errors.Is(err, user.ErrNoRowsUpdated):
+			return nil, status.Error(codes.AlreadyExists, "share keys already set")
+		default:
+			us.log.Error("error saving share keys", zap.Error(err), zap.Int("UserID", userID))
+			return nil, status.Error(codes.Internal, "failed to save share keys")
+		}
+	}
+
+	us.log.Info("share keys set", zap.Int("UserID", userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetPublicKey открытый ключ пользователя, для которого владелец шифрует ключ доступа к секрету E2E.
+func (us *UserServer) GetPublicKey(ctx context.Context, in *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	if _, _, err := sessionFromCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	u, err := us.service.GetUserByLogin(ctx, in.GetLogin())
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		us.log.Error("error getting user by login", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get public key")
+	}
+
+	return &pb.GetPublicKeyResponse{
+		EncryptionMode: encryptionModeToPB(u.EncryptionMode),
+		PublicKey:      u.PublicKey,
+	}, nil
+}
+
+// shareToPB доступ к секрету в ответ клиенту. Ключ доступа и данные заполнены только для секретов E2E.
+func shareToPB(sh *secret.Share) *pb.SecretShare {
+	return &pb.SecretShare{
+		Id:             int64(sh.ID),
+		SecretId:       int64(sh.SecretID),
+		SecretName:     sh.SecretName,
+		Type:           secretTypeToPB(sh.SecretType),
+		OwnerLogin:     sh.OwnerLogin,
+		RecipientLogin: sh.RecipientLogin,
+		Permission:     permissionToPB(sh.Permission),
+		CreatedAt:      timestamppb.New(sh.CreatedAt),
+		WrappedKey:     sh.WrappedKey,
+		Payload:        sh.Payload,
+	}
+}
+
+func secretTypeToPB(t secret.TypeOfSecret) pb.SecretType {
+	switch t {
+	case secret.TypeCard:
+		return pb.SecretType_SECRET_TYPE_CARD
+	case secret.TypeBinary:
+		return pb.SecretType_SECRET_TYPE_BINARY
+	default:
+		return pb.SecretType_SECRET_TYPE_PASSWORD
+	}
+}
+
+func permissionToPB(secret.Permission) pb.SharePermission {
+	return pb.SharePermission_SHARE_PERMISSION_READ
+}
+
+func permissionFromPB(p pb.SharePermission) secret.Permission {
+	if p == pb.SharePermission_SHARE_PERMISSION_READ {
+		return secret.PermissionRead
+	}
+
+	return secret.Permission(p.String())
+}
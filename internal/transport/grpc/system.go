@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/seal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// operatorTokenHeader заголовок с токеном оператора.
+const operatorTokenHeader = "x-operator-token"
+
+// SealBarrier интерфейс барьера запечатанного сервера.
+type SealBarrier interface {
+	Status() seal.Status
+	Unseal(ctx context.Context, share []byte) (seal.Status, error)
+	ResetProgress()
+	Seal() error
+}
+
+// SystemServer gRPC обработчик административных методов сервера.
+type SystemServer struct {
+	pb.UnimplementedSystemServiceServer
+	barrier SealBarrier
+	log     *zap.Logger
+	cfg     *config.Config
+}
+
+// NewSystemServer новый gRPC обработчик административных методов.
+func NewSystemServer(b SealBarrier, log *zap.Logger, cfg *config.Config) *SystemServer {
+	return &SystemServer{
+		barrier: b,
+		log:     log,
+		cfg:     cfg,
+	}
+}
+
+// Unseal прием доли ключа распечатывания.
+func (ss *SystemServer) Unseal(ctx context.Context, in *pb.UnsealRequest) (*pb.SealStatusResponse, error) {
+	if in.GetResetProgress() {
+		ss.barrier.ResetProgress()
+		ss.log.Info("unseal progress reset")
+		return sealStatusToPB(ss.barrier.Status()), nil
+	}
+
+	st, err := ss.barrier.Unseal(ctx, in.GetShare())
+	if err != nil {
+		ss.log.Warn("unseal attempt failed", zap.Error(err))
+		if errors.Is(err, seal.ErrEmptyShare) {
+			return nil, status.Error(codes.InvalidArgument, "share is empty")
+		}
+		if errors.Is(err, seal.ErrInvalidShares) {
+			return nil, status.Error(codes.InvalidArgument, "invalid unseal shares, progress reset")
+		}
+		return nil, status.Error(codes.Internal, "failed to unseal")
+	}
+
+	if !st.Sealed {
+		ss.log.Info("server unsealed")
+	}
+
+	return sealStatusToPB(st), nil
+}
+
+// Seal запечатывание сервера, доступно только оператору.
+func (ss *SystemServer) Seal(ctx context.Context, _ *emptypb.Empty) (*pb.SealStatusResponse, error) {
+	if err := ss.checkOperator(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := ss.barrier.Seal(); err != nil && !errors.Is(err, seal.ErrSealed) {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to seal: %v", err))
+	}
+
+	ss.log.Info("server sealed by operator")
+
+	return sealStatusToPB(ss.barrier.Status()), nil
+}
+
+// SealStatus состояние сервера.
+func (ss *SystemServer) SealStatus(_ context.Context, _ *emptypb.Empty) (*pb.SealStatusResponse, error) {
+	return sealStatusToPB(ss.barrier.Status()), nil
+}
+
+// checkOperator проверка токена оператора из метаданных.
+func (ss *SystemServer) checkOperator(ctx context.Context) error {
+	return checkOperatorToken(ctx, ss.cfg)
+}
+
+// checkOperatorToken проверка токена оператора для административных методов.
+func checkOperatorToken(ctx context.Context, cfg *config.Config) error {
+	if cfg.Security.OperatorToken == "" {
+		return status.Error(codes.PermissionDenied, "operator methods are disabled")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(operatorTokenHeader)
+	if len(tokens) == 0 ||
+		subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(cfg.Security.OperatorToken)) != 1 {
+		return status.Error(codes.PermissionDenied, "invalid operator token")
+	}
+
+	return nil
+}
+
+func sealStatusToPB(st seal.Status) *pb.SealStatusResponse {
+	return &pb.SealStatusResponse{
+		Sealed:    st.Sealed,
+		Shares:    uint32(max(st.Shares, 0)),
+		Threshold: uint32(max(st.Threshold, 0)),
+		Progress:  uint32(max(st.Progress, 0)),
+	}
+}
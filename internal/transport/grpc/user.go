@@ -5,66 +5,137 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
-	"github.com/Melikhov-p/goph-keeper/internal/auth"
 	"github.com/Melikhov-p/goph-keeper/internal/config"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/lockout"
 	"github.com/Melikhov-p/goph-keeper/internal/domain/user"
+	"github.com/Melikhov-p/goph-keeper/internal/encryptor"
+	"github.com/Melikhov-p/goph-keeper/internal/passpolicy"
 	"github.com/Melikhov-p/goph-keeper/internal/util"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // UserService интерфейс сервиса пользователя.
 type UserService interface {
-	Register(ctx context.Context, login, password, pepper string) (*user.User, error)
-	Login(ctx context.Context, login, password, pepper string) (*user.User, error)
-	Update(ctx context.Context, u *user.User) error
+	Register(ctx context.Context, login, password, inviteCode string) (*user.User, error)
+	RegisterE2E(
+		ctx context.Context,
+		login, authKey, inviteCode string,
+		kdf *encryptor.KDFParams,
+		wrappedVaultKey []byte,
+	) (*user.User, error)
+	GetKDFParams(ctx context.Context, login string) (user.EncryptionMode, *encryptor.KDFParams, error)
+	Login(ctx context.Context, login, password string) (*user.User, error)
+	UpdateCredentials(ctx context.Context, userID int, upd user.CredentialsUpdate) (*user.User, error)
+	GetUserByID(ctx context.Context, userID int) (*user.User, error)
+	ScheduleDeletion(
+		ctx context.Context,
+		userID int,
+		password string,
+		grace time.Duration,
+	) (*user.User, error)
+	CancelDeletion(ctx context.Context, userID int) error
+	GetUserByLogin(ctx context.Context, login string) (*user.User, error)
+	SetShareKeys(ctx context.Context, userID int, publicKey, wrappedPrivateKey []byte) error
 }
 
 // UserServer gRPC обработчик запросов для методов пользователя.
 type UserServer struct {
 	pb.UnimplementedUserServiceServer
-	service UserService
-	log     *zap.Logger
-	cfg     *config.Config
+	service  UserService
+	sessions SessionService
+	mfa      MFAService
+	lockouts LockoutService
+	tokens   TokenIssuer
+	log      *zap.Logger
+	cfg      *config.Config
 }
 
 // NewUserServer новый gRPC обработчик для пользователя.
-func NewUserServer(us UserService, log *zap.Logger, cfg *config.Config) *UserServer {
+func NewUserServer(
+	us UserService,
+	ss SessionService,
+	ms MFAService,
+	ls LockoutService,
+	tokens TokenIssuer,
+	log *zap.Logger,
+	cfg *config.Config,
+) *UserServer {
 	return &UserServer{
-		service: us,
-		log:     log,
-		cfg:     cfg,
+		service:  us,
+		sessions: ss,
+		mfa:      ms,
+		lockouts: ls,
+		tokens:   tokens,
+		log:      log,
+		cfg:      cfg,
 	}
 }
 
 // Register регистрация пользователя.
 func (us *UserServer) Register(ctx context.Context, in *pb.RegisterUserRequest) (*pb.RegisterUserResponse, error) {
 	var (
-		u   *user.User
-		res pb.RegisterUserResponse
-		err error
+		u          *user.User
+		res        pb.RegisterUserResponse
+		inviteCode = in.GetInviteCode()
+		err        error
 	)
 
-	u, err = us.service.Register(ctx, in.GetLogin(), in.GetPassword(), us.cfg.Security.Pepper)
+	switch user.RegistrationMode(us.cfg.Security.Registration.Mode) {
+	case user.RegistrationDisabled:
+		return nil, status.Error(codes.PermissionDenied, "registration is disabled")
+	case user.RegistrationInvite:
+		if inviteCode == "" {
+			return nil, status.Error(codes.PermissionDenied, "invite code required")
+		}
+	default:
+		// В открытом режиме приглашение не нужно и не погашается
+		inviteCode = ""
+	}
+
+	if in.GetEncryptionMode() == pb.EncryptionMode_ENCRYPTION_MODE_E2E {
+		u, err = us.service.RegisterE2E(
+			ctx,
+			in.GetLogin(), in.GetPassword(), inviteCode,
+			kdfParamsFromPB(in.GetKdfParams()), in.GetWrappedVaultKey(),
+		)
+	} else {
+		u, err = us.service.Register(ctx, in.GetLogin(), in.GetPassword(), inviteCode)
+	}
 	if err != nil {
 		us.log.Error("error register new user", zap.Error(err), zap.String("Login", in.GetLogin()))
 		if errors.Is(err, user.ErrAlreadyExist) {
 			err = status.Error(codes.AlreadyExists, "user already exist")
 			return nil, fmt.Errorf("failed to register new user: %w", err)
 		}
+		if errors.Is(err, user.ErrInvalidE2EParams) {
+			err = status.Error(codes.InvalidArgument, "invalid end-to-end encryption params")
+			return nil, fmt.Errorf("failed to register new user: %w", err)
+		}
+		if errors.Is(err, user.ErrInvalidInvite) {
+			err = status.Error(codes.PermissionDenied, "invalid or expired invite code")
+			return nil, fmt.Errorf("failed to register new user: %w", err)
+		}
+		if errors.Is(err, user.ErrWeakPassword) {
+			return nil, fmt.Errorf("failed to register new user: %w", weakPasswordStatus(err))
+		}
 		err = status.Error(codes.Internal, "failed to register")
 		return nil, fmt.Errorf("failed to register new user: %w", err)
 	}
 
-	err = addTokenToCtx(ctx, u.ID, us.cfg.Security.TokenKey, us.cfg.Security.TokenTTL)
-	if err != nil {
-		us.log.Error("error adding token to context for new user", zap.Error(err), zap.Int("UserID", u.ID))
+	// При обязательном втором факторе сессия открывается только после входа с ним
+	if !us.cfg.Security.MFA.Required {
+		res.RefreshToken, err = us.startSession(ctx, u.ID)
+		if err != nil {
+			us.log.Error("error starting session for new user", zap.Error(err), zap.Int("UserID", u.ID))
+		}
 	}
 
 	userID32, err := util.SafeConvertToInt32(u.ID)
@@ -86,65 +157,179 @@ func (us *UserServer) Register(ctx context.Context, in *pb.RegisterUserRequest)
 func (us *UserServer) Login(ctx context.Context, in *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
 	var (
 		u   *user.User
-		res pb.LoginUserResponse
 		err error
 	)
 
-	u, err = us.service.Login(ctx, in.GetLogin(), in.GetPassword(), us.cfg.Security.Pepper)
+	// Неудачи считаются и по логину, и по адресу: перебор паролей к одному аккаунту
+	// и перебор аккаунтов с одного адреса
+	keys := []string{
+		lockout.Key(lockout.KindLogin, in.GetLogin()),
+		lockout.Key(lockout.KindIP, clientIP(ctx)),
+	}
+	if err = us.lockouts.Check(ctx, keys...); err != nil {
+		if errors.Is(err, lockout.ErrThrottled) {
+			us.log.Warn("login throttled", zap.Error(err), zap.String("Login", in.GetLogin()))
+			return nil, throttledStatus(err)
+		}
+		us.log.Error("error checking login attempts", zap.Error(err))
+		err = status.Error(codes.Internal, "failed to login")
+		return nil, fmt.Errorf("failed to login user: %w", err)
+	}
+
+	u, err = us.service.Login(ctx, in.GetLogin(), in.GetPassword())
+	if errors.Is(err, user.ErrRehashFailed) {
+		// Пароль верный, старый хэш пересчитается при следующем входе
+		us.log.Error("error rehashing user password", zap.Error(err), zap.Int("UserID", u.ID))
+		err = nil
+	}
 	if err != nil {
-		us.log.Error("error login user", zap.Error(err), zap.String("Login", in.GetLogin()))
-		switch {
-		case errors.Is(err, user.ErrInvalidCredentials):
-			err = status.Error(codes.InvalidArgument, "invalid credentials")
+		// Неизвестный логин и неверный пароль неотличимы для клиента
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			us.log.Warn("invalid credentials", zap.String("Login", in.GetLogin()))
+			if err = us.lockouts.Fail(ctx, keys...); err != nil {
+				us.log.Error("error recording failed login", zap.Error(err))
+			}
+			err = status.Error(codes.Unauthenticated, "invalid login or password")
 			return nil, fmt.Errorf("failed to login user: %w", err)
-		case errors.Is(err, user.ErrNotFound):
-			err = status.Error(codes.NotFound, "user not found")
-			return nil, fmt.Errorf("failed to login user: %w", err)
-		default:
-			err = status.Error(codes.Internal, "failed to login")
+		}
+		if errors.Is(err, user.ErrDisabled) {
+			us.log.Warn("login of disabled user", zap.String("Login", in.GetLogin()))
+			err = status.Error(codes.PermissionDenied, "account is disabled")
 			return nil, fmt.Errorf("failed to login user: %w", err)
 		}
+		us.log.Error("error login user", zap.Error(err), zap.String("Login", in.GetLogin()))
+		err = status.Error(codes.Internal, "failed to login")
+		return nil, fmt.Errorf("failed to login user: %w", err)
+	}
+
+	if err = us.lockouts.Succeed(ctx, keys[0]); err != nil {
+		us.log.Error("error resetting login attempts", zap.Error(err), zap.Int("UserID", u.ID))
 	}
 
-	err = addTokenToCtx(ctx, u.ID, us.cfg.Security.TokenKey, us.cfg.Security.TokenTTL)
+	mfaRequired, err := us.mfa.Required(ctx, u.ID)
 	if err != nil {
-		us.log.Error("failed to add token for login user to context", zap.Error(err))
+		us.log.Error("failed to check mfa for login user", zap.Error(err), zap.Int("UserID", u.ID))
+		err = status.Error(codes.Internal, "failed to login")
+		return nil, fmt.Errorf("failed to login user: %w", err)
+	}
+	if mfaRequired {
+		return us.mfaChallenge(ctx, u)
 	}
 
-	userID32, err := util.SafeConvertToInt32(u.ID)
+	return us.completeLogin(ctx, u)
+}
+
+// completeLogin открытие сессии и ответ на успешный вход после проверки всех факторов.
+func (us *UserServer) completeLogin(ctx context.Context, u *user.User) (*pb.LoginUserResponse, error) {
+	var (
+		res pb.LoginUserResponse
+		err error
+	)
+
+	res.RefreshToken, err = us.startSession(ctx, u.ID)
+	if err != nil {
+		us.log.Error("failed to start session for login user", zap.Error(err), zap.Int("UserID", u.ID))
+		err = status.Error(codes.Internal, "failed to login")
+		return nil, fmt.Errorf("failed to login user: %w", err)
+	}
+
+	res.User, err = userToPB(u)
 	if err != nil {
 		us.log.Error("error convert userID to int32", zap.Error(err), zap.Int("UserID", u.ID))
 		err = status.Error(codes.Internal, "failed to build response")
 		return nil, fmt.Errorf("failed to write response: %w", err)
 	}
+	res.EncryptionMode = encryptionModeToPB(u.EncryptionMode)
+	res.WrappedVaultKey = u.WrappedVaultKey
+	res.PublicKey = u.PublicKey
+	res.WrappedPrivateKey = u.WrappedPrivateKey
+	if u.DeletionScheduled() {
+		res.DeleteAfter = timestamppb.New(u.DeleteAfter)
+	}
 
-	res.User = &pb.User{
+	return &res, nil
+}
+
+func userToPB(u *user.User) (*pb.User, error) {
+	userID32, err := util.SafeConvertToInt32(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error convert userID to int32 %w", err)
+	}
+
+	return &pb.User{
 		Login: u.Login,
 		Id:    userID32,
+	}, nil
+}
+
+// GetKDFParams получение режима шифрования и параметров KDF для входа в E2E аккаунт.
+func (us *UserServer) GetKDFParams(
+	ctx context.Context,
+	in *pb.GetKDFParamsRequest,
+) (*pb.GetKDFParamsResponse, error) {
+	mode, kdf, err := us.service.GetKDFParams(ctx, in.GetLogin())
+	if err != nil {
+		us.log.Error("error getting kdf params", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get kdf params")
 	}
 
-	return &res, nil
+	return &pb.GetKDFParamsResponse{
+		EncryptionMode: encryptionModeToPB(mode),
+		KdfParams:      kdfParamsToPB(kdf),
+	}, nil
 }
 
-func addTokenToCtx(ctx context.Context, userID int, tokenSecret string, tokenTTL time.Duration) error {
-	op := "transport.gRPC.user.addTokenToCtx"
+// GetPasswordPolicy политика паролей аккаунтов для проверки мастер-пароля E2E аккаунта на клиенте.
+func (us *UserServer) GetPasswordPolicy(context.Context, *emptypb.Empty) (*pb.PasswordPolicy, error) {
+	p := us.cfg.Security.PasswordPolicy
 
-	var (
-		token string
-		err   error
-	)
+	return &pb.PasswordPolicy{
+		MinLength:  uint32(max(p.MinLength, 0)),  //nolint:gosec // отрицательные значения отсечены
+		MinClasses: uint32(max(p.MinClasses, 0)), //nolint:gosec // отрицательные значения отсечены
+		MinScore:   uint32(max(p.MinScore, 0)),   //nolint:gosec // отрицательные значения отсечены
+	}, nil
+}
 
-	token, err = auth.BuildJWTToken(userID, tokenSecret, tokenTTL)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+// weakPasswordStatus ответ на пароль, не прошедший политику, со списком нарушений для пользователя.
+func weakPasswordStatus(err error) error {
+	var violation *passpolicy.ViolationError
+	if errors.As(err, &violation) {
+		return status.Error(codes.InvalidArgument, violation.Error())
 	}
 
-	err = grpc.SendHeader(ctx, metadata.New(map[string]string{
-		"authorization": token,
-	}))
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	return status.Error(codes.InvalidArgument, "weak password")
+}
+
+func encryptionModeToPB(mode user.EncryptionMode) pb.EncryptionMode {
+	if mode == user.EncryptionModeE2E {
+		return pb.EncryptionMode_ENCRYPTION_MODE_E2E
+	}
+
+	return pb.EncryptionMode_ENCRYPTION_MODE_SERVER
+}
+
+func kdfParamsFromPB(in *pb.KDFParams) *encryptor.KDFParams {
+	if in == nil {
+		return nil
+	}
+
+	return &encryptor.KDFParams{
+		Salt:    in.GetSalt(),
+		Time:    in.GetTime(),
+		Memory:  in.GetMemory(),
+		Threads: uint8(min(in.GetThreads(), math.MaxUint8)),
+	}
+}
+
+func kdfParamsToPB(kdf *encryptor.KDFParams) *pb.KDFParams {
+	if kdf == nil {
+		return nil
 	}
 
-	return nil
+	return &pb.KDFParams{
+		Salt:    kdf.Salt,
+		Time:    kdf.Time,
+		Memory:  kdf.Memory,
+		Threads: uint32(kdf.Threads),
+	}
 }
@@ -0,0 +1,47 @@
+// Package http пакет обработчиков служебных HTTP эндпоинтов.
+package http
+
+import (
+	"net/http"
+
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+
+	"go.uber.org/zap"
+)
+
+// JWKSPath путь публикации ключей проверки токенов.
+const JWKSPath = "/.well-known/jwks.json"
+
+// jwksMaxAge время кэширования JWKS клиентами в секундах. Новый ключ должен появиться в JWKS
+// раньше, чем им начнут подписываться токены, хотя бы на это время.
+const jwksMaxAge = "300"
+
+// JWKSSource источник документа JSON Web Key Set.
+type JWKSSource interface {
+	JWKS() ([]byte, error)
+}
+
+// NewRouter обработчик служебных HTTP эндпоинтов и страницы одноразовых ссылок.
+func NewRouter(keys JWKSSource, shares ShareRedeemer, log *zap.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+JWKSPath, jwksHandler(keys, log))
+	mux.HandleFunc("GET "+onetime.PagePath+"{id}", sharePageHandler)
+	mux.HandleFunc("POST "+onetime.PagePath+"{id}", redeemHandler(shares, log))
+
+	return mux
+}
+
+func jwksHandler(keys JWKSSource, log *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		doc, err := keys.JWKS()
+		if err != nil {
+			log.Error("error building JWKS", zap.Error(err))
+			http.Error(w, "failed to build JWKS", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/jwk-set+json")
+		w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+		_, _ = w.Write(doc)
+	}
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	pb "github.com/Melikhov-p/goph-keeper/internal/api/gen"
+	"github.com/Melikhov-p/goph-keeper/internal/domain/onetime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// redeemBodyLimit наибольший размер тела запроса открытия ссылки: в нем только ключ.
+const redeemBodyLimit = 1024
+
+// ShareRedeemer открытие одноразовой ссылки.
+type ShareRedeemer interface {
+	RedeemShare(ctx context.Context, in *pb.RedeemShareRequest) (*pb.RedeemShareResponse, error)
+}
+
+// sharePageCSP политика страницы просмотра: разрешен только встроенный скрипт страницы и запросы к серверу.
+var sharePageCSP = func() string {
+	sum := sha256.Sum256([]byte(sharePageScript))
+
+	return "default-src 'none'; script-src 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'; " +
+		"connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+}()
+
+// sharePageHandler страница просмотра одноразовой ссылки. Страница не открывает ссылку сама: сервисы
+// предпросмотра ссылок в мессенджерах иначе тратили бы просмотры. Ключ из фрагмента URL скрипт отправляет
+// в теле запроса только после нажатия кнопки.
+func sharePageHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", sharePageCSP)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, _ = w.Write([]byte(sharePageHTML))
+}
+
+// redeemHandler открытие ссылки: тело {"key": "<ключ из фрагмента URL>"}, в ответе RedeemShareResponse в JSON.
+func redeemHandler(shares ShareRedeemer, log *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+
+		var body struct {
+			Key string `json:"key"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, redeemBodyLimit)).Decode(&body); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		key, err := onetime.DecodeKey(body.Key)
+		if err != nil {
+			http.Error(w, "share not found or already used", http.StatusNotFound)
+			return
+		}
+
+		res, err := shares.RedeemShare(r.Context(), &pb.RedeemShareRequest{Id: r.PathValue("id"), Key: key})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.NotFound:
+				http.Error(w, "share not found or already used", http.StatusNotFound)
+			default:
+				http.Error(w, "failed to open share", http.StatusInternalServerError)
+			}
+			return
+		}
+
+		doc, err := protojson.Marshal(res)
+		if err != nil {
+			log.Error("error marshaling one-time share", zap.Error(err))
+			http.Error(w, "failed to open share", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	}
+}
+
+const sharePageScript = `
+(function () {
+  var key = location.hash.slice(1);
+  var info = document.getElementById("info");
+  var button = document.getElementById("reveal");
+  var result = document.getElementById("result");
+
+  if (!key) {
+    info.textContent = "The link is incomplete: the key after # is missing.";
+    button.hidden = true;
+    return;
+  }
+
+  function row(label, value) {
+    if (!value) {
+      return;
+    }
+    var p = document.createElement("p");
+    var b = document.createElement("b");
+    var code = document.createElement("code");
+    b.textContent = label + ": ";
+    code.textContent = value;
+    p.appendChild(b);
+    p.appendChild(code);
+    result.appendChild(p);
+  }
+
+  button.addEventListener("click", function () {
+    button.disabled = true;
+    fetch(location.pathname, {
+      method: "POST",
+      headers: {"Content-Type": "application/json"},
+      body: JSON.stringify({key: key})
+    }).then(function (resp) {
+      if (!resp.ok) {
+        throw new Error(resp.status === 404
+          ? "This link does not exist, has expired or has already been used."
+          : "Failed to open the link, try again later.");
+      }
+      return resp.json();
+    }).then(function (res) {
+      var c = res.content || {};
+      button.hidden = true;
+      history.replaceState(null, "", location.pathname);
+
+      row("Name", c.name);
+      row("Text", c.text);
+      if (c.passwordData) {
+        row("Username", c.passwordData.username);
+        row("Password", c.passwordData.password);
+        row("URL", c.passwordData.url);
+        row("Notes", c.passwordData.notes);
+      }
+      if (c.cardData) {
+        row("Owner", c.cardData.Owner);
+        row("Number", c.cardData.Number);
+        row("CVV", c.cardData.CVV);
+        row("Expire date", c.cardData.ExpireDate);
+        row("Notes", c.cardData.notes);
+      }
+      if (c.binaryData) {
+        var bytes = Uint8Array.from(atob(c.binaryData.content || ""), function (ch) {
+          return ch.charCodeAt(0);
+        });
+        var a = document.createElement("a");
+        a.href = URL.createObjectURL(new Blob([bytes]));
+        a.download = c.binaryData.filename || "secret";
+        a.textContent = "Download " + a.download;
+        result.appendChild(a);
+        row("Notes", c.binaryData.notes);
+      }
+
+      info.textContent = res.viewsLeft
+        ? "Views left: " + res.viewsLeft
+        : "The link has been destroyed, save the secret now.";
+    }).catch(function (err) {
+      info.textContent = err.message;
+      button.hidden = true;
+    });
+  });
+})();
+`
+
+const sharePageHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>GophKeeper one-time secret</title>
+</head>
+<body>
+<h1>One-time secret</h1>
+<p id="info">Someone shared a secret with you. The link works a limited number of times,
+opening it uses up one view.</p>
+<button id="reveal">Reveal secret</button>
+<div id="result"></div>
+<script>` + sharePageScript + `</script>
+</body>
+</html>
+`